@@ -2,6 +2,8 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
 	"log"
 	"os"
 	"time"
@@ -9,9 +11,17 @@ import (
 	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq"
 	
+	"oilgas-backend/internal/attachment"
 	"oilgas-backend/internal/auth"
 	"oilgas-backend/internal/customer"
+	"oilgas-backend/internal/inventory"
+	"oilgas-backend/internal/invoice"
+	"oilgas-backend/internal/label"
+	"oilgas-backend/internal/reference"
 	"oilgas-backend/internal/shared/database"
+	"oilgas-backend/internal/numbering"
+	"oilgas-backend/internal/shared/events"
+	"oilgas-backend/internal/workorder"
 )
 
 func main() {
@@ -26,6 +36,16 @@ func main() {
 		MaxLifetime:  time.Hour,
 	}
 	
+	// The other yards' databases are only needed for inter-yard transfers
+	for tenantID, keys := range map[string][2]string{
+		"bakersfield": {"BAKERSFIELD_DB_URL", "DEV_BAKERSFIELD_DB_URL"},
+		"colorado":    {"COLORADO_DB_URL", "DEV_COLORADO_DB_URL"},
+	} {
+		if url := getDBURL(keys[0], keys[1]); url != "" {
+			dbConfig.TenantDBs[tenantID] = url
+		}
+	}
+	
 	// Initialize database manager
 	dbManager, err := database.NewDatabaseManager(dbConfig)
 	if err != nil {
@@ -36,15 +56,98 @@ func main() {
 	// Initialize services
 	authRepo := auth.NewRepository(dbManager.GetCentralDB())
 	authSvc := auth.NewService(dbManager, authRepo)
+	authMW := auth.NewMiddleware(authSvc)
 	customerRepo := customer.NewRepository(dbManager)
 	customerCache := customer.NewInMemoryCache(time.Hour)
 	customerSvc := customer.NewService(customerRepo, authSvc, customerCache)
 	customerHandlers := customer.NewHandlers(customerSvc)
 	
+	// Events are audited in the database of the tenant they belong to
+	eventBus := events.NewEventBus(events.NewTenantEventStore(dbManager))
+	
+	documentNumbers := numbering.NewAllocator(numbering.NewTenantSettingsSource(dbManager.GetCentralDB()))
+
+	workOrderRepo := workorder.NewRepository(dbManager, documentNumbers)
+	approvalRepo := workorder.NewApprovalRepository(dbManager)
+	approvalSvc := workorder.NewApprovalService(workOrderRepo, approvalRepo, eventBus)
+	approvalHandlers := workorder.NewApprovalHandlers(approvalSvc)
+	laborRepo := workorder.NewLaborRepository(dbManager)
+	laborSvc := workorder.NewLaborService(workOrderRepo, laborRepo)
+	laborHandlers := workorder.NewLaborHandlers(laborSvc)
+	scheduleRepo := workorder.NewScheduleRepository(dbManager)
+	scheduleSvc := workorder.NewScheduleService(workOrderRepo, scheduleRepo)
+	scheduleHandlers := workorder.NewScheduleHandlers(scheduleSvc)
+	slaRepo := workorder.NewSLARepository(dbManager)
+	slaSvc := workorder.NewSLAService(slaRepo, eventBus, time.Now)
+	slaHandlers := workorder.NewSLAHandlers(slaSvc)
+	workOrderSvc := workorder.NewService(workOrderRepo, eventBus)
+	workOrderHandlers := workorder.NewHandlers(workOrderSvc)
+	portalHandlers := workorder.NewPortalHandlers(workOrderSvc, approvalSvc)
+	templateRepo := workorder.NewTemplateRepository(dbManager)
+	templateSvc := workorder.NewTemplateService(workOrderRepo, templateRepo, workOrderSvc)
+	templateHandlers := workorder.NewTemplateHandlers(templateSvc)
+	varianceSvc := workorder.NewVarianceService(workorder.NewVarianceRepository(dbManager))
+	varianceHandlers := workorder.NewVarianceHandlers(varianceSvc)
+	inspectionSvc := workorder.NewInspectionService(workorder.NewInspectionRepository(dbManager))
+	inspectionHandlers := workorder.NewInspectionHandlers(inspectionSvc)
+	
+	referenceSvc := reference.NewService(reference.NewRepository(dbManager))
+	referenceHandlers := reference.NewHandlers(referenceSvc)
+	
+	inventorySvc := inventory.NewService(inventory.NewRepository(dbManager))
+	inventoryHandlers := inventory.NewHandlers(inventorySvc)
+	reservationSvc := inventory.NewReservationService(inventory.NewReservationRepository(dbManager))
+	reservationHandlers := inventory.NewReservationHandlers(reservationSvc)
+	shipmentSvc := inventory.NewShipmentService(inventory.NewShipmentRepository(dbManager, documentNumbers), eventBus)
+	shipmentHandlers := inventory.NewShipmentHandlers(shipmentSvc)
+	receivingSvc := inventory.NewReceivingService(inventory.NewReceivingRepository(dbManager), referenceSvc)
+	receivingHandlers := inventory.NewReceivingHandlers(receivingSvc)
+	transferSvc := inventory.NewTransferService(inventory.NewTransferRepository(dbManager, documentNumbers))
+	transferHandlers := inventory.NewTransferHandlers(transferSvc)
+	countSvc := inventory.NewCountService(inventory.NewCountRepository(dbManager))
+	countHandlers := inventory.NewCountHandlers(countSvc)
+	weightRepo := inventory.NewWeightRepository(dbManager)
+	weightSvc := inventory.NewWeightService(weightRepo)
+	weightHandlers := inventory.NewWeightHandlers(weightSvc)
+	storageSvc := inventory.NewStorageService(inventory.NewStorageRepository(dbManager), weightRepo, time.Now)
+	storageHandlers := inventory.NewStorageHandlers(storageSvc)
+	snapshotSvc := inventory.NewSnapshotService(inventory.NewSnapshotRepository(dbManager), weightRepo, time.Now)
+	snapshotHandlers := inventory.NewSnapshotHandlers(snapshotSvc)
+	
+	labelHandlers := label.NewHandlers(label.NewService(inventorySvc, referenceSvc, shipmentSvc))
+	
+	invoiceRepo := invoice.NewRepository(dbManager, documentNumbers)
+	invoiceSvc := invoice.NewService(invoiceRepo, workOrderSvc, customerSvc, eventBus)
+	invoiceHandlers := invoice.NewHandlers(invoiceSvc)
+	
+	attachmentStore, err := attachment.NewLocalStore(getEnv("ATTACHMENT_DIR", "./data/attachments"))
+	if err != nil {
+		log.Fatal("Failed to initialize attachment storage:", err)
+	}
+	attachmentSigner := attachment.NewURLSigner(attachmentSigningKey(), getEnv("PUBLIC_API_URL", "http://localhost:8080")+"/files")
+	attachmentRepo := attachment.NewRepository(dbManager)
+	attachmentLimits := attachment.NewTenantSettingsSource(dbManager.GetCentralDB())
+	attachmentSvc := attachment.NewService(attachmentRepo, attachmentStore, attachmentLimits, attachmentSigner)
+	attachmentHandlers := attachment.NewHandlers(attachmentSvc)
+	
+	// Escalate work orders that are about to miss, or have missed, their SLAs
+	workorder.NewSLAWorker(slaSvc, []string{"longbeach"}, 5*time.Minute).Start(context.Background())
+	
+	// Deliver inter-yard transfer messages for every connected yard
+	inventory.NewTransferRelay(transferSvc, dbManager.TenantIDs(), time.Minute).Start(context.Background())
+	
+	// Snapshot Long Beach's closing inventory, filling in any nights missed.
+	// The other yards are connected for transfers only and have no snapshot
+	// tables.
+	inventory.NewSnapshotWorker(snapshotSvc, []string{"longbeach"}, time.Hour).Start(context.Background())
+	
 	// Setup router
 	router := gin.New()
 	router.Use(gin.Logger(), gin.Recovery())
 	
+	// Signed attachment downloads carry their own authorization
+	attachmentHandlers.RegisterDownloadRoutes(router.Group("/files"))
+	
 	// Apply middleware
 	api := router.Group("/api/v1")
 	api.Use(tenantMiddleware("longbeach")) // Set Long Beach as default tenant
@@ -52,6 +155,28 @@ func main() {
 	
 	// Register routes
 	customerHandlers.RegisterRoutes(api, authMiddleware(authSvc))
+	workOrderHandlers.RegisterRoutes(api, authMW)
+	approvalHandlers.RegisterRoutes(api, authMW)
+	portalHandlers.RegisterRoutes(api, authMW)
+	laborHandlers.RegisterRoutes(api, authMW)
+	scheduleHandlers.RegisterRoutes(api, authMW)
+	slaHandlers.RegisterRoutes(api, authMW)
+	templateHandlers.RegisterRoutes(api, authMW)
+	varianceHandlers.RegisterRoutes(api, authMW)
+	inspectionHandlers.RegisterRoutes(api, authMW)
+	referenceHandlers.RegisterRoutes(api, authMW)
+	inventoryHandlers.RegisterRoutes(api, authMW)
+	reservationHandlers.RegisterRoutes(api, authMW)
+	shipmentHandlers.RegisterRoutes(api, authMW)
+	receivingHandlers.RegisterRoutes(api, authMW)
+	transferHandlers.RegisterRoutes(api, authMW)
+	countHandlers.RegisterRoutes(api, authMW)
+	weightHandlers.RegisterRoutes(api, authMW)
+	storageHandlers.RegisterRoutes(api, authMW)
+	snapshotHandlers.RegisterRoutes(api, authMW)
+	labelHandlers.RegisterRoutes(api, authMW)
+	invoiceHandlers.RegisterRoutes(api, authMW)
+	attachmentHandlers.RegisterRoutes(api, authMW)
 	
 	log.Println("Long Beach location service starting on :8080")
 	log.Fatal(router.Run(":8080"))
@@ -106,3 +231,24 @@ func getDBURL(prodKey, devKey string) string {
 	}
 	return os.Getenv(prodKey)
 }
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// attachmentSigningKey signs download links. Without ATTACHMENT_SIGNING_KEY a
+// random key is used, so links stop working when the service restarts.
+func attachmentSigningKey() []byte {
+	if key := os.Getenv("ATTACHMENT_SIGNING_KEY"); key != "" {
+		return []byte(key)
+	}
+	log.Println("ATTACHMENT_SIGNING_KEY not set; download links will not survive a restart")
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		log.Fatal("Failed to generate attachment signing key:", err)
+	}
+	return key
+}
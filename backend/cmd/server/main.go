@@ -12,10 +12,15 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
+	_ "github.com/lib/pq"
 
 	"oilgas-backend/internal/auth"
 	"oilgas-backend/internal/handlers"
 	"oilgas-backend/internal/middleware"
+	"oilgas-backend/internal/numbering"
+	"oilgas-backend/internal/shared/database"
+	"oilgas-backend/internal/shared/events"
+	"oilgas-backend/internal/workorder"
 	// "oilgas-backend/pkg/cache"
 )
 
@@ -50,6 +55,16 @@ func main() {
 	// Setup router
 	router := setupRouter(authHandler, customerHandler, inventoryHandler, sessionManager)
 
+	// Work orders run on the tenant database manager and session middleware
+	// shared with the location services rather than the pool above
+	workOrderDB, err := setupWorkOrderRoutes(router)
+	if err != nil {
+		log.Fatalf("Failed to initialize work order routes: %v", err)
+	}
+	if workOrderDB != nil {
+		defer workOrderDB.Close()
+	}
+
 	// Start server
 	port := os.Getenv("API_PORT")
 	if port == "" {
@@ -97,6 +112,62 @@ func initializeDatabase() (*pgxpool.Pool, error) {
 	return pool, nil
 }
 
+func setupWorkOrderRoutes(router *gin.Engine) (*database.DatabaseManager, error) {
+	centralDBURL := os.Getenv("CENTRAL_AUTH_DB_URL")
+	if centralDBURL == "" {
+		log.Printf("Warning: CENTRAL_AUTH_DB_URL not set, work order routes disabled")
+		return nil, nil
+	}
+
+	tenantDBs := map[string]string{}
+	for tenantID, key := range map[string]string{
+		"longbeach":   "LONGBEACH_DB_URL",
+		"bakersfield": "BAKERSFIELD_DB_URL",
+		"colorado":    "COLORADO_DB_URL",
+	} {
+		if url := os.Getenv(key); url != "" {
+			tenantDBs[tenantID] = url
+		}
+	}
+
+	dbManager, err := database.NewDatabaseManager(&database.Config{
+		CentralDBURL: centralDBURL,
+		TenantDBs:    tenantDBs,
+		MaxOpenConns: 25,
+		MaxIdleConns: 5,
+		MaxLifetime:  time.Hour,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize database manager: %w", err)
+	}
+
+	defaultTenant := os.Getenv("DEFAULT_TENANT")
+	if defaultTenant == "" {
+		defaultTenant = "longbeach"
+	}
+	eventDB, err := dbManager.GetTenantDB(defaultTenant)
+	if err != nil {
+		dbManager.Close()
+		return nil, fmt.Errorf("failed to get event database: %w", err)
+	}
+
+	authSvc := auth.NewService(dbManager, auth.NewRepository(dbManager.GetCentralDB()))
+	authMW := auth.NewMiddleware(authSvc)
+	eventBus := events.NewEventBus(events.NewDatabaseEventStore(eventDB))
+	documentNumbers := numbering.NewAllocator(numbering.NewTenantSettingsSource(dbManager.GetCentralDB()))
+
+	workOrderSvc := workorder.NewService(workorder.NewRepository(dbManager, documentNumbers), eventBus)
+	workOrderHandlers := workorder.NewHandlers(workOrderSvc)
+
+	// The tenant comes from the X-Tenant-ID header and is checked against the
+	// signed-in user's tenant access
+	api := router.Group("/api/v1")
+	api.Use(authMW.RequireAuth(), authMW.RequireTenantAccess())
+	workOrderHandlers.RegisterRoutes(api, authMW)
+
+	return dbManager, nil
+}
+
 func setupRouter(authHandler *handlers.AuthHandler, customerHandler *handlers.CustomerHandler, 
 	inventoryHandler *handlers.InventoryHandler, sessionManager *auth.SessionManager) *gin.Engine {
 	
@@ -0,0 +1,125 @@
+// cmd/tools/migrate-received/main.go
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	_ "github.com/lib/pq"
+
+	"oilgas-backend/internal/shared/database"
+	"oilgas-backend/internal/workorder"
+)
+
+func main() {
+	var (
+		tenant      = flag.String("tenant", "longbeach", "Tenant ID")
+		userID      = flag.Int("user", 0, "ID of the user recorded as creating the imported work orders")
+		serviceType = flag.String("service-type", string(workorder.ServiceInspection), "Service type for imported work orders")
+		dryRun      = flag.Bool("dry-run", true, "Report what would be imported without writing to database")
+		reportFile  = flag.String("report", "", "Optional CSV file to write unresolved rows to")
+	)
+	flag.Parse()
+
+	if *userID <= 0 {
+		log.Fatal("Please provide the importing user ID with --user")
+	}
+
+	// Database setup
+	dbConfig := &database.Config{
+		CentralDBURL: os.Getenv("CENTRAL_AUTH_DB_URL"),
+		TenantDBs: map[string]string{
+			*tenant: getTenantDBURL(*tenant),
+		},
+		MaxOpenConns: 10,
+		MaxIdleConns: 2,
+		MaxLifetime:  time.Hour,
+	}
+
+	dbManager, err := database.NewDatabaseManager(dbConfig)
+	if err != nil {
+		log.Fatal("Failed to connect to databases:", err)
+	}
+	defer dbManager.Close()
+
+	if *dryRun {
+		fmt.Println("🔍 DRY RUN - No data will be written to database")
+	}
+
+	importer := workorder.NewLegacyImporter(workorder.NewLegacyRepository(dbManager))
+	start := time.Now()
+	report, err := importer.Import(context.Background(), *tenant, *userID, workorder.ServiceType(*serviceType), *dryRun)
+	if report != nil {
+		printReport(report, time.Since(start))
+		if *reportFile != "" {
+			if err := writeUnresolved(*reportFile, report.Unresolved); err != nil {
+				log.Printf("Failed to write report: %v", err)
+			} else {
+				fmt.Printf("\nUnresolved rows written to %s\n", *reportFile)
+			}
+		}
+	}
+	if err != nil {
+		log.Fatal("Import failed:", err)
+	}
+}
+
+func getTenantDBURL(tenant string) string {
+	switch tenant {
+	case "longbeach":
+		return os.Getenv("LONGBEACH_DB_URL")
+	case "bakersfield":
+		return os.Getenv("BAKERSFIELD_DB_URL")
+	case "colorado":
+		return os.Getenv("COLORADO_DB_URL")
+	default:
+		log.Fatalf("Unknown tenant: %s", tenant)
+		return ""
+	}
+}
+
+func printReport(report *workorder.LegacyImportReport, duration time.Duration) {
+	fmt.Printf("\n📊 Import Results:\n")
+	fmt.Printf("  Received rows: %d\n", report.Rows)
+	fmt.Printf("  Legacy work orders: %d\n", report.Jobs)
+	if report.DryRun {
+		fmt.Printf("  Would import: %d (%d items)\n", report.Imported, report.Items)
+	} else {
+		fmt.Printf("  Imported: %d (%d items, %d linked to inventory)\n", report.Imported, report.Items, report.LinkedInventory)
+		fmt.Printf("  Already imported: %d\n", report.AlreadyImported)
+	}
+	for status, count := range report.StatusCounts {
+		fmt.Printf("    %s: %d\n", status, count)
+	}
+	fmt.Printf("  Unresolved rows: %d\n", len(report.Unresolved))
+	fmt.Printf("  Duration: %v\n", duration)
+
+	if len(report.Unresolved) > 0 {
+		fmt.Printf("\n⚠️  Unresolved:\n")
+		for _, u := range report.Unresolved {
+			fmt.Printf("  received %d [%s]: %s\n", u.ReceivedID, u.WorkOrder, u.Reason)
+		}
+	}
+}
+
+func writeUnresolved(path string, unresolved []workorder.LegacyUnresolved) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	w.Write([]string{"received_id", "work_order", "reason"})
+	for _, u := range unresolved {
+		w.Write([]string{strconv.Itoa(u.ReceivedID), u.WorkOrder, u.Reason})
+	}
+	w.Flush()
+	return w.Error()
+}
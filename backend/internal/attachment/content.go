@@ -0,0 +1,116 @@
+// backend/internal/attachment/content.go
+package attachment
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// allowedContentTypes are the files yards exchange: photos, scanned or
+// generated PDFs, and spreadsheet or document exports
+var allowedContentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+	"text/plain":      true,
+	"text/csv":        true,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document": true,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":       true,
+}
+
+// officeTypes are ZIP containers http.DetectContentType cannot tell apart;
+// the extension picks the type once the bytes are known to be a ZIP
+var officeTypes = map[string]string{
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// sniffContentType decides a file's type from its first bytes. The file name
+// only refines a sniffed type, so renaming an executable to .pdf is rejected.
+func sniffContentType(fileName string, head []byte) (string, error) {
+	sniffed, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return "", fmt.Errorf("%w: unrecognised content", ErrContentTypeNotAllowed)
+	}
+
+	ext := strings.ToLower(filepath.Ext(fileName))
+	switch {
+	case sniffed == "application/zip" && officeTypes[ext] != "":
+		sniffed = officeTypes[ext]
+	case sniffed == "text/plain" && ext == ".csv":
+		sniffed = "text/csv"
+	}
+
+	if !allowedContentTypes[sniffed] {
+		return "", fmt.Errorf("%w: %s", ErrContentTypeNotAllowed, sniffed)
+	}
+	return sniffed, nil
+}
+
+const limitsSettingsKey = "attachments"
+
+// DefaultLimits apply when a tenant has not configured its own
+var DefaultLimits = Limits{
+	MaxFileBytes:  25 << 20,
+	MaxTotalBytes: 10 << 30,
+}
+
+// LimitSource resolves a tenant's attachment limits
+type LimitSource interface {
+	GetLimits(ctx context.Context, tenantID string) (*Limits, error)
+}
+
+type tenantSettings struct {
+	db *sql.DB
+}
+
+// NewTenantSettingsSource reads limits from the "attachments" entry of the
+// settings column of auth.tenants in the central database, falling back to
+// DefaultLimits for anything not set
+func NewTenantSettingsSource(centralDB *sql.DB) LimitSource {
+	return &tenantSettings{db: centralDB}
+}
+
+func (s *tenantSettings) GetLimits(ctx context.Context, tenantID string) (*Limits, error) {
+	var raw []byte
+	err := s.db.QueryRowContext(ctx,
+		`SELECT settings FROM auth.tenants WHERE id = $1`, tenantID).Scan(&raw)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("tenant not found: %s", tenantID)
+		}
+		return nil, fmt.Errorf("failed to get tenant settings: %w", err)
+	}
+
+	var settings struct {
+		Attachments *Limits `json:"attachments"`
+	}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &settings); err != nil {
+			return nil, fmt.Errorf("failed to decode tenant settings %s: %w", limitsSettingsKey, err)
+		}
+	}
+
+	return mergeLimits(settings.Attachments), nil
+}
+
+func mergeLimits(configured *Limits) *Limits {
+	limits := DefaultLimits
+	if configured != nil {
+		if configured.MaxFileBytes > 0 {
+			limits.MaxFileBytes = configured.MaxFileBytes
+		}
+		if configured.MaxTotalBytes > 0 {
+			limits.MaxTotalBytes = configured.MaxTotalBytes
+		}
+	}
+	return &limits
+}
@@ -0,0 +1,22 @@
+// backend/internal/attachment/errors.go
+package attachment
+
+import "errors"
+
+// Attachment errors
+var (
+	ErrAttachmentNotFound    = errors.New("attachment not found")
+	ErrEntityNotFound        = errors.New("attached entity not found")
+	ErrEmptyFile             = errors.New("file is empty")
+	ErrFileTooLarge          = errors.New("file exceeds the tenant's size limit")
+	ErrQuotaExceeded         = errors.New("tenant attachment storage is full")
+	ErrContentTypeNotAllowed = errors.New("file type is not allowed")
+)
+
+// Blob store and download link errors
+var (
+	ErrBlobNotFound     = errors.New("blob not found")
+	ErrInvalidKey       = errors.New("invalid storage key")
+	ErrInvalidSignature = errors.New("invalid download signature")
+	ErrLinkExpired      = errors.New("download link has expired")
+)
@@ -0,0 +1,266 @@
+// backend/internal/attachment/handlers.go
+package attachment
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"oilgas-backend/internal/auth"
+)
+
+// defaultLinkTTL is how long a download link lasts unless the caller asks
+const defaultLinkTTL = 15 * time.Minute
+
+type Handlers struct {
+	service Service
+}
+
+func NewHandlers(service Service) *Handlers {
+	return &Handlers{service: service}
+}
+
+func (h *Handlers) RegisterRoutes(router *gin.RouterGroup, authMiddleware *auth.Middleware) {
+	attachments := router.Group("/attachments")
+	attachments.Use(authMiddleware.RequireAuth())
+
+	attachments.GET("", h.ListAttachments)
+	attachments.POST("", h.UploadAttachment)
+	attachments.GET("/:id", h.GetAttachment)
+	attachments.GET("/:id/url", h.GetDownloadURL)
+	attachments.DELETE("/:id", authMiddleware.RequireRole(auth.RoleOperator, auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin), h.DeleteAttachment)
+}
+
+// RegisterDownloadRoutes mounts the signed download route. It must sit
+// outside any session middleware: the link's signature is its authorization.
+func (h *Handlers) RegisterDownloadRoutes(router *gin.RouterGroup) {
+	router.GET("/:tenant/:id", h.DownloadAttachment)
+}
+
+func (h *Handlers) ListAttachments(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+
+	entityType := EntityType(c.Query("entity_type"))
+	entityID, err := strconv.Atoi(c.Query("entity_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entity ID"})
+		return
+	}
+
+	if !h.authorizeEntity(c, entityType, entityID) {
+		return
+	}
+
+	attachments, err := h.service.ListAttachments(c.Request.Context(), tenantID, entityType, entityID)
+	if err != nil {
+		c.JSON(attachmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  attachments,
+		"total": len(attachments),
+	})
+}
+
+// UploadAttachment takes a multipart form with "file", "entity_type",
+// "entity_id" and an optional "description"
+func (h *Handlers) UploadAttachment(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+
+	entityType := EntityType(c.PostForm("entity_type"))
+	entityID, err := strconv.Atoi(c.PostForm("entity_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entity ID"})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A file is required"})
+		return
+	}
+
+	if !h.authorizeEntity(c, entityType, entityID) {
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read file"})
+		return
+	}
+	defer file.Close()
+
+	att, err := h.service.Upload(c.Request.Context(), tenantID, c.GetInt("user_id"), &Upload{
+		EntityType:  entityType,
+		EntityID:    entityID,
+		FileName:    fileHeader.Filename,
+		Description: c.PostForm("description"),
+		Content:     file,
+	})
+	if err != nil {
+		c.JSON(attachmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, att)
+}
+
+func (h *Handlers) GetAttachment(c *gin.Context) {
+	att, ok := h.loadAttachment(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, att)
+}
+
+// GetDownloadURL signs a link to the file; ttl is in minutes (default 15)
+func (h *Handlers) GetDownloadURL(c *gin.Context) {
+	att, ok := h.loadAttachment(c)
+	if !ok {
+		return
+	}
+
+	ttl := defaultLinkTTL
+	if minutes := c.Query("ttl"); minutes != "" {
+		m, err := strconv.Atoi(minutes)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ttl, expected minutes"})
+			return
+		}
+		ttl = time.Duration(m) * time.Minute
+	}
+
+	link, err := h.service.DownloadURL(c.Request.Context(), att.TenantID, att.ID, ttl)
+	if err != nil {
+		c.JSON(attachmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+func (h *Handlers) DeleteAttachment(c *gin.Context) {
+	att, ok := h.loadAttachment(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteAttachment(c.Request.Context(), att.TenantID, c.GetInt("user_id"), att.ID); err != nil {
+		c.JSON(attachmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Attachment deleted"})
+}
+
+// DownloadAttachment serves a file to anyone holding an unexpired signed link
+func (h *Handlers) DownloadAttachment(c *gin.Context) {
+	tenantID := c.Param("tenant")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid attachment ID"})
+		return
+	}
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": ErrInvalidSignature.Error()})
+		return
+	}
+
+	att, content, err := h.service.OpenSigned(c.Request.Context(), tenantID, id, expires, c.Query("signature"))
+	if err != nil {
+		c.JSON(attachmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, att.SizeBytes, att.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": att.FileName}),
+		"X-Content-Type-Options": "nosniff",
+		"Cache-Control":          "private, no-store",
+	})
+}
+
+// loadAttachment fetches the :id attachment and checks the caller may see
+// the entity it belongs to
+func (h *Handlers) loadAttachment(c *gin.Context) (*Attachment, bool) {
+	tenantID := c.GetString("tenant_id")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid attachment ID"})
+		return nil, false
+	}
+
+	att, err := h.service.GetAttachment(c.Request.Context(), tenantID, id)
+	if err != nil {
+		c.JSON(attachmentErrorStatus(err), gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	if !h.authorizeEntity(c, att.EntityType, att.EntityID) {
+		return nil, false
+	}
+
+	return att, true
+}
+
+// authorizeEntity keeps customer contacts to their own customer's files.
+// Anything else is reported as not found so IDs cannot be probed.
+func (h *Handlers) authorizeEntity(c *gin.Context, entityType EntityType, entityID int) bool {
+	scope := customerScope(c)
+	if scope == nil {
+		return true
+	}
+
+	owner, err := h.service.EntityCustomerID(c.Request.Context(), c.GetString("tenant_id"), entityType, entityID)
+	if err != nil {
+		c.JSON(attachmentErrorStatus(err), gin.H{"error": err.Error()})
+		return false
+	}
+	if owner == nil || *owner != *scope {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("%s not found", entityType)})
+		return false
+	}
+	return true
+}
+
+// customerScope is the customer a contact is limited to, or nil for staff
+func customerScope(c *gin.Context) *int {
+	if value, exists := c.Get("user"); exists {
+		if user, ok := value.(*auth.User); ok && user != nil && user.IsCustomerContact() {
+			customerID := *user.CustomerID
+			return &customerID
+		}
+	}
+	if customerID := c.GetInt("customer_filter"); customerID > 0 {
+		return &customerID
+	}
+	return nil
+}
+
+func attachmentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrAttachmentNotFound), errors.Is(err, ErrEntityNotFound), errors.Is(err, ErrBlobNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrFileTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrQuotaExceeded):
+		return http.StatusInsufficientStorage
+	case errors.Is(err, ErrContentTypeNotAllowed):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, ErrInvalidSignature):
+		return http.StatusForbidden
+	case errors.Is(err, ErrLinkExpired):
+		return http.StatusGone
+	default:
+		return http.StatusBadRequest
+	}
+}
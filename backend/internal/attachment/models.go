@@ -0,0 +1,65 @@
+// backend/internal/attachment/models.go
+package attachment
+
+import (
+	"io"
+	"time"
+)
+
+// Attachment is the metadata of one stored file. The bytes live in the blob
+// store under StorageKey, which always starts with the tenant ID.
+type Attachment struct {
+	ID         int        `json:"id" db:"id"`
+	TenantID   string     `json:"tenant_id" db:"tenant_id"`
+	EntityType EntityType `json:"entity_type" db:"entity_type"`
+	EntityID   int        `json:"entity_id" db:"entity_id"`
+
+	FileName    string  `json:"file_name" db:"file_name"`
+	ContentType string  `json:"content_type" db:"content_type"` // Sniffed from the content, not taken from the client
+	SizeBytes   int64   `json:"size_bytes" db:"size_bytes"`
+	Checksum    string  `json:"checksum_sha256" db:"checksum_sha256"`
+	StorageKey  string  `json:"-" db:"storage_key"`
+	Description *string `json:"description,omitempty" db:"description"`
+
+	UploadedByUserID int       `json:"uploaded_by_user_id" db:"uploaded_by_user_id"`
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+}
+
+// EntityType names what an attachment belongs to
+type EntityType string
+
+const (
+	EntityWorkOrder     EntityType = "WORK_ORDER"
+	EntityWorkOrderItem EntityType = "WORK_ORDER_ITEM"
+	EntityInventoryItem EntityType = "INVENTORY_ITEM"
+	EntityCustomer      EntityType = "CUSTOMER"
+)
+
+func (t EntityType) IsValid() bool {
+	switch t {
+	case EntityWorkOrder, EntityWorkOrderItem, EntityInventoryItem, EntityCustomer:
+		return true
+	}
+	return false
+}
+
+// Upload is a file to attach to an entity
+type Upload struct {
+	EntityType  EntityType
+	EntityID    int
+	FileName    string
+	Description string
+	Content     io.Reader
+}
+
+// Limits caps what a tenant may store
+type Limits struct {
+	MaxFileBytes  int64 `json:"max_file_bytes"`
+	MaxTotalBytes int64 `json:"max_total_bytes"`
+}
+
+// DownloadLink is a signed URL that works without a session until ExpiresAt
+type DownloadLink struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
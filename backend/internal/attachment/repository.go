@@ -0,0 +1,211 @@
+// backend/internal/attachment/repository.go
+package attachment
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"oilgas-backend/internal/shared/database"
+)
+
+type Repository interface {
+	GetAttachment(ctx context.Context, tenantID string, id int) (*Attachment, error)
+	ListAttachments(ctx context.Context, tenantID string, entityType EntityType, entityID int) ([]Attachment, error)
+
+	// GetEntityCustomerID returns the customer that owns an attachable
+	// entity, nil for inventory not tied to a customer, or ErrEntityNotFound
+	GetEntityCustomerID(ctx context.Context, tenantID string, entityType EntityType, entityID int) (*int, error)
+
+	// CreateAttachment records an uploaded blob, or returns ErrQuotaExceeded
+	// when it would take the tenant's active attachments past maxTotalBytes
+	CreateAttachment(ctx context.Context, tenantID string, att *Attachment, maxTotalBytes int64) error
+	DeleteAttachment(ctx context.Context, tenantID string, userID, id int) (*Attachment, error)
+}
+
+type repository struct {
+	dbManager *database.DatabaseManager
+}
+
+func NewRepository(dbManager *database.DatabaseManager) Repository {
+	return &repository{dbManager: dbManager}
+}
+
+const attachmentColumns = `
+	id, tenant_id, entity_type, entity_id, file_name, content_type, size_bytes,
+	checksum_sha256, storage_key, description, uploaded_by_user_id, created_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAttachment(row rowScanner) (*Attachment, error) {
+	var att Attachment
+	err := row.Scan(
+		&att.ID, &att.TenantID, &att.EntityType, &att.EntityID, &att.FileName, &att.ContentType, &att.SizeBytes,
+		&att.Checksum, &att.StorageKey, &att.Description, &att.UploadedByUserID, &att.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &att, nil
+}
+
+func (r *repository) GetAttachment(ctx context.Context, tenantID string, id int) (*Attachment, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	att, err := scanAttachment(db.QueryRowContext(ctx, `
+		SELECT`+attachmentColumns+`
+		FROM store.attachments
+		WHERE id = $1 AND tenant_id = $2 AND is_active = true`, id, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+
+	return att, nil
+}
+
+func (r *repository) ListAttachments(ctx context.Context, tenantID string, entityType EntityType, entityID int) ([]Attachment, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT`+attachmentColumns+`
+		FROM store.attachments
+		WHERE tenant_id = $1 AND entity_type = $2 AND entity_id = $3 AND is_active = true
+		ORDER BY created_at DESC, id DESC`, tenantID, entityType, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := []Attachment{}
+	for rows.Next() {
+		att, err := scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, *att)
+	}
+
+	return attachments, rows.Err()
+}
+
+// entityOwnerQueries look up the customer behind each kind of attachable
+// entity, scoped to the tenant
+var entityOwnerQueries = map[EntityType]string{
+	EntityWorkOrder: `
+		SELECT customer_id FROM store.workorders
+		WHERE id = $1 AND tenant_id = $2 AND is_active = true`,
+	EntityWorkOrderItem: `
+		SELECT w.customer_id FROM store.workorder_items i
+		JOIN store.workorders w ON w.id = i.workorder_id
+		WHERE i.id = $1 AND w.tenant_id = $2 AND w.is_active = true`,
+	EntityInventoryItem: `
+		SELECT customer_id FROM store.inventory
+		WHERE id = $1 AND tenant_id = $2 AND deleted = false`,
+	EntityCustomer: `
+		SELECT id FROM store.customers
+		WHERE id = $1 AND tenant_id = $2 AND is_active = true`,
+}
+
+func (r *repository) GetEntityCustomerID(ctx context.Context, tenantID string, entityType EntityType, entityID int) (*int, error) {
+	query, ok := entityOwnerQueries[entityType]
+	if !ok {
+		return nil, fmt.Errorf("unknown entity type: %s", entityType)
+	}
+
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	var customerID *int
+	if err := db.QueryRowContext(ctx, query, entityID, tenantID).Scan(&customerID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrEntityNotFound
+		}
+		return nil, fmt.Errorf("failed to get %s %d: %w", entityType, entityID, err)
+	}
+
+	return customerID, nil
+}
+
+func (r *repository) CreateAttachment(ctx context.Context, tenantID string, att *Attachment, maxTotalBytes int64) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Serialize uploads per tenant so concurrent ones cannot both fit under
+	// the quota
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('attachments:' || $1))`, tenantID); err != nil {
+		return fmt.Errorf("failed to lock attachment quota: %w", err)
+	}
+
+	var used int64
+	err = tx.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(size_bytes), 0) FROM store.attachments
+		WHERE tenant_id = $1 AND is_active = true`, tenantID).Scan(&used)
+	if err != nil {
+		return fmt.Errorf("failed to get attachment usage: %w", err)
+	}
+	if used+att.SizeBytes > maxTotalBytes {
+		return fmt.Errorf("%w: %d of %d bytes used", ErrQuotaExceeded, used, maxTotalBytes)
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO store.attachments (
+			tenant_id, entity_type, entity_id, file_name, content_type, size_bytes,
+			checksum_sha256, storage_key, description, uploaded_by_user_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at`,
+		tenantID, att.EntityType, att.EntityID, att.FileName, att.ContentType, att.SizeBytes,
+		att.Checksum, att.StorageKey, att.Description, att.UploadedByUserID,
+	).Scan(&att.ID, &att.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit attachment: %w", err)
+	}
+
+	att.TenantID = tenantID
+	return nil
+}
+
+func (r *repository) DeleteAttachment(ctx context.Context, tenantID string, userID, id int) (*Attachment, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	att, err := scanAttachment(db.QueryRowContext(ctx, `
+		UPDATE store.attachments
+		SET is_active = false, deleted_by_user_id = $3, deleted_at = NOW()
+		WHERE id = $1 AND tenant_id = $2 AND is_active = true
+		RETURNING`+attachmentColumns, id, tenantID, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	return att, nil
+}
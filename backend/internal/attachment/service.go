@@ -0,0 +1,262 @@
+// backend/internal/attachment/service.go
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxLinkTTL bounds how long a signed download link stays valid
+const MaxLinkTTL = 7 * 24 * time.Hour
+
+type Service interface {
+	Upload(ctx context.Context, tenantID string, userID int, upload *Upload) (*Attachment, error)
+	GetAttachment(ctx context.Context, tenantID string, id int) (*Attachment, error)
+	ListAttachments(ctx context.Context, tenantID string, entityType EntityType, entityID int) ([]Attachment, error)
+	DeleteAttachment(ctx context.Context, tenantID string, userID, id int) error
+
+	// EntityCustomerID is the customer an attachable entity belongs to, so
+	// handlers can keep customer contacts to their own files
+	EntityCustomerID(ctx context.Context, tenantID string, entityType EntityType, entityID int) (*int, error)
+
+	// DownloadURL signs a link to the attachment that expires after ttl
+	DownloadURL(ctx context.Context, tenantID string, id int, ttl time.Duration) (*DownloadLink, error)
+
+	// OpenSigned verifies a signed link and opens the attachment's content;
+	// the caller closes the reader
+	OpenSigned(ctx context.Context, tenantID string, id int, expires int64, signature string) (*Attachment, io.ReadCloser, error)
+}
+
+type service struct {
+	repo   Repository
+	store  BlobStore
+	limits LimitSource
+	signer *URLSigner
+}
+
+func NewService(repo Repository, store BlobStore, limits LimitSource, signer *URLSigner) Service {
+	return &service{
+		repo:   repo,
+		store:  store,
+		limits: limits,
+		signer: signer,
+	}
+}
+
+func (s *service) Upload(ctx context.Context, tenantID string, userID int, upload *Upload) (*Attachment, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if err := validateUpload(upload); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if _, err := s.repo.GetEntityCustomerID(ctx, tenantID, upload.EntityType, upload.EntityID); err != nil {
+		return nil, err
+	}
+
+	limits, err := s.limits.GetLimits(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachment limits: %w", err)
+	}
+
+	// Read one byte past the limit to tell "exactly at" from "over"
+	content, err := io.ReadAll(io.LimitReader(upload.Content, limits.MaxFileBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if len(content) == 0 {
+		return nil, ErrEmptyFile
+	}
+	if int64(len(content)) > limits.MaxFileBytes {
+		return nil, fmt.Errorf("%w of %d bytes", ErrFileTooLarge, limits.MaxFileBytes)
+	}
+
+	contentType, err := sniffContentType(upload.FileName, content)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(content)
+	att := &Attachment{
+		TenantID:         tenantID,
+		EntityType:       upload.EntityType,
+		EntityID:         upload.EntityID,
+		FileName:         cleanFileName(upload.FileName),
+		ContentType:      contentType,
+		SizeBytes:        int64(len(content)),
+		Checksum:         hex.EncodeToString(sum[:]),
+		StorageKey:       storageKey(tenantID, upload.EntityType, upload.EntityID),
+		UploadedByUserID: userID,
+	}
+	if description := strings.TrimSpace(upload.Description); description != "" {
+		att.Description = &description
+	}
+
+	if err := s.store.Put(ctx, att.StorageKey, bytes.NewReader(content), att.SizeBytes, contentType); err != nil {
+		return nil, fmt.Errorf("failed to store file: %w", err)
+	}
+
+	if err := s.repo.CreateAttachment(ctx, tenantID, att, limits.MaxTotalBytes); err != nil {
+		s.deleteBlob(ctx, att.StorageKey)
+		return nil, err
+	}
+
+	return att, nil
+}
+
+func (s *service) GetAttachment(ctx context.Context, tenantID string, id int) (*Attachment, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.repo.GetAttachment(ctx, tenantID, id)
+}
+
+func (s *service) ListAttachments(ctx context.Context, tenantID string, entityType EntityType, entityID int) ([]Attachment, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if !entityType.IsValid() {
+		return nil, fmt.Errorf("validation failed: invalid entity type: %s", entityType)
+	}
+
+	return s.repo.ListAttachments(ctx, tenantID, entityType, entityID)
+}
+
+func (s *service) DeleteAttachment(ctx context.Context, tenantID string, userID, id int) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	att, err := s.repo.DeleteAttachment(ctx, tenantID, userID, id)
+	if err != nil {
+		return err
+	}
+
+	s.deleteBlob(ctx, att.StorageKey)
+	return nil
+}
+
+func (s *service) EntityCustomerID(ctx context.Context, tenantID string, entityType EntityType, entityID int) (*int, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if !entityType.IsValid() {
+		return nil, fmt.Errorf("validation failed: invalid entity type: %s", entityType)
+	}
+
+	return s.repo.GetEntityCustomerID(ctx, tenantID, entityType, entityID)
+}
+
+func (s *service) DownloadURL(ctx context.Context, tenantID string, id int, ttl time.Duration) (*DownloadLink, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if ttl <= 0 || ttl > MaxLinkTTL {
+		return nil, fmt.Errorf("validation failed: link lifetime must be between 1 second and %s", MaxLinkTTL)
+	}
+
+	if _, err := s.repo.GetAttachment(ctx, tenantID, id); err != nil {
+		return nil, err
+	}
+
+	link := s.signer.Sign(tenantID, id, ttl)
+	return &link, nil
+}
+
+func (s *service) OpenSigned(ctx context.Context, tenantID string, id int, expires int64, signature string) (*Attachment, io.ReadCloser, error) {
+	if err := s.signer.Verify(tenantID, id, expires, signature); err != nil {
+		return nil, nil, err
+	}
+
+	att, err := s.repo.GetAttachment(ctx, tenantID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.store.Get(ctx, att.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open attachment %d: %w", id, err)
+	}
+
+	return att, content, nil
+}
+
+// deleteBlob is best-effort: an orphaned blob wastes space but the metadata
+// row is what grants access to it
+func (s *service) deleteBlob(ctx context.Context, key string) {
+	if err := s.store.Delete(ctx, key); err != nil && !errors.Is(err, ErrBlobNotFound) {
+		log.Printf("failed to delete attachment blob %s: %v", key, err)
+	}
+}
+
+func validateUpload(upload *Upload) error {
+	if upload == nil || upload.Content == nil {
+		return fmt.Errorf("file is required")
+	}
+	if !upload.EntityType.IsValid() {
+		return fmt.Errorf("invalid entity type: %s", upload.EntityType)
+	}
+	if upload.EntityID <= 0 {
+		return fmt.Errorf("invalid entity ID: %d", upload.EntityID)
+	}
+	if strings.TrimSpace(upload.FileName) == "" {
+		return fmt.Errorf("file name is required")
+	}
+	if len(upload.Description) > 1000 {
+		return fmt.Errorf("description too long (max 1000 characters)")
+	}
+	return nil
+}
+
+// storageKey places a new blob under its tenant and entity; the random last
+// segment means keys never collide and cannot be guessed
+func storageKey(tenantID string, entityType EntityType, entityID int) string {
+	return fmt.Sprintf("%s/%s/%d/%s", tenantID, strings.ToLower(string(entityType)), entityID, uuid.NewString())
+}
+
+// cleanFileName keeps only the base name a browser sent, without any path
+func cleanFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(strings.TrimSpace(name), "\\", "/"))
+	if len(name) > 255 {
+		ext := filepath.Ext(name)
+		if len(ext) > 20 {
+			ext = ""
+		}
+		name = name[:255-len(ext)] + ext
+	}
+	return name
+}
+
+func validateTenantID(tenantID string) error {
+	if tenantID == "" {
+		return fmt.Errorf("tenant ID is required")
+	}
+	if len(tenantID) > 100 {
+		return fmt.Errorf("tenant ID too long: %d characters", len(tenantID))
+	}
+	if strings.ContainsAny(tenantID, "/\\") {
+		return fmt.Errorf("tenant ID contains a path separator")
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+// backend/internal/attachment/service_test.go
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type mockRepository struct {
+	mock.Mock
+}
+
+func (m *mockRepository) GetAttachment(ctx context.Context, tenantID string, id int) (*Attachment, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Attachment), args.Error(1)
+}
+
+func (m *mockRepository) ListAttachments(ctx context.Context, tenantID string, entityType EntityType, entityID int) ([]Attachment, error) {
+	args := m.Called(ctx, tenantID, entityType, entityID)
+	return args.Get(0).([]Attachment), args.Error(1)
+}
+
+func (m *mockRepository) GetEntityCustomerID(ctx context.Context, tenantID string, entityType EntityType, entityID int) (*int, error) {
+	args := m.Called(ctx, tenantID, entityType, entityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int), args.Error(1)
+}
+
+func (m *mockRepository) CreateAttachment(ctx context.Context, tenantID string, att *Attachment, maxTotalBytes int64) error {
+	args := m.Called(ctx, tenantID, att, maxTotalBytes)
+	return args.Error(0)
+}
+
+func (m *mockRepository) DeleteAttachment(ctx context.Context, tenantID string, userID, id int) (*Attachment, error) {
+	args := m.Called(ctx, tenantID, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Attachment), args.Error(1)
+}
+
+type fixedLimits Limits
+
+func (l fixedLimits) GetLimits(ctx context.Context, tenantID string) (*Limits, error) {
+	limits := Limits(l)
+	return &limits, nil
+}
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+type AttachmentServiceTestSuite struct {
+	suite.Suite
+	service  Service
+	repo     *mockRepository
+	store    BlobStore
+	signer   *URLSigner
+	ctx      context.Context
+	tenantID string
+	customer int
+}
+
+func (suite *AttachmentServiceTestSuite) SetupTest() {
+	store, err := NewLocalStore(suite.T().TempDir())
+	suite.Require().NoError(err)
+
+	suite.repo = &mockRepository{}
+	suite.store = store
+	suite.signer = NewURLSigner([]byte("test-signing-key"), "https://api.example.com/files")
+	suite.service = NewService(suite.repo, suite.store, fixedLimits{MaxFileBytes: 64, MaxTotalBytes: 1 << 20}, suite.signer)
+	suite.ctx = context.Background()
+	suite.tenantID = "longbeach"
+	suite.customer = 3
+
+	suite.repo.On("GetEntityCustomerID", suite.ctx, suite.tenantID, EntityWorkOrder, 42).Return(&suite.customer, nil).Maybe()
+}
+
+func TestAttachmentServiceSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentServiceTestSuite))
+}
+
+func (suite *AttachmentServiceTestSuite) upload(name string, content []byte) (*Attachment, error) {
+	return suite.service.Upload(suite.ctx, suite.tenantID, 7, &Upload{
+		EntityType: EntityWorkOrder,
+		EntityID:   42,
+		FileName:   name,
+		Content:    bytes.NewReader(content),
+	})
+}
+
+func (suite *AttachmentServiceTestSuite) TestUpload_SniffsTypeAndStoresUnderTenant() {
+	suite.repo.On("CreateAttachment", suite.ctx, suite.tenantID, mock.AnythingOfType("*attachment.Attachment"), int64(1<<20)).Return(nil)
+
+	// A PNG named .jpg is stored as what it is
+	att, err := suite.upload(`C:\photos\joint-14.jpg`, pngHeader)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "image/png", att.ContentType)
+	assert.Equal(suite.T(), "joint-14.jpg", att.FileName)
+	assert.Equal(suite.T(), int64(len(pngHeader)), att.SizeBytes)
+	assert.Len(suite.T(), att.Checksum, 64)
+	assert.True(suite.T(), strings.HasPrefix(att.StorageKey, "longbeach/work_order/42/"))
+
+	blob, err := suite.store.Get(suite.ctx, att.StorageKey)
+	require.NoError(suite.T(), err)
+	defer blob.Close()
+	stored, _ := io.ReadAll(blob)
+	assert.Equal(suite.T(), pngHeader, stored)
+}
+
+func (suite *AttachmentServiceTestSuite) TestUpload_RejectsDisallowedContent() {
+	_, err := suite.upload("mill-test-report.pdf", []byte("MZ\x90\x00\x03\x00\x00\x00\x04\x00\x00\x00\xff\xff"))
+
+	assert.ErrorIs(suite.T(), err, ErrContentTypeNotAllowed)
+	suite.repo.AssertNotCalled(suite.T(), "CreateAttachment")
+}
+
+func (suite *AttachmentServiceTestSuite) TestUpload_EnforcesFileSizeLimit() {
+	_, err := suite.upload("notes.txt", bytes.Repeat([]byte("a"), 65))
+
+	assert.ErrorIs(suite.T(), err, ErrFileTooLarge)
+	suite.repo.AssertNotCalled(suite.T(), "CreateAttachment")
+}
+
+func (suite *AttachmentServiceTestSuite) TestUpload_QuotaExceededRemovesBlob() {
+	var key string
+	suite.repo.On("CreateAttachment", suite.ctx, suite.tenantID, mock.AnythingOfType("*attachment.Attachment"), int64(1<<20)).
+		Run(func(args mock.Arguments) { key = args.Get(2).(*Attachment).StorageKey }).
+		Return(ErrQuotaExceeded)
+
+	_, err := suite.upload("ticket.csv", []byte("joint,length\n1,31.2\n"))
+
+	assert.ErrorIs(suite.T(), err, ErrQuotaExceeded)
+	_, err = suite.store.Get(suite.ctx, key)
+	assert.ErrorIs(suite.T(), err, ErrBlobNotFound)
+}
+
+func (suite *AttachmentServiceTestSuite) TestUpload_UnknownEntity() {
+	suite.repo.On("GetEntityCustomerID", suite.ctx, suite.tenantID, EntityCustomer, 99).Return(nil, ErrEntityNotFound)
+
+	_, err := suite.service.Upload(suite.ctx, suite.tenantID, 7, &Upload{
+		EntityType: EntityCustomer,
+		EntityID:   99,
+		FileName:   "w9.pdf",
+		Content:    bytes.NewReader([]byte("%PDF-1.7")),
+	})
+
+	assert.ErrorIs(suite.T(), err, ErrEntityNotFound)
+}
+
+func (suite *AttachmentServiceTestSuite) TestSignedDownload() {
+	att := &Attachment{ID: 5, TenantID: suite.tenantID, ContentType: "image/png", StorageKey: "longbeach/work_order/42/photo"}
+	suite.Require().NoError(suite.store.Put(suite.ctx, att.StorageKey, bytes.NewReader(pngHeader), int64(len(pngHeader)), "image/png"))
+	suite.repo.On("GetAttachment", suite.ctx, suite.tenantID, 5).Return(att, nil)
+
+	link, err := suite.service.DownloadURL(suite.ctx, suite.tenantID, 5, time.Hour)
+	require.NoError(suite.T(), err)
+
+	parsed, err := url.Parse(link.URL)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "/files/longbeach/5", parsed.Path)
+	expires, _ := strconv.ParseInt(parsed.Query().Get("expires"), 10, 64)
+	signature := parsed.Query().Get("signature")
+
+	_, content, err := suite.service.OpenSigned(suite.ctx, suite.tenantID, 5, expires, signature)
+	require.NoError(suite.T(), err)
+	content.Close()
+
+	// The same signature does not open another tenant's attachment 5
+	_, _, err = suite.service.OpenSigned(suite.ctx, "bakersfield", 5, expires, signature)
+	assert.ErrorIs(suite.T(), err, ErrInvalidSignature)
+
+	// Nor can the expiry be extended
+	_, _, err = suite.service.OpenSigned(suite.ctx, suite.tenantID, 5, expires+3600, signature)
+	assert.ErrorIs(suite.T(), err, ErrInvalidSignature)
+}
+
+func (suite *AttachmentServiceTestSuite) TestSignedDownload_Expired() {
+	suite.signer.now = func() time.Time { return time.Now().Add(-2 * time.Hour) }
+	link := suite.signer.Sign(suite.tenantID, 5, time.Hour)
+	suite.signer.now = time.Now
+
+	parsed, _ := url.Parse(link.URL)
+	expires, _ := strconv.ParseInt(parsed.Query().Get("expires"), 10, 64)
+
+	_, _, err := suite.service.OpenSigned(suite.ctx, suite.tenantID, 5, expires, parsed.Query().Get("signature"))
+	assert.ErrorIs(suite.T(), err, ErrLinkExpired)
+}
+
+func TestSniffContentType(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fileName string
+		content  []byte
+		expected string
+		err      error
+	}{
+		{"pdf", "mtr.PDF", []byte("%PDF-1.4\n"), "application/pdf", nil},
+		{"csv from text", "tally.csv", []byte("joint,length\n"), "text/csv", nil},
+		{"xlsx from zip", "tally.xlsx", []byte("PK\x03\x04\x14\x00\x06\x00"), "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", nil},
+		{"bare zip", "archive.zip", []byte("PK\x03\x04\x14\x00\x06\x00"), "", ErrContentTypeNotAllowed},
+		{"html", "ticket.txt", []byte("<html><script>alert(1)</script>"), "", ErrContentTypeNotAllowed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			contentType, err := sniffContentType(tc.fileName, tc.content)
+			if tc.err != nil {
+				assert.True(t, errors.Is(err, tc.err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, contentType)
+		})
+	}
+}
+
+func TestLocalStore_RejectsKeysOutsideTenant(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"../etc/passwd", "/abs/path", "longbeach/../bakersfield/x", "longbeach//x", `longbeach\x`} {
+		err := store.Put(context.Background(), key, strings.NewReader("x"), 1, "text/plain")
+		assert.ErrorIs(t, err, ErrInvalidKey, key)
+	}
+}
+
+func TestMergeLimits(t *testing.T) {
+	assert.Equal(t, DefaultLimits, *mergeLimits(nil))
+	assert.Equal(t, Limits{MaxFileBytes: 5 << 20, MaxTotalBytes: DefaultLimits.MaxTotalBytes}, *mergeLimits(&Limits{MaxFileBytes: 5 << 20}))
+}
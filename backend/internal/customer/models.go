@@ -58,6 +58,12 @@ type CustomerAnalytics struct {
 	TotalRevenue    float64    `json:"total_revenue"`
 	AvgOrderValue   float64    `json:"avg_order_value"`
 	LastOrderDate   *time.Time `json:"last_order_date,omitempty"`
+
+	// Estimate accuracy over completed work orders that were quoted in hours
+	EstimatedOrders  int      `json:"estimated_orders"`
+	EstimatedHours   float64  `json:"estimated_hours"`
+	ActualHours      float64  `json:"actual_hours"`
+	HoursVariancePct *float64 `json:"hours_variance_pct,omitempty"`
 }
 
 type SearchFilters struct {
@@ -5,6 +5,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math"
 	"strings"
 	
 	"oilgas-backend/internal/shared/database"
@@ -142,14 +143,37 @@ func (r *repository) GetCustomerAnalytics(ctx context.Context, tenantID string,
 		return nil, fmt.Errorf("customer not found")
 	}
 	
-	return &CustomerAnalytics{
-		CustomerID:      customerID,
-		TotalWorkOrders: 0,
-		ActiveOrders:    0,
-		TotalRevenue:    0,
-		AvgOrderValue:   0,
-		LastOrderDate:   nil,
-	}, nil
+	analytics := &CustomerAnalytics{CustomerID: customerID}
+	var invoiced int
+	err = db.QueryRowContext(ctx, `
+		SELECT COUNT(*),
+		       COUNT(*) FILTER (WHERE status IN ('PENDING', 'APPROVED', 'IN_PROGRESS', 'ON_HOLD')),
+		       COUNT(*) FILTER (WHERE status IN ('INVOICED', 'PAID')),
+		       COALESCE(SUM(total_amount) FILTER (WHERE status IN ('INVOICED', 'PAID')), 0),
+		       MAX(created_at),
+		       COUNT(*) FILTER (WHERE status IN ('COMPLETED', 'INVOICED', 'PAID') AND estimated_hours > 0),
+		       COALESCE(SUM(estimated_hours) FILTER (WHERE status IN ('COMPLETED', 'INVOICED', 'PAID') AND estimated_hours > 0), 0),
+		       COALESCE(SUM(COALESCE(actual_hours, 0)) FILTER (WHERE status IN ('COMPLETED', 'INVOICED', 'PAID') AND estimated_hours > 0), 0)
+		FROM store.workorders
+		WHERE tenant_id = $1 AND customer_id = $2 AND is_active = true`,
+		tenantID, customerID,
+	).Scan(&analytics.TotalWorkOrders, &analytics.ActiveOrders, &invoiced, &analytics.TotalRevenue, &analytics.LastOrderDate,
+		&analytics.EstimatedOrders, &analytics.EstimatedHours, &analytics.ActualHours)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer work orders: %w", err)
+	}
+
+	if invoiced > 0 {
+		analytics.AvgOrderValue = math.Round(analytics.TotalRevenue/float64(invoiced)*100) / 100
+	}
+
+	// Estimate accuracy uses the same rule as the work order variance report
+	if analytics.EstimatedHours > 0 {
+		pct := math.Round((analytics.ActualHours-analytics.EstimatedHours)/analytics.EstimatedHours*10000) / 100
+		analytics.HoursVariancePct = &pct
+	}
+
+	return analytics, nil
 }
 
 func (r *repository) SearchCustomers(ctx context.Context, tenantID string, filters SearchFilters) ([]Customer, int, error) {
@@ -0,0 +1,193 @@
+// backend/internal/inventory/bill_of_lading.go
+package inventory
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"oilgas-backend/internal/shared/pdf"
+)
+
+var billOfLadingTemplate = template.Must(template.New("bill_of_lading").Funcs(template.FuncMap{
+	"deref":  derefString,
+	"weight": formatWeight,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Bill of Lading {{.ShipmentNumber}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; font-size: 12px; margin: 32px; }
+h1 { font-size: 20px; margin: 0 0 4px; }
+table { border-collapse: collapse; width: 100%; margin-top: 16px; }
+th, td { border: 1px solid #333; padding: 4px 6px; text-align: left; }
+td.num, th.num { text-align: right; }
+.header td { border: none; padding: 2px 12px 2px 0; }
+.signatures { margin-top: 48px; display: flex; gap: 48px; }
+.signatures div { flex: 1; border-top: 1px solid #333; padding-top: 4px; }
+@media print { body { margin: 0; } }
+</style>
+</head>
+<body>
+<h1>BILL OF LADING</h1>
+<div>{{.Yard}}</div>
+<table class="header">
+<tr><td><strong>BOL #</strong></td><td>{{.ShipmentNumber}}</td><td><strong>Date</strong></td><td>{{.ShippedAt.Format "01/02/2006"}}</td></tr>
+<tr><td><strong>Customer</strong></td><td>{{deref .Customer}}</td><td><strong>Carrier</strong></td><td>{{deref .Carrier}}</td></tr>
+<tr><td><strong>Well</strong></td><td>{{deref .WellOut}}</td><td><strong>Truck / Trailer</strong></td><td>{{deref .TruckNumber}}{{with .TrailerNumber}} / {{.}}{{end}}</td></tr>
+<tr><td><strong>Lease</strong></td><td>{{deref .LeaseOut}}</td><td><strong>Driver</strong></td><td>{{deref .DriverName}}</td></tr>
+</table>
+<table>
+<thead><tr><th>Work Order</th><th>R #</th><th>Size</th><th class="num">Weight</th><th>Grade</th><th>Connection</th><th>Rack</th><th class="num">Joints</th></tr></thead>
+<tbody>
+{{range .Lines}}<tr><td>{{deref .WorkOrder}}</td><td>{{deref .RNumber}}</td><td>{{deref .Size}}</td><td class="num">{{weight .Weight}}</td><td>{{deref .Grade}}</td><td>{{deref .Connection}}</td><td>{{deref .FromRack}}</td><td class="num">{{.Joints}}</td></tr>
+{{end}}<tr><td colspan="7"><strong>Total joints</strong></td><td class="num"><strong>{{.TotalJoints}}</strong></td></tr>
+</tbody>
+</table>
+{{with .Notes}}<p><strong>Notes:</strong> {{.}}</p>{{end}}
+<div class="signatures"><div>Shipper</div><div>Driver</div><div>Received by</div></div>
+</body>
+</html>
+`))
+
+type billOfLading struct {
+	*Shipment
+	Yard string
+}
+
+// RenderBillOfLadingHTML renders a printable bill of lading; yard names the
+// shipping yard in the heading
+func RenderBillOfLadingHTML(shipment *Shipment, yard string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := billOfLadingTemplate.Execute(&buf, billOfLading{Shipment: shipment, Yard: yard}); err != nil {
+		return nil, fmt.Errorf("failed to render bill of lading: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Bill of lading PDF layout, in points from the top-left corner
+const (
+	bolMargin     = 40.0
+	bolLineHeight = 16.0
+	bolFooter     = 680.0 // Lines past this start a new page
+)
+
+var bolColumns = []struct {
+	title string
+	x     float64
+	right bool
+}{
+	{"Work Order", bolMargin, false},
+	{"R #", 120, false},
+	{"Size", 185, false},
+	{"Weight", 285, true},
+	{"Grade", 295, false},
+	{"Connection", 345, false},
+	{"Rack", 455, false},
+	{"Joints", pdf.PageWidth - bolMargin, true},
+}
+
+// RenderBillOfLadingPDF lays the same bill of lading out as a PDF
+func RenderBillOfLadingPDF(shipment *Shipment, yard string) []byte {
+	doc := pdf.NewDocument("Bill of Lading " + shipment.ShipmentNumber)
+	doc.AddPage()
+
+	doc.Text(bolMargin, 56, pdf.HelveticaBold, 18, "BILL OF LADING")
+	doc.Text(bolMargin, 74, pdf.Helvetica, 10, yard)
+	rightText(doc, pdf.PageWidth-bolMargin, 56, pdf.HelveticaBold, 14, shipment.ShipmentNumber)
+	rightText(doc, pdf.PageWidth-bolMargin, 74, pdf.Helvetica, 10, shipment.ShippedAt.Format("01/02/2006"))
+
+	y := 104.0
+	header := [][4]string{
+		{"Customer", derefString(shipment.Customer), "Carrier", derefString(shipment.Carrier)},
+		{"Well", derefString(shipment.WellOut), "Truck", derefString(shipment.TruckNumber)},
+		{"Lease", derefString(shipment.LeaseOut), "Trailer", derefString(shipment.TrailerNumber)},
+		{"", "", "Driver", derefString(shipment.DriverName)},
+	}
+	for _, row := range header {
+		doc.Text(bolMargin, y, pdf.HelveticaBold, 10, row[0])
+		doc.Text(bolMargin+60, y, pdf.Helvetica, 10, truncate(row[1], 40))
+		doc.Text(330, y, pdf.HelveticaBold, 10, row[2])
+		doc.Text(390, y, pdf.Helvetica, 10, truncate(row[3], 30))
+		y += bolLineHeight
+	}
+
+	y = bolTableHeader(doc, y+12)
+	for _, line := range shipment.Lines {
+		if y > bolFooter {
+			doc.AddPage()
+			doc.Text(bolMargin, 56, pdf.HelveticaBold, 12, "BILL OF LADING "+shipment.ShipmentNumber+" (continued)")
+			y = bolTableHeader(doc, 80)
+		}
+		values := []string{
+			truncate(derefString(line.WorkOrder), 14),
+			truncate(derefString(line.RNumber), 12),
+			truncate(derefString(line.Size), 18),
+			formatWeight(line.Weight),
+			truncate(derefString(line.Grade), 8),
+			truncate(derefString(line.Connection), 20),
+			truncate(derefString(line.FromRack), 12),
+			fmt.Sprintf("%d", line.Joints),
+		}
+		for i, col := range bolColumns {
+			if col.right {
+				rightText(doc, col.x, y, pdf.Helvetica, 9, values[i])
+			} else {
+				doc.Text(col.x, y, pdf.Helvetica, 9, values[i])
+			}
+		}
+		y += bolLineHeight
+	}
+
+	doc.Line(bolMargin, y-10, pdf.PageWidth-bolMargin, y-10, 0.75)
+	doc.Text(bolMargin, y+2, pdf.HelveticaBold, 10, "Total joints")
+	rightText(doc, pdf.PageWidth-bolMargin, y+2, pdf.HelveticaBold, 10, fmt.Sprintf("%d", shipment.TotalJoints))
+
+	if shipment.Notes != nil {
+		doc.Text(bolMargin, y+24, pdf.Helvetica, 9, "Notes: "+truncate(*shipment.Notes, 100))
+	}
+
+	signatureY := 740.0
+	for i, label := range []string{"Shipper", "Driver", "Received by"} {
+		x := bolMargin + float64(i)*180
+		doc.Line(x, signatureY, x+160, signatureY, 0.75)
+		doc.Text(x, signatureY+12, pdf.Helvetica, 9, label)
+	}
+
+	return doc.Bytes()
+}
+
+// bolTableHeader draws the line item column titles and returns the baseline
+// of the first row
+func bolTableHeader(doc *pdf.Document, y float64) float64 {
+	for _, col := range bolColumns {
+		if col.right {
+			rightText(doc, col.x, y, pdf.HelveticaBold, 9, col.title)
+		} else {
+			doc.Text(col.x, y, pdf.HelveticaBold, 9, col.title)
+		}
+	}
+	doc.Line(bolMargin, y+4, pdf.PageWidth-bolMargin, y+4, 0.75)
+	return y + bolLineHeight + 2
+}
+
+func rightText(doc *pdf.Document, right, y float64, font pdf.Font, size float64, text string) {
+	doc.Text(right-pdf.TextWidth(text, size), y, font, size, text)
+}
+
+func truncate(s string, max int) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= max {
+		return s
+	}
+	return s[:max-1] + "~"
+}
+
+func formatWeight(weight *float64) string {
+	if weight == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.2f", *weight)
+}
@@ -0,0 +1,224 @@
+// backend/internal/inventory/count.go
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type CountService interface {
+	GetSession(ctx context.Context, tenantID string, id int) (*CountSession, error)
+	GetSessions(ctx context.Context, tenantID string, filters CountSessionFilters) ([]CountSession, int, error)
+	OpenSession(ctx context.Context, tenantID string, userID int, req *OpenCountRequest) (*CountSession, error)
+
+	// RecordCounts enters operator counts; a count that differs from the
+	// system may carry its reason code now or before submission
+	RecordCounts(ctx context.Context, tenantID string, userID int, req *RecordCountsRequest) (*CountSession, error)
+	SubmitSession(ctx context.Context, tenantID string, userID, id int) (*CountSession, error)
+
+	// ApproveSession posts the session's differences to inventory as ledger
+	// adjustments; RejectSession sends it back for a recount
+	ApproveSession(ctx context.Context, tenantID string, userID, id int) (*CountSession, error)
+	RejectSession(ctx context.Context, tenantID string, userID, id int, reason string) (*CountSession, error)
+	CancelSession(ctx context.Context, tenantID string, userID, id int) (*CountSession, error)
+}
+
+type countService struct {
+	counts CountRepository
+}
+
+func NewCountService(counts CountRepository) CountService {
+	return &countService{counts: counts}
+}
+
+func (s *countService) GetSession(ctx context.Context, tenantID string, id int) (*CountSession, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.counts.GetSession(ctx, tenantID, id)
+}
+
+func (s *countService) GetSessions(ctx context.Context, tenantID string, filters CountSessionFilters) ([]CountSession, int, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, 0, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if filters.Status != nil && !isValidCountStatus(*filters.Status) {
+		return nil, 0, fmt.Errorf("invalid count status: %s", *filters.Status)
+	}
+	if filters.Limit <= 0 {
+		filters.Limit = 50
+	}
+	if filters.Limit > 1000 {
+		return nil, 0, fmt.Errorf("limit too large: %d (max 1000)", filters.Limit)
+	}
+	if filters.Offset < 0 {
+		return nil, 0, fmt.Errorf("offset cannot be negative: %d", filters.Offset)
+	}
+
+	return s.counts.GetSessions(ctx, tenantID, filters)
+}
+
+func (s *countService) OpenSession(ctx context.Context, tenantID string, userID int, req *OpenCountRequest) (*CountSession, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if err := validateOpenCountRequest(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	return s.counts.OpenSession(ctx, tenantID, userID, req)
+}
+
+func (s *countService) RecordCounts(ctx context.Context, tenantID string, userID int, req *RecordCountsRequest) (*CountSession, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if err := validateRecordCountsRequest(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	return s.counts.RecordCounts(ctx, tenantID, userID, req)
+}
+
+func (s *countService) SubmitSession(ctx context.Context, tenantID string, userID, id int) (*CountSession, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	return s.counts.SubmitSession(ctx, tenantID, userID, id)
+}
+
+func (s *countService) ApproveSession(ctx context.Context, tenantID string, userID, id int) (*CountSession, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	return s.counts.ApproveSession(ctx, tenantID, userID, id)
+}
+
+func (s *countService) RejectSession(ctx context.Context, tenantID string, userID, id int, reason string) (*CountSession, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("validation failed: a reason is required to send a count back")
+	}
+	if len(reason) > 1000 {
+		return nil, fmt.Errorf("validation failed: reason too long (max 1000 characters)")
+	}
+
+	return s.counts.RejectSession(ctx, tenantID, userID, id, reason)
+}
+
+func (s *countService) CancelSession(ctx context.Context, tenantID string, userID, id int) (*CountSession, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	return s.counts.CancelSession(ctx, tenantID, userID, id)
+}
+
+func isValidCountStatus(status CountStatus) bool {
+	switch status {
+	case CountOpen, CountSubmitted, CountApproved, CountCancelled:
+		return true
+	}
+	return false
+}
+
+func isValidAdjustmentReason(reason AdjustmentReason) bool {
+	for _, r := range AdjustmentReasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+func validateOpenCountRequest(req *OpenCountRequest) error {
+	if req == nil {
+		return fmt.Errorf("count session is required")
+	}
+
+	req.Rack = strings.TrimSpace(req.Rack)
+	req.Location = strings.TrimSpace(req.Location)
+	if len(req.Rack) > 50 {
+		return fmt.Errorf("rack too long (max 50 characters)")
+	}
+	if len(req.Location) > 100 {
+		return fmt.Errorf("location too long (max 100 characters)")
+	}
+	if len(req.Notes) > 1000 {
+		return fmt.Errorf("notes too long (max 1000 characters)")
+	}
+
+	return nil
+}
+
+func validateRecordCountsRequest(req *RecordCountsRequest) error {
+	if req == nil {
+		return fmt.Errorf("counts are required")
+	}
+	if req.SessionID <= 0 {
+		return fmt.Errorf("invalid count session ID: %d", req.SessionID)
+	}
+
+	if len(req.Counts) == 0 {
+		return fmt.Errorf("at least one count is required")
+	}
+	seen := make(map[int]bool, len(req.Counts))
+	for i := range req.Counts {
+		entry := &req.Counts[i]
+		if entry.ItemID <= 0 {
+			return fmt.Errorf("count %d: invalid inventory item ID: %d", i+1, entry.ItemID)
+		}
+		if seen[entry.ItemID] {
+			return fmt.Errorf("count %d: inventory item %d is counted twice", i+1, entry.ItemID)
+		}
+		seen[entry.ItemID] = true
+		if entry.CountedJoints < 0 {
+			return fmt.Errorf("count %d: counted joints cannot be negative", i+1)
+		}
+
+		entry.ReasonCode = AdjustmentReason(strings.ToUpper(strings.TrimSpace(string(entry.ReasonCode))))
+		if entry.ReasonCode != "" && !isValidAdjustmentReason(entry.ReasonCode) {
+			return fmt.Errorf("count %d: unknown reason code: %s", i+1, entry.ReasonCode)
+		}
+		if len(entry.Notes) > 1000 {
+			return fmt.Errorf("count %d: notes too long (max 1000 characters)", i+1)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+// backend/internal/inventory/count_handlers.go
+package inventory
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"oilgas-backend/internal/auth"
+)
+
+type CountHandlers struct {
+	service CountService
+}
+
+func NewCountHandlers(service CountService) *CountHandlers {
+	return &CountHandlers{service: service}
+}
+
+func (h *CountHandlers) RegisterRoutes(router *gin.RouterGroup, authMiddleware *auth.Middleware) {
+	staff := authMiddleware.RequireRole(auth.RoleOperator, auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin)
+	managers := authMiddleware.RequireRole(auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin)
+
+	counts := router.Group("/cycle-counts")
+	counts.Use(authMiddleware.RequireAuth())
+	counts.Use(staff)
+
+	counts.GET("", h.GetSessions)
+	counts.POST("", h.OpenSession)
+	counts.GET("/reason-codes", h.GetReasonCodes)
+	counts.GET("/:id", h.GetSession)
+	counts.POST("/:id/counts", h.RecordCounts)
+	counts.POST("/:id/submit", h.SubmitSession)
+	counts.POST("/:id/cancel", h.CancelSession)
+
+	// Posting adjustments to the ledger is a manager's call
+	counts.POST("/:id/approve", managers, h.ApproveSession)
+	counts.POST("/:id/reject", managers, h.RejectSession)
+}
+
+// GetSessions lists count sessions, optionally by ?status=
+func (h *CountHandlers) GetSessions(c *gin.Context) {
+	var filters CountSessionFilters
+
+	if raw := c.Query("status"); raw != "" {
+		status := CountStatus(raw)
+		filters.Status = &status
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filters.Limit = l
+		}
+	}
+
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filters.Offset = o
+		}
+	}
+
+	sessions, total, err := h.service.GetSessions(c.Request.Context(), c.GetString("tenant_id"), filters)
+	if err != nil {
+		c.JSON(countErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  sessions,
+		"total": total,
+	})
+}
+
+// OpenSession starts a count of a rack, a location, or the whole yard
+func (h *CountHandlers) OpenSession(c *gin.Context) {
+	var req OpenCountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := h.service.OpenSession(c.Request.Context(), c.GetString("tenant_id"), c.GetInt("user_id"), &req)
+	if err != nil {
+		c.JSON(countErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, session)
+}
+
+func (h *CountHandlers) GetReasonCodes(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"data":  AdjustmentReasons,
+		"total": len(AdjustmentReasons),
+	})
+}
+
+func (h *CountHandlers) GetSession(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid count session ID"})
+		return
+	}
+
+	session, err := h.service.GetSession(c.Request.Context(), c.GetString("tenant_id"), id)
+	if err != nil {
+		c.JSON(countErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// RecordCounts accepts one or many counts, as entered at the rack or
+// uploaded from a handheld
+func (h *CountHandlers) RecordCounts(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid count session ID"})
+		return
+	}
+
+	var req RecordCountsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.SessionID = id
+
+	session, err := h.service.RecordCounts(c.Request.Context(), c.GetString("tenant_id"), c.GetInt("user_id"), &req)
+	if err != nil {
+		c.JSON(countErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+func (h *CountHandlers) SubmitSession(c *gin.Context) {
+	h.transition(c, h.service.SubmitSession)
+}
+
+func (h *CountHandlers) ApproveSession(c *gin.Context) {
+	h.transition(c, h.service.ApproveSession)
+}
+
+func (h *CountHandlers) CancelSession(c *gin.Context) {
+	h.transition(c, h.service.CancelSession)
+}
+
+func (h *CountHandlers) RejectSession(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid count session ID"})
+		return
+	}
+
+	var req RejectCountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := h.service.RejectSession(c.Request.Context(), c.GetString("tenant_id"), c.GetInt("user_id"), id, req.Reason)
+	if err != nil {
+		c.JSON(countErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// transition runs a bodiless status change on the session in the path
+func (h *CountHandlers) transition(c *gin.Context, change func(ctx context.Context, tenantID string, userID, id int) (*CountSession, error)) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid count session ID"})
+		return
+	}
+
+	session, err := change(c.Request.Context(), c.GetString("tenant_id"), c.GetInt("user_id"), id)
+	if err != nil {
+		c.JSON(countErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+func countErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrCountNotFound), errors.Is(err, ErrCountLineNotFound), errors.Is(err, ErrItemNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrCountInProgress), errors.Is(err, ErrCountNotOpen), errors.Is(err, ErrCountNotSubmitted),
+		errors.Is(err, ErrCountStale), errors.Is(err, ErrOverAllocated):
+		return http.StatusConflict
+	case errors.Is(err, ErrCountIncomplete), errors.Is(err, ErrReasonRequired), errors.Is(err, ErrCountScopeEmpty):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,565 @@
+// backend/internal/inventory/count_repository.go
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"oilgas-backend/internal/shared/database"
+)
+
+type CountRepository interface {
+	GetSession(ctx context.Context, tenantID string, id int) (*CountSession, error)
+	GetSessions(ctx context.Context, tenantID string, filters CountSessionFilters) ([]CountSession, int, error)
+
+	// OpenSession snapshots the joints on every in-stock row in scope. A row
+	// can only be in one open or submitted count at a time.
+	OpenSession(ctx context.Context, tenantID string, userID int, req *OpenCountRequest) (*CountSession, error)
+	RecordCounts(ctx context.Context, tenantID string, userID int, req *RecordCountsRequest) (*CountSession, error)
+
+	// SubmitSession sends a fully counted session for approval
+	SubmitSession(ctx context.Context, tenantID string, userID, id int) (*CountSession, error)
+
+	// ApproveSession applies every difference to its inventory row and
+	// writes an ADJUST ledger entry for it, in one transaction. A row that
+	// has changed since the session was opened fails the approval.
+	ApproveSession(ctx context.Context, tenantID string, userID, id int) (*CountSession, error)
+
+	// RejectSession sends a submitted session back for a recount
+	RejectSession(ctx context.Context, tenantID string, userID, id int, reason string) (*CountSession, error)
+	CancelSession(ctx context.Context, tenantID string, userID, id int) (*CountSession, error)
+}
+
+type countRepository struct {
+	dbManager *database.DatabaseManager
+}
+
+func NewCountRepository(dbManager *database.DatabaseManager) CountRepository {
+	return &countRepository{dbManager: dbManager}
+}
+
+const countSessionColumns = `
+	s.id, s.tenant_id, s.rack, s.location, s.status, s.notes,
+	s.opened_by_user_id, s.opened_at, s.submitted_by_user_id, s.submitted_at,
+	s.reviewed_by_user_id, s.reviewed_at, s.rejection_reason,
+	(SELECT COUNT(*) FROM store.count_lines l WHERE l.session_id = s.id),
+	(SELECT COUNT(*) FROM store.count_lines l WHERE l.session_id = s.id AND l.counted_joints IS NOT NULL),
+	(SELECT COUNT(*) FROM store.count_lines l WHERE l.session_id = s.id AND l.variance <> 0)`
+
+const countLineColumns = `
+	id, session_id, inventory_item_id, r_number, rack, location,
+	expected_joints, counted_joints, variance, reason_code, notes,
+	counted_by_user_id, counted_at, movement_id`
+
+func scanCountSession(row rowScanner) (*CountSession, error) {
+	var s CountSession
+	err := row.Scan(
+		&s.ID, &s.TenantID, &s.Rack, &s.Location, &s.Status, &s.Notes,
+		&s.OpenedByUserID, &s.OpenedAt, &s.SubmittedByUserID, &s.SubmittedAt,
+		&s.ReviewedByUserID, &s.ReviewedAt, &s.RejectionReason,
+		&s.TotalLines, &s.CountedLines, &s.VarianceLines,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func scanCountLine(row rowScanner) (*CountLine, error) {
+	var l CountLine
+	err := row.Scan(
+		&l.ID, &l.SessionID, &l.ItemID, &l.RNumber, &l.Rack, &l.Location,
+		&l.ExpectedJoints, &l.CountedJoints, &l.Variance, &l.ReasonCode, &l.Notes,
+		&l.CountedByUserID, &l.CountedAt, &l.MovementID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// getCountSession loads a session and its lines, in rack order
+func getCountSession(ctx context.Context, q querier, tenantID string, id int) (*CountSession, error) {
+	session, err := scanCountSession(q.QueryRowContext(ctx, `
+		SELECT`+countSessionColumns+`
+		FROM store.count_sessions s
+		WHERE s.id = $1 AND s.tenant_id = $2`, id, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrCountNotFound
+		}
+		return nil, fmt.Errorf("failed to get count session: %w", err)
+	}
+
+	rows, err := q.QueryContext(ctx, `
+		SELECT`+countLineColumns+`
+		FROM store.count_lines
+		WHERE session_id = $1
+		ORDER BY rack NULLS LAST, location NULLS LAST, inventory_item_id`, session.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get count lines: %w", err)
+	}
+	defer rows.Close()
+
+	session.Lines = []CountLine{}
+	for rows.Next() {
+		line, err := scanCountLine(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan count line: %w", err)
+		}
+		session.Lines = append(session.Lines, *line)
+	}
+
+	return session, rows.Err()
+}
+
+// lockCountSessionTx locks a session and checks it is in the given status
+func lockCountSessionTx(ctx context.Context, tx *sql.Tx, tenantID string, id int, status CountStatus, wrongStatus error) error {
+	var current CountStatus
+	err := tx.QueryRowContext(ctx, `
+		SELECT status FROM store.count_sessions
+		WHERE id = $1 AND tenant_id = $2
+		FOR UPDATE`, id, tenantID).Scan(&current)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrCountNotFound
+		}
+		return fmt.Errorf("failed to lock count session: %w", err)
+	}
+	if current != status {
+		return fmt.Errorf("%w: session is %s", wrongStatus, current)
+	}
+	return nil
+}
+
+func (r *countRepository) GetSession(ctx context.Context, tenantID string, id int) (*CountSession, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	return getCountSession(ctx, db, tenantID, id)
+}
+
+// GetSessions lists session headers with their progress, newest first
+func (r *countRepository) GetSessions(ctx context.Context, tenantID string, filters CountSessionFilters) ([]CountSession, int, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	where := "WHERE s.tenant_id = $1"
+	args := []interface{}{tenantID}
+
+	if filters.Status != nil {
+		args = append(args, *filters.Status)
+		where += fmt.Sprintf(" AND s.status = $%d", len(args))
+	}
+
+	var total int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM store.count_sessions s "+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count count sessions: %w", err)
+	}
+
+	query := `
+		SELECT` + countSessionColumns + `
+		FROM store.count_sessions s
+		` + where + `
+		ORDER BY s.opened_at DESC, s.id DESC`
+
+	if filters.Limit > 0 {
+		args = append(args, filters.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filters.Offset > 0 {
+		args = append(args, filters.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get count sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []CountSession{}
+	for rows.Next() {
+		session, err := scanCountSession(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan count session: %w", err)
+		}
+		sessions = append(sessions, *session)
+	}
+
+	return sessions, total, rows.Err()
+}
+
+func (r *countRepository) OpenSession(ctx context.Context, tenantID string, userID int, req *OpenCountRequest) (*CountSession, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Opening sessions is serialised per tenant so two overlapping counts
+	// cannot both pass the in-progress check
+	if _, err := tx.ExecContext(ctx, `
+		SELECT pg_advisory_xact_lock(hashtext('store.count_sessions'), hashtext($1))`, tenantID); err != nil {
+		return nil, fmt.Errorf("failed to lock count sessions: %w", err)
+	}
+
+	rack, location := nullableString(req.Rack), nullableString(req.Location)
+	scope := `i.tenant_id = $1 AND i.deleted = false AND i.date_out IS NULL
+		AND ($2::varchar IS NULL OR i.rack = $2) AND ($3::varchar IS NULL OR i.location = $3)`
+
+	var inProgress bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM store.count_lines l
+			JOIN store.count_sessions s ON s.id = l.session_id
+			JOIN store.inventory i ON i.id = l.inventory_item_id
+			WHERE s.tenant_id = $1 AND s.status IN ('OPEN', 'SUBMITTED') AND `+scope+`
+		)`, tenantID, rack, location).Scan(&inProgress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check open counts: %w", err)
+	}
+	if inProgress {
+		return nil, ErrCountInProgress
+	}
+
+	var id int
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO store.count_sessions (tenant_id, rack, location, notes, opened_by_user_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`, tenantID, rack, location, nullableString(req.Notes), userID).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create count session: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO store.count_lines (session_id, inventory_item_id, r_number, rack, location, expected_joints)
+		SELECT $4, i.id, i.r_number, i.rack, i.location, COALESCE(i.joints, 0)
+		FROM store.inventory i
+		WHERE `+scope, tenantID, rack, location, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot count lines: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return nil, ErrCountScopeEmpty
+	}
+
+	session, err := getCountSession(ctx, tx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit count session: %w", err)
+	}
+
+	return session, nil
+}
+
+func (r *countRepository) RecordCounts(ctx context.Context, tenantID string, userID int, req *RecordCountsRequest) (*CountSession, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockCountSessionTx(ctx, tx, tenantID, req.SessionID, CountOpen, ErrCountNotOpen); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range req.Counts {
+		var reason *AdjustmentReason
+		if entry.ReasonCode != "" {
+			reason = &entry.ReasonCode
+		}
+
+		result, err := tx.ExecContext(ctx, `
+			UPDATE store.count_lines
+			SET counted_joints = $3, reason_code = $4, notes = $5, counted_by_user_id = $6, counted_at = NOW()
+			WHERE session_id = $1 AND inventory_item_id = $2`,
+			req.SessionID, entry.ItemID, entry.CountedJoints, reason, nullableString(entry.Notes), userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record count: %w", err)
+		}
+		if n, _ := result.RowsAffected(); n == 0 {
+			return nil, fmt.Errorf("%w: item %d", ErrCountLineNotFound, entry.ItemID)
+		}
+	}
+
+	session, err := getCountSession(ctx, tx, tenantID, req.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit counts: %w", err)
+	}
+
+	return session, nil
+}
+
+func (r *countRepository) SubmitSession(ctx context.Context, tenantID string, userID, id int) (*CountSession, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockCountSessionTx(ctx, tx, tenantID, id, CountOpen, ErrCountNotOpen); err != nil {
+		return nil, err
+	}
+
+	var uncounted, unexplained int
+	err = tx.QueryRowContext(ctx, `
+		SELECT COUNT(*) FILTER (WHERE counted_joints IS NULL),
+		       COUNT(*) FILTER (WHERE variance <> 0 AND reason_code IS NULL)
+		FROM store.count_lines
+		WHERE session_id = $1`, id).Scan(&uncounted, &unexplained)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check count lines: %w", err)
+	}
+	if uncounted > 0 {
+		return nil, fmt.Errorf("%w: %d row(s) not counted", ErrCountIncomplete, uncounted)
+	}
+	if unexplained > 0 {
+		return nil, fmt.Errorf("%w: %d row(s) without a reason", ErrReasonRequired, unexplained)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE store.count_sessions
+		SET status = 'SUBMITTED', submitted_by_user_id = $2, submitted_at = NOW()
+		WHERE id = $1`, id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit count session: %w", err)
+	}
+
+	session, err := getCountSession(ctx, tx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit count submission: %w", err)
+	}
+
+	return session, nil
+}
+
+func (r *countRepository) ApproveSession(ctx context.Context, tenantID string, userID, id int) (*CountSession, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockCountSessionTx(ctx, tx, tenantID, id, CountSubmitted, ErrCountNotSubmitted); err != nil {
+		return nil, err
+	}
+
+	// Rows are locked in id order, as everywhere else that locks several
+	rows, err := tx.QueryContext(ctx, `
+		SELECT`+countLineColumns+`
+		FROM store.count_lines
+		WHERE session_id = $1 AND variance <> 0
+		ORDER BY inventory_item_id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get count variances: %w", err)
+	}
+	var lines []CountLine
+	for rows.Next() {
+		line, err := scanCountLine(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan count line: %w", err)
+		}
+		lines = append(lines, *line)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, line := range lines {
+		if err := adjustItemTx(ctx, tx, tenantID, userID, id, &line); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE store.count_sessions
+		SET status = 'APPROVED', reviewed_by_user_id = $2, reviewed_at = NOW()
+		WHERE id = $1`, id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to approve count session: %w", err)
+	}
+
+	session, err := getCountSession(ctx, tx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit count approval: %w", err)
+	}
+
+	return session, nil
+}
+
+// adjustItemTx sets a row to its counted joints and records the difference
+// in the ledger. A row counted at zero is dated out rather than left in
+// stock with no joints.
+func adjustItemTx(ctx context.Context, tx *sql.Tx, tenantID string, userID, sessionID int, line *CountLine) error {
+	item, err := lockItemTx(ctx, tx, tenantID, line.ItemID)
+	if err != nil {
+		return err
+	}
+	if item.DateOut != nil || item.Joints != line.ExpectedJoints {
+		return fmt.Errorf("%w: item %d: %d expected when counted, %d now", ErrCountStale, item.ID, line.ExpectedJoints, item.Joints)
+	}
+
+	counted := *line.CountedJoints
+	delta := counted - item.Joints
+	if delta < 0 {
+		reserved, err := reservedJointsTx(ctx, tx, item.ID)
+		if err != nil {
+			return err
+		}
+		if counted < reserved {
+			return fmt.Errorf("%w: item %d: %d counted, %d reserved", ErrOverAllocated, item.ID, counted, reserved)
+		}
+	}
+
+	if counted == 0 {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE store.inventory SET date_out = CURRENT_DATE WHERE id = $1`, item.ID)
+	} else {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE store.inventory SET joints = $1 WHERE id = $2`, counted, item.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to adjust inventory item: %w", err)
+	}
+
+	reason := string(*line.ReasonCode)
+	movement := Movement{
+		TenantID:      tenantID,
+		MovementType:  MovementAdjust,
+		ItemID:        item.ID,
+		RNumber:       item.RNumber,
+		CustomerID:    item.CustomerID,
+		Joints:        delta,
+		Notes:         nullableString(fmt.Sprintf("count session %d", sessionID)),
+		ReasonCode:    &reason,
+		MovedByUserID: userID,
+	}
+	if delta < 0 {
+		movement.Joints = -delta
+		movement.FromRack, movement.FromLocation = item.Rack, item.Location
+	} else {
+		movement.ToRack, movement.ToLocation = item.Rack, item.Location
+	}
+	if line.Notes != nil {
+		movement.Notes = nullableString(*movement.Notes + ": " + *line.Notes)
+	}
+
+	if err := insertMovementTx(ctx, tx, &movement); err != nil {
+		return err
+	}
+	if err := logInventoryEventTx(ctx, tx, "inventory.adjusted", &movement); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE store.count_lines SET movement_id = $1 WHERE id = $2`, movement.ID, line.ID); err != nil {
+		return fmt.Errorf("failed to link count adjustment: %w", err)
+	}
+	return nil
+}
+
+func (r *countRepository) RejectSession(ctx context.Context, tenantID string, userID, id int, reason string) (*CountSession, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockCountSessionTx(ctx, tx, tenantID, id, CountSubmitted, ErrCountNotSubmitted); err != nil {
+		return nil, err
+	}
+
+	// The counts are kept so the recount only has to correct the rows in
+	// question
+	_, err = tx.ExecContext(ctx, `
+		UPDATE store.count_sessions
+		SET status = 'OPEN', reviewed_by_user_id = $2, reviewed_at = NOW(), rejection_reason = $3
+		WHERE id = $1`, id, userID, nullableString(reason))
+	if err != nil {
+		return nil, fmt.Errorf("failed to reject count session: %w", err)
+	}
+
+	session, err := getCountSession(ctx, tx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit count rejection: %w", err)
+	}
+
+	return session, nil
+}
+
+func (r *countRepository) CancelSession(ctx context.Context, tenantID string, userID, id int) (*CountSession, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	// Either an open or a submitted count can be abandoned
+	result, err := db.ExecContext(ctx, `
+		UPDATE store.count_sessions
+		SET status = 'CANCELLED', reviewed_by_user_id = $3, reviewed_at = NOW()
+		WHERE id = $1 AND tenant_id = $2 AND status IN ('OPEN', 'SUBMITTED')`, id, tenantID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel count session: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		session, err := getCountSession(ctx, db, tenantID, id)
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: session is %s", ErrCountNotOpen, session.Status)
+	}
+
+	return getCountSession(ctx, db, tenantID, id)
+}
@@ -0,0 +1,157 @@
+// backend/internal/inventory/count_test.go
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type mockCountRepository struct {
+	mock.Mock
+}
+
+func (m *mockCountRepository) session(args mock.Arguments) (*CountSession, error) {
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*CountSession), args.Error(1)
+}
+
+func (m *mockCountRepository) GetSession(ctx context.Context, tenantID string, id int) (*CountSession, error) {
+	return m.session(m.Called(ctx, tenantID, id))
+}
+
+func (m *mockCountRepository) GetSessions(ctx context.Context, tenantID string, filters CountSessionFilters) ([]CountSession, int, error) {
+	args := m.Called(ctx, tenantID, filters)
+	return args.Get(0).([]CountSession), args.Int(1), args.Error(2)
+}
+
+func (m *mockCountRepository) OpenSession(ctx context.Context, tenantID string, userID int, req *OpenCountRequest) (*CountSession, error) {
+	return m.session(m.Called(ctx, tenantID, userID, req))
+}
+
+func (m *mockCountRepository) RecordCounts(ctx context.Context, tenantID string, userID int, req *RecordCountsRequest) (*CountSession, error) {
+	return m.session(m.Called(ctx, tenantID, userID, req))
+}
+
+func (m *mockCountRepository) SubmitSession(ctx context.Context, tenantID string, userID, id int) (*CountSession, error) {
+	return m.session(m.Called(ctx, tenantID, userID, id))
+}
+
+func (m *mockCountRepository) ApproveSession(ctx context.Context, tenantID string, userID, id int) (*CountSession, error) {
+	return m.session(m.Called(ctx, tenantID, userID, id))
+}
+
+func (m *mockCountRepository) RejectSession(ctx context.Context, tenantID string, userID, id int, reason string) (*CountSession, error) {
+	return m.session(m.Called(ctx, tenantID, userID, id, reason))
+}
+
+func (m *mockCountRepository) CancelSession(ctx context.Context, tenantID string, userID, id int) (*CountSession, error) {
+	return m.session(m.Called(ctx, tenantID, userID, id))
+}
+
+type CountServiceTestSuite struct {
+	suite.Suite
+	service  CountService
+	counts   *mockCountRepository
+	ctx      context.Context
+	tenantID string
+}
+
+func (suite *CountServiceTestSuite) SetupTest() {
+	suite.counts = &mockCountRepository{}
+	suite.service = NewCountService(suite.counts)
+	suite.ctx = context.Background()
+	suite.tenantID = "longbeach"
+}
+
+func TestCountServiceSuite(t *testing.T) {
+	suite.Run(t, new(CountServiceTestSuite))
+}
+
+func (suite *CountServiceTestSuite) TestOpenSession_TrimsScope() {
+	req := &OpenCountRequest{Rack: " A-12 "}
+	session := &CountSession{ID: 3, Rack: strPtr("A-12"), Status: CountOpen, TotalLines: 4}
+	suite.counts.On("OpenSession", suite.ctx, suite.tenantID, 7, req).Return(session, nil)
+
+	got, err := suite.service.OpenSession(suite.ctx, suite.tenantID, 7, req)
+
+	suite.NoError(err)
+	suite.Equal(session, got)
+	suite.Equal("A-12", req.Rack)
+}
+
+func (suite *CountServiceTestSuite) TestRecordCounts_NormalizesReasonCodes() {
+	req := &RecordCountsRequest{
+		SessionID: 3,
+		Counts: []CountEntry{
+			{ItemID: 501, CountedJoints: 58, ReasonCode: " damaged "},
+			{ItemID: 502, CountedJoints: 40},
+		},
+	}
+	session := &CountSession{ID: 3, Status: CountOpen, CountedLines: 2}
+	suite.counts.On("RecordCounts", suite.ctx, suite.tenantID, 7, req).Return(session, nil)
+
+	_, err := suite.service.RecordCounts(suite.ctx, suite.tenantID, 7, req)
+
+	suite.NoError(err)
+	suite.Equal(ReasonDamaged, req.Counts[0].ReasonCode)
+	suite.Equal(AdjustmentReason(""), req.Counts[1].ReasonCode)
+}
+
+func (suite *CountServiceTestSuite) TestRecordCounts_RejectsInvalidRequests() {
+	testCases := []struct {
+		name string
+		req  *RecordCountsRequest
+	}{
+		{"missing request", nil},
+		{"missing session", &RecordCountsRequest{Counts: []CountEntry{{ItemID: 501, CountedJoints: 10}}}},
+		{"no counts", &RecordCountsRequest{SessionID: 3}},
+		{"negative count", &RecordCountsRequest{SessionID: 3, Counts: []CountEntry{{ItemID: 501, CountedJoints: -1}}}},
+		{"counted twice", &RecordCountsRequest{SessionID: 3, Counts: []CountEntry{{ItemID: 501, CountedJoints: 10}, {ItemID: 501, CountedJoints: 12}}}},
+		{"unknown reason", &RecordCountsRequest{SessionID: 3, Counts: []CountEntry{{ItemID: 501, CountedJoints: 10, ReasonCode: "STOLEN_BY_ALIENS"}}}},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			_, err := suite.service.RecordCounts(suite.ctx, suite.tenantID, 7, tc.req)
+
+			assert.Error(suite.T(), err)
+			assert.Contains(suite.T(), err.Error(), "validation failed")
+		})
+	}
+
+	suite.counts.AssertNotCalled(suite.T(), "RecordCounts")
+}
+
+func (suite *CountServiceTestSuite) TestRejectSession_RequiresReason() {
+	_, err := suite.service.RejectSession(suite.ctx, suite.tenantID, 9, 3, "   ")
+	suite.Error(err)
+	suite.counts.AssertNotCalled(suite.T(), "RejectSession")
+
+	session := &CountSession{ID: 3, Status: CountOpen, RejectionReason: strPtr("recount rack A-12")}
+	suite.counts.On("RejectSession", suite.ctx, suite.tenantID, 9, 3, "recount rack A-12").Return(session, nil)
+
+	got, err := suite.service.RejectSession(suite.ctx, suite.tenantID, 9, 3, " recount rack A-12 ")
+	suite.NoError(err)
+	suite.Equal(CountOpen, got.Status)
+}
+
+func (suite *CountServiceTestSuite) TestApproveSession_StaleCount() {
+	suite.counts.On("ApproveSession", suite.ctx, suite.tenantID, 9, 3).Return(nil, ErrCountStale)
+
+	_, err := suite.service.ApproveSession(suite.ctx, suite.tenantID, 9, 3)
+
+	suite.ErrorIs(err, ErrCountStale)
+}
+
+func (suite *CountServiceTestSuite) TestGetSessions_ValidatesStatus() {
+	status := CountStatus("DONE")
+	_, _, err := suite.service.GetSessions(suite.ctx, suite.tenantID, CountSessionFilters{Status: &status})
+	suite.Error(err)
+	suite.counts.AssertNotCalled(suite.T(), "GetSessions")
+}
@@ -0,0 +1,80 @@
+// backend/internal/inventory/errors.go
+package inventory
+
+import "errors"
+
+// Inventory errors
+var (
+	ErrItemNotFound       = errors.New("inventory item not found")
+	ErrNotInStock         = errors.New("inventory item has left the yard")
+	ErrInsufficientJoints = errors.New("inventory item has fewer joints than requested")
+	ErrSamePlace          = errors.New("joints are already in that rack and location")
+)
+
+// Reservation errors
+var (
+	ErrReservationNotFound   = errors.New("reservation not found")
+	ErrReservationReleased   = errors.New("reservation has already been released")
+	ErrOverAllocated         = errors.New("not enough unreserved joints")
+	ErrAlreadyReserved       = errors.New("inventory item is already reserved for this work order")
+	ErrWorkOrderNotFound     = errors.New("work order not found")
+	ErrWorkOrderItemNotFound = errors.New("work order item not found")
+	ErrWorkOrderClosed       = errors.New("work order can no longer reserve inventory")
+	ErrCustomerMismatch      = errors.New("inventory belongs to a different customer than the work order")
+)
+
+// Shipment errors
+var (
+	ErrShipmentNotFound      = errors.New("shipment not found")
+	ErrNotCustomerInventory  = errors.New("inventory belongs to a different customer than the shipment")
+	ErrReservationNotForItem = errors.New("reservation does not hold joints in this inventory item")
+)
+
+// Receiving errors
+var (
+	ErrReceivedNotFound    = errors.New("received ticket not found")
+	ErrAlreadyCheckedIn    = errors.New("received ticket has already been checked in")
+	ErrReceiptNotFound     = errors.New("receipt not found")
+	ErrDiscrepancyUnstated = errors.New("counted joints differ from the ordered count; a discrepancy reason is required")
+)
+
+// Transfer errors
+var (
+	ErrTransferNotFound     = errors.New("transfer order not found")
+	ErrUnknownYard          = errors.New("destination yard is not connected")
+	ErrYardNotSetUp         = errors.New("destination yard's database does not have the transfer tables")
+	ErrTransferNotPending   = errors.New("transfer has already reached the destination yard and can no longer be cancelled")
+	ErrTransferNotInTransit = errors.New("transfer is not in transit to this yard")
+	ErrTransferLineMismatch = errors.New("every transfer line must be received exactly once")
+	ErrTransferReceived     = errors.New("transfer has already been received by the destination yard")
+)
+
+// Cycle count errors
+var (
+	ErrCountNotFound     = errors.New("count session not found")
+	ErrCountInProgress   = errors.New("some of these rows are already in an open count")
+	ErrCountScopeEmpty   = errors.New("no inventory in stock for this rack or location")
+	ErrCountNotOpen      = errors.New("count session is not open for counting")
+	ErrCountNotSubmitted = errors.New("count session has not been submitted for approval")
+	ErrCountIncomplete   = errors.New("every row in the session must be counted before it is submitted")
+	ErrCountLineNotFound = errors.New("inventory item is not part of this count")
+	ErrReasonRequired    = errors.New("a reason code is required where the count differs from the system")
+	ErrCountStale        = errors.New("inventory changed since it was counted; recount the row")
+)
+
+// Weight errors
+var (
+	ErrTallyTooLong = errors.New("tally has more joints than the inventory row")
+)
+
+// Storage billing errors
+var (
+	ErrRatePlanNotFound = errors.New("storage rate plan not found")
+	ErrRatePlanExists   = errors.New("an active rate plan already covers this customer")
+	ErrPeriodNotEnded   = errors.New("storage period has not ended")
+)
+
+// Snapshot errors
+var (
+	ErrDayNotClosed = errors.New("inventory day has not closed yet")
+)
@@ -0,0 +1,60 @@
+// backend/internal/inventory/events.go
+package inventory
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"oilgas-backend/internal/shared/events"
+)
+
+type ShippedLine struct {
+	ItemID        int     `json:"inventory_item_id"`
+	ShippedItemID int     `json:"shipped_item_id"`
+	RNumber       *string `json:"r_number"`
+	WorkOrder     *string `json:"work_order"`
+	Joints        int     `json:"joints"`
+}
+
+type InventoryShippedEvent struct {
+	events.BaseEvent
+	ShipmentID     int           `json:"shipment_id"`
+	ShipmentNumber string        `json:"shipment_number"`
+	CustomerID     int           `json:"customer_id"`
+	WellOut        *string       `json:"well_out"`
+	LeaseOut       *string       `json:"lease_out"`
+	TotalJoints    int           `json:"total_joints"`
+	Lines          []ShippedLine `json:"lines"`
+	ShippedBy      int           `json:"shipped_by_user_id"`
+}
+
+func NewInventoryShippedEvent(tenantID string, shipment *Shipment) *InventoryShippedEvent {
+	lines := make([]ShippedLine, len(shipment.Lines))
+	for i, line := range shipment.Lines {
+		lines[i] = ShippedLine{
+			ItemID:        line.ItemID,
+			ShippedItemID: line.ShippedItemID,
+			RNumber:       line.RNumber,
+			WorkOrder:     line.WorkOrder,
+			Joints:        line.Joints,
+		}
+	}
+
+	return &InventoryShippedEvent{
+		BaseEvent: events.BaseEvent{
+			ID:        uuid.New().String(),
+			Type:      "inventory.shipped",
+			Tenant:    tenantID,
+			CreatedAt: time.Now(),
+		},
+		ShipmentID:     shipment.ID,
+		ShipmentNumber: shipment.ShipmentNumber,
+		CustomerID:     shipment.CustomerID,
+		WellOut:        shipment.WellOut,
+		LeaseOut:       shipment.LeaseOut,
+		TotalJoints:    shipment.TotalJoints,
+		Lines:          lines,
+		ShippedBy:      shipment.ShippedByUserID,
+	}
+}
@@ -0,0 +1,134 @@
+// backend/internal/inventory/handlers.go
+package inventory
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"oilgas-backend/internal/auth"
+)
+
+type Handlers struct {
+	service Service
+}
+
+func NewHandlers(service Service) *Handlers {
+	return &Handlers{service: service}
+}
+
+func (h *Handlers) RegisterRoutes(router *gin.RouterGroup, authMiddleware *auth.Middleware) {
+	staff := authMiddleware.RequireRole(auth.RoleOperator, auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin)
+
+	inventory := router.Group("/inventory")
+	inventory.Use(authMiddleware.RequireAuth())
+	inventory.Use(staff)
+
+	inventory.GET("/r-numbers/:rNumber/history", h.GetRNumberHistory)
+	inventory.GET("/:id", h.GetItem)
+	inventory.GET("/:id/movements", h.GetItemMovements)
+	inventory.POST("/:id/move", h.MoveItem)
+}
+
+func (h *Handlers) GetItem(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid inventory item ID"})
+		return
+	}
+
+	item, err := h.service.GetItem(c.Request.Context(), tenantID, id)
+	if err != nil {
+		c.JSON(inventoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// MoveItem moves some or all joints to another rack or location; omitting
+// joints moves them all
+func (h *Handlers) MoveItem(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid inventory item ID"})
+		return
+	}
+
+	var req MoveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ItemID = id
+
+	result, err := h.service.MoveItem(c.Request.Context(), tenantID, c.GetInt("user_id"), &req)
+	if err != nil {
+		c.JSON(inventoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *Handlers) GetItemMovements(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid inventory item ID"})
+		return
+	}
+
+	limit, offset := 0, 0
+	if l := c.Query("limit"); l != "" {
+		if limit, err = strconv.Atoi(l); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+	}
+	if o := c.Query("offset"); o != "" {
+		if offset, err = strconv.Atoi(o); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset"})
+			return
+		}
+	}
+
+	movements, err := h.service.GetItemMovements(c.Request.Context(), tenantID, id, limit, offset)
+	if err != nil {
+		c.JSON(inventoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  movements,
+		"total": len(movements),
+	})
+}
+
+// GetRNumberHistory answers where an R-number's joints are now and every
+// move they made to get there
+func (h *Handlers) GetRNumberHistory(c *gin.Context) {
+	history, err := h.service.GetRNumberHistory(c.Request.Context(), c.GetString("tenant_id"), c.Param("rNumber"))
+	if err != nil {
+		c.JSON(inventoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+func inventoryErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrItemNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrNotInStock), errors.Is(err, ErrInsufficientJoints), errors.Is(err, ErrSamePlace),
+		errors.Is(err, ErrOverAllocated):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,857 @@
+// backend/internal/inventory/models.go
+package inventory
+
+import (
+	"time"
+
+	"oilgas-backend/internal/invoice"
+	"oilgas-backend/internal/shared/weight"
+)
+
+// Item is one row of a tenant's pipe inventory: a count of joints of the same
+// size, grade and connection sitting in one rack
+type Item struct {
+	ID         int      `json:"id" db:"id"`
+	TenantID   string   `json:"tenant_id" db:"tenant_id"`
+	CustomerID *int     `json:"customer_id" db:"customer_id"`
+	Customer   *string  `json:"customer" db:"customer"`
+	WorkOrder  *string  `json:"work_order" db:"work_order"`
+	RNumber    *string  `json:"r_number" db:"r_number"`
+	Joints     int      `json:"joints" db:"joints"`
+	Size       *string  `json:"size" db:"size"`
+	Weight     *float64 `json:"weight" db:"weight"`
+	Grade      *string  `json:"grade" db:"grade"`
+	Connection *string  `json:"connection" db:"connection"`
+
+	Rack     *string `json:"rack" db:"rack"`
+	Location *string `json:"location" db:"location"`
+	Notes    *string `json:"notes" db:"notes"`
+
+	DateIn    *time.Time `json:"date_in" db:"date_in"`
+	DateOut   *time.Time `json:"date_out" db:"date_out"` // Set once the joints have left the yard
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// MovementType names what a ledger entry records
+type MovementType string
+
+const (
+	MovementMove    MovementType = "MOVE"    // Rack-to-rack or location-to-location within the yard
+	MovementShip    MovementType = "SHIP"    // Out of the yard on a shipment; Notes carries the bill of lading number
+	MovementReceive MovementType = "RECEIVE" // Into the yard from a received ticket; opens the row's ledger
+
+	MovementTransferOut MovementType = "TRANSFER_OUT" // Dated out to another yard; Notes carries the transfer number
+	MovementTransferIn  MovementType = "TRANSFER_IN"  // Racked from another yard, or returned by a cancelled transfer
+
+	MovementAdjust MovementType = "ADJUST" // Approved cycle count difference; a loss is from the rack, a gain to it
+)
+
+// Movement is an immutable ledger entry. A partial move splits the joints
+// off into a new inventory row, named by ToItemID.
+type Movement struct {
+	ID           int64        `json:"id" db:"id"`
+	TenantID     string       `json:"tenant_id" db:"tenant_id"`
+	MovementType MovementType `json:"movement_type" db:"movement_type"`
+
+	ItemID     int     `json:"inventory_item_id" db:"inventory_item_id"`
+	ToItemID   *int    `json:"to_inventory_item_id" db:"to_inventory_item_id"`
+	RNumber    *string `json:"r_number" db:"r_number"`
+	CustomerID *int    `json:"customer_id" db:"customer_id"`
+	Joints     int     `json:"joints" db:"joints"`
+
+	FromRack     *string `json:"from_rack" db:"from_rack"`
+	FromLocation *string `json:"from_location" db:"from_location"`
+	ToRack       *string `json:"to_rack" db:"to_rack"`
+	ToLocation   *string `json:"to_location" db:"to_location"`
+	Notes        *string `json:"notes" db:"notes"`
+	ReasonCode   *string `json:"reason_code" db:"reason_code"` // Set on adjustments
+
+	MovedByUserID int       `json:"moved_by_user_id" db:"moved_by_user_id"`
+	MovedAt       time.Time `json:"moved_at" db:"moved_at"`
+}
+
+// MoveRequest moves some or all of an item's joints. Joints of zero moves
+// them all; an empty ToLocation keeps the item's current location.
+type MoveRequest struct {
+	ItemID     int    `json:"-"`
+	Joints     int    `json:"joints"`
+	ToRack     string `json:"to_rack"`
+	ToLocation string `json:"to_location"`
+	Notes      string `json:"notes"`
+}
+
+// MoveResult is the ledger entry and the rows it touched
+type MoveResult struct {
+	Movement Movement `json:"movement"`
+	Source   Item     `json:"source"`
+	Split    *Item    `json:"split,omitempty"` // The new row on a partial move
+}
+
+// MovementFilters narrows ledger queries; at least one of ItemID and RNumber
+// is set
+type MovementFilters struct {
+	ItemID  *int
+	RNumber *string
+	Limit   int
+	Offset  int
+}
+
+// RNumberHistory answers where an R-number's joints are and have been
+type RNumberHistory struct {
+	RNumber   string     `json:"r_number"`
+	Items     []Item     `json:"items"`     // Current rows carrying the R-number
+	Movements []Movement `json:"movements"` // Oldest first
+}
+
+// ReservationStatus is where a hold on joints stands
+type ReservationStatus string
+
+const (
+	ReservationActive   ReservationStatus = "ACTIVE"
+	ReservationReleased ReservationStatus = "RELEASED"
+	ReservationConsumed ReservationStatus = "CONSUMED" // The joints shipped
+)
+
+// Reservation holds joints of one inventory row for a work order so they
+// cannot be promised to another job
+type Reservation struct {
+	ID              int               `json:"id" db:"id"`
+	TenantID        string            `json:"tenant_id" db:"tenant_id"`
+	ItemID          int               `json:"inventory_item_id" db:"inventory_item_id"`
+	WorkOrderID     int               `json:"workorder_id" db:"workorder_id"`
+	WorkOrderItemID *int              `json:"workorder_item_id" db:"workorder_item_id"`
+	Joints          int               `json:"joints" db:"joints"`
+	Status          ReservationStatus `json:"status" db:"status"`
+	Notes           *string           `json:"notes" db:"notes"`
+
+	ReservedByUserID int        `json:"reserved_by_user_id" db:"reserved_by_user_id"`
+	ReservedAt       time.Time  `json:"reserved_at" db:"reserved_at"`
+	ReleasedByUserID *int       `json:"released_by_user_id" db:"released_by_user_id"` // Nil when released by a cancellation
+	ReleasedAt       *time.Time `json:"released_at" db:"released_at"`
+	ReleaseReason    *string    `json:"release_reason" db:"release_reason"`
+}
+
+// ReserveRequest holds joints for a work order, optionally against the line
+// item they will be worked under
+type ReserveRequest struct {
+	ItemID          int    `json:"-"`
+	WorkOrderID     int    `json:"workorder_id"`
+	WorkOrderItemID *int   `json:"workorder_item_id"`
+	Joints          int    `json:"joints"`
+	Notes           string `json:"notes"`
+}
+
+// ReservationFilters narrows reservation queries; at least one of ItemID and
+// WorkOrderID is set
+type ReservationFilters struct {
+	ItemID      *int
+	WorkOrderID *int
+	ActiveOnly  bool
+}
+
+// Availability is an item's available-to-promise count: the joints in the
+// yard less those held by active reservations
+type Availability struct {
+	ItemID          int `json:"inventory_item_id"`
+	Joints          int `json:"joints"`
+	ReservedJoints  int `json:"reserved_joints"`
+	AvailableJoints int `json:"available_joints"`
+}
+
+// AvailableItem is an in-stock row with joints still free to promise
+type AvailableItem struct {
+	Item
+	ReservedJoints  int `json:"reserved_joints"`
+	AvailableJoints int `json:"available_joints"`
+}
+
+// Shipment is one load of pipe leaving the yard for a well or lease. Its
+// number is the bill of lading number.
+type Shipment struct {
+	ID             int     `json:"id" db:"id"`
+	TenantID       string  `json:"tenant_id" db:"tenant_id"`
+	ShipmentNumber string  `json:"shipment_number" db:"shipment_number"`
+	CustomerID     int     `json:"customer_id" db:"customer_id"`
+	Customer       *string `json:"customer" db:"customer"`
+
+	WellOut       *string `json:"well_out" db:"well_out"`
+	LeaseOut      *string `json:"lease_out" db:"lease_out"`
+	Carrier       *string `json:"carrier" db:"carrier"`
+	TruckNumber   *string `json:"truck_number" db:"truck_number"`
+	TrailerNumber *string `json:"trailer_number" db:"trailer_number"`
+	DriverName    *string `json:"driver_name" db:"driver_name"`
+	Notes         *string `json:"notes" db:"notes"`
+	TotalJoints   int     `json:"total_joints" db:"total_joints"`
+
+	ShippedByUserID int       `json:"shipped_by_user_id" db:"shipped_by_user_id"`
+	ShippedAt       time.Time `json:"shipped_at" db:"shipped_at"`
+
+	Lines []ShipmentLine `json:"lines"`
+}
+
+// ShipmentLine is the joints shipped from one inventory row, with the pipe
+// described as it was when it left
+type ShipmentLine struct {
+	ID            int  `json:"id" db:"id"`
+	ShipmentID    int  `json:"shipment_id" db:"shipment_id"`
+	ItemID        int  `json:"inventory_item_id" db:"inventory_item_id"`
+	ShippedItemID int  `json:"shipped_item_id" db:"shipped_item_id"` // The row marked out; a new row on a partial shipment
+	ReservationID *int `json:"reservation_id" db:"reservation_id"`
+
+	WorkOrder    *string  `json:"work_order" db:"work_order"`
+	RNumber      *string  `json:"r_number" db:"r_number"`
+	Joints       int      `json:"joints" db:"joints"`
+	Size         *string  `json:"size" db:"size"`
+	Weight       *float64 `json:"weight" db:"weight"`
+	Grade        *string  `json:"grade" db:"grade"`
+	Connection   *string  `json:"connection" db:"connection"`
+	FromRack     *string  `json:"from_rack" db:"from_rack"`
+	FromLocation *string  `json:"from_location" db:"from_location"`
+}
+
+// ShipRequest ships joints from one or more of a customer's inventory rows
+// to a well or lease
+type ShipRequest struct {
+	CustomerID    int               `json:"customer_id"`
+	WellOut       string            `json:"well_out"`
+	LeaseOut      string            `json:"lease_out"`
+	Carrier       string            `json:"carrier"`
+	TruckNumber   string            `json:"truck_number"`
+	TrailerNumber string            `json:"trailer_number"`
+	DriverName    string            `json:"driver_name"`
+	Notes         string            `json:"notes"`
+	Lines         []ShipLineRequest `json:"lines"`
+}
+
+// ShipLineRequest ships some or all of a row's joints; Joints of zero ships
+// them all. Joints reserved for a work order ship only against that
+// reservation.
+type ShipLineRequest struct {
+	ItemID        int  `json:"inventory_item_id"`
+	Joints        int  `json:"joints"`
+	ReservationID *int `json:"reservation_id"`
+}
+
+type ShipmentFilters struct {
+	CustomerID *int
+	Limit      int
+	Offset     int
+}
+
+// ReceivedTicket is a store.received row: what a customer said was coming in
+type ReceivedTicket struct {
+	ID           int        `json:"id" db:"id"`
+	TenantID     string     `json:"tenant_id" db:"tenant_id"`
+	WorkOrder    *string    `json:"work_order" db:"work_order"`
+	CustomerID   *int       `json:"customer_id" db:"customer_id"`
+	Customer     *string    `json:"customer" db:"customer"`
+	Joints       *int       `json:"joints" db:"joints"` // The ordered count
+	Size         *string    `json:"size" db:"size"`
+	Weight       *float64   `json:"weight" db:"weight"`
+	Grade        *string    `json:"grade" db:"grade"`
+	Connection   *string    `json:"connection" db:"connection"`
+	Well         *string    `json:"well" db:"well"`
+	Lease        *string    `json:"lease" db:"lease"`
+	OrderedBy    *string    `json:"ordered_by" db:"ordered_by"`
+	Notes        *string    `json:"notes" db:"notes"`
+	DateReceived *time.Time `json:"date_received" db:"date_received"`
+}
+
+// Receipt records a ticket's check-in: the joints counted against the
+// ordered count, and the inventory rows they were racked into
+type Receipt struct {
+	ID         int     `json:"id" db:"id"`
+	TenantID   string  `json:"tenant_id" db:"tenant_id"`
+	ReceivedID int     `json:"received_id" db:"received_id"`
+	CustomerID *int    `json:"customer_id" db:"customer_id"`
+	WorkOrder  *string `json:"work_order" db:"work_order"`
+	RNumber    *string `json:"r_number" db:"r_number"`
+
+	OrderedJoints     *int    `json:"ordered_joints" db:"ordered_joints"`
+	CountedJoints     int     `json:"counted_joints" db:"counted_joints"`
+	Discrepancy       *int    `json:"discrepancy" db:"discrepancy"` // Counted less ordered; nil when nothing was ordered
+	DiscrepancyReason *string `json:"discrepancy_reason" db:"discrepancy_reason"`
+	Notes             *string `json:"notes" db:"notes"`
+
+	ReceivedByUserID int       `json:"received_by_user_id" db:"received_by_user_id"`
+	ReceivedAt       time.Time `json:"received_at" db:"received_at"`
+
+	Lines []ReceiptLine `json:"lines"`
+}
+
+type ReceiptLine struct {
+	ID        int     `json:"id" db:"id"`
+	ReceiptID int     `json:"receipt_id" db:"receipt_id"`
+	ItemID    int     `json:"inventory_item_id" db:"inventory_item_id"`
+	Joints    int     `json:"joints" db:"joints"`
+	Rack      *string `json:"rack" db:"rack"`
+	Location  *string `json:"location" db:"location"`
+}
+
+// CheckInRequest checks a received ticket in. Each line is the joints
+// counted into one rack; a discrepancy reason is required when the total
+// differs from the ordered count. Size, Grade and Connection correct the
+// ticket where the pipe on the truck differs from the paperwork.
+type CheckInRequest struct {
+	ReceivedID        int           `json:"-"`
+	RNumber           string        `json:"r_number"`
+	Size              string        `json:"size"`
+	Grade             string        `json:"grade"`
+	Connection        string        `json:"connection"`
+	Lines             []CheckInLine `json:"lines"`
+	DiscrepancyReason string        `json:"discrepancy_reason"`
+	Notes             string        `json:"notes"`
+}
+
+type CheckInLine struct {
+	Joints   int    `json:"joints"`
+	Rack     string `json:"rack"`
+	Location string `json:"location"`
+}
+
+// CheckInResult is the receipt and the inventory rows it created
+type CheckInResult struct {
+	Receipt Receipt `json:"receipt"`
+	Items   []Item  `json:"items"`
+}
+
+// TransferDirection says which side of a transfer a yard's copy records
+type TransferDirection string
+
+const (
+	TransferOutbound TransferDirection = "OUTBOUND"
+	TransferInbound  TransferDirection = "INBOUND"
+)
+
+// TransferStatus tracks a transfer from dispatch to racking. An outbound
+// transfer is PENDING until the destination yard has its inbound copy.
+type TransferStatus string
+
+const (
+	TransferPending   TransferStatus = "PENDING"
+	TransferInTransit TransferStatus = "IN_TRANSIT"
+	TransferReceived  TransferStatus = "RECEIVED"
+	TransferCancelled TransferStatus = "CANCELLED"
+)
+
+// TransferOrder moves joints from one yard to another. The source and
+// destination yards each keep a copy, linked by TransferUID.
+type TransferOrder struct {
+	ID                  int               `json:"id" db:"id"`
+	TenantID            string            `json:"tenant_id" db:"tenant_id"`
+	TransferUID         string            `json:"transfer_uid" db:"transfer_uid"`
+	TransferNumber      string            `json:"transfer_number" db:"transfer_number"`
+	Direction           TransferDirection `json:"direction" db:"direction"`
+	SourceTenantID      string            `json:"source_tenant_id" db:"source_tenant_id"`
+	DestinationTenantID string            `json:"destination_tenant_id" db:"destination_tenant_id"`
+
+	CustomerID       int     `json:"customer_id" db:"customer_id"` // In this yard's customer ids
+	Customer         *string `json:"customer" db:"customer"`
+	RemoteCustomerID int     `json:"remote_customer_id" db:"remote_customer_id"`
+
+	Carrier     *string `json:"carrier" db:"carrier"`
+	TruckNumber *string `json:"truck_number" db:"truck_number"`
+	Notes       *string `json:"notes" db:"notes"`
+	TotalJoints int     `json:"total_joints" db:"total_joints"`
+
+	Status            TransferStatus `json:"status" db:"status"`
+	CreatedByUserID   *int           `json:"created_by_user_id" db:"created_by_user_id"`
+	DispatchedAt      time.Time      `json:"dispatched_at" db:"dispatched_at"`
+	DeliveredAt       *time.Time     `json:"delivered_at" db:"delivered_at"`
+	ReceivedByUserID  *int           `json:"received_by_user_id" db:"received_by_user_id"`
+	ReceivedAt        *time.Time     `json:"received_at" db:"received_at"`
+	CancelledByUserID *int           `json:"cancelled_by_user_id" db:"cancelled_by_user_id"`
+	CancelledAt       *time.Time     `json:"cancelled_at" db:"cancelled_at"`
+	CancelReason      *string        `json:"cancel_reason" db:"cancel_reason"`
+
+	// Set while a message for the other yard is still waiting to be
+	// delivered; DeliveryError is the relay's last failure
+	DeliveryPending bool    `json:"delivery_pending" db:"delivery_pending"`
+	DeliveryError   *string `json:"delivery_error" db:"delivery_error"`
+
+	Lines []TransferLine `json:"lines"`
+}
+
+type TransferLine struct {
+	ID              int  `json:"id" db:"id"`
+	TransferOrderID int  `json:"transfer_order_id" db:"transfer_order_id"`
+	LineNumber      int  `json:"line_number" db:"line_number"`
+	SourceItemID    int  `json:"source_item_id" db:"source_item_id"`
+	InTransitItemID *int `json:"in_transit_item_id" db:"in_transit_item_id"` // Source yard row dated out while the joints travel
+	ReceivedItemID  *int `json:"received_item_id" db:"received_item_id"`     // Destination yard row once racked
+
+	WorkOrder        *string  `json:"work_order" db:"work_order"`
+	RNumber          *string  `json:"r_number" db:"r_number"`
+	Joints           int      `json:"joints" db:"joints"`
+	Size             *string  `json:"size" db:"size"`
+	Weight           *float64 `json:"weight" db:"weight"`
+	Grade            *string  `json:"grade" db:"grade"`
+	Connection       *string  `json:"connection" db:"connection"`
+	FromRack         *string  `json:"from_rack" db:"from_rack"`
+	FromLocation     *string  `json:"from_location" db:"from_location"`
+	ReceivedRack     *string  `json:"received_rack" db:"received_rack"`
+	ReceivedLocation *string  `json:"received_location" db:"received_location"`
+}
+
+// TransferRequest sends one customer's joints to another yard. The customer
+// is named by its id in each yard, since customer ids are per tenant.
+type TransferRequest struct {
+	DestinationTenantID   string                `json:"destination_tenant_id"`
+	CustomerID            int                   `json:"customer_id"`
+	DestinationCustomerID int                   `json:"destination_customer_id"`
+	Carrier               string                `json:"carrier"`
+	TruckNumber           string                `json:"truck_number"`
+	Notes                 string                `json:"notes"`
+	Lines                 []TransferLineRequest `json:"lines"`
+}
+
+// TransferLineRequest transfers joints from one inventory row; Joints of
+// zero sends them all
+type TransferLineRequest struct {
+	ItemID int `json:"inventory_item_id"`
+	Joints int `json:"joints"`
+}
+
+// TransferReceiveRequest racks an inbound transfer; every line is given a
+// rack or location
+type TransferReceiveRequest struct {
+	TransferID int                   `json:"-"`
+	Lines      []TransferReceiveLine `json:"lines"`
+}
+
+type TransferReceiveLine struct {
+	LineID   int    `json:"line_id"`
+	Rack     string `json:"rack"`
+	Location string `json:"location"`
+}
+
+type CancelTransferRequest struct {
+	Reason string `json:"reason"`
+}
+
+type TransferFilters struct {
+	Direction *TransferDirection
+	Status    *TransferStatus
+	Limit     int
+	Offset    int
+}
+
+// CountStatus tracks a cycle count session. A rejected count goes back to
+// OPEN for a recount.
+type CountStatus string
+
+const (
+	CountOpen      CountStatus = "OPEN"
+	CountSubmitted CountStatus = "SUBMITTED"
+	CountApproved  CountStatus = "APPROVED"
+	CountCancelled CountStatus = "CANCELLED"
+)
+
+// AdjustmentReason explains a difference between the counted and expected
+// joints
+type AdjustmentReason string
+
+const (
+	ReasonMiscount           AdjustmentReason = "MISCOUNT" // The earlier count or entry was wrong
+	ReasonDamaged            AdjustmentReason = "DAMAGED"
+	ReasonScrapped           AdjustmentReason = "SCRAPPED"
+	ReasonFound              AdjustmentReason = "FOUND"
+	ReasonMisplaced          AdjustmentReason = "MISPLACED" // In another rack than the system says
+	ReasonUnrecordedShipment AdjustmentReason = "UNRECORDED_SHIPMENT"
+	ReasonUnrecordedReceipt  AdjustmentReason = "UNRECORDED_RECEIPT"
+	ReasonOther              AdjustmentReason = "OTHER"
+)
+
+// AdjustmentReasons lists the reason codes in display order
+var AdjustmentReasons = []AdjustmentReason{
+	ReasonMiscount, ReasonDamaged, ReasonScrapped, ReasonFound, ReasonMisplaced,
+	ReasonUnrecordedShipment, ReasonUnrecordedReceipt, ReasonOther,
+}
+
+// CountSession is a physical count of one rack, one location, or the whole
+// yard. Its lines are the rows that were in scope when it was opened.
+type CountSession struct {
+	ID       int         `json:"id" db:"id"`
+	TenantID string      `json:"tenant_id" db:"tenant_id"`
+	Rack     *string     `json:"rack" db:"rack"`         // Nil counts every rack
+	Location *string     `json:"location" db:"location"` // Nil counts every location
+	Status   CountStatus `json:"status" db:"status"`
+	Notes    *string     `json:"notes" db:"notes"`
+
+	OpenedByUserID    int        `json:"opened_by_user_id" db:"opened_by_user_id"`
+	OpenedAt          time.Time  `json:"opened_at" db:"opened_at"`
+	SubmittedByUserID *int       `json:"submitted_by_user_id" db:"submitted_by_user_id"`
+	SubmittedAt       *time.Time `json:"submitted_at" db:"submitted_at"`
+	ReviewedByUserID  *int       `json:"reviewed_by_user_id" db:"reviewed_by_user_id"`
+	ReviewedAt        *time.Time `json:"reviewed_at" db:"reviewed_at"`
+	RejectionReason   *string    `json:"rejection_reason" db:"rejection_reason"`
+
+	TotalLines    int `json:"total_lines" db:"total_lines"`
+	CountedLines  int `json:"counted_lines" db:"counted_lines"`
+	VarianceLines int `json:"variance_lines" db:"variance_lines"`
+
+	Lines []CountLine `json:"lines,omitempty"`
+}
+
+type CountLine struct {
+	ID        int     `json:"id" db:"id"`
+	SessionID int     `json:"session_id" db:"session_id"`
+	ItemID    int     `json:"inventory_item_id" db:"inventory_item_id"`
+	RNumber   *string `json:"r_number" db:"r_number"`
+	Rack      *string `json:"rack" db:"rack"`
+	Location  *string `json:"location" db:"location"`
+
+	ExpectedJoints  int               `json:"expected_joints" db:"expected_joints"`
+	CountedJoints   *int              `json:"counted_joints" db:"counted_joints"`
+	Variance        *int              `json:"variance" db:"variance"` // Counted less expected; nil until counted
+	ReasonCode      *AdjustmentReason `json:"reason_code" db:"reason_code"`
+	Notes           *string           `json:"notes" db:"notes"`
+	CountedByUserID *int              `json:"counted_by_user_id" db:"counted_by_user_id"`
+	CountedAt       *time.Time        `json:"counted_at" db:"counted_at"`
+	MovementID      *int64            `json:"movement_id" db:"movement_id"` // The approved adjustment
+}
+
+// OpenCountRequest scopes a new count; leaving both empty counts the yard
+type OpenCountRequest struct {
+	Rack     string `json:"rack"`
+	Location string `json:"location"`
+	Notes    string `json:"notes"`
+}
+
+// RecordCountsRequest enters counts against an open session, one or many at
+// a time as a handheld uploads them. Counting a row again replaces the
+// earlier count.
+type RecordCountsRequest struct {
+	SessionID int          `json:"-"`
+	Counts    []CountEntry `json:"counts"`
+}
+
+type CountEntry struct {
+	ItemID        int              `json:"inventory_item_id"`
+	CountedJoints int              `json:"counted_joints"`
+	ReasonCode    AdjustmentReason `json:"reason_code"`
+	Notes         string           `json:"notes"`
+}
+
+type RejectCountRequest struct {
+	Reason string `json:"reason"`
+}
+
+type CountSessionFilters struct {
+	Status *CountStatus
+	Limit  int
+	Offset int
+}
+
+// WeightLine is what an inventory row's weight is worked out from
+type WeightLine struct {
+	ItemID        int      `json:"inventory_item_id" db:"id"`
+	RNumber       *string  `json:"r_number" db:"r_number"`
+	CustomerID    *int     `json:"customer_id" db:"customer_id"`
+	Customer      *string  `json:"customer" db:"customer"`
+	Joints        int      `json:"joints" db:"joints"`
+	Size          *string  `json:"size" db:"size"`
+	Weight        *float64 `json:"weight" db:"weight"` // Weight per foot as typed in
+	Grade         *string  `json:"grade" db:"grade"`
+	Rack          *string  `json:"rack" db:"rack"`
+	Location      *string  `json:"location" db:"location"`
+	AverageLength *float64 `json:"average_joint_length" db:"average_joint_length"`
+	TalliedJoints int      `json:"tallied_joints"`
+	TalliedFeet   float64  `json:"tallied_feet"`
+}
+
+// ItemWeight is a row's calculated weight in the units the yard and its
+// customers ask for. MatchedSize is the size reference entry used, nil when
+// the row's size is not in the reference data.
+type ItemWeight struct {
+	WeightLine
+	weight.Result
+
+	MatchedSize    *string `json:"matched_size"`
+	WeightPerMeter float64 `json:"weight_per_meter"` // kg/m
+	TotalKilograms float64 `json:"total_kilograms"`
+	ShortTons      float64 `json:"short_tons"`
+	MetricTons     float64 `json:"metric_tons"`
+}
+
+// WeightSummary totals calculated weights. TotalWeight is in pounds and is
+// the figure InventorySummary.TotalWeight reports.
+type WeightSummary struct {
+	TotalItems     int     `json:"total_items"`
+	TotalJoints    int     `json:"total_joints"`
+	TotalFeet      float64 `json:"total_feet"`
+	TotalWeight    float64 `json:"total_weight"`
+	TotalKilograms float64 `json:"total_kilograms"`
+	ShortTons      float64 `json:"short_tons"`
+	MetricTons     float64 `json:"metric_tons"`
+
+	FlaggedItems       int `json:"flagged_items"`        // Entered weight far off the calculated one
+	EnteredItems       int `json:"entered_items"`        // Weighed on the entered weight; size not in the reference data
+	UnknownItems       int `json:"unknown_items"`        // No weight per foot at all; counted at zero
+	TalliedItems       int `json:"tallied_items"`        // Footage from a joint tally
+	DefaultLengthItems int `json:"default_length_items"` // Footage from the default joint length
+}
+
+// WeightFilters narrows the rows weighed. Size through Search match the
+// inventory list's filters so the inventory summary can weigh the rows it
+// counts.
+type WeightFilters struct {
+	CustomerID  *int
+	Rack        string
+	Location    string
+	Size        string
+	Grade       string
+	WorkOrder   string
+	DateFrom    *time.Time // On date_in
+	DateTo      *time.Time
+	Search      string // Customer, work order or notes
+	FlaggedOnly bool
+	Limit       int
+	Offset      int
+
+	// Only in-stock rows are weighed unless IncludeShipped is set; Available
+	// then picks in-stock (true) or shipped (false) rows, and nil weighs both
+	IncludeShipped bool
+	Available      *bool
+}
+
+// WeightCheckRequest checks a weight per foot before it is entered. Unit
+// defaults to lb/ft.
+type WeightCheckRequest struct {
+	Size          string            `json:"size"`
+	Grade         string            `json:"grade"`
+	Weight        *float64          `json:"weight"`
+	Unit          weight.LinearUnit `json:"unit"`
+	Joints        int               `json:"joints"`
+	AverageLength *float64          `json:"average_joint_length"`
+}
+
+// JointTally is one measured joint on an inventory row
+type JointTally struct {
+	ID              int       `json:"id" db:"id"`
+	ItemID          int       `json:"inventory_item_id" db:"inventory_item_id"`
+	JointNumber     int       `json:"joint_number" db:"joint_number"`
+	LengthFt        float64   `json:"length_ft" db:"length_ft"`
+	TalliedByUserID int       `json:"tallied_by_user_id" db:"tallied_by_user_id"`
+	TalliedAt       time.Time `json:"tallied_at" db:"tallied_at"`
+}
+
+// RecordTallyRequest replaces a row's tally with the given lengths in feet,
+// joint 1 first. A partial tally is allowed; the row's other joints are
+// weighed at its average.
+type RecordTallyRequest struct {
+	ItemID  int       `json:"-"`
+	Lengths []float64 `json:"lengths"`
+}
+
+// SetAverageLengthRequest records an average joint length on a row that
+// has not been tallied; nil clears it
+type SetAverageLengthRequest struct {
+	ItemID        int      `json:"-"`
+	AverageLength *float64 `json:"average_joint_length"`
+}
+
+// RateBasis is how a storage rate plan prices rack space
+type RateBasis string
+
+const (
+	RatePerJointDay RateBasis = "JOINT_DAY" // Rate × joints × days on rack
+	RatePerTonMonth RateBasis = "TON_MONTH" // Rate × short tons × the share of the month on rack
+)
+
+// RatePlan prices a customer's storage. The plan with no customer is the
+// yard's default, used for customers without a plan of their own.
+type RatePlan struct {
+	ID            int       `json:"id" db:"id"`
+	TenantID      string    `json:"tenant_id" db:"tenant_id"`
+	CustomerID    *int      `json:"customer_id" db:"customer_id"`
+	Name          string    `json:"name" db:"name"`
+	RateBasis     RateBasis `json:"rate_basis" db:"rate_basis"`
+	Rate          float64   `json:"rate" db:"rate"`
+	FreeDays      int       `json:"free_days" db:"free_days"`           // Days after date in that are not charged
+	MinimumCharge float64   `json:"minimum_charge" db:"minimum_charge"` // Per month, once anything is charged
+	Active        bool      `json:"active" db:"active"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// StorageLine is an inventory row as storage billing sees it: what it
+// weighs and when it came in and left
+type StorageLine struct {
+	WeightLine
+	WorkOrder *string    `json:"work_order" db:"work_order"`
+	DateIn    time.Time  `json:"date_in" db:"date_in"`
+	DateOut   *time.Time `json:"date_out" db:"date_out"`
+}
+
+// RackDays is how long a row has been on rack as of a date
+type RackDays struct {
+	ItemID     int        `json:"inventory_item_id"`
+	RNumber    *string    `json:"r_number"`
+	CustomerID *int       `json:"customer_id"`
+	Customer   *string    `json:"customer"`
+	Joints     int        `json:"joints"`
+	Rack       *string    `json:"rack"`
+	Location   *string    `json:"location"`
+	DateIn     time.Time  `json:"date_in"`
+	DateOut    *time.Time `json:"date_out"`
+	Days       int        `json:"days"`
+	Bucket     string     `json:"bucket"`
+}
+
+// StorageFilters selects the rows on rack as of a date; a zero AsOf is
+// today
+type StorageFilters struct {
+	CustomerID *int
+	AsOf       time.Time
+	Limit      int
+	Offset     int
+}
+
+// Aging buckets, by days on rack
+const (
+	Aging0To30  = "0-30"
+	Aging31To90 = "31-90"
+	AgingOver90 = "90+"
+)
+
+// AgingBucket totals the rows whose days on rack fall in one bucket
+type AgingBucket struct {
+	Bucket    string  `json:"bucket"`
+	Items     int     `json:"items"`
+	Joints    int     `json:"joints"`
+	ShortTons float64 `json:"short_tons"`
+}
+
+// CustomerAging is one customer's row of the aging report
+type CustomerAging struct {
+	CustomerID *int          `json:"customer_id"`
+	Customer   *string       `json:"customer"`
+	Buckets    []AgingBucket `json:"buckets"`
+}
+
+// AgingReport buckets the pipe on rack by how long it has been there
+type AgingReport struct {
+	AsOf      time.Time       `json:"as_of"`
+	Totals    []AgingBucket   `json:"totals"`
+	Customers []CustomerAging `json:"customers"`
+}
+
+// StorageCharge is one line of a customer's monthly storage charges.
+// InventoryItemID is nil on the line that tops the month up to the plan's
+// minimum.
+type StorageCharge struct {
+	ID                int       `json:"id" db:"id"`
+	TenantID          string    `json:"tenant_id" db:"tenant_id"`
+	PeriodStart       time.Time `json:"period_start" db:"period_start"`
+	CustomerID        int       `json:"customer_id" db:"customer_id"`
+	Customer          *string   `json:"customer"` // Loaded separately
+	RatePlanID        int       `json:"rate_plan_id" db:"rate_plan_id"`
+	ItemID            *int      `json:"inventory_item_id" db:"inventory_item_id"`
+	RNumber           *string   `json:"r_number" db:"r_number"`
+	Description       string    `json:"description" db:"description"`
+	Joints            int       `json:"joints" db:"joints"`
+	BillableDays      int       `json:"billable_days" db:"billable_days"`
+	ShortTons         *float64  `json:"short_tons" db:"short_tons"`
+	Quantity          float64   `json:"quantity" db:"quantity"`
+	UnitPrice         float64   `json:"unit_price" db:"unit_price"`
+	Amount            float64   `json:"amount" db:"amount"`
+	SortOrder         int       `json:"sort_order" db:"sort_order"`
+	GeneratedByUserID int       `json:"generated_by_user_id" db:"generated_by_user_id"`
+	GeneratedAt       time.Time `json:"generated_at" db:"generated_at"`
+}
+
+// StorageStatement is a customer's storage charges for one month, with
+// the invoice lines they bill as
+type StorageStatement struct {
+	CustomerID   int             `json:"customer_id"`
+	Customer     *string         `json:"customer"`
+	PeriodStart  time.Time       `json:"period_start"`
+	PeriodEnd    time.Time       `json:"period_end"` // Last day of the month
+	Charges      []StorageCharge `json:"charges"`
+	InvoiceLines []invoice.Line  `json:"invoice_lines"`
+	Total        float64         `json:"total"`
+}
+
+// StorageRun is a month's storage charges across customers. Unpriced lists
+// customers with pipe on rack but no rate plan and no default plan.
+type StorageRun struct {
+	PeriodStart time.Time          `json:"period_start"`
+	PeriodEnd   time.Time          `json:"period_end"`
+	Statements  []StorageStatement `json:"statements"`
+	Unpriced    []int              `json:"unpriced_customer_ids"`
+	Total       float64            `json:"total"`
+}
+
+// GenerateChargesRequest charges a finished month, "2006-01", for every
+// customer or just one
+type GenerateChargesRequest struct {
+	Period     string `json:"period"`
+	CustomerID *int   `json:"customer_id"`
+}
+
+// LedgerEntry is a movement read back to reconstruct past inventory. Loss
+// is set on an adjustment that took joints off its row.
+type LedgerEntry struct {
+	Movement
+	Loss bool `json:"loss"`
+}
+
+// Summary totals the pipe on hand, as models.InventorySummary does for the
+// legacy API. The count maps are joints per location, grade and size; rows
+// without one are left out of that map. Reservations are not on the ledger,
+// so a summary has no available items.
+type Summary struct {
+	TotalItems       int            `json:"total_items"`
+	TotalJoints      int            `json:"total_joints"`
+	TotalWeight      float64        `json:"total_weight"` // Pounds
+	ShortTons        float64        `json:"short_tons"`
+	UniqueCustomers  int            `json:"unique_customers"`
+	UniqueWorkOrders int            `json:"unique_work_orders"`
+	UniqueSizes      int            `json:"unique_sizes"`
+	UniqueGrades     int            `json:"unique_grades"`
+	LocationCounts   map[string]int `json:"location_counts"`
+	GradeCounts      map[string]int `json:"grade_counts"`
+	SizeCounts       map[string]int `json:"size_counts"`
+}
+
+// Snapshot is the summary of a day's closing inventory, for the whole yard
+// or one customer
+type Snapshot struct {
+	ID           int64     `json:"id" db:"id"`
+	TenantID     string    `json:"tenant_id" db:"tenant_id"`
+	SnapshotDate time.Time `json:"snapshot_date" db:"snapshot_date"`
+	CustomerID   *int      `json:"customer_id" db:"customer_id"` // Nil for the whole yard
+	Summary
+	TakenAt time.Time `json:"taken_at" db:"taken_at"`
+}
+
+// SnapshotFilters selects the snapshots of one customer, or of the whole
+// yard when CustomerID is nil, taken in [From, To]
+type SnapshotFilters struct {
+	CustomerID *int
+	From       time.Time
+	To         time.Time
+}
+
+// TrendPoint is one day of a snapshot trend, for charting
+type TrendPoint struct {
+	Date        time.Time `json:"date"`
+	TotalItems  int       `json:"total_items"`
+	TotalJoints int       `json:"total_joints"`
+	TotalWeight float64   `json:"total_weight"`
+	ShortTons   float64   `json:"short_tons"`
+}
+
+// InventoryAsOf is the inventory on hand at the close of a past day,
+// reconstructed from today's rows and the ledger since
+type InventoryAsOf struct {
+	AsOf       time.Time `json:"as_of"`
+	CustomerID *int      `json:"customer_id"`
+	Summary    Summary   `json:"summary"`
+}
+
+// TakeSnapshotsRequest retakes a closed day's snapshots, "2006-01-02"
+type TakeSnapshotsRequest struct {
+	Date string `json:"date"`
+}
@@ -0,0 +1,137 @@
+// backend/internal/inventory/receiving.go
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type ReceivingService interface {
+	GetPendingTickets(ctx context.Context, tenantID string, customerID *int) ([]ReceivedTicket, error)
+	GetReceipt(ctx context.Context, tenantID string, id int) (*Receipt, error)
+
+	// CheckIn converts a received ticket into racked inventory. A count that
+	// differs from the ordered joints needs a discrepancy reason.
+	CheckIn(ctx context.Context, tenantID string, userID int, req *CheckInRequest) (*CheckInResult, error)
+}
+
+// PipeValidator checks a pipe description against the reference data;
+// reference.Service satisfies it
+type PipeValidator interface {
+	ValidatePipe(ctx context.Context, tenantID, grade, size, connection string) error
+}
+
+type receivingService struct {
+	receiving ReceivingRepository
+	reference PipeValidator
+}
+
+func NewReceivingService(receiving ReceivingRepository, reference PipeValidator) ReceivingService {
+	return &receivingService{receiving: receiving, reference: reference}
+}
+
+func (s *receivingService) GetPendingTickets(ctx context.Context, tenantID string, customerID *int) ([]ReceivedTicket, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.receiving.GetPendingTickets(ctx, tenantID, customerID)
+}
+
+func (s *receivingService) GetReceipt(ctx context.Context, tenantID string, id int) (*Receipt, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.receiving.GetReceipt(ctx, tenantID, id)
+}
+
+func (s *receivingService) CheckIn(ctx context.Context, tenantID string, userID int, req *CheckInRequest) (*CheckInResult, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if err := validateCheckInRequest(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	// Racked pipe must be described in reference terms, whether the
+	// description comes from the ticket or from the check-in's corrections
+	ticket, err := s.receiving.GetTicket(ctx, tenantID, req.ReceivedID)
+	if err != nil {
+		return nil, err
+	}
+	grade, size, connection := req.Grade, req.Size, req.Connection
+	if grade == "" {
+		grade = derefString(ticket.Grade)
+	}
+	if size == "" {
+		size = derefString(ticket.Size)
+	}
+	if connection == "" {
+		connection = derefString(ticket.Connection)
+	}
+	if err := s.reference.ValidatePipe(ctx, tenantID, grade, size, connection); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	return s.receiving.CheckIn(ctx, tenantID, userID, req)
+}
+
+func validateCheckInRequest(req *CheckInRequest) error {
+	if req == nil {
+		return fmt.Errorf("check-in is required")
+	}
+	if req.ReceivedID <= 0 {
+		return fmt.Errorf("invalid received ticket ID: %d", req.ReceivedID)
+	}
+
+	req.RNumber = strings.TrimSpace(req.RNumber)
+	if len(req.RNumber) > 50 {
+		return fmt.Errorf("R-number too long (max 50 characters)")
+	}
+
+	req.Size = strings.TrimSpace(req.Size)
+	req.Grade = strings.ToUpper(strings.TrimSpace(req.Grade))
+	req.Connection = strings.ToUpper(strings.TrimSpace(req.Connection))
+	if len(req.Size) > 50 {
+		return fmt.Errorf("size too long (max 50 characters)")
+	}
+	if len(req.Grade) > 10 {
+		return fmt.Errorf("grade too long (max 10 characters)")
+	}
+	if len(req.Connection) > 50 {
+		return fmt.Errorf("connection too long (max 50 characters)")
+	}
+	if len(req.DiscrepancyReason) > 1000 || len(req.Notes) > 1000 {
+		return fmt.Errorf("discrepancy reason and notes are limited to 1000 characters")
+	}
+
+	if len(req.Lines) == 0 {
+		return fmt.Errorf("at least one counted line is required")
+	}
+	for i := range req.Lines {
+		line := &req.Lines[i]
+		if line.Joints <= 0 {
+			return fmt.Errorf("line %d: joints must be positive", i+1)
+		}
+		line.Rack = strings.TrimSpace(line.Rack)
+		line.Location = strings.TrimSpace(line.Location)
+		if line.Rack == "" && line.Location == "" {
+			return fmt.Errorf("line %d: a rack or location is required", i+1)
+		}
+		if len(line.Rack) > 50 {
+			return fmt.Errorf("line %d: rack too long (max 50 characters)", i+1)
+		}
+		if len(line.Location) > 100 {
+			return fmt.Errorf("line %d: location too long (max 100 characters)", i+1)
+		}
+	}
+
+	return nil
+}
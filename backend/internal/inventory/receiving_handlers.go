@@ -0,0 +1,112 @@
+// backend/internal/inventory/receiving_handlers.go
+package inventory
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"oilgas-backend/internal/auth"
+	"oilgas-backend/internal/reference"
+)
+
+type ReceivingHandlers struct {
+	service ReceivingService
+}
+
+func NewReceivingHandlers(service ReceivingService) *ReceivingHandlers {
+	return &ReceivingHandlers{service: service}
+}
+
+func (h *ReceivingHandlers) RegisterRoutes(router *gin.RouterGroup, authMiddleware *auth.Middleware) {
+	staff := authMiddleware.RequireRole(auth.RoleOperator, auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin)
+
+	receiving := router.Group("/receiving")
+	receiving.Use(authMiddleware.RequireAuth())
+	receiving.Use(staff)
+
+	receiving.GET("/pending", h.GetPendingTickets)
+	receiving.GET("/receipts/:id", h.GetReceipt)
+	receiving.POST("/:receivedId/check-in", h.CheckIn)
+}
+
+// GetPendingTickets lists received tickets waiting to be counted in,
+// optionally for one customer (?customer_id=)
+func (h *ReceivingHandlers) GetPendingTickets(c *gin.Context) {
+	var customerID *int
+	if raw := c.Query("customer_id"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid customer ID"})
+			return
+		}
+		customerID = &parsed
+	}
+
+	tickets, err := h.service.GetPendingTickets(c.Request.Context(), c.GetString("tenant_id"), customerID)
+	if err != nil {
+		c.JSON(receivingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  tickets,
+		"total": len(tickets),
+	})
+}
+
+func (h *ReceivingHandlers) GetReceipt(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid receipt ID"})
+		return
+	}
+
+	receipt, err := h.service.GetReceipt(c.Request.Context(), c.GetString("tenant_id"), id)
+	if err != nil {
+		c.JSON(receivingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, receipt)
+}
+
+// CheckIn records the joints counted off a received ticket and the racks
+// they went into
+func (h *ReceivingHandlers) CheckIn(c *gin.Context) {
+	receivedID, err := strconv.Atoi(c.Param("receivedId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid received ticket ID"})
+		return
+	}
+
+	var req CheckInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ReceivedID = receivedID
+
+	result, err := h.service.CheckIn(c.Request.Context(), c.GetString("tenant_id"), c.GetInt("user_id"), &req)
+	if err != nil {
+		c.JSON(receivingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+func receivingErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrReceivedNotFound), errors.Is(err, ErrReceiptNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrAlreadyCheckedIn):
+		return http.StatusConflict
+	case errors.Is(err, ErrDiscrepancyUnstated), errors.Is(err, reference.ErrUnknownValue):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,281 @@
+// backend/internal/inventory/receiving_repository.go
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"oilgas-backend/internal/shared/database"
+)
+
+type ReceivingRepository interface {
+	// GetPendingTickets lists received tickets that have not been checked in,
+	// oldest first
+	GetPendingTickets(ctx context.Context, tenantID string, customerID *int) ([]ReceivedTicket, error)
+	GetReceipt(ctx context.Context, tenantID string, id int) (*Receipt, error)
+	GetTicket(ctx context.Context, tenantID string, id int) (*ReceivedTicket, error)
+
+	// CheckIn records the count for a received ticket and racks the joints
+	// as new inventory rows, writing their ledger entries and
+	// inventory.received audit events in the same transaction
+	CheckIn(ctx context.Context, tenantID string, userID int, req *CheckInRequest) (*CheckInResult, error)
+}
+
+type receivingRepository struct {
+	dbManager *database.DatabaseManager
+}
+
+func NewReceivingRepository(dbManager *database.DatabaseManager) ReceivingRepository {
+	return &receivingRepository{dbManager: dbManager}
+}
+
+const receivedTicketColumns = `
+	id, tenant_id, work_order, customer_id, customer, joints, size, weight, grade,
+	connection, well, lease, ordered_by, notes, date_received`
+
+const receiptColumns = `
+	id, tenant_id, received_id, customer_id, work_order, r_number, ordered_joints, counted_joints,
+	discrepancy, discrepancy_reason, notes, received_by_user_id, received_at`
+
+func scanReceivedTicket(row rowScanner) (*ReceivedTicket, error) {
+	var t ReceivedTicket
+	err := row.Scan(
+		&t.ID, &t.TenantID, &t.WorkOrder, &t.CustomerID, &t.Customer, &t.Joints, &t.Size, &t.Weight, &t.Grade,
+		&t.Connection, &t.Well, &t.Lease, &t.OrderedBy, &t.Notes, &t.DateReceived,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func scanReceipt(row rowScanner) (*Receipt, error) {
+	var r Receipt
+	err := row.Scan(
+		&r.ID, &r.TenantID, &r.ReceivedID, &r.CustomerID, &r.WorkOrder, &r.RNumber, &r.OrderedJoints, &r.CountedJoints,
+		&r.Discrepancy, &r.DiscrepancyReason, &r.Notes, &r.ReceivedByUserID, &r.ReceivedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (r *receivingRepository) GetPendingTickets(ctx context.Context, tenantID string, customerID *int) ([]ReceivedTicket, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	query := `
+		SELECT` + receivedTicketColumns + `
+		FROM store.received rc
+		WHERE tenant_id = $1 AND deleted = false
+		  AND NOT EXISTS (SELECT 1 FROM store.receipts rp WHERE rp.tenant_id = rc.tenant_id AND rp.received_id = rc.id)`
+	args := []interface{}{tenantID}
+
+	if customerID != nil {
+		args = append(args, *customerID)
+		query += fmt.Sprintf(" AND customer_id = $%d", len(args))
+	}
+	query += " ORDER BY date_received NULLS LAST, id"
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get received tickets: %w", err)
+	}
+	defer rows.Close()
+
+	tickets := []ReceivedTicket{}
+	for rows.Next() {
+		ticket, err := scanReceivedTicket(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan received ticket: %w", err)
+		}
+		tickets = append(tickets, *ticket)
+	}
+
+	return tickets, rows.Err()
+}
+
+func (r *receivingRepository) GetReceipt(ctx context.Context, tenantID string, id int) (*Receipt, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	receipt, err := scanReceipt(db.QueryRowContext(ctx, `
+		SELECT`+receiptColumns+`
+		FROM store.receipts
+		WHERE id = $1 AND tenant_id = $2`, id, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrReceiptNotFound
+		}
+		return nil, fmt.Errorf("failed to get receipt: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, receipt_id, inventory_item_id, joints, rack, location
+		FROM store.receipt_lines
+		WHERE receipt_id = $1
+		ORDER BY id`, receipt.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipt lines: %w", err)
+	}
+	defer rows.Close()
+
+	receipt.Lines = []ReceiptLine{}
+	for rows.Next() {
+		var line ReceiptLine
+		if err := rows.Scan(&line.ID, &line.ReceiptID, &line.ItemID, &line.Joints, &line.Rack, &line.Location); err != nil {
+			return nil, fmt.Errorf("failed to scan receipt line: %w", err)
+		}
+		receipt.Lines = append(receipt.Lines, line)
+	}
+
+	return receipt, rows.Err()
+}
+
+func (r *receivingRepository) GetTicket(ctx context.Context, tenantID string, id int) (*ReceivedTicket, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	ticket, err := scanReceivedTicket(db.QueryRowContext(ctx, `
+		SELECT`+receivedTicketColumns+`
+		FROM store.received
+		WHERE id = $1 AND tenant_id = $2 AND deleted = false`, id, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrReceivedNotFound
+		}
+		return nil, fmt.Errorf("failed to get received ticket: %w", err)
+	}
+
+	return ticket, nil
+}
+
+func (r *receivingRepository) CheckIn(ctx context.Context, tenantID string, userID int, req *CheckInRequest) (*CheckInResult, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Locking the ticket serialises two operators checking in the same load
+	ticket, err := scanReceivedTicket(tx.QueryRowContext(ctx, `
+		SELECT`+receivedTicketColumns+`
+		FROM store.received
+		WHERE id = $1 AND tenant_id = $2 AND deleted = false
+		FOR UPDATE`, req.ReceivedID, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrReceivedNotFound
+		}
+		return nil, fmt.Errorf("failed to lock received ticket: %w", err)
+	}
+
+	var checkedIn bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM store.receipts WHERE tenant_id = $1 AND received_id = $2)`,
+		tenantID, ticket.ID).Scan(&checkedIn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check receipt: %w", err)
+	}
+	if checkedIn {
+		return nil, ErrAlreadyCheckedIn
+	}
+
+	counted := 0
+	for _, line := range req.Lines {
+		counted += line.Joints
+	}
+	reason := nullableString(req.DiscrepancyReason)
+	if ticket.Joints != nil && *ticket.Joints != counted && reason == nil {
+		return nil, fmt.Errorf("%w: %d ordered, %d counted", ErrDiscrepancyUnstated, *ticket.Joints, counted)
+	}
+
+	rNumber := nullableString(req.RNumber)
+	receipt, err := scanReceipt(tx.QueryRowContext(ctx, `
+		INSERT INTO store.receipts (
+			tenant_id, received_id, customer_id, work_order, r_number, ordered_joints, counted_joints,
+			discrepancy_reason, notes, received_by_user_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING`+receiptColumns,
+		tenantID, ticket.ID, ticket.CustomerID, ticket.WorkOrder, rNumber, ticket.Joints, counted,
+		reason, nullableString(req.Notes), userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create receipt: %w", err)
+	}
+
+	size, grade, connection := nullableString(req.Size), nullableString(req.Grade), nullableString(req.Connection)
+	result := &CheckInResult{Items: []Item{}}
+	receipt.Lines = []ReceiptLine{}
+	for _, line := range req.Lines {
+		rack, location := nullableString(line.Rack), nullableString(line.Location)
+
+		item, err := scanItem(tx.QueryRowContext(ctx, `
+			INSERT INTO store.inventory (
+				tenant_id, customer_id, customer, work_order, r_number, joints,
+				size, weight, grade, connection, date_in, well_in, lease_in,
+				rack, location, notes, deleted, created_at
+			)
+			SELECT tenant_id, customer_id, customer, work_order, $2, $3,
+			       COALESCE($6, size), weight, COALESCE($7, grade), COALESCE($8, connection),
+			       CURRENT_DATE, well, lease, $4, $5, notes, false, NOW()
+			FROM store.received WHERE id = $1
+			RETURNING`+itemColumns, ticket.ID, rNumber, line.Joints, rack, location,
+			size, grade, connection))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create inventory item: %w", err)
+		}
+
+		var receiptLine ReceiptLine
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO store.receipt_lines (receipt_id, inventory_item_id, joints, rack, location)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id, receipt_id, inventory_item_id, joints, rack, location`,
+			receipt.ID, item.ID, line.Joints, rack, location,
+		).Scan(&receiptLine.ID, &receiptLine.ReceiptID, &receiptLine.ItemID, &receiptLine.Joints, &receiptLine.Rack, &receiptLine.Location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record receipt line: %w", err)
+		}
+
+		movement := Movement{
+			TenantID:      tenantID,
+			MovementType:  MovementReceive,
+			ItemID:        item.ID,
+			RNumber:       item.RNumber,
+			CustomerID:    item.CustomerID,
+			Joints:        line.Joints,
+			ToRack:        rack,
+			ToLocation:    location,
+			Notes:         nullableString(fmt.Sprintf("received ticket %d", ticket.ID)),
+			MovedByUserID: userID,
+		}
+		if err := insertMovementTx(ctx, tx, &movement); err != nil {
+			return nil, err
+		}
+		if err := logInventoryEventTx(ctx, tx, "inventory.received", &movement); err != nil {
+			return nil, err
+		}
+
+		receipt.Lines = append(receipt.Lines, receiptLine)
+		result.Items = append(result.Items, *item)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit check-in: %w", err)
+	}
+
+	result.Receipt = *receipt
+	return result, nil
+}
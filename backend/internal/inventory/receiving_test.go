@@ -0,0 +1,148 @@
+// backend/internal/inventory/receiving_test.go
+package inventory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type mockReceivingRepository struct {
+	mock.Mock
+}
+
+func (m *mockReceivingRepository) GetPendingTickets(ctx context.Context, tenantID string, customerID *int) ([]ReceivedTicket, error) {
+	args := m.Called(ctx, tenantID, customerID)
+	return args.Get(0).([]ReceivedTicket), args.Error(1)
+}
+
+func (m *mockReceivingRepository) GetReceipt(ctx context.Context, tenantID string, id int) (*Receipt, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Receipt), args.Error(1)
+}
+
+func (m *mockReceivingRepository) GetTicket(ctx context.Context, tenantID string, id int) (*ReceivedTicket, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ReceivedTicket), args.Error(1)
+}
+
+func (m *mockReceivingRepository) CheckIn(ctx context.Context, tenantID string, userID int, req *CheckInRequest) (*CheckInResult, error) {
+	args := m.Called(ctx, tenantID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*CheckInResult), args.Error(1)
+}
+
+type mockPipeValidator struct {
+	mock.Mock
+}
+
+func (m *mockPipeValidator) ValidatePipe(ctx context.Context, tenantID, grade, size, connection string) error {
+	return m.Called(ctx, tenantID, grade, size, connection).Error(0)
+}
+
+type ReceivingServiceTestSuite struct {
+	suite.Suite
+	service   ReceivingService
+	receiving *mockReceivingRepository
+	reference *mockPipeValidator
+	ctx       context.Context
+	tenantID  string
+	ticket    *ReceivedTicket
+}
+
+func (suite *ReceivingServiceTestSuite) SetupTest() {
+	suite.receiving = &mockReceivingRepository{}
+	suite.reference = &mockPipeValidator{}
+	suite.service = NewReceivingService(suite.receiving, suite.reference)
+	suite.ctx = context.Background()
+	suite.tenantID = "longbeach"
+	suite.ticket = &ReceivedTicket{ID: 88, TenantID: suite.tenantID, Size: strPtr(`5 1/2"`), Grade: strPtr("L80"), Connection: strPtr("LTC")}
+	suite.receiving.On("GetTicket", suite.ctx, suite.tenantID, 88).Return(suite.ticket, nil).Maybe()
+}
+
+func TestReceivingServiceSuite(t *testing.T) {
+	suite.Run(t, new(ReceivingServiceTestSuite))
+}
+
+func (suite *ReceivingServiceTestSuite) TestCheckIn_NormalizesLines() {
+	req := &CheckInRequest{
+		ReceivedID: 88,
+		RNumber:    " R-2001 ",
+		Lines: []CheckInLine{
+			{Joints: 60, Rack: " A-12 ", Location: "North"},
+			{Joints: 38, Rack: "A-13"},
+		},
+		DiscrepancyReason: "two joints short on the truck",
+	}
+	result := &CheckInResult{Receipt: Receipt{ID: 4, ReceivedID: 88, CountedJoints: 98}}
+	suite.reference.On("ValidatePipe", suite.ctx, suite.tenantID, "L80", `5 1/2"`, "LTC").Return(nil)
+	suite.receiving.On("CheckIn", suite.ctx, suite.tenantID, 7, req).Return(result, nil)
+
+	got, err := suite.service.CheckIn(suite.ctx, suite.tenantID, 7, req)
+
+	suite.NoError(err)
+	suite.Equal(result, got)
+	suite.Equal("R-2001", req.RNumber)
+	suite.Equal("A-12", req.Lines[0].Rack)
+}
+
+func (suite *ReceivingServiceTestSuite) TestCheckIn_RejectsInvalidRequests() {
+	testCases := []struct {
+		name string
+		req  *CheckInRequest
+	}{
+		{"missing request", nil},
+		{"missing ticket", &CheckInRequest{Lines: []CheckInLine{{Joints: 10, Rack: "A-1"}}}},
+		{"no lines", &CheckInRequest{ReceivedID: 88}},
+		{"zero joints", &CheckInRequest{ReceivedID: 88, Lines: []CheckInLine{{Rack: "A-1"}}}},
+		{"no rack or location", &CheckInRequest{ReceivedID: 88, Lines: []CheckInLine{{Joints: 10, Rack: "  "}}}},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			_, err := suite.service.CheckIn(suite.ctx, suite.tenantID, 7, tc.req)
+
+			assert.Error(suite.T(), err)
+			assert.Contains(suite.T(), err.Error(), "validation failed")
+		})
+	}
+
+	_, err := suite.service.CheckIn(suite.ctx, suite.tenantID, 0, &CheckInRequest{ReceivedID: 88, Lines: []CheckInLine{{Joints: 10, Rack: "A-1"}}})
+	suite.Error(err)
+
+	suite.receiving.AssertNotCalled(suite.T(), "CheckIn")
+}
+
+func (suite *ReceivingServiceTestSuite) TestCheckIn_UnstatedDiscrepancy() {
+	req := &CheckInRequest{ReceivedID: 88, Lines: []CheckInLine{{Joints: 98, Rack: "A-12"}}}
+	suite.reference.On("ValidatePipe", suite.ctx, suite.tenantID, "L80", `5 1/2"`, "LTC").Return(nil)
+	suite.receiving.On("CheckIn", suite.ctx, suite.tenantID, 7, req).Return(nil, ErrDiscrepancyUnstated)
+
+	_, err := suite.service.CheckIn(suite.ctx, suite.tenantID, 7, req)
+
+	suite.ErrorIs(err, ErrDiscrepancyUnstated)
+}
+
+func (suite *ReceivingServiceTestSuite) TestCheckIn_ValidatesCorrectedDescription() {
+	errUnknown := errors.New(`grade "L-80" is not in the reference data (did you mean L80?)`)
+	req := &CheckInRequest{ReceivedID: 88, Grade: " l-80 ", Connection: "btc", Lines: []CheckInLine{{Joints: 98, Rack: "A-12"}}}
+	suite.reference.On("ValidatePipe", suite.ctx, suite.tenantID, "L-80", `5 1/2"`, "BTC").Return(errUnknown)
+
+	_, err := suite.service.CheckIn(suite.ctx, suite.tenantID, 7, req)
+
+	suite.ErrorIs(err, errUnknown)
+	suite.Contains(err.Error(), "validation failed")
+	suite.receiving.AssertNotCalled(suite.T(), "CheckIn")
+}
@@ -0,0 +1,354 @@
+// backend/internal/inventory/repository.go
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"oilgas-backend/internal/shared/database"
+)
+
+type Repository interface {
+	GetItem(ctx context.Context, tenantID string, id int) (*Item, error)
+	GetItemsByRNumber(ctx context.Context, tenantID, rNumber string) ([]Item, error)
+	GetMovements(ctx context.Context, tenantID string, filters MovementFilters) ([]Movement, error)
+
+	// MoveItem relocates joints and writes the ledger entry in one
+	// transaction, splitting the row when only part of it moves
+	MoveItem(ctx context.Context, tenantID string, userID int, req *MoveRequest) (*MoveResult, error)
+}
+
+type repository struct {
+	dbManager *database.DatabaseManager
+}
+
+func NewRepository(dbManager *database.DatabaseManager) Repository {
+	return &repository{dbManager: dbManager}
+}
+
+const itemColumns = `
+	id, tenant_id, customer_id, customer, work_order, r_number, COALESCE(joints, 0),
+	size, weight, grade, connection, rack, location, notes, date_in, date_out, created_at`
+
+const movementColumns = `
+	id, tenant_id, movement_type, inventory_item_id, to_inventory_item_id, r_number, customer_id, joints,
+	from_rack, from_location, to_rack, to_location, notes, reason_code, moved_by_user_id, moved_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanItem(row rowScanner) (*Item, error) {
+	var item Item
+	err := row.Scan(
+		&item.ID, &item.TenantID, &item.CustomerID, &item.Customer, &item.WorkOrder, &item.RNumber, &item.Joints,
+		&item.Size, &item.Weight, &item.Grade, &item.Connection, &item.Rack, &item.Location, &item.Notes,
+		&item.DateIn, &item.DateOut, &item.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func scanMovement(row rowScanner) (*Movement, error) {
+	var m Movement
+	err := row.Scan(
+		&m.ID, &m.TenantID, &m.MovementType, &m.ItemID, &m.ToItemID, &m.RNumber, &m.CustomerID, &m.Joints,
+		&m.FromRack, &m.FromLocation, &m.ToRack, &m.ToLocation, &m.Notes, &m.ReasonCode, &m.MovedByUserID, &m.MovedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (r *repository) GetItem(ctx context.Context, tenantID string, id int) (*Item, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	item, err := scanItem(db.QueryRowContext(ctx, `
+		SELECT`+itemColumns+`
+		FROM store.inventory
+		WHERE id = $1 AND tenant_id = $2 AND deleted = false`, id, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to get inventory item: %w", err)
+	}
+
+	return item, nil
+}
+
+func (r *repository) GetItemsByRNumber(ctx context.Context, tenantID, rNumber string) ([]Item, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT`+itemColumns+`
+		FROM store.inventory
+		WHERE tenant_id = $1 AND r_number = $2 AND deleted = false
+		ORDER BY id`, tenantID, rNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []Item{}
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan inventory item: %w", err)
+		}
+		items = append(items, *item)
+	}
+
+	return items, rows.Err()
+}
+
+func (r *repository) GetMovements(ctx context.Context, tenantID string, filters MovementFilters) ([]Movement, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	conditions := []string{"tenant_id = $1"}
+	args := []interface{}{tenantID}
+
+	if filters.ItemID != nil {
+		args = append(args, *filters.ItemID)
+		conditions = append(conditions, fmt.Sprintf("(inventory_item_id = $%d OR to_inventory_item_id = $%d)", len(args), len(args)))
+	}
+	if filters.RNumber != nil {
+		args = append(args, *filters.RNumber)
+		conditions = append(conditions, fmt.Sprintf("r_number = $%d", len(args)))
+	}
+
+	query := `
+		SELECT` + movementColumns + `
+		FROM store.inventory_movements
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY moved_at, id`
+
+	if filters.Limit > 0 {
+		args = append(args, filters.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filters.Offset > 0 {
+		args = append(args, filters.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory movements: %w", err)
+	}
+	defer rows.Close()
+
+	movements := []Movement{}
+	for rows.Next() {
+		m, err := scanMovement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan inventory movement: %w", err)
+		}
+		movements = append(movements, *m)
+	}
+
+	return movements, rows.Err()
+}
+
+func (r *repository) MoveItem(ctx context.Context, tenantID string, userID int, req *MoveRequest) (*MoveResult, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	source, err := lockItemTx(ctx, tx, tenantID, req.ItemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if source.DateOut != nil {
+		return nil, ErrNotInStock
+	}
+
+	joints := req.Joints
+	if joints == 0 {
+		joints = source.Joints
+	}
+	if joints <= 0 || joints > source.Joints {
+		return nil, fmt.Errorf("%w: %d requested, %d in rack", ErrInsufficientJoints, joints, source.Joints)
+	}
+
+	// Reservations stay with the source row, so a partial move may only take
+	// joints nobody has been promised
+	if joints < source.Joints {
+		reserved, err := reservedJointsTx(ctx, tx, source.ID)
+		if err != nil {
+			return nil, err
+		}
+		if available := source.Joints - reserved; joints > available {
+			return nil, fmt.Errorf("%w: %d requested, %d available", ErrOverAllocated, joints, available)
+		}
+	}
+
+	toRack := nullableString(req.ToRack)
+	toLocation := source.Location
+	if req.ToLocation != "" {
+		toLocation = nullableString(req.ToLocation)
+	}
+	if derefString(source.Rack) == derefString(toRack) && derefString(source.Location) == derefString(toLocation) {
+		return nil, ErrSamePlace
+	}
+
+	movement := Movement{
+		TenantID:      tenantID,
+		MovementType:  MovementMove,
+		ItemID:        source.ID,
+		RNumber:       source.RNumber,
+		CustomerID:    source.CustomerID,
+		Joints:        joints,
+		FromRack:      source.Rack,
+		FromLocation:  source.Location,
+		ToRack:        toRack,
+		ToLocation:    toLocation,
+		Notes:         nullableString(req.Notes),
+		MovedByUserID: userID,
+	}
+	result := &MoveResult{}
+
+	if joints == source.Joints {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE store.inventory SET rack = $1, location = $2
+			WHERE id = $3`, toRack, toLocation, source.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to move inventory item: %w", err)
+		}
+		source.Rack, source.Location = toRack, toLocation
+	} else {
+		split, err := splitItemTx(ctx, tx, source, joints, toRack, toLocation)
+		if err != nil {
+			return nil, err
+		}
+		source.Joints -= joints
+		movement.ToItemID = &split.ID
+		result.Split = split
+	}
+
+	if err := insertMovementTx(ctx, tx, &movement); err != nil {
+		return nil, err
+	}
+
+	if err := logInventoryEventTx(ctx, tx, "inventory.moved", &movement); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit inventory move: %w", err)
+	}
+
+	result.Movement = movement
+	result.Source = *source
+	return result, nil
+}
+
+// lockItemTx loads an in-stock or shipped inventory row and locks it for the
+// rest of the transaction
+func lockItemTx(ctx context.Context, tx *sql.Tx, tenantID string, id int) (*Item, error) {
+	item, err := scanItem(tx.QueryRowContext(ctx, `
+		SELECT`+itemColumns+`
+		FROM store.inventory
+		WHERE id = $1 AND tenant_id = $2 AND deleted = false
+		FOR UPDATE`, id, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to lock inventory item: %w", err)
+	}
+	return item, nil
+}
+
+// splitItemTx takes joints off source into a new row at the given rack and
+// location; everything describing the pipe itself is copied
+func splitItemTx(ctx context.Context, tx *sql.Tx, source *Item, joints int, rack, location *string) (*Item, error) {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE store.inventory SET joints = joints - $1 WHERE id = $2`, joints, source.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to split inventory item: %w", err)
+	}
+
+	split, err := scanItem(tx.QueryRowContext(ctx, `
+		INSERT INTO store.inventory (
+			tenant_id, customer_id, customer, work_order, r_number, joints,
+			size, weight, grade, connection, average_joint_length, date_in, well_in, lease_in,
+			rack, location, notes, deleted, created_at
+		)
+		SELECT tenant_id, customer_id, customer, work_order, r_number, $2,
+		       size, weight, grade, connection, average_joint_length, date_in, well_in, lease_in,
+		       $3, $4, notes, false, NOW()
+		FROM store.inventory WHERE id = $1
+		RETURNING`+itemColumns, source.ID, joints, rack, location))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create split inventory item: %w", err)
+	}
+
+	return split, nil
+}
+
+func insertMovementTx(ctx context.Context, tx *sql.Tx, m *Movement) error {
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO store.inventory_movements (
+			tenant_id, movement_type, inventory_item_id, to_inventory_item_id, r_number, customer_id, joints,
+			from_rack, from_location, to_rack, to_location, notes, reason_code, moved_by_user_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id, moved_at`,
+		m.TenantID, m.MovementType, m.ItemID, m.ToItemID, m.RNumber, m.CustomerID, m.Joints,
+		m.FromRack, m.FromLocation, m.ToRack, m.ToLocation, m.Notes, m.ReasonCode, m.MovedByUserID,
+	).Scan(&m.ID, &m.MovedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record inventory movement: %w", err)
+	}
+	return nil
+}
+
+// logInventoryEventTx writes the audit event behind audit.inventory_trail,
+// keyed by the row the joints left
+func logInventoryEventTx(ctx context.Context, tx *sql.Tx, eventType string, m *Movement) error {
+	data, err := json.Marshal(map[string]interface{}{
+		"movement_id":          m.ID,
+		"joints":               m.Joints,
+		"r_number":             m.RNumber,
+		"from_rack":            m.FromRack,
+		"from_location":        m.FromLocation,
+		"rack":                 m.ToRack,
+		"location":             m.ToLocation,
+		"to_inventory_item_id": m.ToItemID,
+		"reason_code":          m.ReasonCode,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode inventory event: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		SELECT audit.log_event($1, $2, 'inventory', $3, $4, $5::jsonb)`,
+		eventType, m.TenantID, fmt.Sprintf("%d", m.ItemID), m.MovedByUserID, string(data))
+	if err != nil {
+		return fmt.Errorf("failed to log inventory event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// backend/internal/inventory/reservation.go
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type ReservationService interface {
+	// Reserve holds joints for a work order, failing with ErrOverAllocated
+	// when the row does not have that many unreserved joints
+	Reserve(ctx context.Context, tenantID string, userID int, req *ReserveRequest) (*Reservation, error)
+	Release(ctx context.Context, tenantID string, userID, id int, reason string) (*Reservation, error)
+
+	GetItemReservations(ctx context.Context, tenantID string, itemID int, activeOnly bool) ([]Reservation, error)
+	GetWorkOrderReservations(ctx context.Context, tenantID string, workOrderID int, activeOnly bool) ([]Reservation, error)
+	GetAvailability(ctx context.Context, tenantID string, itemID int) (*Availability, error)
+	GetAvailableItems(ctx context.Context, tenantID string, customerID *int) ([]AvailableItem, error)
+}
+
+type reservationService struct {
+	reservations ReservationRepository
+}
+
+func NewReservationService(reservations ReservationRepository) ReservationService {
+	return &reservationService{reservations: reservations}
+}
+
+func (s *reservationService) Reserve(ctx context.Context, tenantID string, userID int, req *ReserveRequest) (*Reservation, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if err := validateReserveRequest(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	return s.reservations.Reserve(ctx, tenantID, userID, req)
+}
+
+func (s *reservationService) Release(ctx context.Context, tenantID string, userID, id int, reason string) (*Reservation, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	reason = strings.TrimSpace(reason)
+	if len(reason) > 255 {
+		return nil, fmt.Errorf("validation failed: release reason too long (max 255 characters)")
+	}
+
+	return s.reservations.Release(ctx, tenantID, userID, id, reason)
+}
+
+func (s *reservationService) GetItemReservations(ctx context.Context, tenantID string, itemID int, activeOnly bool) ([]Reservation, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.reservations.GetReservations(ctx, tenantID, ReservationFilters{ItemID: &itemID, ActiveOnly: activeOnly})
+}
+
+func (s *reservationService) GetWorkOrderReservations(ctx context.Context, tenantID string, workOrderID int, activeOnly bool) ([]Reservation, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.reservations.GetReservations(ctx, tenantID, ReservationFilters{WorkOrderID: &workOrderID, ActiveOnly: activeOnly})
+}
+
+func (s *reservationService) GetAvailability(ctx context.Context, tenantID string, itemID int) (*Availability, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.reservations.GetAvailability(ctx, tenantID, itemID)
+}
+
+func (s *reservationService) GetAvailableItems(ctx context.Context, tenantID string, customerID *int) ([]AvailableItem, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.reservations.GetAvailableItems(ctx, tenantID, customerID)
+}
+
+func validateReserveRequest(req *ReserveRequest) error {
+	if req == nil {
+		return fmt.Errorf("reservation is required")
+	}
+	if req.ItemID <= 0 {
+		return fmt.Errorf("invalid inventory item ID: %d", req.ItemID)
+	}
+	if req.WorkOrderID <= 0 {
+		return fmt.Errorf("invalid work order ID: %d", req.WorkOrderID)
+	}
+	if req.WorkOrderItemID != nil && *req.WorkOrderItemID <= 0 {
+		return fmt.Errorf("invalid work order item ID: %d", *req.WorkOrderItemID)
+	}
+	if req.Joints <= 0 {
+		return fmt.Errorf("joints must be positive")
+	}
+	if len(req.Notes) > 1000 {
+		return fmt.Errorf("notes too long (max 1000 characters)")
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+// backend/internal/inventory/reservation_handlers.go
+package inventory
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"oilgas-backend/internal/auth"
+)
+
+type ReservationHandlers struct {
+	service ReservationService
+}
+
+func NewReservationHandlers(service ReservationService) *ReservationHandlers {
+	return &ReservationHandlers{service: service}
+}
+
+func (h *ReservationHandlers) RegisterRoutes(router *gin.RouterGroup, authMiddleware *auth.Middleware) {
+	staff := authMiddleware.RequireRole(auth.RoleOperator, auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin)
+
+	inventory := router.Group("/inventory")
+	inventory.Use(authMiddleware.RequireAuth())
+	inventory.Use(staff)
+
+	inventory.GET("/available", h.GetAvailableItems)
+	inventory.GET("/reservations", h.GetWorkOrderReservations)
+	inventory.POST("/reservations/:reservationId/release", h.Release)
+	inventory.GET("/:id/availability", h.GetAvailability)
+	inventory.GET("/:id/reservations", h.GetItemReservations)
+	inventory.POST("/:id/reservations", h.Reserve)
+}
+
+// GetAvailableItems lists in-stock rows with joints free to promise,
+// optionally for one customer (?customer_id=)
+func (h *ReservationHandlers) GetAvailableItems(c *gin.Context) {
+	var customerID *int
+	if raw := c.Query("customer_id"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid customer ID"})
+			return
+		}
+		customerID = &parsed
+	}
+
+	items, err := h.service.GetAvailableItems(c.Request.Context(), c.GetString("tenant_id"), customerID)
+	if err != nil {
+		c.JSON(reservationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  items,
+		"total": len(items),
+	})
+}
+
+func (h *ReservationHandlers) GetAvailability(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid inventory item ID"})
+		return
+	}
+
+	availability, err := h.service.GetAvailability(c.Request.Context(), c.GetString("tenant_id"), id)
+	if err != nil {
+		c.JSON(reservationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, availability)
+}
+
+// GetItemReservations lists an inventory row's reservations; ?active=true
+// leaves out released ones
+func (h *ReservationHandlers) GetItemReservations(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid inventory item ID"})
+		return
+	}
+
+	reservations, err := h.service.GetItemReservations(c.Request.Context(), c.GetString("tenant_id"), id, c.Query("active") == "true")
+	if err != nil {
+		c.JSON(reservationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  reservations,
+		"total": len(reservations),
+	})
+}
+
+// GetWorkOrderReservations lists the inventory held for a work order
+// (?workorder_id=, required); ?active=true leaves out released ones
+func (h *ReservationHandlers) GetWorkOrderReservations(c *gin.Context) {
+	workOrderID, err := strconv.Atoi(c.Query("workorder_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid work order ID"})
+		return
+	}
+
+	reservations, err := h.service.GetWorkOrderReservations(c.Request.Context(), c.GetString("tenant_id"), workOrderID, c.Query("active") == "true")
+	if err != nil {
+		c.JSON(reservationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  reservations,
+		"total": len(reservations),
+	})
+}
+
+func (h *ReservationHandlers) Reserve(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid inventory item ID"})
+		return
+	}
+
+	var req ReserveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ItemID = id
+
+	reservation, err := h.service.Reserve(c.Request.Context(), c.GetString("tenant_id"), c.GetInt("user_id"), &req)
+	if err != nil {
+		c.JSON(reservationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, reservation)
+}
+
+type ReleaseReservationRequest struct {
+	Reason string `json:"reason"`
+}
+
+func (h *ReservationHandlers) Release(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("reservationId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reservation ID"})
+		return
+	}
+
+	var req ReleaseReservationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	reservation, err := h.service.Release(c.Request.Context(), c.GetString("tenant_id"), c.GetInt("user_id"), id, req.Reason)
+	if err != nil {
+		c.JSON(reservationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
+
+func reservationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrItemNotFound), errors.Is(err, ErrReservationNotFound),
+		errors.Is(err, ErrWorkOrderNotFound), errors.Is(err, ErrWorkOrderItemNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrNotInStock), errors.Is(err, ErrOverAllocated), errors.Is(err, ErrAlreadyReserved),
+		errors.Is(err, ErrReservationReleased), errors.Is(err, ErrWorkOrderClosed), errors.Is(err, ErrCustomerMismatch):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,323 @@
+// backend/internal/inventory/reservation_repository.go
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"oilgas-backend/internal/shared/database"
+)
+
+type ReservationRepository interface {
+	GetReservation(ctx context.Context, tenantID string, id int) (*Reservation, error)
+	GetReservations(ctx context.Context, tenantID string, filters ReservationFilters) ([]Reservation, error)
+	GetAvailability(ctx context.Context, tenantID string, itemID int) (*Availability, error)
+
+	// GetAvailableItems lists in-stock rows with unreserved joints, optionally
+	// for one customer
+	GetAvailableItems(ctx context.Context, tenantID string, customerID *int) ([]AvailableItem, error)
+
+	// Reserve holds joints for a work order. The inventory row is locked while
+	// the active reservations against it are totalled, so two jobs cannot both
+	// take the last joints.
+	Reserve(ctx context.Context, tenantID string, userID int, req *ReserveRequest) (*Reservation, error)
+	Release(ctx context.Context, tenantID string, userID, id int, reason string) (*Reservation, error)
+}
+
+type reservationRepository struct {
+	dbManager *database.DatabaseManager
+}
+
+func NewReservationRepository(dbManager *database.DatabaseManager) ReservationRepository {
+	return &reservationRepository{dbManager: dbManager}
+}
+
+const reservationColumns = `
+	id, tenant_id, inventory_item_id, workorder_id, workorder_item_id, joints, status, notes,
+	reserved_by_user_id, reserved_at, released_by_user_id, released_at, release_reason`
+
+func scanReservation(row rowScanner) (*Reservation, error) {
+	var r Reservation
+	err := row.Scan(
+		&r.ID, &r.TenantID, &r.ItemID, &r.WorkOrderID, &r.WorkOrderItemID, &r.Joints, &r.Status, &r.Notes,
+		&r.ReservedByUserID, &r.ReservedAt, &r.ReleasedByUserID, &r.ReleasedAt, &r.ReleaseReason,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (r *reservationRepository) GetReservation(ctx context.Context, tenantID string, id int) (*Reservation, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	reservation, err := scanReservation(db.QueryRowContext(ctx, `
+		SELECT`+reservationColumns+`
+		FROM store.inventory_reservations
+		WHERE id = $1 AND tenant_id = $2`, id, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrReservationNotFound
+		}
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+
+	return reservation, nil
+}
+
+func (r *reservationRepository) GetReservations(ctx context.Context, tenantID string, filters ReservationFilters) ([]Reservation, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	conditions := []string{"tenant_id = $1"}
+	args := []interface{}{tenantID}
+
+	if filters.ItemID != nil {
+		args = append(args, *filters.ItemID)
+		conditions = append(conditions, fmt.Sprintf("inventory_item_id = $%d", len(args)))
+	}
+	if filters.WorkOrderID != nil {
+		args = append(args, *filters.WorkOrderID)
+		conditions = append(conditions, fmt.Sprintf("workorder_id = $%d", len(args)))
+	}
+	if filters.ActiveOnly {
+		conditions = append(conditions, "status = 'ACTIVE'")
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT`+reservationColumns+`
+		FROM store.inventory_reservations
+		WHERE `+strings.Join(conditions, " AND ")+`
+		ORDER BY reserved_at, id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservations: %w", err)
+	}
+	defer rows.Close()
+
+	reservations := []Reservation{}
+	for rows.Next() {
+		reservation, err := scanReservation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reservation: %w", err)
+		}
+		reservations = append(reservations, *reservation)
+	}
+
+	return reservations, rows.Err()
+}
+
+func (r *reservationRepository) GetAvailability(ctx context.Context, tenantID string, itemID int) (*Availability, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	var a Availability
+	err = db.QueryRowContext(ctx, `
+		SELECT inventory_item_id, joints, reserved_joints, available_joints
+		FROM store.inventory_availability
+		WHERE inventory_item_id = $1 AND tenant_id = $2`, itemID, tenantID).Scan(
+		&a.ItemID, &a.Joints, &a.ReservedJoints, &a.AvailableJoints,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to get inventory availability: %w", err)
+	}
+
+	return &a, nil
+}
+
+func (r *reservationRepository) GetAvailableItems(ctx context.Context, tenantID string, customerID *int) ([]AvailableItem, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	query := `
+		SELECT` + itemColumns + `, a.reserved_joints, a.available_joints
+		FROM store.inventory
+		JOIN (
+			SELECT inventory_item_id AS available_item_id, reserved_joints, available_joints
+			FROM store.inventory_availability
+		) a ON a.available_item_id = id
+		WHERE tenant_id = $1 AND a.available_joints > 0`
+	args := []interface{}{tenantID}
+
+	if customerID != nil {
+		args = append(args, *customerID)
+		query += fmt.Sprintf(" AND customer_id = $%d", len(args))
+	}
+	query += " ORDER BY customer, size, grade, id"
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get available inventory: %w", err)
+	}
+	defer rows.Close()
+
+	items := []AvailableItem{}
+	for rows.Next() {
+		var item AvailableItem
+		err := rows.Scan(
+			&item.ID, &item.TenantID, &item.CustomerID, &item.Customer, &item.WorkOrder, &item.RNumber, &item.Joints,
+			&item.Size, &item.Weight, &item.Grade, &item.Connection, &item.Rack, &item.Location, &item.Notes,
+			&item.DateIn, &item.DateOut, &item.CreatedAt,
+			&item.ReservedJoints, &item.AvailableJoints,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan available inventory: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func (r *reservationRepository) Reserve(ctx context.Context, tenantID string, userID int, req *ReserveRequest) (*Reservation, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	item, err := lockItemTx(ctx, tx, tenantID, req.ItemID)
+	if err != nil {
+		return nil, err
+	}
+	if item.DateOut != nil {
+		return nil, ErrNotInStock
+	}
+
+	// FOR SHARE holds off a concurrent cancellation until this reservation is
+	// committed, so the cancellation's release picks it up
+	var status string
+	var customerID int
+	err = tx.QueryRowContext(ctx, `
+		SELECT status, customer_id FROM store.workorders
+		WHERE id = $1 AND tenant_id = $2 AND is_active = true
+		FOR SHARE`, req.WorkOrderID, tenantID).Scan(&status, &customerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrWorkOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get work order: %w", err)
+	}
+	if !isReservableStatus(status) {
+		return nil, fmt.Errorf("%w: status is %s", ErrWorkOrderClosed, status)
+	}
+	if item.CustomerID != nil && *item.CustomerID != customerID {
+		return nil, ErrCustomerMismatch
+	}
+
+	if req.WorkOrderItemID != nil {
+		var exists bool
+		err := tx.QueryRowContext(ctx, `
+			SELECT EXISTS(SELECT 1 FROM store.workorder_items WHERE id = $1 AND workorder_id = $2)`,
+			*req.WorkOrderItemID, req.WorkOrderID).Scan(&exists)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get work order item: %w", err)
+		}
+		if !exists {
+			return nil, ErrWorkOrderItemNotFound
+		}
+	}
+
+	var existing bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM store.inventory_reservations
+			WHERE inventory_item_id = $1 AND workorder_id = $2 AND status = 'ACTIVE'
+		)`, item.ID, req.WorkOrderID).Scan(&existing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing reservation: %w", err)
+	}
+	if existing {
+		return nil, ErrAlreadyReserved
+	}
+
+	reserved, err := reservedJointsTx(ctx, tx, item.ID)
+	if err != nil {
+		return nil, err
+	}
+	if available := item.Joints - reserved; req.Joints > available {
+		return nil, fmt.Errorf("%w: %d requested, %d available", ErrOverAllocated, req.Joints, available)
+	}
+
+	reservation, err := scanReservation(tx.QueryRowContext(ctx, `
+		INSERT INTO store.inventory_reservations (
+			tenant_id, inventory_item_id, workorder_id, workorder_item_id, joints, notes, reserved_by_user_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING`+reservationColumns,
+		tenantID, item.ID, req.WorkOrderID, req.WorkOrderItemID, req.Joints, nullableString(req.Notes), userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reservation: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit reservation: %w", err)
+	}
+
+	return reservation, nil
+}
+
+func (r *reservationRepository) Release(ctx context.Context, tenantID string, userID, id int, reason string) (*Reservation, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	reservation, err := scanReservation(db.QueryRowContext(ctx, `
+		UPDATE store.inventory_reservations
+		SET status = 'RELEASED', released_by_user_id = $3, released_at = NOW(), release_reason = $4
+		WHERE id = $1 AND tenant_id = $2 AND status = 'ACTIVE'
+		RETURNING`+reservationColumns, id, tenantID, userID, nullableString(reason)))
+	if err == nil {
+		return reservation, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to release reservation: %w", err)
+	}
+
+	if _, err := r.GetReservation(ctx, tenantID, id); err != nil {
+		return nil, err
+	}
+	return nil, ErrReservationReleased
+}
+
+// reservedJointsTx totals the active reservations against an inventory row;
+// callers hold the row lock so the total cannot change underneath them
+func reservedJointsTx(ctx context.Context, tx *sql.Tx, itemID int) (int, error) {
+	var reserved int
+	err := tx.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(joints), 0) FROM store.inventory_reservations
+		WHERE inventory_item_id = $1 AND status = 'ACTIVE'`, itemID).Scan(&reserved)
+	if err != nil {
+		return 0, fmt.Errorf("failed to total reservations: %w", err)
+	}
+	return reserved, nil
+}
+
+// isReservableStatus reports whether a work order in the given status may
+// still take on inventory; the same statuses the work order domain treats as
+// editable
+func isReservableStatus(status string) bool {
+	switch status {
+	case "DRAFT", "PENDING", "APPROVED", "IN_PROGRESS", "ON_HOLD":
+		return true
+	}
+	return false
+}
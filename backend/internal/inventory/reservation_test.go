@@ -0,0 +1,151 @@
+// backend/internal/inventory/reservation_test.go
+package inventory
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type mockReservationRepository struct {
+	mock.Mock
+}
+
+func (m *mockReservationRepository) GetReservation(ctx context.Context, tenantID string, id int) (*Reservation, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Reservation), args.Error(1)
+}
+
+func (m *mockReservationRepository) GetReservations(ctx context.Context, tenantID string, filters ReservationFilters) ([]Reservation, error) {
+	args := m.Called(ctx, tenantID, filters)
+	return args.Get(0).([]Reservation), args.Error(1)
+}
+
+func (m *mockReservationRepository) GetAvailability(ctx context.Context, tenantID string, itemID int) (*Availability, error) {
+	args := m.Called(ctx, tenantID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Availability), args.Error(1)
+}
+
+func (m *mockReservationRepository) GetAvailableItems(ctx context.Context, tenantID string, customerID *int) ([]AvailableItem, error) {
+	args := m.Called(ctx, tenantID, customerID)
+	return args.Get(0).([]AvailableItem), args.Error(1)
+}
+
+func (m *mockReservationRepository) Reserve(ctx context.Context, tenantID string, userID int, req *ReserveRequest) (*Reservation, error) {
+	args := m.Called(ctx, tenantID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Reservation), args.Error(1)
+}
+
+func (m *mockReservationRepository) Release(ctx context.Context, tenantID string, userID, id int, reason string) (*Reservation, error) {
+	args := m.Called(ctx, tenantID, userID, id, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Reservation), args.Error(1)
+}
+
+type ReservationServiceTestSuite struct {
+	suite.Suite
+	service      ReservationService
+	reservations *mockReservationRepository
+	ctx          context.Context
+	tenantID     string
+}
+
+func (suite *ReservationServiceTestSuite) SetupTest() {
+	suite.reservations = &mockReservationRepository{}
+	suite.service = NewReservationService(suite.reservations)
+	suite.ctx = context.Background()
+	suite.tenantID = "longbeach"
+}
+
+func TestReservationServiceSuite(t *testing.T) {
+	suite.Run(t, new(ReservationServiceTestSuite))
+}
+
+func (suite *ReservationServiceTestSuite) TestReserve() {
+	req := &ReserveRequest{ItemID: 501, WorkOrderID: 10, Joints: 40}
+	reservation := &Reservation{ID: 1, ItemID: 501, WorkOrderID: 10, Joints: 40, Status: ReservationActive}
+	suite.reservations.On("Reserve", suite.ctx, suite.tenantID, 7, req).Return(reservation, nil)
+
+	got, err := suite.service.Reserve(suite.ctx, suite.tenantID, 7, req)
+
+	suite.NoError(err)
+	suite.Equal(reservation, got)
+}
+
+func (suite *ReservationServiceTestSuite) TestReserve_RejectsInvalidRequests() {
+	itemID := 0
+	testCases := []struct {
+		name string
+		req  *ReserveRequest
+	}{
+		{"missing request", nil},
+		{"missing item", &ReserveRequest{WorkOrderID: 10, Joints: 5}},
+		{"missing work order", &ReserveRequest{ItemID: 501, Joints: 5}},
+		{"invalid work order item", &ReserveRequest{ItemID: 501, WorkOrderID: 10, WorkOrderItemID: &itemID, Joints: 5}},
+		{"zero joints", &ReserveRequest{ItemID: 501, WorkOrderID: 10}},
+		{"long notes", &ReserveRequest{ItemID: 501, WorkOrderID: 10, Joints: 5, Notes: strings.Repeat("x", 1001)}},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			_, err := suite.service.Reserve(suite.ctx, suite.tenantID, 7, tc.req)
+
+			assert.Error(suite.T(), err)
+			assert.Contains(suite.T(), err.Error(), "validation failed")
+		})
+	}
+
+	_, err := suite.service.Reserve(suite.ctx, suite.tenantID, 0, &ReserveRequest{ItemID: 501, WorkOrderID: 10, Joints: 5})
+	suite.Error(err)
+
+	suite.reservations.AssertNotCalled(suite.T(), "Reserve")
+}
+
+func (suite *ReservationServiceTestSuite) TestReserve_OverAllocated() {
+	req := &ReserveRequest{ItemID: 501, WorkOrderID: 11, Joints: 80}
+	suite.reservations.On("Reserve", suite.ctx, suite.tenantID, 7, req).Return(nil, ErrOverAllocated)
+
+	_, err := suite.service.Reserve(suite.ctx, suite.tenantID, 7, req)
+
+	suite.ErrorIs(err, ErrOverAllocated)
+}
+
+func (suite *ReservationServiceTestSuite) TestRelease() {
+	reservation := &Reservation{ID: 1, Status: ReservationReleased}
+	suite.reservations.On("Release", suite.ctx, suite.tenantID, 7, 1, "customer pulled the job").Return(reservation, nil)
+
+	got, err := suite.service.Release(suite.ctx, suite.tenantID, 7, 1, "  customer pulled the job ")
+
+	suite.NoError(err)
+	suite.Equal(reservation, got)
+
+	_, err = suite.service.Release(suite.ctx, suite.tenantID, 7, 1, strings.Repeat("x", 256))
+	suite.Error(err)
+}
+
+func (suite *ReservationServiceTestSuite) TestGetWorkOrderReservations() {
+	workOrderID := 10
+	filters := ReservationFilters{WorkOrderID: &workOrderID, ActiveOnly: true}
+	reservations := []Reservation{{ID: 1, WorkOrderID: 10}, {ID: 2, WorkOrderID: 10}}
+	suite.reservations.On("GetReservations", suite.ctx, suite.tenantID, filters).Return(reservations, nil)
+
+	got, err := suite.service.GetWorkOrderReservations(suite.ctx, suite.tenantID, 10, true)
+
+	suite.NoError(err)
+	suite.Len(got, 2)
+}
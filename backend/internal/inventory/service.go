@@ -0,0 +1,155 @@
+// backend/internal/inventory/service.go
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type Service interface {
+	GetItem(ctx context.Context, tenantID string, id int) (*Item, error)
+	MoveItem(ctx context.Context, tenantID string, userID int, req *MoveRequest) (*MoveResult, error)
+
+	// GetItemMovements lists the ledger entries that moved joints out of or
+	// into an inventory row
+	GetItemMovements(ctx context.Context, tenantID string, itemID, limit, offset int) ([]Movement, error)
+	GetRNumberHistory(ctx context.Context, tenantID, rNumber string) (*RNumberHistory, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func (s *service) GetItem(ctx context.Context, tenantID string, id int) (*Item, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.repo.GetItem(ctx, tenantID, id)
+}
+
+func (s *service) MoveItem(ctx context.Context, tenantID string, userID int, req *MoveRequest) (*MoveResult, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if err := validateMoveRequest(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	return s.repo.MoveItem(ctx, tenantID, userID, req)
+}
+
+func (s *service) GetItemMovements(ctx context.Context, tenantID string, itemID, limit, offset int) ([]Movement, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if limit > 1000 {
+		return nil, fmt.Errorf("limit too large: %d (max 1000)", limit)
+	}
+
+	if offset < 0 {
+		return nil, fmt.Errorf("offset cannot be negative: %d", offset)
+	}
+
+	if _, err := s.repo.GetItem(ctx, tenantID, itemID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetMovements(ctx, tenantID, MovementFilters{ItemID: &itemID, Limit: limit, Offset: offset})
+}
+
+func (s *service) GetRNumberHistory(ctx context.Context, tenantID, rNumber string) (*RNumberHistory, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	rNumber = strings.TrimSpace(rNumber)
+	if rNumber == "" {
+		return nil, fmt.Errorf("validation failed: R-number is required")
+	}
+
+	items, err := s.repo.GetItemsByRNumber(ctx, tenantID, rNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	movements, err := s.repo.GetMovements(ctx, tenantID, MovementFilters{RNumber: &rNumber})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 && len(movements) == 0 {
+		return nil, fmt.Errorf("%w: R-number %s", ErrItemNotFound, rNumber)
+	}
+
+	return &RNumberHistory{
+		RNumber:   rNumber,
+		Items:     items,
+		Movements: movements,
+	}, nil
+}
+
+func validateMoveRequest(req *MoveRequest) error {
+	if req == nil {
+		return fmt.Errorf("move is required")
+	}
+	if req.ItemID <= 0 {
+		return fmt.Errorf("invalid inventory item ID: %d", req.ItemID)
+	}
+	if req.Joints < 0 {
+		return fmt.Errorf("joints cannot be negative")
+	}
+
+	req.ToRack = strings.TrimSpace(req.ToRack)
+	req.ToLocation = strings.TrimSpace(req.ToLocation)
+	if req.ToRack == "" && req.ToLocation == "" {
+		return fmt.Errorf("a destination rack or location is required")
+	}
+	if len(req.ToRack) > 50 {
+		return fmt.Errorf("rack too long (max 50 characters)")
+	}
+	if len(req.ToLocation) > 100 {
+		return fmt.Errorf("location too long (max 100 characters)")
+	}
+	if len(req.Notes) > 1000 {
+		return fmt.Errorf("notes too long (max 1000 characters)")
+	}
+
+	return nil
+}
+
+func validateTenantID(tenantID string) error {
+	if tenantID == "" {
+		return fmt.Errorf("tenant ID is required")
+	}
+	if len(tenantID) > 100 {
+		return fmt.Errorf("tenant ID too long: %d characters", len(tenantID))
+	}
+	return nil
+}
+
+func nullableString(s string) *string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,133 @@
+// backend/internal/inventory/service_test.go
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type mockRepository struct {
+	mock.Mock
+}
+
+func (m *mockRepository) GetItem(ctx context.Context, tenantID string, id int) (*Item, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Item), args.Error(1)
+}
+
+func (m *mockRepository) GetItemsByRNumber(ctx context.Context, tenantID, rNumber string) ([]Item, error) {
+	args := m.Called(ctx, tenantID, rNumber)
+	return args.Get(0).([]Item), args.Error(1)
+}
+
+func (m *mockRepository) GetMovements(ctx context.Context, tenantID string, filters MovementFilters) ([]Movement, error) {
+	args := m.Called(ctx, tenantID, filters)
+	return args.Get(0).([]Movement), args.Error(1)
+}
+
+func (m *mockRepository) MoveItem(ctx context.Context, tenantID string, userID int, req *MoveRequest) (*MoveResult, error) {
+	args := m.Called(ctx, tenantID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*MoveResult), args.Error(1)
+}
+
+type InventoryServiceTestSuite struct {
+	suite.Suite
+	service  Service
+	repo     *mockRepository
+	ctx      context.Context
+	tenantID string
+}
+
+func (suite *InventoryServiceTestSuite) SetupTest() {
+	suite.repo = &mockRepository{}
+	suite.service = NewService(suite.repo)
+	suite.ctx = context.Background()
+	suite.tenantID = "longbeach"
+}
+
+func TestInventoryServiceSuite(t *testing.T) {
+	suite.Run(t, new(InventoryServiceTestSuite))
+}
+
+func (suite *InventoryServiceTestSuite) TestMoveItem_TrimsDestination() {
+	req := &MoveRequest{ItemID: 501, Joints: 40, ToRack: "  B-07 ", ToLocation: " "}
+	suite.repo.On("MoveItem", suite.ctx, suite.tenantID, 7, req).Return(&MoveResult{}, nil)
+
+	_, err := suite.service.MoveItem(suite.ctx, suite.tenantID, 7, req)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "B-07", req.ToRack)
+	assert.Equal(suite.T(), "", req.ToLocation)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *InventoryServiceTestSuite) TestMoveItem_Validation() {
+	testCases := []struct {
+		name        string
+		req         *MoveRequest
+		expectError string
+	}{
+		{"missing item", &MoveRequest{ToRack: "A-1"}, "invalid inventory item ID"},
+		{"negative joints", &MoveRequest{ItemID: 1, Joints: -5, ToRack: "A-1"}, "joints cannot be negative"},
+		{"no destination", &MoveRequest{ItemID: 1, ToRack: " ", ToLocation: ""}, "destination rack or location is required"},
+	}
+
+	for _, tc := range testCases {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			_, err := suite.service.MoveItem(suite.ctx, suite.tenantID, 7, tc.req)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectError)
+		})
+	}
+
+	_, err := suite.service.MoveItem(suite.ctx, suite.tenantID, 0, &MoveRequest{ItemID: 1, ToRack: "A-1"})
+	assert.Error(suite.T(), err)
+
+	suite.repo.AssertNotCalled(suite.T(), "MoveItem")
+}
+
+func (suite *InventoryServiceTestSuite) TestGetItemMovements_UnknownItem() {
+	suite.repo.On("GetItem", suite.ctx, suite.tenantID, 99).Return(nil, ErrItemNotFound)
+
+	_, err := suite.service.GetItemMovements(suite.ctx, suite.tenantID, 99, 0, 0)
+
+	assert.ErrorIs(suite.T(), err, ErrItemNotFound)
+	suite.repo.AssertNotCalled(suite.T(), "GetMovements")
+}
+
+func (suite *InventoryServiceTestSuite) TestGetRNumberHistory() {
+	rNumber := "R-20431"
+	rack := "C-02"
+	items := []Item{{ID: 501, RNumber: &rNumber, Joints: 60, Rack: &rack}}
+	movements := []Movement{{ID: 1, ItemID: 501, RNumber: &rNumber, Joints: 100, ToRack: &rack}}
+	suite.repo.On("GetItemsByRNumber", suite.ctx, suite.tenantID, rNumber).Return(items, nil)
+	suite.repo.On("GetMovements", suite.ctx, suite.tenantID, MovementFilters{RNumber: &rNumber}).Return(movements, nil)
+
+	history, err := suite.service.GetRNumberHistory(suite.ctx, suite.tenantID, " R-20431 ")
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), rNumber, history.RNumber)
+	assert.Len(suite.T(), history.Items, 1)
+	assert.Len(suite.T(), history.Movements, 1)
+}
+
+func (suite *InventoryServiceTestSuite) TestGetRNumberHistory_Unknown() {
+	rNumber := "R-1"
+	suite.repo.On("GetItemsByRNumber", suite.ctx, suite.tenantID, rNumber).Return([]Item{}, nil)
+	suite.repo.On("GetMovements", suite.ctx, suite.tenantID, MovementFilters{RNumber: &rNumber}).Return([]Movement{}, nil)
+
+	_, err := suite.service.GetRNumberHistory(suite.ctx, suite.tenantID, rNumber)
+
+	assert.ErrorIs(suite.T(), err, ErrItemNotFound)
+}
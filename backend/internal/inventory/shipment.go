@@ -0,0 +1,177 @@
+// backend/internal/inventory/shipment.go
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"oilgas-backend/internal/shared/events"
+)
+
+// BillOfLadingFormat selects how a bill of lading is rendered
+type BillOfLadingFormat string
+
+const (
+	BillOfLadingPDF  BillOfLadingFormat = "pdf"
+	BillOfLadingHTML BillOfLadingFormat = "html"
+)
+
+type ShipmentService interface {
+	// Ship sends joints out of the yard on a new bill of lading and
+	// publishes inventory.shipped
+	Ship(ctx context.Context, tenantID string, userID int, req *ShipRequest) (*Shipment, error)
+	GetShipment(ctx context.Context, tenantID string, id int) (*Shipment, error)
+	GetShipments(ctx context.Context, tenantID string, filters ShipmentFilters) ([]Shipment, int, error)
+
+	// GetBillOfLading renders a shipment's bill of lading, returning the
+	// document and its content type
+	GetBillOfLading(ctx context.Context, tenantID string, id int, format BillOfLadingFormat) ([]byte, string, error)
+}
+
+type shipmentService struct {
+	shipments ShipmentRepository
+	publisher events.Publisher
+}
+
+func NewShipmentService(shipments ShipmentRepository, publisher events.Publisher) ShipmentService {
+	return &shipmentService{shipments: shipments, publisher: publisher}
+}
+
+func (s *shipmentService) Ship(ctx context.Context, tenantID string, userID int, req *ShipRequest) (*Shipment, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if err := validateShipRequest(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	shipment, err := s.shipments.Ship(ctx, tenantID, userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, NewInventoryShippedEvent(tenantID, shipment))
+	return shipment, nil
+}
+
+func (s *shipmentService) GetShipment(ctx context.Context, tenantID string, id int) (*Shipment, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.shipments.GetShipment(ctx, tenantID, id)
+}
+
+func (s *shipmentService) GetShipments(ctx context.Context, tenantID string, filters ShipmentFilters) ([]Shipment, int, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, 0, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if filters.Limit <= 0 {
+		filters.Limit = 50
+	}
+	if filters.Limit > 1000 {
+		return nil, 0, fmt.Errorf("limit too large: %d (max 1000)", filters.Limit)
+	}
+	if filters.Offset < 0 {
+		return nil, 0, fmt.Errorf("offset cannot be negative: %d", filters.Offset)
+	}
+
+	return s.shipments.GetShipments(ctx, tenantID, filters)
+}
+
+func (s *shipmentService) GetBillOfLading(ctx context.Context, tenantID string, id int, format BillOfLadingFormat) ([]byte, string, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, "", fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if format == "" {
+		format = BillOfLadingPDF
+	}
+	if format != BillOfLadingPDF && format != BillOfLadingHTML {
+		return nil, "", fmt.Errorf("unsupported bill of lading format: %s", format)
+	}
+
+	shipment, err := s.shipments.GetShipment(ctx, tenantID, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	yard := strings.ToUpper(tenantID)
+	if format == BillOfLadingHTML {
+		doc, err := RenderBillOfLadingHTML(shipment, yard)
+		if err != nil {
+			return nil, "", err
+		}
+		return doc, "text/html; charset=utf-8", nil
+	}
+
+	return RenderBillOfLadingPDF(shipment, yard), "application/pdf", nil
+}
+
+func (s *shipmentService) publish(ctx context.Context, event events.Event) {
+	if s.publisher == nil {
+		return
+	}
+	if err := s.publisher.Publish(ctx, event); err != nil {
+		log.Printf("Failed to publish %s for tenant %s: %v", event.EventType(), event.TenantID(), err)
+	}
+}
+
+func validateShipRequest(req *ShipRequest) error {
+	if req == nil {
+		return fmt.Errorf("shipment is required")
+	}
+	if req.CustomerID <= 0 {
+		return fmt.Errorf("invalid customer ID: %d", req.CustomerID)
+	}
+
+	req.WellOut = strings.TrimSpace(req.WellOut)
+	req.LeaseOut = strings.TrimSpace(req.LeaseOut)
+	if req.WellOut == "" && req.LeaseOut == "" {
+		return fmt.Errorf("a destination well or lease is required")
+	}
+
+	for name, value := range map[string]string{
+		"well": req.WellOut, "lease": req.LeaseOut, "carrier": req.Carrier, "driver name": req.DriverName,
+	} {
+		if len(value) > 255 {
+			return fmt.Errorf("%s too long (max 255 characters)", name)
+		}
+	}
+	if len(req.TruckNumber) > 50 || len(req.TrailerNumber) > 50 {
+		return fmt.Errorf("truck and trailer numbers are limited to 50 characters")
+	}
+	if len(req.Notes) > 1000 {
+		return fmt.Errorf("notes too long (max 1000 characters)")
+	}
+
+	if len(req.Lines) == 0 {
+		return fmt.Errorf("at least one line is required")
+	}
+	seen := make(map[int]bool, len(req.Lines))
+	for i, line := range req.Lines {
+		if line.ItemID <= 0 {
+			return fmt.Errorf("line %d: invalid inventory item ID: %d", i+1, line.ItemID)
+		}
+		if seen[line.ItemID] {
+			return fmt.Errorf("line %d: inventory item %d is already on this shipment", i+1, line.ItemID)
+		}
+		seen[line.ItemID] = true
+		if line.Joints < 0 {
+			return fmt.Errorf("line %d: joints cannot be negative", i+1)
+		}
+		if line.ReservationID != nil && *line.ReservationID <= 0 {
+			return fmt.Errorf("line %d: invalid reservation ID: %d", i+1, *line.ReservationID)
+		}
+	}
+
+	return nil
+}
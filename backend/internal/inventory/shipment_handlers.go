@@ -0,0 +1,137 @@
+// backend/internal/inventory/shipment_handlers.go
+package inventory
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"oilgas-backend/internal/auth"
+)
+
+type ShipmentHandlers struct {
+	service ShipmentService
+}
+
+func NewShipmentHandlers(service ShipmentService) *ShipmentHandlers {
+	return &ShipmentHandlers{service: service}
+}
+
+func (h *ShipmentHandlers) RegisterRoutes(router *gin.RouterGroup, authMiddleware *auth.Middleware) {
+	staff := authMiddleware.RequireRole(auth.RoleOperator, auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin)
+
+	shipments := router.Group("/shipments")
+	shipments.Use(authMiddleware.RequireAuth())
+	shipments.Use(staff)
+
+	shipments.GET("", h.GetShipments)
+	shipments.POST("", h.Ship)
+	shipments.GET("/:id", h.GetShipment)
+	shipments.GET("/:id/bill-of-lading", h.GetBillOfLading)
+}
+
+func (h *ShipmentHandlers) GetShipments(c *gin.Context) {
+	var filters ShipmentFilters
+
+	if raw := c.Query("customer_id"); raw != "" {
+		customerID, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid customer ID"})
+			return
+		}
+		filters.CustomerID = &customerID
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filters.Limit = l
+		}
+	}
+
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filters.Offset = o
+		}
+	}
+
+	shipments, total, err := h.service.GetShipments(c.Request.Context(), c.GetString("tenant_id"), filters)
+	if err != nil {
+		c.JSON(shipmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  shipments,
+		"total": total,
+	})
+}
+
+// Ship sends joints out on a new bill of lading; a line without joints
+// ships the whole row
+func (h *ShipmentHandlers) Ship(c *gin.Context) {
+	var req ShipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	shipment, err := h.service.Ship(c.Request.Context(), c.GetString("tenant_id"), c.GetInt("user_id"), &req)
+	if err != nil {
+		c.JSON(shipmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, shipment)
+}
+
+func (h *ShipmentHandlers) GetShipment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid shipment ID"})
+		return
+	}
+
+	shipment, err := h.service.GetShipment(c.Request.Context(), c.GetString("tenant_id"), id)
+	if err != nil {
+		c.JSON(shipmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, shipment)
+}
+
+// GetBillOfLading returns the bill of lading as a PDF download, or as a
+// printable page with ?format=html
+func (h *ShipmentHandlers) GetBillOfLading(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid shipment ID"})
+		return
+	}
+
+	format := BillOfLadingFormat(c.Query("format"))
+	doc, contentType, err := h.service.GetBillOfLading(c.Request.Context(), c.GetString("tenant_id"), id, format)
+	if err != nil {
+		c.JSON(shipmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if format != BillOfLadingHTML {
+		c.Header("Content-Disposition", `attachment; filename="bill-of-lading-`+strconv.Itoa(id)+`.pdf"`)
+	}
+	c.Data(http.StatusOK, contentType, doc)
+}
+
+func shipmentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrShipmentNotFound), errors.Is(err, ErrItemNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrNotInStock), errors.Is(err, ErrInsufficientJoints), errors.Is(err, ErrOverAllocated),
+		errors.Is(err, ErrNotCustomerInventory), errors.Is(err, ErrReservationNotForItem), errors.Is(err, ErrReservationReleased):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
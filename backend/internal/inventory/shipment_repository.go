@@ -0,0 +1,346 @@
+// backend/internal/inventory/shipment_repository.go
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"oilgas-backend/internal/numbering"
+	"oilgas-backend/internal/shared/database"
+)
+
+type ShipmentRepository interface {
+	GetShipment(ctx context.Context, tenantID string, id int) (*Shipment, error)
+	GetShipments(ctx context.Context, tenantID string, filters ShipmentFilters) ([]Shipment, int, error)
+
+	// Ship takes a bill of lading number and marks every line's joints out
+	// in one transaction. A partial line splits the shipped joints off into
+	// their own row, which is the one dated out.
+	Ship(ctx context.Context, tenantID string, userID int, req *ShipRequest) (*Shipment, error)
+}
+
+type shipmentRepository struct {
+	dbManager *database.DatabaseManager
+	numbers   numbering.Allocator
+}
+
+func NewShipmentRepository(dbManager *database.DatabaseManager, numbers numbering.Allocator) ShipmentRepository {
+	return &shipmentRepository{dbManager: dbManager, numbers: numbers}
+}
+
+const shipmentColumns = `
+	id, tenant_id, shipment_number, customer_id, customer, well_out, lease_out,
+	carrier, truck_number, trailer_number, driver_name, notes, total_joints,
+	shipped_by_user_id, shipped_at`
+
+const shipmentLineColumns = `
+	id, shipment_id, inventory_item_id, shipped_item_id, reservation_id, work_order, r_number, joints,
+	size, weight, grade, connection, from_rack, from_location`
+
+func scanShipment(row rowScanner) (*Shipment, error) {
+	var s Shipment
+	err := row.Scan(
+		&s.ID, &s.TenantID, &s.ShipmentNumber, &s.CustomerID, &s.Customer, &s.WellOut, &s.LeaseOut,
+		&s.Carrier, &s.TruckNumber, &s.TrailerNumber, &s.DriverName, &s.Notes, &s.TotalJoints,
+		&s.ShippedByUserID, &s.ShippedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func scanShipmentLine(row rowScanner) (*ShipmentLine, error) {
+	var l ShipmentLine
+	err := row.Scan(
+		&l.ID, &l.ShipmentID, &l.ItemID, &l.ShippedItemID, &l.ReservationID, &l.WorkOrder, &l.RNumber, &l.Joints,
+		&l.Size, &l.Weight, &l.Grade, &l.Connection, &l.FromRack, &l.FromLocation,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (r *shipmentRepository) GetShipment(ctx context.Context, tenantID string, id int) (*Shipment, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	shipment, err := scanShipment(db.QueryRowContext(ctx, `
+		SELECT`+shipmentColumns+`
+		FROM store.shipments
+		WHERE id = $1 AND tenant_id = $2`, id, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrShipmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get shipment: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT`+shipmentLineColumns+`
+		FROM store.shipment_lines
+		WHERE shipment_id = $1
+		ORDER BY id`, shipment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipment lines: %w", err)
+	}
+	defer rows.Close()
+
+	shipment.Lines = []ShipmentLine{}
+	for rows.Next() {
+		line, err := scanShipmentLine(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan shipment line: %w", err)
+		}
+		shipment.Lines = append(shipment.Lines, *line)
+	}
+
+	return shipment, rows.Err()
+}
+
+// GetShipments lists shipment headers, newest first; lines are only loaded
+// by GetShipment
+func (r *shipmentRepository) GetShipments(ctx context.Context, tenantID string, filters ShipmentFilters) ([]Shipment, int, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	where := "WHERE tenant_id = $1"
+	args := []interface{}{tenantID}
+
+	if filters.CustomerID != nil {
+		args = append(args, *filters.CustomerID)
+		where += fmt.Sprintf(" AND customer_id = $%d", len(args))
+	}
+
+	var total int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM store.shipments "+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count shipments: %w", err)
+	}
+
+	query := `
+		SELECT` + shipmentColumns + `
+		FROM store.shipments
+		` + where + `
+		ORDER BY shipped_at DESC, id DESC`
+
+	if filters.Limit > 0 {
+		args = append(args, filters.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filters.Offset > 0 {
+		args = append(args, filters.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get shipments: %w", err)
+	}
+	defer rows.Close()
+
+	shipments := []Shipment{}
+	for rows.Next() {
+		shipment, err := scanShipment(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan shipment: %w", err)
+		}
+		shipments = append(shipments, *shipment)
+	}
+
+	return shipments, total, rows.Err()
+}
+
+func (r *shipmentRepository) Ship(ctx context.Context, tenantID string, userID int, req *ShipRequest) (*Shipment, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Rows are locked in id order so two shipments sharing rows cannot
+	// deadlock
+	lines := make([]ShipLineRequest, len(req.Lines))
+	copy(lines, req.Lines)
+	sort.Slice(lines, func(i, j int) bool { return lines[i].ItemID < lines[j].ItemID })
+
+	items := make([]*Item, len(lines))
+	for i, line := range lines {
+		item, err := lockItemTx(ctx, tx, tenantID, line.ItemID)
+		if err != nil {
+			return nil, err
+		}
+		if item.DateOut != nil {
+			return nil, fmt.Errorf("%w: item %d", ErrNotInStock, item.ID)
+		}
+		if item.CustomerID == nil || *item.CustomerID != req.CustomerID {
+			return nil, fmt.Errorf("%w: item %d", ErrNotCustomerInventory, item.ID)
+		}
+		items[i] = item
+	}
+
+	number, err := r.numbers.Next(ctx, tx, tenantID, numbering.DocumentBillOfLading)
+	if err != nil {
+		return nil, err
+	}
+
+	shipment, err := scanShipment(tx.QueryRowContext(ctx, `
+		INSERT INTO store.shipments (
+			tenant_id, shipment_number, customer_id, customer, well_out, lease_out,
+			carrier, truck_number, trailer_number, driver_name, notes, shipped_by_user_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING`+shipmentColumns,
+		tenantID, number, req.CustomerID, items[0].Customer,
+		nullableString(req.WellOut), nullableString(req.LeaseOut), nullableString(req.Carrier),
+		nullableString(req.TruckNumber), nullableString(req.TrailerNumber), nullableString(req.DriverName),
+		nullableString(req.Notes), userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create shipment: %w", err)
+	}
+
+	shipment.Lines = []ShipmentLine{}
+	for i, line := range lines {
+		shipped, err := r.shipLineTx(ctx, tx, shipment, userID, items[i], line)
+		if err != nil {
+			return nil, err
+		}
+		shipment.Lines = append(shipment.Lines, *shipped)
+		shipment.TotalJoints += shipped.Joints
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE store.shipments SET total_joints = $1 WHERE id = $2`,
+		shipment.TotalJoints, shipment.ID); err != nil {
+		return nil, fmt.Errorf("failed to total shipment: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit shipment: %w", err)
+	}
+
+	return shipment, nil
+}
+
+// shipLineTx marks one line's joints out of the yard, consuming the
+// reservation it ships against, and records the line and its ledger entry
+func (r *shipmentRepository) shipLineTx(ctx context.Context, tx *sql.Tx, shipment *Shipment, userID int, item *Item, req ShipLineRequest) (*ShipmentLine, error) {
+	joints := req.Joints
+	if joints == 0 {
+		joints = item.Joints
+	}
+	if joints <= 0 || joints > item.Joints {
+		return nil, fmt.Errorf("%w: item %d: %d requested, %d in rack", ErrInsufficientJoints, item.ID, joints, item.Joints)
+	}
+
+	reserved, err := reservedJointsTx(ctx, tx, item.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ReservationID != nil {
+		var held int
+		var status ReservationStatus
+		err := tx.QueryRowContext(ctx, `
+			SELECT joints, status FROM store.inventory_reservations
+			WHERE id = $1 AND tenant_id = $2 AND inventory_item_id = $3
+			FOR UPDATE`, *req.ReservationID, shipment.TenantID, item.ID).Scan(&held, &status)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, fmt.Errorf("%w: reservation %d, item %d", ErrReservationNotForItem, *req.ReservationID, item.ID)
+			}
+			return nil, fmt.Errorf("failed to get reservation: %w", err)
+		}
+		if status != ReservationActive {
+			return nil, fmt.Errorf("%w: reservation %d", ErrReservationReleased, *req.ReservationID)
+		}
+
+		// The reservation's own joints are available to it
+		reserved -= held
+		if err := consumeReservationTx(ctx, tx, *req.ReservationID, joints, held, shipment.ShipmentNumber); err != nil {
+			return nil, err
+		}
+	}
+
+	if available := item.Joints - reserved; joints > available {
+		return nil, fmt.Errorf("%w: item %d: %d requested, %d available", ErrOverAllocated, item.ID, joints, available)
+	}
+
+	shippedItemID := item.ID
+	var splitID *int
+	if joints < item.Joints {
+		split, err := splitItemTx(ctx, tx, item, joints, item.Rack, item.Location)
+		if err != nil {
+			return nil, err
+		}
+		shippedItemID = split.ID
+		splitID = &split.ID
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE store.inventory SET date_out = CURRENT_DATE, well_out = $1, lease_out = $2
+		WHERE id = $3`, shipment.WellOut, shipment.LeaseOut, shippedItemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark inventory shipped: %w", err)
+	}
+
+	line, err := scanShipmentLine(tx.QueryRowContext(ctx, `
+		INSERT INTO store.shipment_lines (
+			shipment_id, inventory_item_id, shipped_item_id, reservation_id, work_order, r_number, joints,
+			size, weight, grade, connection, from_rack, from_location
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING`+shipmentLineColumns,
+		shipment.ID, item.ID, shippedItemID, req.ReservationID, item.WorkOrder, item.RNumber, joints,
+		item.Size, item.Weight, item.Grade, item.Connection, item.Rack, item.Location))
+	if err != nil {
+		return nil, fmt.Errorf("failed to record shipment line: %w", err)
+	}
+
+	movement := Movement{
+		TenantID:      shipment.TenantID,
+		MovementType:  MovementShip,
+		ItemID:        item.ID,
+		ToItemID:      splitID,
+		RNumber:       item.RNumber,
+		CustomerID:    item.CustomerID,
+		Joints:        joints,
+		FromRack:      item.Rack,
+		FromLocation:  item.Location,
+		Notes:         &shipment.ShipmentNumber,
+		MovedByUserID: userID,
+	}
+	if err := insertMovementTx(ctx, tx, &movement); err != nil {
+		return nil, err
+	}
+
+	return line, nil
+}
+
+// consumeReservationTx draws shipped joints down from a reservation; once
+// all of them have shipped the reservation is consumed
+func consumeReservationTx(ctx context.Context, tx *sql.Tx, id, joints, held int, shipmentNumber string) error {
+	var err error
+	if joints >= held {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE store.inventory_reservations
+			SET status = 'CONSUMED', released_at = NOW(), release_reason = $2
+			WHERE id = $1`, id, "shipped on "+shipmentNumber)
+	} else {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE store.inventory_reservations SET joints = joints - $2 WHERE id = $1`, id, joints)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to consume reservation: %w", err)
+	}
+	return nil
+}
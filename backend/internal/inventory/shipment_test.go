@@ -0,0 +1,174 @@
+// backend/internal/inventory/shipment_test.go
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"oilgas-backend/internal/shared/events"
+)
+
+type mockShipmentRepository struct {
+	mock.Mock
+}
+
+func (m *mockShipmentRepository) GetShipment(ctx context.Context, tenantID string, id int) (*Shipment, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Shipment), args.Error(1)
+}
+
+func (m *mockShipmentRepository) GetShipments(ctx context.Context, tenantID string, filters ShipmentFilters) ([]Shipment, int, error) {
+	args := m.Called(ctx, tenantID, filters)
+	return args.Get(0).([]Shipment), args.Int(1), args.Error(2)
+}
+
+func (m *mockShipmentRepository) Ship(ctx context.Context, tenantID string, userID int, req *ShipRequest) (*Shipment, error) {
+	args := m.Called(ctx, tenantID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Shipment), args.Error(1)
+}
+
+type mockPublisher struct {
+	mock.Mock
+}
+
+func (m *mockPublisher) Publish(ctx context.Context, event events.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+type ShipmentServiceTestSuite struct {
+	suite.Suite
+	service   ShipmentService
+	shipments *mockShipmentRepository
+	publisher *mockPublisher
+	ctx       context.Context
+	tenantID  string
+}
+
+func (suite *ShipmentServiceTestSuite) SetupTest() {
+	suite.shipments = &mockShipmentRepository{}
+	suite.publisher = &mockPublisher{}
+	suite.service = NewShipmentService(suite.shipments, suite.publisher)
+	suite.ctx = context.Background()
+	suite.tenantID = "longbeach"
+}
+
+func TestShipmentServiceSuite(t *testing.T) {
+	suite.Run(t, new(ShipmentServiceTestSuite))
+}
+
+func testShipment() *Shipment {
+	weight := 17.0
+	return &Shipment{
+		ID:             3,
+		ShipmentNumber: "BOL-000042",
+		CustomerID:     12,
+		Customer:       strPtr("Permian Operating (West)"),
+		WellOut:        strPtr("Smith #4"),
+		Carrier:        strPtr("Basin Hot Shot"),
+		TruckNumber:    strPtr("T-19"),
+		TotalJoints:    60,
+		ShippedAt:      time.Date(2025, 3, 14, 15, 0, 0, 0, time.UTC),
+		Lines: []ShipmentLine{
+			{ItemID: 501, ShippedItemID: 501, RNumber: strPtr("R-1001"), Joints: 40, Size: strPtr("5 1/2\""), Weight: &weight, Grade: strPtr("L80")},
+			{ItemID: 502, ShippedItemID: 610, RNumber: strPtr("R-1002"), Joints: 20, Grade: strPtr("<J55>")},
+		},
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func (suite *ShipmentServiceTestSuite) TestShip_PublishesShippedEvent() {
+	req := &ShipRequest{
+		CustomerID: 12,
+		WellOut:    "  Smith #4 ",
+		Lines:      []ShipLineRequest{{ItemID: 501}, {ItemID: 502, Joints: 20}},
+	}
+	shipment := testShipment()
+	suite.shipments.On("Ship", suite.ctx, suite.tenantID, 7, req).Return(shipment, nil)
+	suite.publisher.On("Publish", suite.ctx, mock.MatchedBy(func(e *InventoryShippedEvent) bool {
+		return e.EventType() == "inventory.shipped" && e.ShipmentNumber == "BOL-000042" &&
+			e.TotalJoints == 60 && len(e.Lines) == 2 && e.Lines[1].ShippedItemID == 610
+	})).Return(nil)
+
+	got, err := suite.service.Ship(suite.ctx, suite.tenantID, 7, req)
+
+	suite.NoError(err)
+	suite.Equal(shipment, got)
+	suite.Equal("Smith #4", req.WellOut)
+	suite.publisher.AssertExpectations(suite.T())
+}
+
+func (suite *ShipmentServiceTestSuite) TestShip_RejectsInvalidRequests() {
+	reservationID := 0
+	testCases := []struct {
+		name string
+		req  *ShipRequest
+	}{
+		{"missing request", nil},
+		{"missing customer", &ShipRequest{WellOut: "Smith #4", Lines: []ShipLineRequest{{ItemID: 501}}}},
+		{"no destination", &ShipRequest{CustomerID: 12, LeaseOut: "  ", Lines: []ShipLineRequest{{ItemID: 501}}}},
+		{"no lines", &ShipRequest{CustomerID: 12, WellOut: "Smith #4"}},
+		{"duplicate item", &ShipRequest{CustomerID: 12, WellOut: "Smith #4", Lines: []ShipLineRequest{{ItemID: 501}, {ItemID: 501, Joints: 5}}}},
+		{"negative joints", &ShipRequest{CustomerID: 12, WellOut: "Smith #4", Lines: []ShipLineRequest{{ItemID: 501, Joints: -5}}}},
+		{"invalid reservation", &ShipRequest{CustomerID: 12, WellOut: "Smith #4", Lines: []ShipLineRequest{{ItemID: 501, ReservationID: &reservationID}}}},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			_, err := suite.service.Ship(suite.ctx, suite.tenantID, 7, tc.req)
+
+			assert.Error(suite.T(), err)
+			assert.Contains(suite.T(), err.Error(), "validation failed")
+		})
+	}
+
+	suite.shipments.AssertNotCalled(suite.T(), "Ship")
+	suite.publisher.AssertNotCalled(suite.T(), "Publish")
+}
+
+func (suite *ShipmentServiceTestSuite) TestShip_OverAllocatedDoesNotPublish() {
+	req := &ShipRequest{CustomerID: 12, LeaseOut: "North Unit", Lines: []ShipLineRequest{{ItemID: 501, Joints: 80}}}
+	suite.shipments.On("Ship", suite.ctx, suite.tenantID, 7, req).Return(nil, ErrOverAllocated)
+
+	_, err := suite.service.Ship(suite.ctx, suite.tenantID, 7, req)
+
+	suite.ErrorIs(err, ErrOverAllocated)
+	suite.publisher.AssertNotCalled(suite.T(), "Publish")
+}
+
+func (suite *ShipmentServiceTestSuite) TestGetBillOfLading() {
+	suite.shipments.On("GetShipment", suite.ctx, suite.tenantID, 3).Return(testShipment(), nil)
+
+	doc, contentType, err := suite.service.GetBillOfLading(suite.ctx, suite.tenantID, 3, "")
+	suite.NoError(err)
+	suite.Equal("application/pdf", contentType)
+	suite.True(bytes.HasPrefix(doc, []byte("%PDF-")))
+	suite.Contains(string(doc), "(BOL-000042)")
+	suite.Contains(string(doc), "Permian Operating \\(West\\)")
+
+	doc, contentType, err = suite.service.GetBillOfLading(suite.ctx, suite.tenantID, 3, BillOfLadingHTML)
+	suite.NoError(err)
+	suite.Equal("text/html; charset=utf-8", contentType)
+	suite.Contains(string(doc), "<title>Bill of Lading BOL-000042</title>")
+	suite.Contains(string(doc), "Smith #4")
+	suite.Contains(string(doc), "&lt;J55&gt;")
+	suite.Contains(string(doc), "<strong>60</strong>")
+
+	_, _, err = suite.service.GetBillOfLading(suite.ctx, suite.tenantID, 3, "docx")
+	suite.Error(err)
+}
@@ -0,0 +1,389 @@
+// backend/internal/inventory/snapshot.go
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"oilgas-backend/internal/shared/weight"
+)
+
+// maxCatchUpDays bounds how many missed nights the snapshot worker fills in
+// at once; older days can still be taken by hand
+const maxCatchUpDays = 31
+
+type SnapshotService interface {
+	// TakeSnapshots records a closed day's inventory for the whole yard and
+	// for each customer with pipe on hand, replacing any taken for it before.
+	// A past day is reconstructed from the ledger, so it can be taken late.
+	TakeSnapshots(ctx context.Context, tenantID string, date time.Time) ([]Snapshot, error)
+
+	// CatchUp takes the snapshots of the days closed since the latest one,
+	// returning how many days it took
+	CatchUp(ctx context.Context, tenantID string) (int, error)
+
+	GetSnapshots(ctx context.Context, tenantID string, filters SnapshotFilters) ([]Snapshot, error)
+	GetTrend(ctx context.Context, tenantID string, filters SnapshotFilters) ([]TrendPoint, error)
+
+	// GetInventoryAsOf summarizes the pipe on hand at the close of a day; a
+	// zero AsOf is today. GetItemsAsOf lists the rows it is made of, with
+	// the joints, rack and location they had then.
+	GetInventoryAsOf(ctx context.Context, tenantID string, filters StorageFilters) (*InventoryAsOf, error)
+	GetItemsAsOf(ctx context.Context, tenantID string, filters StorageFilters) ([]StorageLine, int, error)
+}
+
+type snapshotService struct {
+	snapshots SnapshotRepository
+	weights   WeightRepository
+	now       func() time.Time
+}
+
+func NewSnapshotService(snapshots SnapshotRepository, weights WeightRepository, now func() time.Time) SnapshotService {
+	return &snapshotService{snapshots: snapshots, weights: weights, now: now}
+}
+
+func (s *snapshotService) TakeSnapshots(ctx context.Context, tenantID string, date time.Time) ([]Snapshot, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	date = dateOnly(date)
+	if !date.Before(dateOnly(s.now())) {
+		return nil, fmt.Errorf("%w: %s", ErrDayNotClosed, date.Format("2006-01-02"))
+	}
+
+	lines, err := s.linesAsOf(ctx, tenantID, date, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	specs, err := s.weights.GetSizes(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := snapshotsFor(lines, specs)
+	for i := range snapshots {
+		snapshots[i].TenantID = tenantID
+		snapshots[i].SnapshotDate = date
+	}
+
+	if err := s.snapshots.SaveSnapshots(ctx, tenantID, date, snapshots); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+func (s *snapshotService) CatchUp(ctx context.Context, tenantID string) (int, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return 0, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	latest, err := s.snapshots.GetLatestSnapshotDate(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+
+	// A yard's first run backfills the last month from the ledger
+	yesterday := dateOnly(s.now()).AddDate(0, 0, -1)
+	from := yesterday.AddDate(0, 0, 1-maxCatchUpDays)
+	if latest != nil && !dateOnly(*latest).Before(from) {
+		from = dateOnly(*latest).AddDate(0, 0, 1)
+	}
+
+	taken := 0
+	for day := from; !day.After(yesterday); day = day.AddDate(0, 0, 1) {
+		if _, err := s.TakeSnapshots(ctx, tenantID, day); err != nil {
+			return taken, fmt.Errorf("failed to snapshot %s: %w", day.Format("2006-01-02"), err)
+		}
+		taken++
+	}
+	return taken, nil
+}
+
+func (s *snapshotService) GetSnapshots(ctx context.Context, tenantID string, filters SnapshotFilters) ([]Snapshot, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	filters, err := s.snapshotRange(filters)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	return s.snapshots.GetSnapshots(ctx, tenantID, filters)
+}
+
+func (s *snapshotService) GetTrend(ctx context.Context, tenantID string, filters SnapshotFilters) ([]TrendPoint, error) {
+	snapshots, err := s.GetSnapshots(ctx, tenantID, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]TrendPoint, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		points = append(points, TrendPoint{
+			Date:        snapshot.SnapshotDate,
+			TotalItems:  snapshot.TotalItems,
+			TotalJoints: snapshot.TotalJoints,
+			TotalWeight: snapshot.TotalWeight,
+			ShortTons:   snapshot.ShortTons,
+		})
+	}
+	return points, nil
+}
+
+func (s *snapshotService) GetInventoryAsOf(ctx context.Context, tenantID string, filters StorageFilters) (*InventoryAsOf, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	asOf, err := s.pastDate(filters.AsOf)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	lines, err := s.linesAsOf(ctx, tenantID, asOf, filters.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	specs, err := s.weights.GetSizes(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InventoryAsOf{
+		AsOf:       asOf,
+		CustomerID: filters.CustomerID,
+		Summary:    summarize(lines, specs),
+	}, nil
+}
+
+func (s *snapshotService) GetItemsAsOf(ctx context.Context, tenantID string, filters StorageFilters) ([]StorageLine, int, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, 0, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if filters.Limit <= 0 {
+		filters.Limit = 50
+	}
+	if filters.Limit > 1000 {
+		return nil, 0, fmt.Errorf("limit too large: %d (max 1000)", filters.Limit)
+	}
+	if filters.Offset < 0 {
+		return nil, 0, fmt.Errorf("offset cannot be negative: %d", filters.Offset)
+	}
+
+	asOf, err := s.pastDate(filters.AsOf)
+	if err != nil {
+		return nil, 0, fmt.Errorf("validation failed: %w", err)
+	}
+
+	lines, err := s.linesAsOf(ctx, tenantID, asOf, filters.CustomerID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total := len(lines)
+	if filters.Offset >= total {
+		return []StorageLine{}, total, nil
+	}
+	end := filters.Offset + filters.Limit
+	if end > total {
+		end = total
+	}
+	return lines[filters.Offset:end], total, nil
+}
+
+// linesAsOf reads today's candidate rows and rolls them back over the
+// ledger entries made after the day closed
+func (s *snapshotService) linesAsOf(ctx context.Context, tenantID string, asOf time.Time, customerID *int) ([]StorageLine, error) {
+	lines, err := s.snapshots.GetLinesAsOf(ctx, tenantID, asOf, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	ledger, err := s.snapshots.GetLedgerSince(ctx, tenantID, asOf.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	return reconstruct(lines, ledger), nil
+}
+
+// pastDate defaults a zero date to today and refuses one still to come
+func (s *snapshotService) pastDate(date time.Time) (time.Time, error) {
+	today := dateOnly(s.now())
+	if date.IsZero() {
+		return today, nil
+	}
+	if dateOnly(date).After(today) {
+		return time.Time{}, fmt.Errorf("as of date %s is in the future", date.Format("2006-01-02"))
+	}
+	return dateOnly(date), nil
+}
+
+// snapshotRange defaults to the 90 days up to yesterday's snapshot
+func (s *snapshotService) snapshotRange(filters SnapshotFilters) (SnapshotFilters, error) {
+	if filters.To.IsZero() {
+		filters.To = dateOnly(s.now()).AddDate(0, 0, -1)
+	}
+	filters.To = dateOnly(filters.To)
+
+	if filters.From.IsZero() {
+		filters.From = filters.To.AddDate(0, 0, -89)
+	}
+	filters.From = dateOnly(filters.From)
+
+	if filters.From.After(filters.To) {
+		return filters, fmt.Errorf("from %s is after to %s", filters.From.Format("2006-01-02"), filters.To.Format("2006-01-02"))
+	}
+	return filters, nil
+}
+
+// reconstruct rolls rows back to what they held at the close of a day,
+// given the ledger entries made since, newest first. Rows that were not on
+// hand then are dropped.
+func reconstruct(lines []StorageLine, ledger []LedgerEntry) []StorageLine {
+	byItem := make(map[int][]LedgerEntry)
+	for _, entry := range ledger {
+		byItem[entry.ItemID] = append(byItem[entry.ItemID], entry)
+		if entry.ToItemID != nil {
+			byItem[*entry.ToItemID] = append(byItem[*entry.ToItemID], entry)
+		}
+	}
+
+	past := make([]StorageLine, 0, len(lines))
+	for _, line := range lines {
+		if then, ok := lineAsOf(line, byItem[line.ItemID]); ok {
+			past = append(past, then)
+		}
+	}
+	return past
+}
+
+// lineAsOf undoes a row's ledger entries, newest first. A row leaves the
+// yard whole, keeping its joints and getting a date out; joints leaving
+// some of a row are split off it into a new row first.
+func lineAsOf(line StorageLine, entries []LedgerEntry) (StorageLine, bool) {
+	present := line.DateOut == nil
+	exited := false
+
+	for _, entry := range entries {
+		if entry.ToItemID != nil && *entry.ToItemID == line.ItemID {
+			// Split off its source since
+			return line, false
+		}
+
+		switch entry.MovementType {
+		case MovementReceive, MovementTransferIn:
+			// Received, or returned by a cancelled transfer
+			present = false
+		case MovementMove:
+			if entry.ToItemID != nil {
+				line.Joints += entry.Joints
+			} else {
+				line.Rack, line.Location = entry.FromRack, entry.FromLocation
+			}
+		case MovementShip, MovementTransferOut:
+			if entry.ToItemID != nil {
+				line.Joints += entry.Joints
+			} else {
+				present, exited = true, true
+			}
+		case MovementAdjust:
+			switch {
+			case entry.Loss && !present:
+				// Counted to zero, which dates the row out as it stands
+				present, exited = true, true
+			case entry.Loss:
+				line.Joints += entry.Joints
+			default:
+				line.Joints -= entry.Joints
+			}
+		}
+	}
+
+	// Dated out with nothing on the ledger, as imported rows are
+	if !present && !exited && line.DateOut != nil {
+		present = true
+	}
+
+	if !present || line.Joints <= 0 {
+		return line, false
+	}
+	line.DateOut = nil
+	return line, true
+}
+
+// snapshotsFor summarizes the whole yard, then each customer in ID order
+func snapshotsFor(lines []StorageLine, specs []weight.Spec) []Snapshot {
+	byCustomer := make(map[int][]StorageLine)
+	for _, line := range lines {
+		if line.CustomerID != nil {
+			byCustomer[*line.CustomerID] = append(byCustomer[*line.CustomerID], line)
+		}
+	}
+
+	customerIDs := make([]int, 0, len(byCustomer))
+	for id := range byCustomer {
+		customerIDs = append(customerIDs, id)
+	}
+	sort.Ints(customerIDs)
+
+	snapshots := []Snapshot{{Summary: summarize(lines, specs)}}
+	for _, id := range customerIDs {
+		customerID := id
+		snapshots = append(snapshots, Snapshot{
+			CustomerID: &customerID,
+			Summary:    summarize(byCustomer[id], specs),
+		})
+	}
+	return snapshots
+}
+
+// summarize totals rows the way the weight summary does, so the two agree
+// on what the yard holds
+func summarize(lines []StorageLine, specs []weight.Spec) Summary {
+	summary := Summary{
+		LocationCounts: make(map[string]int),
+		GradeCounts:    make(map[string]int),
+		SizeCounts:     make(map[string]int),
+	}
+	customers := make(map[int]bool)
+	workOrders := make(map[string]bool)
+
+	for _, line := range lines {
+		summary.TotalItems++
+		summary.TotalJoints += line.Joints
+		summary.TotalWeight += weighLine(line.WeightLine, specs, weight.DefaultTolerance).Pounds
+
+		if line.CustomerID != nil {
+			customers[*line.CustomerID] = true
+		}
+		if wo := derefString(line.WorkOrder); wo != "" {
+			workOrders[wo] = true
+		}
+		if location := derefString(line.Location); location != "" {
+			summary.LocationCounts[location] += line.Joints
+		}
+		if grade := derefString(line.Grade); grade != "" {
+			summary.GradeCounts[grade] += line.Joints
+		}
+		if size := derefString(line.Size); size != "" {
+			summary.SizeCounts[size] += line.Joints
+		}
+	}
+
+	summary.TotalWeight = roundCents(summary.TotalWeight)
+	summary.ShortTons, _ = weight.ConvertMass(summary.TotalWeight, weight.Pounds, weight.ShortTons)
+	summary.ShortTons = roundTo(summary.ShortTons, 4)
+	summary.UniqueCustomers = len(customers)
+	summary.UniqueWorkOrders = len(workOrders)
+	summary.UniqueSizes = len(summary.SizeCounts)
+	summary.UniqueGrades = len(summary.GradeCounts)
+	return summary
+}
@@ -0,0 +1,190 @@
+// backend/internal/inventory/snapshot_handlers.go
+package inventory
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"oilgas-backend/internal/auth"
+)
+
+type SnapshotHandlers struct {
+	service SnapshotService
+}
+
+func NewSnapshotHandlers(service SnapshotService) *SnapshotHandlers {
+	return &SnapshotHandlers{service: service}
+}
+
+func (h *SnapshotHandlers) RegisterRoutes(router *gin.RouterGroup, authMiddleware *auth.Middleware) {
+	staff := authMiddleware.RequireRole(auth.RoleOperator, auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin)
+	managers := authMiddleware.RequireRole(auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin)
+
+	inventory := router.Group("/inventory")
+	inventory.Use(authMiddleware.RequireAuth())
+	inventory.Use(staff)
+
+	inventory.GET("/as-of", h.GetInventoryAsOf)
+	inventory.GET("/as-of/items", h.GetItemsAsOf)
+	inventory.GET("/snapshots", h.GetSnapshots)
+	inventory.GET("/snapshots/trend", h.GetTrend)
+
+	// The worker takes them nightly; a manager can retake a day by hand
+	inventory.POST("/snapshots", managers, h.TakeSnapshots)
+}
+
+// snapshotFilters reads ?customer_id= and the ?from= and ?to= dates
+func snapshotFilters(c *gin.Context) (SnapshotFilters, bool) {
+	var filters SnapshotFilters
+
+	if raw := c.Query("customer_id"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid customer ID"})
+			return filters, false
+		}
+		filters.CustomerID = &parsed
+	}
+
+	if raw := c.Query("from"); raw != "" {
+		from, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date, expected YYYY-MM-DD"})
+			return filters, false
+		}
+		filters.From = from
+	}
+
+	if raw := c.Query("to"); raw != "" {
+		to, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date, expected YYYY-MM-DD"})
+			return filters, false
+		}
+		filters.To = to
+	}
+
+	return filters, true
+}
+
+// GetInventoryAsOf summarizes the pipe on hand at the close of ?as_of=,
+// for the yard or one ?customer_id=
+func (h *SnapshotHandlers) GetInventoryAsOf(c *gin.Context) {
+	filters, ok := storageFilters(c)
+	if !ok {
+		return
+	}
+
+	asOf, err := h.service.GetInventoryAsOf(c.Request.Context(), c.GetString("tenant_id"), filters)
+	if err != nil {
+		c.JSON(snapshotErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, asOf)
+}
+
+func (h *SnapshotHandlers) GetItemsAsOf(c *gin.Context) {
+	filters, ok := storageFilters(c)
+	if !ok {
+		return
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filters.Limit = l
+		}
+	}
+
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filters.Offset = o
+		}
+	}
+
+	items, total, err := h.service.GetItemsAsOf(c.Request.Context(), c.GetString("tenant_id"), filters)
+	if err != nil {
+		c.JSON(snapshotErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  items,
+		"total": total,
+	})
+}
+
+func (h *SnapshotHandlers) GetSnapshots(c *gin.Context) {
+	filters, ok := snapshotFilters(c)
+	if !ok {
+		return
+	}
+
+	snapshots, err := h.service.GetSnapshots(c.Request.Context(), c.GetString("tenant_id"), filters)
+	if err != nil {
+		c.JSON(snapshotErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  snapshots,
+		"total": len(snapshots),
+	})
+}
+
+// GetTrend returns the daily totals between ?from= and ?to= for charting
+func (h *SnapshotHandlers) GetTrend(c *gin.Context) {
+	filters, ok := snapshotFilters(c)
+	if !ok {
+		return
+	}
+
+	points, err := h.service.GetTrend(c.Request.Context(), c.GetString("tenant_id"), filters)
+	if err != nil {
+		c.JSON(snapshotErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  points,
+		"total": len(points),
+	})
+}
+
+func (h *SnapshotHandlers) TakeSnapshots(c *gin.Context) {
+	var req TakeSnapshotsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date, expected YYYY-MM-DD"})
+		return
+	}
+
+	snapshots, err := h.service.TakeSnapshots(c.Request.Context(), c.GetString("tenant_id"), date)
+	if err != nil {
+		c.JSON(snapshotErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":  snapshots,
+		"total": len(snapshots),
+	})
+}
+
+func snapshotErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrDayNotClosed):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,274 @@
+// backend/internal/inventory/snapshot_repository.go
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"oilgas-backend/internal/shared/database"
+)
+
+type SnapshotRepository interface {
+	// GetLinesAsOf returns the rows that may have been on hand at the close
+	// of a day: in by then, and not out by then. Rows without a date in are
+	// dated by when they were created. Rows split off or received since are
+	// among them; the ledger tells them apart.
+	GetLinesAsOf(ctx context.Context, tenantID string, asOf time.Time, customerID *int) ([]StorageLine, error)
+
+	// GetLedgerSince returns the movements from a time on, newest first
+	GetLedgerSince(ctx context.Context, tenantID string, since time.Time) ([]LedgerEntry, error)
+
+	// SaveSnapshots replaces a day's snapshots with the given ones in one
+	// transaction
+	SaveSnapshots(ctx context.Context, tenantID string, date time.Time, snapshots []Snapshot) error
+	GetSnapshots(ctx context.Context, tenantID string, filters SnapshotFilters) ([]Snapshot, error)
+
+	// GetLatestSnapshotDate returns the last day the whole yard was
+	// snapshotted, nil before the first snapshot
+	GetLatestSnapshotDate(ctx context.Context, tenantID string) (*time.Time, error)
+}
+
+type snapshotRepository struct {
+	dbManager *database.DatabaseManager
+}
+
+func NewSnapshotRepository(dbManager *database.DatabaseManager) SnapshotRepository {
+	return &snapshotRepository{dbManager: dbManager}
+}
+
+const snapshotColumns = `
+	id, tenant_id, snapshot_date, customer_id, total_items, total_joints, total_weight, short_tons,
+	unique_customers, unique_work_orders, unique_sizes, unique_grades,
+	location_counts, grade_counts, size_counts, taken_at`
+
+// snapshotLineQuery is storageLineQuery as of the close of day $2
+const snapshotLineQuery = `
+	SELECT i.id, i.r_number, i.customer_id, i.customer, COALESCE(i.joints, 0),
+	       i.size, i.weight, i.grade, i.rack, i.location, i.average_joint_length,
+	       COALESCE(t.joints, 0), COALESCE(t.feet, 0),
+	       i.work_order, COALESCE(i.date_in, i.created_at::date), i.date_out
+	FROM store.inventory i
+	LEFT JOIN (
+		SELECT inventory_item_id, COUNT(*) AS joints, SUM(length_ft) AS feet
+		FROM store.joint_tallies
+		WHERE tenant_id = $1
+		GROUP BY inventory_item_id
+	) t ON t.inventory_item_id = i.id
+	WHERE i.tenant_id = $1 AND i.deleted = false
+	  AND COALESCE(i.date_in, i.created_at::date) <= $2
+	  AND (i.date_out IS NULL OR i.date_out > $2)`
+
+func scanSnapshot(row rowScanner) (*Snapshot, error) {
+	var s Snapshot
+	var locations, grades, sizes []byte
+	err := row.Scan(
+		&s.ID, &s.TenantID, &s.SnapshotDate, &s.CustomerID, &s.TotalItems, &s.TotalJoints, &s.TotalWeight, &s.ShortTons,
+		&s.UniqueCustomers, &s.UniqueWorkOrders, &s.UniqueSizes, &s.UniqueGrades,
+		&locations, &grades, &sizes, &s.TakenAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(locations, &s.LocationCounts); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot location counts: %w", err)
+	}
+	if err := json.Unmarshal(grades, &s.GradeCounts); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot grade counts: %w", err)
+	}
+	if err := json.Unmarshal(sizes, &s.SizeCounts); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot size counts: %w", err)
+	}
+	return &s, nil
+}
+
+func scanLedgerEntry(row rowScanner) (*LedgerEntry, error) {
+	var e LedgerEntry
+	m := &e.Movement
+	err := row.Scan(
+		&m.ID, &m.TenantID, &m.MovementType, &m.ItemID, &m.ToItemID, &m.RNumber, &m.CustomerID, &m.Joints,
+		&m.FromRack, &m.FromLocation, &m.ToRack, &m.ToLocation, &m.Notes, &m.ReasonCode, &m.MovedByUserID, &m.MovedAt,
+		&e.Loss,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *snapshotRepository) GetLinesAsOf(ctx context.Context, tenantID string, asOf time.Time, customerID *int) ([]StorageLine, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	query := snapshotLineQuery
+	args := []interface{}{tenantID, asOf}
+	if customerID != nil {
+		args = append(args, *customerID)
+		query += fmt.Sprintf(" AND i.customer_id = $%d", len(args))
+	}
+	query += " ORDER BY i.customer_id, i.id"
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory as of %s: %w", asOf.Format("2006-01-02"), err)
+	}
+	defer rows.Close()
+
+	lines := []StorageLine{}
+	for rows.Next() {
+		line, err := scanStorageLine(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan inventory line: %w", err)
+		}
+		lines = append(lines, *line)
+	}
+
+	return lines, rows.Err()
+}
+
+func (r *snapshotRepository) GetLedgerSince(ctx context.Context, tenantID string, since time.Time) ([]LedgerEntry, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	// Adjustments record joints as a positive count either way; the count
+	// line they were approved from says which way
+	rows, err := db.QueryContext(ctx, `
+		SELECT`+movementColumns+`,
+		       COALESCE((
+		           SELECT cl.counted_joints < cl.expected_joints
+		           FROM store.count_lines cl
+		           WHERE cl.movement_id = m.id
+		       ), false)
+		FROM store.inventory_movements m
+		WHERE tenant_id = $1 AND moved_at >= $2
+		ORDER BY moved_at DESC, id DESC`, tenantID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory ledger: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []LedgerEntry{}
+	for rows.Next() {
+		entry, err := scanLedgerEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan inventory movement: %w", err)
+		}
+		entries = append(entries, *entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func (r *snapshotRepository) SaveSnapshots(ctx context.Context, tenantID string, date time.Time, snapshots []Snapshot) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM store.inventory_snapshots WHERE tenant_id = $1 AND snapshot_date = $2`, tenantID, date); err != nil {
+		return fmt.Errorf("failed to clear inventory snapshots: %w", err)
+	}
+
+	for i := range snapshots {
+		s := &snapshots[i]
+
+		locations, err := json.Marshal(s.LocationCounts)
+		if err != nil {
+			return fmt.Errorf("failed to encode snapshot location counts: %w", err)
+		}
+		grades, err := json.Marshal(s.GradeCounts)
+		if err != nil {
+			return fmt.Errorf("failed to encode snapshot grade counts: %w", err)
+		}
+		sizes, err := json.Marshal(s.SizeCounts)
+		if err != nil {
+			return fmt.Errorf("failed to encode snapshot size counts: %w", err)
+		}
+
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO store.inventory_snapshots (
+				tenant_id, snapshot_date, customer_id, total_items, total_joints, total_weight, short_tons,
+				unique_customers, unique_work_orders, unique_sizes, unique_grades,
+				location_counts, grade_counts, size_counts
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			RETURNING id, taken_at`,
+			tenantID, date, s.CustomerID, s.TotalItems, s.TotalJoints, s.TotalWeight, s.ShortTons,
+			s.UniqueCustomers, s.UniqueWorkOrders, s.UniqueSizes, s.UniqueGrades,
+			string(locations), string(grades), string(sizes),
+		).Scan(&s.ID, &s.TakenAt)
+		if err != nil {
+			return fmt.Errorf("failed to record inventory snapshot: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit inventory snapshots: %w", err)
+	}
+	return nil
+}
+
+func (r *snapshotRepository) GetSnapshots(ctx context.Context, tenantID string, filters SnapshotFilters) ([]Snapshot, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	query := `
+		SELECT` + snapshotColumns + `
+		FROM store.inventory_snapshots
+		WHERE tenant_id = $1 AND snapshot_date BETWEEN $2 AND $3`
+	args := []interface{}{tenantID, filters.From, filters.To}
+	if filters.CustomerID != nil {
+		query += ` AND customer_id = $4`
+		args = append(args, *filters.CustomerID)
+	} else {
+		query += ` AND customer_id IS NULL`
+	}
+	query += ` ORDER BY snapshot_date`
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	snapshots := []Snapshot{}
+	for rows.Next() {
+		snapshot, err := scanSnapshot(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan inventory snapshot: %w", err)
+		}
+		snapshots = append(snapshots, *snapshot)
+	}
+
+	return snapshots, rows.Err()
+}
+
+func (r *snapshotRepository) GetLatestSnapshotDate(ctx context.Context, tenantID string) (*time.Time, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	var latest *time.Time
+	err = db.QueryRowContext(ctx, `
+		SELECT MAX(snapshot_date) FROM store.inventory_snapshots
+		WHERE tenant_id = $1 AND customer_id IS NULL`, tenantID).Scan(&latest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest inventory snapshot: %w", err)
+	}
+	return latest, nil
+}
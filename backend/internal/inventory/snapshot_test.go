@@ -0,0 +1,259 @@
+// backend/internal/inventory/snapshot_test.go
+package inventory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"oilgas-backend/internal/shared/weight"
+)
+
+type mockSnapshotRepository struct {
+	mock.Mock
+}
+
+func (m *mockSnapshotRepository) GetLinesAsOf(ctx context.Context, tenantID string, asOf time.Time, customerID *int) ([]StorageLine, error) {
+	args := m.Called(ctx, tenantID, asOf, customerID)
+	return args.Get(0).([]StorageLine), args.Error(1)
+}
+
+func (m *mockSnapshotRepository) GetLedgerSince(ctx context.Context, tenantID string, since time.Time) ([]LedgerEntry, error) {
+	args := m.Called(ctx, tenantID, since)
+	return args.Get(0).([]LedgerEntry), args.Error(1)
+}
+
+func (m *mockSnapshotRepository) SaveSnapshots(ctx context.Context, tenantID string, date time.Time, snapshots []Snapshot) error {
+	return m.Called(ctx, tenantID, date, snapshots).Error(0)
+}
+
+func (m *mockSnapshotRepository) GetSnapshots(ctx context.Context, tenantID string, filters SnapshotFilters) ([]Snapshot, error) {
+	args := m.Called(ctx, tenantID, filters)
+	return args.Get(0).([]Snapshot), args.Error(1)
+}
+
+func (m *mockSnapshotRepository) GetLatestSnapshotDate(ctx context.Context, tenantID string) (*time.Time, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func ledgerEntry(movementType MovementType, itemID int, toItemID *int, joints int) LedgerEntry {
+	return LedgerEntry{Movement: Movement{MovementType: movementType, ItemID: itemID, ToItemID: toItemID, Joints: joints}}
+}
+
+func TestReconstruct(t *testing.T) {
+	racked := func(itemID, joints int, rack string, dateOut *time.Time) StorageLine {
+		line := storageLine(itemID, 12, joints, `5 1/2"`, 17, date(2026, 8, 1), dateOut)
+		line.Rack = strPtr(rack)
+		return line
+	}
+	loss := ledgerEntry(MovementAdjust, 105, nil, 6)
+	loss.Loss = true
+	zeroed := ledgerEntry(MovementAdjust, 106, nil, 30)
+	zeroed.Loss = true
+	moved := ledgerEntry(MovementMove, 101, nil, 40)
+	moved.FromRack = strPtr("A-1")
+
+	lines := []StorageLine{
+		racked(101, 40, "B-7", nil),                  // Moved whole since
+		racked(102, 60, "A-2", nil),                  // Had 25 of its joints shipped since
+		racked(103, 25, "A-2", datePtr(2026, 10, 1)), // The 25 shipped, split off 102
+		racked(104, 80, "A-3", nil),                  // Received since
+		racked(105, 44, "A-4", nil),                  // Counted 6 short since
+		racked(106, 30, "A-5", datePtr(2026, 10, 2)), // Counted to zero since
+		racked(107, 12, "A-6", nil),                  // Counted 3 over since
+		racked(108, 50, "A-7", nil),                  // Sent before, the transfer cancelled since
+		racked(109, 20, "A-8", nil),                  // Sent and the transfer cancelled, both since
+		racked(110, 15, "A-9", datePtr(2026, 10, 3)), // Imported with a date out, no ledger
+		racked(111, 10, "A-10", nil),                 // Untouched
+	}
+	ledger := []LedgerEntry{
+		ledgerEntry(MovementTransferIn, 109, nil, 20),
+		ledgerEntry(MovementTransferOut, 109, nil, 20),
+		ledgerEntry(MovementTransferIn, 108, nil, 50),
+		ledgerEntry(MovementAdjust, 107, nil, 3),
+		zeroed,
+		loss,
+		ledgerEntry(MovementReceive, 104, nil, 80),
+		ledgerEntry(MovementShip, 102, intPtr(103), 25),
+		moved,
+	}
+
+	past := reconstruct(lines, ledger)
+
+	joints := map[int]int{}
+	for _, line := range past {
+		joints[line.ItemID] = line.Joints
+		assert.Nil(t, line.DateOut, "item %d was on hand", line.ItemID)
+	}
+	assert.Equal(t, map[int]int{
+		101: 40,
+		102: 85,
+		105: 50,
+		106: 30,
+		107: 9,
+		109: 20,
+		110: 15,
+		111: 10,
+	}, joints)
+	assert.Equal(t, "A-1", *past[0].Rack)
+}
+
+type SnapshotServiceTestSuite struct {
+	suite.Suite
+	service   SnapshotService
+	snapshots *mockSnapshotRepository
+	weights   *mockWeightRepository
+	ctx       context.Context
+	tenantID  string
+	today     time.Time
+}
+
+func (suite *SnapshotServiceTestSuite) SetupTest() {
+	suite.snapshots = &mockSnapshotRepository{}
+	suite.weights = &mockWeightRepository{}
+	suite.today = date(2026, 10, 16)
+	suite.service = NewSnapshotService(suite.snapshots, suite.weights, func() time.Time {
+		return suite.today.Add(2 * time.Hour)
+	})
+	suite.ctx = context.Background()
+	suite.tenantID = "longbeach"
+
+	suite.weights.On("GetSizes", suite.ctx, suite.tenantID).Return([]weight.Spec{
+		{Size: `5 1/2" 17#`, NominalSize: strPtr(`5 1/2"`), OuterDiameter: floatPtr(5.5), InnerDiameter: floatPtr(4.892), WeightPerFoot: floatPtr(17)},
+	}, nil).Maybe()
+}
+
+func TestSnapshotServiceSuite(t *testing.T) {
+	suite.Run(t, new(SnapshotServiceTestSuite))
+}
+
+func (suite *SnapshotServiceTestSuite) TestTakeSnapshots_YardAndEachCustomer() {
+	day := date(2026, 9, 30)
+	lines := []StorageLine{
+		storageLine(501, 14, 10, `5 1/2"`, 17, date(2026, 8, 1), nil),
+		storageLine(502, 12, 100, `5 1/2"`, 17, date(2026, 8, 1), nil),
+		storageLine(503, 12, 20, `5 1/2"`, 17, date(2026, 9, 1), nil),
+	}
+	lines[0].Location, lines[0].Grade, lines[0].WorkOrder = strPtr("North"), strPtr("L80"), strPtr("LB-001")
+	lines[1].Location, lines[1].Grade, lines[1].WorkOrder = strPtr("North"), strPtr("J55"), strPtr("LB-002")
+	lines[2].Location, lines[2].Grade = strPtr("South"), strPtr("J55")
+
+	suite.snapshots.On("GetLinesAsOf", suite.ctx, suite.tenantID, day, (*int)(nil)).Return(lines, nil)
+	suite.snapshots.On("GetLedgerSince", suite.ctx, suite.tenantID, date(2026, 10, 1)).Return([]LedgerEntry{
+		ledgerEntry(MovementShip, 502, intPtr(504), 30),
+	}, nil)
+	suite.snapshots.On("SaveSnapshots", suite.ctx, suite.tenantID, day, mock.Anything).Return(nil)
+
+	snapshots, err := suite.service.TakeSnapshots(suite.ctx, suite.tenantID, day.Add(20*time.Hour))
+
+	suite.NoError(err)
+	suite.Require().Len(snapshots, 3)
+
+	yard := snapshots[0]
+	suite.Nil(yard.CustomerID)
+	suite.Equal(day, yard.SnapshotDate)
+	suite.Equal(suite.tenantID, yard.TenantID)
+	suite.Equal(3, yard.TotalItems)
+	suite.Equal(160, yard.TotalJoints)
+	suite.InDelta(160*31*17.0, yard.TotalWeight, 1e-6)
+	suite.InDelta(42.16, yard.ShortTons, 1e-9)
+	suite.Equal(2, yard.UniqueCustomers)
+	suite.Equal(2, yard.UniqueWorkOrders)
+	suite.Equal(1, yard.UniqueSizes)
+	suite.Equal(2, yard.UniqueGrades)
+	suite.Equal(map[string]int{"North": 140, "South": 20}, yard.LocationCounts)
+	suite.Equal(map[string]int{"L80": 10, "J55": 150}, yard.GradeCounts)
+
+	suite.Equal(12, *snapshots[1].CustomerID)
+	suite.Equal(150, snapshots[1].TotalJoints)
+	suite.Equal(14, *snapshots[2].CustomerID)
+	suite.Equal(10, snapshots[2].TotalJoints)
+	suite.Equal(1, snapshots[2].UniqueCustomers)
+}
+
+func (suite *SnapshotServiceTestSuite) TestTakeSnapshots_RejectsADayStillOpen() {
+	_, err := suite.service.TakeSnapshots(suite.ctx, suite.tenantID, suite.today)
+	suite.ErrorIs(err, ErrDayNotClosed)
+
+	_, err = suite.service.TakeSnapshots(suite.ctx, "", suite.today.AddDate(0, 0, -1))
+	suite.Error(err)
+	suite.Contains(err.Error(), "invalid tenant")
+
+	suite.snapshots.AssertNotCalled(suite.T(), "SaveSnapshots")
+}
+
+func (suite *SnapshotServiceTestSuite) TestCatchUp_FillsTheDaysSinceTheLatest() {
+	latest := date(2026, 10, 13)
+	suite.snapshots.On("GetLatestSnapshotDate", suite.ctx, suite.tenantID).Return(&latest, nil)
+	suite.snapshots.On("GetLinesAsOf", suite.ctx, suite.tenantID, mock.Anything, (*int)(nil)).Return([]StorageLine{}, nil)
+	suite.snapshots.On("GetLedgerSince", suite.ctx, suite.tenantID, mock.Anything).Return([]LedgerEntry{}, nil)
+	suite.snapshots.On("SaveSnapshots", suite.ctx, suite.tenantID, mock.Anything, mock.Anything).Return(nil)
+
+	taken, err := suite.service.CatchUp(suite.ctx, suite.tenantID)
+
+	suite.NoError(err)
+	suite.Equal(2, taken)
+	suite.snapshots.AssertCalled(suite.T(), "SaveSnapshots", suite.ctx, suite.tenantID, date(2026, 10, 14), mock.Anything)
+	suite.snapshots.AssertCalled(suite.T(), "SaveSnapshots", suite.ctx, suite.tenantID, date(2026, 10, 15), mock.Anything)
+}
+
+func (suite *SnapshotServiceTestSuite) TestCatchUp_BackfillsAMonthOnTheFirstRun() {
+	suite.snapshots.On("GetLatestSnapshotDate", suite.ctx, suite.tenantID).Return((*time.Time)(nil), nil)
+	suite.snapshots.On("GetLinesAsOf", suite.ctx, suite.tenantID, mock.Anything, (*int)(nil)).Return([]StorageLine{}, nil)
+	suite.snapshots.On("GetLedgerSince", suite.ctx, suite.tenantID, mock.Anything).Return([]LedgerEntry{}, nil)
+	suite.snapshots.On("SaveSnapshots", suite.ctx, suite.tenantID, mock.Anything, mock.Anything).Return(nil)
+
+	taken, err := suite.service.CatchUp(suite.ctx, suite.tenantID)
+
+	suite.NoError(err)
+	suite.Equal(maxCatchUpDays, taken)
+	suite.snapshots.AssertCalled(suite.T(), "SaveSnapshots", suite.ctx, suite.tenantID, date(2026, 9, 15), mock.Anything)
+	suite.snapshots.AssertNotCalled(suite.T(), "SaveSnapshots", suite.ctx, suite.tenantID, suite.today, mock.Anything)
+}
+
+func (suite *SnapshotServiceTestSuite) TestGetInventoryAsOf_ReconstructsFromTheLedger() {
+	customerID := 12
+	day := date(2026, 9, 30)
+	suite.snapshots.On("GetLinesAsOf", suite.ctx, suite.tenantID, day, &customerID).Return([]StorageLine{
+		storageLine(502, 12, 70, `5 1/2"`, 17, date(2026, 8, 1), nil),
+	}, nil)
+	suite.snapshots.On("GetLedgerSince", suite.ctx, suite.tenantID, date(2026, 10, 1)).Return([]LedgerEntry{
+		ledgerEntry(MovementShip, 502, intPtr(504), 30),
+	}, nil)
+
+	asOf, err := suite.service.GetInventoryAsOf(suite.ctx, suite.tenantID, StorageFilters{CustomerID: &customerID, AsOf: day})
+
+	suite.NoError(err)
+	suite.Equal(day, asOf.AsOf)
+	suite.Equal(&customerID, asOf.CustomerID)
+	suite.Equal(100, asOf.Summary.TotalJoints)
+
+	_, err = suite.service.GetInventoryAsOf(suite.ctx, suite.tenantID, StorageFilters{AsOf: suite.today.AddDate(0, 0, 1)})
+	suite.Error(err)
+	suite.Contains(err.Error(), "validation failed")
+}
+
+func (suite *SnapshotServiceTestSuite) TestGetTrend_DefaultsToTheLastNinetyDays() {
+	filters := SnapshotFilters{From: date(2026, 7, 18), To: date(2026, 10, 15)}
+	suite.snapshots.On("GetSnapshots", suite.ctx, suite.tenantID, filters).Return([]Snapshot{
+		{SnapshotDate: date(2026, 10, 14), Summary: Summary{TotalItems: 3, TotalJoints: 160, TotalWeight: 84320, ShortTons: 42.16}},
+		{SnapshotDate: date(2026, 10, 15), Summary: Summary{TotalItems: 2, TotalJoints: 130, TotalWeight: 68510, ShortTons: 34.255}},
+	}, nil)
+
+	points, err := suite.service.GetTrend(suite.ctx, suite.tenantID, SnapshotFilters{})
+
+	suite.NoError(err)
+	suite.Equal([]TrendPoint{
+		{Date: date(2026, 10, 14), TotalItems: 3, TotalJoints: 160, TotalWeight: 84320, ShortTons: 42.16},
+		{Date: date(2026, 10, 15), TotalItems: 2, TotalJoints: 130, TotalWeight: 68510, ShortTons: 34.255},
+	}, points)
+
+	_, err = suite.service.GetTrend(suite.ctx, suite.tenantID, SnapshotFilters{From: date(2026, 10, 2), To: date(2026, 10, 1)})
+	suite.Error(err)
+	suite.Contains(err.Error(), "validation failed")
+}
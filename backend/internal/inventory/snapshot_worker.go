@@ -0,0 +1,56 @@
+// backend/internal/inventory/snapshot_worker.go
+package inventory
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// SnapshotWorker takes each yard's nightly inventory snapshots. It checks
+// more often than nightly so a day missed while the service was down is
+// filled in once it is back.
+type SnapshotWorker struct {
+	service  SnapshotService
+	tenants  []string
+	interval time.Duration
+}
+
+func NewSnapshotWorker(service SnapshotService, tenants []string, interval time.Duration) *SnapshotWorker {
+	return &SnapshotWorker{
+		service:  service,
+		tenants:  tenants,
+		interval: interval,
+	}
+}
+
+// Start runs the worker in the background until ctx is cancelled
+func (w *SnapshotWorker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		w.RunOnce(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.RunOnce(ctx)
+			}
+		}
+	}()
+}
+
+// RunOnce catches every yard up; one yard failing does not stop the others
+func (w *SnapshotWorker) RunOnce(ctx context.Context) {
+	for _, tenantID := range w.tenants {
+		taken, err := w.service.CatchUp(ctx, tenantID)
+		if err != nil {
+			log.Printf("Inventory snapshot failed for tenant %s: %v", tenantID, err)
+		}
+		if taken > 0 {
+			log.Printf("Inventory snapshot took %d day(s) for tenant %s", taken, tenantID)
+		}
+	}
+}
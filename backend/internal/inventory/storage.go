@@ -0,0 +1,551 @@
+// backend/internal/inventory/storage.go
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"oilgas-backend/internal/invoice"
+	"oilgas-backend/internal/shared/weight"
+)
+
+// maxFreeDays keeps a typo in a rate plan from waiving years of rent
+const maxFreeDays = 365
+
+type StorageService interface {
+	GetRatePlans(ctx context.Context, tenantID string) ([]RatePlan, error)
+
+	// SaveRatePlan creates a plan with no ID and updates one with an ID. A
+	// customer has at most one active plan; so does the yard's default.
+	SaveRatePlan(ctx context.Context, tenantID string, plan *RatePlan) error
+
+	// GetDaysOnRack lists the rows on rack as of a date, longest on rack
+	// first
+	GetDaysOnRack(ctx context.Context, tenantID string, filters StorageFilters) ([]RackDays, int, error)
+	GetAgingReport(ctx context.Context, tenantID string, filters StorageFilters) (*AgingReport, error)
+
+	// GenerateCharges prices a finished month's storage under each
+	// customer's rate plan and replaces any charges already generated for it
+	GenerateCharges(ctx context.Context, tenantID string, userID int, req *GenerateChargesRequest) (*StorageRun, error)
+	GetCharges(ctx context.Context, tenantID, period string, customerID *int) (*StorageRun, error)
+}
+
+type storageService struct {
+	storage StorageRepository
+	weights WeightRepository
+	now     func() time.Time
+}
+
+func NewStorageService(storage StorageRepository, weights WeightRepository, now func() time.Time) StorageService {
+	return &storageService{storage: storage, weights: weights, now: now}
+}
+
+func (s *storageService) GetRatePlans(ctx context.Context, tenantID string) ([]RatePlan, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.storage.GetRatePlans(ctx, tenantID)
+}
+
+func (s *storageService) SaveRatePlan(ctx context.Context, tenantID string, plan *RatePlan) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if err := validateRatePlan(plan); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return s.storage.SaveRatePlan(ctx, tenantID, plan)
+}
+
+func (s *storageService) GetDaysOnRack(ctx context.Context, tenantID string, filters StorageFilters) ([]RackDays, int, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, 0, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if filters.Limit <= 0 {
+		filters.Limit = 50
+	}
+	if filters.Limit > 1000 {
+		return nil, 0, fmt.Errorf("limit too large: %d (max 1000)", filters.Limit)
+	}
+	if filters.Offset < 0 {
+		return nil, 0, fmt.Errorf("offset cannot be negative: %d", filters.Offset)
+	}
+
+	asOf := s.asOf(filters)
+	lines, err := s.storage.GetStorageLines(ctx, tenantID, asOf, asOf.AddDate(0, 0, 1), filters.CustomerID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	days := make([]RackDays, 0, len(lines))
+	for _, line := range lines {
+		days = append(days, rackDays(line, asOf))
+	}
+	sort.SliceStable(days, func(i, j int) bool {
+		return days[i].Days > days[j].Days
+	})
+
+	total := len(days)
+	if filters.Offset >= total {
+		return []RackDays{}, total, nil
+	}
+	end := filters.Offset + filters.Limit
+	if end > total {
+		end = total
+	}
+	return days[filters.Offset:end], total, nil
+}
+
+func (s *storageService) GetAgingReport(ctx context.Context, tenantID string, filters StorageFilters) (*AgingReport, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	asOf := s.asOf(filters)
+	lines, err := s.storage.GetStorageLines(ctx, tenantID, asOf, asOf.AddDate(0, 0, 1), filters.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	specs, err := s.weights.GetSizes(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return agingReport(lines, specs, asOf), nil
+}
+
+func (s *storageService) GenerateCharges(ctx context.Context, tenantID string, userID int, req *GenerateChargesRequest) (*StorageRun, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if req == nil {
+		return nil, fmt.Errorf("validation failed: a period is required")
+	}
+	from, err := parsePeriod(req.Period)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	to := from.AddDate(0, 1, 0)
+
+	// A month is charged once it is over, so rows still on rack are charged
+	// for the whole of it
+	if dateOnly(s.now()).Before(to) {
+		return nil, fmt.Errorf("%w: %s runs until %s", ErrPeriodNotEnded, req.Period, to.AddDate(0, 0, -1).Format("2006-01-02"))
+	}
+
+	lines, err := s.storage.GetStorageLines(ctx, tenantID, from, to, req.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	plans, err := s.storage.GetRatePlans(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	specs, err := s.weights.GetSizes(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	charges, unpriced := priceStorage(lines, plans, specs, from, to)
+	for i := range charges {
+		charges[i].TenantID = tenantID
+		charges[i].GeneratedByUserID = userID
+	}
+
+	if err := s.storage.ReplaceCharges(ctx, tenantID, from, req.CustomerID, charges); err != nil {
+		return nil, err
+	}
+
+	run := buildStorageRun(charges, from)
+	run.Unpriced = unpriced
+	return run, nil
+}
+
+func (s *storageService) GetCharges(ctx context.Context, tenantID, period string, customerID *int) (*StorageRun, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	from, err := parsePeriod(period)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	charges, err := s.storage.GetCharges(ctx, tenantID, from, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildStorageRun(charges, from), nil
+}
+
+func (s *storageService) asOf(filters StorageFilters) time.Time {
+	if filters.AsOf.IsZero() {
+		return dateOnly(s.now())
+	}
+	return dateOnly(filters.AsOf)
+}
+
+// dateOnly drops the time of day, keeping the calendar date
+func dateOnly(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// daysBetween counts the calendar days from one date to a later one
+func daysBetween(from, to time.Time) int {
+	return int(math.Round(dateOnly(to).Sub(dateOnly(from)).Hours() / 24))
+}
+
+// parsePeriod reads a month written "2006-01" as its first day
+func parsePeriod(period string) (time.Time, error) {
+	start, err := time.Parse("2006-01", strings.TrimSpace(period))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("period must be a month written YYYY-MM: %q", period)
+	}
+	return start, nil
+}
+
+// DaysOnRack counts the days a row has been on rack as of a date. The day
+// it came in counts and the day it left does not, so pipe received and
+// shipped the same day was never on rack.
+func DaysOnRack(dateIn time.Time, dateOut *time.Time, asOf time.Time) int {
+	end := dateOnly(asOf).AddDate(0, 0, 1)
+	if dateOut != nil && dateOnly(*dateOut).Before(end) {
+		end = dateOnly(*dateOut)
+	}
+	if days := daysBetween(dateIn, end); days > 0 {
+		return days
+	}
+	return 0
+}
+
+// BillableDays counts the days in [from, to) that a row was on rack once
+// its free days had run out, counting days as DaysOnRack does
+func BillableDays(dateIn time.Time, dateOut *time.Time, freeDays int, from, to time.Time) int {
+	start := dateOnly(dateIn).AddDate(0, 0, freeDays)
+	if dateOnly(from).After(start) {
+		start = dateOnly(from)
+	}
+
+	end := dateOnly(to)
+	if dateOut != nil && dateOnly(*dateOut).Before(end) {
+		end = dateOnly(*dateOut)
+	}
+
+	if days := daysBetween(start, end); days > 0 {
+		return days
+	}
+	return 0
+}
+
+// AgingBucketFor names the bucket a number of days on rack falls in
+func AgingBucketFor(days int) string {
+	switch {
+	case days <= 30:
+		return Aging0To30
+	case days <= 90:
+		return Aging31To90
+	default:
+		return AgingOver90
+	}
+}
+
+func rackDays(line StorageLine, asOf time.Time) RackDays {
+	days := DaysOnRack(line.DateIn, line.DateOut, asOf)
+	return RackDays{
+		ItemID:     line.ItemID,
+		RNumber:    line.RNumber,
+		CustomerID: line.CustomerID,
+		Customer:   line.Customer,
+		Joints:     line.Joints,
+		Rack:       line.Rack,
+		Location:   line.Location,
+		DateIn:     line.DateIn,
+		DateOut:    line.DateOut,
+		Days:       days,
+		Bucket:     AgingBucketFor(days),
+	}
+}
+
+func emptyAgingBuckets() []AgingBucket {
+	return []AgingBucket{{Bucket: Aging0To30}, {Bucket: Aging31To90}, {Bucket: AgingOver90}}
+}
+
+// agingReport buckets the rows on rack by days on rack, for the yard and
+// for each customer
+func agingReport(lines []StorageLine, specs []weight.Spec, asOf time.Time) *AgingReport {
+	report := &AgingReport{AsOf: asOf, Totals: emptyAgingBuckets(), Customers: []CustomerAging{}}
+	rows := make(map[int]int) // Customer ID, zero for unassigned pipe → index in report.Customers
+
+	for _, line := range lines {
+		index := 0
+		switch AgingBucketFor(DaysOnRack(line.DateIn, line.DateOut, asOf)) {
+		case Aging31To90:
+			index = 1
+		case AgingOver90:
+			index = 2
+		}
+		tons := weighLine(line.WeightLine, specs, weight.DefaultTolerance).ShortTons
+
+		key := 0
+		if line.CustomerID != nil {
+			key = *line.CustomerID
+		}
+		row, ok := rows[key]
+		if !ok {
+			report.Customers = append(report.Customers, CustomerAging{
+				CustomerID: line.CustomerID,
+				Customer:   line.Customer,
+				Buckets:    emptyAgingBuckets(),
+			})
+			row = len(report.Customers) - 1
+			rows[key] = row
+		}
+
+		for _, bucket := range []*AgingBucket{&report.Totals[index], &report.Customers[row].Buckets[index]} {
+			bucket.Items++
+			bucket.Joints += line.Joints
+			bucket.ShortTons += tons
+		}
+	}
+
+	return report
+}
+
+// priceStorage charges each customer's rows under the customer's active
+// rate plan, or the yard's default. Rows with no customer are not charged;
+// customers with no plan to charge under are returned as unpriced.
+func priceStorage(lines []StorageLine, plans []RatePlan, specs []weight.Spec, from, to time.Time) ([]StorageCharge, []int) {
+	var fallback *RatePlan
+	byCustomer := make(map[int]*RatePlan)
+	for i := range plans {
+		plan := &plans[i]
+		if !plan.Active {
+			continue
+		}
+		if plan.CustomerID == nil {
+			fallback = plan
+		} else {
+			byCustomer[*plan.CustomerID] = plan
+		}
+	}
+
+	rows := make(map[int][]StorageLine)
+	names := make(map[int]*string)
+	var customerIDs []int
+	for _, line := range lines {
+		if line.CustomerID == nil {
+			continue
+		}
+		id := *line.CustomerID
+		if _, ok := rows[id]; !ok {
+			customerIDs = append(customerIDs, id)
+			names[id] = line.Customer
+		}
+		rows[id] = append(rows[id], line)
+	}
+	sort.Ints(customerIDs)
+
+	charges := []StorageCharge{}
+	unpriced := []int{}
+	daysInPeriod := daysBetween(from, to)
+	for _, customerID := range customerIDs {
+		plan := byCustomer[customerID]
+		if plan == nil {
+			plan = fallback
+		}
+		if plan == nil {
+			unpriced = append(unpriced, customerID)
+			continue
+		}
+
+		var statement []StorageCharge
+		var total float64
+		for _, line := range rows[customerID] {
+			days := BillableDays(line.DateIn, line.DateOut, plan.FreeDays, from, to)
+			if days == 0 || line.Joints == 0 {
+				continue
+			}
+			charge := priceLine(line, plan, specs, days, daysInPeriod)
+			total += charge.Amount
+			statement = append(statement, charge)
+		}
+
+		if total > 0 && total < plan.MinimumCharge {
+			topUp := roundCents(plan.MinimumCharge - total)
+			statement = append(statement, StorageCharge{
+				Description: fmt.Sprintf("Storage minimum - %s (%s)", plan.Name, formatMoney(plan.MinimumCharge)),
+				Quantity:    1,
+				UnitPrice:   topUp,
+				Amount:      topUp,
+			})
+		}
+
+		for i := range statement {
+			statement[i].PeriodStart = from
+			statement[i].CustomerID = customerID
+			statement[i].Customer = names[customerID]
+			statement[i].RatePlanID = plan.ID
+			statement[i].SortOrder = i + 1
+		}
+		charges = append(charges, statement...)
+	}
+
+	return charges, unpriced
+}
+
+// priceLine charges one row for its billable days in the period
+func priceLine(line StorageLine, plan *RatePlan, specs []weight.Spec, days, daysInPeriod int) StorageCharge {
+	itemID := line.ItemID
+	charge := StorageCharge{
+		ItemID:       &itemID,
+		RNumber:      line.RNumber,
+		Joints:       line.Joints,
+		BillableDays: days,
+		UnitPrice:    plan.Rate,
+	}
+	label := describeStorageLine(line)
+
+	switch plan.RateBasis {
+	case RatePerTonMonth:
+		tons := roundTo(weighLine(line.WeightLine, specs, weight.DefaultTolerance).ShortTons, 4)
+		charge.ShortTons = &tons
+		charge.Quantity = roundTo(tons*float64(days)/float64(daysInPeriod), 4)
+		charge.Description = fmt.Sprintf("Rack rent %s - %.4f tons × %d/%d days", label, tons, days, daysInPeriod)
+		if tons == 0 {
+			charge.Description += " (weight unknown)"
+		}
+	default:
+		charge.Quantity = float64(line.Joints * days)
+		charge.Description = fmt.Sprintf("Rack rent %s - %d jts × %d days", label, line.Joints, days)
+	}
+
+	charge.Amount = roundCents(charge.Quantity * plan.Rate)
+	return charge
+}
+
+// describeStorageLine names a row on an invoice: its R-number, size and grade
+func describeStorageLine(line StorageLine) string {
+	parts := []string{}
+	if line.RNumber != nil && *line.RNumber != "" {
+		parts = append(parts, *line.RNumber)
+	} else {
+		parts = append(parts, fmt.Sprintf("item %d", line.ItemID))
+	}
+	if line.Size != nil && *line.Size != "" {
+		parts = append(parts, *line.Size)
+	}
+	if line.Grade != nil && *line.Grade != "" {
+		parts = append(parts, *line.Grade)
+	}
+	return strings.Join(parts, " ")
+}
+
+// InvoiceLine is the charge as a line on a customer invoice
+func (c StorageCharge) InvoiceLine() invoice.Line {
+	return invoice.Line{
+		LineType:    invoice.LineStorage,
+		Description: c.Description,
+		Quantity:    c.Quantity,
+		UnitPrice:   c.UnitPrice,
+		Amount:      c.Amount,
+		SortOrder:   c.SortOrder,
+	}
+}
+
+// buildStorageRun groups charges, in customer and sort order, into one
+// statement per customer
+func buildStorageRun(charges []StorageCharge, from time.Time) *StorageRun {
+	periodEnd := from.AddDate(0, 1, -1)
+	run := &StorageRun{PeriodStart: from, PeriodEnd: periodEnd, Statements: []StorageStatement{}, Unpriced: []int{}}
+
+	for _, charge := range charges {
+		n := len(run.Statements)
+		if n == 0 || run.Statements[n-1].CustomerID != charge.CustomerID {
+			run.Statements = append(run.Statements, StorageStatement{
+				CustomerID:   charge.CustomerID,
+				Customer:     charge.Customer,
+				PeriodStart:  from,
+				PeriodEnd:    periodEnd,
+				Charges:      []StorageCharge{},
+				InvoiceLines: []invoice.Line{},
+			})
+			n++
+		}
+
+		statement := &run.Statements[n-1]
+		statement.Charges = append(statement.Charges, charge)
+		statement.InvoiceLines = append(statement.InvoiceLines, charge.InvoiceLine())
+		statement.Total = roundCents(statement.Total + charge.Amount)
+		run.Total = roundCents(run.Total + charge.Amount)
+	}
+
+	return run
+}
+
+func validateRatePlan(plan *RatePlan) error {
+	if plan == nil {
+		return fmt.Errorf("rate plan is required")
+	}
+
+	plan.Name = strings.TrimSpace(plan.Name)
+	if plan.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(plan.Name) > 100 {
+		return fmt.Errorf("name too long (max 100 characters)")
+	}
+	if plan.CustomerID != nil && *plan.CustomerID <= 0 {
+		return fmt.Errorf("invalid customer ID: %d", *plan.CustomerID)
+	}
+
+	switch plan.RateBasis {
+	case RatePerJointDay, RatePerTonMonth:
+	default:
+		return fmt.Errorf("rate basis must be %s or %s", RatePerJointDay, RatePerTonMonth)
+	}
+
+	if plan.Rate < 0 {
+		return fmt.Errorf("rate cannot be negative")
+	}
+	if plan.FreeDays < 0 || plan.FreeDays > maxFreeDays {
+		return fmt.Errorf("free days must be between 0 and %d", maxFreeDays)
+	}
+	if plan.MinimumCharge < 0 {
+		return fmt.Errorf("minimum charge cannot be negative")
+	}
+
+	plan.Rate = roundTo(plan.Rate, 4)
+	plan.MinimumCharge = roundCents(plan.MinimumCharge)
+	return nil
+}
+
+func roundTo(value float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(value*scale) / scale
+}
+
+func roundCents(amount float64) float64 {
+	return roundTo(amount, 2)
+}
+
+func formatMoney(amount float64) string {
+	return fmt.Sprintf("$%.2f", amount)
+}
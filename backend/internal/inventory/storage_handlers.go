@@ -0,0 +1,204 @@
+// backend/internal/inventory/storage_handlers.go
+package inventory
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"oilgas-backend/internal/auth"
+)
+
+type StorageHandlers struct {
+	service StorageService
+}
+
+func NewStorageHandlers(service StorageService) *StorageHandlers {
+	return &StorageHandlers{service: service}
+}
+
+func (h *StorageHandlers) RegisterRoutes(router *gin.RouterGroup, authMiddleware *auth.Middleware) {
+	staff := authMiddleware.RequireRole(auth.RoleOperator, auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin)
+	managers := authMiddleware.RequireRole(auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin)
+
+	storage := router.Group("/storage")
+	storage.Use(authMiddleware.RequireAuth())
+	storage.Use(staff)
+
+	storage.GET("/days-on-rack", h.GetDaysOnRack)
+	storage.GET("/aging", h.GetAgingReport)
+	storage.GET("/charges", h.GetCharges)
+	storage.GET("/rate-plans", h.GetRatePlans)
+
+	// Pricing and billing storage is a manager's call
+	storage.POST("/charges", managers, h.GenerateCharges)
+	storage.POST("/rate-plans", managers, h.SaveRatePlan)
+	storage.PUT("/rate-plans/:id", managers, h.SaveRatePlan)
+}
+
+// storageFilters reads ?customer_id= and ?as_of=YYYY-MM-DD
+func storageFilters(c *gin.Context) (StorageFilters, bool) {
+	var filters StorageFilters
+
+	if raw := c.Query("customer_id"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid customer ID"})
+			return filters, false
+		}
+		filters.CustomerID = &parsed
+	}
+
+	if raw := c.Query("as_of"); raw != "" {
+		asOf, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid as_of date, expected YYYY-MM-DD"})
+			return filters, false
+		}
+		filters.AsOf = asOf
+	}
+
+	return filters, true
+}
+
+// GetDaysOnRack lists how long each row has been on rack, longest first
+func (h *StorageHandlers) GetDaysOnRack(c *gin.Context) {
+	filters, ok := storageFilters(c)
+	if !ok {
+		return
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filters.Limit = l
+		}
+	}
+
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filters.Offset = o
+		}
+	}
+
+	days, total, err := h.service.GetDaysOnRack(c.Request.Context(), c.GetString("tenant_id"), filters)
+	if err != nil {
+		c.JSON(storageErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  days,
+		"total": total,
+	})
+}
+
+// GetAgingReport buckets the pipe on rack into 0-30, 31-90 and 90+ days
+func (h *StorageHandlers) GetAgingReport(c *gin.Context) {
+	filters, ok := storageFilters(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.GetAgingReport(c.Request.Context(), c.GetString("tenant_id"), filters)
+	if err != nil {
+		c.JSON(storageErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetCharges returns a month's generated storage charges; ?period=YYYY-MM
+func (h *StorageHandlers) GetCharges(c *gin.Context) {
+	filters, ok := storageFilters(c)
+	if !ok {
+		return
+	}
+
+	run, err := h.service.GetCharges(c.Request.Context(), c.GetString("tenant_id"), c.Query("period"), filters.CustomerID)
+	if err != nil {
+		c.JSON(storageErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+// GenerateCharges prices a finished month's storage, replacing any charges
+// generated for it before
+func (h *StorageHandlers) GenerateCharges(c *gin.Context) {
+	var req GenerateChargesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	run, err := h.service.GenerateCharges(c.Request.Context(), c.GetString("tenant_id"), c.GetInt("user_id"), &req)
+	if err != nil {
+		c.JSON(storageErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, run)
+}
+
+func (h *StorageHandlers) GetRatePlans(c *gin.Context) {
+	plans, err := h.service.GetRatePlans(c.Request.Context(), c.GetString("tenant_id"))
+	if err != nil {
+		c.JSON(storageErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  plans,
+		"total": len(plans),
+	})
+}
+
+// SaveRatePlan creates a plan, or updates the one named by :id. A plan
+// without a customer is the yard's default.
+func (h *StorageHandlers) SaveRatePlan(c *gin.Context) {
+	id := 0
+	if raw := c.Param("id"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rate plan ID"})
+			return
+		}
+		id = parsed
+	}
+
+	plan := RatePlan{Active: true}
+	if err := c.ShouldBindJSON(&plan); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	plan.ID = id
+
+	if err := h.service.SaveRatePlan(c.Request.Context(), c.GetString("tenant_id"), &plan); err != nil {
+		c.JSON(storageErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if id == 0 {
+		c.JSON(http.StatusCreated, plan)
+		return
+	}
+	c.JSON(http.StatusOK, plan)
+}
+
+func storageErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrRatePlanNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrRatePlanExists):
+		return http.StatusConflict
+	case errors.Is(err, ErrPeriodNotEnded):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusBadRequest
+	}
+}
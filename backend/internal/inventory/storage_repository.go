@@ -0,0 +1,273 @@
+// backend/internal/inventory/storage_repository.go
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"oilgas-backend/internal/shared/database"
+)
+
+type StorageRepository interface {
+	// GetRatePlans returns every plan, active ones first
+	GetRatePlans(ctx context.Context, tenantID string) ([]RatePlan, error)
+	SaveRatePlan(ctx context.Context, tenantID string, plan *RatePlan) error
+
+	// GetStorageLines returns the rows on rack at some point in [from, to),
+	// with what they weigh. Rows without a date in cannot be aged and are
+	// left out.
+	GetStorageLines(ctx context.Context, tenantID string, from, to time.Time, customerID *int) ([]StorageLine, error)
+
+	// ReplaceCharges swaps a month's charges, for every customer or just
+	// one, for the given ones in one transaction
+	ReplaceCharges(ctx context.Context, tenantID string, periodStart time.Time, customerID *int, charges []StorageCharge) error
+	GetCharges(ctx context.Context, tenantID string, periodStart time.Time, customerID *int) ([]StorageCharge, error)
+}
+
+type storageRepository struct {
+	dbManager *database.DatabaseManager
+}
+
+func NewStorageRepository(dbManager *database.DatabaseManager) StorageRepository {
+	return &storageRepository{dbManager: dbManager}
+}
+
+const ratePlanColumns = `
+	id, tenant_id, customer_id, name, rate_basis, rate, free_days, minimum_charge,
+	active, created_at, updated_at`
+
+const storageChargeColumns = `
+	sc.id, sc.tenant_id, sc.period_start, sc.customer_id, sc.rate_plan_id, sc.inventory_item_id,
+	sc.r_number, sc.description, sc.joints, sc.billable_days, sc.short_tons, sc.quantity,
+	sc.unit_price, sc.amount, sc.sort_order, sc.generated_by_user_id, sc.generated_at`
+
+// storageLineQuery is weightLineQuery with the columns storage is billed on
+const storageLineQuery = `
+	SELECT i.id, i.r_number, i.customer_id, i.customer, COALESCE(i.joints, 0),
+	       i.size, i.weight, i.grade, i.rack, i.location, i.average_joint_length,
+	       COALESCE(t.joints, 0), COALESCE(t.feet, 0),
+	       i.work_order, i.date_in, i.date_out
+	FROM store.inventory i
+	LEFT JOIN (
+		SELECT inventory_item_id, COUNT(*) AS joints, SUM(length_ft) AS feet
+		FROM store.joint_tallies
+		WHERE tenant_id = $1
+		GROUP BY inventory_item_id
+	) t ON t.inventory_item_id = i.id
+	WHERE i.tenant_id = $1 AND i.deleted = false
+	  AND i.date_in IS NOT NULL AND i.date_in < $3
+	  AND (i.date_out IS NULL OR i.date_out > $2)`
+
+func scanRatePlan(row rowScanner) (*RatePlan, error) {
+	var p RatePlan
+	err := row.Scan(
+		&p.ID, &p.TenantID, &p.CustomerID, &p.Name, &p.RateBasis, &p.Rate, &p.FreeDays, &p.MinimumCharge,
+		&p.Active, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func scanStorageLine(row rowScanner) (*StorageLine, error) {
+	var l StorageLine
+	err := row.Scan(
+		&l.ItemID, &l.RNumber, &l.CustomerID, &l.Customer, &l.Joints,
+		&l.Size, &l.Weight, &l.Grade, &l.Rack, &l.Location, &l.AverageLength,
+		&l.TalliedJoints, &l.TalliedFeet,
+		&l.WorkOrder, &l.DateIn, &l.DateOut,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (r *storageRepository) GetRatePlans(ctx context.Context, tenantID string) ([]RatePlan, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT`+ratePlanColumns+`
+		FROM store.storage_rate_plans
+		WHERE tenant_id = $1
+		ORDER BY active DESC, customer_id NULLS FIRST, id`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rate plans: %w", err)
+	}
+	defer rows.Close()
+
+	plans := []RatePlan{}
+	for rows.Next() {
+		plan, err := scanRatePlan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rate plan: %w", err)
+		}
+		plans = append(plans, *plan)
+	}
+
+	return plans, rows.Err()
+}
+
+func (r *storageRepository) SaveRatePlan(ctx context.Context, tenantID string, plan *RatePlan) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	var saved *RatePlan
+	if plan.ID == 0 {
+		saved, err = scanRatePlan(db.QueryRowContext(ctx, `
+			INSERT INTO store.storage_rate_plans (
+				tenant_id, customer_id, name, rate_basis, rate, free_days, minimum_charge, active
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING`+ratePlanColumns,
+			tenantID, plan.CustomerID, plan.Name, plan.RateBasis, plan.Rate, plan.FreeDays, plan.MinimumCharge, plan.Active))
+	} else {
+		saved, err = scanRatePlan(db.QueryRowContext(ctx, `
+			UPDATE store.storage_rate_plans
+			SET customer_id = $3, name = $4, rate_basis = $5, rate = $6, free_days = $7,
+			    minimum_charge = $8, active = $9, updated_at = NOW()
+			WHERE id = $1 AND tenant_id = $2
+			RETURNING`+ratePlanColumns,
+			plan.ID, tenantID, plan.CustomerID, plan.Name, plan.RateBasis, plan.Rate, plan.FreeDays, plan.MinimumCharge, plan.Active))
+	}
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrRatePlanNotFound
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return ErrRatePlanExists
+		}
+		return fmt.Errorf("failed to save rate plan: %w", err)
+	}
+
+	*plan = *saved
+	return nil
+}
+
+func (r *storageRepository) GetStorageLines(ctx context.Context, tenantID string, from, to time.Time, customerID *int) ([]StorageLine, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	query := storageLineQuery
+	args := []interface{}{tenantID, from, to}
+	if customerID != nil {
+		args = append(args, *customerID)
+		query += fmt.Sprintf(" AND i.customer_id = $%d", len(args))
+	}
+	query += " ORDER BY i.customer_id, i.date_in, i.id"
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage lines: %w", err)
+	}
+	defer rows.Close()
+
+	lines := []StorageLine{}
+	for rows.Next() {
+		line, err := scanStorageLine(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan storage line: %w", err)
+		}
+		lines = append(lines, *line)
+	}
+
+	return lines, rows.Err()
+}
+
+func (r *storageRepository) ReplaceCharges(ctx context.Context, tenantID string, periodStart time.Time, customerID *int, charges []StorageCharge) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `DELETE FROM store.storage_charges WHERE tenant_id = $1 AND period_start = $2`
+	args := []interface{}{tenantID, periodStart}
+	if customerID != nil {
+		query += ` AND customer_id = $3`
+		args = append(args, *customerID)
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to clear storage charges: %w", err)
+	}
+
+	for i := range charges {
+		c := &charges[i]
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO store.storage_charges (
+				tenant_id, period_start, customer_id, rate_plan_id, inventory_item_id, r_number,
+				description, joints, billable_days, short_tons, quantity, unit_price, amount,
+				sort_order, generated_by_user_id
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+			RETURNING id, generated_at`,
+			tenantID, c.PeriodStart, c.CustomerID, c.RatePlanID, c.ItemID, c.RNumber,
+			c.Description, c.Joints, c.BillableDays, c.ShortTons, c.Quantity, c.UnitPrice, c.Amount,
+			c.SortOrder, c.GeneratedByUserID,
+		).Scan(&c.ID, &c.GeneratedAt)
+		if err != nil {
+			return fmt.Errorf("failed to record storage charge: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit storage charges: %w", err)
+	}
+	return nil
+}
+
+func (r *storageRepository) GetCharges(ctx context.Context, tenantID string, periodStart time.Time, customerID *int) ([]StorageCharge, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	query := `
+		SELECT` + storageChargeColumns + `, c.name
+		FROM store.storage_charges sc
+		LEFT JOIN store.customers c ON c.id = sc.customer_id
+		WHERE sc.tenant_id = $1 AND sc.period_start = $2`
+	args := []interface{}{tenantID, periodStart}
+	if customerID != nil {
+		query += ` AND sc.customer_id = $3`
+		args = append(args, *customerID)
+	}
+	query += ` ORDER BY sc.customer_id, sc.sort_order`
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage charges: %w", err)
+	}
+	defer rows.Close()
+
+	charges := []StorageCharge{}
+	for rows.Next() {
+		var c StorageCharge
+		err := rows.Scan(
+			&c.ID, &c.TenantID, &c.PeriodStart, &c.CustomerID, &c.RatePlanID, &c.ItemID,
+			&c.RNumber, &c.Description, &c.Joints, &c.BillableDays, &c.ShortTons, &c.Quantity,
+			&c.UnitPrice, &c.Amount, &c.SortOrder, &c.GeneratedByUserID, &c.GeneratedAt, &c.Customer,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan storage charge: %w", err)
+		}
+		charges = append(charges, c)
+	}
+
+	return charges, rows.Err()
+}
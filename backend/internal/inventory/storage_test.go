@@ -0,0 +1,322 @@
+// backend/internal/inventory/storage_test.go
+package inventory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"oilgas-backend/internal/invoice"
+	"oilgas-backend/internal/shared/weight"
+)
+
+type mockStorageRepository struct {
+	mock.Mock
+}
+
+func (m *mockStorageRepository) GetRatePlans(ctx context.Context, tenantID string) ([]RatePlan, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).([]RatePlan), args.Error(1)
+}
+
+func (m *mockStorageRepository) SaveRatePlan(ctx context.Context, tenantID string, plan *RatePlan) error {
+	return m.Called(ctx, tenantID, plan).Error(0)
+}
+
+func (m *mockStorageRepository) GetStorageLines(ctx context.Context, tenantID string, from, to time.Time, customerID *int) ([]StorageLine, error) {
+	args := m.Called(ctx, tenantID, from, to, customerID)
+	return args.Get(0).([]StorageLine), args.Error(1)
+}
+
+func (m *mockStorageRepository) ReplaceCharges(ctx context.Context, tenantID string, periodStart time.Time, customerID *int, charges []StorageCharge) error {
+	return m.Called(ctx, tenantID, periodStart, customerID, charges).Error(0)
+}
+
+func (m *mockStorageRepository) GetCharges(ctx context.Context, tenantID string, periodStart time.Time, customerID *int) ([]StorageCharge, error) {
+	args := m.Called(ctx, tenantID, periodStart, customerID)
+	return args.Get(0).([]StorageCharge), args.Error(1)
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func datePtr(year int, month time.Month, day int) *time.Time {
+	d := date(year, month, day)
+	return &d
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func storageLine(itemID, customerID, joints int, size string, perFoot float64, dateIn time.Time, dateOut *time.Time) StorageLine {
+	return StorageLine{
+		WeightLine: WeightLine{
+			ItemID:     itemID,
+			RNumber:    strPtr("R-" + time.Unix(int64(itemID), 0).UTC().Format("150405")),
+			CustomerID: &customerID,
+			Customer:   strPtr("Customer"),
+			Joints:     joints,
+			Size:       strPtr(size),
+			Weight:     &perFoot,
+		},
+		DateIn:  dateIn,
+		DateOut: dateOut,
+	}
+}
+
+type StorageServiceTestSuite struct {
+	suite.Suite
+	service  StorageService
+	storage  *mockStorageRepository
+	weights  *mockWeightRepository
+	ctx      context.Context
+	tenantID string
+}
+
+func (suite *StorageServiceTestSuite) SetupTest() {
+	suite.storage = &mockStorageRepository{}
+	suite.weights = &mockWeightRepository{}
+	suite.service = NewStorageService(suite.storage, suite.weights, func() time.Time {
+		return time.Date(2026, time.October, 16, 9, 30, 0, 0, time.UTC)
+	})
+	suite.ctx = context.Background()
+	suite.tenantID = "longbeach"
+
+	suite.weights.On("GetSizes", suite.ctx, suite.tenantID).Return([]weight.Spec{
+		{Size: `5 1/2" 17#`, NominalSize: strPtr(`5 1/2"`), OuterDiameter: floatPtr(5.5), InnerDiameter: floatPtr(4.892), WeightPerFoot: floatPtr(17)},
+	}, nil).Maybe()
+}
+
+func TestStorageServiceSuite(t *testing.T) {
+	suite.Run(t, new(StorageServiceTestSuite))
+}
+
+func TestDaysOnRack(t *testing.T) {
+	testCases := []struct {
+		name    string
+		dateIn  time.Time
+		dateOut *time.Time
+		asOf    time.Time
+		want    int
+	}{
+		{"received today", date(2026, 10, 16), nil, date(2026, 10, 16), 1},
+		{"still on rack", date(2026, 9, 1), nil, date(2026, 10, 16), 46},
+		{"shipped", date(2026, 9, 1), datePtr(2026, 9, 11), date(2026, 10, 16), 10},
+		{"in and out the same day", date(2026, 9, 1), datePtr(2026, 9, 1), date(2026, 10, 16), 0},
+		{"not yet received", date(2026, 11, 1), nil, date(2026, 10, 16), 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, DaysOnRack(tc.dateIn, tc.dateOut, tc.asOf))
+		})
+	}
+}
+
+func TestBillableDays(t *testing.T) {
+	from, to := date(2026, 9, 1), date(2026, 10, 1)
+
+	testCases := []struct {
+		name     string
+		dateIn   time.Time
+		dateOut  *time.Time
+		freeDays int
+		want     int
+	}{
+		{"whole month", date(2026, 8, 1), nil, 0, 30},
+		{"free days used up before the month", date(2026, 8, 1), nil, 10, 30},
+		{"free days run into the month", date(2026, 8, 25), nil, 10, 27},
+		{"received mid-month", date(2026, 9, 10), nil, 0, 21},
+		{"shipped mid-month", date(2026, 8, 1), datePtr(2026, 9, 5), 0, 4},
+		{"shipped inside its free days", date(2026, 9, 10), datePtr(2026, 9, 15), 7, 0},
+		{"shipped before the month", date(2026, 8, 1), datePtr(2026, 8, 20), 0, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, BillableDays(tc.dateIn, tc.dateOut, tc.freeDays, from, to))
+		})
+	}
+}
+
+func (suite *StorageServiceTestSuite) TestGenerateCharges_AppliesRatePlans() {
+	from, to := date(2026, 9, 1), date(2026, 10, 1)
+	lines := []StorageLine{
+		storageLine(501, 12, 100, `5 1/2"`, 17, date(2026, 8, 1), nil),
+		storageLine(502, 12, 20, `5 1/2"`, 17, date(2026, 9, 10), datePtr(2026, 9, 25)),
+		storageLine(601, 14, 10, `5 1/2"`, 17, date(2026, 9, 16), nil),
+	}
+	plans := []RatePlan{
+		{ID: 1, Name: "Yard default", RateBasis: RatePerTonMonth, Rate: 8, MinimumCharge: 50, Active: true},
+		{ID: 2, CustomerID: intPtr(12), Name: "Contract", RateBasis: RatePerJointDay, Rate: 0.05, FreeDays: 5, MinimumCharge: 25, Active: true},
+		{ID: 3, CustomerID: intPtr(14), Name: "Expired", RateBasis: RatePerJointDay, Rate: 1, Active: false},
+	}
+	suite.storage.On("GetStorageLines", suite.ctx, suite.tenantID, from, to, (*int)(nil)).Return(lines, nil)
+	suite.storage.On("GetRatePlans", suite.ctx, suite.tenantID).Return(plans, nil)
+
+	var saved []StorageCharge
+	suite.storage.On("ReplaceCharges", suite.ctx, suite.tenantID, from, (*int)(nil), mock.Anything).
+		Run(func(args mock.Arguments) { saved = args.Get(4).([]StorageCharge) }).
+		Return(nil)
+
+	run, err := suite.service.GenerateCharges(suite.ctx, suite.tenantID, 7, &GenerateChargesRequest{Period: " 2026-09 "})
+
+	suite.NoError(err)
+	suite.Require().Len(saved, 4)
+	suite.Equal(date(2026, 9, 30), run.PeriodEnd)
+	suite.Require().Len(run.Statements, 2)
+
+	// 100 joints for all 30 days, and 20 joints for the 10 days after their
+	// free days until they shipped
+	contract := run.Statements[0]
+	suite.Equal(12, contract.CustomerID)
+	suite.Require().Len(contract.Charges, 2)
+	suite.Equal(30, contract.Charges[0].BillableDays)
+	suite.InDelta(3000, contract.Charges[0].Quantity, 1e-9)
+	suite.InDelta(150.00, contract.Charges[0].Amount, 1e-9)
+	suite.Equal(10, contract.Charges[1].BillableDays)
+	suite.InDelta(10.00, contract.Charges[1].Amount, 1e-9)
+	suite.InDelta(160.00, contract.Total, 1e-9)
+	suite.Equal(2, contract.Charges[0].RatePlanID)
+
+	// 10 joints × 31 ft × 17 lb/ft is 2.635 tons, on rack half the month,
+	// topped up to the default plan's $50 minimum
+	fallback := run.Statements[1]
+	suite.Equal(14, fallback.CustomerID)
+	suite.Require().Len(fallback.Charges, 2)
+	suite.InDelta(2.635, *fallback.Charges[0].ShortTons, 1e-9)
+	suite.InDelta(1.3175, fallback.Charges[0].Quantity, 1e-9)
+	suite.InDelta(10.54, fallback.Charges[0].Amount, 1e-9)
+	suite.Nil(fallback.Charges[1].ItemID)
+	suite.InDelta(39.46, fallback.Charges[1].Amount, 1e-9)
+	suite.InDelta(50.00, fallback.Total, 1e-9)
+	suite.Equal(1, fallback.Charges[1].RatePlanID)
+
+	suite.InDelta(210.00, run.Total, 1e-9)
+	suite.Empty(run.Unpriced)
+
+	line := fallback.InvoiceLines[1]
+	suite.Equal(invoice.LineStorage, line.LineType)
+	suite.Equal(2, line.SortOrder)
+	suite.InDelta(39.46, line.Amount, 1e-9)
+
+	for _, charge := range saved {
+		suite.Equal(suite.tenantID, charge.TenantID)
+		suite.Equal(7, charge.GeneratedByUserID)
+		suite.Equal(from, charge.PeriodStart)
+	}
+}
+
+func (suite *StorageServiceTestSuite) TestGenerateCharges_ReportsUnpricedCustomers() {
+	from, to := date(2026, 9, 1), date(2026, 10, 1)
+	customerID := 16
+	unassigned := storageLine(701, 0, 40, `5 1/2"`, 17, date(2026, 8, 1), nil)
+	unassigned.CustomerID = nil
+	lines := []StorageLine{
+		storageLine(702, 16, 40, `5 1/2"`, 17, date(2026, 8, 1), nil),
+		unassigned,
+	}
+	suite.storage.On("GetStorageLines", suite.ctx, suite.tenantID, from, to, &customerID).Return(lines, nil)
+	suite.storage.On("GetRatePlans", suite.ctx, suite.tenantID).Return([]RatePlan{
+		{ID: 2, CustomerID: intPtr(12), Name: "Contract", RateBasis: RatePerJointDay, Rate: 0.05, Active: true},
+	}, nil)
+	suite.storage.On("ReplaceCharges", suite.ctx, suite.tenantID, from, &customerID, []StorageCharge{}).Return(nil)
+
+	run, err := suite.service.GenerateCharges(suite.ctx, suite.tenantID, 7, &GenerateChargesRequest{Period: "2026-09", CustomerID: &customerID})
+
+	suite.NoError(err)
+	suite.Empty(run.Statements)
+	suite.Equal([]int{16}, run.Unpriced)
+}
+
+func (suite *StorageServiceTestSuite) TestGenerateCharges_RejectsAnUnfinishedMonth() {
+	_, err := suite.service.GenerateCharges(suite.ctx, suite.tenantID, 7, &GenerateChargesRequest{Period: "2026-10"})
+	suite.ErrorIs(err, ErrPeriodNotEnded)
+
+	_, err = suite.service.GenerateCharges(suite.ctx, suite.tenantID, 7, &GenerateChargesRequest{Period: "Sept 2026"})
+	suite.Error(err)
+	suite.Contains(err.Error(), "validation failed")
+
+	suite.storage.AssertNotCalled(suite.T(), "GetStorageLines")
+	suite.storage.AssertNotCalled(suite.T(), "ReplaceCharges")
+}
+
+func (suite *StorageServiceTestSuite) TestGetAgingReport_BucketsByDaysOnRack() {
+	asOf := date(2026, 10, 16)
+	lines := []StorageLine{
+		storageLine(501, 12, 100, `5 1/2"`, 17, date(2026, 10, 1), nil),
+		storageLine(502, 12, 20, `5 1/2"`, 17, date(2026, 8, 1), nil),
+		storageLine(503, 12, 50, `5 1/2"`, 17, date(2026, 1, 5), nil),
+		storageLine(601, 14, 10, `5 1/2"`, 17, date(2026, 10, 16), nil),
+	}
+	suite.storage.On("GetStorageLines", suite.ctx, suite.tenantID, asOf, asOf.AddDate(0, 0, 1), (*int)(nil)).Return(lines, nil)
+
+	report, err := suite.service.GetAgingReport(suite.ctx, suite.tenantID, StorageFilters{})
+
+	suite.NoError(err)
+	suite.Equal(asOf, report.AsOf)
+	suite.Equal([]AgingBucket{
+		{Bucket: Aging0To30, Items: 2, Joints: 110, ShortTons: 110 * 31 * 17.0 / 2000},
+		{Bucket: Aging31To90, Items: 1, Joints: 20, ShortTons: 20 * 31 * 17.0 / 2000},
+		{Bucket: AgingOver90, Items: 1, Joints: 50, ShortTons: 50 * 31 * 17.0 / 2000},
+	}, report.Totals)
+	suite.Require().Len(report.Customers, 2)
+	suite.Equal(12, *report.Customers[0].CustomerID)
+	suite.Equal(100, report.Customers[0].Buckets[0].Joints)
+	suite.Equal(10, report.Customers[1].Buckets[0].Joints)
+}
+
+func (suite *StorageServiceTestSuite) TestGetDaysOnRack_LongestFirst() {
+	asOf := date(2026, 9, 30)
+	lines := []StorageLine{
+		storageLine(501, 12, 100, `5 1/2"`, 17, date(2026, 9, 20), nil),
+		storageLine(502, 12, 20, `5 1/2"`, 17, date(2026, 5, 1), nil),
+	}
+	suite.storage.On("GetStorageLines", suite.ctx, suite.tenantID, asOf, asOf.AddDate(0, 0, 1), (*int)(nil)).Return(lines, nil)
+
+	days, total, err := suite.service.GetDaysOnRack(suite.ctx, suite.tenantID, StorageFilters{AsOf: asOf.Add(15 * time.Hour)})
+
+	suite.NoError(err)
+	suite.Equal(2, total)
+	suite.Equal(502, days[0].ItemID)
+	suite.Equal(153, days[0].Days)
+	suite.Equal(AgingOver90, days[0].Bucket)
+	suite.Equal(11, days[1].Days)
+}
+
+func (suite *StorageServiceTestSuite) TestSaveRatePlan_Validates() {
+	testCases := []struct {
+		name string
+		plan *RatePlan
+	}{
+		{"missing plan", nil},
+		{"missing name", &RatePlan{RateBasis: RatePerJointDay, Rate: 0.05}},
+		{"unknown basis", &RatePlan{Name: "Contract", RateBasis: "PER_RACK", Rate: 0.05}},
+		{"negative rate", &RatePlan{Name: "Contract", RateBasis: RatePerJointDay, Rate: -1}},
+		{"too many free days", &RatePlan{Name: "Contract", RateBasis: RatePerJointDay, FreeDays: 900}},
+		{"negative minimum", &RatePlan{Name: "Contract", RateBasis: RatePerTonMonth, MinimumCharge: -5}},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			err := suite.service.SaveRatePlan(suite.ctx, suite.tenantID, tc.plan)
+
+			assert.Error(suite.T(), err)
+			assert.Contains(suite.T(), err.Error(), "validation failed")
+		})
+	}
+
+	plan := &RatePlan{Name: " Contract ", RateBasis: RatePerJointDay, Rate: 0.04567, MinimumCharge: 25.004}
+	suite.storage.On("SaveRatePlan", suite.ctx, suite.tenantID, plan).Return(nil)
+
+	suite.NoError(suite.service.SaveRatePlan(suite.ctx, suite.tenantID, plan))
+	suite.Equal("Contract", plan.Name)
+	suite.Equal(0.0457, plan.Rate)
+	suite.Equal(25.00, plan.MinimumCharge)
+}
@@ -0,0 +1,228 @@
+// backend/internal/inventory/transfer.go
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// relayBatchSize caps the messages one relay pass delivers per yard
+const relayBatchSize = 100
+
+type TransferService interface {
+	// CreateTransfer dates joints out of this yard for another one. They
+	// show as in transit in both yards until the destination racks them.
+	CreateTransfer(ctx context.Context, tenantID string, userID int, req *TransferRequest) (*TransferOrder, error)
+	GetTransfer(ctx context.Context, tenantID string, id int) (*TransferOrder, error)
+	GetTransfers(ctx context.Context, tenantID string, filters TransferFilters) ([]TransferOrder, int, error)
+	GetInTransit(ctx context.Context, tenantID string) ([]TransferOrder, error)
+
+	// CancelTransfer returns the joints to stock; only possible until the
+	// destination yard has accepted the transfer
+	CancelTransfer(ctx context.Context, tenantID string, userID, id int, reason string) (*TransferOrder, error)
+	ReceiveTransfer(ctx context.Context, tenantID string, userID int, req *TransferReceiveRequest) (*TransferOrder, error)
+
+	// RelayOutbox delivers a yard's waiting transfer messages to the other
+	// yards and returns how many went through
+	RelayOutbox(ctx context.Context, tenantID string) (int, error)
+}
+
+type transferService struct {
+	transfers TransferRepository
+}
+
+func NewTransferService(transfers TransferRepository) TransferService {
+	return &transferService{transfers: transfers}
+}
+
+func (s *transferService) CreateTransfer(ctx context.Context, tenantID string, userID int, req *TransferRequest) (*TransferOrder, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if err := validateTransferRequest(tenantID, req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	return s.transfers.CreateTransfer(ctx, tenantID, userID, req)
+}
+
+func (s *transferService) GetTransfer(ctx context.Context, tenantID string, id int) (*TransferOrder, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.transfers.GetTransfer(ctx, tenantID, id)
+}
+
+func (s *transferService) GetTransfers(ctx context.Context, tenantID string, filters TransferFilters) ([]TransferOrder, int, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, 0, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if filters.Direction != nil && *filters.Direction != TransferOutbound && *filters.Direction != TransferInbound {
+		return nil, 0, fmt.Errorf("invalid transfer direction: %s", *filters.Direction)
+	}
+	if filters.Status != nil && !isValidTransferStatus(*filters.Status) {
+		return nil, 0, fmt.Errorf("invalid transfer status: %s", *filters.Status)
+	}
+	if filters.Limit <= 0 {
+		filters.Limit = 50
+	}
+	if filters.Limit > 1000 {
+		return nil, 0, fmt.Errorf("limit too large: %d (max 1000)", filters.Limit)
+	}
+	if filters.Offset < 0 {
+		return nil, 0, fmt.Errorf("offset cannot be negative: %d", filters.Offset)
+	}
+
+	return s.transfers.GetTransfers(ctx, tenantID, filters)
+}
+
+func (s *transferService) GetInTransit(ctx context.Context, tenantID string) ([]TransferOrder, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.transfers.GetInTransit(ctx, tenantID)
+}
+
+func (s *transferService) CancelTransfer(ctx context.Context, tenantID string, userID, id int, reason string) (*TransferOrder, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if len(reason) > 1000 {
+		return nil, fmt.Errorf("validation failed: cancel reason too long (max 1000 characters)")
+	}
+
+	return s.transfers.CancelTransfer(ctx, tenantID, userID, id, reason)
+}
+
+func (s *transferService) ReceiveTransfer(ctx context.Context, tenantID string, userID int, req *TransferReceiveRequest) (*TransferOrder, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if err := validateTransferReceiveRequest(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	return s.transfers.ReceiveTransfer(ctx, tenantID, userID, req)
+}
+
+func (s *transferService) RelayOutbox(ctx context.Context, tenantID string) (int, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return 0, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.transfers.RelayOutbox(ctx, tenantID, relayBatchSize)
+}
+
+func isValidTransferStatus(status TransferStatus) bool {
+	switch status {
+	case TransferPending, TransferInTransit, TransferReceived, TransferCancelled:
+		return true
+	}
+	return false
+}
+
+func validateTransferRequest(tenantID string, req *TransferRequest) error {
+	if req == nil {
+		return fmt.Errorf("transfer is required")
+	}
+
+	req.DestinationTenantID = strings.TrimSpace(req.DestinationTenantID)
+	if err := validateTenantID(req.DestinationTenantID); err != nil {
+		return fmt.Errorf("invalid destination yard: %w", err)
+	}
+	if req.DestinationTenantID == tenantID {
+		return fmt.Errorf("destination must be a different yard")
+	}
+	if req.CustomerID <= 0 {
+		return fmt.Errorf("invalid customer ID: %d", req.CustomerID)
+	}
+	if req.DestinationCustomerID <= 0 {
+		return fmt.Errorf("invalid destination customer ID: %d", req.DestinationCustomerID)
+	}
+
+	if len(req.Carrier) > 255 {
+		return fmt.Errorf("carrier too long (max 255 characters)")
+	}
+	if len(req.TruckNumber) > 50 {
+		return fmt.Errorf("truck number too long (max 50 characters)")
+	}
+	if len(req.Notes) > 1000 {
+		return fmt.Errorf("notes too long (max 1000 characters)")
+	}
+
+	if len(req.Lines) == 0 {
+		return fmt.Errorf("at least one line is required")
+	}
+	seen := make(map[int]bool, len(req.Lines))
+	for i, line := range req.Lines {
+		if line.ItemID <= 0 {
+			return fmt.Errorf("line %d: invalid inventory item ID: %d", i+1, line.ItemID)
+		}
+		if seen[line.ItemID] {
+			return fmt.Errorf("line %d: inventory item %d is already on this transfer", i+1, line.ItemID)
+		}
+		seen[line.ItemID] = true
+		if line.Joints < 0 {
+			return fmt.Errorf("line %d: joints cannot be negative", i+1)
+		}
+	}
+
+	return nil
+}
+
+func validateTransferReceiveRequest(req *TransferReceiveRequest) error {
+	if req == nil {
+		return fmt.Errorf("receipt is required")
+	}
+	if req.TransferID <= 0 {
+		return fmt.Errorf("invalid transfer ID: %d", req.TransferID)
+	}
+
+	if len(req.Lines) == 0 {
+		return fmt.Errorf("at least one line is required")
+	}
+	seen := make(map[int]bool, len(req.Lines))
+	for i := range req.Lines {
+		line := &req.Lines[i]
+		if line.LineID <= 0 {
+			return fmt.Errorf("line %d: invalid transfer line ID: %d", i+1, line.LineID)
+		}
+		if seen[line.LineID] {
+			return fmt.Errorf("line %d: transfer line %d is already received", i+1, line.LineID)
+		}
+		seen[line.LineID] = true
+
+		line.Rack = strings.TrimSpace(line.Rack)
+		line.Location = strings.TrimSpace(line.Location)
+		if line.Rack == "" && line.Location == "" {
+			return fmt.Errorf("line %d: a rack or location is required", i+1)
+		}
+		if len(line.Rack) > 50 {
+			return fmt.Errorf("line %d: rack too long (max 50 characters)", i+1)
+		}
+		if len(line.Location) > 100 {
+			return fmt.Errorf("line %d: location too long (max 100 characters)", i+1)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+// backend/internal/inventory/transfer_handlers.go
+package inventory
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"oilgas-backend/internal/auth"
+)
+
+type TransferHandlers struct {
+	service TransferService
+}
+
+func NewTransferHandlers(service TransferService) *TransferHandlers {
+	return &TransferHandlers{service: service}
+}
+
+func (h *TransferHandlers) RegisterRoutes(router *gin.RouterGroup, authMiddleware *auth.Middleware) {
+	staff := authMiddleware.RequireRole(auth.RoleOperator, auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin)
+
+	transfers := router.Group("/transfers")
+	transfers.Use(authMiddleware.RequireAuth())
+	transfers.Use(staff)
+
+	transfers.GET("", h.GetTransfers)
+	transfers.POST("", h.CreateTransfer)
+	transfers.GET("/in-transit", h.GetInTransit)
+	transfers.GET("/:id", h.GetTransfer)
+	transfers.POST("/:id/cancel", h.CancelTransfer)
+	transfers.POST("/:id/receive", h.ReceiveTransfer)
+}
+
+// GetTransfers lists this yard's transfer orders, optionally by
+// ?direction=OUTBOUND|INBOUND and ?status=
+func (h *TransferHandlers) GetTransfers(c *gin.Context) {
+	var filters TransferFilters
+
+	if raw := c.Query("direction"); raw != "" {
+		direction := TransferDirection(raw)
+		filters.Direction = &direction
+	}
+
+	if raw := c.Query("status"); raw != "" {
+		status := TransferStatus(raw)
+		filters.Status = &status
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filters.Limit = l
+		}
+	}
+
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filters.Offset = o
+		}
+	}
+
+	transfers, total, err := h.service.GetTransfers(c.Request.Context(), c.GetString("tenant_id"), filters)
+	if err != nil {
+		c.JSON(transferErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  transfers,
+		"total": total,
+	})
+}
+
+// GetInTransit lists joints travelling to or from this yard
+func (h *TransferHandlers) GetInTransit(c *gin.Context) {
+	transfers, err := h.service.GetInTransit(c.Request.Context(), c.GetString("tenant_id"))
+	if err != nil {
+		c.JSON(transferErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  transfers,
+		"total": len(transfers),
+	})
+}
+
+// CreateTransfer dates joints out to another yard; a line without joints
+// sends the whole row
+func (h *TransferHandlers) CreateTransfer(c *gin.Context) {
+	var req TransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	transfer, err := h.service.CreateTransfer(c.Request.Context(), c.GetString("tenant_id"), c.GetInt("user_id"), &req)
+	if err != nil {
+		c.JSON(transferErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, transfer)
+}
+
+func (h *TransferHandlers) GetTransfer(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transfer ID"})
+		return
+	}
+
+	transfer, err := h.service.GetTransfer(c.Request.Context(), c.GetString("tenant_id"), id)
+	if err != nil {
+		c.JSON(transferErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, transfer)
+}
+
+func (h *TransferHandlers) CancelTransfer(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transfer ID"})
+		return
+	}
+
+	var req CancelTransferRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	transfer, err := h.service.CancelTransfer(c.Request.Context(), c.GetString("tenant_id"), c.GetInt("user_id"), id, req.Reason)
+	if err != nil {
+		c.JSON(transferErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, transfer)
+}
+
+// ReceiveTransfer racks an inbound transfer's lines in this yard
+func (h *TransferHandlers) ReceiveTransfer(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transfer ID"})
+		return
+	}
+
+	var req TransferReceiveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.TransferID = id
+
+	transfer, err := h.service.ReceiveTransfer(c.Request.Context(), c.GetString("tenant_id"), c.GetInt("user_id"), &req)
+	if err != nil {
+		c.JSON(transferErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, transfer)
+}
+
+func transferErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrTransferNotFound), errors.Is(err, ErrItemNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrTransferNotPending), errors.Is(err, ErrTransferNotInTransit),
+		errors.Is(err, ErrTransferReceived), errors.Is(err, ErrNotInStock), errors.Is(err, ErrOverAllocated):
+		return http.StatusConflict
+	case errors.Is(err, ErrTransferLineMismatch), errors.Is(err, ErrYardNotSetUp):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusBadRequest
+	}
+}
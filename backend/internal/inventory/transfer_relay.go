@@ -0,0 +1,57 @@
+// backend/internal/inventory/transfer_relay.go
+package inventory
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// TransferRelay periodically delivers the transfer outboxes of a fixed set
+// of yards. Relaying another yard's outbox is safe: deliveries are
+// idempotent and a message being delivered elsewhere is skipped.
+type TransferRelay struct {
+	service  TransferService
+	tenants  []string
+	interval time.Duration
+}
+
+func NewTransferRelay(service TransferService, tenants []string, interval time.Duration) *TransferRelay {
+	return &TransferRelay{
+		service:  service,
+		tenants:  tenants,
+		interval: interval,
+	}
+}
+
+// Start runs the relay in the background until ctx is cancelled
+func (w *TransferRelay) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		w.RunOnce(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.RunOnce(ctx)
+			}
+		}
+	}()
+}
+
+// RunOnce relays every yard's outbox; one yard failing does not stop the
+// others
+func (w *TransferRelay) RunOnce(ctx context.Context) {
+	for _, tenantID := range w.tenants {
+		delivered, err := w.service.RelayOutbox(ctx, tenantID)
+		if err != nil {
+			log.Printf("Transfer relay failed for tenant %s: %v", tenantID, err)
+		}
+		if delivered > 0 {
+			log.Printf("Transfer relay delivered %d message(s) for tenant %s", delivered, tenantID)
+		}
+	}
+}
@@ -0,0 +1,946 @@
+// backend/internal/inventory/transfer_repository.go
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"oilgas-backend/internal/numbering"
+	"oilgas-backend/internal/shared/database"
+)
+
+// Transfer outbox message types
+const (
+	transferMessageDispatched = "DISPATCHED" // Source to destination: create the inbound copy
+	transferMessageReceived   = "RECEIVED"   // Destination to source: the joints are racked
+	transferMessageCancelled  = "CANCELLED"  // Source to destination: withdraw the inbound copy
+)
+
+// transferSchemaQuery checks a yard's database for the tables transfers
+// write on both sides, so a yard not yet migrated is refused rather than
+// retried forever
+const transferSchemaQuery = `
+	SELECT to_regclass('store.inventory_movements') IS NOT NULL
+	   AND to_regclass('store.transfer_orders') IS NOT NULL
+	   AND to_regclass('store.transfer_lines') IS NOT NULL
+	   AND to_regclass('store.transfer_outbox') IS NOT NULL`
+
+type TransferRepository interface {
+	GetTransfer(ctx context.Context, tenantID string, id int) (*TransferOrder, error)
+	GetTransfers(ctx context.Context, tenantID string, filters TransferFilters) ([]TransferOrder, int, error)
+
+	// GetInTransit lists open transfers in both directions, with their lines
+	GetInTransit(ctx context.Context, tenantID string) ([]TransferOrder, error)
+
+	// CreateTransfer dates the joints out of the source yard and queues the
+	// transfer for the destination in one transaction. The destination's
+	// copy is written later by RelayOutbox.
+	CreateTransfer(ctx context.Context, tenantID string, userID int, req *TransferRequest) (*TransferOrder, error)
+
+	// CancelTransfer puts the joints of a transfer the destination has not
+	// yet accepted back into stock
+	CancelTransfer(ctx context.Context, tenantID string, userID, id int, reason string) (*TransferOrder, error)
+
+	// ReceiveTransfer racks an inbound transfer as new inventory rows and
+	// queues the confirmation for the source yard
+	ReceiveTransfer(ctx context.Context, tenantID string, userID int, req *TransferReceiveRequest) (*TransferOrder, error)
+
+	// RelayOutbox delivers up to limit due messages from a yard's outbox to
+	// the yards they are for, returning how many were delivered. A failed
+	// delivery is recorded on the message and retried with a backoff.
+	RelayOutbox(ctx context.Context, tenantID string, limit int) (int, error)
+}
+
+type transferRepository struct {
+	dbManager *database.DatabaseManager
+	numbers   numbering.Allocator
+}
+
+func NewTransferRepository(dbManager *database.DatabaseManager, numbers numbering.Allocator) TransferRepository {
+	return &transferRepository{dbManager: dbManager, numbers: numbers}
+}
+
+// querier is satisfied by both *sql.DB and *sql.Tx
+type querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+const transferColumns = `
+	t.id, t.tenant_id, t.transfer_uid, t.transfer_number, t.direction, t.source_tenant_id, t.destination_tenant_id,
+	t.customer_id, t.customer, t.remote_customer_id, t.carrier, t.truck_number, t.notes, t.total_joints,
+	t.status, t.created_by_user_id, t.dispatched_at, t.delivered_at, t.received_by_user_id, t.received_at,
+	t.cancelled_by_user_id, t.cancelled_at, t.cancel_reason,
+	ob.id IS NOT NULL, ob.last_error`
+
+// transferFrom joins each order to its newest undelivered outbox message
+const transferFrom = `
+	FROM store.transfer_orders t
+	LEFT JOIN LATERAL (
+		SELECT id, last_error FROM store.transfer_outbox
+		WHERE transfer_order_id = t.id AND delivered_at IS NULL AND discarded_at IS NULL
+		ORDER BY id DESC LIMIT 1
+	) ob ON true`
+
+const transferLineColumns = `
+	id, transfer_order_id, line_number, source_item_id, in_transit_item_id, received_item_id,
+	work_order, r_number, joints, size, weight, grade, connection,
+	from_rack, from_location, received_rack, received_location`
+
+func scanTransfer(row rowScanner) (*TransferOrder, error) {
+	var t TransferOrder
+	err := row.Scan(
+		&t.ID, &t.TenantID, &t.TransferUID, &t.TransferNumber, &t.Direction, &t.SourceTenantID, &t.DestinationTenantID,
+		&t.CustomerID, &t.Customer, &t.RemoteCustomerID, &t.Carrier, &t.TruckNumber, &t.Notes, &t.TotalJoints,
+		&t.Status, &t.CreatedByUserID, &t.DispatchedAt, &t.DeliveredAt, &t.ReceivedByUserID, &t.ReceivedAt,
+		&t.CancelledByUserID, &t.CancelledAt, &t.CancelReason,
+		&t.DeliveryPending, &t.DeliveryError,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func scanTransferLine(row rowScanner) (*TransferLine, error) {
+	var l TransferLine
+	err := row.Scan(
+		&l.ID, &l.TransferOrderID, &l.LineNumber, &l.SourceItemID, &l.InTransitItemID, &l.ReceivedItemID,
+		&l.WorkOrder, &l.RNumber, &l.Joints, &l.Size, &l.Weight, &l.Grade, &l.Connection,
+		&l.FromRack, &l.FromLocation, &l.ReceivedRack, &l.ReceivedLocation,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// getTransfer loads an order and its lines; lock takes the order row for
+// update
+func getTransfer(ctx context.Context, q querier, tenantID string, id int, lock bool) (*TransferOrder, error) {
+	query := `
+		SELECT` + transferColumns + transferFrom + `
+		WHERE t.id = $1 AND t.tenant_id = $2`
+	if lock {
+		query += " FOR UPDATE OF t"
+	}
+
+	order, err := scanTransfer(q.QueryRowContext(ctx, query, id, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrTransferNotFound
+		}
+		return nil, fmt.Errorf("failed to get transfer order: %w", err)
+	}
+
+	if order.Lines, err = getTransferLines(ctx, q, order.ID); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func getTransferLines(ctx context.Context, q querier, orderID int) ([]TransferLine, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT`+transferLineColumns+`
+		FROM store.transfer_lines
+		WHERE transfer_order_id = $1
+		ORDER BY line_number`, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfer lines: %w", err)
+	}
+	defer rows.Close()
+
+	lines := []TransferLine{}
+	for rows.Next() {
+		line, err := scanTransferLine(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transfer line: %w", err)
+		}
+		lines = append(lines, *line)
+	}
+
+	return lines, rows.Err()
+}
+
+func (r *transferRepository) GetTransfer(ctx context.Context, tenantID string, id int) (*TransferOrder, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	return getTransfer(ctx, db, tenantID, id, false)
+}
+
+// GetTransfers lists order headers, newest first; lines are only loaded by
+// GetTransfer and GetInTransit
+func (r *transferRepository) GetTransfers(ctx context.Context, tenantID string, filters TransferFilters) ([]TransferOrder, int, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	where := "WHERE t.tenant_id = $1"
+	args := []interface{}{tenantID}
+
+	if filters.Direction != nil {
+		args = append(args, *filters.Direction)
+		where += fmt.Sprintf(" AND t.direction = $%d", len(args))
+	}
+	if filters.Status != nil {
+		args = append(args, *filters.Status)
+		where += fmt.Sprintf(" AND t.status = $%d", len(args))
+	}
+
+	var total int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM store.transfer_orders t "+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count transfer orders: %w", err)
+	}
+
+	query := `
+		SELECT` + transferColumns + transferFrom + `
+		` + where + `
+		ORDER BY t.dispatched_at DESC, t.id DESC`
+
+	if filters.Limit > 0 {
+		args = append(args, filters.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filters.Offset > 0 {
+		args = append(args, filters.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get transfer orders: %w", err)
+	}
+	defer rows.Close()
+
+	orders := []TransferOrder{}
+	for rows.Next() {
+		order, err := scanTransfer(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan transfer order: %w", err)
+		}
+		orders = append(orders, *order)
+	}
+
+	return orders, total, rows.Err()
+}
+
+func (r *transferRepository) GetInTransit(ctx context.Context, tenantID string) ([]TransferOrder, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT`+transferColumns+transferFrom+`
+		WHERE t.tenant_id = $1 AND t.status IN ('PENDING', 'IN_TRANSIT')
+		ORDER BY t.dispatched_at, t.id`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfers in transit: %w", err)
+	}
+	defer rows.Close()
+
+	orders := []TransferOrder{}
+	for rows.Next() {
+		order, err := scanTransfer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transfer order: %w", err)
+		}
+		orders = append(orders, *order)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for i := range orders {
+		if orders[i].Lines, err = getTransferLines(ctx, db, orders[i].ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return orders, nil
+}
+
+func (r *transferRepository) CreateTransfer(ctx context.Context, tenantID string, userID int, req *TransferRequest) (*TransferOrder, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	// The relay needs a connection to the destination, and the destination
+	// needs the transfer tables, to deliver the transfer; refuse one it
+	// could never send
+	destination, err := r.dbManager.GetTenantDB(req.DestinationTenantID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownYard, req.DestinationTenantID)
+	}
+	ok, err := hasTransferSchema(ctx, destination)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrYardNotSetUp, req.DestinationTenantID)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Rows are locked in id order so two transfers sharing rows cannot
+	// deadlock
+	lines := make([]TransferLineRequest, len(req.Lines))
+	copy(lines, req.Lines)
+	sort.Slice(lines, func(i, j int) bool { return lines[i].ItemID < lines[j].ItemID })
+
+	items := make([]*Item, len(lines))
+	for i, line := range lines {
+		item, err := lockItemTx(ctx, tx, tenantID, line.ItemID)
+		if err != nil {
+			return nil, err
+		}
+		if item.DateOut != nil {
+			return nil, fmt.Errorf("%w: item %d", ErrNotInStock, item.ID)
+		}
+		if item.CustomerID == nil || *item.CustomerID != req.CustomerID {
+			return nil, fmt.Errorf("%w: item %d", ErrNotCustomerInventory, item.ID)
+		}
+		items[i] = item
+	}
+
+	number, err := r.numbers.Next(ctx, tx, tenantID, numbering.DocumentTransfer)
+	if err != nil {
+		return nil, err
+	}
+
+	var orderID int
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO store.transfer_orders (
+			tenant_id, transfer_uid, transfer_number, direction, source_tenant_id, destination_tenant_id,
+			customer_id, customer, remote_customer_id, carrier, truck_number, notes, status, created_by_user_id
+		) VALUES ($1, $2, $3, 'OUTBOUND', $1, $4, $5, $6, $7, $8, $9, $10, 'PENDING', $11)
+		RETURNING id`,
+		tenantID, uuid.New().String(), number, req.DestinationTenantID,
+		req.CustomerID, items[0].Customer, req.DestinationCustomerID,
+		nullableString(req.Carrier), nullableString(req.TruckNumber), nullableString(req.Notes), userID,
+	).Scan(&orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transfer order: %w", err)
+	}
+
+	total := 0
+	for i, line := range lines {
+		joints, err := dispatchLineTx(ctx, tx, tenantID, userID, orderID, i+1, number, req.DestinationTenantID, items[i], line)
+		if err != nil {
+			return nil, err
+		}
+		total += joints
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE store.transfer_orders SET total_joints = $1 WHERE id = $2`, total, orderID); err != nil {
+		return nil, fmt.Errorf("failed to total transfer order: %w", err)
+	}
+
+	order, err := getTransfer(ctx, tx, tenantID, orderID, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := enqueueTransferMessageTx(ctx, tx, order, transferMessageDispatched, order.DestinationTenantID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transfer order: %w", err)
+	}
+
+	order.DeliveryPending = true
+	return order, nil
+}
+
+// dispatchLineTx dates one line's joints out of the source yard, returning
+// how many were sent. Reserved joints stay behind for their work order.
+func dispatchLineTx(ctx context.Context, tx *sql.Tx, tenantID string, userID, orderID, lineNumber int, number, destination string, item *Item, req TransferLineRequest) (int, error) {
+	joints := req.Joints
+	if joints == 0 {
+		joints = item.Joints
+	}
+	if joints <= 0 || joints > item.Joints {
+		return 0, fmt.Errorf("%w: item %d: %d requested, %d in rack", ErrInsufficientJoints, item.ID, joints, item.Joints)
+	}
+
+	reserved, err := reservedJointsTx(ctx, tx, item.ID)
+	if err != nil {
+		return 0, err
+	}
+	if available := item.Joints - reserved; joints > available {
+		return 0, fmt.Errorf("%w: item %d: %d requested, %d available", ErrOverAllocated, item.ID, joints, available)
+	}
+
+	inTransitID := item.ID
+	var splitID *int
+	if joints < item.Joints {
+		split, err := splitItemTx(ctx, tx, item, joints, item.Rack, item.Location)
+		if err != nil {
+			return 0, err
+		}
+		inTransitID = split.ID
+		splitID = &split.ID
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE store.inventory SET date_out = CURRENT_DATE WHERE id = $1`, inTransitID); err != nil {
+		return 0, fmt.Errorf("failed to mark inventory in transit: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO store.transfer_lines (
+			transfer_order_id, line_number, source_item_id, in_transit_item_id, work_order, r_number, joints,
+			size, weight, grade, connection, from_rack, from_location
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+		orderID, lineNumber, item.ID, inTransitID, item.WorkOrder, item.RNumber, joints,
+		item.Size, item.Weight, item.Grade, item.Connection, item.Rack, item.Location)
+	if err != nil {
+		return 0, fmt.Errorf("failed to record transfer line: %w", err)
+	}
+
+	movement := Movement{
+		TenantID:      tenantID,
+		MovementType:  MovementTransferOut,
+		ItemID:        item.ID,
+		ToItemID:      splitID,
+		RNumber:       item.RNumber,
+		CustomerID:    item.CustomerID,
+		Joints:        joints,
+		FromRack:      item.Rack,
+		FromLocation:  item.Location,
+		Notes:         nullableString(fmt.Sprintf("%s to %s", number, destination)),
+		MovedByUserID: userID,
+	}
+	if err := insertMovementTx(ctx, tx, &movement); err != nil {
+		return 0, err
+	}
+	if err := logInventoryEventTx(ctx, tx, "inventory.transferred_out", &movement); err != nil {
+		return 0, err
+	}
+
+	return joints, nil
+}
+
+func (r *transferRepository) CancelTransfer(ctx context.Context, tenantID string, userID, id int, reason string) (*TransferOrder, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// The order is locked before its outbox messages, the same order the
+	// relay takes them in
+	order, err := getTransfer(ctx, tx, tenantID, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if order.Direction != TransferOutbound {
+		return nil, fmt.Errorf("%w: inbound transfers are cancelled by the source yard", ErrTransferNotPending)
+	}
+	switch order.Status {
+	case TransferPending:
+	case TransferReceived:
+		return nil, ErrTransferReceived
+	default:
+		return nil, ErrTransferNotPending
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE store.transfer_outbox SET discarded_at = NOW()
+		WHERE transfer_order_id = $1 AND message_type = $2 AND delivered_at IS NULL AND discarded_at IS NULL`,
+		order.ID, transferMessageDispatched)
+	if err != nil {
+		return nil, fmt.Errorf("failed to withdraw transfer dispatch: %w", err)
+	}
+
+	lines := make([]TransferLine, len(order.Lines))
+	copy(lines, order.Lines)
+	sort.Slice(lines, func(i, j int) bool { return *lines[i].InTransitItemID < *lines[j].InTransitItemID })
+
+	for _, line := range lines {
+		item, err := lockItemTx(ctx, tx, tenantID, *line.InTransitItemID)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE store.inventory SET date_out = NULL WHERE id = $1`, item.ID); err != nil {
+			return nil, fmt.Errorf("failed to return inventory to stock: %w", err)
+		}
+
+		movement := Movement{
+			TenantID:      tenantID,
+			MovementType:  MovementTransferIn,
+			ItemID:        item.ID,
+			RNumber:       item.RNumber,
+			CustomerID:    item.CustomerID,
+			Joints:        item.Joints,
+			ToRack:        item.Rack,
+			ToLocation:    item.Location,
+			Notes:         nullableString(order.TransferNumber + " cancelled"),
+			MovedByUserID: userID,
+		}
+		if err := insertMovementTx(ctx, tx, &movement); err != nil {
+			return nil, err
+		}
+		if err := logInventoryEventTx(ctx, tx, "inventory.transferred_in", &movement); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE store.transfer_orders
+		SET status = 'CANCELLED', cancelled_by_user_id = $2, cancelled_at = NOW(), cancel_reason = $3
+		WHERE id = $1`, order.ID, userID, nullableString(reason))
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel transfer order: %w", err)
+	}
+
+	order, err = getTransfer(ctx, tx, tenantID, order.ID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// A delivery can reach the destination and then fail before it is
+	// recorded here, so the destination is always told to withdraw its copy
+	if err := enqueueTransferMessageTx(ctx, tx, order, transferMessageCancelled, order.DestinationTenantID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transfer cancellation: %w", err)
+	}
+
+	order.DeliveryPending = true
+	return order, nil
+}
+
+func (r *transferRepository) ReceiveTransfer(ctx context.Context, tenantID string, userID int, req *TransferReceiveRequest) (*TransferOrder, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	order, err := getTransfer(ctx, tx, tenantID, req.TransferID, true)
+	if err != nil {
+		return nil, err
+	}
+	if order.Direction != TransferInbound {
+		return nil, ErrTransferNotInTransit
+	}
+	switch order.Status {
+	case TransferInTransit:
+	case TransferReceived:
+		return nil, ErrTransferReceived
+	default:
+		return nil, ErrTransferNotInTransit
+	}
+
+	racks := make(map[int]TransferReceiveLine, len(req.Lines))
+	for _, line := range req.Lines {
+		racks[line.LineID] = line
+	}
+	if len(racks) != len(order.Lines) {
+		return nil, fmt.Errorf("%w: %d lines on the transfer, %d received", ErrTransferLineMismatch, len(order.Lines), len(req.Lines))
+	}
+
+	notes := fmt.Sprintf("transferred from %s on %s", order.SourceTenantID, order.TransferNumber)
+	for _, line := range order.Lines {
+		received, ok := racks[line.ID]
+		if !ok {
+			return nil, fmt.Errorf("%w: line %d not received", ErrTransferLineMismatch, line.LineNumber)
+		}
+		rack, location := nullableString(received.Rack), nullableString(received.Location)
+
+		item, err := scanItem(tx.QueryRowContext(ctx, `
+			INSERT INTO store.inventory (
+				tenant_id, customer_id, customer, work_order, r_number, joints,
+				size, weight, grade, connection, date_in,
+				rack, location, notes, deleted, created_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, CURRENT_DATE, $11, $12, $13, false, NOW())
+			RETURNING`+itemColumns,
+			tenantID, order.CustomerID, order.Customer, line.WorkOrder, line.RNumber, line.Joints,
+			line.Size, line.Weight, line.Grade, line.Connection, rack, location, notes))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create inventory item: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE store.transfer_lines SET received_item_id = $2, received_rack = $3, received_location = $4
+			WHERE id = $1`, line.ID, item.ID, rack, location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record received transfer line: %w", err)
+		}
+
+		movement := Movement{
+			TenantID:      tenantID,
+			MovementType:  MovementTransferIn,
+			ItemID:        item.ID,
+			RNumber:       item.RNumber,
+			CustomerID:    item.CustomerID,
+			Joints:        item.Joints,
+			ToRack:        rack,
+			ToLocation:    location,
+			Notes:         nullableString(fmt.Sprintf("%s from %s", order.TransferNumber, order.SourceTenantID)),
+			MovedByUserID: userID,
+		}
+		if err := insertMovementTx(ctx, tx, &movement); err != nil {
+			return nil, err
+		}
+		if err := logInventoryEventTx(ctx, tx, "inventory.transferred_in", &movement); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE store.transfer_orders
+		SET status = 'RECEIVED', received_by_user_id = $2, received_at = NOW()
+		WHERE id = $1`, order.ID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to receive transfer order: %w", err)
+	}
+
+	order, err = getTransfer(ctx, tx, tenantID, order.ID, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := enqueueTransferMessageTx(ctx, tx, order, transferMessageReceived, order.SourceTenantID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transfer receipt: %w", err)
+	}
+
+	order.DeliveryPending = true
+	return order, nil
+}
+
+// enqueueTransferMessageTx queues a message for another yard in the same
+// transaction as the change it announces. The payload is the sender's copy
+// of the order.
+func enqueueTransferMessageTx(ctx context.Context, tx *sql.Tx, order *TransferOrder, messageType, target string) error {
+	payload, err := json.Marshal(order)
+	if err != nil {
+		return fmt.Errorf("failed to encode transfer message: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO store.transfer_outbox (tenant_id, transfer_order_id, message_type, target_tenant_id, payload)
+		VALUES ($1, $2, $3, $4, $5::jsonb)`,
+		order.TenantID, order.ID, messageType, target, string(payload))
+	if err != nil {
+		return fmt.Errorf("failed to queue transfer message: %w", err)
+	}
+	return nil
+}
+
+func (r *transferRepository) RelayOutbox(ctx context.Context, tenantID string, limit int) (int, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	// A yard without the transfer tables has sent nothing
+	ok, err := hasTransferSchema(ctx, db)
+	if err != nil || !ok {
+		return 0, err
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, transfer_order_id FROM store.transfer_outbox
+		WHERE tenant_id = $1 AND delivered_at IS NULL AND discarded_at IS NULL AND next_attempt_at <= NOW()
+		ORDER BY id
+		LIMIT $2`, tenantID, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get transfer outbox: %w", err)
+	}
+
+	type pending struct {
+		id      int64
+		orderID int
+	}
+	var due []pending
+	for rows.Next() {
+		var p pending
+		if err := rows.Scan(&p.id, &p.orderID); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan transfer outbox: %w", err)
+		}
+		due = append(due, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	// One undeliverable message does not hold up the rest
+	delivered := 0
+	var failures []error
+	for _, p := range due {
+		ok, err := r.deliverMessage(ctx, db, p.id, p.orderID)
+		if err != nil {
+			failures = append(failures, err)
+			continue
+		}
+		if ok {
+			delivered++
+		}
+	}
+
+	if len(failures) > 0 {
+		return delivered, fmt.Errorf("%d of %d transfer message(s) failed: %w", len(failures), len(due), errors.Join(failures...))
+	}
+	return delivered, nil
+}
+
+// hasTransferSchema reports whether a yard's database can send and take
+// transfers
+func hasTransferSchema(ctx context.Context, db querier) (bool, error) {
+	var ok bool
+	if err := db.QueryRowContext(ctx, transferSchemaQuery).Scan(&ok); err != nil {
+		return false, fmt.Errorf("failed to check transfer tables: %w", err)
+	}
+	return ok, nil
+}
+
+// deliverMessage applies one outbox message in the target yard and, once
+// that has committed, marks it delivered in the sending yard. If the second
+// step is lost the message is simply delivered again: applying it is
+// idempotent. Returns false when the message was skipped because another
+// relay or a cancellation holds it.
+func (r *transferRepository) deliverMessage(ctx context.Context, db *sql.DB, id int64, orderID int) (bool, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var locked int
+	err = tx.QueryRowContext(ctx, `
+		SELECT id FROM store.transfer_orders WHERE id = $1 FOR UPDATE SKIP LOCKED`, orderID).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to lock transfer order: %w", err)
+	}
+
+	var messageType, target string
+	var payload []byte
+	err = tx.QueryRowContext(ctx, `
+		SELECT message_type, target_tenant_id, payload FROM store.transfer_outbox
+		WHERE id = $1 AND delivered_at IS NULL AND discarded_at IS NULL
+		FOR UPDATE`, id).Scan(&messageType, &target, &payload)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to lock transfer message: %w", err)
+	}
+
+	if deliveryErr := r.applyMessage(ctx, target, messageType, payload); deliveryErr != nil {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE store.transfer_outbox
+			SET attempts = attempts + 1, last_error = $2, last_attempt_at = NOW(),
+			    next_attempt_at = NOW() + LEAST(attempts + 1, 30) * INTERVAL '1 minute'
+			WHERE id = $1`, id, deliveryErr.Error())
+		if err != nil {
+			return false, fmt.Errorf("failed to record transfer delivery failure: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return false, fmt.Errorf("failed to commit transfer delivery failure: %w", err)
+		}
+		return false, fmt.Errorf("transfer message %d to %s: %w", id, target, deliveryErr)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE store.transfer_outbox
+		SET attempts = attempts + 1, last_error = NULL, last_attempt_at = NOW(), delivered_at = NOW()
+		WHERE id = $1`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark transfer message delivered: %w", err)
+	}
+
+	if messageType == transferMessageDispatched {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE store.transfer_orders SET status = 'IN_TRANSIT', delivered_at = NOW()
+			WHERE id = $1 AND status = 'PENDING'`, orderID)
+		if err != nil {
+			return false, fmt.Errorf("failed to mark transfer in transit: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transfer delivery: %w", err)
+	}
+	return true, nil
+}
+
+// applyMessage writes a message's effect into the target yard's database
+func (r *transferRepository) applyMessage(ctx context.Context, target, messageType string, payload []byte) error {
+	var order TransferOrder
+	if err := json.Unmarshal(payload, &order); err != nil {
+		return fmt.Errorf("failed to decode transfer message: %w", err)
+	}
+
+	db, err := r.dbManager.GetTenantDB(target)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrUnknownYard, target)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	switch messageType {
+	case transferMessageDispatched:
+		err = insertInboundTransferTx(ctx, tx, target, &order)
+	case transferMessageReceived:
+		err = markOutboundReceivedTx(ctx, tx, target, &order)
+	case transferMessageCancelled:
+		err = cancelInboundTransferTx(ctx, tx, target, &order)
+	default:
+		err = fmt.Errorf("unknown transfer message type %q", messageType)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insertInboundTransferTx writes the destination yard's copy of a dispatched
+// transfer; a copy already there from an earlier attempt is left alone
+func insertInboundTransferTx(ctx context.Context, tx *sql.Tx, tenantID string, order *TransferOrder) error {
+	var id int
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO store.transfer_orders (
+			tenant_id, transfer_uid, transfer_number, direction, source_tenant_id, destination_tenant_id,
+			customer_id, customer, remote_customer_id, carrier, truck_number, notes, total_joints,
+			status, created_by_user_id, dispatched_at, delivered_at
+		) VALUES ($1, $2, $3, 'INBOUND', $4, $5, $6, $7, $8, $9, $10, $11, $12, 'IN_TRANSIT', $13, $14, NOW())
+		ON CONFLICT (tenant_id, transfer_uid) DO NOTHING
+		RETURNING id`,
+		tenantID, order.TransferUID, order.TransferNumber, order.SourceTenantID, order.DestinationTenantID,
+		order.RemoteCustomerID, order.Customer, order.CustomerID, order.Carrier, order.TruckNumber, order.Notes,
+		order.TotalJoints, order.CreatedByUserID, order.DispatchedAt,
+	).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create inbound transfer order: %w", err)
+	}
+
+	for _, line := range order.Lines {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO store.transfer_lines (
+				transfer_order_id, line_number, source_item_id, work_order, r_number, joints,
+				size, weight, grade, connection, from_rack, from_location
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+			id, line.LineNumber, line.SourceItemID, line.WorkOrder, line.RNumber, line.Joints,
+			line.Size, line.Weight, line.Grade, line.Connection, line.FromRack, line.FromLocation)
+		if err != nil {
+			return fmt.Errorf("failed to create inbound transfer line: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// markOutboundReceivedTx closes the source yard's copy once the destination
+// has racked the joints. The confirmation can arrive before the source has
+// recorded its own dispatch as delivered, so PENDING is accepted too.
+func markOutboundReceivedTx(ctx context.Context, tx *sql.Tx, tenantID string, order *TransferOrder) error {
+	result, err := tx.ExecContext(ctx, `
+		UPDATE store.transfer_orders
+		SET status = 'RECEIVED', received_by_user_id = $3, received_at = $4, delivered_at = COALESCE(delivered_at, NOW())
+		WHERE tenant_id = $1 AND transfer_uid = $2 AND direction = 'OUTBOUND' AND status IN ('PENDING', 'IN_TRANSIT')`,
+		tenantID, order.TransferUID, order.ReceivedByUserID, order.ReceivedAt)
+	if err != nil {
+		return fmt.Errorf("failed to mark transfer received: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n > 0 {
+		return nil
+	}
+
+	status, err := transferStatusTx(ctx, tx, tenantID, order.TransferUID, TransferOutbound)
+	if err != nil {
+		return err
+	}
+	if status == TransferReceived {
+		return nil
+	}
+	return fmt.Errorf("transfer %s is %s in %s and cannot be received", order.TransferNumber, status, tenantID)
+}
+
+// cancelInboundTransferTx withdraws the destination yard's copy of a
+// cancelled transfer. There may be no copy, if the dispatch never arrived.
+func cancelInboundTransferTx(ctx context.Context, tx *sql.Tx, tenantID string, order *TransferOrder) error {
+	result, err := tx.ExecContext(ctx, `
+		UPDATE store.transfer_orders
+		SET status = 'CANCELLED', cancelled_by_user_id = $3, cancelled_at = $4, cancel_reason = $5
+		WHERE tenant_id = $1 AND transfer_uid = $2 AND direction = 'INBOUND' AND status = 'IN_TRANSIT'`,
+		tenantID, order.TransferUID, order.CancelledByUserID, order.CancelledAt, order.CancelReason)
+	if err != nil {
+		return fmt.Errorf("failed to cancel inbound transfer: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n > 0 {
+		return nil
+	}
+
+	status, err := transferStatusTx(ctx, tx, tenantID, order.TransferUID, TransferInbound)
+	if errors.Is(err, ErrTransferNotFound) || status == TransferCancelled {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: transfer %s in %s", ErrTransferReceived, order.TransferNumber, tenantID)
+}
+
+func transferStatusTx(ctx context.Context, tx *sql.Tx, tenantID, transferUID string, direction TransferDirection) (TransferStatus, error) {
+	var status TransferStatus
+	err := tx.QueryRowContext(ctx, `
+		SELECT status FROM store.transfer_orders
+		WHERE tenant_id = $1 AND transfer_uid = $2 AND direction = $3`,
+		tenantID, transferUID, direction).Scan(&status)
+	if err == sql.ErrNoRows {
+		return "", ErrTransferNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get transfer status: %w", err)
+	}
+	return status, nil
+}
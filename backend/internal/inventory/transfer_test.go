@@ -0,0 +1,231 @@
+// backend/internal/inventory/transfer_test.go
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type mockTransferRepository struct {
+	mock.Mock
+}
+
+func (m *mockTransferRepository) GetTransfer(ctx context.Context, tenantID string, id int) (*TransferOrder, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*TransferOrder), args.Error(1)
+}
+
+func (m *mockTransferRepository) GetTransfers(ctx context.Context, tenantID string, filters TransferFilters) ([]TransferOrder, int, error) {
+	args := m.Called(ctx, tenantID, filters)
+	return args.Get(0).([]TransferOrder), args.Int(1), args.Error(2)
+}
+
+func (m *mockTransferRepository) GetInTransit(ctx context.Context, tenantID string) ([]TransferOrder, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).([]TransferOrder), args.Error(1)
+}
+
+func (m *mockTransferRepository) CreateTransfer(ctx context.Context, tenantID string, userID int, req *TransferRequest) (*TransferOrder, error) {
+	args := m.Called(ctx, tenantID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*TransferOrder), args.Error(1)
+}
+
+func (m *mockTransferRepository) CancelTransfer(ctx context.Context, tenantID string, userID, id int, reason string) (*TransferOrder, error) {
+	args := m.Called(ctx, tenantID, userID, id, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*TransferOrder), args.Error(1)
+}
+
+func (m *mockTransferRepository) ReceiveTransfer(ctx context.Context, tenantID string, userID int, req *TransferReceiveRequest) (*TransferOrder, error) {
+	args := m.Called(ctx, tenantID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*TransferOrder), args.Error(1)
+}
+
+func (m *mockTransferRepository) RelayOutbox(ctx context.Context, tenantID string, limit int) (int, error) {
+	args := m.Called(ctx, tenantID, limit)
+	return args.Int(0), args.Error(1)
+}
+
+type TransferServiceTestSuite struct {
+	suite.Suite
+	service   TransferService
+	transfers *mockTransferRepository
+	ctx       context.Context
+	tenantID  string
+}
+
+func (suite *TransferServiceTestSuite) SetupTest() {
+	suite.transfers = &mockTransferRepository{}
+	suite.service = NewTransferService(suite.transfers)
+	suite.ctx = context.Background()
+	suite.tenantID = "longbeach"
+}
+
+func TestTransferServiceSuite(t *testing.T) {
+	suite.Run(t, new(TransferServiceTestSuite))
+}
+
+func (suite *TransferServiceTestSuite) TestCreateTransfer_NormalizesDestination() {
+	req := &TransferRequest{
+		DestinationTenantID:   " bakersfield ",
+		CustomerID:            12,
+		DestinationCustomerID: 40,
+		Lines:                 []TransferLineRequest{{ItemID: 501}, {ItemID: 502, Joints: 20}},
+	}
+	order := &TransferOrder{ID: 9, TransferNumber: "TR-000003", Direction: TransferOutbound, Status: TransferPending, DeliveryPending: true}
+	suite.transfers.On("CreateTransfer", suite.ctx, suite.tenantID, 7, req).Return(order, nil)
+
+	got, err := suite.service.CreateTransfer(suite.ctx, suite.tenantID, 7, req)
+
+	suite.NoError(err)
+	suite.Equal(order, got)
+	suite.Equal("bakersfield", req.DestinationTenantID)
+}
+
+func (suite *TransferServiceTestSuite) TestCreateTransfer_RejectsInvalidRequests() {
+	lines := []TransferLineRequest{{ItemID: 501}}
+	testCases := []struct {
+		name string
+		req  *TransferRequest
+	}{
+		{"missing request", nil},
+		{"missing destination", &TransferRequest{CustomerID: 12, DestinationCustomerID: 40, Lines: lines}},
+		{"same yard", &TransferRequest{DestinationTenantID: "longbeach", CustomerID: 12, DestinationCustomerID: 40, Lines: lines}},
+		{"missing customer", &TransferRequest{DestinationTenantID: "colorado", DestinationCustomerID: 40, Lines: lines}},
+		{"missing destination customer", &TransferRequest{DestinationTenantID: "colorado", CustomerID: 12, Lines: lines}},
+		{"no lines", &TransferRequest{DestinationTenantID: "colorado", CustomerID: 12, DestinationCustomerID: 40}},
+		{"duplicate item", &TransferRequest{DestinationTenantID: "colorado", CustomerID: 12, DestinationCustomerID: 40, Lines: []TransferLineRequest{{ItemID: 501}, {ItemID: 501, Joints: 5}}}},
+		{"negative joints", &TransferRequest{DestinationTenantID: "colorado", CustomerID: 12, DestinationCustomerID: 40, Lines: []TransferLineRequest{{ItemID: 501, Joints: -5}}}},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			_, err := suite.service.CreateTransfer(suite.ctx, suite.tenantID, 7, tc.req)
+
+			assert.Error(suite.T(), err)
+			assert.Contains(suite.T(), err.Error(), "validation failed")
+		})
+	}
+
+	suite.transfers.AssertNotCalled(suite.T(), "CreateTransfer")
+}
+
+func (suite *TransferServiceTestSuite) TestCancelTransfer_AfterDelivery() {
+	suite.transfers.On("CancelTransfer", suite.ctx, suite.tenantID, 7, 9, "truck broke down").Return(nil, ErrTransferNotPending)
+
+	_, err := suite.service.CancelTransfer(suite.ctx, suite.tenantID, 7, 9, "truck broke down")
+
+	suite.ErrorIs(err, ErrTransferNotPending)
+}
+
+func (suite *TransferServiceTestSuite) TestReceiveTransfer_NormalizesLines() {
+	req := &TransferReceiveRequest{
+		TransferID: 4,
+		Lines:      []TransferReceiveLine{{LineID: 10, Rack: " B-3 "}, {LineID: 11, Location: "East fence"}},
+	}
+	order := &TransferOrder{ID: 4, Direction: TransferInbound, Status: TransferReceived}
+	suite.transfers.On("ReceiveTransfer", suite.ctx, "bakersfield", 7, req).Return(order, nil)
+
+	got, err := suite.service.ReceiveTransfer(suite.ctx, "bakersfield", 7, req)
+
+	suite.NoError(err)
+	suite.Equal(order, got)
+	suite.Equal("B-3", req.Lines[0].Rack)
+}
+
+func (suite *TransferServiceTestSuite) TestReceiveTransfer_RejectsInvalidRequests() {
+	testCases := []struct {
+		name string
+		req  *TransferReceiveRequest
+	}{
+		{"missing request", nil},
+		{"missing transfer", &TransferReceiveRequest{Lines: []TransferReceiveLine{{LineID: 10, Rack: "B-3"}}}},
+		{"no lines", &TransferReceiveRequest{TransferID: 4}},
+		{"duplicate line", &TransferReceiveRequest{TransferID: 4, Lines: []TransferReceiveLine{{LineID: 10, Rack: "B-3"}, {LineID: 10, Rack: "B-4"}}}},
+		{"no rack or location", &TransferReceiveRequest{TransferID: 4, Lines: []TransferReceiveLine{{LineID: 10, Rack: " "}}}},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			_, err := suite.service.ReceiveTransfer(suite.ctx, "bakersfield", 7, tc.req)
+
+			assert.Error(suite.T(), err)
+			assert.Contains(suite.T(), err.Error(), "validation failed")
+		})
+	}
+
+	suite.transfers.AssertNotCalled(suite.T(), "ReceiveTransfer")
+}
+
+func (suite *TransferServiceTestSuite) TestGetTransfers_ValidatesFilters() {
+	direction := TransferDirection("SIDEWAYS")
+	_, _, err := suite.service.GetTransfers(suite.ctx, suite.tenantID, TransferFilters{Direction: &direction})
+	suite.Error(err)
+
+	status := TransferStatus("LOST")
+	_, _, err = suite.service.GetTransfers(suite.ctx, suite.tenantID, TransferFilters{Status: &status})
+	suite.Error(err)
+
+	inbound := TransferInbound
+	suite.transfers.On("GetTransfers", suite.ctx, suite.tenantID, TransferFilters{Direction: &inbound, Limit: 50}).
+		Return([]TransferOrder{{ID: 4}}, 1, nil)
+
+	transfers, total, err := suite.service.GetTransfers(suite.ctx, suite.tenantID, TransferFilters{Direction: &inbound})
+	suite.NoError(err)
+	suite.Equal(1, total)
+	suite.Len(transfers, 1)
+}
+
+func (suite *TransferServiceTestSuite) TestRelay_OneYardFailingDoesNotStopOthers() {
+	suite.transfers.On("RelayOutbox", suite.ctx, "bakersfield", relayBatchSize).Return(0, errors.New("connection refused"))
+	suite.transfers.On("RelayOutbox", suite.ctx, "longbeach", relayBatchSize).Return(2, nil)
+
+	NewTransferRelay(suite.service, []string{"bakersfield", "longbeach"}, time.Minute).RunOnce(suite.ctx)
+
+	suite.transfers.AssertExpectations(suite.T())
+}
+
+func TestHasTransferSchema(t *testing.T) {
+	db, mockDB, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mockDB.ExpectQuery(`to_regclass\('store.transfer_outbox'\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"ok"}).AddRow(true))
+	mockDB.ExpectQuery(`to_regclass`).
+		WillReturnRows(sqlmock.NewRows([]string{"ok"}).AddRow(false))
+
+	ok, err := hasTransferSchema(context.Background(), db)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// A yard on the initial schema alone is refused as a destination
+	ok, err = hasTransferSchema(context.Background(), db)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusUnprocessableEntity, transferErrorStatus(fmt.Errorf("%w: colorado", ErrYardNotSetUp)))
+
+	assert.NoError(t, mockDB.ExpectationsWereMet())
+}
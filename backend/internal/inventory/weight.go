@@ -0,0 +1,322 @@
+// backend/internal/inventory/weight.go
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"oilgas-backend/internal/shared/weight"
+)
+
+// maxJointLength matches the joint tally's check constraint, in feet
+const maxJointLength = 60.0
+
+type WeightService interface {
+	GetItemWeight(ctx context.Context, tenantID string, itemID int) (*ItemWeight, error)
+
+	// GetItemWeights lists calculated weights for the rows in stock; with
+	// FlaggedOnly it lists the rows whose entered weight is far off
+	GetItemWeights(ctx context.Context, tenantID string, filters WeightFilters) ([]ItemWeight, int, error)
+	GetWeightSummary(ctx context.Context, tenantID string, filters WeightFilters) (*WeightSummary, error)
+
+	// CheckWeight weighs pipe that has not been entered yet, flagging a
+	// weight per foot the size reference data disagrees with
+	CheckWeight(ctx context.Context, tenantID string, req *WeightCheckRequest) (*ItemWeight, error)
+
+	GetTally(ctx context.Context, tenantID string, itemID int) ([]JointTally, error)
+	RecordTally(ctx context.Context, tenantID string, userID int, req *RecordTallyRequest) ([]JointTally, error)
+	SetAverageLength(ctx context.Context, tenantID string, req *SetAverageLengthRequest) (*ItemWeight, error)
+}
+
+type weightService struct {
+	weights   WeightRepository
+	tolerance float64
+}
+
+func NewWeightService(weights WeightRepository) WeightService {
+	return &weightService{weights: weights, tolerance: weight.DefaultTolerance}
+}
+
+func (s *weightService) GetItemWeight(ctx context.Context, tenantID string, itemID int) (*ItemWeight, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	line, err := s.weights.GetWeightLine(ctx, tenantID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	specs, err := s.weights.GetSizes(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := weighLine(*line, specs, s.tolerance)
+	return &result, nil
+}
+
+func (s *weightService) GetItemWeights(ctx context.Context, tenantID string, filters WeightFilters) ([]ItemWeight, int, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, 0, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if filters.Limit <= 0 {
+		filters.Limit = 50
+	}
+	if filters.Limit > 1000 {
+		return nil, 0, fmt.Errorf("limit too large: %d (max 1000)", filters.Limit)
+	}
+	if filters.Offset < 0 {
+		return nil, 0, fmt.Errorf("offset cannot be negative: %d", filters.Offset)
+	}
+
+	weights, err := s.weighLines(ctx, tenantID, filters)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if filters.FlaggedOnly {
+		flagged := weights[:0]
+		for _, w := range weights {
+			if w.Flagged {
+				flagged = append(flagged, w)
+			}
+		}
+		weights = flagged
+	}
+
+	total := len(weights)
+	if filters.Offset >= total {
+		return []ItemWeight{}, total, nil
+	}
+	end := filters.Offset + filters.Limit
+	if end > total {
+		end = total
+	}
+	return weights[filters.Offset:end], total, nil
+}
+
+func (s *weightService) GetWeightSummary(ctx context.Context, tenantID string, filters WeightFilters) (*WeightSummary, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	weights, err := s.weighLines(ctx, tenantID, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return summarizeWeights(weights), nil
+}
+
+func (s *weightService) CheckWeight(ctx context.Context, tenantID string, req *WeightCheckRequest) (*ItemWeight, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if err := validateWeightCheckRequest(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	line := WeightLine{
+		Joints:        req.Joints,
+		Size:          nullableString(req.Size),
+		Grade:         nullableString(req.Grade),
+		AverageLength: req.AverageLength,
+	}
+	if req.Weight != nil {
+		perFoot, err := weight.ConvertLinear(*req.Weight, req.Unit, weight.PoundsPerFoot)
+		if err != nil {
+			return nil, fmt.Errorf("validation failed: %w", err)
+		}
+		line.Weight = &perFoot
+	}
+
+	specs, err := s.weights.GetSizes(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := weighLine(line, specs, s.tolerance)
+	return &result, nil
+}
+
+func (s *weightService) GetTally(ctx context.Context, tenantID string, itemID int) ([]JointTally, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.weights.GetTally(ctx, tenantID, itemID)
+}
+
+func (s *weightService) RecordTally(ctx context.Context, tenantID string, userID int, req *RecordTallyRequest) ([]JointTally, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if err := validateRecordTallyRequest(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	return s.weights.RecordTally(ctx, tenantID, userID, req)
+}
+
+func (s *weightService) SetAverageLength(ctx context.Context, tenantID string, req *SetAverageLengthRequest) (*ItemWeight, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if req == nil || req.ItemID <= 0 {
+		return nil, fmt.Errorf("validation failed: inventory item is required")
+	}
+	if err := validateJointLength(req.AverageLength); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.weights.SetAverageLength(ctx, tenantID, req); err != nil {
+		return nil, err
+	}
+
+	return s.GetItemWeight(ctx, tenantID, req.ItemID)
+}
+
+func (s *weightService) weighLines(ctx context.Context, tenantID string, filters WeightFilters) ([]ItemWeight, error) {
+	filters.Rack = strings.TrimSpace(filters.Rack)
+	filters.Location = strings.TrimSpace(filters.Location)
+
+	lines, err := s.weights.GetWeightLines(ctx, tenantID, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	specs, err := s.weights.GetSizes(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make([]ItemWeight, 0, len(lines))
+	for _, line := range lines {
+		weights = append(weights, weighLine(line, specs, s.tolerance))
+	}
+	return weights, nil
+}
+
+// weighLine calculates one row's weight against the size reference data
+// and fills in the metric figures
+func weighLine(line WeightLine, specs []weight.Spec, tolerance float64) ItemWeight {
+	grade := derefString(line.Grade)
+	spec := weight.Match(specs, derefString(line.Size), grade, line.Weight)
+
+	result := ItemWeight{
+		WeightLine: line,
+		Result: weight.Calculate(weight.Input{
+			Joints:        line.Joints,
+			Grade:         grade,
+			Entered:       line.Weight,
+			Spec:          spec,
+			TalliedJoints: line.TalliedJoints,
+			TalliedFeet:   line.TalliedFeet,
+			AverageLength: line.AverageLength,
+		}, tolerance),
+	}
+	if spec != nil {
+		result.MatchedSize = &spec.Size
+	}
+
+	// Conversions between known units cannot fail
+	result.WeightPerMeter, _ = weight.ConvertLinear(result.PerFoot, weight.PoundsPerFoot, weight.KilogramsPerMeter)
+	result.TotalKilograms, _ = weight.ConvertMass(result.Pounds, weight.Pounds, weight.Kilograms)
+	result.ShortTons, _ = weight.ConvertMass(result.Pounds, weight.Pounds, weight.ShortTons)
+	result.MetricTons, _ = weight.ConvertMass(result.Pounds, weight.Pounds, weight.MetricTons)
+	return result
+}
+
+// summarizeWeights totals rows weighed by weighLine. The tons are converted
+// from the total pounds rather than summed, so they agree with it.
+func summarizeWeights(weights []ItemWeight) *WeightSummary {
+	summary := &WeightSummary{}
+	for _, w := range weights {
+		summary.TotalItems++
+		summary.TotalJoints += w.Joints
+		summary.TotalFeet += w.Feet
+		summary.TotalWeight += w.Pounds
+
+		if w.Flagged {
+			summary.FlaggedItems++
+		}
+		switch w.PerFootSource {
+		case weight.PerFootEntered:
+			summary.EnteredItems++
+		case weight.PerFootUnknown:
+			summary.UnknownItems++
+		}
+		switch w.LengthSource {
+		case weight.LengthTally, weight.LengthTallyAverage:
+			summary.TalliedItems++
+		case weight.LengthDefault:
+			summary.DefaultLengthItems++
+		}
+	}
+
+	summary.TotalKilograms, _ = weight.ConvertMass(summary.TotalWeight, weight.Pounds, weight.Kilograms)
+	summary.ShortTons, _ = weight.ConvertMass(summary.TotalWeight, weight.Pounds, weight.ShortTons)
+	summary.MetricTons, _ = weight.ConvertMass(summary.TotalWeight, weight.Pounds, weight.MetricTons)
+	return summary
+}
+
+func validateWeightCheckRequest(req *WeightCheckRequest) error {
+	if req == nil {
+		return fmt.Errorf("size is required")
+	}
+
+	req.Size = strings.TrimSpace(req.Size)
+	req.Grade = strings.ToUpper(strings.TrimSpace(req.Grade))
+	if req.Size == "" {
+		return fmt.Errorf("size is required")
+	}
+	if req.Unit == "" {
+		req.Unit = weight.PoundsPerFoot
+	}
+	if req.Unit != weight.PoundsPerFoot && req.Unit != weight.KilogramsPerMeter {
+		return fmt.Errorf("unknown weight unit: %s", req.Unit)
+	}
+	if req.Weight != nil && *req.Weight <= 0 {
+		return fmt.Errorf("weight must be positive")
+	}
+	if req.Joints < 0 {
+		return fmt.Errorf("joints cannot be negative")
+	}
+
+	return validateJointLength(req.AverageLength)
+}
+
+func validateRecordTallyRequest(req *RecordTallyRequest) error {
+	if req == nil || req.ItemID <= 0 {
+		return fmt.Errorf("inventory item is required")
+	}
+
+	for i := range req.Lengths {
+		length := req.Lengths[i]
+		if err := validateJointLength(&length); err != nil {
+			return fmt.Errorf("joint %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+func validateJointLength(length *float64) error {
+	if length == nil {
+		return nil
+	}
+	if *length <= 0 || *length > maxJointLength {
+		return fmt.Errorf("joint length must be more than 0 and at most %.0f feet", maxJointLength)
+	}
+	return nil
+}
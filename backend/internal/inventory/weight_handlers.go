@@ -0,0 +1,223 @@
+// backend/internal/inventory/weight_handlers.go
+package inventory
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"oilgas-backend/internal/auth"
+)
+
+type WeightHandlers struct {
+	service WeightService
+}
+
+func NewWeightHandlers(service WeightService) *WeightHandlers {
+	return &WeightHandlers{service: service}
+}
+
+func (h *WeightHandlers) RegisterRoutes(router *gin.RouterGroup, authMiddleware *auth.Middleware) {
+	staff := authMiddleware.RequireRole(auth.RoleOperator, auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin)
+
+	inventory := router.Group("/inventory")
+	inventory.Use(authMiddleware.RequireAuth())
+	inventory.Use(staff)
+
+	inventory.GET("/weights", h.GetItemWeights)
+	inventory.GET("/weights/summary", h.GetWeightSummary)
+	inventory.POST("/weights/check", h.CheckWeight)
+	inventory.GET("/:id/weight", h.GetItemWeight)
+	inventory.GET("/:id/tally", h.GetTally)
+	inventory.PUT("/:id/tally", h.RecordTally)
+	inventory.PUT("/:id/average-length", h.SetAverageLength)
+}
+
+// weightFilters reads ?customer_id=, ?rack= and ?location=
+func weightFilters(c *gin.Context) (WeightFilters, bool) {
+	filters := WeightFilters{
+		Rack:     c.Query("rack"),
+		Location: c.Query("location"),
+	}
+
+	if raw := c.Query("customer_id"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid customer ID"})
+			return filters, false
+		}
+		filters.CustomerID = &parsed
+	}
+
+	return filters, true
+}
+
+// GetItemWeights lists calculated weights for the rows in stock;
+// ?flagged=true lists only rows whose entered weight is far off
+func (h *WeightHandlers) GetItemWeights(c *gin.Context) {
+	filters, ok := weightFilters(c)
+	if !ok {
+		return
+	}
+	filters.FlaggedOnly = c.Query("flagged") == "true"
+
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filters.Limit = l
+		}
+	}
+
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filters.Offset = o
+		}
+	}
+
+	weights, total, err := h.service.GetItemWeights(c.Request.Context(), c.GetString("tenant_id"), filters)
+	if err != nil {
+		c.JSON(weightErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  weights,
+		"total": total,
+	})
+}
+
+// GetWeightSummary totals the weight in stock, optionally for one
+// customer, rack or location
+func (h *WeightHandlers) GetWeightSummary(c *gin.Context) {
+	filters, ok := weightFilters(c)
+	if !ok {
+		return
+	}
+
+	summary, err := h.service.GetWeightSummary(c.Request.Context(), c.GetString("tenant_id"), filters)
+	if err != nil {
+		c.JSON(weightErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// CheckWeight weighs pipe before it is entered, so the form can warn about
+// a weight per foot the reference data disagrees with
+func (h *WeightHandlers) CheckWeight(c *gin.Context) {
+	var req WeightCheckRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.CheckWeight(c.Request.Context(), c.GetString("tenant_id"), &req)
+	if err != nil {
+		c.JSON(weightErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *WeightHandlers) GetItemWeight(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid inventory item ID"})
+		return
+	}
+
+	result, err := h.service.GetItemWeight(c.Request.Context(), c.GetString("tenant_id"), id)
+	if err != nil {
+		c.JSON(weightErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *WeightHandlers) GetTally(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid inventory item ID"})
+		return
+	}
+
+	tally, err := h.service.GetTally(c.Request.Context(), c.GetString("tenant_id"), id)
+	if err != nil {
+		c.JSON(weightErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  tally,
+		"total": len(tally),
+	})
+}
+
+// RecordTally replaces the row's joint tally; an empty list clears it
+func (h *WeightHandlers) RecordTally(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid inventory item ID"})
+		return
+	}
+
+	var req RecordTallyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ItemID = id
+
+	tally, err := h.service.RecordTally(c.Request.Context(), c.GetString("tenant_id"), c.GetInt("user_id"), &req)
+	if err != nil {
+		c.JSON(weightErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  tally,
+		"total": len(tally),
+	})
+}
+
+// SetAverageLength records an average joint length for a row without a
+// tally and returns its weight at that length
+func (h *WeightHandlers) SetAverageLength(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid inventory item ID"})
+		return
+	}
+
+	var req SetAverageLengthRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ItemID = id
+
+	result, err := h.service.SetAverageLength(c.Request.Context(), c.GetString("tenant_id"), &req)
+	if err != nil {
+		c.JSON(weightErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func weightErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrItemNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrNotInStock):
+		return http.StatusConflict
+	case errors.Is(err, ErrTallyTooLong):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusBadRequest
+	}
+}
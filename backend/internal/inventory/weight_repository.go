@@ -0,0 +1,327 @@
+// backend/internal/inventory/weight_repository.go
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"oilgas-backend/internal/shared/database"
+	"oilgas-backend/internal/shared/weight"
+)
+
+type WeightRepository interface {
+	// GetSizes returns the active size reference entries
+	GetSizes(ctx context.Context, tenantID string) ([]weight.Spec, error)
+
+	// GetWeightLine returns what a row's weight is worked out from, whether
+	// or not it is still in the yard
+	GetWeightLine(ctx context.Context, tenantID string, itemID int) (*WeightLine, error)
+
+	// GetWeightLines returns the rows matching the filters, in id order;
+	// Limit, Offset and FlaggedOnly are left to the caller
+	GetWeightLines(ctx context.Context, tenantID string, filters WeightFilters) ([]WeightLine, error)
+
+	GetTally(ctx context.Context, tenantID string, itemID int) ([]JointTally, error)
+
+	// RecordTally replaces a row's tally in one transaction
+	RecordTally(ctx context.Context, tenantID string, userID int, req *RecordTallyRequest) ([]JointTally, error)
+	SetAverageLength(ctx context.Context, tenantID string, req *SetAverageLengthRequest) error
+}
+
+type weightRepository struct {
+	dbManager *database.DatabaseManager
+}
+
+func NewWeightRepository(dbManager *database.DatabaseManager) WeightRepository {
+	return &weightRepository{dbManager: dbManager}
+}
+
+// weightLineQuery joins each row to a summary of its tally
+const weightLineQuery = `
+	SELECT i.id, i.r_number, i.customer_id, i.customer, COALESCE(i.joints, 0),
+	       i.size, i.weight, i.grade, i.rack, i.location, i.average_joint_length,
+	       COALESCE(t.joints, 0), COALESCE(t.feet, 0)
+	FROM store.inventory i
+	LEFT JOIN (
+		SELECT inventory_item_id, COUNT(*) AS joints, SUM(length_ft) AS feet
+		FROM store.joint_tallies
+		WHERE tenant_id = $1
+		GROUP BY inventory_item_id
+	) t ON t.inventory_item_id = i.id
+	WHERE i.tenant_id = $1 AND i.deleted = false`
+
+const tallyColumns = `
+	id, inventory_item_id, joint_number, length_ft, tallied_by_user_id, tallied_at`
+
+func scanWeightLine(row rowScanner) (*WeightLine, error) {
+	var l WeightLine
+	err := row.Scan(
+		&l.ItemID, &l.RNumber, &l.CustomerID, &l.Customer, &l.Joints,
+		&l.Size, &l.Weight, &l.Grade, &l.Rack, &l.Location, &l.AverageLength,
+		&l.TalliedJoints, &l.TalliedFeet,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func scanTally(row rowScanner) (*JointTally, error) {
+	var t JointTally
+	err := row.Scan(&t.ID, &t.ItemID, &t.JointNumber, &t.LengthFt, &t.TalliedByUserID, &t.TalliedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *weightRepository) GetSizes(ctx context.Context, tenantID string) ([]weight.Spec, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT size, nominal_size, outer_diameter, inner_diameter, weight_per_foot
+		FROM store.sizes
+		WHERE active = true
+		ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sizes: %w", err)
+	}
+	defer rows.Close()
+
+	var specs []weight.Spec
+	for rows.Next() {
+		var s weight.Spec
+		if err := rows.Scan(&s.Size, &s.NominalSize, &s.OuterDiameter, &s.InnerDiameter, &s.WeightPerFoot); err != nil {
+			return nil, fmt.Errorf("failed to scan size: %w", err)
+		}
+		specs = append(specs, s)
+	}
+
+	return specs, rows.Err()
+}
+
+func (r *weightRepository) GetWeightLine(ctx context.Context, tenantID string, itemID int) (*WeightLine, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	line, err := scanWeightLine(db.QueryRowContext(ctx, weightLineQuery+` AND i.id = $2`, tenantID, itemID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to get inventory item: %w", err)
+	}
+	return line, nil
+}
+
+func (r *weightRepository) GetWeightLines(ctx context.Context, tenantID string, filters WeightFilters) ([]WeightLine, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	var where []string
+	switch {
+	case !filters.IncludeShipped:
+		where = append(where, "i.date_out IS NULL")
+	case filters.Available != nil && *filters.Available:
+		where = append(where, "i.date_out IS NULL")
+	case filters.Available != nil:
+		where = append(where, "i.date_out IS NOT NULL")
+	}
+	args := []interface{}{tenantID}
+	argIndex := 2
+
+	if filters.CustomerID != nil {
+		where = append(where, fmt.Sprintf("i.customer_id = $%d", argIndex))
+		args = append(args, *filters.CustomerID)
+		argIndex++
+	}
+	if filters.Rack != "" {
+		where = append(where, fmt.Sprintf("i.rack = $%d", argIndex))
+		args = append(args, filters.Rack)
+		argIndex++
+	}
+	if filters.Location != "" {
+		where = append(where, fmt.Sprintf("i.location = $%d", argIndex))
+		args = append(args, filters.Location)
+		argIndex++
+	}
+	if filters.Size != "" {
+		where = append(where, fmt.Sprintf("i.size = $%d", argIndex))
+		args = append(args, filters.Size)
+		argIndex++
+	}
+	if filters.Grade != "" {
+		where = append(where, fmt.Sprintf("i.grade = $%d", argIndex))
+		args = append(args, filters.Grade)
+		argIndex++
+	}
+	if filters.WorkOrder != "" {
+		where = append(where, fmt.Sprintf("i.work_order = $%d", argIndex))
+		args = append(args, filters.WorkOrder)
+		argIndex++
+	}
+	if filters.DateFrom != nil {
+		where = append(where, fmt.Sprintf("i.date_in >= $%d", argIndex))
+		args = append(args, *filters.DateFrom)
+		argIndex++
+	}
+	if filters.DateTo != nil {
+		where = append(where, fmt.Sprintf("i.date_in <= $%d", argIndex))
+		args = append(args, *filters.DateTo)
+		argIndex++
+	}
+	if filters.Search != "" {
+		where = append(where, fmt.Sprintf("(i.customer ILIKE $%d OR i.work_order ILIKE $%d OR i.notes ILIKE $%d)", argIndex, argIndex, argIndex))
+		args = append(args, "%"+filters.Search+"%")
+		argIndex++
+	}
+
+	query := weightLineQuery
+	if len(where) > 0 {
+		query += ` AND ` + strings.Join(where, " AND ")
+	}
+	rows, err := db.QueryContext(ctx, query+`
+		ORDER BY i.id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query inventory weights: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []WeightLine
+	for rows.Next() {
+		line, err := scanWeightLine(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan inventory weight: %w", err)
+		}
+		lines = append(lines, *line)
+	}
+
+	return lines, rows.Err()
+}
+
+func (r *weightRepository) GetTally(ctx context.Context, tenantID string, itemID int) ([]JointTally, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	var exists bool
+	err = db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM store.inventory WHERE id = $1 AND tenant_id = $2 AND deleted = false)`,
+		itemID, tenantID).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check inventory item: %w", err)
+	}
+	if !exists {
+		return nil, ErrItemNotFound
+	}
+
+	return queryTally(ctx, db, tenantID, itemID)
+}
+
+func queryTally(ctx context.Context, q querier, tenantID string, itemID int) ([]JointTally, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT`+tallyColumns+`
+		FROM store.joint_tallies
+		WHERE tenant_id = $1 AND inventory_item_id = $2
+		ORDER BY joint_number`, tenantID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query joint tally: %w", err)
+	}
+	defer rows.Close()
+
+	tally := []JointTally{}
+	for rows.Next() {
+		t, err := scanTally(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan joint tally: %w", err)
+		}
+		tally = append(tally, *t)
+	}
+
+	return tally, rows.Err()
+}
+
+func (r *weightRepository) RecordTally(ctx context.Context, tenantID string, userID int, req *RecordTallyRequest) ([]JointTally, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	item, err := lockItemTx(ctx, tx, tenantID, req.ItemID)
+	if err != nil {
+		return nil, err
+	}
+	if item.DateOut != nil {
+		return nil, ErrNotInStock
+	}
+	if len(req.Lengths) > item.Joints {
+		return nil, ErrTallyTooLong
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM store.joint_tallies WHERE tenant_id = $1 AND inventory_item_id = $2`, tenantID, item.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear joint tally: %w", err)
+	}
+
+	for i, length := range req.Lengths {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO store.joint_tallies (
+				tenant_id, inventory_item_id, joint_number, length_ft, tallied_by_user_id
+			) VALUES ($1, $2, $3, $4, $5)`,
+			tenantID, item.ID, i+1, length, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record joint %d: %w", i+1, err)
+		}
+	}
+
+	tally, err := queryTally(ctx, tx, tenantID, item.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit joint tally: %w", err)
+	}
+	return tally, nil
+}
+
+func (r *weightRepository) SetAverageLength(ctx context.Context, tenantID string, req *SetAverageLengthRequest) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	result, err := db.ExecContext(ctx, `
+		UPDATE store.inventory SET average_joint_length = $1
+		WHERE id = $2 AND tenant_id = $3 AND deleted = false`,
+		req.AverageLength, req.ItemID, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to set average joint length: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to set average joint length: %w", err)
+	}
+	if affected == 0 {
+		return ErrItemNotFound
+	}
+	return nil
+}
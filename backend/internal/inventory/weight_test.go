@@ -0,0 +1,194 @@
+// backend/internal/inventory/weight_test.go
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"oilgas-backend/internal/shared/weight"
+)
+
+type mockWeightRepository struct {
+	mock.Mock
+}
+
+func (m *mockWeightRepository) GetSizes(ctx context.Context, tenantID string) ([]weight.Spec, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).([]weight.Spec), args.Error(1)
+}
+
+func (m *mockWeightRepository) GetWeightLine(ctx context.Context, tenantID string, itemID int) (*WeightLine, error) {
+	args := m.Called(ctx, tenantID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*WeightLine), args.Error(1)
+}
+
+func (m *mockWeightRepository) GetWeightLines(ctx context.Context, tenantID string, filters WeightFilters) ([]WeightLine, error) {
+	args := m.Called(ctx, tenantID, filters)
+	return args.Get(0).([]WeightLine), args.Error(1)
+}
+
+func (m *mockWeightRepository) GetTally(ctx context.Context, tenantID string, itemID int) ([]JointTally, error) {
+	args := m.Called(ctx, tenantID, itemID)
+	return args.Get(0).([]JointTally), args.Error(1)
+}
+
+func (m *mockWeightRepository) RecordTally(ctx context.Context, tenantID string, userID int, req *RecordTallyRequest) ([]JointTally, error) {
+	args := m.Called(ctx, tenantID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]JointTally), args.Error(1)
+}
+
+func (m *mockWeightRepository) SetAverageLength(ctx context.Context, tenantID string, req *SetAverageLengthRequest) error {
+	return m.Called(ctx, tenantID, req).Error(0)
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+type WeightServiceTestSuite struct {
+	suite.Suite
+	service  WeightService
+	weights  *mockWeightRepository
+	ctx      context.Context
+	tenantID string
+	specs    []weight.Spec
+}
+
+func (suite *WeightServiceTestSuite) SetupTest() {
+	suite.weights = &mockWeightRepository{}
+	suite.service = NewWeightService(suite.weights)
+	suite.ctx = context.Background()
+	suite.tenantID = "longbeach"
+	suite.specs = []weight.Spec{
+		{Size: `5 1/2" 17#`, NominalSize: strPtr(`5 1/2"`), OuterDiameter: floatPtr(5.5), InnerDiameter: floatPtr(4.892), WeightPerFoot: floatPtr(17)},
+		{Size: `5 1/2" 20#`, NominalSize: strPtr(`5 1/2"`), OuterDiameter: floatPtr(5.5), InnerDiameter: floatPtr(4.778), WeightPerFoot: floatPtr(20)},
+		{Size: `2 7/8" 6.5#`, NominalSize: strPtr(`2 7/8"`), OuterDiameter: floatPtr(2.875), InnerDiameter: floatPtr(2.441), WeightPerFoot: floatPtr(6.5)},
+	}
+}
+
+func TestWeightServiceSuite(t *testing.T) {
+	suite.Run(t, new(WeightServiceTestSuite))
+}
+
+func (suite *WeightServiceTestSuite) TestGetItemWeight_TalliedRow() {
+	line := &WeightLine{ItemID: 501, Joints: 2, Size: strPtr(`5-1/2"`), Weight: floatPtr(17), Grade: strPtr("L80"), TalliedJoints: 2, TalliedFeet: 62.5}
+	suite.weights.On("GetWeightLine", suite.ctx, suite.tenantID, 501).Return(line, nil)
+	suite.weights.On("GetSizes", suite.ctx, suite.tenantID).Return(suite.specs, nil)
+
+	got, err := suite.service.GetItemWeight(suite.ctx, suite.tenantID, 501)
+
+	suite.NoError(err)
+	suite.Equal(`5 1/2" 17#`, *got.MatchedSize)
+	suite.Equal(weight.LengthTally, got.LengthSource)
+	suite.InDelta(1062.5, got.Pounds, 1e-9)
+	suite.InDelta(0.53125, got.ShortTons, 1e-9)
+	suite.InDelta(25.30, got.WeightPerMeter, 0.01)
+	suite.False(got.Flagged)
+}
+
+func (suite *WeightServiceTestSuite) TestGetItemWeights_FlaggedOnly() {
+	filters := WeightFilters{FlaggedOnly: true, Limit: 50}
+	lines := []WeightLine{
+		{ItemID: 501, Joints: 10, Size: strPtr(`5 1/2"`), Weight: floatPtr(17)},
+		{ItemID: 502, Joints: 10, Size: strPtr(`2 7/8"`), Weight: floatPtr(65)},
+		{ItemID: 503, Joints: 10, Size: strPtr(`5 1/2"`), Weight: floatPtr(19.8)},
+	}
+	suite.weights.On("GetWeightLines", suite.ctx, suite.tenantID, filters).Return(lines, nil)
+	suite.weights.On("GetSizes", suite.ctx, suite.tenantID).Return(suite.specs, nil)
+
+	got, total, err := suite.service.GetItemWeights(suite.ctx, suite.tenantID, filters)
+
+	suite.NoError(err)
+	suite.Equal(1, total)
+	suite.Require().Len(got, 1)
+	suite.Equal(502, got[0].ItemID)
+	suite.InDelta(6.5, got[0].PerFoot, 1e-9)
+	suite.InDelta(9.0, *got[0].Deviation, 1e-9)
+}
+
+func (suite *WeightServiceTestSuite) TestGetWeightSummary_TotalsInEveryUnit() {
+	customerID := 12
+	filters := WeightFilters{CustomerID: &customerID, Rack: "A-12"}
+	lines := []WeightLine{
+		{ItemID: 501, Joints: 100, Size: strPtr(`5 1/2"`), Weight: floatPtr(17), AverageLength: floatPtr(40)},
+		{ItemID: 502, Joints: 20, Size: strPtr(`3 1/2"`), Weight: floatPtr(9.3)},
+		{ItemID: 503, Joints: 5, Size: strPtr("odd size")},
+	}
+	suite.weights.On("GetWeightLines", suite.ctx, suite.tenantID, filters).Return(lines, nil)
+	suite.weights.On("GetSizes", suite.ctx, suite.tenantID).Return(suite.specs, nil)
+
+	summary, err := suite.service.GetWeightSummary(suite.ctx, suite.tenantID, WeightFilters{CustomerID: &customerID, Rack: " A-12 "})
+
+	suite.NoError(err)
+	pounds := 100*40*17.0 + 20*weight.DefaultJointLength*9.3
+	suite.Equal(3, summary.TotalItems)
+	suite.Equal(125, summary.TotalJoints)
+	suite.InDelta(pounds, summary.TotalWeight, 1e-6)
+	suite.InDelta(pounds/2000, summary.ShortTons, 1e-9)
+	suite.InDelta(pounds*weight.KilogramsPerPound/1000, summary.MetricTons, 1e-9)
+	suite.Equal(1, summary.EnteredItems)
+	suite.Equal(1, summary.UnknownItems)
+	suite.Equal(2, summary.DefaultLengthItems)
+}
+
+func (suite *WeightServiceTestSuite) TestCheckWeight_ConvertsMetricWeight() {
+	suite.weights.On("GetSizes", suite.ctx, suite.tenantID).Return(suite.specs, nil)
+
+	// 29.76 kg/m is 20 lb/ft
+	req := &WeightCheckRequest{Size: " 5-1/2 in ", Grade: "p110", Weight: floatPtr(29.76), Unit: weight.KilogramsPerMeter, Joints: 40}
+
+	got, err := suite.service.CheckWeight(suite.ctx, suite.tenantID, req)
+
+	suite.NoError(err)
+	suite.Equal(`5 1/2" 20#`, *got.MatchedSize)
+	suite.False(got.Flagged)
+	suite.InDelta(20*40*weight.DefaultJointLength, got.Pounds, 1e-9)
+	suite.Equal("P110", req.Grade)
+}
+
+func (suite *WeightServiceTestSuite) TestCheckWeight_RejectsInvalidRequests() {
+	testCases := []struct {
+		name string
+		req  *WeightCheckRequest
+	}{
+		{"missing request", nil},
+		{"missing size", &WeightCheckRequest{Weight: floatPtr(17)}},
+		{"unknown unit", &WeightCheckRequest{Size: `7"`, Weight: floatPtr(26), Unit: "lb/in"}},
+		{"negative weight", &WeightCheckRequest{Size: `7"`, Weight: floatPtr(-26)}},
+		{"joint too long", &WeightCheckRequest{Size: `7"`, AverageLength: floatPtr(95)}},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			_, err := suite.service.CheckWeight(suite.ctx, suite.tenantID, tc.req)
+
+			assert.Error(suite.T(), err)
+			assert.Contains(suite.T(), err.Error(), "validation failed")
+		})
+	}
+
+	suite.weights.AssertNotCalled(suite.T(), "GetSizes")
+}
+
+func (suite *WeightServiceTestSuite) TestRecordTally_ValidatesLengths() {
+	_, err := suite.service.RecordTally(suite.ctx, suite.tenantID, 7, &RecordTallyRequest{ItemID: 501, Lengths: []float64{31.2, 0}})
+	suite.Error(err)
+	suite.Contains(err.Error(), "joint 2")
+	suite.weights.AssertNotCalled(suite.T(), "RecordTally")
+
+	req := &RecordTallyRequest{ItemID: 501, Lengths: []float64{31.2, 30.8}}
+	suite.weights.On("RecordTally", suite.ctx, suite.tenantID, 7, req).Return(nil, ErrTallyTooLong)
+
+	_, err = suite.service.RecordTally(suite.ctx, suite.tenantID, 7, req)
+	suite.ErrorIs(err, ErrTallyTooLong)
+}
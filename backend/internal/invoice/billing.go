@@ -0,0 +1,133 @@
+// backend/internal/invoice/billing.go
+package invoice
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"oilgas-backend/internal/workorder"
+)
+
+const defaultTermDays = 30
+
+var netTermsRegex = regexp.MustCompile(`^NET\s*-?\s*(\d{1,3})$`)
+
+// TermDays converts customer payment terms such as "NET30", "Net 45" or
+// "Due on receipt" to a number of days. Unrecognised terms fall back to 30.
+func TermDays(terms string) (int, bool) {
+	normalized := strings.ToUpper(strings.TrimSpace(terms))
+	normalized = strings.NewReplacer("_", " ", "-", " ").Replace(normalized)
+
+	switch normalized {
+	case "":
+		return defaultTermDays, false
+	case "DUE ON RECEIPT", "COD", "CIA", "IMMEDIATE":
+		return 0, true
+	}
+
+	if match := netTermsRegex.FindStringSubmatch(normalized); match != nil {
+		days, err := strconv.Atoi(match[1])
+		if err == nil {
+			return days, true
+		}
+	}
+
+	return defaultTermDays, false
+}
+
+// DueDate returns the due date for an invoice dated invoiceDate
+func DueDate(terms string, invoiceDate time.Time) time.Time {
+	days, _ := TermDays(terms)
+	return invoiceDate.AddDate(0, 0, days)
+}
+
+// BuildLines turns a work order into invoice lines: one per priced item,
+// then labor (ActualHours × HourlyRate) and materials
+func BuildLines(wo *workorder.WorkOrder) ([]Line, error) {
+	var lines []Line
+
+	for _, item := range wo.Items {
+		amount, unitPrice, ok := itemAmount(item)
+		if !ok {
+			continue
+		}
+		itemID := item.ID
+		lines = append(lines, Line{
+			LineType:        LineItem,
+			WorkOrderItemID: &itemID,
+			Description:     item.Description,
+			Quantity:        float64(item.Quantity),
+			UnitPrice:       unitPrice,
+			Amount:          amount,
+		})
+	}
+
+	if wo.ActualHours != nil && *wo.ActualHours > 0 {
+		if wo.HourlyRate == nil {
+			return nil, fmt.Errorf("work order has %.2f labor hours but no hourly rate", *wo.ActualHours)
+		}
+		lines = append(lines, Line{
+			LineType:    LineLabor,
+			Description: fmt.Sprintf("Labor - %s", strings.ToLower(string(wo.ServiceType))),
+			Quantity:    *wo.ActualHours,
+			UnitPrice:   *wo.HourlyRate,
+			Amount:      roundCents(*wo.ActualHours * *wo.HourlyRate),
+		})
+	}
+
+	if wo.MaterialsCost != nil && *wo.MaterialsCost > 0 {
+		lines = append(lines, Line{
+			LineType:    LineMaterials,
+			Description: "Materials",
+			Quantity:    1,
+			UnitPrice:   *wo.MaterialsCost,
+			Amount:      roundCents(*wo.MaterialsCost),
+		})
+	}
+
+	for i := range lines {
+		lines[i].SortOrder = i + 1
+	}
+
+	if sumLines(lines) <= 0 {
+		return nil, ErrNothingToBill
+	}
+
+	return lines, nil
+}
+
+// itemAmount prefers the item's stored total and falls back to quantity ×
+// unit price. Items without either are covered by labor and skipped.
+func itemAmount(item workorder.WorkOrderItem) (amount, unitPrice float64, ok bool) {
+	switch {
+	case item.TotalPrice != nil:
+		amount = roundCents(*item.TotalPrice)
+		if item.UnitPrice != nil {
+			unitPrice = *item.UnitPrice
+		} else if item.Quantity > 0 {
+			unitPrice = roundCents(amount / float64(item.Quantity))
+		}
+	case item.UnitPrice != nil:
+		unitPrice = *item.UnitPrice
+		amount = roundCents(unitPrice * float64(item.Quantity))
+	default:
+		return 0, 0, false
+	}
+	return amount, unitPrice, amount != 0
+}
+
+func sumLines(lines []Line) float64 {
+	var total float64
+	for _, line := range lines {
+		total += line.Amount
+	}
+	return roundCents(total)
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
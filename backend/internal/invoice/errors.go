@@ -0,0 +1,14 @@
+// backend/internal/invoice/errors.go
+package invoice
+
+import "errors"
+
+// Invoice errors
+var (
+	ErrInvoiceNotFound      = errors.New("invoice not found")
+	ErrWorkOrderNotBillable = errors.New("work order is not ready to invoice")
+	ErrNothingToBill        = errors.New("work order has no billable amounts")
+	ErrInvoiceClosed        = errors.New("invoice does not accept payments")
+	ErrOverpayment          = errors.New("payment exceeds balance due")
+	ErrCannotVoid           = errors.New("invoice cannot be voided")
+)
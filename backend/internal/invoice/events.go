@@ -0,0 +1,62 @@
+// backend/internal/invoice/events.go
+package invoice
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"oilgas-backend/internal/shared/events"
+)
+
+type PaymentRecordedEvent struct {
+	events.BaseEvent
+	InvoiceID   int           `json:"invoice_id"`
+	WorkOrderID *int          `json:"work_order_id"`
+	PaymentID   int           `json:"payment_id"`
+	Amount      float64       `json:"amount"`
+	Method      PaymentMethod `json:"method"`
+	BalanceDue  float64       `json:"balance_due"`
+	RecordedBy  int           `json:"recorded_by_user_id"`
+}
+
+func NewPaymentRecordedEvent(tenantID string, inv *Invoice, payment *Payment) *PaymentRecordedEvent {
+	return &PaymentRecordedEvent{
+		BaseEvent: events.BaseEvent{
+			ID:        uuid.New().String(),
+			Type:      "invoice.payment_recorded",
+			Tenant:    tenantID,
+			CreatedAt: time.Now(),
+		},
+		InvoiceID:   inv.ID,
+		WorkOrderID: inv.WorkOrderID,
+		PaymentID:   payment.ID,
+		Amount:      payment.Amount,
+		Method:      payment.Method,
+		BalanceDue:  inv.BalanceDue,
+		RecordedBy:  payment.RecordedByUserID,
+	}
+}
+
+type InvoiceVoidedEvent struct {
+	events.BaseEvent
+	InvoiceID   int    `json:"invoice_id"`
+	WorkOrderID *int   `json:"work_order_id"`
+	Reason      string `json:"reason"`
+	VoidedBy    int    `json:"voided_by_user_id"`
+}
+
+func NewInvoiceVoidedEvent(tenantID string, inv *Invoice, reason string, voidedBy int) *InvoiceVoidedEvent {
+	return &InvoiceVoidedEvent{
+		BaseEvent: events.BaseEvent{
+			ID:        uuid.New().String(),
+			Type:      "invoice.voided",
+			Tenant:    tenantID,
+			CreatedAt: time.Now(),
+		},
+		InvoiceID:   inv.ID,
+		WorkOrderID: inv.WorkOrderID,
+		Reason:      reason,
+		VoidedBy:    voidedBy,
+	}
+}
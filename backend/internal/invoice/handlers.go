@@ -0,0 +1,252 @@
+// backend/internal/invoice/handlers.go
+package invoice
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"oilgas-backend/internal/auth"
+	"oilgas-backend/internal/workorder"
+)
+
+type Handlers struct {
+	service Service
+}
+
+func NewHandlers(service Service) *Handlers {
+	return &Handlers{service: service}
+}
+
+func (h *Handlers) RegisterRoutes(router *gin.RouterGroup, authMiddleware *auth.Middleware) {
+	invoices := router.Group("/invoices")
+	invoices.Use(authMiddleware.RequireAuth())
+	invoices.Use(authMiddleware.RequireRole(auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin))
+
+	invoices.GET("", h.SearchInvoices)
+	invoices.POST("", h.GenerateInvoice)
+	invoices.GET("/:id", h.GetInvoice)
+	invoices.POST("/:id/payments", h.RecordPayment)
+	invoices.POST("/:id/void", h.VoidInvoice)
+	invoices.POST("/:id/credit-memos", h.IssueCreditMemo)
+}
+
+func (h *Handlers) GetInvoice(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invoice ID"})
+		return
+	}
+
+	inv, err := h.service.GetInvoice(c.Request.Context(), tenantID, id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Invoice not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, inv)
+}
+
+func (h *Handlers) SearchInvoices(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+
+	filters := SearchFilters{
+		InvoiceType: Type(c.Query("invoice_type")),
+	}
+
+	if customerID := c.Query("customer_id"); customerID != "" {
+		if id, err := strconv.Atoi(customerID); err == nil {
+			filters.CustomerID = &id
+		}
+	}
+
+	if workOrderID := c.Query("work_order_id"); workOrderID != "" {
+		if id, err := strconv.Atoi(workOrderID); err == nil {
+			filters.WorkOrderID = &id
+		}
+	}
+
+	for _, status := range c.QueryArray("status") {
+		filters.Status = append(filters.Status, Status(status))
+	}
+
+	if from := c.Query("date_from"); from != "" {
+		if t, err := time.Parse("2006-01-02", from); err == nil {
+			filters.DateFrom = &t
+		}
+	}
+
+	if to := c.Query("date_to"); to != "" {
+		if t, err := time.Parse("2006-01-02", to); err == nil {
+			filters.DateTo = &t
+		}
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filters.Limit = l
+		}
+	}
+
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filters.Offset = o
+		}
+	}
+
+	invoices, total, err := h.service.SearchInvoices(c.Request.Context(), tenantID, filters)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  invoices,
+		"total": total,
+	})
+}
+
+type GenerateInvoiceRequest struct {
+	WorkOrderID int `json:"work_order_id" binding:"required"`
+}
+
+func (h *Handlers) GenerateInvoice(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+
+	var req GenerateInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	inv, err := h.service.GenerateFromWorkOrder(c.Request.Context(), tenantID, c.GetInt("user_id"), req.WorkOrderID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, inv)
+}
+
+type RecordPaymentRequest struct {
+	Amount      float64       `json:"amount" binding:"required"`
+	Method      PaymentMethod `json:"method" binding:"required"`
+	PaymentDate string        `json:"payment_date"`
+	Reference   *string       `json:"reference"`
+	Notes       *string       `json:"notes"`
+}
+
+func (h *Handlers) RecordPayment(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invoice ID"})
+		return
+	}
+
+	var req RecordPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	payment := &Payment{
+		InvoiceID: id,
+		Amount:    req.Amount,
+		Method:    req.Method,
+		Reference: req.Reference,
+		Notes:     req.Notes,
+	}
+
+	if req.PaymentDate != "" {
+		date, err := time.Parse("2006-01-02", req.PaymentDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "payment_date must be YYYY-MM-DD"})
+			return
+		}
+		payment.PaymentDate = date
+	}
+
+	inv, err := h.service.RecordPayment(c.Request.Context(), tenantID, c.GetInt("user_id"), payment)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"payment": payment,
+		"invoice": inv,
+	})
+}
+
+type VoidInvoiceRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+func (h *Handlers) VoidInvoice(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invoice ID"})
+		return
+	}
+
+	var req VoidInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	inv, err := h.service.VoidInvoice(c.Request.Context(), tenantID, c.GetInt("user_id"), id, req.Reason)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, inv)
+}
+
+type CreditMemoRequest struct {
+	Amount float64 `json:"amount" binding:"required"`
+	Reason string  `json:"reason" binding:"required"`
+}
+
+func (h *Handlers) IssueCreditMemo(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invoice ID"})
+		return
+	}
+
+	var req CreditMemoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	memo, err := h.service.IssueCreditMemo(c.Request.Context(), tenantID, c.GetInt("user_id"), id, req.Amount, req.Reason)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, memo)
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrInvoiceNotFound), errors.Is(err, workorder.ErrWorkOrderNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrWorkOrderNotBillable), errors.Is(err, ErrInvoiceClosed),
+		errors.Is(err, ErrOverpayment), errors.Is(err, ErrCannotVoid),
+		errors.Is(err, workorder.ErrStatusConflict):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,113 @@
+// backend/internal/invoice/models.go
+package invoice
+
+import "time"
+
+// Invoice bills a customer for a completed work order. Credit memos share the
+// same table with a negative total and a reference to the invoice they credit.
+type Invoice struct {
+	ID                int    `json:"id" db:"id"`
+	TenantID          string `json:"tenant_id" db:"tenant_id"`
+	CustomerID        int    `json:"customer_id" db:"customer_id"`
+	WorkOrderID       *int   `json:"work_order_id" db:"workorder_id"`
+	OriginalInvoiceID *int   `json:"original_invoice_id,omitempty" db:"original_invoice_id"`
+
+	InvoiceNumber string `json:"invoice_number" db:"invoice_number"`
+	InvoiceType   Type   `json:"invoice_type" db:"invoice_type"`
+	Status        Status `json:"status" db:"status"`
+
+	PaymentTerms string    `json:"payment_terms" db:"payment_terms"`
+	InvoiceDate  time.Time `json:"invoice_date" db:"invoice_date"`
+	DueDate      time.Time `json:"due_date" db:"due_date"`
+
+	Total      float64 `json:"total" db:"total"`
+	AmountPaid float64 `json:"amount_paid" db:"amount_paid"`
+	BalanceDue float64 `json:"balance_due" db:"balance_due"`
+
+	Notes      *string    `json:"notes,omitempty" db:"notes"`
+	VoidReason *string    `json:"void_reason,omitempty" db:"void_reason"`
+	VoidedAt   *time.Time `json:"voided_at,omitempty" db:"voided_at"`
+
+	CreatedByUserID int       `json:"created_by_user_id" db:"created_by_user_id"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+
+	// Relationships (loaded separately)
+	Lines    []Line    `json:"lines,omitempty"`
+	Payments []Payment `json:"payments,omitempty"`
+}
+
+type Type string
+
+const (
+	TypeInvoice    Type = "INVOICE"
+	TypeCreditMemo Type = "CREDIT_MEMO"
+)
+
+type Status string
+
+const (
+	StatusOpen          Status = "OPEN"
+	StatusPartiallyPaid Status = "PARTIALLY_PAID"
+	StatusPaid          Status = "PAID"
+	StatusVoid          Status = "VOID"
+	StatusApplied       Status = "APPLIED" // Credit memo applied to its invoice
+)
+
+// Line is a single billed amount on an invoice
+type Line struct {
+	ID              int      `json:"id" db:"id"`
+	InvoiceID       int      `json:"invoice_id" db:"invoice_id"`
+	LineType        LineType `json:"line_type" db:"line_type"`
+	WorkOrderItemID *int     `json:"work_order_item_id,omitempty" db:"workorder_item_id"`
+	Description     string   `json:"description" db:"description"`
+	Quantity        float64  `json:"quantity" db:"quantity"`
+	UnitPrice       float64  `json:"unit_price" db:"unit_price"`
+	Amount          float64  `json:"amount" db:"amount"`
+	SortOrder       int      `json:"sort_order" db:"sort_order"`
+}
+
+type LineType string
+
+const (
+	LineItem      LineType = "ITEM"
+	LineLabor     LineType = "LABOR"
+	LineMaterials LineType = "MATERIALS"
+	LineCredit    LineType = "CREDIT"
+	LineStorage   LineType = "STORAGE" // Rack rent, see inventory.StorageService
+)
+
+// Payment is money (or credit) applied against an invoice
+type Payment struct {
+	ID               int           `json:"id" db:"id"`
+	InvoiceID        int           `json:"invoice_id" db:"invoice_id"`
+	Amount           float64       `json:"amount" db:"amount"`
+	PaymentDate      time.Time     `json:"payment_date" db:"payment_date"`
+	Method           PaymentMethod `json:"method" db:"method"`
+	Reference        *string       `json:"reference,omitempty" db:"reference"`
+	Notes            *string       `json:"notes,omitempty" db:"notes"`
+	RecordedByUserID int           `json:"recorded_by_user_id" db:"recorded_by_user_id"`
+	CreatedAt        time.Time     `json:"created_at" db:"created_at"`
+}
+
+type PaymentMethod string
+
+const (
+	MethodCheck      PaymentMethod = "CHECK"
+	MethodACH        PaymentMethod = "ACH"
+	MethodWire       PaymentMethod = "WIRE"
+	MethodCard       PaymentMethod = "CARD"
+	MethodCash       PaymentMethod = "CASH"
+	MethodCreditMemo PaymentMethod = "CREDIT_MEMO"
+)
+
+type SearchFilters struct {
+	CustomerID  *int       `json:"customer_id,omitempty"`
+	WorkOrderID *int       `json:"work_order_id,omitempty"`
+	Status      []Status   `json:"status,omitempty"`
+	InvoiceType Type       `json:"invoice_type,omitempty"`
+	DateFrom    *time.Time `json:"date_from,omitempty"`
+	DateTo      *time.Time `json:"date_to,omitempty"`
+	Limit       int        `json:"limit,omitempty"`
+	Offset      int        `json:"offset,omitempty"`
+}
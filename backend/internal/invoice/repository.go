@@ -0,0 +1,532 @@
+// backend/internal/invoice/repository.go
+package invoice
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"oilgas-backend/internal/numbering"
+	"oilgas-backend/internal/shared/database"
+	"oilgas-backend/internal/workorder"
+)
+
+type Repository interface {
+	GetInvoiceByID(ctx context.Context, tenantID string, id int) (*Invoice, error)
+	SearchInvoices(ctx context.Context, tenantID string, filters SearchFilters) ([]Invoice, int, error)
+	GetLines(ctx context.Context, tenantID string, invoiceID int) ([]Line, error)
+	GetPayments(ctx context.Context, tenantID string, invoiceID int) ([]Payment, error)
+
+	CreateInvoice(ctx context.Context, tenantID string, inv *Invoice) error
+	RecordPayment(ctx context.Context, tenantID string, payment *Payment) (*Invoice, error)
+	VoidInvoice(ctx context.Context, tenantID string, id, userID int, reason string) (*Invoice, error)
+	CreateCreditMemo(ctx context.Context, tenantID string, memo *Invoice) (*Invoice, error)
+}
+
+type repository struct {
+	dbManager *database.DatabaseManager
+	numbers   numbering.Allocator
+}
+
+func NewRepository(dbManager *database.DatabaseManager, numbers numbering.Allocator) Repository {
+	return &repository{dbManager: dbManager, numbers: numbers}
+}
+
+const invoiceColumns = `
+		id, tenant_id, customer_id, workorder_id, original_invoice_id,
+		invoice_number, invoice_type, status, payment_terms, invoice_date, due_date,
+		total, amount_paid, balance_due, notes, void_reason, voided_at,
+		created_by_user_id, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanInvoice(row rowScanner, inv *Invoice) error {
+	return row.Scan(
+		&inv.ID, &inv.TenantID, &inv.CustomerID, &inv.WorkOrderID, &inv.OriginalInvoiceID,
+		&inv.InvoiceNumber, &inv.InvoiceType, &inv.Status, &inv.PaymentTerms, &inv.InvoiceDate, &inv.DueDate,
+		&inv.Total, &inv.AmountPaid, &inv.BalanceDue, &inv.Notes, &inv.VoidReason, &inv.VoidedAt,
+		&inv.CreatedByUserID, &inv.CreatedAt, &inv.UpdatedAt,
+	)
+}
+
+func (r *repository) GetInvoiceByID(ctx context.Context, tenantID string, id int) (*Invoice, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	query := `SELECT ` + invoiceColumns + `
+		FROM store.invoices
+		WHERE id = $1 AND tenant_id = $2`
+
+	var inv Invoice
+	if err := scanInvoice(db.QueryRowContext(ctx, query, id, tenantID), &inv); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrInvoiceNotFound
+		}
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	return &inv, nil
+}
+
+func (r *repository) SearchInvoices(ctx context.Context, tenantID string, filters SearchFilters) ([]Invoice, int, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", argIndex))
+	args = append(args, tenantID)
+	argIndex++
+
+	if filters.CustomerID != nil {
+		conditions = append(conditions, fmt.Sprintf("customer_id = $%d", argIndex))
+		args = append(args, *filters.CustomerID)
+		argIndex++
+	}
+
+	if filters.WorkOrderID != nil {
+		conditions = append(conditions, fmt.Sprintf("workorder_id = $%d", argIndex))
+		args = append(args, *filters.WorkOrderID)
+		argIndex++
+	}
+
+	if len(filters.Status) > 0 {
+		statusPlaceholders := make([]string, len(filters.Status))
+		for i, status := range filters.Status {
+			statusPlaceholders[i] = fmt.Sprintf("$%d", argIndex)
+			args = append(args, status)
+			argIndex++
+		}
+		conditions = append(conditions, fmt.Sprintf("status IN (%s)", strings.Join(statusPlaceholders, ",")))
+	}
+
+	if filters.InvoiceType != "" {
+		conditions = append(conditions, fmt.Sprintf("invoice_type = $%d", argIndex))
+		args = append(args, filters.InvoiceType)
+		argIndex++
+	}
+
+	if filters.DateFrom != nil {
+		conditions = append(conditions, fmt.Sprintf("invoice_date >= $%d", argIndex))
+		args = append(args, *filters.DateFrom)
+		argIndex++
+	}
+
+	if filters.DateTo != nil {
+		conditions = append(conditions, fmt.Sprintf("invoice_date <= $%d", argIndex))
+		args = append(args, *filters.DateTo)
+		argIndex++
+	}
+
+	whereClause := strings.Join(conditions, " AND ")
+
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM store.invoices WHERE %s", whereClause)
+	var total int
+	if err := db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count invoices: %w", err)
+	}
+
+	query := fmt.Sprintf(`SELECT %s
+		FROM store.invoices
+		WHERE %s
+		ORDER BY invoice_date DESC, id DESC`, invoiceColumns, whereClause)
+
+	if filters.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIndex)
+		args = append(args, filters.Limit)
+		argIndex++
+	}
+
+	if filters.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIndex)
+		args = append(args, filters.Offset)
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search invoices: %w", err)
+	}
+	defer rows.Close()
+
+	var invoices []Invoice
+	for rows.Next() {
+		var inv Invoice
+		if err := scanInvoice(rows, &inv); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan invoice: %w", err)
+		}
+		invoices = append(invoices, inv)
+	}
+
+	return invoices, total, rows.Err()
+}
+
+func (r *repository) GetLines(ctx context.Context, tenantID string, invoiceID int) ([]Line, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT l.id, l.invoice_id, l.line_type, l.workorder_item_id, l.description,
+		       l.quantity, l.unit_price, l.amount, l.sort_order
+		FROM store.invoice_lines l
+		JOIN store.invoices i ON i.id = l.invoice_id
+		WHERE l.invoice_id = $1 AND i.tenant_id = $2
+		ORDER BY l.sort_order ASC, l.id ASC`, invoiceID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoice lines: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []Line
+	for rows.Next() {
+		var l Line
+		err := rows.Scan(
+			&l.ID, &l.InvoiceID, &l.LineType, &l.WorkOrderItemID, &l.Description,
+			&l.Quantity, &l.UnitPrice, &l.Amount, &l.SortOrder,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invoice line: %w", err)
+		}
+		lines = append(lines, l)
+	}
+
+	return lines, rows.Err()
+}
+
+func (r *repository) GetPayments(ctx context.Context, tenantID string, invoiceID int) ([]Payment, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT p.id, p.invoice_id, p.amount, p.payment_date, p.method,
+		       p.reference, p.notes, p.recorded_by_user_id, p.created_at
+		FROM store.invoice_payments p
+		JOIN store.invoices i ON i.id = p.invoice_id
+		WHERE p.invoice_id = $1 AND i.tenant_id = $2
+		ORDER BY p.payment_date ASC, p.id ASC`, invoiceID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoice payments: %w", err)
+	}
+	defer rows.Close()
+
+	var payments []Payment
+	for rows.Next() {
+		var p Payment
+		err := rows.Scan(
+			&p.ID, &p.InvoiceID, &p.Amount, &p.PaymentDate, &p.Method,
+			&p.Reference, &p.Notes, &p.RecordedByUserID, &p.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invoice payment: %w", err)
+		}
+		payments = append(payments, p)
+	}
+
+	return payments, rows.Err()
+}
+
+// CreateInvoice inserts the invoice and its lines and moves the work order to
+// INVOICED in one transaction. The optimistic status check on the work order
+// also stops two invoices being generated for it concurrently.
+func (r *repository) CreateInvoice(ctx context.Context, tenantID string, inv *Invoice) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.insertInvoiceTx(ctx, tx, tenantID, inv); err != nil {
+		return err
+	}
+
+	if inv.WorkOrderID != nil {
+		history := &workorder.WorkOrderHistory{
+			ChangedByUserID: inv.CreatedByUserID,
+			Notes:           stringPtr(fmt.Sprintf("Invoice %s generated", inv.InvoiceNumber)),
+		}
+		err := workorder.ApplyStatusChange(ctx, tx, tenantID, *inv.WorkOrderID,
+			workorder.StatusCompleted, workorder.StatusInvoiced, history)
+		if err != nil {
+			return fmt.Errorf("failed to mark work order invoiced: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit invoice: %w", err)
+	}
+
+	return nil
+}
+
+// RecordPayment applies a payment under a row lock so concurrent payments
+// cannot overpay. Settling the balance marks the work order PAID.
+func (r *repository) RecordPayment(ctx context.Context, tenantID string, payment *Payment) (*Invoice, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	inv, err := lockInvoiceTx(ctx, tx, tenantID, payment.InvoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyPaymentTx(ctx, tx, tenantID, inv, payment); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit payment: %w", err)
+	}
+
+	return inv, nil
+}
+
+// VoidInvoice cancels an unpaid invoice and returns its work order to
+// COMPLETED so it can be invoiced again
+func (r *repository) VoidInvoice(ctx context.Context, tenantID string, id, userID int, reason string) (*Invoice, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	inv, err := lockInvoiceTx(ctx, tx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if inv.InvoiceType != TypeInvoice || inv.Status != StatusOpen || inv.AmountPaid != 0 {
+		return nil, fmt.Errorf("%w: %s invoice is %s with %.2f paid", ErrCannotVoid, inv.InvoiceType, inv.Status, inv.AmountPaid)
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		UPDATE store.invoices
+		SET status = 'VOID', void_reason = $3, voided_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND tenant_id = $2
+		RETURNING status, void_reason, voided_at, updated_at`,
+		id, tenantID, reason,
+	).Scan(&inv.Status, &inv.VoidReason, &inv.VoidedAt, &inv.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to void invoice: %w", err)
+	}
+
+	if inv.WorkOrderID != nil {
+		history := &workorder.WorkOrderHistory{
+			ChangedByUserID: userID,
+			Notes:           stringPtr(fmt.Sprintf("Invoice %s voided: %s", inv.InvoiceNumber, reason)),
+		}
+		err := workorder.ApplyStatusChange(ctx, tx, tenantID, *inv.WorkOrderID,
+			workorder.StatusInvoiced, workorder.StatusCompleted, history)
+		if err != nil {
+			return nil, fmt.Errorf("failed to return work order to completed: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit void: %w", err)
+	}
+
+	return inv, nil
+}
+
+// CreateCreditMemo inserts the memo and applies it to the original invoice
+// as a CREDIT_MEMO payment. Returns the updated original invoice.
+func (r *repository) CreateCreditMemo(ctx context.Context, tenantID string, memo *Invoice) (*Invoice, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	original, err := lockInvoiceTx(ctx, tx, tenantID, *memo.OriginalInvoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if original.InvoiceType != TypeInvoice {
+		return nil, fmt.Errorf("credit memos can only be issued against invoices")
+	}
+
+	memo.CustomerID = original.CustomerID
+	memo.WorkOrderID = original.WorkOrderID
+	memo.PaymentTerms = original.PaymentTerms
+	if err := r.insertInvoiceTx(ctx, tx, tenantID, memo); err != nil {
+		return nil, err
+	}
+
+	credit := &Payment{
+		InvoiceID:        original.ID,
+		Amount:           -memo.Total,
+		PaymentDate:      memo.InvoiceDate,
+		Method:           MethodCreditMemo,
+		Reference:        stringPtr(memo.InvoiceNumber),
+		Notes:            memo.Notes,
+		RecordedByUserID: memo.CreatedByUserID,
+	}
+	if err := applyPaymentTx(ctx, tx, tenantID, original, credit); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit credit memo: %w", err)
+	}
+
+	original.Payments = append(original.Payments, *credit)
+	return original, nil
+}
+
+func (r *repository) insertInvoiceTx(ctx context.Context, tx *sql.Tx, tenantID string, inv *Invoice) error {
+	// Invoices and credit memos share one series. The number is taken in the
+	// same transaction as the insert so a failed invoice never burns a number.
+	number, err := r.numbers.Next(ctx, tx, tenantID, numbering.DocumentInvoice)
+	if err != nil {
+		return err
+	}
+	inv.InvoiceNumber = number
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO store.invoices (
+			tenant_id, customer_id, workorder_id, original_invoice_id, invoice_number,
+			invoice_type, status, payment_terms, invoice_date, due_date,
+			total, amount_paid, notes, created_by_user_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id, balance_due, created_at, updated_at`,
+		tenantID, inv.CustomerID, inv.WorkOrderID, inv.OriginalInvoiceID, inv.InvoiceNumber,
+		inv.InvoiceType, inv.Status, inv.PaymentTerms, inv.InvoiceDate, inv.DueDate,
+		inv.Total, inv.AmountPaid, inv.Notes, inv.CreatedByUserID,
+	).Scan(&inv.ID, &inv.BalanceDue, &inv.CreatedAt, &inv.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create invoice: %w", err)
+	}
+
+	for i := range inv.Lines {
+		line := &inv.Lines[i]
+		line.InvoiceID = inv.ID
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO store.invoice_lines (
+				invoice_id, line_type, workorder_item_id, description,
+				quantity, unit_price, amount, sort_order
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING id`,
+			line.InvoiceID, line.LineType, line.WorkOrderItemID, line.Description,
+			line.Quantity, line.UnitPrice, line.Amount, line.SortOrder,
+		).Scan(&line.ID)
+		if err != nil {
+			return fmt.Errorf("failed to create invoice line: %w", err)
+		}
+	}
+
+	inv.TenantID = tenantID
+	return nil
+}
+
+func lockInvoiceTx(ctx context.Context, tx *sql.Tx, tenantID string, id int) (*Invoice, error) {
+	query := `SELECT ` + invoiceColumns + `
+		FROM store.invoices
+		WHERE id = $1 AND tenant_id = $2
+		FOR UPDATE`
+
+	var inv Invoice
+	if err := scanInvoice(tx.QueryRowContext(ctx, query, id, tenantID), &inv); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrInvoiceNotFound
+		}
+		return nil, fmt.Errorf("failed to lock invoice: %w", err)
+	}
+
+	return &inv, nil
+}
+
+// applyPaymentTx records a payment against a locked invoice and updates its
+// paid amount and status, marking the work order PAID when settled
+func applyPaymentTx(ctx context.Context, tx *sql.Tx, tenantID string, inv *Invoice, payment *Payment) error {
+	if inv.Status != StatusOpen && inv.Status != StatusPartiallyPaid {
+		return fmt.Errorf("%w: invoice %s is %s", ErrInvoiceClosed, inv.InvoiceNumber, inv.Status)
+	}
+
+	if roundCents(payment.Amount) > roundCents(inv.BalanceDue) {
+		return fmt.Errorf("%w: %.2f against %.2f due", ErrOverpayment, payment.Amount, inv.BalanceDue)
+	}
+
+	payment.InvoiceID = inv.ID
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO store.invoice_payments (
+			invoice_id, amount, payment_date, method, reference, notes, recorded_by_user_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at`,
+		payment.InvoiceID, payment.Amount, payment.PaymentDate, payment.Method,
+		payment.Reference, payment.Notes, payment.RecordedByUserID,
+	).Scan(&payment.ID, &payment.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record payment: %w", err)
+	}
+
+	amountPaid := roundCents(inv.AmountPaid + payment.Amount)
+	status := StatusPartiallyPaid
+	if amountPaid >= inv.Total {
+		status = StatusPaid
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		UPDATE store.invoices
+		SET amount_paid = $3, status = $4, updated_at = NOW()
+		WHERE id = $1 AND tenant_id = $2
+		RETURNING amount_paid, balance_due, status, updated_at`,
+		inv.ID, tenantID, amountPaid, status,
+	).Scan(&inv.AmountPaid, &inv.BalanceDue, &inv.Status, &inv.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update invoice balance: %w", err)
+	}
+
+	if inv.Status == StatusPaid && inv.WorkOrderID != nil {
+		history := &workorder.WorkOrderHistory{
+			ChangedByUserID: payment.RecordedByUserID,
+			Notes:           stringPtr(fmt.Sprintf("Invoice %s paid in full", inv.InvoiceNumber)),
+		}
+		err := workorder.ApplyStatusChange(ctx, tx, tenantID, *inv.WorkOrderID,
+			workorder.StatusInvoiced, workorder.StatusPaid, history)
+		if err != nil {
+			return fmt.Errorf("failed to mark work order paid: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,362 @@
+// backend/internal/invoice/service.go
+package invoice
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"oilgas-backend/internal/customer"
+	"oilgas-backend/internal/shared/events"
+	"oilgas-backend/internal/workorder"
+)
+
+// WorkOrderReader is the part of the work order domain invoicing reads from
+type WorkOrderReader interface {
+	GetWorkOrder(ctx context.Context, tenantID string, id int) (*workorder.WorkOrder, error)
+}
+
+// CustomerReader supplies the payment terms invoices are issued under
+type CustomerReader interface {
+	GetCustomer(ctx context.Context, tenantID string, id int) (*customer.Customer, error)
+}
+
+type Service interface {
+	GenerateFromWorkOrder(ctx context.Context, tenantID string, userID, workOrderID int) (*Invoice, error)
+	GetInvoice(ctx context.Context, tenantID string, id int) (*Invoice, error)
+	SearchInvoices(ctx context.Context, tenantID string, filters SearchFilters) ([]Invoice, int, error)
+
+	RecordPayment(ctx context.Context, tenantID string, userID int, payment *Payment) (*Invoice, error)
+	VoidInvoice(ctx context.Context, tenantID string, userID, id int, reason string) (*Invoice, error)
+	IssueCreditMemo(ctx context.Context, tenantID string, userID, invoiceID int, amount float64, reason string) (*Invoice, error)
+}
+
+type service struct {
+	repo       Repository
+	workOrders WorkOrderReader
+	customers  CustomerReader
+	publisher  events.Publisher
+}
+
+func NewService(repo Repository, workOrders WorkOrderReader, customers CustomerReader, publisher events.Publisher) Service {
+	return &service{
+		repo:       repo,
+		workOrders: workOrders,
+		customers:  customers,
+		publisher:  publisher,
+	}
+}
+
+// GenerateFromWorkOrder bills a COMPLETED work order and moves it to INVOICED
+func (s *service) GenerateFromWorkOrder(ctx context.Context, tenantID string, userID, workOrderID int) (*Invoice, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	wo, err := s.workOrders.GetWorkOrder(ctx, tenantID, workOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work order %d: %w", workOrderID, err)
+	}
+
+	if wo.Status != workorder.StatusCompleted {
+		return nil, fmt.Errorf("%w: status is %s", ErrWorkOrderNotBillable, wo.Status)
+	}
+
+	lines, err := BuildLines(wo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build invoice lines: %w", err)
+	}
+
+	cust, err := s.customers.GetCustomer(ctx, tenantID, wo.CustomerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer %d: %w", wo.CustomerID, err)
+	}
+
+	invoiceDate := today()
+	terms := cust.PaymentTerms
+	if _, known := TermDays(terms); !known {
+		log.Printf("Unrecognised payment terms %q for customer %d, using NET%d", terms, cust.ID, defaultTermDays)
+		terms = fmt.Sprintf("NET%d", defaultTermDays)
+	}
+
+	inv := &Invoice{
+		TenantID:        tenantID,
+		CustomerID:      wo.CustomerID,
+		WorkOrderID:     &wo.ID,
+		InvoiceType:     TypeInvoice,
+		Status:          StatusOpen,
+		PaymentTerms:    terms,
+		InvoiceDate:     invoiceDate,
+		DueDate:         DueDate(terms, invoiceDate),
+		Total:           sumLines(lines),
+		Notes:           stringPtr(fmt.Sprintf("Work order %s", wo.WorkOrderNumber)),
+		CreatedByUserID: userID,
+		Lines:           lines,
+	}
+
+	if err := s.repo.CreateInvoice(ctx, tenantID, inv); err != nil {
+		return nil, fmt.Errorf("failed to create invoice: %w", err)
+	}
+
+	s.publish(ctx, workorder.NewWorkOrderStatusChangedEvent(tenantID, wo.ID,
+		workorder.StatusCompleted, workorder.StatusInvoiced, userID, "Invoice "+inv.InvoiceNumber))
+	s.publish(ctx, workorder.NewInvoiceGeneratedEvent(tenantID, wo.ID, inv.ID, inv.Total, userID))
+	return inv, nil
+}
+
+func (s *service) GetInvoice(ctx context.Context, tenantID string, id int) (*Invoice, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid invoice ID: %d", id)
+	}
+
+	inv, err := s.repo.GetInvoiceByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoice %d: %w", id, err)
+	}
+
+	lines, err := s.repo.GetLines(ctx, tenantID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoice lines: %w", err)
+	}
+	inv.Lines = lines
+
+	payments, err := s.repo.GetPayments(ctx, tenantID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoice payments: %w", err)
+	}
+	inv.Payments = payments
+
+	return inv, nil
+}
+
+func (s *service) SearchInvoices(ctx context.Context, tenantID string, filters SearchFilters) ([]Invoice, int, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, 0, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if err := validateSearchFilters(&filters); err != nil {
+		return nil, 0, fmt.Errorf("validation failed: %w", err)
+	}
+
+	invoices, total, err := s.repo.SearchInvoices(ctx, tenantID, filters)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search invoices: %w", err)
+	}
+
+	return invoices, total, nil
+}
+
+// RecordPayment applies a full or partial payment. Paying the balance in
+// full marks the invoice and its work order PAID.
+func (s *service) RecordPayment(ctx context.Context, tenantID string, userID int, payment *Payment) (*Invoice, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if err := validatePayment(payment); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	payment.Amount = roundCents(payment.Amount)
+	payment.RecordedByUserID = userID
+	if payment.PaymentDate.IsZero() {
+		payment.PaymentDate = today()
+	}
+
+	inv, err := s.repo.RecordPayment(ctx, tenantID, payment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record payment: %w", err)
+	}
+
+	s.publish(ctx, NewPaymentRecordedEvent(tenantID, inv, payment))
+	s.publishPaid(ctx, tenantID, inv, userID)
+	return inv, nil
+}
+
+// VoidInvoice cancels an invoice with no payments against it. Invoices that
+// have been paid in part must be corrected with a credit memo instead.
+func (s *service) VoidInvoice(ctx context.Context, tenantID string, userID, id int, reason string) (*Invoice, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if strings.TrimSpace(reason) == "" {
+		return nil, fmt.Errorf("validation failed: void reason is required")
+	}
+
+	inv, err := s.repo.VoidInvoice(ctx, tenantID, id, userID, reason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to void invoice %d: %w", id, err)
+	}
+
+	if inv.WorkOrderID != nil {
+		s.publish(ctx, workorder.NewWorkOrderStatusChangedEvent(tenantID, *inv.WorkOrderID,
+			workorder.StatusInvoiced, workorder.StatusCompleted, userID, "Invoice "+inv.InvoiceNumber+" voided"))
+	}
+	s.publish(ctx, NewInvoiceVoidedEvent(tenantID, inv, reason, userID))
+	return inv, nil
+}
+
+// IssueCreditMemo credits part or all of an invoice's balance. The memo is
+// stored as its own document and applied to the invoice as a payment.
+func (s *service) IssueCreditMemo(ctx context.Context, tenantID string, userID, invoiceID int, amount float64, reason string) (*Invoice, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if invoiceID <= 0 {
+		return nil, fmt.Errorf("invalid invoice ID: %d", invoiceID)
+	}
+
+	amount = roundCents(amount)
+	if amount <= 0 {
+		return nil, fmt.Errorf("validation failed: credit amount must be positive")
+	}
+
+	if strings.TrimSpace(reason) == "" {
+		return nil, fmt.Errorf("validation failed: credit reason is required")
+	}
+
+	invoiceDate := today()
+	memo := &Invoice{
+		TenantID:          tenantID,
+		OriginalInvoiceID: &invoiceID,
+		InvoiceType:       TypeCreditMemo,
+		Status:            StatusApplied,
+		InvoiceDate:       invoiceDate,
+		DueDate:           invoiceDate,
+		Total:             -amount,
+		AmountPaid:        -amount,
+		Notes:             stringPtr(reason),
+		CreatedByUserID:   userID,
+		Lines: []Line{{
+			LineType:    LineCredit,
+			Description: reason,
+			Quantity:    1,
+			UnitPrice:   -amount,
+			Amount:      -amount,
+			SortOrder:   1,
+		}},
+	}
+
+	original, err := s.repo.CreateCreditMemo(ctx, tenantID, memo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue credit memo: %w", err)
+	}
+
+	if n := len(original.Payments); n > 0 {
+		s.publish(ctx, NewPaymentRecordedEvent(tenantID, original, &original.Payments[n-1]))
+	}
+	s.publishPaid(ctx, tenantID, original, userID)
+	return memo, nil
+}
+
+func (s *service) publishPaid(ctx context.Context, tenantID string, inv *Invoice, userID int) {
+	if inv.Status != StatusPaid || inv.WorkOrderID == nil {
+		return
+	}
+	s.publish(ctx, workorder.NewWorkOrderStatusChangedEvent(tenantID, *inv.WorkOrderID,
+		workorder.StatusInvoiced, workorder.StatusPaid, userID, "Invoice "+inv.InvoiceNumber+" paid"))
+}
+
+func (s *service) publish(ctx context.Context, event events.Event) {
+	if s.publisher == nil {
+		return
+	}
+	if err := s.publisher.Publish(ctx, event); err != nil {
+		log.Printf("Failed to publish %s for tenant %s: %v", event.EventType(), event.TenantID(), err)
+	}
+}
+
+func validateTenantID(tenantID string) error {
+	if tenantID == "" {
+		return fmt.Errorf("tenant ID is required")
+	}
+	if len(tenantID) > 100 {
+		return fmt.Errorf("tenant ID too long: %d characters", len(tenantID))
+	}
+	return nil
+}
+
+func validatePayment(payment *Payment) error {
+	if payment == nil {
+		return fmt.Errorf("payment is required")
+	}
+
+	if payment.InvoiceID <= 0 {
+		return fmt.Errorf("invoice ID is required")
+	}
+
+	if roundCents(payment.Amount) <= 0 {
+		return fmt.Errorf("payment amount must be positive")
+	}
+
+	switch payment.Method {
+	case MethodCheck, MethodACH, MethodWire, MethodCard, MethodCash:
+	case MethodCreditMemo:
+		return fmt.Errorf("credit memos are applied by issuing a credit memo")
+	default:
+		return fmt.Errorf("invalid payment method: %s", payment.Method)
+	}
+
+	if payment.Reference != nil && len(*payment.Reference) > 100 {
+		return fmt.Errorf("payment reference too long: %d characters", len(*payment.Reference))
+	}
+
+	return nil
+}
+
+func validateSearchFilters(filters *SearchFilters) error {
+	if filters == nil {
+		return nil
+	}
+
+	if filters.Limit < 0 || filters.Limit > 1000 {
+		return fmt.Errorf("limit must be between 0 and 1000")
+	}
+
+	if filters.Offset < 0 {
+		return fmt.Errorf("offset must be non-negative")
+	}
+
+	for _, status := range filters.Status {
+		switch status {
+		case StatusOpen, StatusPartiallyPaid, StatusPaid, StatusVoid, StatusApplied:
+		default:
+			return fmt.Errorf("invalid status in filter: %s", status)
+		}
+	}
+
+	if filters.DateFrom != nil && filters.DateTo != nil && filters.DateTo.Before(*filters.DateFrom) {
+		return fmt.Errorf("date_to cannot be before date_from")
+	}
+
+	return nil
+}
+
+func today() time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
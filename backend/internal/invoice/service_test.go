@@ -0,0 +1,345 @@
+// backend/internal/invoice/service_test.go
+package invoice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"oilgas-backend/internal/customer"
+	"oilgas-backend/internal/shared/events"
+	"oilgas-backend/internal/workorder"
+)
+
+type mockRepository struct {
+	mock.Mock
+}
+
+func (m *mockRepository) GetInvoiceByID(ctx context.Context, tenantID string, id int) (*Invoice, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Invoice), args.Error(1)
+}
+
+func (m *mockRepository) SearchInvoices(ctx context.Context, tenantID string, filters SearchFilters) ([]Invoice, int, error) {
+	args := m.Called(ctx, tenantID, filters)
+	return args.Get(0).([]Invoice), args.Get(1).(int), args.Error(2)
+}
+
+func (m *mockRepository) GetLines(ctx context.Context, tenantID string, invoiceID int) ([]Line, error) {
+	args := m.Called(ctx, tenantID, invoiceID)
+	return args.Get(0).([]Line), args.Error(1)
+}
+
+func (m *mockRepository) GetPayments(ctx context.Context, tenantID string, invoiceID int) ([]Payment, error) {
+	args := m.Called(ctx, tenantID, invoiceID)
+	return args.Get(0).([]Payment), args.Error(1)
+}
+
+func (m *mockRepository) CreateInvoice(ctx context.Context, tenantID string, inv *Invoice) error {
+	args := m.Called(ctx, tenantID, inv)
+	if args.Error(0) == nil {
+		inv.ID = 500
+		inv.InvoiceNumber = "INV-000500"
+		inv.BalanceDue = inv.Total - inv.AmountPaid
+	}
+	return args.Error(0)
+}
+
+func (m *mockRepository) RecordPayment(ctx context.Context, tenantID string, payment *Payment) (*Invoice, error) {
+	args := m.Called(ctx, tenantID, payment)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Invoice), args.Error(1)
+}
+
+func (m *mockRepository) VoidInvoice(ctx context.Context, tenantID string, id, userID int, reason string) (*Invoice, error) {
+	args := m.Called(ctx, tenantID, id, userID, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Invoice), args.Error(1)
+}
+
+func (m *mockRepository) CreateCreditMemo(ctx context.Context, tenantID string, memo *Invoice) (*Invoice, error) {
+	args := m.Called(ctx, tenantID, memo)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Invoice), args.Error(1)
+}
+
+type mockWorkOrders struct {
+	mock.Mock
+}
+
+func (m *mockWorkOrders) GetWorkOrder(ctx context.Context, tenantID string, id int) (*workorder.WorkOrder, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*workorder.WorkOrder), args.Error(1)
+}
+
+type mockCustomers struct {
+	mock.Mock
+}
+
+func (m *mockCustomers) GetCustomer(ctx context.Context, tenantID string, id int) (*customer.Customer, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*customer.Customer), args.Error(1)
+}
+
+type mockPublisher struct {
+	mock.Mock
+}
+
+func (m *mockPublisher) Publish(ctx context.Context, event events.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+type InvoiceServiceTestSuite struct {
+	suite.Suite
+	service    Service
+	repo       *mockRepository
+	workOrders *mockWorkOrders
+	customers  *mockCustomers
+	publisher  *mockPublisher
+	ctx        context.Context
+	tenantID   string
+	userID     int
+}
+
+func (suite *InvoiceServiceTestSuite) SetupTest() {
+	suite.repo = &mockRepository{}
+	suite.workOrders = &mockWorkOrders{}
+	suite.customers = &mockCustomers{}
+	suite.publisher = &mockPublisher{}
+	suite.service = NewService(suite.repo, suite.workOrders, suite.customers, suite.publisher)
+	suite.ctx = context.Background()
+	suite.tenantID = "longbeach"
+	suite.userID = 7
+}
+
+func TestInvoiceServiceSuite(t *testing.T) {
+	suite.Run(t, new(InvoiceServiceTestSuite))
+}
+
+func (suite *InvoiceServiceTestSuite) completedWorkOrder() *workorder.WorkOrder {
+	hours, rate, materials := 6.5, 95.0, 240.0
+	unitPrice := 12.5
+	total := 480.0
+	return &workorder.WorkOrder{
+		ID:              42,
+		TenantID:        suite.tenantID,
+		CustomerID:      3,
+		WorkOrderNumber: "LON-000042",
+		ServiceType:     workorder.ServiceInspection,
+		Status:          workorder.StatusCompleted,
+		ActualHours:     &hours,
+		HourlyRate:      &rate,
+		MaterialsCost:   &materials,
+		Items: []workorder.WorkOrderItem{
+			{ID: 1, Description: "EMI inspect 5-1/2 casing", Quantity: 40, UnitPrice: &unitPrice},
+			{ID: 2, Description: "Thread protectors", Quantity: 80, TotalPrice: &total},
+			{ID: 3, Description: "Unpriced handling", Quantity: 40},
+		},
+	}
+}
+
+func (suite *InvoiceServiceTestSuite) TestGenerateFromWorkOrder_Success() {
+	suite.workOrders.On("GetWorkOrder", suite.ctx, suite.tenantID, 42).Return(suite.completedWorkOrder(), nil)
+	suite.customers.On("GetCustomer", suite.ctx, suite.tenantID, 3).Return(&customer.Customer{ID: 3, PaymentTerms: "NET45"}, nil)
+	suite.repo.On("CreateInvoice", suite.ctx, suite.tenantID, mock.AnythingOfType("*invoice.Invoice")).Return(nil)
+	suite.publisher.On("Publish", suite.ctx, mock.AnythingOfType("*workorder.WorkOrderStatusChangedEvent")).Return(nil)
+	suite.publisher.On("Publish", suite.ctx, mock.MatchedBy(func(e *workorder.InvoiceGeneratedEvent) bool {
+		return e.WorkOrderID == 42 && e.InvoiceID == 500 && e.Amount == 1837.5
+	})).Return(nil)
+
+	inv, err := suite.service.GenerateFromWorkOrder(suite.ctx, suite.tenantID, suite.userID, 42)
+
+	assert.NoError(suite.T(), err)
+	// 40 × 12.50 + 480 + 6.5h × 95 + 240 materials
+	assert.Equal(suite.T(), 1837.5, inv.Total)
+	assert.Len(suite.T(), inv.Lines, 4)
+	assert.Equal(suite.T(), "NET45", inv.PaymentTerms)
+	assert.Equal(suite.T(), 45*24*time.Hour, inv.DueDate.Sub(inv.InvoiceDate))
+	assert.Equal(suite.T(), StatusOpen, inv.Status)
+	suite.publisher.AssertExpectations(suite.T())
+}
+
+func (suite *InvoiceServiceTestSuite) TestGenerateFromWorkOrder_NotCompleted() {
+	wo := suite.completedWorkOrder()
+	wo.Status = workorder.StatusInProgress
+	suite.workOrders.On("GetWorkOrder", suite.ctx, suite.tenantID, 42).Return(wo, nil)
+
+	_, err := suite.service.GenerateFromWorkOrder(suite.ctx, suite.tenantID, suite.userID, 42)
+
+	assert.True(suite.T(), errors.Is(err, ErrWorkOrderNotBillable))
+	suite.repo.AssertNotCalled(suite.T(), "CreateInvoice")
+}
+
+func (suite *InvoiceServiceTestSuite) TestRecordPayment_PartialPayment() {
+	inv := &Invoice{ID: 500, WorkOrderID: intPtr(42), Total: 1000, AmountPaid: 400, BalanceDue: 600, Status: StatusPartiallyPaid}
+	suite.repo.On("RecordPayment", suite.ctx, suite.tenantID, mock.MatchedBy(func(p *Payment) bool {
+		return p.Amount == 400 && p.RecordedByUserID == suite.userID && !p.PaymentDate.IsZero()
+	})).Return(inv, nil)
+	suite.publisher.On("Publish", suite.ctx, mock.AnythingOfType("*invoice.PaymentRecordedEvent")).Return(nil)
+
+	result, err := suite.service.RecordPayment(suite.ctx, suite.tenantID, suite.userID,
+		&Payment{InvoiceID: 500, Amount: 399.999, Method: MethodCheck})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), StatusPartiallyPaid, result.Status)
+	suite.publisher.AssertNumberOfCalls(suite.T(), "Publish", 1)
+}
+
+func (suite *InvoiceServiceTestSuite) TestRecordPayment_PaidInFullSyncsWorkOrder() {
+	inv := &Invoice{ID: 500, WorkOrderID: intPtr(42), InvoiceNumber: "INV-000500", Total: 1000, AmountPaid: 1000, Status: StatusPaid}
+	suite.repo.On("RecordPayment", suite.ctx, suite.tenantID, mock.Anything).Return(inv, nil)
+	suite.publisher.On("Publish", suite.ctx, mock.AnythingOfType("*invoice.PaymentRecordedEvent")).Return(nil)
+	suite.publisher.On("Publish", suite.ctx, mock.MatchedBy(func(e *workorder.WorkOrderStatusChangedEvent) bool {
+		return e.WorkOrderID == 42 && e.NewStatus == string(workorder.StatusPaid)
+	})).Return(nil)
+
+	_, err := suite.service.RecordPayment(suite.ctx, suite.tenantID, suite.userID,
+		&Payment{InvoiceID: 500, Amount: 600, Method: MethodACH})
+
+	assert.NoError(suite.T(), err)
+	suite.publisher.AssertExpectations(suite.T())
+}
+
+func (suite *InvoiceServiceTestSuite) TestRecordPayment_Validation() {
+	testCases := []struct {
+		name        string
+		payment     *Payment
+		expectError string
+	}{
+		{"nil payment", nil, "payment is required"},
+		{"zero amount", &Payment{InvoiceID: 1, Amount: 0, Method: MethodCheck}, "payment amount must be positive"},
+		{"unknown method", &Payment{InvoiceID: 1, Amount: 10, Method: "BARTER"}, "invalid payment method"},
+		{"credit memo method", &Payment{InvoiceID: 1, Amount: 10, Method: MethodCreditMemo}, "issuing a credit memo"},
+	}
+
+	for _, tc := range testCases {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			_, err := suite.service.RecordPayment(suite.ctx, suite.tenantID, suite.userID, tc.payment)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectError)
+		})
+	}
+
+	suite.repo.AssertNotCalled(suite.T(), "RecordPayment")
+}
+
+func (suite *InvoiceServiceTestSuite) TestVoidInvoice_RequiresReason() {
+	_, err := suite.service.VoidInvoice(suite.ctx, suite.tenantID, suite.userID, 500, "")
+
+	assert.Error(suite.T(), err)
+	suite.repo.AssertNotCalled(suite.T(), "VoidInvoice")
+}
+
+func (suite *InvoiceServiceTestSuite) TestVoidInvoice_ReturnsWorkOrderToCompleted() {
+	inv := &Invoice{ID: 500, WorkOrderID: intPtr(42), InvoiceNumber: "INV-000500", Status: StatusVoid}
+	suite.repo.On("VoidInvoice", suite.ctx, suite.tenantID, 500, suite.userID, "Wrong customer").Return(inv, nil)
+	suite.publisher.On("Publish", suite.ctx, mock.MatchedBy(func(e *workorder.WorkOrderStatusChangedEvent) bool {
+		return e.NewStatus == string(workorder.StatusCompleted)
+	})).Return(nil)
+	suite.publisher.On("Publish", suite.ctx, mock.AnythingOfType("*invoice.InvoiceVoidedEvent")).Return(nil)
+
+	result, err := suite.service.VoidInvoice(suite.ctx, suite.tenantID, suite.userID, 500, "Wrong customer")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), StatusVoid, result.Status)
+	suite.publisher.AssertExpectations(suite.T())
+}
+
+func (suite *InvoiceServiceTestSuite) TestIssueCreditMemo() {
+	original := &Invoice{
+		ID: 500, WorkOrderID: intPtr(42), Total: 1000, AmountPaid: 250, BalanceDue: 750, Status: StatusPartiallyPaid,
+		Payments: []Payment{{ID: 9, Amount: 250, Method: MethodCreditMemo}},
+	}
+	suite.repo.On("CreateCreditMemo", suite.ctx, suite.tenantID, mock.MatchedBy(func(memo *Invoice) bool {
+		return memo.InvoiceType == TypeCreditMemo &&
+			*memo.OriginalInvoiceID == 500 &&
+			memo.Total == -250 &&
+			memo.Status == StatusApplied &&
+			len(memo.Lines) == 1 && memo.Lines[0].Amount == -250
+	})).Return(original, nil)
+	suite.publisher.On("Publish", suite.ctx, mock.AnythingOfType("*invoice.PaymentRecordedEvent")).Return(nil)
+
+	memo, err := suite.service.IssueCreditMemo(suite.ctx, suite.tenantID, suite.userID, 500, 250, "Two joints rejected")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), TypeCreditMemo, memo.InvoiceType)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func TestTermDays(t *testing.T) {
+	testCases := []struct {
+		terms       string
+		expectDays  int
+		expectKnown bool
+	}{
+		{"NET30", 30, true},
+		{"Net 45", 45, true},
+		{"net-60", 60, true},
+		{"NET_15", 15, true},
+		{"Due on receipt", 0, true},
+		{"COD", 0, true},
+		{"", 30, false},
+		{"2/10 NET 30", 30, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.terms, func(t *testing.T) {
+			days, known := TermDays(tc.terms)
+			assert.Equal(t, tc.expectDays, days)
+			assert.Equal(t, tc.expectKnown, known)
+		})
+	}
+}
+
+func TestBuildLines(t *testing.T) {
+	t.Run("labor without rate", func(t *testing.T) {
+		hours := 3.0
+		_, err := BuildLines(&workorder.WorkOrder{ActualHours: &hours})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no hourly rate")
+	})
+
+	t.Run("nothing billable", func(t *testing.T) {
+		_, err := BuildLines(&workorder.WorkOrder{Items: []workorder.WorkOrderItem{{ID: 1, Quantity: 2}}})
+		assert.True(t, errors.Is(err, ErrNothingToBill))
+	})
+
+	t.Run("lines are ordered", func(t *testing.T) {
+		materials := 99.999
+		price := 10.0
+		lines, err := BuildLines(&workorder.WorkOrder{
+			MaterialsCost: &materials,
+			Items:         []workorder.WorkOrderItem{{ID: 1, Quantity: 3, UnitPrice: &price}},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, lines, 2)
+		assert.Equal(t, LineItem, lines[0].LineType)
+		assert.Equal(t, 1, lines[0].SortOrder)
+		assert.Equal(t, 100.0, lines[1].Amount)
+	})
+}
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,12 @@
+// backend/internal/label/errors.go
+package label
+
+import "errors"
+
+// Label errors
+var (
+	ErrUnknownKind = errors.New("unknown label kind")
+	ErrNotFound    = errors.New("labelled entry not found")
+	ErrInvalidCode = errors.New("not a label code")
+	ErrOtherTenant = errors.New("label belongs to another yard")
+)
@@ -0,0 +1,118 @@
+// backend/internal/label/handlers.go
+package label
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"oilgas-backend/internal/auth"
+)
+
+// pathKinds maps the path segment of GET /labels/:kind/:id to a kind
+var pathKinds = map[string]Kind{
+	"items":     KindItem,
+	"locations": KindLocation,
+	"shipments": KindShipment,
+}
+
+type Handlers struct {
+	service Service
+}
+
+func NewHandlers(service Service) *Handlers {
+	return &Handlers{service: service}
+}
+
+func (h *Handlers) RegisterRoutes(router *gin.RouterGroup, authMiddleware *auth.Middleware) {
+	labels := router.Group("/labels")
+	labels.Use(authMiddleware.RequireAuth())
+	labels.Use(authMiddleware.RequireRole(auth.RoleOperator, auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin))
+
+	labels.POST("", h.RenderLabels)
+	labels.GET("/scan", h.Scan)
+	labels.GET("/:kind/:id", h.GetLabel)
+}
+
+// RenderLabels returns a label file for a LabelRequest as a download
+func (h *Handlers) RenderLabels(c *gin.Context) {
+	var req LabelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.render(c, &req)
+}
+
+// GetLabel returns one entry's label, so a page can link or embed it; the
+// symbology, format and layout come from the query string
+func (h *Handlers) GetLabel(c *gin.Context) {
+	kind, ok := pathKinds[c.Param("kind")]
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": ErrUnknownKind.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	h.render(c, &LabelRequest{
+		Kind:      kind,
+		IDs:       []int{id},
+		Symbology: Symbology(strings.ToUpper(c.Query("symbology"))),
+		Format:    Format(strings.ToUpper(c.Query("format"))),
+		Layout:    Layout(strings.ToUpper(c.Query("layout"))),
+	})
+}
+
+func (h *Handlers) render(c *gin.Context, req *LabelRequest) {
+	rendered, err := h.service.Render(c.Request.Context(), c.GetString("tenant_id"), req)
+	if err != nil {
+		c.JSON(labelErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	disposition := "attachment"
+	if rendered.ContentType == "image/png" {
+		disposition = "inline"
+	}
+	c.Header("Content-Disposition", disposition+`; filename="`+rendered.FileName+`"`)
+	c.Data(http.StatusOK, rendered.ContentType, rendered.Content)
+}
+
+// Scan resolves ?code= from a scanned label
+func (h *Handlers) Scan(c *gin.Context) {
+	code := c.Query("code")
+	if code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "code is required"})
+		return
+	}
+
+	result, err := h.service.Scan(c.Request.Context(), c.GetString("tenant_id"), code)
+	if err != nil {
+		c.JSON(labelErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func labelErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrOtherTenant):
+		return http.StatusForbidden
+	case errors.Is(err, ErrInvalidCode):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,193 @@
+// backend/internal/label/models.go
+package label
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"oilgas-backend/internal/inventory"
+	"oilgas-backend/internal/reference"
+)
+
+// Kind names what a label is stuck on
+type Kind string
+
+const (
+	KindItem     Kind = "ITEM"     // An inventory row, titled by its R-number
+	KindLocation Kind = "LOCATION" // A rack or yard location from the reference data
+	KindShipment Kind = "SHIPMENT" // The paperwork or bundle tag for a shipment
+)
+
+// kindCodes are the single letters a kind takes in an identifier, which
+// keeps Code128 labels short enough for the small sheet
+var kindCodes = map[Kind]string{
+	KindItem:     "I",
+	KindLocation: "L",
+	KindShipment: "S",
+}
+
+func (k Kind) IsValid() bool {
+	_, ok := kindCodes[k]
+	return ok
+}
+
+// Identifier is what a label encodes. It carries the tenant so a label
+// scanned at the wrong yard is refused rather than resolved to whatever has
+// the same ID there.
+type Identifier struct {
+	TenantID string
+	Kind     Kind
+	ID       int
+}
+
+// String is the encoded form, e.g. "longbeach:I:1042"
+func (id Identifier) String() string {
+	return id.TenantID + ":" + kindCodes[id.Kind] + ":" + strconv.Itoa(id.ID)
+}
+
+// ParseIdentifier reads a scanned code. Scanners may add a trailing return
+// or spaces, which are ignored.
+func ParseIdentifier(code string) (Identifier, error) {
+	parts := strings.Split(strings.TrimSpace(code), ":")
+	if len(parts) < 3 {
+		return Identifier{}, ErrInvalidCode
+	}
+	n := len(parts)
+
+	var id Identifier
+	id.TenantID = strings.Join(parts[:n-2], ":")
+	for kind, letter := range kindCodes {
+		if parts[n-2] == letter {
+			id.Kind = kind
+		}
+	}
+
+	var err error
+	id.ID, err = strconv.Atoi(parts[n-1])
+	if id.TenantID == "" || id.Kind == "" || err != nil || id.ID <= 0 {
+		return Identifier{}, ErrInvalidCode
+	}
+	return id, nil
+}
+
+// Symbology is the barcode printed on a label
+type Symbology string
+
+const (
+	SymbologyCode128 Symbology = "CODE128" // For the handheld scanners in the yard
+	SymbologyQR      Symbology = "QR"      // For phones
+)
+
+// Format is what Render produces
+type Format string
+
+const (
+	FormatPDF Format = "PDF" // Sheets for office printers, laid out by Layout
+	FormatPNG Format = "PNG" // The bare symbol for one label, to place in other documents
+	FormatZPL Format = "ZPL" // 4x2 inch labels for Zebra thermal printers
+)
+
+// Layout is a label sheet for PDF output
+type Layout string
+
+const (
+	LayoutAvery5160 Layout = "AVERY_5160" // 30 per sheet, 2-5/8 x 1 inch
+	LayoutAvery5163 Layout = "AVERY_5163" // 10 per sheet, 4 x 2 inch
+)
+
+// LabelRequest asks for labels for entries of one kind. Symbology, format and
+// layout default to a QR on AVERY_5163 sheets.
+type LabelRequest struct {
+	Kind      Kind      `json:"kind"`
+	IDs       []int     `json:"ids"`
+	Symbology Symbology `json:"symbology"`
+	Format    Format    `json:"format"`
+	Layout    Layout    `json:"layout"`
+	Copies    int       `json:"copies"` // Of each label; defaults to 1
+	Skip      int       `json:"skip"`   // Positions already used on a partly printed first sheet
+}
+
+// Label is the content of one label: the code and the text printed beside it
+type Label struct {
+	Identifier Identifier
+	Title      string
+	Lines      []string
+}
+
+// Rendered is a finished label file
+type Rendered struct {
+	Content     []byte
+	ContentType string
+	FileName    string
+}
+
+// ScanResult is what a scanned label is stuck on; one of Item, Location or
+// Shipment is set, according to Kind
+type ScanResult struct {
+	Identifier string              `json:"identifier"`
+	Kind       Kind                `json:"kind"`
+	Item       *inventory.Item     `json:"item,omitempty"`
+	Location   *reference.Location `json:"location,omitempty"`
+	Shipment   *inventory.Shipment `json:"shipment,omitempty"`
+}
+
+func (r *LabelRequest) applyDefaults() {
+	if r.Symbology == "" {
+		r.Symbology = SymbologyQR
+	}
+	if r.Format == "" {
+		r.Format = FormatPDF
+	}
+	if r.Layout == "" {
+		r.Layout = LayoutAvery5163
+	}
+	if r.Copies == 0 {
+		r.Copies = 1
+	}
+}
+
+func (r *LabelRequest) validate() error {
+	if !r.Kind.IsValid() {
+		return fmt.Errorf("%w: %q", ErrUnknownKind, r.Kind)
+	}
+	if len(r.IDs) == 0 {
+		return fmt.Errorf("at least one ID is required")
+	}
+	for _, id := range r.IDs {
+		if id <= 0 {
+			return fmt.Errorf("invalid ID: %d", id)
+		}
+	}
+	if r.Copies < 1 || r.Copies > maxCopies {
+		return fmt.Errorf("copies must be between 1 and %d", maxCopies)
+	}
+	if len(r.IDs)*r.Copies > maxLabels {
+		return fmt.Errorf("too many labels: %d (max %d)", len(r.IDs)*r.Copies, maxLabels)
+	}
+
+	switch r.Symbology {
+	case SymbologyCode128, SymbologyQR:
+	default:
+		return fmt.Errorf("unknown symbology: %q", r.Symbology)
+	}
+
+	switch r.Format {
+	case FormatPDF:
+		sheet, ok := sheets[r.Layout]
+		if !ok {
+			return fmt.Errorf("unknown layout: %q", r.Layout)
+		}
+		if r.Skip < 0 || r.Skip >= sheet.columns*sheet.rows {
+			return fmt.Errorf("skip must be between 0 and %d", sheet.columns*sheet.rows-1)
+		}
+	case FormatPNG:
+		if len(r.IDs)*r.Copies > 1 {
+			return fmt.Errorf("PNG renders a single label; use PDF or ZPL for several")
+		}
+	case FormatZPL:
+	default:
+		return fmt.Errorf("unknown format: %q", r.Format)
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+// backend/internal/label/render.go
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"math"
+	"strings"
+
+	"oilgas-backend/internal/shared/barcode"
+	"oilgas-backend/internal/shared/pdf"
+)
+
+// sheet is a label stock: the grid of labels and where it sits on a US
+// Letter page, in points
+type sheet struct {
+	columns, rows  int
+	width, height  float64
+	top, left      float64
+	pitchX, pitchY float64
+}
+
+var sheets = map[Layout]sheet{
+	LayoutAvery5160: {columns: 3, rows: 10, width: 189, height: 72, top: 36, left: 13.5, pitchX: 198, pitchY: 72},
+	LayoutAvery5163: {columns: 2, rows: 5, width: 288, height: 144, top: 36, left: 11.25, pitchX: 301.5, pitchY: 144},
+}
+
+// labelPadding keeps print off the die-cut edge, in points
+const labelPadding = 6.0
+
+// renderPDF fills sheets left to right and top to bottom, starting skip
+// positions into the first
+func renderPDF(labels []Label, copies int, symbology Symbology, sh sheet, skip int) ([]byte, error) {
+	doc := pdf.NewDocument("Labels")
+	perSheet := sh.columns * sh.rows
+
+	n := 0
+	for _, l := range labels {
+		for i := 0; i < copies; i++ {
+			pos := (n + skip) % perSheet
+			if n == 0 || pos == 0 {
+				doc.AddPage()
+			}
+			x := sh.left + float64(pos%sh.columns)*sh.pitchX
+			y := sh.top + float64(pos/sh.columns)*sh.pitchY
+			if err := drawLabel(doc, l, symbology, x, y, sh.width, sh.height); err != nil {
+				return nil, err
+			}
+			n++
+		}
+	}
+	return doc.Bytes(), nil
+}
+
+// drawLabel puts a QR on the left with the text beside it, or the text above
+// a Code128 that spans the label with the code spelled out beneath
+func drawLabel(doc *pdf.Document, l Label, symbology Symbology, x, y, w, h float64) error {
+	code := l.Identifier.String()
+	titleSize := math.Min(h/6, 16)
+	lineSize := titleSize * 0.7
+
+	if symbology == SymbologyQR {
+		m, err := barcode.QR(code)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", code, err)
+		}
+		side := h - 2*labelPadding
+		drawMatrix(doc, m, x+labelPadding, y+labelPadding, side)
+
+		textX := x + side + 2*labelPadding
+		drawText(doc, l, textX, y+labelPadding, x+w-labelPadding-textX, y+h-labelPadding, titleSize, lineSize)
+		return nil
+	}
+
+	bars, err := barcode.Code128(code)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", code, err)
+	}
+	codeSize := lineSize * 0.8
+	barHeight := h * 0.35
+	barTop := y + h - labelPadding - codeSize - 2 - barHeight
+
+	drawText(doc, l, x+labelPadding, y+labelPadding, w-2*labelPadding, barTop-2, titleSize, lineSize)
+	drawBars(doc, bars, x+labelPadding, barTop, w-2*labelPadding, barHeight)
+	doc.Text(x+(w-pdf.TextWidth(code, codeSize))/2, y+h-labelPadding, pdf.Helvetica, codeSize, code)
+	return nil
+}
+
+// drawText writes the title and as many lines as fit above bottom
+func drawText(doc *pdf.Document, l Label, x, top, width, bottom, titleSize, lineSize float64) {
+	y := top + titleSize
+	doc.Text(x, y, pdf.HelveticaBold, titleSize, fit(l.Title, titleSize, width))
+	for _, line := range l.Lines {
+		y += lineSize + 2
+		if y > bottom {
+			break
+		}
+		doc.Text(x, y, pdf.Helvetica, lineSize, fit(line, lineSize, width))
+	}
+}
+
+// drawMatrix fills each row's runs of dark modules, quiet zone included in
+// side
+func drawMatrix(doc *pdf.Document, m barcode.Matrix, x, y, side float64) {
+	module := side / float64(len(m)+2*barcode.QRQuietZone)
+	x += barcode.QRQuietZone * module
+	y += barcode.QRQuietZone * module
+	for r, row := range m {
+		for c := 0; c < len(row); {
+			if !row[c] {
+				c++
+				continue
+			}
+			start := c
+			for c < len(row) && row[c] {
+				c++
+			}
+			doc.Rect(x+float64(start)*module, y+float64(r)*module, float64(c-start)*module, module, true)
+		}
+	}
+}
+
+// drawBars fills each bar, quiet zone included in width
+func drawBars(doc *pdf.Document, bars barcode.Bars, x, y, width, height float64) {
+	module := width / float64(len(bars)+2*barcode.Code128QuietZone)
+	x += barcode.Code128QuietZone * module
+	for i := 0; i < len(bars); {
+		if !bars[i] {
+			i++
+			continue
+		}
+		start := i
+		for i < len(bars) && bars[i] {
+			i++
+		}
+		doc.Rect(x+float64(start)*module, y, float64(i-start)*module, height, true)
+	}
+}
+
+// fit cuts text to the width available, by pdf.TextWidth's estimate
+func fit(text string, size, width float64) string {
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.TextWidth(string(runes), size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes)
+}
+
+// renderPNG draws the bare symbol. There is no text: the standard library
+// has no fonts, and a PNG is meant to be placed in another document.
+func renderPNG(l Label, symbology Symbology) ([]byte, error) {
+	code := l.Identifier.String()
+
+	var buf bytes.Buffer
+	if symbology == SymbologyQR {
+		m, err := barcode.QR(code)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", code, err)
+		}
+		err = png.Encode(&buf, m.Image(8))
+		if err != nil {
+			return nil, fmt.Errorf("failed to write PNG: %w", err)
+		}
+		return buf.Bytes(), nil
+	}
+
+	bars, err := barcode.Code128(code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", code, err)
+	}
+	if err := png.Encode(&buf, bars.Image(3, 120)); err != nil {
+		return nil, fmt.Errorf("failed to write PNG: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ZPL label size and type, in dots at 203 dpi
+const (
+	zplWidth    = 812 // 4 inches
+	zplHeight   = 406 // 2 inches
+	zplMargin   = 30
+	zplTitle    = 48
+	zplLine     = 30
+	zplBarcode  = 100
+	zplCharSize = 0.55 // Average width of font 0 against its height
+)
+
+var zplEscaper = strings.NewReplacer("_", "_5F", "^", "_5E", "~", "_7E")
+
+// renderZPL writes one format per label, printed copies times. The printer
+// does the barcode encoding; QR is sized here so the symbol fits the label.
+func renderZPL(labels []Label, copies int, symbology Symbology) ([]byte, error) {
+	var b strings.Builder
+	for _, l := range labels {
+		code := l.Identifier.String()
+
+		b.WriteString("^XA\n^CI28\n")
+		fmt.Fprintf(&b, "^PW%d\n^LL%d\n", zplWidth, zplHeight)
+
+		textWidth := zplWidth - 2*zplMargin
+		bottom := zplHeight - zplMargin
+		if symbology == SymbologyQR {
+			m, err := barcode.QR(code)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode %s: %w", code, err)
+			}
+			magnification := (zplHeight - 2*zplMargin) / len(m)
+			if magnification > 10 {
+				magnification = 10
+			}
+			qrX := zplWidth - zplMargin - magnification*len(m)
+			textWidth = qrX - 2*zplMargin
+			fmt.Fprintf(&b, "^FO%d,%d^BQN,2,%d^FH^FDMA,%s^FS\n", qrX, zplMargin, magnification, zplEscaper.Replace(code))
+		} else {
+			bottom = zplHeight - zplMargin - zplBarcode - zplLine
+			fmt.Fprintf(&b, "^FO%d,%d^BY2^BCN,%d,Y,N,N,A^FH^FD%s^FS\n", zplMargin, bottom+10, zplBarcode, zplEscaper.Replace(code))
+		}
+
+		fmt.Fprintf(&b, "^FO%d,%d^A0N,%d,%d^FH^FD%s^FS\n", zplMargin, zplMargin, zplTitle, zplTitle, zplText(l.Title, zplTitle, textWidth))
+		y := zplMargin + zplTitle + 12
+		for _, line := range l.Lines {
+			if y+zplLine > bottom {
+				break
+			}
+			fmt.Fprintf(&b, "^FO%d,%d^A0N,%d,%d^FH^FD%s^FS\n", zplMargin, y, zplLine, zplLine, zplText(line, zplLine, textWidth))
+			y += zplLine + 8
+		}
+
+		fmt.Fprintf(&b, "^PQ%d\n^XZ\n", copies)
+	}
+	return []byte(b.String()), nil
+}
+
+// zplText cuts text to the width available and escapes ZPL's command
+// characters
+func zplText(text string, size, width int) string {
+	runes := []rune(text)
+	if max := int(float64(width) / (float64(size) * zplCharSize)); len(runes) > max {
+		runes = runes[:max]
+	}
+	return zplEscaper.Replace(string(runes))
+}
@@ -0,0 +1,239 @@
+// backend/internal/label/service.go
+package label
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"oilgas-backend/internal/inventory"
+	"oilgas-backend/internal/reference"
+)
+
+const (
+	maxCopies = 50
+	maxLabels = 300 // Ten full AVERY_5160 sheets
+)
+
+type Service interface {
+	// Render looks up each entry and lays its label out in the requested
+	// symbology and format
+	Render(ctx context.Context, tenantID string, req *LabelRequest) (*Rendered, error)
+
+	// Scan resolves a scanned code back to what the label is stuck on
+	Scan(ctx context.Context, tenantID, code string) (*ScanResult, error)
+}
+
+// ItemSource looks up inventory rows; inventory.Service satisfies it
+type ItemSource interface {
+	GetItem(ctx context.Context, tenantID string, id int) (*inventory.Item, error)
+}
+
+// LocationSource looks up racks and yard locations; reference.Service
+// satisfies it
+type LocationSource interface {
+	GetLocation(ctx context.Context, tenantID string, id int) (*reference.Location, error)
+}
+
+// ShipmentSource looks up shipments; inventory.ShipmentService satisfies it
+type ShipmentSource interface {
+	GetShipment(ctx context.Context, tenantID string, id int) (*inventory.Shipment, error)
+}
+
+type service struct {
+	items     ItemSource
+	locations LocationSource
+	shipments ShipmentSource
+}
+
+func NewService(items ItemSource, locations LocationSource, shipments ShipmentSource) Service {
+	return &service{
+		items:     items,
+		locations: locations,
+		shipments: shipments,
+	}
+}
+
+func (s *service) Render(ctx context.Context, tenantID string, req *LabelRequest) (*Rendered, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if req == nil {
+		return nil, fmt.Errorf("validation failed: label request is required")
+	}
+	req.applyDefaults()
+	if err := req.validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	labels := make([]Label, 0, len(req.IDs))
+	for _, id := range req.IDs {
+		l, err := s.label(ctx, Identifier{TenantID: tenantID, Kind: req.Kind, ID: id})
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, *l)
+	}
+
+	name := "labels-" + strings.ToLower(string(req.Kind))
+	switch req.Format {
+	case FormatPNG:
+		content, err := renderPNG(labels[0], req.Symbology)
+		if err != nil {
+			return nil, err
+		}
+		return &Rendered{
+			Content:     content,
+			ContentType: "image/png",
+			FileName:    fmt.Sprintf("label-%s-%d.png", strings.ToLower(string(req.Kind)), req.IDs[0]),
+		}, nil
+	case FormatZPL:
+		content, err := renderZPL(labels, req.Copies, req.Symbology)
+		if err != nil {
+			return nil, err
+		}
+		return &Rendered{Content: content, ContentType: "text/plain; charset=utf-8", FileName: name + ".zpl"}, nil
+	default:
+		content, err := renderPDF(labels, req.Copies, req.Symbology, sheets[req.Layout], req.Skip)
+		if err != nil {
+			return nil, err
+		}
+		return &Rendered{Content: content, ContentType: "application/pdf", FileName: name + ".pdf"}, nil
+	}
+}
+
+func (s *service) Scan(ctx context.Context, tenantID, code string) (*ScanResult, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	id, err := ParseIdentifier(code)
+	if err != nil {
+		return nil, err
+	}
+	if id.TenantID != tenantID {
+		return nil, ErrOtherTenant
+	}
+
+	result := &ScanResult{Identifier: id.String(), Kind: id.Kind}
+	switch id.Kind {
+	case KindItem:
+		result.Item, err = s.items.GetItem(ctx, tenantID, id.ID)
+	case KindLocation:
+		result.Location, err = s.locations.GetLocation(ctx, tenantID, id.ID)
+	case KindShipment:
+		result.Shipment, err = s.shipments.GetShipment(ctx, tenantID, id.ID)
+	}
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return result, nil
+}
+
+// label looks an entry up and writes the text printed beside its code
+func (s *service) label(ctx context.Context, id Identifier) (*Label, error) {
+	l := &Label{Identifier: id}
+
+	switch id.Kind {
+	case KindItem:
+		item, err := s.items.GetItem(ctx, id.TenantID, id.ID)
+		if err != nil {
+			return nil, notFound(err)
+		}
+		l.Title = "Item " + strconv.Itoa(item.ID)
+		if item.RNumber != nil && *item.RNumber != "" {
+			l.Title = *item.RNumber
+		}
+		l.Lines = nonEmpty(
+			deref(item.Customer),
+			join(item.Size, item.Grade, item.Connection),
+			fmt.Sprintf("%d joints", item.Joints),
+			place(item.Rack, item.Location),
+		)
+
+	case KindLocation:
+		location, err := s.locations.GetLocation(ctx, id.TenantID, id.ID)
+		if err != nil {
+			return nil, notFound(err)
+		}
+		l.Title = location.Location
+		l.Lines = nonEmpty(deref(location.Description))
+		if location.Capacity != nil {
+			l.Lines = append(l.Lines, fmt.Sprintf("Capacity %d joints", *location.Capacity))
+		}
+
+	case KindShipment:
+		shipment, err := s.shipments.GetShipment(ctx, id.TenantID, id.ID)
+		if err != nil {
+			return nil, notFound(err)
+		}
+		l.Title = shipment.ShipmentNumber
+		l.Lines = nonEmpty(
+			deref(shipment.Customer),
+			fmt.Sprintf("%d joints", shipment.TotalJoints),
+			strings.TrimSpace(shipment.ShippedAt.Format("01/02/2006")+" "+deref(shipment.Carrier)),
+		)
+	}
+	return l, nil
+}
+
+// notFound folds the sources' not-found errors into ErrNotFound so handlers
+// need only one
+func notFound(err error) error {
+	if errors.Is(err, inventory.ErrItemNotFound) || errors.Is(err, inventory.ErrShipmentNotFound) ||
+		errors.Is(err, reference.ErrNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return strings.TrimSpace(*s)
+}
+
+func join(values ...*string) string {
+	var parts []string
+	for _, v := range values {
+		if d := deref(v); d != "" {
+			parts = append(parts, d)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func place(rack, location *string) string {
+	switch {
+	case deref(rack) != "" && deref(location) != "":
+		return "Rack " + deref(rack) + " / " + deref(location)
+	case deref(rack) != "":
+		return "Rack " + deref(rack)
+	default:
+		return deref(location)
+	}
+}
+
+func nonEmpty(values ...string) []string {
+	lines := []string{}
+	for _, v := range values {
+		if v != "" {
+			lines = append(lines, v)
+		}
+	}
+	return lines
+}
+
+func validateTenantID(tenantID string) error {
+	if tenantID == "" {
+		return fmt.Errorf("tenant ID is required")
+	}
+	if len(tenantID) > 100 {
+		return fmt.Errorf("tenant ID too long: %d characters", len(tenantID))
+	}
+	return nil
+}
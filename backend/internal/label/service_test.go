@@ -0,0 +1,321 @@
+// backend/internal/label/service_test.go
+package label
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"oilgas-backend/internal/inventory"
+	"oilgas-backend/internal/reference"
+)
+
+type mockItems struct {
+	mock.Mock
+}
+
+func (m *mockItems) GetItem(ctx context.Context, tenantID string, id int) (*inventory.Item, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.Item), args.Error(1)
+}
+
+type mockLocations struct {
+	mock.Mock
+}
+
+func (m *mockLocations) GetLocation(ctx context.Context, tenantID string, id int) (*reference.Location, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*reference.Location), args.Error(1)
+}
+
+type mockShipments struct {
+	mock.Mock
+}
+
+func (m *mockShipments) GetShipment(ctx context.Context, tenantID string, id int) (*inventory.Shipment, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.Shipment), args.Error(1)
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+type LabelServiceTestSuite struct {
+	suite.Suite
+	service   Service
+	items     *mockItems
+	locations *mockLocations
+	shipments *mockShipments
+	ctx       context.Context
+	tenantID  string
+}
+
+func (suite *LabelServiceTestSuite) SetupTest() {
+	suite.items = &mockItems{}
+	suite.locations = &mockLocations{}
+	suite.shipments = &mockShipments{}
+	suite.service = NewService(suite.items, suite.locations, suite.shipments)
+	suite.ctx = context.Background()
+	suite.tenantID = "longbeach"
+}
+
+func TestLabelServiceSuite(t *testing.T) {
+	suite.Run(t, new(LabelServiceTestSuite))
+}
+
+func (suite *LabelServiceTestSuite) item(id int, rNumber string) *inventory.Item {
+	item := &inventory.Item{
+		ID:         id,
+		TenantID:   suite.tenantID,
+		Customer:   strPtr("Chevron"),
+		RNumber:    strPtr(rNumber),
+		Joints:     120,
+		Size:       strPtr(`5 1/2"`),
+		Grade:      strPtr("P110"),
+		Connection: strPtr("BTC"),
+		Rack:       strPtr("A-12"),
+	}
+	suite.items.On("GetItem", suite.ctx, suite.tenantID, id).Return(item, nil).Maybe()
+	return item
+}
+
+func TestIdentifier(t *testing.T) {
+	id := Identifier{TenantID: "longbeach", Kind: KindItem, ID: 1042}
+	assert.Equal(t, "longbeach:I:1042", id.String())
+
+	parsed, err := ParseIdentifier(" longbeach:I:1042\r\n")
+	require.NoError(t, err)
+	assert.Equal(t, id, parsed)
+
+	// Only the last two parts are fixed, so a tenant may contain a colon
+	parsed, err = ParseIdentifier("gulf:coast:L:7")
+	require.NoError(t, err)
+	assert.Equal(t, Identifier{TenantID: "gulf:coast", Kind: KindLocation, ID: 7}, parsed)
+
+	for _, code := range []string{"", "R-1042", "longbeach:I", ":I:1", "longbeach:X:1", "longbeach:I:0", "longbeach:I:R1"} {
+		_, err := ParseIdentifier(code)
+		assert.ErrorIs(t, err, ErrInvalidCode, code)
+	}
+}
+
+func (suite *LabelServiceTestSuite) TestRender_PDFDefaultsToQROnLargeLabels() {
+	suite.item(1042, "R-1042")
+	suite.item(1043, "R-1043")
+
+	req := &LabelRequest{Kind: KindItem, IDs: []int{1042, 1043}}
+	rendered, err := suite.service.Render(suite.ctx, suite.tenantID, req)
+
+	suite.Require().NoError(err)
+	suite.Equal("application/pdf", rendered.ContentType)
+	suite.Equal("labels-item.pdf", rendered.FileName)
+	suite.Equal(SymbologyQR, req.Symbology)
+	suite.Equal(LayoutAvery5163, req.Layout)
+
+	content := string(rendered.Content)
+	suite.True(strings.HasPrefix(content, "%PDF-"))
+	suite.Contains(content, "(R-1042)")
+	suite.Contains(content, "(R-1043)")
+	suite.Contains(content, `(5 1/2" P110 BTC)`)
+	suite.Contains(content, "(Rack A-12)")
+	suite.Contains(content, " re f\n")
+}
+
+func (suite *LabelServiceTestSuite) TestRender_PDFFillsSheetsAfterSkip() {
+	suite.item(1042, "R-1042")
+
+	// 28 used positions plus 3 copies runs onto a second 5160 sheet
+	rendered, err := suite.service.Render(suite.ctx, suite.tenantID, &LabelRequest{
+		Kind:      KindItem,
+		IDs:       []int{1042},
+		Symbology: SymbologyCode128,
+		Layout:    LayoutAvery5160,
+		Copies:    3,
+		Skip:      28,
+	})
+
+	suite.Require().NoError(err)
+	content := string(rendered.Content)
+	suite.Equal(3, strings.Count(content, "(R-1042)"))
+	suite.Equal(3, strings.Count(content, "(longbeach:I:1042)"))
+	suite.Contains(content, "/Count 2")
+}
+
+func (suite *LabelServiceTestSuite) TestRender_PNGIsTheBareSymbol() {
+	suite.item(1042, "R-1042")
+
+	rendered, err := suite.service.Render(suite.ctx, suite.tenantID, &LabelRequest{
+		Kind:   KindItem,
+		IDs:    []int{1042},
+		Format: FormatPNG,
+	})
+
+	suite.Require().NoError(err)
+	suite.Equal("image/png", rendered.ContentType)
+	suite.Equal("label-item-1042.png", rendered.FileName)
+
+	img, err := png.Decode(bytes.NewReader(rendered.Content))
+	suite.Require().NoError(err)
+	// "longbeach:I:1042" is two bytes past version 1: 25 modules plus the
+	// quiet zone, 8 pixels each
+	suite.Equal((25+8)*8, img.Bounds().Dx())
+	suite.Equal(img.Bounds().Dx(), img.Bounds().Dy())
+}
+
+func (suite *LabelServiceTestSuite) TestRender_ZPL() {
+	suite.locations.On("GetLocation", suite.ctx, suite.tenantID, 7).Return(&reference.Location{
+		ID:          7,
+		TenantID:    suite.tenantID,
+		Location:    "Rack_A^12",
+		Description: strPtr("North fence"),
+		Capacity:    intPtr(400),
+	}, nil)
+
+	rendered, err := suite.service.Render(suite.ctx, suite.tenantID, &LabelRequest{
+		Kind:      KindLocation,
+		IDs:       []int{7},
+		Symbology: SymbologyCode128,
+		Format:    FormatZPL,
+		Copies:    2,
+	})
+
+	suite.Require().NoError(err)
+	suite.Equal("labels-location.zpl", rendered.FileName)
+
+	zpl := string(rendered.Content)
+	suite.True(strings.HasPrefix(zpl, "^XA\n"))
+	suite.True(strings.HasSuffix(zpl, "^PQ2\n^XZ\n"))
+	suite.Contains(zpl, "^BCN,100,Y,N,N,A^FH^FDlongbeach:L:7^FS")
+	suite.Contains(zpl, "^FDRack_5FA_5E12^FS")
+	suite.Contains(zpl, "^FDNorth fence^FS")
+	suite.Contains(zpl, "^FDCapacity 400 joints^FS")
+}
+
+func (suite *LabelServiceTestSuite) TestRender_ZPLSizesQRToTheLabel() {
+	suite.shipments.On("GetShipment", suite.ctx, suite.tenantID, 120).Return(&inventory.Shipment{
+		ID:             120,
+		ShipmentNumber: "BOL-2026-0120",
+		Customer:       strPtr("Chevron"),
+		Carrier:        strPtr("Hot Shot Trucking"),
+		TotalJoints:    240,
+		ShippedAt:      time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC),
+	}, nil)
+
+	rendered, err := suite.service.Render(suite.ctx, suite.tenantID, &LabelRequest{
+		Kind:   KindShipment,
+		IDs:    []int{120},
+		Format: FormatZPL,
+	})
+
+	suite.Require().NoError(err)
+	zpl := string(rendered.Content)
+	// 25 modules at 10 dots, right-aligned inside the margin
+	suite.Contains(zpl, fmt.Sprintf("^FO%d,30^BQN,2,10^FH^FDMA,longbeach:S:120^FS", 812-30-250))
+	suite.Contains(zpl, "^FDBOL-2026-0120^FS")
+	suite.Contains(zpl, "^FD10/14/2026 Hot Shot Trucking^FS")
+	suite.Contains(zpl, "^PQ1\n")
+}
+
+func (suite *LabelServiceTestSuite) TestRender_NotFound() {
+	suite.items.On("GetItem", suite.ctx, suite.tenantID, 99).Return(nil, inventory.ErrItemNotFound)
+
+	_, err := suite.service.Render(suite.ctx, suite.tenantID, &LabelRequest{Kind: KindItem, IDs: []int{99}})
+
+	suite.ErrorIs(err, ErrNotFound)
+}
+
+func (suite *LabelServiceTestSuite) TestRender_Validation() {
+	testCases := []struct {
+		name string
+		req  *LabelRequest
+	}{
+		{"no request", nil},
+		{"unknown kind", &LabelRequest{Kind: "RACK", IDs: []int{1}}},
+		{"no IDs", &LabelRequest{Kind: KindItem}},
+		{"bad ID", &LabelRequest{Kind: KindItem, IDs: []int{0}}},
+		{"too many copies", &LabelRequest{Kind: KindItem, IDs: []int{1}, Copies: maxCopies + 1}},
+		{"too many labels", &LabelRequest{Kind: KindItem, IDs: make([]int, 11), Copies: 30}},
+		{"unknown symbology", &LabelRequest{Kind: KindItem, IDs: []int{1}, Symbology: "DATAMATRIX"}},
+		{"unknown format", &LabelRequest{Kind: KindItem, IDs: []int{1}, Format: "SVG"}},
+		{"unknown layout", &LabelRequest{Kind: KindItem, IDs: []int{1}, Layout: "AVERY_5167"}},
+		{"skip past the sheet", &LabelRequest{Kind: KindItem, IDs: []int{1}, Skip: 10}},
+		{"several PNGs", &LabelRequest{Kind: KindItem, IDs: []int{1, 2}, Format: FormatPNG}},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			_, err := suite.service.Render(suite.ctx, suite.tenantID, tc.req)
+			suite.Require().Error(err)
+			suite.Contains(err.Error(), "validation failed")
+		})
+	}
+	suite.items.AssertNotCalled(suite.T(), "GetItem", mock.Anything, mock.Anything, mock.Anything)
+
+	_, err := suite.service.Render(suite.ctx, "", &LabelRequest{Kind: KindItem, IDs: []int{1}})
+	suite.Contains(err.Error(), "invalid tenant")
+}
+
+func (suite *LabelServiceTestSuite) TestScan() {
+	item := suite.item(1042, "R-1042")
+
+	result, err := suite.service.Scan(suite.ctx, suite.tenantID, "longbeach:I:1042\n")
+
+	suite.Require().NoError(err)
+	suite.Equal("longbeach:I:1042", result.Identifier)
+	suite.Equal(KindItem, result.Kind)
+	suite.Equal(item, result.Item)
+	suite.Nil(result.Location)
+	suite.Nil(result.Shipment)
+}
+
+func (suite *LabelServiceTestSuite) TestScan_Location() {
+	location := &reference.Location{ID: 7, TenantID: suite.tenantID, Location: "A-12"}
+	suite.locations.On("GetLocation", suite.ctx, suite.tenantID, 7).Return(location, nil)
+
+	result, err := suite.service.Scan(suite.ctx, suite.tenantID, "longbeach:L:7")
+
+	suite.Require().NoError(err)
+	suite.Equal(location, result.Location)
+}
+
+func (suite *LabelServiceTestSuite) TestScan_Refuses() {
+	suite.locations.On("GetLocation", suite.ctx, suite.tenantID, 8).Return(nil, reference.ErrNotFound)
+	suite.shipments.On("GetShipment", suite.ctx, suite.tenantID, 9).Return(nil, errors.New("connection refused"))
+
+	_, err := suite.service.Scan(suite.ctx, suite.tenantID, "houston:I:1042")
+	suite.ErrorIs(err, ErrOtherTenant)
+
+	_, err = suite.service.Scan(suite.ctx, suite.tenantID, "R-1042")
+	suite.ErrorIs(err, ErrInvalidCode)
+
+	_, err = suite.service.Scan(suite.ctx, suite.tenantID, "longbeach:L:8")
+	suite.ErrorIs(err, ErrNotFound)
+
+	_, err = suite.service.Scan(suite.ctx, suite.tenantID, "longbeach:S:9")
+	suite.EqualError(err, "connection refused")
+
+	suite.items.AssertNotCalled(suite.T(), "GetItem", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -31,13 +31,18 @@ type InventoryItem struct {
 	ImportedAt   *time.Time `json:"imported_at,omitempty" db:"imported_at"`
 	Deleted      bool       `json:"deleted" db:"deleted"`
 	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+
+	// Available-to-promise, from store.inventory_availability; nil when the
+	// query did not compute them
+	ReservedJoints  *int `json:"reserved_joints,omitempty" db:"reserved_joints"`
+	AvailableJoints *int `json:"available_joints,omitempty" db:"available_joints"`
 }
 
 // InventorySummary provides aggregated inventory metrics
 type InventorySummary struct {
 	TotalItems       int                `json:"total_items"`
 	TotalJoints      int                `json:"total_joints"`
-	TotalWeight      float64            `json:"total_weight"`
+	TotalWeight      float64            `json:"total_weight"` // Pounds, from the inventory weight summary
 	AvailableItems   int                `json:"available_items"`
 	UniqueCustomers  int                `json:"unique_customers"`
 	UniqueWorkOrders int                `json:"unique_work_orders"`
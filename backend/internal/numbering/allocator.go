@@ -0,0 +1,62 @@
+// backend/internal/numbering/allocator.go
+package numbering
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Allocator hands out document numbers. Numbers are always taken inside the
+// caller's transaction: the sequence row stays locked until that transaction
+// ends, so concurrent creates queue behind each other instead of colliding,
+// and a rollback returns the number to the series. That makes every series
+// gap-free, which invoices require.
+type Allocator interface {
+	Next(ctx context.Context, tx *sql.Tx, tenantID string, docType DocumentType) (string, error)
+}
+
+type allocator struct {
+	formats FormatSource
+	now     func() time.Time
+}
+
+func NewAllocator(formats FormatSource) Allocator {
+	return &allocator{formats: formats, now: time.Now}
+}
+
+func (a *allocator) Next(ctx context.Context, tx *sql.Tx, tenantID string, docType DocumentType) (string, error) {
+	if !IsValidDocumentType(docType) {
+		return "", fmt.Errorf("%w: %s", ErrUnknownDocumentType, docType)
+	}
+
+	format, err := a.formats.GetFormat(ctx, tenantID, docType)
+	if err != nil {
+		return "", err
+	}
+
+	at := a.now()
+	seq, err := nextValueTx(ctx, tx, tenantID, docType, format.PeriodKey(at))
+	if err != nil {
+		return "", err
+	}
+
+	return format.Render(tenantID, seq, at), nil
+}
+
+func nextValueTx(ctx context.Context, tx *sql.Tx, tenantID string, docType DocumentType, period string) (int64, error) {
+	var value int64
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO store.document_sequences (tenant_id, document_type, period, last_value)
+		VALUES ($1, $2, $3, 1)
+		ON CONFLICT (tenant_id, document_type, period)
+		DO UPDATE SET last_value = store.document_sequences.last_value + 1, updated_at = NOW()
+		RETURNING last_value`,
+		tenantID, docType, period,
+	).Scan(&value)
+	if err != nil {
+		return 0, fmt.Errorf("failed to allocate %s number: %w", docType, err)
+	}
+	return value, nil
+}
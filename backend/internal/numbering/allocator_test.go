@@ -0,0 +1,141 @@
+// backend/internal/numbering/allocator_test.go
+package numbering
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+)
+
+type staticFormats struct {
+	formats map[DocumentType]string
+	err     error
+}
+
+func (s *staticFormats) GetFormat(ctx context.Context, tenantID string, docType DocumentType) (*Format, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return ParseFormat(s.formats[docType])
+}
+
+type AllocatorTestSuite struct {
+	suite.Suite
+	db        *sql.DB
+	mock      sqlmock.Sqlmock
+	formats   *staticFormats
+	allocator *allocator
+	ctx       context.Context
+}
+
+func (suite *AllocatorTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New()
+	suite.Require().NoError(err)
+
+	suite.db = db
+	suite.mock = mock
+	suite.formats = &staticFormats{formats: map[DocumentType]string{
+		DocumentWorkOrder: "LB-{YYYY}-{seq:05}",
+		DocumentInvoice:   "INV-{seq:06}",
+	}}
+	suite.allocator = &allocator{
+		formats: suite.formats,
+		now:     func() time.Time { return time.Date(2025, time.January, 2, 8, 0, 0, 0, time.UTC) },
+	}
+	suite.ctx = context.Background()
+}
+
+func (suite *AllocatorTestSuite) TearDownTest() {
+	suite.NoError(suite.mock.ExpectationsWereMet())
+	suite.db.Close()
+}
+
+func TestAllocatorSuite(t *testing.T) {
+	suite.Run(t, new(AllocatorTestSuite))
+}
+
+func (suite *AllocatorTestSuite) beginTx() *sql.Tx {
+	suite.mock.ExpectBegin()
+	tx, err := suite.db.Begin()
+	suite.Require().NoError(err)
+	return tx
+}
+
+func (suite *AllocatorTestSuite) TestNext_YearlySeries() {
+	tx := suite.beginTx()
+	suite.mock.ExpectQuery("INSERT INTO store.document_sequences").
+		WithArgs("longbeach", DocumentWorkOrder, "2025").
+		WillReturnRows(sqlmock.NewRows([]string{"last_value"}).AddRow(17))
+	suite.mock.ExpectRollback()
+
+	number, err := suite.allocator.Next(suite.ctx, tx, "longbeach", DocumentWorkOrder)
+
+	suite.NoError(err)
+	suite.Equal("LB-2025-00017", number)
+	suite.NoError(tx.Rollback())
+}
+
+func (suite *AllocatorTestSuite) TestNext_UndatedSeriesUsesSingleCounter() {
+	tx := suite.beginTx()
+	suite.mock.ExpectQuery("INSERT INTO store.document_sequences").
+		WithArgs("longbeach", DocumentInvoice, "").
+		WillReturnRows(sqlmock.NewRows([]string{"last_value"}).AddRow(1))
+	suite.mock.ExpectCommit()
+
+	number, err := suite.allocator.Next(suite.ctx, tx, "longbeach", DocumentInvoice)
+
+	suite.NoError(err)
+	suite.Equal("INV-000001", number)
+	suite.NoError(tx.Commit())
+}
+
+func (suite *AllocatorTestSuite) TestNext_UnknownDocumentType() {
+	tx := suite.beginTx()
+	suite.mock.ExpectRollback()
+
+	_, err := suite.allocator.Next(suite.ctx, tx, "longbeach", "PACKING_SLIP")
+
+	suite.True(errors.Is(err, ErrUnknownDocumentType))
+	suite.NoError(tx.Rollback())
+}
+
+func (suite *AllocatorTestSuite) TestNext_FormatLookupFails() {
+	suite.formats.err = errors.New("central database unavailable")
+	tx := suite.beginTx()
+	suite.mock.ExpectRollback()
+
+	_, err := suite.allocator.Next(suite.ctx, tx, "longbeach", DocumentWorkOrder)
+
+	suite.Error(err)
+	suite.NoError(tx.Rollback())
+}
+
+func (suite *AllocatorTestSuite) TestNext_SequenceError() {
+	tx := suite.beginTx()
+	suite.mock.ExpectQuery("INSERT INTO store.document_sequences").
+		WillReturnError(errors.New("lock timeout"))
+	suite.mock.ExpectRollback()
+
+	_, err := suite.allocator.Next(suite.ctx, tx, "longbeach", DocumentInvoice)
+
+	suite.Error(err)
+	suite.Contains(err.Error(), "failed to allocate INVOICE number")
+	suite.NoError(tx.Rollback())
+}
+
+func (suite *AllocatorTestSuite) TestTenantSettingsSource() {
+	suite.mock.ExpectQuery("SELECT settings FROM auth.tenants").
+		WithArgs("longbeach").
+		WillReturnRows(sqlmock.NewRows([]string{"settings"}).
+			AddRow([]byte(`{"document_numbering": {"bill_of_lading": "LB-BOL-{YY}{seq:04}"}}`)))
+
+	format, err := NewTenantSettingsSource(suite.db).GetFormat(suite.ctx, "longbeach", DocumentBillOfLading)
+
+	suite.NoError(err)
+	suite.Equal("LB-BOL-{YY}{seq:04}", format.String())
+}
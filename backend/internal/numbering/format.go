@@ -0,0 +1,208 @@
+// backend/internal/numbering/format.go
+package numbering
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Period controls when a sequence restarts at 1. It is derived from the date
+// tokens present in a format so that a number can never repeat within the
+// rendered text: a yearly format gets a counter per year, a monthly one per
+// month, and a format without date tokens uses a single running counter.
+type Period string
+
+const (
+	PeriodNone    Period = ""
+	PeriodYearly  Period = "YEARLY"
+	PeriodMonthly Period = "MONTHLY"
+)
+
+const maxSeqWidth = 12
+
+type tokenKind int
+
+const (
+	tokenLiteral tokenKind = iota
+	tokenSeq
+	tokenYear4
+	tokenYear2
+	tokenMonth
+	tokenTenant
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	width int
+}
+
+// Format is a parsed document number template. Supported placeholders:
+//
+//	{seq} {seq:05}   sequence value, optionally zero padded to a width
+//	{YYYY} {YY}      four or two digit year
+//	{MM}             two digit month (requires a year token)
+//	{TENANT}         upper-cased tenant ID
+//	{TENANT:3}       first n characters of the upper-cased tenant ID
+type Format struct {
+	raw    string
+	tokens []token
+	period Period
+}
+
+func ParseFormat(raw string) (*Format, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, fmt.Errorf("%w: format is empty", ErrInvalidFormat)
+	}
+	if len(raw) > 50 {
+		return nil, fmt.Errorf("%w: format too long: %d characters", ErrInvalidFormat, len(raw))
+	}
+
+	f := &Format{raw: raw}
+	var seqCount int
+	var hasYear, hasMonth bool
+
+	rest := raw
+	for rest != "" {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			if strings.ContainsRune(rest, '}') {
+				return nil, fmt.Errorf("%w: unmatched '}' in %q", ErrInvalidFormat, raw)
+			}
+			f.tokens = append(f.tokens, token{kind: tokenLiteral, text: rest})
+			break
+		}
+		if open > 0 {
+			if strings.ContainsRune(rest[:open], '}') {
+				return nil, fmt.Errorf("%w: unmatched '}' in %q", ErrInvalidFormat, raw)
+			}
+			f.tokens = append(f.tokens, token{kind: tokenLiteral, text: rest[:open]})
+		}
+
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unclosed '{' in %q", ErrInvalidFormat, raw)
+		}
+		placeholder := rest[open+1 : open+end]
+		rest = rest[open+end+1:]
+
+		tok, err := parsePlaceholder(placeholder)
+		if err != nil {
+			return nil, err
+		}
+
+		switch tok.kind {
+		case tokenSeq:
+			seqCount++
+		case tokenYear4, tokenYear2:
+			hasYear = true
+		case tokenMonth:
+			hasMonth = true
+		}
+		f.tokens = append(f.tokens, tok)
+	}
+
+	if seqCount != 1 {
+		return nil, fmt.Errorf("%w: format must contain exactly one {seq} placeholder", ErrInvalidFormat)
+	}
+	if hasMonth && !hasYear {
+		return nil, fmt.Errorf("%w: {MM} requires {YYYY} or {YY}", ErrInvalidFormat)
+	}
+
+	switch {
+	case hasMonth:
+		f.period = PeriodMonthly
+	case hasYear:
+		f.period = PeriodYearly
+	}
+
+	return f, nil
+}
+
+func parsePlaceholder(placeholder string) (token, error) {
+	name, arg, hasArg := strings.Cut(placeholder, ":")
+
+	switch name {
+	case "seq":
+		if !hasArg {
+			return token{kind: tokenSeq}, nil
+		}
+		width, err := strconv.Atoi(arg)
+		if err != nil || width < 1 || width > maxSeqWidth {
+			return token{}, fmt.Errorf("%w: invalid sequence width %q", ErrInvalidFormat, arg)
+		}
+		return token{kind: tokenSeq, width: width}, nil
+	case "TENANT":
+		if !hasArg {
+			return token{kind: tokenTenant}, nil
+		}
+		width, err := strconv.Atoi(arg)
+		if err != nil || width < 1 {
+			return token{}, fmt.Errorf("%w: invalid tenant prefix length %q", ErrInvalidFormat, arg)
+		}
+		return token{kind: tokenTenant, width: width}, nil
+	}
+
+	if hasArg {
+		return token{}, fmt.Errorf("%w: unknown placeholder {%s}", ErrInvalidFormat, placeholder)
+	}
+
+	switch name {
+	case "YYYY":
+		return token{kind: tokenYear4}, nil
+	case "YY":
+		return token{kind: tokenYear2}, nil
+	case "MM":
+		return token{kind: tokenMonth}, nil
+	default:
+		return token{}, fmt.Errorf("%w: unknown placeholder {%s}", ErrInvalidFormat, placeholder)
+	}
+}
+
+func (f *Format) String() string {
+	return f.raw
+}
+
+func (f *Format) Period() Period {
+	return f.period
+}
+
+// PeriodKey identifies the counter a number issued at the given time draws
+// from. Formats without date tokens share a single counter keyed by "".
+func (f *Format) PeriodKey(at time.Time) string {
+	switch f.period {
+	case PeriodMonthly:
+		return at.Format("2006-01")
+	case PeriodYearly:
+		return at.Format("2006")
+	default:
+		return ""
+	}
+}
+
+func (f *Format) Render(tenantID string, seq int64, at time.Time) string {
+	var b strings.Builder
+	for _, tok := range f.tokens {
+		switch tok.kind {
+		case tokenLiteral:
+			b.WriteString(tok.text)
+		case tokenSeq:
+			fmt.Fprintf(&b, "%0*d", tok.width, seq)
+		case tokenYear4:
+			b.WriteString(at.Format("2006"))
+		case tokenYear2:
+			b.WriteString(at.Format("06"))
+		case tokenMonth:
+			b.WriteString(at.Format("01"))
+		case tokenTenant:
+			prefix := strings.ToUpper(tenantID)
+			if tok.width > 0 && len(prefix) > tok.width {
+				prefix = prefix[:tok.width]
+			}
+			b.WriteString(prefix)
+		}
+	}
+	return b.String()
+}
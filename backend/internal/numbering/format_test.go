@@ -0,0 +1,99 @@
+// backend/internal/numbering/format_test.go
+package numbering
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormat_Render(t *testing.T) {
+	at := time.Date(2025, time.March, 9, 14, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		format       string
+		seq          int64
+		expectNumber string
+		expectPeriod string
+	}{
+		{"legacy work order default", "{TENANT:3}-{seq:06}", 42, "LON-000042", ""},
+		{"yearly prefix", "LB-{YYYY}-{seq:05}", 7, "LB-2025-00007", "2025"},
+		{"monthly", "WO{YY}{MM}-{seq:04}", 123, "WO2503-0123", "2025-03"},
+		{"unpadded", "INV{seq}", 9, "INV9", ""},
+		{"overflowing width", "BOL-{seq:03}", 12345, "BOL-12345", ""},
+		{"full tenant", "{TENANT}/{seq:03}", 5, "LONGBEACH/005", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := ParseFormat(tc.format)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectNumber, f.Render("longbeach", tc.seq, at))
+			assert.Equal(t, tc.expectPeriod, f.PeriodKey(at))
+		})
+	}
+}
+
+func TestParseFormat_Invalid(t *testing.T) {
+	testCases := []struct {
+		name   string
+		format string
+	}{
+		{"empty", ""},
+		{"no sequence", "LB-{YYYY}"},
+		{"two sequences", "{seq}-{seq}"},
+		{"month without year", "WO-{MM}-{seq}"},
+		{"unknown placeholder", "WO-{DD}-{seq}"},
+		{"bad width", "WO-{seq:abc}"},
+		{"zero width", "WO-{seq:0}"},
+		{"unclosed brace", "WO-{seq"},
+		{"stray closing brace", "WO}-{seq}"},
+		{"argument on year", "{YYYY:2}-{seq}"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseFormat(tc.format)
+			assert.True(t, errors.Is(err, ErrInvalidFormat), "expected ErrInvalidFormat, got %v", err)
+		})
+	}
+}
+
+func TestFormatFromSettings(t *testing.T) {
+	settings := map[string]interface{}{
+		"document_numbering": map[string]interface{}{
+			"work_order": "LB-{YYYY}-{seq:05}",
+			"invoice":    42,
+		},
+	}
+
+	f, err := FormatFromSettings(settings, DocumentWorkOrder)
+	require.NoError(t, err)
+	assert.Equal(t, "LB-{YYYY}-{seq:05}", f.String())
+	assert.Equal(t, PeriodYearly, f.Period())
+
+	f, err = FormatFromSettings(settings, DocumentBillOfLading)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultFormats[DocumentBillOfLading], f.String())
+
+	f, err = FormatFromSettings(nil, DocumentInvoice)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultFormats[DocumentInvoice], f.String())
+
+	_, err = FormatFromSettings(settings, DocumentInvoice)
+	assert.True(t, errors.Is(err, ErrInvalidFormat))
+
+	_, err = FormatFromSettings(settings, "PACKING_SLIP")
+	assert.True(t, errors.Is(err, ErrUnknownDocumentType))
+}
+
+func TestDefaultFormatsParse(t *testing.T) {
+	for docType, raw := range DefaultFormats {
+		_, err := ParseFormat(raw)
+		assert.NoError(t, err, "default format for %s", docType)
+	}
+}
@@ -0,0 +1,45 @@
+// backend/internal/numbering/numbering.go
+package numbering
+
+import "errors"
+
+// DocumentType identifies an independently numbered document series.
+type DocumentType string
+
+const (
+	DocumentWorkOrder    DocumentType = "WORK_ORDER"
+	DocumentInvoice      DocumentType = "INVOICE"
+	DocumentBillOfLading DocumentType = "BILL_OF_LADING"
+	DocumentTransfer     DocumentType = "TRANSFER"
+)
+
+// settingsKeys maps each document type to its key under the
+// "document_numbering" object in Tenant.Settings, e.g.
+//
+//	{"document_numbering": {"work_order": "LB-{YYYY}-{seq:05}"}}
+var settingsKeys = map[DocumentType]string{
+	DocumentWorkOrder:    "work_order",
+	DocumentInvoice:      "invoice",
+	DocumentBillOfLading: "bill_of_lading",
+	DocumentTransfer:     "transfer",
+}
+
+// DefaultFormats are used when a tenant has not configured a format. The work
+// order default reproduces the numbers generated by the old
+// generate_work_order_number function so existing series continue unbroken.
+var DefaultFormats = map[DocumentType]string{
+	DocumentWorkOrder:    "{TENANT:3}-{seq:06}",
+	DocumentInvoice:      "INV-{seq:06}",
+	DocumentBillOfLading: "BOL-{seq:06}",
+	DocumentTransfer:     "TR-{seq:06}",
+}
+
+var (
+	ErrUnknownDocumentType = errors.New("unknown document type")
+	ErrInvalidFormat       = errors.New("invalid document number format")
+)
+
+func IsValidDocumentType(docType DocumentType) bool {
+	_, ok := settingsKeys[docType]
+	return ok
+}
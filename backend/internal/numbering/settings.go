@@ -0,0 +1,69 @@
+// backend/internal/numbering/settings.go
+package numbering
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+const settingsKey = "document_numbering"
+
+// FormatSource resolves the configured format for a tenant's document series.
+type FormatSource interface {
+	GetFormat(ctx context.Context, tenantID string, docType DocumentType) (*Format, error)
+}
+
+type tenantSettings struct {
+	db *sql.DB
+}
+
+// NewTenantSettingsSource reads formats from the settings column of
+// auth.tenants in the central database, falling back to DefaultFormats.
+func NewTenantSettingsSource(centralDB *sql.DB) FormatSource {
+	return &tenantSettings{db: centralDB}
+}
+
+func (s *tenantSettings) GetFormat(ctx context.Context, tenantID string, docType DocumentType) (*Format, error) {
+	var raw []byte
+	err := s.db.QueryRowContext(ctx,
+		`SELECT settings FROM auth.tenants WHERE id = $1`, tenantID).Scan(&raw)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("tenant not found: %s", tenantID)
+		}
+		return nil, fmt.Errorf("failed to get tenant settings: %w", err)
+	}
+
+	var settings map[string]interface{}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &settings); err != nil {
+			return nil, fmt.Errorf("failed to decode tenant settings: %w", err)
+		}
+	}
+
+	return FormatFromSettings(settings, docType)
+}
+
+// FormatFromSettings picks the format for docType out of a Tenant.Settings
+// map. A configured but malformed format is an error rather than a silent
+// fallback, so a typo cannot quietly start a second numbering series.
+func FormatFromSettings(settings map[string]interface{}, docType DocumentType) (*Format, error) {
+	key, ok := settingsKeys[docType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDocumentType, docType)
+	}
+
+	if formats, ok := settings[settingsKey].(map[string]interface{}); ok {
+		if value, exists := formats[key]; exists {
+			raw, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s.%s must be a string", ErrInvalidFormat, settingsKey, key)
+			}
+			return ParseFormat(raw)
+		}
+	}
+
+	return ParseFormat(DefaultFormats[docType])
+}
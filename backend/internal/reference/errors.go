@@ -0,0 +1,37 @@
+// backend/internal/reference/errors.go
+package reference
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrNotFound     = errors.New("reference entry not found")
+	ErrDuplicate    = errors.New("a reference entry with that name already exists")
+	ErrUnknownKind  = errors.New("unknown reference data kind")
+	ErrUnknownValue = errors.New("not in the reference data")
+)
+
+// UnknownValuesError reports the values in a pipe description that have no
+// active reference entry, with suggestions for each
+type UnknownValuesError struct {
+	Problems []Problem
+}
+
+func (e *UnknownValuesError) Error() string {
+	parts := make([]string, 0, len(e.Problems))
+	for _, p := range e.Problems {
+		part := fmt.Sprintf("%s %q is %s", p.Field, p.Value, ErrUnknownValue)
+		if len(p.Suggestions) > 0 {
+			part += fmt.Sprintf(" (did you mean %s?)", strings.Join(p.Suggestions, ", "))
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func (e *UnknownValuesError) Unwrap() error {
+	return ErrUnknownValue
+}
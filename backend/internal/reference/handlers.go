@@ -0,0 +1,259 @@
+// backend/internal/reference/handlers.go
+package reference
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"oilgas-backend/internal/auth"
+)
+
+type Handlers struct {
+	service Service
+}
+
+func NewHandlers(service Service) *Handlers {
+	return &Handlers{service: service}
+}
+
+func (h *Handlers) RegisterRoutes(router *gin.RouterGroup, authMiddleware *auth.Middleware) {
+	admins := authMiddleware.RequireRole(auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin)
+
+	refs := router.Group("/reference")
+	refs.Use(authMiddleware.RequireAuth())
+
+	refs.GET("/grades", h.GetGrades)
+	refs.GET("/sizes", h.GetSizes)
+	refs.GET("/connections", h.GetConnections)
+	refs.GET("/locations", h.GetLocations)
+	refs.POST("/check", h.CheckPipe)
+
+	// Reference data is maintained by admins
+	refs.POST("/grades", admins, h.SaveGrade)
+	refs.PUT("/grades/:id", admins, h.SaveGrade)
+	refs.DELETE("/grades/:id", admins, h.deactivate(KindGrade))
+	refs.POST("/sizes", admins, h.SaveSize)
+	refs.PUT("/sizes/:id", admins, h.SaveSize)
+	refs.DELETE("/sizes/:id", admins, h.deactivate(KindSize))
+	refs.POST("/connections", admins, h.SaveConnection)
+	refs.PUT("/connections/:id", admins, h.SaveConnection)
+	refs.DELETE("/connections/:id", admins, h.deactivate(KindConnection))
+	refs.POST("/locations", admins, h.SaveLocation)
+	refs.PUT("/locations/:id", admins, h.SaveLocation)
+	refs.DELETE("/locations/:id", admins, h.deactivate(KindLocation))
+}
+
+// GetGrades lists active grades; ?include_inactive=true lists retired ones too
+func (h *Handlers) GetGrades(c *gin.Context) {
+	grades, err := h.service.GetGrades(c.Request.Context(), c.GetString("tenant_id"), c.Query("include_inactive") == "true")
+	if err != nil {
+		c.JSON(referenceErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  grades,
+		"total": len(grades),
+	})
+}
+
+func (h *Handlers) GetSizes(c *gin.Context) {
+	sizes, err := h.service.GetSizes(c.Request.Context(), c.GetString("tenant_id"), c.Query("include_inactive") == "true")
+	if err != nil {
+		c.JSON(referenceErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  sizes,
+		"total": len(sizes),
+	})
+}
+
+func (h *Handlers) GetConnections(c *gin.Context) {
+	connections, err := h.service.GetConnections(c.Request.Context(), c.GetString("tenant_id"), c.Query("include_inactive") == "true")
+	if err != nil {
+		c.JSON(referenceErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  connections,
+		"total": len(connections),
+	})
+}
+
+func (h *Handlers) GetLocations(c *gin.Context) {
+	locations, err := h.service.GetLocations(c.Request.Context(), c.GetString("tenant_id"), c.Query("include_inactive") == "true")
+	if err != nil {
+		c.JSON(referenceErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  locations,
+		"total": len(locations),
+	})
+}
+
+// CheckPipe checks a grade, size and connection before they are entered
+// and suggests the closest reference values for any that are unknown
+func (h *Handlers) CheckPipe(c *gin.Context) {
+	var check PipeCheck
+	if err := c.ShouldBindJSON(&check); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.CheckPipe(c.Request.Context(), c.GetString("tenant_id"), &check)
+	if err != nil {
+		c.JSON(referenceErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// entryID reads the :id of an update, or zero for a create. A new entry
+// is active unless the body says otherwise.
+func entryID(c *gin.Context) (int, bool) {
+	raw := c.Param("id")
+	if raw == "" {
+		return 0, true
+	}
+
+	id, err := strconv.Atoi(raw)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reference entry ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+// saved answers a create with 201 and an update with 200
+func saved(c *gin.Context, id int, entry interface{}) {
+	if id == 0 {
+		c.JSON(http.StatusCreated, entry)
+		return
+	}
+	c.JSON(http.StatusOK, entry)
+}
+
+func (h *Handlers) SaveGrade(c *gin.Context) {
+	id, ok := entryID(c)
+	if !ok {
+		return
+	}
+
+	grade := Grade{Active: true}
+	if err := c.ShouldBindJSON(&grade); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	grade.ID = id
+
+	if err := h.service.SaveGrade(c.Request.Context(), c.GetString("tenant_id"), &grade); err != nil {
+		c.JSON(referenceErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	saved(c, id, grade)
+}
+
+func (h *Handlers) SaveSize(c *gin.Context) {
+	id, ok := entryID(c)
+	if !ok {
+		return
+	}
+
+	size := Size{Active: true}
+	if err := c.ShouldBindJSON(&size); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	size.ID = id
+
+	if err := h.service.SaveSize(c.Request.Context(), c.GetString("tenant_id"), &size); err != nil {
+		c.JSON(referenceErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	saved(c, id, size)
+}
+
+func (h *Handlers) SaveConnection(c *gin.Context) {
+	id, ok := entryID(c)
+	if !ok {
+		return
+	}
+
+	connection := Connection{Active: true}
+	if err := c.ShouldBindJSON(&connection); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	connection.ID = id
+
+	if err := h.service.SaveConnection(c.Request.Context(), c.GetString("tenant_id"), &connection); err != nil {
+		c.JSON(referenceErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	saved(c, id, connection)
+}
+
+func (h *Handlers) SaveLocation(c *gin.Context) {
+	id, ok := entryID(c)
+	if !ok {
+		return
+	}
+
+	location := Location{Active: true}
+	if err := c.ShouldBindJSON(&location); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	location.ID = id
+
+	if err := h.service.SaveLocation(c.Request.Context(), c.GetString("tenant_id"), &location); err != nil {
+		c.JSON(referenceErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	saved(c, id, location)
+}
+
+// deactivate retires an entry of one kind; a PUT with "active": true
+// brings it back
+func (h *Handlers) deactivate(kind Kind) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reference entry ID"})
+			return
+		}
+
+		if err := h.service.Deactivate(c.Request.Context(), c.GetString("tenant_id"), kind, id); err != nil {
+			c.JSON(referenceErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Reference entry deactivated"})
+	}
+}
+
+func referenceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrDuplicate):
+		return http.StatusConflict
+	case errors.Is(err, ErrUnknownValue):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusBadRequest
+	}
+}
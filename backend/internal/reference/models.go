@@ -0,0 +1,80 @@
+// backend/internal/reference/models.go
+package reference
+
+import "time"
+
+// Kind names one list of reference data
+type Kind string
+
+const (
+	KindGrade      Kind = "grades"
+	KindSize       Kind = "sizes"
+	KindConnection Kind = "connections"
+	KindLocation   Kind = "locations"
+)
+
+// Grade is a steel grade, e.g. L80
+type Grade struct {
+	ID          int       `json:"id" db:"id"`
+	Grade       string    `json:"grade" db:"grade"`
+	Description *string   `json:"description" db:"description"`
+	Strength    *int      `json:"strength" db:"strength"` // Minimum yield, ksi
+	Standard    *string   `json:"standard" db:"standard"`
+	Active      bool      `json:"active" db:"active"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// Size is one size and weight of pipe, e.g. 5 1/2" 17#. Inventory usually
+// records the nominal size, which several entries can share.
+type Size struct {
+	ID            int       `json:"id" db:"id"`
+	Size          string    `json:"size" db:"size"`
+	NominalSize   *string   `json:"nominal_size" db:"nominal_size"`
+	OuterDiameter *float64  `json:"outer_diameter" db:"outer_diameter"` // Inches
+	InnerDiameter *float64  `json:"inner_diameter" db:"inner_diameter"` // Inches
+	WeightPerFoot *float64  `json:"weight_per_foot" db:"weight_per_foot"`
+	Active        bool      `json:"active" db:"active"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// Connection is a thread or coupling type, e.g. BTC
+type Connection struct {
+	ID          int       `json:"id" db:"id"`
+	Connection  string    `json:"connection" db:"connection"`
+	Description *string   `json:"description" db:"description"`
+	Category    *string   `json:"category" db:"category"` // API or PREMIUM
+	Active      bool      `json:"active" db:"active"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// Location is a storage area in one tenant's yard
+type Location struct {
+	ID          int       `json:"id" db:"id"`
+	TenantID    string    `json:"tenant_id" db:"tenant_id"`
+	Location    string    `json:"location" db:"location"`
+	Description *string   `json:"description" db:"description"`
+	Capacity    *int      `json:"capacity" db:"capacity"` // Joints
+	Active      bool      `json:"active" db:"active"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// PipeCheck is a pipe description to check against the reference data;
+// empty fields are not checked
+type PipeCheck struct {
+	Grade      string `json:"grade"`
+	Size       string `json:"size"`
+	Connection string `json:"connection"`
+}
+
+// Problem is a value with no active reference entry, and the entries
+// closest to it
+type Problem struct {
+	Field       string   `json:"field"`
+	Value       string   `json:"value"`
+	Suggestions []string `json:"suggestions"`
+}
+
+type PipeCheckResult struct {
+	Valid    bool      `json:"valid"`
+	Problems []Problem `json:"problems"`
+}
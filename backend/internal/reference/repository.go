@@ -0,0 +1,301 @@
+// backend/internal/reference/repository.go
+package reference
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"oilgas-backend/internal/shared/database"
+)
+
+type Repository interface {
+	GetGrades(ctx context.Context, tenantID string, includeInactive bool) ([]Grade, error)
+	GetSizes(ctx context.Context, tenantID string, includeInactive bool) ([]Size, error)
+	GetConnections(ctx context.Context, tenantID string, includeInactive bool) ([]Connection, error)
+	GetLocations(ctx context.Context, tenantID string, includeInactive bool) ([]Location, error)
+	GetLocation(ctx context.Context, tenantID string, id int) (*Location, error)
+
+	// The Save methods insert an entry with no ID and update one with an ID
+	SaveGrade(ctx context.Context, tenantID string, g *Grade) error
+	SaveSize(ctx context.Context, tenantID string, s *Size) error
+	SaveConnection(ctx context.Context, tenantID string, c *Connection) error
+	SaveLocation(ctx context.Context, tenantID string, l *Location) error
+
+	SetActive(ctx context.Context, tenantID string, kind Kind, id int, active bool) error
+}
+
+type repository struct {
+	dbManager *database.DatabaseManager
+}
+
+func NewRepository(dbManager *database.DatabaseManager) Repository {
+	return &repository{dbManager: dbManager}
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+const gradeColumns = `id, grade, description, strength, standard, active, created_at`
+
+const sizeColumns = `id, size, nominal_size, outer_diameter, inner_diameter, weight_per_foot, active, created_at`
+
+const connectionColumns = `id, connection, description, category, active, created_at`
+
+const locationColumns = `id, tenant_id, location, description, capacity, active, created_at`
+
+func scanGrade(row rowScanner, g *Grade) error {
+	return row.Scan(&g.ID, &g.Grade, &g.Description, &g.Strength, &g.Standard, &g.Active, &g.CreatedAt)
+}
+
+func scanSize(row rowScanner, s *Size) error {
+	return row.Scan(&s.ID, &s.Size, &s.NominalSize, &s.OuterDiameter, &s.InnerDiameter, &s.WeightPerFoot, &s.Active, &s.CreatedAt)
+}
+
+func scanConnection(row rowScanner, c *Connection) error {
+	return row.Scan(&c.ID, &c.Connection, &c.Description, &c.Category, &c.Active, &c.CreatedAt)
+}
+
+func scanLocation(row rowScanner, l *Location) error {
+	return row.Scan(&l.ID, &l.TenantID, &l.Location, &l.Description, &l.Capacity, &l.Active, &l.CreatedAt)
+}
+
+var tables = map[Kind]string{
+	KindGrade:      "store.grades",
+	KindSize:       "store.sizes",
+	KindConnection: "store.connections",
+	KindLocation:   "store.locations",
+}
+
+// query runs a list query and hands each row to scan
+func (r *repository) query(ctx context.Context, tenantID, query string, scan func(rowScanner) error, args ...interface{}) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query reference data: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return fmt.Errorf("failed to scan reference data: %w", err)
+		}
+	}
+	return rows.Err()
+}
+
+func (r *repository) GetGrades(ctx context.Context, tenantID string, includeInactive bool) ([]Grade, error) {
+	grades := []Grade{}
+	err := r.query(ctx, tenantID, `
+		SELECT `+gradeColumns+`
+		FROM store.grades
+		WHERE active = true OR $1
+		ORDER BY grade`, func(row rowScanner) error {
+		var g Grade
+		if err := scanGrade(row, &g); err != nil {
+			return err
+		}
+		grades = append(grades, g)
+		return nil
+	}, includeInactive)
+	return grades, err
+}
+
+func (r *repository) GetSizes(ctx context.Context, tenantID string, includeInactive bool) ([]Size, error) {
+	sizes := []Size{}
+	err := r.query(ctx, tenantID, `
+		SELECT `+sizeColumns+`
+		FROM store.sizes
+		WHERE active = true OR $1
+		ORDER BY outer_diameter NULLS LAST, weight_per_foot NULLS LAST, size`, func(row rowScanner) error {
+		var s Size
+		if err := scanSize(row, &s); err != nil {
+			return err
+		}
+		sizes = append(sizes, s)
+		return nil
+	}, includeInactive)
+	return sizes, err
+}
+
+func (r *repository) GetConnections(ctx context.Context, tenantID string, includeInactive bool) ([]Connection, error) {
+	connections := []Connection{}
+	err := r.query(ctx, tenantID, `
+		SELECT `+connectionColumns+`
+		FROM store.connections
+		WHERE active = true OR $1
+		ORDER BY connection`, func(row rowScanner) error {
+		var c Connection
+		if err := scanConnection(row, &c); err != nil {
+			return err
+		}
+		connections = append(connections, c)
+		return nil
+	}, includeInactive)
+	return connections, err
+}
+
+func (r *repository) GetLocations(ctx context.Context, tenantID string, includeInactive bool) ([]Location, error) {
+	locations := []Location{}
+	err := r.query(ctx, tenantID, `
+		SELECT `+locationColumns+`
+		FROM store.locations
+		WHERE tenant_id = $1 AND (active = true OR $2)
+		ORDER BY location`, func(row rowScanner) error {
+		var l Location
+		if err := scanLocation(row, &l); err != nil {
+			return err
+		}
+		locations = append(locations, l)
+		return nil
+	}, tenantID, includeInactive)
+	return locations, err
+}
+
+func (r *repository) GetLocation(ctx context.Context, tenantID string, id int) (*Location, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	var l Location
+	err = scanLocation(db.QueryRowContext(ctx, `
+		SELECT `+locationColumns+`
+		FROM store.locations
+		WHERE id = $1 AND tenant_id = $2`, id, tenantID), &l)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location: %w", err)
+	}
+	return &l, nil
+}
+
+// save runs an insert or update that returns the entry, translating a
+// missing row and a duplicate name
+func (r *repository) save(ctx context.Context, tenantID, query string, scan func(rowScanner) error, args ...interface{}) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	if err := scan(db.QueryRowContext(ctx, query, args...)); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return ErrDuplicate
+		}
+		return fmt.Errorf("failed to save reference entry: %w", err)
+	}
+	return nil
+}
+
+func (r *repository) SaveGrade(ctx context.Context, tenantID string, g *Grade) error {
+	scan := func(row rowScanner) error { return scanGrade(row, g) }
+	if g.ID == 0 {
+		return r.save(ctx, tenantID, `
+			INSERT INTO store.grades (grade, description, strength, standard, active)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING `+gradeColumns,
+			scan, g.Grade, g.Description, g.Strength, g.Standard, g.Active)
+	}
+	return r.save(ctx, tenantID, `
+		UPDATE store.grades
+		SET grade = $2, description = $3, strength = $4, standard = $5, active = $6
+		WHERE id = $1
+		RETURNING `+gradeColumns,
+		scan, g.ID, g.Grade, g.Description, g.Strength, g.Standard, g.Active)
+}
+
+func (r *repository) SaveSize(ctx context.Context, tenantID string, s *Size) error {
+	scan := func(row rowScanner) error { return scanSize(row, s) }
+	if s.ID == 0 {
+		return r.save(ctx, tenantID, `
+			INSERT INTO store.sizes (size, nominal_size, outer_diameter, inner_diameter, weight_per_foot, active)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING `+sizeColumns,
+			scan, s.Size, s.NominalSize, s.OuterDiameter, s.InnerDiameter, s.WeightPerFoot, s.Active)
+	}
+	return r.save(ctx, tenantID, `
+		UPDATE store.sizes
+		SET size = $2, nominal_size = $3, outer_diameter = $4, inner_diameter = $5, weight_per_foot = $6, active = $7
+		WHERE id = $1
+		RETURNING `+sizeColumns,
+		scan, s.ID, s.Size, s.NominalSize, s.OuterDiameter, s.InnerDiameter, s.WeightPerFoot, s.Active)
+}
+
+func (r *repository) SaveConnection(ctx context.Context, tenantID string, c *Connection) error {
+	scan := func(row rowScanner) error { return scanConnection(row, c) }
+	if c.ID == 0 {
+		return r.save(ctx, tenantID, `
+			INSERT INTO store.connections (connection, description, category, active)
+			VALUES ($1, $2, $3, $4)
+			RETURNING `+connectionColumns,
+			scan, c.Connection, c.Description, c.Category, c.Active)
+	}
+	return r.save(ctx, tenantID, `
+		UPDATE store.connections
+		SET connection = $2, description = $3, category = $4, active = $5
+		WHERE id = $1
+		RETURNING `+connectionColumns,
+		scan, c.ID, c.Connection, c.Description, c.Category, c.Active)
+}
+
+func (r *repository) SaveLocation(ctx context.Context, tenantID string, l *Location) error {
+	scan := func(row rowScanner) error { return scanLocation(row, l) }
+	if l.ID == 0 {
+		return r.save(ctx, tenantID, `
+			INSERT INTO store.locations (tenant_id, location, description, capacity, active)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING `+locationColumns,
+			scan, tenantID, l.Location, l.Description, l.Capacity, l.Active)
+	}
+	return r.save(ctx, tenantID, `
+		UPDATE store.locations
+		SET location = $3, description = $4, capacity = $5, active = $6
+		WHERE id = $1 AND tenant_id = $2
+		RETURNING `+locationColumns,
+		scan, l.ID, tenantID, l.Location, l.Description, l.Capacity, l.Active)
+}
+
+func (r *repository) SetActive(ctx context.Context, tenantID string, kind Kind, id int, active bool) error {
+	table, ok := tables[kind]
+	if !ok {
+		return ErrUnknownKind
+	}
+
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	query := `UPDATE ` + table + ` SET active = $1 WHERE id = $2`
+	args := []interface{}{active, id}
+	if kind == KindLocation {
+		query += ` AND tenant_id = $3`
+		args = append(args, tenantID)
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update reference entry: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update reference entry: %w", err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
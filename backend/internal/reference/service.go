@@ -0,0 +1,427 @@
+// backend/internal/reference/service.go
+package reference
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"oilgas-backend/internal/shared/weight"
+)
+
+// maxSuggestions caps the near misses offered for one value
+const maxSuggestions = 3
+
+type Service interface {
+	GetGrades(ctx context.Context, tenantID string, includeInactive bool) ([]Grade, error)
+	GetSizes(ctx context.Context, tenantID string, includeInactive bool) ([]Size, error)
+	GetConnections(ctx context.Context, tenantID string, includeInactive bool) ([]Connection, error)
+	GetLocations(ctx context.Context, tenantID string, includeInactive bool) ([]Location, error)
+	GetLocation(ctx context.Context, tenantID string, id int) (*Location, error)
+
+	SaveGrade(ctx context.Context, tenantID string, g *Grade) error
+	SaveSize(ctx context.Context, tenantID string, s *Size) error
+	SaveConnection(ctx context.Context, tenantID string, c *Connection) error
+	SaveLocation(ctx context.Context, tenantID string, l *Location) error
+
+	// Deactivate retires an entry. Inventory already recorded with it keeps
+	// its value; new entries are checked against active values only.
+	Deactivate(ctx context.Context, tenantID string, kind Kind, id int) error
+
+	// CheckPipe reports each value in a pipe description that has no active
+	// reference entry, with the closest entries as suggestions
+	CheckPipe(ctx context.Context, tenantID string, check *PipeCheck) (*PipeCheckResult, error)
+
+	// ValidatePipe is CheckPipe for callers that only need an error; it
+	// returns an *UnknownValuesError when a value is not in the reference data
+	ValidatePipe(ctx context.Context, tenantID, grade, size, connection string) error
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func (s *service) GetGrades(ctx context.Context, tenantID string, includeInactive bool) ([]Grade, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.repo.GetGrades(ctx, tenantID, includeInactive)
+}
+
+func (s *service) GetSizes(ctx context.Context, tenantID string, includeInactive bool) ([]Size, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.repo.GetSizes(ctx, tenantID, includeInactive)
+}
+
+func (s *service) GetConnections(ctx context.Context, tenantID string, includeInactive bool) ([]Connection, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.repo.GetConnections(ctx, tenantID, includeInactive)
+}
+
+func (s *service) GetLocations(ctx context.Context, tenantID string, includeInactive bool) ([]Location, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.repo.GetLocations(ctx, tenantID, includeInactive)
+}
+
+func (s *service) GetLocation(ctx context.Context, tenantID string, id int) (*Location, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.repo.GetLocation(ctx, tenantID, id)
+}
+
+func (s *service) SaveGrade(ctx context.Context, tenantID string, g *Grade) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if err := validateGrade(g); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return s.repo.SaveGrade(ctx, tenantID, g)
+}
+
+func (s *service) SaveSize(ctx context.Context, tenantID string, sz *Size) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if err := validateSize(sz); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return s.repo.SaveSize(ctx, tenantID, sz)
+}
+
+func (s *service) SaveConnection(ctx context.Context, tenantID string, c *Connection) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if err := validateConnection(c); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return s.repo.SaveConnection(ctx, tenantID, c)
+}
+
+func (s *service) SaveLocation(ctx context.Context, tenantID string, l *Location) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if err := validateLocation(l); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return s.repo.SaveLocation(ctx, tenantID, l)
+}
+
+func (s *service) Deactivate(ctx context.Context, tenantID string, kind Kind, id int) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if _, ok := tables[kind]; !ok {
+		return ErrUnknownKind
+	}
+	if id <= 0 {
+		return fmt.Errorf("invalid reference entry ID: %d", id)
+	}
+
+	return s.repo.SetActive(ctx, tenantID, kind, id, false)
+}
+
+func (s *service) CheckPipe(ctx context.Context, tenantID string, check *PipeCheck) (*PipeCheckResult, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if check == nil {
+		return nil, fmt.Errorf("validation failed: a pipe description is required")
+	}
+
+	result := &PipeCheckResult{Valid: true, Problems: []Problem{}}
+
+	if grade := strings.TrimSpace(check.Grade); grade != "" {
+		grades, err := s.repo.GetGrades(ctx, tenantID, false)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(grades))
+		for _, g := range grades {
+			names = append(names, g.Grade)
+		}
+		if problem := checkValue("grade", grade, names, codeKey); problem != nil {
+			result.Problems = append(result.Problems, *problem)
+		}
+	}
+
+	if size := strings.TrimSpace(check.Size); size != "" {
+		sizes, err := s.repo.GetSizes(ctx, tenantID, false)
+		if err != nil {
+			return nil, err
+		}
+		// A row may carry the nominal size or the full size and weight
+		names := make([]string, 0, len(sizes)*2)
+		for _, sz := range sizes {
+			if sz.NominalSize != nil {
+				names = append(names, *sz.NominalSize)
+			}
+			names = append(names, sz.Size)
+		}
+		if problem := checkValue("size", size, names, weight.NormalizeSize); problem != nil {
+			result.Problems = append(result.Problems, *problem)
+		}
+	}
+
+	if connection := strings.TrimSpace(check.Connection); connection != "" {
+		connections, err := s.repo.GetConnections(ctx, tenantID, false)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(connections))
+		for _, c := range connections {
+			names = append(names, c.Connection)
+		}
+		if problem := checkValue("connection", connection, names, codeKey); problem != nil {
+			result.Problems = append(result.Problems, *problem)
+		}
+	}
+
+	result.Valid = len(result.Problems) == 0
+	return result, nil
+}
+
+func (s *service) ValidatePipe(ctx context.Context, tenantID, grade, size, connection string) error {
+	result, err := s.CheckPipe(ctx, tenantID, &PipeCheck{Grade: grade, Size: size, Connection: connection})
+	if err != nil {
+		return err
+	}
+	if !result.Valid {
+		return &UnknownValuesError{Problems: result.Problems}
+	}
+	return nil
+}
+
+// codeKey compares grades and connections case-insensitively
+func codeKey(value string) string {
+	return strings.ToUpper(strings.TrimSpace(value))
+}
+
+// looseKey also drops the separators people type inconsistently, so L-80
+// sits at distance zero from L80 when ranking suggestions
+func looseKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '_', '.', '/', '"':
+			return -1
+		}
+		return r
+	}, key)
+}
+
+// checkValue returns nil when value matches one of names under key, and
+// otherwise a problem suggesting the closest names
+func checkValue(field, value string, names []string, key func(string) string) *Problem {
+	want := key(value)
+	for _, name := range names {
+		if key(name) == want {
+			return nil
+		}
+	}
+
+	return &Problem{Field: field, Value: value, Suggestions: suggest(value, names, key)}
+}
+
+// suggest ranks names by edit distance from value, keeping those close
+// enough to be a likely typo
+func suggest(value string, names []string, key func(string) string) []string {
+	want := looseKey(key(value))
+	limit := 1
+	if len(want) > 4 {
+		limit = 2
+	}
+
+	type candidate struct {
+		name     string
+		distance int
+	}
+	var candidates []candidate
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if d := editDistance(want, looseKey(key(name))); d <= limit {
+			candidates = append(candidates, candidate{name, d})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	suggestions := []string{}
+	for i := 0; i < len(candidates) && i < maxSuggestions; i++ {
+		suggestions = append(suggestions, candidates[i].name)
+	}
+	return suggestions
+}
+
+// editDistance is the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func validateGrade(g *Grade) error {
+	if g == nil {
+		return fmt.Errorf("grade is required")
+	}
+
+	g.Grade = codeKey(g.Grade)
+	if g.Grade == "" {
+		return fmt.Errorf("grade is required")
+	}
+	if len(g.Grade) > 10 {
+		return fmt.Errorf("grade too long (max 10 characters)")
+	}
+	if g.Strength != nil && *g.Strength <= 0 {
+		return fmt.Errorf("strength must be positive")
+	}
+	if g.Standard != nil && len(*g.Standard) > 50 {
+		return fmt.Errorf("standard too long (max 50 characters)")
+	}
+
+	return nil
+}
+
+func validateSize(s *Size) error {
+	if s == nil {
+		return fmt.Errorf("size is required")
+	}
+
+	s.Size = strings.TrimSpace(s.Size)
+	if s.Size == "" {
+		return fmt.Errorf("size is required")
+	}
+	if len(s.Size) > 50 {
+		return fmt.Errorf("size too long (max 50 characters)")
+	}
+	if s.NominalSize != nil {
+		nominal := strings.TrimSpace(*s.NominalSize)
+		if len(nominal) > 20 {
+			return fmt.Errorf("nominal size too long (max 20 characters)")
+		}
+		s.NominalSize = nullableString(nominal)
+	}
+	if s.OuterDiameter != nil && *s.OuterDiameter <= 0 {
+		return fmt.Errorf("outer diameter must be positive")
+	}
+	if s.InnerDiameter != nil && *s.InnerDiameter <= 0 {
+		return fmt.Errorf("inner diameter must be positive")
+	}
+	if s.OuterDiameter != nil && s.InnerDiameter != nil && *s.InnerDiameter >= *s.OuterDiameter {
+		return fmt.Errorf("inner diameter must be smaller than the outer diameter")
+	}
+	if s.WeightPerFoot != nil && *s.WeightPerFoot <= 0 {
+		return fmt.Errorf("weight per foot must be positive")
+	}
+
+	return nil
+}
+
+func validateConnection(c *Connection) error {
+	if c == nil {
+		return fmt.Errorf("connection is required")
+	}
+
+	c.Connection = codeKey(c.Connection)
+	if c.Connection == "" {
+		return fmt.Errorf("connection is required")
+	}
+	if len(c.Connection) > 50 {
+		return fmt.Errorf("connection too long (max 50 characters)")
+	}
+	if c.Category != nil {
+		category := strings.ToUpper(strings.TrimSpace(*c.Category))
+		if category != "" && category != "API" && category != "PREMIUM" {
+			return fmt.Errorf("category must be API or PREMIUM")
+		}
+		c.Category = nullableString(category)
+	}
+
+	return nil
+}
+
+func validateLocation(l *Location) error {
+	if l == nil {
+		return fmt.Errorf("location is required")
+	}
+
+	l.Location = strings.TrimSpace(l.Location)
+	if l.Location == "" {
+		return fmt.Errorf("location is required")
+	}
+	if len(l.Location) > 100 {
+		return fmt.Errorf("location too long (max 100 characters)")
+	}
+	if l.Capacity != nil && *l.Capacity < 0 {
+		return fmt.Errorf("capacity cannot be negative")
+	}
+
+	return nil
+}
+
+func validateTenantID(tenantID string) error {
+	if tenantID == "" {
+		return fmt.Errorf("tenant ID is required")
+	}
+	if len(tenantID) > 100 {
+		return fmt.Errorf("tenant ID too long: %d characters", len(tenantID))
+	}
+	return nil
+}
+
+func nullableString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
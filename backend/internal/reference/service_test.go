@@ -0,0 +1,202 @@
+// backend/internal/reference/service_test.go
+package reference
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type mockRepository struct {
+	mock.Mock
+}
+
+func (m *mockRepository) GetGrades(ctx context.Context, tenantID string, includeInactive bool) ([]Grade, error) {
+	args := m.Called(ctx, tenantID, includeInactive)
+	return args.Get(0).([]Grade), args.Error(1)
+}
+
+func (m *mockRepository) GetSizes(ctx context.Context, tenantID string, includeInactive bool) ([]Size, error) {
+	args := m.Called(ctx, tenantID, includeInactive)
+	return args.Get(0).([]Size), args.Error(1)
+}
+
+func (m *mockRepository) GetConnections(ctx context.Context, tenantID string, includeInactive bool) ([]Connection, error) {
+	args := m.Called(ctx, tenantID, includeInactive)
+	return args.Get(0).([]Connection), args.Error(1)
+}
+
+func (m *mockRepository) GetLocations(ctx context.Context, tenantID string, includeInactive bool) ([]Location, error) {
+	args := m.Called(ctx, tenantID, includeInactive)
+	return args.Get(0).([]Location), args.Error(1)
+}
+
+func (m *mockRepository) GetLocation(ctx context.Context, tenantID string, id int) (*Location, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Location), args.Error(1)
+}
+
+func (m *mockRepository) SaveGrade(ctx context.Context, tenantID string, g *Grade) error {
+	return m.Called(ctx, tenantID, g).Error(0)
+}
+
+func (m *mockRepository) SaveSize(ctx context.Context, tenantID string, s *Size) error {
+	return m.Called(ctx, tenantID, s).Error(0)
+}
+
+func (m *mockRepository) SaveConnection(ctx context.Context, tenantID string, c *Connection) error {
+	return m.Called(ctx, tenantID, c).Error(0)
+}
+
+func (m *mockRepository) SaveLocation(ctx context.Context, tenantID string, l *Location) error {
+	return m.Called(ctx, tenantID, l).Error(0)
+}
+
+func (m *mockRepository) SetActive(ctx context.Context, tenantID string, kind Kind, id int, active bool) error {
+	return m.Called(ctx, tenantID, kind, id, active).Error(0)
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+type ReferenceServiceTestSuite struct {
+	suite.Suite
+	service  Service
+	repo     *mockRepository
+	ctx      context.Context
+	tenantID string
+}
+
+func (suite *ReferenceServiceTestSuite) SetupTest() {
+	suite.repo = &mockRepository{}
+	suite.service = NewService(suite.repo)
+	suite.ctx = context.Background()
+	suite.tenantID = "longbeach"
+
+	suite.repo.On("GetGrades", suite.ctx, suite.tenantID, false).Return([]Grade{
+		{ID: 1, Grade: "J55", Active: true},
+		{ID: 2, Grade: "L80", Active: true},
+		{ID: 3, Grade: "N80", Active: true},
+		{ID: 4, Grade: "P110", Active: true},
+	}, nil).Maybe()
+	suite.repo.On("GetSizes", suite.ctx, suite.tenantID, false).Return([]Size{
+		{ID: 1, Size: `5 1/2" 17#`, NominalSize: strPtr(`5 1/2"`), WeightPerFoot: floatPtr(17), Active: true},
+		{ID: 2, Size: `5 1/2" 20#`, NominalSize: strPtr(`5 1/2"`), WeightPerFoot: floatPtr(20), Active: true},
+		{ID: 3, Size: `7" 26#`, NominalSize: strPtr(`7"`), WeightPerFoot: floatPtr(26), Active: true},
+	}, nil).Maybe()
+	suite.repo.On("GetConnections", suite.ctx, suite.tenantID, false).Return([]Connection{
+		{ID: 1, Connection: "BTC", Active: true},
+		{ID: 2, Connection: "LTC", Active: true},
+		{ID: 3, Connection: "EUE", Active: true},
+	}, nil).Maybe()
+}
+
+func TestReferenceServiceSuite(t *testing.T) {
+	suite.Run(t, new(ReferenceServiceTestSuite))
+}
+
+func (suite *ReferenceServiceTestSuite) TestCheckPipe_MatchesLooselyWrittenValues() {
+	result, err := suite.service.CheckPipe(suite.ctx, suite.tenantID, &PipeCheck{Grade: " l80 ", Size: "5-1/2", Connection: "btc"})
+
+	suite.NoError(err)
+	suite.True(result.Valid)
+	suite.Empty(result.Problems)
+}
+
+func (suite *ReferenceServiceTestSuite) TestCheckPipe_SuggestsNearMisses() {
+	result, err := suite.service.CheckPipe(suite.ctx, suite.tenantID, &PipeCheck{Grade: "L-80", Size: `5 1/4"`, Connection: "XYZ"})
+
+	suite.NoError(err)
+	suite.False(result.Valid)
+	suite.Require().Len(result.Problems, 3)
+
+	suite.Equal("grade", result.Problems[0].Field)
+	suite.Equal([]string{"L80", "N80"}, result.Problems[0].Suggestions)
+
+	suite.Equal("size", result.Problems[1].Field)
+	suite.Equal([]string{`5 1/2"`}, result.Problems[1].Suggestions)
+
+	suite.Equal("connection", result.Problems[2].Field)
+	suite.Empty(result.Problems[2].Suggestions)
+}
+
+func (suite *ReferenceServiceTestSuite) TestCheckPipe_SkipsEmptyFields() {
+	result, err := suite.service.CheckPipe(suite.ctx, suite.tenantID, &PipeCheck{Grade: "P110"})
+
+	suite.NoError(err)
+	suite.True(result.Valid)
+	suite.repo.AssertNotCalled(suite.T(), "GetSizes", suite.ctx, suite.tenantID, false)
+	suite.repo.AssertNotCalled(suite.T(), "GetConnections", suite.ctx, suite.tenantID, false)
+}
+
+func (suite *ReferenceServiceTestSuite) TestValidatePipe_ReturnsUnknownValues() {
+	err := suite.service.ValidatePipe(suite.ctx, suite.tenantID, "P-110", `7"`, "LTC")
+
+	suite.Error(err)
+	suite.ErrorIs(err, ErrUnknownValue)
+
+	var unknown *UnknownValuesError
+	suite.Require().True(errors.As(err, &unknown))
+	suite.Len(unknown.Problems, 1)
+	suite.Contains(err.Error(), `grade "P-110"`)
+	suite.Contains(err.Error(), "P110")
+}
+
+func (suite *ReferenceServiceTestSuite) TestSaveGrade_Normalizes() {
+	grade := &Grade{Grade: " q125 ", Active: true}
+	suite.repo.On("SaveGrade", suite.ctx, suite.tenantID, grade).Return(nil)
+
+	err := suite.service.SaveGrade(suite.ctx, suite.tenantID, grade)
+
+	suite.NoError(err)
+	suite.Equal("Q125", grade.Grade)
+}
+
+func (suite *ReferenceServiceTestSuite) TestSave_RejectsInvalidEntries() {
+	testCases := []struct {
+		name string
+		save func() error
+	}{
+		{"missing grade", func() error { return suite.service.SaveGrade(suite.ctx, suite.tenantID, &Grade{Grade: " "}) }},
+		{"inner diameter too large", func() error {
+			return suite.service.SaveSize(suite.ctx, suite.tenantID, &Size{Size: `7" 26#`, OuterDiameter: floatPtr(7), InnerDiameter: floatPtr(7.2)})
+		}},
+		{"unknown category", func() error {
+			return suite.service.SaveConnection(suite.ctx, suite.tenantID, &Connection{Connection: "VAM TOP", Category: strPtr("custom")})
+		}},
+		{"negative capacity", func() error {
+			capacity := -1
+			return suite.service.SaveLocation(suite.ctx, suite.tenantID, &Location{Location: "Yard A", Capacity: &capacity})
+		}},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			err := tc.save()
+
+			assert.Error(suite.T(), err)
+			assert.Contains(suite.T(), err.Error(), "validation failed")
+		})
+	}
+}
+
+func (suite *ReferenceServiceTestSuite) TestDeactivate() {
+	suite.repo.On("SetActive", suite.ctx, suite.tenantID, KindConnection, 3, false).Return(nil)
+
+	suite.NoError(suite.service.Deactivate(suite.ctx, suite.tenantID, KindConnection, 3))
+
+	err := suite.service.Deactivate(suite.ctx, suite.tenantID, Kind("threads"), 3)
+	suite.ErrorIs(err, ErrUnknownKind)
+}
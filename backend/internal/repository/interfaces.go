@@ -59,9 +59,14 @@ type TenantInventoryRepository interface {
 	GetAllForTenant(ctx context.Context, tenantID string, filters models.InventoryFilters) ([]models.InventoryItem, error)
 	GetByIDForTenant(ctx context.Context, tenantID string, id int) (*models.InventoryItem, error)
 	GetByWorkOrderForTenant(ctx context.Context, tenantID, workOrder string) ([]models.InventoryItem, error)
+	// GetAvailableForTenant returns in-stock items. TenantInventoryService
+	// trims them to joints still available to promise, per
+	// store.inventory_availability, and fills ReservedJoints and AvailableJoints.
 	GetAvailableForTenant(ctx context.Context, tenantID string) ([]models.InventoryItem, error)
 	SearchForTenant(ctx context.Context, tenantID, query string) ([]models.InventoryItem, error)
 	GetCountForTenant(ctx context.Context, tenantID string, filters models.InventoryFilters) (int, error)
+	// GetSummaryForTenant leaves TotalWeight to TenantInventoryService, which
+	// takes it from the inventory weight summary
 	GetSummaryForTenant(ctx context.Context, tenantID string, filters models.InventoryFilters) (*models.InventorySummary, error)
 	GetWorkOrdersForTenant(ctx context.Context, tenantID string, filters models.WorkOrderFilters) ([]models.WorkOrder, error)
 	GetWorkOrderCountForTenant(ctx context.Context, tenantID string, filters models.WorkOrderFilters) (int, error)
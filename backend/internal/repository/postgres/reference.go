@@ -0,0 +1,125 @@
+// internal/repository/postgres/reference.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"oilgas-backend/internal/models"
+	"oilgas-backend/internal/repository"
+)
+
+// ReferenceRepo reads the active reference data; entries are maintained
+// through internal/reference
+type ReferenceRepo struct {
+	db *sql.DB
+}
+
+func NewReferenceRepository(db *sql.DB) repository.ReferenceRepository {
+	return &ReferenceRepo{db: db}
+}
+
+func (r *ReferenceRepo) GetGrades(ctx context.Context) ([]models.Grade, error) {
+	query := `
+		SELECT id, grade, description, strength, standard, active, created_at
+		FROM store.grades
+		WHERE active = true
+		ORDER BY grade`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query grades: %w", err)
+	}
+	defer rows.Close()
+
+	var grades []models.Grade
+	for rows.Next() {
+		var g models.Grade
+		err := rows.Scan(&g.ID, &g.Grade, &g.Description, &g.Strength, &g.Standard, &g.Active, &g.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan grade: %w", err)
+		}
+		grades = append(grades, g)
+	}
+
+	return grades, rows.Err()
+}
+
+func (r *ReferenceRepo) GetSizes(ctx context.Context) ([]models.Size, error) {
+	query := `
+		SELECT id, size, nominal_size, outer_diameter, inner_diameter, weight_per_foot, active, created_at
+		FROM store.sizes
+		WHERE active = true
+		ORDER BY outer_diameter NULLS LAST, weight_per_foot NULLS LAST, size`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sizes: %w", err)
+	}
+	defer rows.Close()
+
+	var sizes []models.Size
+	for rows.Next() {
+		var s models.Size
+		err := rows.Scan(&s.ID, &s.Size, &s.NominalSize, &s.OuterDiam, &s.InnerDiam, &s.Weight, &s.Active, &s.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan size: %w", err)
+		}
+		sizes = append(sizes, s)
+	}
+
+	return sizes, rows.Err()
+}
+
+func (r *ReferenceRepo) GetConnections(ctx context.Context) ([]models.Connection, error) {
+	query := `
+		SELECT id, connection, description, category, active, created_at
+		FROM store.connections
+		WHERE active = true
+		ORDER BY connection`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query connections: %w", err)
+	}
+	defer rows.Close()
+
+	var connections []models.Connection
+	for rows.Next() {
+		var c models.Connection
+		err := rows.Scan(&c.ID, &c.Connection, &c.Description, &c.Category, &c.Active, &c.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan connection: %w", err)
+		}
+		connections = append(connections, c)
+	}
+
+	return connections, rows.Err()
+}
+
+func (r *ReferenceRepo) GetLocationsForTenant(ctx context.Context, tenantID string) ([]models.Location, error) {
+	query := `
+		SELECT id, location, description, capacity, tenant_id, active, created_at
+		FROM store.locations
+		WHERE tenant_id = $1 AND active = true
+		ORDER BY location`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query locations: %w", err)
+	}
+	defer rows.Close()
+
+	var locations []models.Location
+	for rows.Next() {
+		var l models.Location
+		err := rows.Scan(&l.ID, &l.Location, &l.Description, &l.Capacity, &l.TenantID, &l.Active, &l.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan location: %w", err)
+		}
+		locations = append(locations, l)
+	}
+
+	return locations, rows.Err()
+}
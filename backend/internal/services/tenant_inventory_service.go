@@ -7,20 +7,47 @@ import (
 	"fmt"
 	"strings"
 
+	"oilgas-backend/internal/inventory"
 	"oilgas-backend/internal/repository"
 	"oilgas-backend/internal/models"
+	"oilgas-backend/pkg/utils"
 )
 
+// PipeValidator checks grade, size and connection against the reference
+// data; reference.Service satisfies it
+type PipeValidator interface {
+	ValidatePipe(ctx context.Context, tenantID, grade, size, connection string) error
+}
+
+// AvailabilitySource lists in-stock rows with joints not yet promised to a
+// work order, from store.inventory_availability; inventory.ReservationService
+// satisfies it
+type AvailabilitySource interface {
+	GetAvailableItems(ctx context.Context, tenantID string, customerID *int) ([]inventory.AvailableItem, error)
+}
+
+// WeightSummarySource weighs in-stock rows against the size reference data;
+// inventory.WeightService satisfies it
+type WeightSummarySource interface {
+	GetWeightSummary(ctx context.Context, tenantID string, filters inventory.WeightFilters) (*inventory.WeightSummary, error)
+}
+
 // TenantInventoryService extends InventoryService with tenant capabilities
 type TenantInventoryService struct {
 	*InventoryService // Embed existing service
 	tenantRepo        repository.TenantInventoryRepository
+	reference         PipeValidator
+	availability      AvailabilitySource
+	weights           WeightSummarySource
 }
 
-func NewTenantInventoryService(repo repository.InventoryRepository, tenantRepo repository.TenantInventoryRepository) *TenantInventoryService {
+func NewTenantInventoryService(repo repository.InventoryRepository, tenantRepo repository.TenantInventoryRepository, reference PipeValidator, availability AvailabilitySource, weights WeightSummarySource) *TenantInventoryService {
 	return &TenantInventoryService{
 		InventoryService: NewInventoryService(repo),
 		tenantRepo:       tenantRepo,
+		reference:        reference,
+		availability:     availability,
+		weights:          weights,
 	}
 }
 
@@ -86,14 +113,77 @@ func (s *TenantInventoryService) GetAvailableInventoryForTenant(ctx context.Cont
 	if err := s.validateTenantID(tenantID); err != nil {
 		return nil, err
 	}
-	return s.tenantRepo.GetAvailableForTenant(ctx, tenantID)
+	items, err := s.tenantRepo.GetAvailableForTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Joints held for a work order are not available to promise. Rows with
+	// nothing left unreserved are missing from the availability list.
+	promisable, err := s.availability.GetAvailableItems(ctx, tenantID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory availability: %w", err)
+	}
+	byID := make(map[int]inventory.AvailableItem, len(promisable))
+	for _, a := range promisable {
+		byID[a.ID] = a
+	}
+	
+	available := make([]models.InventoryItem, 0, len(items))
+	for _, item := range items {
+		a, ok := byID[item.ID]
+		if !ok || a.AvailableJoints <= 0 {
+			continue
+		}
+		reserved, free := a.ReservedJoints, a.AvailableJoints
+		item.ReservedJoints = &reserved
+		item.AvailableJoints = &free
+		available = append(available, item)
+	}
+	return available, nil
 }
 
 func (s *TenantInventoryService) GetInventorySummaryForTenant(ctx context.Context, tenantID string, filters models.InventoryFilters) (*models.InventorySummary, error) {
 	if err := s.validateTenantID(tenantID); err != nil {
 		return nil, err
 	}
-	return s.tenantRepo.GetSummaryForTenant(ctx, tenantID, filters)
+	summary, err := s.tenantRepo.GetSummaryForTenant(ctx, tenantID, filters)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Weigh the same rows through the inventory weight summary so both
+	// report the same pounds
+	weights, err := s.weights.GetWeightSummary(ctx, tenantID, summaryWeightFilters(filters))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory weight: %w", err)
+	}
+	summary.TotalWeight = weights.TotalWeight
+	return summary, nil
+}
+
+// summaryWeightFilters selects the rows GetSummaryForTenant counts, shipped
+// ones included unless filters.Available says otherwise
+func summaryWeightFilters(filters models.InventoryFilters) inventory.WeightFilters {
+	weightFilters := inventory.WeightFilters{
+		CustomerID:     filters.CustomerID,
+		WorkOrder:      filters.WorkOrder,
+		DateFrom:       filters.DateFrom,
+		DateTo:         filters.DateTo,
+		Search:         filters.Search,
+		IncludeShipped: true,
+		Available:      filters.Available,
+	}
+	if filters.Location != nil {
+		weightFilters.Location = *filters.Location
+	}
+	if filters.Size != nil {
+		weightFilters.Size = *filters.Size
+	}
+	if filters.Grade != nil {
+		weightFilters.Grade = *filters.Grade
+	}
+	return weightFilters
 }
 
 // Work Order methods
@@ -141,6 +231,9 @@ func (s *TenantInventoryService) CreateInventoryItemForTenant(ctx context.Contex
 	if err := s.validateInventoryItem(item); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
+	if err := s.validatePipe(ctx, tenantID, item); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
 	
 	// Set tenant ID in inventory item
 	item.TenantID = tenantID
@@ -158,6 +251,9 @@ func (s *TenantInventoryService) UpdateInventoryItemForTenant(ctx context.Contex
 	if err := s.validateInventoryItem(item); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
+	if err := s.validatePipe(ctx, tenantID, item); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
 	
 	// Ensure tenant ID matches
 	item.TenantID = tenantID
@@ -175,6 +271,30 @@ func (s *TenantInventoryService) DeleteInventoryItemForTenant(ctx context.Contex
 	return s.tenantRepo.DeleteForTenant(ctx, tenantID, id)
 }
 
+// validatePipe checks the item's grade, size and connection against the
+// active reference values; fields left empty are not checked
+func (s *TenantInventoryService) validatePipe(ctx context.Context, tenantID string, item *models.InventoryItem) error {
+	if s.reference == nil {
+		return nil
+	}
+	
+	var grade, size, connection string
+	if item.Grade != nil {
+		grade = utils.NormalizeGrade(*item.Grade)
+		item.Grade = &grade
+	}
+	if item.Size != nil {
+		size = utils.NormalizePipeSize(*item.Size)
+		item.Size = &size
+	}
+	if item.Connection != nil {
+		connection = strings.ToUpper(strings.TrimSpace(*item.Connection))
+		item.Connection = &connection
+	}
+	
+	return s.reference.ValidatePipe(ctx, tenantID, grade, size, connection)
+}
+
 func (s *TenantInventoryService) validateTenantID(tenantID string) error {
 	tenantID = strings.TrimSpace(tenantID)
 	if tenantID == "" {
@@ -0,0 +1,175 @@
+// backend/internal/services/tenant_inventory_service_test.go
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"oilgas-backend/internal/inventory"
+	"oilgas-backend/internal/models"
+	"oilgas-backend/internal/repository"
+	"oilgas-backend/internal/shared/weight"
+)
+
+// mockTenantInventoryRepository stubs the calls under test; the embedded
+// interface panics on anything else
+type mockTenantInventoryRepository struct {
+	repository.TenantInventoryRepository
+	mock.Mock
+}
+
+func (m *mockTenantInventoryRepository) GetAvailableForTenant(ctx context.Context, tenantID string) ([]models.InventoryItem, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).([]models.InventoryItem), args.Error(1)
+}
+
+func (m *mockTenantInventoryRepository) GetSummaryForTenant(ctx context.Context, tenantID string, filters models.InventoryFilters) (*models.InventorySummary, error) {
+	args := m.Called(ctx, tenantID, filters)
+	return args.Get(0).(*models.InventorySummary), args.Error(1)
+}
+
+// stubWeightRepository feeds fixed rows to a real inventory.WeightService,
+// narrowing them by size and grade, and keeps the filters it was given
+type stubWeightRepository struct {
+	inventory.WeightRepository
+	lines   []inventory.WeightLine
+	specs   []weight.Spec
+	filters inventory.WeightFilters
+}
+
+func (s *stubWeightRepository) GetWeightLines(ctx context.Context, tenantID string, filters inventory.WeightFilters) ([]inventory.WeightLine, error) {
+	s.filters = filters
+	var lines []inventory.WeightLine
+	for _, line := range s.lines {
+		if filters.Size != "" && *line.Size != filters.Size {
+			continue
+		}
+		if filters.Grade != "" && (line.Grade == nil || *line.Grade != filters.Grade) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+func (s *stubWeightRepository) GetSizes(ctx context.Context, tenantID string) ([]weight.Spec, error) {
+	return s.specs, nil
+}
+
+type mockAvailabilitySource struct {
+	mock.Mock
+}
+
+func (m *mockAvailabilitySource) GetAvailableItems(ctx context.Context, tenantID string, customerID *int) ([]inventory.AvailableItem, error) {
+	args := m.Called(ctx, tenantID, customerID)
+	return args.Get(0).([]inventory.AvailableItem), args.Error(1)
+}
+
+func TestGetAvailableInventoryForTenant_ExcludesPromisedJoints(t *testing.T) {
+	ctx := context.Background()
+	repo := &mockTenantInventoryRepository{}
+	availability := &mockAvailabilitySource{}
+	service := NewTenantInventoryService(nil, repo, nil, availability, nil)
+
+	// 101 is partly reserved, 102 fully reserved, 103 untouched
+	repo.On("GetAvailableForTenant", ctx, "longbeach").Return([]models.InventoryItem{
+		{ID: 101},
+		{ID: 102},
+		{ID: 103},
+	}, nil)
+	availability.On("GetAvailableItems", ctx, "longbeach", (*int)(nil)).Return([]inventory.AvailableItem{
+		{Item: inventory.Item{ID: 101, Joints: 120}, ReservedJoints: 50, AvailableJoints: 70},
+		{Item: inventory.Item{ID: 103, Joints: 40}, ReservedJoints: 0, AvailableJoints: 40},
+	}, nil)
+
+	items, err := service.GetAvailableInventoryForTenant(ctx, "longbeach")
+
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, 101, items[0].ID)
+	assert.Equal(t, 50, *items[0].ReservedJoints)
+	assert.Equal(t, 70, *items[0].AvailableJoints)
+	assert.Equal(t, 103, items[1].ID)
+	assert.Equal(t, 40, *items[1].AvailableJoints)
+}
+
+func TestGetAvailableInventoryForTenant_AvailabilityError(t *testing.T) {
+	ctx := context.Background()
+	repo := &mockTenantInventoryRepository{}
+	availability := &mockAvailabilitySource{}
+	service := NewTenantInventoryService(nil, repo, nil, availability, nil)
+
+	repo.On("GetAvailableForTenant", ctx, "longbeach").Return([]models.InventoryItem{{ID: 101}}, nil)
+	availability.On("GetAvailableItems", ctx, "longbeach", (*int)(nil)).
+		Return([]inventory.AvailableItem(nil), errors.New("connection refused"))
+
+	// Without availability nothing can be promised, so the list is not returned
+	_, err := service.GetAvailableInventoryForTenant(ctx, "longbeach")
+
+	assert.Error(t, err)
+}
+
+func TestGetInventorySummaryForTenant_WeightMatchesWeightSummary(t *testing.T) {
+	ctx := context.Background()
+	size, otherSize, grade := `5 1/2"`, `2 7/8"`, "L80"
+	perFoot, otherPerFoot, average := 17.0, 6.5, 31.0
+	repoStub := &stubWeightRepository{
+		lines: []inventory.WeightLine{
+			{ItemID: 101, Joints: 10, Size: &size, Weight: &perFoot, Grade: &grade},
+			{ItemID: 102, Joints: 4, Size: &size, Weight: &perFoot, AverageLength: &average},
+			{ItemID: 103, Joints: 20, Size: &otherSize, Weight: &otherPerFoot, Grade: &grade},
+		},
+		specs: []weight.Spec{
+			{Size: `5 1/2" 17#`, NominalSize: &size, WeightPerFoot: &perFoot},
+			{Size: `2 7/8" 6.5#`, NominalSize: &otherSize, WeightPerFoot: &otherPerFoot},
+		},
+	}
+	weights := inventory.NewWeightService(repoStub)
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	inStock := true
+
+	tests := []struct {
+		name     string
+		filters  models.InventoryFilters
+		joints   int
+		expected inventory.WeightFilters
+	}{
+		{
+			name:     "unfiltered",
+			filters:  models.InventoryFilters{},
+			joints:   34,
+			expected: inventory.WeightFilters{IncludeShipped: true},
+		},
+		{
+			name:     "size, grade and date range",
+			filters:  models.InventoryFilters{Size: &size, Grade: &grade, DateFrom: &from, Available: &inStock, Search: "acme"},
+			joints:   10,
+			expected: inventory.WeightFilters{Size: size, Grade: grade, DateFrom: &from, Search: "acme", IncludeShipped: true, Available: &inStock},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockTenantInventoryRepository{}
+			service := NewTenantInventoryService(nil, repo, nil, nil, weights)
+			repo.On("GetSummaryForTenant", ctx, "longbeach", tt.filters).
+				Return(&models.InventorySummary{TotalJoints: tt.joints}, nil)
+
+			summary, err := service.GetInventorySummaryForTenant(ctx, "longbeach", tt.filters)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, repoStub.filters)
+
+			expected, err := weights.GetWeightSummary(ctx, "longbeach", tt.expected)
+			require.NoError(t, err)
+			assert.Equal(t, tt.joints, expected.TotalJoints)
+			assert.Greater(t, expected.TotalWeight, 0.0)
+			assert.Equal(t, expected.TotalWeight, summary.TotalWeight)
+		})
+	}
+}
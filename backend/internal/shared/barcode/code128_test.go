@@ -0,0 +1,100 @@
+// backend/internal/shared/barcode/code128_test.go
+package barcode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// symbols reads bars back into Code128 symbol values
+func symbols(t *testing.T, bars Bars) []int {
+	var widths []byte
+	run := 1
+	for i := 1; i <= len(bars); i++ {
+		if i < len(bars) && bars[i] == bars[i-1] {
+			run++
+			continue
+		}
+		widths = append(widths, byte('0'+run))
+		run = 1
+	}
+
+	lookup := make(map[string]int)
+	for v, pattern := range code128Patterns {
+		lookup[pattern] = v
+	}
+
+	var values []int
+	for len(widths) > 7 {
+		v, ok := lookup[string(widths[:6])]
+		require.True(t, ok, "unknown symbol %s", widths[:6])
+		values = append(values, v)
+		widths = widths[6:]
+	}
+	require.Equal(t, "2331112", string(widths))
+	return append(values, code128Stop)
+}
+
+func TestCode128Patterns(t *testing.T) {
+	seen := make(map[string]bool)
+	for v, pattern := range code128Patterns {
+		modules := 0
+		for _, w := range pattern {
+			modules += int(w - '0')
+		}
+		if v == code128Stop {
+			assert.Equal(t, 13, modules)
+			continue
+		}
+		assert.Equal(t, 11, modules, "symbol %d", v)
+		assert.False(t, seen[pattern], "symbol %d repeats a pattern", v)
+		seen[pattern] = true
+	}
+}
+
+func TestCode128(t *testing.T) {
+	bars, err := Code128("Wikipedia")
+	require.NoError(t, err)
+
+	// W i k i p e d i a, then the checksum 3281 mod 103
+	assert.Equal(t, []int{104, 55, 73, 75, 73, 80, 69, 68, 73, 65, 88, 106}, symbols(t, bars))
+	assert.Len(t, bars, 11*11+13)
+	assert.True(t, bars[0])
+	assert.True(t, bars[len(bars)-1])
+}
+
+func TestCode128_DigitsUseCodeSetC(t *testing.T) {
+	bars, err := Code128("123456")
+	require.NoError(t, err)
+	assert.Equal(t, []int{105, 12, 34, 56, (105 + 12 + 34*2 + 56*3) % 103, 106}, symbols(t, bars))
+
+	// An odd number of digits stays in code set B
+	bars, err = Code128("12345")
+	require.NoError(t, err)
+	assert.Equal(t, 104, symbols(t, bars)[0])
+}
+
+func TestCode128_Rejects(t *testing.T) {
+	_, err := Code128("")
+	assert.Error(t, err)
+
+	_, err = Code128("5 1/2\" casing\n")
+	assert.Error(t, err)
+
+	_, err = Code128("café")
+	assert.Error(t, err)
+}
+
+func TestBarsImage(t *testing.T) {
+	bars, err := Code128("R-1")
+	require.NoError(t, err)
+
+	img := bars.Image(2, 40)
+
+	assert.Equal(t, (len(bars)+2*Code128QuietZone)*2, img.Bounds().Dx())
+	assert.Equal(t, 40, img.Bounds().Dy())
+	assert.Equal(t, uint8(0xFF), img.GrayAt(0, 0).Y)
+	assert.Equal(t, uint8(0), img.GrayAt(Code128QuietZone*2, 20).Y)
+}
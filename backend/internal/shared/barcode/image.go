@@ -0,0 +1,64 @@
+// backend/internal/shared/barcode/image.go
+package barcode
+
+import (
+	"image"
+	"image/color"
+)
+
+// Quiet zones, in modules, that scanners need around each symbol
+const (
+	Code128QuietZone = 10
+	QRQuietZone      = 4
+)
+
+// Image draws the bars scale pixels per module and height pixels tall,
+// inside the quiet zone
+func (b Bars) Image(scale, height int) *image.Gray {
+	if scale < 1 {
+		scale = 1
+	}
+	width := (len(b) + 2*Code128QuietZone) * scale
+	img := blank(width, height)
+	for i, bar := range b {
+		if !bar {
+			continue
+		}
+		x := (i + Code128QuietZone) * scale
+		fill(img, x, 0, scale, height)
+	}
+	return img
+}
+
+// Image draws the matrix scale pixels per module, inside the quiet zone
+func (m Matrix) Image(scale int) *image.Gray {
+	if scale < 1 {
+		scale = 1
+	}
+	side := (len(m) + 2*QRQuietZone) * scale
+	img := blank(side, side)
+	for r, row := range m {
+		for c, dark := range row {
+			if dark {
+				fill(img, (c+QRQuietZone)*scale, (r+QRQuietZone)*scale, scale, scale)
+			}
+		}
+	}
+	return img
+}
+
+func blank(width, height int) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xFF
+	}
+	return img
+}
+
+func fill(img *image.Gray, x, y, w, h int) {
+	for dy := 0; dy < h; dy++ {
+		for dx := 0; dx < w; dx++ {
+			img.SetGray(x+dx, y+dy, color.Gray{Y: 0})
+		}
+	}
+}
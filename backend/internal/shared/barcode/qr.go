@@ -0,0 +1,445 @@
+// backend/internal/shared/barcode/qr.go
+package barcode
+
+import "fmt"
+
+// Matrix is a two-dimensional symbol indexed [row][column], true for a dark
+// module. It does not include the quiet zone.
+type Matrix [][]bool
+
+// qrVersion is the error correction block structure of one QR version at
+// level M. Versions 1 to 6 split their codewords into equal blocks and
+// carry no version information, which keeps the encoder small.
+type qrVersion struct {
+	blocks       int
+	dataPerBlock int
+	ecPerBlock   int
+}
+
+var qrVersions = [...]qrVersion{
+	{blocks: 1, dataPerBlock: 16, ecPerBlock: 10},
+	{blocks: 1, dataPerBlock: 28, ecPerBlock: 16},
+	{blocks: 1, dataPerBlock: 44, ecPerBlock: 26},
+	{blocks: 2, dataPerBlock: 32, ecPerBlock: 18},
+	{blocks: 2, dataPerBlock: 43, ecPerBlock: 24},
+	{blocks: 4, dataPerBlock: 27, ecPerBlock: 16},
+}
+
+// QRMaxBytes is the most a label's QR symbol holds
+const QRMaxBytes = 106
+
+// QR encodes data in byte mode at error correction level M, which recovers
+// about 15% of a scuffed label, using the smallest version that holds it
+func QR(data string) (Matrix, error) {
+	if data == "" {
+		return nil, fmt.Errorf("nothing to encode")
+	}
+
+	version := 0
+	for i, v := range qrVersions {
+		if 4+8+8*len(data) <= v.blocks*v.dataPerBlock*8 {
+			version = i + 1
+			break
+		}
+	}
+	if version == 0 {
+		return nil, fmt.Errorf("too long for a QR label: %d bytes (max %d)", len(data), QRMaxBytes)
+	}
+
+	codewords := qrCodewords(data, qrVersions[version-1])
+
+	grid := newQRGrid(version)
+	grid.drawFunctionPatterns(version)
+	grid.drawCodewords(codewords)
+
+	// Keep the mask that leaves the fewest patterns a scanner could misread
+	var best *qrGrid
+	bestPenalty := 0
+	for mask := 0; mask < 8; mask++ {
+		candidate := grid.clone()
+		candidate.applyMask(mask)
+		candidate.drawFormat(mask)
+		if penalty := candidate.penalty(); best == nil || penalty < bestPenalty {
+			best, bestPenalty = candidate, penalty
+		}
+	}
+	return best.modules, nil
+}
+
+// qrCodewords encodes the data segment, pads it to the version's capacity
+// and interleaves it with the error correction of each block
+func qrCodewords(data string, v qrVersion) []byte {
+	capacity := v.blocks * v.dataPerBlock
+
+	var bits []bool
+	appendBits := func(value, n int) {
+		for i := n - 1; i >= 0; i-- {
+			bits = append(bits, (value>>uint(i))&1 != 0)
+		}
+	}
+	appendBits(0x4, 4) // Byte mode
+	appendBits(len(data), 8)
+	for i := 0; i < len(data); i++ {
+		appendBits(int(data[i]), 8)
+	}
+
+	terminator := capacity*8 - len(bits)
+	if terminator > 4 {
+		terminator = 4
+	}
+	appendBits(0, terminator)
+	for len(bits)%8 != 0 {
+		bits = append(bits, false)
+	}
+
+	encoded := make([]byte, 0, capacity)
+	for i := 0; i < len(bits); i += 8 {
+		var b byte
+		for _, bit := range bits[i : i+8] {
+			b <<= 1
+			if bit {
+				b |= 1
+			}
+		}
+		encoded = append(encoded, b)
+	}
+	for pad := byte(0xEC); len(encoded) < capacity; pad ^= 0xEC ^ 0x11 {
+		encoded = append(encoded, pad)
+	}
+
+	generator := rsGenerator(v.ecPerBlock)
+	ec := make([][]byte, v.blocks)
+	for b := range ec {
+		ec[b] = rsRemainder(encoded[b*v.dataPerBlock:(b+1)*v.dataPerBlock], generator)
+	}
+
+	interleaved := make([]byte, 0, capacity+v.blocks*v.ecPerBlock)
+	for i := 0; i < v.dataPerBlock; i++ {
+		for b := 0; b < v.blocks; b++ {
+			interleaved = append(interleaved, encoded[b*v.dataPerBlock+i])
+		}
+	}
+	for i := 0; i < v.ecPerBlock; i++ {
+		for b := 0; b < v.blocks; b++ {
+			interleaved = append(interleaved, ec[b][i])
+		}
+	}
+	return interleaved
+}
+
+// GF(256) over the QR polynomial x^8 + x^4 + x^3 + x^2 + 1
+var gfExp, gfLog = gfTables()
+
+func gfTables() (exp [512]byte, log [256]byte) {
+	x := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = byte(x)
+		log[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11D
+		}
+	}
+	for i := 255; i < 512; i++ {
+		exp[i] = exp[i-255]
+	}
+	return exp, log
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+// rsGenerator returns the Reed-Solomon generator polynomial of a degree,
+// highest power first
+func rsGenerator(degree int) []byte {
+	generator := []byte{1}
+	for i := 0; i < degree; i++ {
+		next := make([]byte, len(generator)+1)
+		for j, c := range generator {
+			next[j] ^= c
+			next[j+1] ^= gfMul(c, gfExp[i])
+		}
+		generator = next
+	}
+	return generator
+}
+
+// rsRemainder divides a block by the generator; the remainder is its error
+// correction
+func rsRemainder(data, generator []byte) []byte {
+	degree := len(generator) - 1
+	remainder := make([]byte, degree)
+	for _, d := range data {
+		factor := d ^ remainder[0]
+		copy(remainder, remainder[1:])
+		remainder[degree-1] = 0
+		for i := 0; i < degree; i++ {
+			remainder[i] ^= gfMul(generator[i+1], factor)
+		}
+	}
+	return remainder
+}
+
+// qrGrid is a symbol being drawn; function marks the modules that are not
+// data, which masking leaves alone
+type qrGrid struct {
+	size     int
+	modules  [][]bool
+	function [][]bool
+}
+
+func newQRGrid(version int) *qrGrid {
+	size := 17 + 4*version
+	g := &qrGrid{size: size, modules: make([][]bool, size), function: make([][]bool, size)}
+	for i := range g.modules {
+		g.modules[i] = make([]bool, size)
+		g.function[i] = make([]bool, size)
+	}
+	return g
+}
+
+func (g *qrGrid) clone() *qrGrid {
+	c := &qrGrid{size: g.size, modules: make([][]bool, g.size), function: g.function}
+	for i := range g.modules {
+		c.modules[i] = append([]bool(nil), g.modules[i]...)
+	}
+	return c
+}
+
+func (g *qrGrid) set(row, col int, dark bool) {
+	g.modules[row][col] = dark
+	g.function[row][col] = true
+}
+
+func (g *qrGrid) drawFunctionPatterns(version int) {
+	for i := 0; i < g.size; i++ {
+		g.set(6, i, i%2 == 0)
+		g.set(i, 6, i%2 == 0)
+	}
+
+	g.drawFinder(3, 3)
+	g.drawFinder(3, g.size-4)
+	g.drawFinder(g.size-4, 3)
+
+	// Versions 2 to 6 have a single alignment pattern
+	if version > 1 {
+		g.drawAlignment(g.size-7, g.size-7)
+	}
+
+	// Reserve the format areas; they are drawn again once a mask is chosen
+	g.drawFormat(0)
+}
+
+// drawFinder draws a finder pattern and its light separator around a centre
+func (g *qrGrid) drawFinder(row, col int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			r, c := row+dy, col+dx
+			if r < 0 || r >= g.size || c < 0 || c >= g.size {
+				continue
+			}
+			dist := chebyshev(dx, dy)
+			g.set(r, c, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (g *qrGrid) drawAlignment(row, col int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			g.set(row+dy, col+dx, chebyshev(dx, dy) != 1)
+		}
+	}
+}
+
+// drawFormat writes level M and the mask, BCH protected, in both copies
+func (g *qrGrid) drawFormat(mask int) {
+	bits := qrFormatBits(mask)
+	bit := func(i int) bool { return (bits>>uint(i))&1 != 0 }
+
+	// Around the top-left finder
+	for i := 0; i <= 5; i++ {
+		g.set(i, 8, bit(i))
+	}
+	g.set(7, 8, bit(6))
+	g.set(8, 8, bit(7))
+	g.set(8, 7, bit(8))
+	for i := 9; i < 15; i++ {
+		g.set(8, 14-i, bit(i))
+	}
+
+	// Split between the top-right and bottom-left finders
+	for i := 0; i < 8; i++ {
+		g.set(8, g.size-1-i, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		g.set(g.size-15+i, 8, bit(i))
+	}
+	g.set(g.size-8, 8, true)
+}
+
+// qrFormatBits is the 15-bit format word for level M and a mask
+func qrFormatBits(mask int) int {
+	data := mask // Level M is 00 in the two bits above the mask
+	remainder := data
+	for i := 0; i < 10; i++ {
+		remainder = (remainder << 1) ^ ((remainder >> 9) * 0x537)
+	}
+	return (data<<10 | remainder) ^ 0x5412
+}
+
+// drawCodewords fills the data modules two columns at a time, zigzagging
+// up and down from the bottom-right corner
+func (g *qrGrid) drawCodewords(data []byte) {
+	i := 0
+	for right := g.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // Skip the vertical timing pattern
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < g.size; vert++ {
+			row := vert
+			if upward {
+				row = g.size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				col := right - j
+				if g.function[row][col] || i >= len(data)*8 {
+					continue
+				}
+				g.modules[row][col] = (data[i>>3]>>uint(7-(i&7)))&1 != 0
+				i++
+			}
+		}
+	}
+}
+
+func (g *qrGrid) applyMask(mask int) {
+	for r := 0; r < g.size; r++ {
+		for c := 0; c < g.size; c++ {
+			if !g.function[r][c] && qrMaskBit(mask, r, c) {
+				g.modules[r][c] = !g.modules[r][c]
+			}
+		}
+	}
+}
+
+func qrMaskBit(mask, r, c int) bool {
+	switch mask {
+	case 0:
+		return (r+c)%2 == 0
+	case 1:
+		return r%2 == 0
+	case 2:
+		return c%3 == 0
+	case 3:
+		return (r+c)%3 == 0
+	case 4:
+		return (r/2+c/3)%2 == 0
+	case 5:
+		return r*c%2+r*c%3 == 0
+	case 6:
+		return (r*c%2+r*c%3)%2 == 0
+	default:
+		return ((r+c)%2+r*c%3)%2 == 0
+	}
+}
+
+// penalty scores a masked symbol by the rules QR uses to pick a mask: long
+// runs, 2x2 blocks, finder look-alikes and an uneven dark share
+func (g *qrGrid) penalty() int {
+	penalty := 0
+	dark := 0
+
+	line := make([]bool, g.size)
+	for horizontal := 0; horizontal < 2; horizontal++ {
+		for i := 0; i < g.size; i++ {
+			for j := 0; j < g.size; j++ {
+				if horizontal == 0 {
+					line[j] = g.modules[i][j]
+				} else {
+					line[j] = g.modules[j][i]
+				}
+			}
+			penalty += runPenalty(line) + finderPenalty(line)
+		}
+	}
+
+	for r := 0; r < g.size; r++ {
+		for c := 0; c < g.size; c++ {
+			if g.modules[r][c] {
+				dark++
+			}
+			if r > 0 && c > 0 {
+				m := g.modules[r][c]
+				if g.modules[r-1][c] == m && g.modules[r][c-1] == m && g.modules[r-1][c-1] == m {
+					penalty += 3
+				}
+			}
+		}
+	}
+
+	percent := dark * 100 / (g.size * g.size)
+	deviation := percent - 50
+	if deviation < 0 {
+		deviation = -deviation
+	}
+	return penalty + deviation/5*10
+}
+
+func runPenalty(line []bool) int {
+	penalty := 0
+	run := 1
+	for i := 1; i <= len(line); i++ {
+		if i < len(line) && line[i] == line[i-1] {
+			run++
+			continue
+		}
+		if run >= 5 {
+			penalty += 3 + run - 5
+		}
+		run = 1
+	}
+	return penalty
+}
+
+var finderLike = [][]bool{
+	{true, false, true, true, true, false, true, false, false, false, false},
+	{false, false, false, false, true, false, true, true, true, false, true},
+}
+
+func finderPenalty(line []bool) int {
+	penalty := 0
+	for start := 0; start+11 <= len(line); start++ {
+		for _, pattern := range finderLike {
+			match := true
+			for k, want := range pattern {
+				if line[start+k] != want {
+					match = false
+					break
+				}
+			}
+			if match {
+				penalty += 40
+			}
+		}
+	}
+	return penalty
+}
+
+func chebyshev(dx, dy int) int {
+	if dx < 0 {
+		dx = -dx
+	}
+	if dy < 0 {
+		dy = -dy
+	}
+	if dx > dy {
+		return dx
+	}
+	return dy
+}
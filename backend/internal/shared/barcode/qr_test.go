@@ -0,0 +1,169 @@
+// backend/internal/shared/barcode/qr_test.go
+package barcode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readQR decodes a symbol the way a scanner would once it has found it:
+// format, unmask, read the zigzag, check each block's error correction and
+// parse the byte segment
+func readQR(t *testing.T, m Matrix) string {
+	size := len(m)
+	require.Zero(t, (size-17)%4, "size %d", size)
+	version := (size - 17) / 4
+
+	get := func(r, c int) int {
+		if m[r][c] {
+			return 1
+		}
+		return 0
+	}
+	format, second := 0, 0
+	for i := 0; i <= 5; i++ {
+		format |= get(i, 8) << uint(i)
+	}
+	format |= get(7, 8)<<6 | get(8, 8)<<7 | get(8, 7)<<8
+	for i := 9; i < 15; i++ {
+		format |= get(8, 14-i) << uint(i)
+	}
+	for i := 0; i < 8; i++ {
+		second |= get(8, size-1-i) << uint(i)
+	}
+	for i := 8; i < 15; i++ {
+		second |= get(size-15+i, 8) << uint(i)
+	}
+	require.Equal(t, format, second, "format copies differ")
+
+	mask := -1
+	for candidate := 0; candidate < 8; candidate++ {
+		if qrFormatBits(candidate) == format {
+			mask = candidate
+		}
+	}
+	require.NotEqual(t, -1, mask, "format %015b is not level M", format)
+
+	layout := newQRGrid(version)
+	layout.drawFunctionPatterns(version)
+
+	var bits []bool
+	for right := size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < size; vert++ {
+			row := vert
+			if upward {
+				row = size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				col := right - j
+				if !layout.function[row][col] {
+					bits = append(bits, m[row][col] != qrMaskBit(mask, row, col))
+				}
+			}
+		}
+	}
+
+	v := qrVersions[version-1]
+	codewords := make([]byte, v.blocks*(v.dataPerBlock+v.ecPerBlock))
+	for i := range codewords {
+		for _, bit := range bits[i*8 : i*8+8] {
+			codewords[i] <<= 1
+			if bit {
+				codewords[i] |= 1
+			}
+		}
+	}
+
+	generator := rsGenerator(v.ecPerBlock)
+	var data []byte
+	blocks := make([][]byte, v.blocks)
+	for i := 0; i < v.dataPerBlock*v.blocks; i++ {
+		blocks[i%v.blocks] = append(blocks[i%v.blocks], codewords[i])
+	}
+	for b, block := range blocks {
+		ec := make([]byte, v.ecPerBlock)
+		for i := range ec {
+			ec[i] = codewords[v.dataPerBlock*v.blocks+i*v.blocks+b]
+		}
+		require.Equal(t, rsRemainder(block, generator), ec, "block %d error correction", b)
+		data = append(data, block...)
+	}
+
+	require.Equal(t, byte(0x4), data[0]>>4, "byte mode")
+	length := int(data[0]&0x0F)<<4 | int(data[1]>>4)
+	decoded := make([]byte, length)
+	for i := range decoded {
+		decoded[i] = data[1+i]<<4 | data[2+i]>>4
+	}
+	return string(decoded)
+}
+
+func TestRSRemainder(t *testing.T) {
+	// The version 1-M "01234567" example from the QR specification
+	data := []byte{0x10, 0x20, 0x0C, 0x56, 0x61, 0x80, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11}
+	assert.Equal(t,
+		[]byte{0xA5, 0x24, 0xD4, 0xC1, 0xED, 0x36, 0xC7, 0x87, 0x2C, 0x55},
+		rsRemainder(data, rsGenerator(10)))
+}
+
+func TestQRFormatBits(t *testing.T) {
+	want := []int{0x5412, 0x5125, 0x5E7C, 0x5B4B, 0x45F9, 0x40CE, 0x4F97, 0x4AA0}
+	for mask, bits := range want {
+		assert.Equal(t, bits, qrFormatBits(mask), "mask %d", mask)
+	}
+}
+
+func TestQR(t *testing.T) {
+	testCases := []struct {
+		data string
+		size int
+	}{
+		{"longbeach:I:42", 21},
+		{"longbeach:L:1234567", 25},
+		{"https://yard.example.com/scan?code=longbeach:S:120", 33},
+		{strings.Repeat("x", 84), 37},
+		{strings.Repeat("y", QRMaxBytes), 41},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.data[:10], func(t *testing.T) {
+			m, err := QR(tc.data)
+			require.NoError(t, err)
+			require.Len(t, m, tc.size)
+
+			// Finder pattern cores in three corners, none in the fourth
+			assert.True(t, m[3][3])
+			assert.True(t, m[3][tc.size-4])
+			assert.True(t, m[tc.size-4][3])
+			assert.False(t, m[7][7])
+
+			assert.Equal(t, tc.data, readQR(t, m))
+		})
+	}
+}
+
+func TestQR_Rejects(t *testing.T) {
+	_, err := QR("")
+	assert.Error(t, err)
+
+	_, err = QR(strings.Repeat("z", QRMaxBytes+1))
+	assert.Error(t, err)
+}
+
+func TestMatrixImage(t *testing.T) {
+	m, err := QR("longbeach:I:42")
+	require.NoError(t, err)
+
+	img := m.Image(3)
+
+	assert.Equal(t, (21+2*QRQuietZone)*3, img.Bounds().Dx())
+	assert.Equal(t, uint8(0xFF), img.GrayAt(0, 0).Y)
+	assert.Equal(t, uint8(0), img.GrayAt(QRQuietZone*3, QRQuietZone*3).Y)
+}
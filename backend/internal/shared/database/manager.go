@@ -4,6 +4,7 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 )
@@ -88,6 +89,19 @@ func (dm *DatabaseManager) GetTenantDB(tenantID string) (*sql.DB, error) {
 	return db, nil
 }
 
+// TenantIDs lists the tenants with an open connection, sorted
+func (dm *DatabaseManager) TenantIDs() []string {
+	dm.mutex.RLock()
+	ids := make([]string, 0, len(dm.tenantDBs))
+	for tenantID := range dm.tenantDBs {
+		ids = append(ids, tenantID)
+	}
+	dm.mutex.RUnlock()
+	
+	sort.Strings(ids)
+	return ids
+}
+
 func (dm *DatabaseManager) Close() error {
 	var errs []error
 	
@@ -0,0 +1,155 @@
+// backend/internal/shared/events/event_bus.go
+package events
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Event represents a domain event
+type Event interface {
+	EventType() string
+	EventID() string
+	TenantID() string
+	Timestamp() time.Time
+	Data() interface{}
+}
+
+// BaseEvent provides common event functionality
+type BaseEvent struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	Tenant    string      `json:"tenant_id"`
+	CreatedAt time.Time   `json:"timestamp"`
+	Payload   interface{} `json:"data"`
+}
+
+func (e BaseEvent) EventType() string    { return e.Type }
+func (e BaseEvent) EventID() string      { return e.ID }
+func (e BaseEvent) TenantID() string     { return e.Tenant }
+func (e BaseEvent) Timestamp() time.Time { return e.CreatedAt }
+func (e BaseEvent) Data() interface{}    { return e.Payload }
+
+// EventHandler processes events
+type EventHandler func(ctx context.Context, event Event) error
+
+// Publisher is the narrow interface domain services depend on
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// EventBus coordinates event publishing and subscription
+type EventBus struct {
+	handlers map[string][]EventHandler
+	mutex    sync.RWMutex
+	store    EventStore // For audit trail, optional
+}
+
+func NewEventBus(store EventStore) *EventBus {
+	return &EventBus{
+		handlers: make(map[string][]EventHandler),
+		store:    store,
+	}
+}
+
+// Subscribe registers an event handler for a specific event type
+func (eb *EventBus) Subscribe(eventType string, handler EventHandler) {
+	eb.mutex.Lock()
+	defer eb.mutex.Unlock()
+
+	eb.handlers[eventType] = append(eb.handlers[eventType], handler)
+}
+
+// Publish sends an event to all registered handlers
+func (eb *EventBus) Publish(ctx context.Context, event Event) error {
+	// Store event for audit trail
+	if eb.store != nil {
+		if err := eb.store.Store(ctx, event); err != nil {
+			log.Printf("Failed to store event %s: %v", event.EventID(), err)
+			// Don't fail the operation, but log the issue
+		}
+	}
+
+	eb.mutex.RLock()
+	handlers := eb.handlers[event.EventType()]
+	eb.mutex.RUnlock()
+
+	// Process handlers asynchronously to avoid blocking
+	for _, handler := range handlers {
+		go func(h EventHandler) {
+			if err := h(ctx, event); err != nil {
+				log.Printf("Event handler failed for %s: %v", event.EventType(), err)
+			}
+		}(handler)
+	}
+
+	return nil
+}
+
+// EventStore persists events for audit trails
+type EventStore interface {
+	Store(ctx context.Context, event Event) error
+}
+
+// DatabaseEventStore writes events to audit.events in a tenant database
+type DatabaseEventStore struct {
+	db *sql.DB
+}
+
+func NewDatabaseEventStore(db *sql.DB) *DatabaseEventStore {
+	return &DatabaseEventStore{db: db}
+}
+
+func (es *DatabaseEventStore) Store(ctx context.Context, event Event) error {
+	eventData, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event data: %w", err)
+	}
+
+	query := `
+		INSERT INTO audit.events (id, event_type, tenant_id, event_data, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err = es.db.ExecContext(ctx, query,
+		event.EventID(),
+		event.EventType(),
+		event.TenantID(),
+		eventData,
+		event.Timestamp(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store event: %w", err)
+	}
+
+	return nil
+}
+
+// TenantDatabases resolves a tenant's database; database.DatabaseManager
+// satisfies it
+type TenantDatabases interface {
+	GetTenantDB(tenantID string) (*sql.DB, error)
+}
+
+// TenantEventStore writes each event to audit.events in the database of the
+// tenant it belongs to, so every yard's audit log holds only its own events
+type TenantEventStore struct {
+	dbs TenantDatabases
+}
+
+func NewTenantEventStore(dbs TenantDatabases) *TenantEventStore {
+	return &TenantEventStore{dbs: dbs}
+}
+
+func (es *TenantEventStore) Store(ctx context.Context, event Event) error {
+	db, err := es.dbs.GetTenantDB(event.TenantID())
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	return NewDatabaseEventStore(db).Store(ctx, event)
+}
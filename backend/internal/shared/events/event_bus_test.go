@@ -0,0 +1,47 @@
+// backend/internal/shared/events/event_bus_test.go
+package events
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tenantDBs map[string]*sql.DB
+
+func (t tenantDBs) GetTenantDB(tenantID string) (*sql.DB, error) {
+	db, ok := t[tenantID]
+	if !ok {
+		return nil, errors.New("unknown tenant")
+	}
+	return db, nil
+}
+
+func TestTenantEventStore_WritesToTheEventsTenant(t *testing.T) {
+	longbeach, longbeachMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer longbeach.Close()
+	bakersfield, bakersfieldMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer bakersfield.Close()
+
+	store := NewTenantEventStore(tenantDBs{"longbeach": longbeach, "bakersfield": bakersfield})
+	event := BaseEvent{ID: "evt-1", Type: "transfer.received", Tenant: "bakersfield", CreatedAt: time.Now()}
+
+	bakersfieldMock.ExpectExec(`INSERT INTO audit.events`).
+		WithArgs("evt-1", "transfer.received", "bakersfield", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, store.Store(context.Background(), event))
+	assert.NoError(t, bakersfieldMock.ExpectationsWereMet())
+	assert.NoError(t, longbeachMock.ExpectationsWereMet())
+
+	event.Tenant = "colorado"
+	assert.Error(t, store.Store(context.Background(), event))
+}
@@ -0,0 +1,46 @@
+// backend/internal/shared/pdf/document_test.go
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_Bytes(t *testing.T) {
+	doc := NewDocument("BOL-000042")
+	doc.Text(40, 60, HelveticaBold, 16, "BILL OF LADING (BOL-000042)")
+	doc.Line(40, 70, 572, 70, 1)
+	doc.AddPage()
+	doc.Rect(40, 40, 100, 20, true)
+
+	out := doc.Bytes()
+
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	assert.Contains(t, string(out), "/Count 2")
+	assert.Contains(t, string(out), `(BILL OF LADING \(BOL-000042\)) Tj`)
+	assert.Contains(t, string(out), "40.00 722.00 m 572.00 722.00 l S")
+
+	// Every xref entry must point at the object it names
+	xref := regexp.MustCompile(`startxref\n(\d+)`).FindSubmatch(out)
+	require.NotNil(t, xref)
+	start, _ := strconv.Atoi(string(xref[1]))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllSubmatch(out[start:], -1)
+	require.Len(t, entries, 9)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		assert.True(t, bytes.HasPrefix(out[offset:], []byte(fmt.Sprintf("%d 0 obj", i+1))), "object %d", i+1)
+	}
+}
+
+func TestEscape(t *testing.T) {
+	assert.Equal(t, `a\(b\)c\\d`, escape(`a(b)c\d`))
+	assert.Equal(t, `5 1/2" casing`, escape(`5 1/2" casing`))
+	assert.Equal(t, "caf?", escape("café"))
+}
@@ -0,0 +1,315 @@
+// backend/internal/shared/weight/weight.go
+// Package weight works out what pipe weighs. A weight per foot comes from
+// the size reference data (the nominal weight, or the steel in the tube's
+// cross-section when only the diameters are known), a length comes from the
+// joint tally or an average joint length, and their product is the weight
+// the yard reports and bills storage on.
+package weight
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Conversion factors, exact where the unit is defined that way
+const (
+	KilogramsPerPound = 0.45359237
+	MetersPerFoot     = 0.3048
+	PoundsPerShortTon = 2000.0
+	PoundsPerTonne    = 1000.0 / KilogramsPerPound
+)
+
+// Steel densities in lb/in³. Chrome grades run slightly lighter than carbon
+// and low-alloy steel.
+const (
+	CarbonSteelDensity = 0.2836
+	ChromeSteelDensity = 0.2800
+)
+
+// DefaultJointLength is a Range 2 joint in feet, used when a row has neither
+// a tally nor an average length of its own
+const DefaultJointLength = 31.0
+
+// DefaultTolerance is how far, as a fraction, an entered weight per foot may
+// sit from the calculated one before it is flagged
+const DefaultTolerance = 0.05
+
+// LinearUnit is a weight per length
+type LinearUnit string
+
+const (
+	PoundsPerFoot     LinearUnit = "lb/ft"
+	KilogramsPerMeter LinearUnit = "kg/m"
+)
+
+// MassUnit is a total weight
+type MassUnit string
+
+const (
+	Pounds     MassUnit = "lb"
+	Kilograms  MassUnit = "kg"
+	ShortTons  MassUnit = "ton"
+	MetricTons MassUnit = "t"
+)
+
+// ConvertLinear converts a weight per length between lb/ft and kg/m
+func ConvertLinear(value float64, from, to LinearUnit) (float64, error) {
+	perFoot, err := toPoundsPerFoot(value, from)
+	if err != nil {
+		return 0, err
+	}
+
+	switch to {
+	case PoundsPerFoot:
+		return perFoot, nil
+	case KilogramsPerMeter:
+		return perFoot * KilogramsPerPound / MetersPerFoot, nil
+	}
+	return 0, fmt.Errorf("unknown weight per length unit: %s", to)
+}
+
+func toPoundsPerFoot(value float64, unit LinearUnit) (float64, error) {
+	switch unit {
+	case PoundsPerFoot:
+		return value, nil
+	case KilogramsPerMeter:
+		return value * MetersPerFoot / KilogramsPerPound, nil
+	}
+	return 0, fmt.Errorf("unknown weight per length unit: %s", unit)
+}
+
+// ConvertMass converts a total weight between pounds, kilograms, short tons
+// and metric tons
+func ConvertMass(value float64, from, to MassUnit) (float64, error) {
+	pounds, err := toPounds(value, from)
+	if err != nil {
+		return 0, err
+	}
+
+	switch to {
+	case Pounds:
+		return pounds, nil
+	case Kilograms:
+		return pounds * KilogramsPerPound, nil
+	case ShortTons:
+		return pounds / PoundsPerShortTon, nil
+	case MetricTons:
+		return pounds / PoundsPerTonne, nil
+	}
+	return 0, fmt.Errorf("unknown weight unit: %s", to)
+}
+
+func toPounds(value float64, unit MassUnit) (float64, error) {
+	switch unit {
+	case Pounds:
+		return value, nil
+	case Kilograms:
+		return value / KilogramsPerPound, nil
+	case ShortTons:
+		return value * PoundsPerShortTon, nil
+	case MetricTons:
+		return value * PoundsPerTonne, nil
+	}
+	return 0, fmt.Errorf("unknown weight unit: %s", unit)
+}
+
+// DensityForGrade returns the steel density for a grade, defaulting to
+// carbon steel for anything that is not a chrome grade
+func DensityForGrade(grade string) float64 {
+	if strings.Contains(strings.ToUpper(grade), "13CR") {
+		return ChromeSteelDensity
+	}
+	return CarbonSteelDensity
+}
+
+// PlainEndPerFoot is the weight per foot of the steel in a tube with the
+// given diameters in inches, before couplings or upsets. At carbon steel
+// density this is the API formula 10.69 × (D − t) × t.
+func PlainEndPerFoot(outerDiameter, innerDiameter, density float64) float64 {
+	if outerDiameter <= 0 || innerDiameter < 0 || innerDiameter >= outerDiameter {
+		return 0
+	}
+	area := math.Pi / 4 * (outerDiameter*outerDiameter - innerDiameter*innerDiameter)
+	return area * 12 * density
+}
+
+// Spec is one size reference entry. A size can have several weights, e.g.
+// 5-1/2" in 17# and 20#, each its own entry.
+type Spec struct {
+	Size          string
+	NominalSize   *string
+	OuterDiameter *float64
+	InnerDiameter *float64
+	WeightPerFoot *float64
+}
+
+// PerFoot is the spec's weight per foot: the nominal weight when the entry
+// has one, otherwise the plain-end weight from its diameters. The boolean
+// is false when the entry has neither.
+func (s Spec) PerFoot(grade string) (float64, bool) {
+	if s.WeightPerFoot != nil && *s.WeightPerFoot > 0 {
+		return *s.WeightPerFoot, true
+	}
+	if s.OuterDiameter != nil && s.InnerDiameter != nil {
+		if w := PlainEndPerFoot(*s.OuterDiameter, *s.InnerDiameter, DensityForGrade(grade)); w > 0 {
+			return w, true
+		}
+	}
+	return 0, false
+}
+
+// NormalizeSize reduces the ways a size gets typed (5 1/2", 5-1/2 in,
+// 5-1/2") to one key for matching against the reference data
+func NormalizeSize(size string) string {
+	size = strings.ToLower(strings.TrimSpace(size))
+	size = strings.TrimSuffix(size, "in")
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '"', '\'':
+			return -1
+		}
+		return r
+	}, size)
+}
+
+// Match picks the reference entry for an item's size. An entry matches on
+// its size or nominal size; among several, the one whose weight per foot is
+// nearest the entered weight wins. With no entered weight a size only
+// matches when it has a single entry.
+func Match(specs []Spec, size, grade string, entered *float64) *Spec {
+	key := NormalizeSize(size)
+	if key == "" {
+		return nil
+	}
+
+	var candidates []Spec
+	for _, spec := range specs {
+		if NormalizeSize(spec.Size) == key || (spec.NominalSize != nil && NormalizeSize(*spec.NominalSize) == key) {
+			if _, ok := spec.PerFoot(grade); ok {
+				candidates = append(candidates, spec)
+			}
+		}
+	}
+
+	switch {
+	case len(candidates) == 0:
+		return nil
+	case len(candidates) == 1:
+		return &candidates[0]
+	case entered == nil || *entered <= 0:
+		return nil
+	}
+
+	best := 0
+	bestDiff := math.Inf(1)
+	for i, spec := range candidates {
+		perFoot, _ := spec.PerFoot(grade)
+		if diff := math.Abs(perFoot - *entered); diff < bestDiff {
+			best, bestDiff = i, diff
+		}
+	}
+	return &candidates[best]
+}
+
+// PerFootSource says where a calculated weight per foot came from
+type PerFootSource string
+
+const (
+	PerFootReference PerFootSource = "REFERENCE" // The size's nominal weight
+	PerFootDiameters PerFootSource = "DIAMETERS" // Steel in the size's cross-section
+	PerFootEntered   PerFootSource = "ENTERED"   // Nothing in the reference data; the typed-in weight
+	PerFootUnknown   PerFootSource = ""
+)
+
+// LengthSource says where a row's footage came from
+type LengthSource string
+
+const (
+	LengthTally        LengthSource = "TALLY"         // Every joint tallied
+	LengthTallyAverage LengthSource = "TALLY_AVERAGE" // Tallied joints' average, times the joints on the row
+	LengthAverage      LengthSource = "AVERAGE"       // The row's average joint length
+	LengthDefault      LengthSource = "DEFAULT"       // DefaultJointLength
+)
+
+// Input is what is known about one inventory row
+type Input struct {
+	Joints int
+	Grade  string
+
+	// Entered is the weight per foot typed in for the row
+	Entered *float64
+
+	// Spec is the matching size reference entry, nil when there is none
+	Spec *Spec
+
+	// TalliedJoints and TalliedFeet summarize the row's joint tally;
+	// AverageLength is an average joint length recorded on the row
+	TalliedJoints int
+	TalliedFeet   float64
+	AverageLength *float64
+}
+
+// Result is a row's calculated weight. Deviation compares the entered
+// weight per foot with the calculated one, as a fraction of the latter,
+// and is nil when there is nothing to compare.
+type Result struct {
+	PerFoot       float64       `json:"weight_per_foot"`
+	PerFootSource PerFootSource `json:"weight_per_foot_source"`
+	Feet          float64       `json:"total_feet"`
+	LengthSource  LengthSource  `json:"length_source"`
+	Pounds        float64       `json:"total_pounds"`
+	Deviation     *float64      `json:"deviation,omitempty"`
+	Flagged       bool          `json:"flagged"`
+}
+
+// Calculate works out a row's weight. The reference data wins over the
+// entered weight; an entered weight further than tolerance from it is
+// flagged rather than used.
+func Calculate(in Input, tolerance float64) Result {
+	var result Result
+
+	if in.Spec != nil {
+		if perFoot, ok := in.Spec.PerFoot(in.Grade); ok {
+			result.PerFoot = perFoot
+			result.PerFootSource = PerFootReference
+			if in.Spec.WeightPerFoot == nil || *in.Spec.WeightPerFoot <= 0 {
+				result.PerFootSource = PerFootDiameters
+			}
+		}
+	}
+
+	if in.Entered != nil && *in.Entered > 0 {
+		if result.PerFootSource == PerFootUnknown {
+			result.PerFoot = *in.Entered
+			result.PerFootSource = PerFootEntered
+		} else {
+			deviation := (*in.Entered - result.PerFoot) / result.PerFoot
+			result.Deviation = &deviation
+			result.Flagged = math.Abs(deviation) > tolerance
+		}
+	}
+
+	result.Feet, result.LengthSource = Footage(in.Joints, in.TalliedJoints, in.TalliedFeet, in.AverageLength)
+	result.Pounds = result.PerFoot * result.Feet
+	return result
+}
+
+// Footage is the length of pipe on a row. A complete tally is summed; a
+// partial one, or one taken before joints left the row, gives an average
+// joint length for the joints there now.
+func Footage(joints, talliedJoints int, talliedFeet float64, averageLength *float64) (float64, LengthSource) {
+	if joints <= 0 {
+		return 0, LengthDefault
+	}
+
+	switch {
+	case talliedJoints == joints && talliedFeet > 0:
+		return talliedFeet, LengthTally
+	case talliedJoints > 0 && talliedFeet > 0:
+		return talliedFeet / float64(talliedJoints) * float64(joints), LengthTallyAverage
+	case averageLength != nil && *averageLength > 0:
+		return *averageLength * float64(joints), LengthAverage
+	}
+	return DefaultJointLength * float64(joints), LengthDefault
+}
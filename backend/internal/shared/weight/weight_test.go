@@ -0,0 +1,150 @@
+// backend/internal/shared/weight/weight_test.go
+package weight
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestConvertLinear(t *testing.T) {
+	kgPerM, err := ConvertLinear(17, PoundsPerFoot, KilogramsPerMeter)
+	require.NoError(t, err)
+	assert.InDelta(t, 25.30, kgPerM, 0.01)
+
+	lbPerFt, err := ConvertLinear(kgPerM, KilogramsPerMeter, PoundsPerFoot)
+	require.NoError(t, err)
+	assert.InDelta(t, 17, lbPerFt, 1e-9)
+
+	_, err = ConvertLinear(17, "lb/in", PoundsPerFoot)
+	assert.Error(t, err)
+}
+
+func TestConvertMass(t *testing.T) {
+	testCases := []struct {
+		to       MassUnit
+		expected float64
+	}{
+		{Pounds, 4000},
+		{Kilograms, 1814.37},
+		{ShortTons, 2},
+		{MetricTons, 1.814},
+	}
+
+	for _, tc := range testCases {
+		got, err := ConvertMass(4000, Pounds, tc.to)
+		require.NoError(t, err)
+		assert.InDelta(t, tc.expected, got, 0.01, string(tc.to))
+
+		back, err := ConvertMass(got, tc.to, Pounds)
+		require.NoError(t, err)
+		assert.InDelta(t, 4000, back, 1e-6, string(tc.to))
+	}
+}
+
+func TestPlainEndPerFoot(t *testing.T) {
+	// 5-1/2" 17# has a 4.892" bore; its plain-end weight is 16.89 lb/ft
+	assert.InDelta(t, 16.89, PlainEndPerFoot(5.5, 4.892, CarbonSteelDensity), 0.01)
+	assert.Less(t, PlainEndPerFoot(5.5, 4.892, DensityForGrade("13CR80")), PlainEndPerFoot(5.5, 4.892, DensityForGrade("L80")))
+	assert.Zero(t, PlainEndPerFoot(5.5, 5.5, CarbonSteelDensity))
+}
+
+func TestNormalizeSize(t *testing.T) {
+	for _, size := range []string{`5 1/2"`, "5-1/2 in", ` 5-1/2" `, "5 1/2"} {
+		assert.Equal(t, "51/2", NormalizeSize(size), size)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	specs := []Spec{
+		{Size: `5 1/2" 17#`, NominalSize: strPtr(`5 1/2"`), OuterDiameter: floatPtr(5.5), InnerDiameter: floatPtr(4.892), WeightPerFoot: floatPtr(17)},
+		{Size: `5 1/2" 20#`, NominalSize: strPtr(`5 1/2"`), OuterDiameter: floatPtr(5.5), InnerDiameter: floatPtr(4.778), WeightPerFoot: floatPtr(20)},
+		{Size: `7" 26#`, NominalSize: strPtr(`7"`), WeightPerFoot: floatPtr(26)},
+	}
+
+	assert.Equal(t, `5 1/2" 20#`, Match(specs, "5-1/2", "L80", floatPtr(19.5)).Size)
+	assert.Equal(t, `5 1/2" 17#`, Match(specs, `5 1/2" 17#`, "", nil).Size)
+	assert.Equal(t, `7" 26#`, Match(specs, `7"`, "", nil).Size)
+
+	// Two weights for the size and nothing to choose between them
+	assert.Nil(t, Match(specs, `5 1/2"`, "", nil))
+	assert.Nil(t, Match(specs, `9 5/8"`, "", floatPtr(40)))
+}
+
+func TestCalculate(t *testing.T) {
+	spec := &Spec{Size: `5 1/2" 17#`, WeightPerFoot: floatPtr(17)}
+
+	testCases := []struct {
+		name          string
+		in            Input
+		perFoot       float64
+		perFootSource PerFootSource
+		feet          float64
+		lengthSource  LengthSource
+		flagged       bool
+	}{
+		{
+			name:          "reference weight, full tally",
+			in:            Input{Joints: 3, Spec: spec, Entered: floatPtr(17), TalliedJoints: 3, TalliedFeet: 94.5},
+			perFoot:       17,
+			perFootSource: PerFootReference,
+			feet:          94.5,
+			lengthSource:  LengthTally,
+		},
+		{
+			name:          "tally taken before joints shipped",
+			in:            Input{Joints: 2, Spec: spec, TalliedJoints: 4, TalliedFeet: 128},
+			perFoot:       17,
+			perFootSource: PerFootReference,
+			feet:          64,
+			lengthSource:  LengthTallyAverage,
+		},
+		{
+			name:          "entered weight far off the reference",
+			in:            Input{Joints: 10, Spec: spec, Entered: floatPtr(170), AverageLength: floatPtr(40)},
+			perFoot:       17,
+			perFootSource: PerFootReference,
+			feet:          400,
+			lengthSource:  LengthAverage,
+			flagged:       true,
+		},
+		{
+			name:          "diameters only",
+			in:            Input{Joints: 1, Spec: &Spec{OuterDiameter: floatPtr(5.5), InnerDiameter: floatPtr(4.892)}},
+			perFoot:       16.89,
+			perFootSource: PerFootDiameters,
+			feet:          DefaultJointLength,
+			lengthSource:  LengthDefault,
+		},
+		{
+			name:          "no reference data",
+			in:            Input{Joints: 2, Entered: floatPtr(9.3)},
+			perFoot:       9.3,
+			perFootSource: PerFootEntered,
+			feet:          2 * DefaultJointLength,
+			lengthSource:  LengthDefault,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Calculate(tc.in, DefaultTolerance)
+
+			assert.InDelta(t, tc.perFoot, got.PerFoot, 0.01)
+			assert.Equal(t, tc.perFootSource, got.PerFootSource)
+			assert.InDelta(t, tc.feet, got.Feet, 1e-9)
+			assert.Equal(t, tc.lengthSource, got.LengthSource)
+			assert.InDelta(t, got.PerFoot*got.Feet, got.Pounds, 1e-9)
+			assert.Equal(t, tc.flagged, got.Flagged)
+		})
+	}
+}
@@ -0,0 +1,222 @@
+// backend/internal/workorder/approval_handlers.go
+package workorder
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"oilgas-backend/internal/auth"
+)
+
+type ApprovalHandlers struct {
+	service ApprovalService
+}
+
+func NewApprovalHandlers(service ApprovalService) *ApprovalHandlers {
+	return &ApprovalHandlers{service: service}
+}
+
+func (h *ApprovalHandlers) RegisterRoutes(router *gin.RouterGroup, authMiddleware *auth.Middleware) {
+	workOrders := router.Group("/workorders")
+	workOrders.Use(authMiddleware.RequireAuth())
+
+	workOrders.GET("/approvals/waiting", h.GetApprovalsWaitingOnMe)
+	workOrders.GET("/:id/approvals", h.GetApprovals)
+	workOrders.POST("/:id/submit", authMiddleware.RequirePermission(auth.PermissionCreateWorkOrder), h.SubmitForApproval)
+	workOrders.POST("/:id/approve", h.Approve)
+	workOrders.POST("/:id/reject", h.Reject)
+
+	chains := router.Group("/approval-chains")
+	chains.Use(authMiddleware.RequireAuth())
+	chains.Use(authMiddleware.RequireRole(auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin))
+
+	chains.GET("", h.ListApprovalChains)
+	chains.POST("", h.CreateApprovalChain)
+	chains.DELETE("/:id", h.DeactivateApprovalChain)
+}
+
+// GetApprovalsWaitingOnMe lists approvals the current user can answer now
+func (h *ApprovalHandlers) GetApprovalsWaitingOnMe(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	approvals, err := h.service.GetApprovalsWaitingOn(c.Request.Context(), tenantID, user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get pending approvals"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  approvals,
+		"total": len(approvals),
+	})
+}
+
+func (h *ApprovalHandlers) GetApprovals(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid work order ID"})
+		return
+	}
+
+	// Customer contacts only see the approvals of their own work orders
+	if user.IsCustomerContact() {
+		wo, err := h.service.GetCustomerWorkOrder(c.Request.Context(), tenantID, user, id)
+		if err != nil {
+			c.JSON(approvalErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"data": wo.Approvals})
+		return
+	}
+
+	approvals, err := h.service.GetApprovals(c.Request.Context(), tenantID, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get approvals"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": approvals})
+}
+
+type ApprovalRequest struct {
+	Comments string `json:"comments"`
+}
+
+// SubmitForApproval submits a draft; ?customer_approval=true also asks the
+// customer's APPROVER contacts to sign off as the last level
+func (h *ApprovalHandlers) SubmitForApproval(c *gin.Context) {
+	if c.Query("customer_approval") == "true" {
+		h.respond(c, h.service.SubmitWithCustomerApproval)
+		return
+	}
+	h.respond(c, h.service.SubmitForApproval)
+}
+
+func (h *ApprovalHandlers) Approve(c *gin.Context) {
+	h.respond(c, h.service.Approve)
+}
+
+func (h *ApprovalHandlers) Reject(c *gin.Context) {
+	h.respond(c, h.service.Reject)
+}
+
+type approvalAction func(ctx context.Context, tenantID string, user *auth.User, workOrderID int, comments string) (*WorkOrder, error)
+
+func (h *ApprovalHandlers) respond(c *gin.Context, action approvalAction) {
+	tenantID := c.GetString("tenant_id")
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid work order ID"})
+		return
+	}
+
+	var req ApprovalRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	wo, err := action(c.Request.Context(), tenantID, user, id, req.Comments)
+	if err != nil {
+		c.JSON(approvalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, wo)
+}
+
+func (h *ApprovalHandlers) ListApprovalChains(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+
+	chains, err := h.service.ListApprovalChains(c.Request.Context(), tenantID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get approval chains"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": chains})
+}
+
+func (h *ApprovalHandlers) CreateApprovalChain(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+
+	var chain ApprovalChain
+	if err := c.ShouldBindJSON(&chain); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.CreateApprovalChain(c.Request.Context(), tenantID, &chain); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, chain)
+}
+
+func (h *ApprovalHandlers) DeactivateApprovalChain(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid approval chain ID"})
+		return
+	}
+
+	if err := h.service.DeactivateApprovalChain(c.Request.Context(), tenantID, id); err != nil {
+		if errors.Is(err, ErrApprovalChainNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Approval chain not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to deactivate approval chain"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Approval chain deactivated"})
+}
+
+func currentUser(c *gin.Context) (*auth.User, bool) {
+	value, exists := c.Get("user")
+	if !exists {
+		return nil, false
+	}
+	user, ok := value.(*auth.User)
+	return user, ok && user != nil
+}
+
+func approvalErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrWorkOrderNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrNotApprover):
+		return http.StatusForbidden
+	case errors.Is(err, ErrInvalidTransition), errors.Is(err, ErrNoPendingApproval),
+		errors.Is(err, ErrApprovalConflict), errors.Is(err, ErrStatusConflict):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
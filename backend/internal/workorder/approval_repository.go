@@ -0,0 +1,368 @@
+// backend/internal/workorder/approval_repository.go
+package workorder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"oilgas-backend/internal/shared/database"
+)
+
+type ApprovalRepository interface {
+	GetApprovalChains(ctx context.Context, tenantID string) ([]ApprovalChain, error)
+	CreateApprovalChain(ctx context.Context, tenantID string, chain *ApprovalChain) error
+	DeactivateApprovalChain(ctx context.Context, tenantID string, id int) error
+
+	GetApprovals(ctx context.Context, tenantID string, workOrderID int) ([]WorkOrderApproval, error)
+	GetPendingApprovals(ctx context.Context, tenantID string) ([]PendingApproval, error)
+	SubmitForApproval(ctx context.Context, tenantID string, workOrderID int, from WorkOrderStatus, approvals []WorkOrderApproval, history []WorkOrderHistory) error
+	RecordDecision(ctx context.Context, tenantID string, decision *ApprovalDecision) error
+}
+
+// ApprovalDecision is a response to one approval level, applied atomically
+// together with any resulting work order status change
+type ApprovalDecision struct {
+	Approval WorkOrderApproval
+	ToStatus *WorkOrderStatus // Set when the decision completes or ends the chain
+	History  []WorkOrderHistory
+}
+
+type approvalRepository struct {
+	dbManager *database.DatabaseManager
+}
+
+func NewApprovalRepository(dbManager *database.DatabaseManager) ApprovalRepository {
+	return &approvalRepository{dbManager: dbManager}
+}
+
+func (r *approvalRepository) GetApprovalChains(ctx context.Context, tenantID string) ([]ApprovalChain, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, tenant_id, name, service_type, priority, min_amount,
+		       is_active, created_at, updated_at
+		FROM store.approval_chains
+		WHERE tenant_id = $1 AND is_active = true
+		ORDER BY id ASC`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval chains: %w", err)
+	}
+	defer rows.Close()
+
+	var chains []ApprovalChain
+	chainIndex := make(map[int]int)
+	for rows.Next() {
+		var chain ApprovalChain
+		err := rows.Scan(
+			&chain.ID, &chain.TenantID, &chain.Name, &chain.ServiceType, &chain.Priority, &chain.MinAmount,
+			&chain.IsActive, &chain.CreatedAt, &chain.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan approval chain: %w", err)
+		}
+		chainIndex[chain.ID] = len(chains)
+		chains = append(chains, chain)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(chains) == 0 {
+		return chains, nil
+	}
+
+	levelRows, err := db.QueryContext(ctx, `
+		SELECT l.id, l.chain_id, l.level, l.approver_type, l.approver_user_id
+		FROM store.approval_chain_levels l
+		JOIN store.approval_chains c ON c.id = l.chain_id
+		WHERE c.tenant_id = $1 AND c.is_active = true
+		ORDER BY l.chain_id ASC, l.level ASC`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval chain levels: %w", err)
+	}
+	defer levelRows.Close()
+
+	for levelRows.Next() {
+		var level ApprovalChainLevel
+		if err := levelRows.Scan(&level.ID, &level.ChainID, &level.Level, &level.ApproverType, &level.ApproverUserID); err != nil {
+			return nil, fmt.Errorf("failed to scan approval chain level: %w", err)
+		}
+		if i, ok := chainIndex[level.ChainID]; ok {
+			chains[i].Levels = append(chains[i].Levels, level)
+		}
+	}
+
+	return chains, levelRows.Err()
+}
+
+func (r *approvalRepository) CreateApprovalChain(ctx context.Context, tenantID string, chain *ApprovalChain) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO store.approval_chains (tenant_id, name, service_type, priority, min_amount, is_active)
+		VALUES ($1, $2, $3, $4, $5, true)
+		RETURNING id, created_at, updated_at`,
+		tenantID, chain.Name, chain.ServiceType, chain.Priority, chain.MinAmount,
+	).Scan(&chain.ID, &chain.CreatedAt, &chain.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create approval chain: %w", err)
+	}
+
+	for i := range chain.Levels {
+		level := &chain.Levels[i]
+		level.ChainID = chain.ID
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO store.approval_chain_levels (chain_id, level, approver_type, approver_user_id)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id`,
+			level.ChainID, level.Level, level.ApproverType, level.ApproverUserID,
+		).Scan(&level.ID)
+		if err != nil {
+			return fmt.Errorf("failed to create approval chain level %d: %w", level.Level, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit approval chain: %w", err)
+	}
+
+	chain.TenantID = tenantID
+	chain.IsActive = true
+	return nil
+}
+
+func (r *approvalRepository) DeactivateApprovalChain(ctx context.Context, tenantID string, id int) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	result, err := db.ExecContext(ctx, `
+		UPDATE store.approval_chains
+		SET is_active = false, updated_at = NOW()
+		WHERE id = $1 AND tenant_id = $2 AND is_active = true`, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate approval chain: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrApprovalChainNotFound
+	}
+
+	return nil
+}
+
+func (r *approvalRepository) GetApprovals(ctx context.Context, tenantID string, workOrderID int) ([]WorkOrderApproval, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT a.id, a.workorder_id, a.chain_id, a.approver_type, a.approver_user_id,
+		       a.approval_level, a.status, a.comments, a.requested_at, a.responded_at
+		FROM store.workorder_approvals a
+		JOIN store.workorders w ON w.id = a.workorder_id
+		WHERE a.workorder_id = $1 AND w.tenant_id = $2
+		ORDER BY a.requested_at ASC, a.approval_level ASC`, workOrderID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work order approvals: %w", err)
+	}
+	defer rows.Close()
+
+	var approvals []WorkOrderApproval
+	for rows.Next() {
+		var a WorkOrderApproval
+		if err := scanApproval(rows, &a); err != nil {
+			return nil, fmt.Errorf("failed to scan work order approval: %w", err)
+		}
+		approvals = append(approvals, a)
+	}
+
+	return approvals, rows.Err()
+}
+
+// GetPendingApprovals returns the current level of every work order awaiting
+// approval. Later levels only become actionable once earlier ones approve.
+func (r *approvalRepository) GetPendingApprovals(ctx context.Context, tenantID string) ([]PendingApproval, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT a.id, a.workorder_id, a.chain_id, a.approver_type, a.approver_user_id,
+		       a.approval_level, a.status, a.comments, a.requested_at, a.responded_at,
+		       w.work_order_number, w.customer_id, w.service_type, w.priority, w.description,
+		       COALESCE(w.total_amount, COALESCE(w.estimated_hours * w.hourly_rate, 0) + COALESCE(w.materials_cost, 0))
+		FROM store.workorder_approvals a
+		JOIN store.workorders w ON w.id = a.workorder_id
+		WHERE w.tenant_id = $1 AND w.status = 'PENDING' AND w.is_active = true
+		  AND a.status = 'PENDING'
+		  AND a.approval_level = (
+		      SELECT MIN(p.approval_level) FROM store.workorder_approvals p
+		      WHERE p.workorder_id = a.workorder_id AND p.status = 'PENDING'
+		  )
+		ORDER BY a.requested_at ASC`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending approvals: %w", err)
+	}
+	defer rows.Close()
+
+	var pending []PendingApproval
+	for rows.Next() {
+		var p PendingApproval
+		err := rows.Scan(
+			&p.ID, &p.WorkOrderID, &p.ChainID, &p.ApproverType, &p.ApproverUserID,
+			&p.ApprovalLevel, &p.Status, &p.Comments, &p.RequestedAt, &p.RespondedAt,
+			&p.WorkOrderNumber, &p.CustomerID, &p.ServiceType, &p.Priority, &p.Description,
+			&p.Amount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pending approval: %w", err)
+		}
+		pending = append(pending, p)
+	}
+
+	return pending, rows.Err()
+}
+
+func (r *approvalRepository) SubmitForApproval(ctx context.Context, tenantID string, workOrderID int, from WorkOrderStatus, approvals []WorkOrderApproval, history []WorkOrderHistory) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := updateStatusTx(ctx, tx, tenantID, workOrderID, from, StatusPending); err != nil {
+		return err
+	}
+
+	for i := range approvals {
+		a := &approvals[i]
+		a.WorkOrderID = workOrderID
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO store.workorder_approvals (
+				workorder_id, chain_id, approver_type, approver_user_id, approval_level, status
+			) VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id, requested_at`,
+			a.WorkOrderID, a.ChainID, a.ApproverType, a.ApproverUserID, a.ApprovalLevel, a.Status,
+		).Scan(&a.ID, &a.RequestedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create approval level %d: %w", a.ApprovalLevel, err)
+		}
+	}
+
+	for i := range history {
+		history[i].WorkOrderID = workOrderID
+		if err := insertHistory(ctx, tx, &history[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit approval submission: %w", err)
+	}
+
+	return nil
+}
+
+// RecordDecision stores a response to a pending approval. A rejection also
+// discards the remaining levels; an approval that does not end the chain
+// starts the clock on the next level.
+func (r *approvalRepository) RecordDecision(ctx context.Context, tenantID string, decision *ApprovalDecision) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	a := &decision.Approval
+	err = tx.QueryRowContext(ctx, `
+		UPDATE store.workorder_approvals
+		SET status = $2, approver_user_id = $3, comments = $4, responded_at = NOW()
+		WHERE id = $1 AND status = 'PENDING'
+		RETURNING responded_at`,
+		a.ID, a.Status, a.ApproverUserID, a.Comments,
+	).Scan(&a.RespondedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrApprovalConflict
+		}
+		return fmt.Errorf("failed to record approval decision: %w", err)
+	}
+
+	if a.Status == ApprovalRejected {
+		_, err = tx.ExecContext(ctx, `
+			DELETE FROM store.workorder_approvals
+			WHERE workorder_id = $1 AND status = 'PENDING'`, a.WorkOrderID)
+		if err != nil {
+			return fmt.Errorf("failed to discard remaining approval levels: %w", err)
+		}
+	} else if decision.ToStatus == nil {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE store.workorder_approvals
+			SET requested_at = NOW()
+			WHERE workorder_id = $1 AND status = 'PENDING' AND approval_level = (
+			    SELECT MIN(approval_level) FROM store.workorder_approvals
+			    WHERE workorder_id = $1 AND status = 'PENDING'
+			)`, a.WorkOrderID)
+		if err != nil {
+			return fmt.Errorf("failed to request next approval level: %w", err)
+		}
+	}
+
+	if decision.ToStatus != nil {
+		if err := updateStatusTx(ctx, tx, tenantID, a.WorkOrderID, StatusPending, *decision.ToStatus); err != nil {
+			return err
+		}
+	}
+
+	for i := range decision.History {
+		decision.History[i].WorkOrderID = a.WorkOrderID
+		if err := insertHistory(ctx, tx, &decision.History[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit approval decision: %w", err)
+	}
+
+	return nil
+}
+
+func scanApproval(row rowScanner, a *WorkOrderApproval) error {
+	return row.Scan(
+		&a.ID, &a.WorkOrderID, &a.ChainID, &a.ApproverType, &a.ApproverUserID,
+		&a.ApprovalLevel, &a.Status, &a.Comments, &a.RequestedAt, &a.RespondedAt,
+	)
+}
@@ -0,0 +1,560 @@
+// backend/internal/workorder/approvals.go
+package workorder
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"oilgas-backend/internal/auth"
+	"oilgas-backend/internal/shared/events"
+)
+
+type ApprovalService interface {
+	ListApprovalChains(ctx context.Context, tenantID string) ([]ApprovalChain, error)
+	CreateApprovalChain(ctx context.Context, tenantID string, chain *ApprovalChain) error
+	DeactivateApprovalChain(ctx context.Context, tenantID string, id int) error
+
+	GetApprovals(ctx context.Context, tenantID string, workOrderID int) ([]WorkOrderApproval, error)
+	SubmitForApproval(ctx context.Context, tenantID string, user *auth.User, workOrderID int, notes string) (*WorkOrder, error)
+	SubmitWithCustomerApproval(ctx context.Context, tenantID string, user *auth.User, workOrderID int, notes string) (*WorkOrder, error)
+	Approve(ctx context.Context, tenantID string, user *auth.User, workOrderID int, comments string) (*WorkOrder, error)
+	Reject(ctx context.Context, tenantID string, user *auth.User, workOrderID int, comments string) (*WorkOrder, error)
+	GetApprovalsWaitingOn(ctx context.Context, tenantID string, user *auth.User) ([]PendingApproval, error)
+
+	// GetCustomerWorkOrder returns a work order with its approvals for one of
+	// the customer's own contacts, or ErrWorkOrderNotFound for anyone else's
+	GetCustomerWorkOrder(ctx context.Context, tenantID string, user *auth.User, workOrderID int) (*WorkOrder, error)
+}
+
+type approvalService struct {
+	repo      Repository
+	approvals ApprovalRepository
+	publisher events.Publisher
+}
+
+func NewApprovalService(repo Repository, approvals ApprovalRepository, publisher events.Publisher) ApprovalService {
+	return &approvalService{
+		repo:      repo,
+		approvals: approvals,
+		publisher: publisher,
+	}
+}
+
+// defaultApprovalLevels applies when no configured chain matches a work order
+var defaultApprovalLevels = []ApprovalChainLevel{
+	{Level: 1, ApproverType: ApproverManager},
+}
+
+func (s *approvalService) ListApprovalChains(ctx context.Context, tenantID string) ([]ApprovalChain, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	chains, err := s.approvals.GetApprovalChains(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval chains: %w", err)
+	}
+
+	return chains, nil
+}
+
+func (s *approvalService) CreateApprovalChain(ctx context.Context, tenantID string, chain *ApprovalChain) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if err := validateApprovalChain(chain); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.approvals.CreateApprovalChain(ctx, tenantID, chain); err != nil {
+		return fmt.Errorf("failed to create approval chain: %w", err)
+	}
+
+	return nil
+}
+
+func (s *approvalService) DeactivateApprovalChain(ctx context.Context, tenantID string, id int) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if id <= 0 {
+		return fmt.Errorf("invalid approval chain ID: %d", id)
+	}
+
+	if err := s.approvals.DeactivateApprovalChain(ctx, tenantID, id); err != nil {
+		return fmt.Errorf("failed to deactivate approval chain %d: %w", id, err)
+	}
+
+	return nil
+}
+
+func (s *approvalService) GetApprovals(ctx context.Context, tenantID string, workOrderID int) ([]WorkOrderApproval, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if workOrderID <= 0 {
+		return nil, fmt.Errorf("invalid work order ID: %d", workOrderID)
+	}
+
+	approvals, err := s.approvals.GetApprovals(ctx, tenantID, workOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approvals: %w", err)
+	}
+
+	return approvals, nil
+}
+
+// SubmitForApproval moves a draft work order to PENDING and creates one
+// approval row per level of the chain that matches it
+func (s *approvalService) SubmitForApproval(ctx context.Context, tenantID string, user *auth.User, workOrderID int, notes string) (*WorkOrder, error) {
+	return s.submit(ctx, tenantID, user, workOrderID, notes, false)
+}
+
+// SubmitWithCustomerApproval submits like SubmitForApproval and, unless the
+// matching chain already asks the customer, adds a last level for the
+// customer's APPROVER contacts
+func (s *approvalService) SubmitWithCustomerApproval(ctx context.Context, tenantID string, user *auth.User, workOrderID int, notes string) (*WorkOrder, error) {
+	return s.submit(ctx, tenantID, user, workOrderID, notes, true)
+}
+
+func (s *approvalService) submit(ctx context.Context, tenantID string, user *auth.User, workOrderID int, notes string, customerApproval bool) (*WorkOrder, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if user == nil {
+		return nil, fmt.Errorf("user is required")
+	}
+
+	wo, err := s.repo.GetWorkOrderByID(ctx, tenantID, workOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work order %d: %w", workOrderID, err)
+	}
+
+	if user.IsCustomerContact() && *user.CustomerID != wo.CustomerID {
+		return nil, ErrWorkOrderNotFound
+	}
+
+	if wo.Status != StatusDraft {
+		return nil, fmt.Errorf("%w: only draft work orders can be submitted, status is %s", ErrInvalidTransition, wo.Status)
+	}
+
+	chains, err := s.approvals.GetApprovalChains(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval chains: %w", err)
+	}
+
+	var chainID *int
+	levels := defaultApprovalLevels
+	chainName := "default"
+	if chain := selectApprovalChain(chains, wo); chain != nil {
+		chainID = &chain.ID
+		levels = chain.Levels
+		chainName = chain.Name
+	}
+	if customerApproval {
+		levels = withCustomerLevel(levels)
+	}
+
+	approvals := make([]WorkOrderApproval, len(levels))
+	for i, level := range levels {
+		approvals[i] = WorkOrderApproval{
+			ChainID:        chainID,
+			ApproverType:   level.ApproverType,
+			ApproverUserID: level.ApproverUserID,
+			ApprovalLevel:  level.Level,
+			Status:         ApprovalPending,
+		}
+	}
+
+	historyNotes := fmt.Sprintf("Submitted for approval (%s chain, %d levels)", chainName, len(levels))
+	if strings.TrimSpace(notes) != "" {
+		historyNotes += ": " + notes
+	}
+	history := []WorkOrderHistory{{
+		ChangedByUserID: user.ID,
+		Action:          "status_changed",
+		OldValue:        stringPtr(string(StatusDraft)),
+		NewValue:        stringPtr(string(StatusPending)),
+		Notes:           stringPtr(historyNotes),
+	}}
+
+	if err := s.approvals.SubmitForApproval(ctx, tenantID, wo.ID, StatusDraft, approvals, history); err != nil {
+		return nil, fmt.Errorf("failed to submit work order for approval: %w", err)
+	}
+
+	wo.Status = StatusPending
+	wo.Approvals = approvals
+
+	s.publish(ctx, NewWorkOrderStatusChangedEvent(tenantID, wo.ID, StatusDraft, StatusPending, user.ID, notes))
+	return wo, nil
+}
+
+// Approve answers the current level. Approving the last level moves the work
+// order to APPROVED.
+func (s *approvalService) Approve(ctx context.Context, tenantID string, user *auth.User, workOrderID int, comments string) (*WorkOrder, error) {
+	return s.decide(ctx, tenantID, user, workOrderID, ApprovalApproved, comments)
+}
+
+// Reject answers the current level and sends the work order back to DRAFT so
+// it can be revised and resubmitted
+func (s *approvalService) Reject(ctx context.Context, tenantID string, user *auth.User, workOrderID int, comments string) (*WorkOrder, error) {
+	if strings.TrimSpace(comments) == "" {
+		return nil, fmt.Errorf("validation failed: comments are required when rejecting")
+	}
+	return s.decide(ctx, tenantID, user, workOrderID, ApprovalRejected, comments)
+}
+
+func (s *approvalService) decide(ctx context.Context, tenantID string, user *auth.User, workOrderID int, status ApprovalStatus, comments string) (*WorkOrder, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if user == nil {
+		return nil, fmt.Errorf("user is required")
+	}
+
+	wo, err := s.repo.GetWorkOrderByID(ctx, tenantID, workOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work order %d: %w", workOrderID, err)
+	}
+
+	// Contacts never learn that another customer's work order exists
+	if user.IsCustomerContact() && *user.CustomerID != wo.CustomerID {
+		return nil, ErrWorkOrderNotFound
+	}
+
+	if wo.Status != StatusPending {
+		return nil, ErrNoPendingApproval
+	}
+
+	approvals, err := s.approvals.GetApprovals(ctx, tenantID, workOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approvals: %w", err)
+	}
+
+	current, remaining := currentApprovalLevel(approvals)
+	if current == nil {
+		return nil, ErrNoPendingApproval
+	}
+
+	if !canRespondToApproval(user, tenantID, wo.CustomerID, current) {
+		return nil, ErrNotApprover
+	}
+
+	decision := &ApprovalDecision{Approval: *current}
+	decision.Approval.Status = status
+	decision.Approval.ApproverUserID = &user.ID
+	decision.Approval.Comments = nullableString(strings.TrimSpace(comments))
+
+	action := "approval_granted"
+	if status == ApprovalRejected {
+		action = "approval_rejected"
+	}
+	decision.History = append(decision.History, WorkOrderHistory{
+		ChangedByUserID: user.ID,
+		Action:          action,
+		NewValue:        stringPtr(fmt.Sprintf("level %d", current.ApprovalLevel)),
+		Notes:           decision.Approval.Comments,
+	})
+
+	var to WorkOrderStatus
+	switch {
+	case status == ApprovalRejected:
+		to = StatusDraft
+	case remaining == 0:
+		to = StatusApproved
+	}
+	if to != "" {
+		decision.ToStatus = &to
+		decision.History = append(decision.History, WorkOrderHistory{
+			ChangedByUserID: user.ID,
+			Action:          "status_changed",
+			OldValue:        stringPtr(string(StatusPending)),
+			NewValue:        stringPtr(string(to)),
+			Notes:           decision.Approval.Comments,
+		})
+	}
+
+	if err := s.approvals.RecordDecision(ctx, tenantID, decision); err != nil {
+		return nil, fmt.Errorf("failed to record approval decision: %w", err)
+	}
+
+	for i := range approvals {
+		if approvals[i].ID == decision.Approval.ID {
+			approvals[i] = decision.Approval
+		}
+	}
+	wo.Approvals = approvals
+
+	s.publish(ctx, NewWorkOrderApprovalDecidedEvent(tenantID, wo.ID, status, current.ApprovalLevel, user.ID, comments, to != ""))
+	if to != "" {
+		wo.Status = to
+		s.publish(ctx, NewWorkOrderStatusChangedEvent(tenantID, wo.ID, StatusPending, to, user.ID, comments))
+		s.publish(ctx, NewWorkOrderApprovalEvent(tenantID, wo.ID, status, current.ApprovalLevel, user.ID, comments))
+	}
+
+	return wo, nil
+}
+
+// GetApprovalsWaitingOn lists the approvals the user can answer right now
+func (s *approvalService) GetApprovalsWaitingOn(ctx context.Context, tenantID string, user *auth.User) ([]PendingApproval, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if user == nil {
+		return nil, fmt.Errorf("user is required")
+	}
+
+	pending, err := s.approvals.GetPendingApprovals(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending approvals: %w", err)
+	}
+
+	waiting := []PendingApproval{}
+	for _, p := range pending {
+		if canRespondToApproval(user, tenantID, p.CustomerID, &p.WorkOrderApproval) {
+			waiting = append(waiting, p)
+		}
+	}
+
+	return waiting, nil
+}
+
+func (s *approvalService) GetCustomerWorkOrder(ctx context.Context, tenantID string, user *auth.User, workOrderID int) (*WorkOrder, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if user == nil || !user.IsCustomerContact() || !user.CanAccessTenant(tenantID) {
+		return nil, ErrWorkOrderNotFound
+	}
+
+	wo, err := s.repo.GetWorkOrderByID(ctx, tenantID, workOrderID)
+	if err != nil {
+		return nil, err
+	}
+	if wo.CustomerID != *user.CustomerID {
+		return nil, ErrWorkOrderNotFound
+	}
+
+	approvals, err := s.approvals.GetApprovals(ctx, tenantID, workOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approvals: %w", err)
+	}
+	wo.Approvals = approvals
+
+	return wo, nil
+}
+
+func (s *approvalService) publish(ctx context.Context, event events.Event) {
+	publishEvent(ctx, s.publisher, event)
+}
+
+// currentApprovalLevel returns the lowest pending level and how many pending
+// levels follow it
+func currentApprovalLevel(approvals []WorkOrderApproval) (*WorkOrderApproval, int) {
+	var current *WorkOrderApproval
+	pending := 0
+	for i := range approvals {
+		if approvals[i].Status != ApprovalPending {
+			continue
+		}
+		pending++
+		if current == nil || approvals[i].ApprovalLevel < current.ApprovalLevel {
+			current = &approvals[i]
+		}
+	}
+	if current == nil {
+		return nil, 0
+	}
+	return current, pending - 1
+}
+
+// withCustomerLevel appends a customer approver level after the chain's own
+// levels, unless one of them already goes to the customer
+func withCustomerLevel(levels []ApprovalChainLevel) []ApprovalChainLevel {
+	for _, level := range levels {
+		if level.ApproverType == ApproverCustomerApprover {
+			return levels
+		}
+	}
+
+	extended := make([]ApprovalChainLevel, len(levels), len(levels)+1)
+	copy(extended, levels)
+	return append(extended, ApprovalChainLevel{
+		Level:        len(levels) + 1,
+		ApproverType: ApproverCustomerApprover,
+	})
+}
+
+// canRespondToApproval decides whether a user may answer an approval level.
+// Named approvers are exclusive; manager levels need APPROVE_WORK_ORDER or
+// CanApprove in the tenant; customer levels need an APPROVER contact of the
+// work order's customer.
+func canRespondToApproval(user *auth.User, tenantID string, customerID int, approval *WorkOrderApproval) bool {
+	if approval.ApproverUserID != nil {
+		return user.ID == *approval.ApproverUserID
+	}
+
+	switch approval.ApproverType {
+	case ApproverManager:
+		if user.IsCustomerContact() {
+			return false
+		}
+		return canApproveInTenant(user, tenantID)
+	case ApproverCustomerApprover:
+		return user.IsCustomerContact() &&
+			user.ContactType == auth.ContactApprover &&
+			*user.CustomerID == customerID &&
+			user.CanAccessTenant(tenantID)
+	default:
+		return false
+	}
+}
+
+func canApproveInTenant(user *auth.User, tenantID string) bool {
+	if user.Role == auth.RoleSystemAdmin {
+		return true
+	}
+
+	if user.HasPermissionInTenant(tenantID, auth.PermissionApproveWorkOrder) {
+		return true
+	}
+
+	for _, access := range user.TenantAccess {
+		if access.TenantID == tenantID && access.CanApprove {
+			return true
+		}
+	}
+
+	return false
+}
+
+// selectApprovalChain picks the most specific active chain matching the work
+// order: highest amount threshold first, then the one constraining more fields
+func selectApprovalChain(chains []ApprovalChain, wo *WorkOrder) *ApprovalChain {
+	amount := estimatedAmount(wo)
+
+	var best *ApprovalChain
+	for i := range chains {
+		chain := &chains[i]
+		if !chain.IsActive || len(chain.Levels) == 0 {
+			continue
+		}
+		if chain.ServiceType != nil && *chain.ServiceType != wo.ServiceType {
+			continue
+		}
+		if chain.Priority != nil && *chain.Priority != wo.Priority {
+			continue
+		}
+		if chain.MinAmount != nil && amount < *chain.MinAmount {
+			continue
+		}
+		if best == nil || moreSpecificChain(chain, best) {
+			best = chain
+		}
+	}
+
+	return best
+}
+
+func moreSpecificChain(a, b *ApprovalChain) bool {
+	threshold := func(c *ApprovalChain) float64 {
+		if c.MinAmount == nil {
+			return 0
+		}
+		return *c.MinAmount
+	}
+	specificity := func(c *ApprovalChain) int {
+		n := 0
+		if c.ServiceType != nil {
+			n++
+		}
+		if c.Priority != nil {
+			n++
+		}
+		return n
+	}
+
+	if threshold(a) != threshold(b) {
+		return threshold(a) > threshold(b)
+	}
+	if specificity(a) != specificity(b) {
+		return specificity(a) > specificity(b)
+	}
+	return a.ID < b.ID
+}
+
+// estimatedAmount is the total used for approval thresholds: the quoted total
+// when set, otherwise estimated labor plus materials
+func estimatedAmount(wo *WorkOrder) float64 {
+	if wo.TotalAmount != nil {
+		return *wo.TotalAmount
+	}
+
+	var amount float64
+	if wo.EstimatedHours != nil && wo.HourlyRate != nil {
+		amount += *wo.EstimatedHours * *wo.HourlyRate
+	}
+	if wo.MaterialsCost != nil {
+		amount += *wo.MaterialsCost
+	}
+	return amount
+}
+
+func validateApprovalChain(chain *ApprovalChain) error {
+	if chain == nil {
+		return fmt.Errorf("approval chain is required")
+	}
+
+	if strings.TrimSpace(chain.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	if len(chain.Name) > 255 {
+		return fmt.Errorf("name too long: %d characters", len(chain.Name))
+	}
+
+	if chain.ServiceType != nil && !isValidServiceType(*chain.ServiceType) {
+		return fmt.Errorf("invalid service type: %s", *chain.ServiceType)
+	}
+
+	if chain.Priority != nil && !isValidPriority(*chain.Priority) {
+		return fmt.Errorf("invalid priority: %s", *chain.Priority)
+	}
+
+	if chain.MinAmount != nil && *chain.MinAmount < 0 {
+		return fmt.Errorf("minimum amount cannot be negative")
+	}
+
+	if len(chain.Levels) == 0 {
+		return fmt.Errorf("at least one approval level is required")
+	}
+
+	sort.Slice(chain.Levels, func(i, j int) bool {
+		return chain.Levels[i].Level < chain.Levels[j].Level
+	})
+
+	for i, level := range chain.Levels {
+		if level.Level != i+1 {
+			return fmt.Errorf("approval levels must be numbered consecutively from 1")
+		}
+		switch level.ApproverType {
+		case ApproverManager, ApproverCustomerApprover:
+		default:
+			return fmt.Errorf("invalid approver type for level %d: %s", level.Level, level.ApproverType)
+		}
+		if level.ApproverUserID != nil && *level.ApproverUserID <= 0 {
+			return fmt.Errorf("invalid approver user ID for level %d", level.Level)
+		}
+	}
+
+	return nil
+}
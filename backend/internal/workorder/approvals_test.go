@@ -0,0 +1,502 @@
+// backend/internal/workorder/approvals_test.go
+package workorder
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"oilgas-backend/internal/auth"
+)
+
+type mockApprovalRepository struct {
+	mock.Mock
+}
+
+func (m *mockApprovalRepository) GetApprovalChains(ctx context.Context, tenantID string) ([]ApprovalChain, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).([]ApprovalChain), args.Error(1)
+}
+
+func (m *mockApprovalRepository) CreateApprovalChain(ctx context.Context, tenantID string, chain *ApprovalChain) error {
+	args := m.Called(ctx, tenantID, chain)
+	return args.Error(0)
+}
+
+func (m *mockApprovalRepository) DeactivateApprovalChain(ctx context.Context, tenantID string, id int) error {
+	args := m.Called(ctx, tenantID, id)
+	return args.Error(0)
+}
+
+func (m *mockApprovalRepository) GetApprovals(ctx context.Context, tenantID string, workOrderID int) ([]WorkOrderApproval, error) {
+	args := m.Called(ctx, tenantID, workOrderID)
+	return args.Get(0).([]WorkOrderApproval), args.Error(1)
+}
+
+func (m *mockApprovalRepository) GetPendingApprovals(ctx context.Context, tenantID string) ([]PendingApproval, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).([]PendingApproval), args.Error(1)
+}
+
+func (m *mockApprovalRepository) SubmitForApproval(ctx context.Context, tenantID string, workOrderID int, from WorkOrderStatus, approvals []WorkOrderApproval, history []WorkOrderHistory) error {
+	args := m.Called(ctx, tenantID, workOrderID, from, approvals, history)
+	return args.Error(0)
+}
+
+func (m *mockApprovalRepository) RecordDecision(ctx context.Context, tenantID string, decision *ApprovalDecision) error {
+	args := m.Called(ctx, tenantID, decision)
+	return args.Error(0)
+}
+
+type ApprovalServiceTestSuite struct {
+	suite.Suite
+	service   ApprovalService
+	repo      *mockRepository
+	approvals *mockApprovalRepository
+	publisher *mockPublisher
+	ctx       context.Context
+	tenantID  string
+	manager   *auth.User
+	approver  *auth.User
+}
+
+func (suite *ApprovalServiceTestSuite) SetupTest() {
+	suite.repo = &mockRepository{}
+	suite.approvals = &mockApprovalRepository{}
+	suite.publisher = &mockPublisher{}
+	suite.service = NewApprovalService(suite.repo, suite.approvals, suite.publisher)
+	suite.ctx = context.Background()
+	suite.tenantID = "longbeach"
+
+	suite.manager = &auth.User{
+		ID:   10,
+		Role: auth.RoleManager,
+		TenantAccess: auth.TenantAccessList{{
+			TenantID:    "longbeach",
+			Role:        auth.RoleManager,
+			Permissions: []auth.Permission{auth.PermissionApproveWorkOrder},
+		}},
+	}
+
+	customerID := 3
+	suite.approver = &auth.User{
+		ID:          20,
+		Role:        auth.RoleCustomerContact,
+		CustomerID:  &customerID,
+		ContactType: auth.ContactApprover,
+		TenantAccess: auth.TenantAccessList{{
+			TenantID: "longbeach",
+			Role:     auth.RoleCustomerContact,
+		}},
+	}
+}
+
+func TestApprovalServiceSuite(t *testing.T) {
+	suite.Run(t, new(ApprovalServiceTestSuite))
+}
+
+func (suite *ApprovalServiceTestSuite) urgentRepair(status WorkOrderStatus) *WorkOrder {
+	total := 12500.0
+	return &WorkOrder{
+		ID:          42,
+		TenantID:    suite.tenantID,
+		CustomerID:  3,
+		ServiceType: ServiceRepair,
+		Status:      status,
+		Priority:    PriorityUrgent,
+		Description: "Re-thread 40 joints",
+		TotalAmount: &total,
+		IsActive:    true,
+	}
+}
+
+func (suite *ApprovalServiceTestSuite) twoLevelApprovals() []WorkOrderApproval {
+	chainID := 5
+	return []WorkOrderApproval{
+		{ID: 100, WorkOrderID: 42, ChainID: &chainID, ApproverType: ApproverManager, ApprovalLevel: 1, Status: ApprovalPending},
+		{ID: 101, WorkOrderID: 42, ChainID: &chainID, ApproverType: ApproverCustomerApprover, ApprovalLevel: 2, Status: ApprovalPending},
+	}
+}
+
+func (suite *ApprovalServiceTestSuite) TestSubmitForApproval_UsesMatchingChain() {
+	repair := ServiceRepair
+	urgent := PriorityUrgent
+	threshold := 10000.0
+	chains := []ApprovalChain{
+		{ID: 1, Name: "Default", IsActive: true, Levels: []ApprovalChainLevel{
+			{Level: 1, ApproverType: ApproverManager},
+		}},
+		{ID: 5, Name: "Urgent repair over 10k", ServiceType: &repair, Priority: &urgent, MinAmount: &threshold, IsActive: true,
+			Levels: []ApprovalChainLevel{
+				{Level: 1, ApproverType: ApproverManager},
+				{Level: 2, ApproverType: ApproverCustomerApprover},
+			}},
+	}
+
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(suite.urgentRepair(StatusDraft), nil)
+	suite.approvals.On("GetApprovalChains", suite.ctx, suite.tenantID).Return(chains, nil)
+	suite.approvals.On("SubmitForApproval", suite.ctx, suite.tenantID, 42, StatusDraft,
+		mock.MatchedBy(func(a []WorkOrderApproval) bool {
+			return len(a) == 2 &&
+				*a[0].ChainID == 5 && a[0].ApproverType == ApproverManager && a[0].ApprovalLevel == 1 &&
+				a[1].ApproverType == ApproverCustomerApprover && a[1].ApprovalLevel == 2
+		}),
+		mock.MatchedBy(func(h []WorkOrderHistory) bool {
+			return len(h) == 1 && *h[0].NewValue == string(StatusPending)
+		})).Return(nil)
+	suite.publisher.On("Publish", suite.ctx, mock.AnythingOfType("*workorder.WorkOrderStatusChangedEvent")).Return(nil)
+
+	wo, err := suite.service.SubmitForApproval(suite.ctx, suite.tenantID, suite.manager, 42, "")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), StatusPending, wo.Status)
+	assert.Len(suite.T(), wo.Approvals, 2)
+	suite.approvals.AssertExpectations(suite.T())
+}
+
+func (suite *ApprovalServiceTestSuite) TestSubmitForApproval_DefaultsToSingleManagerLevel() {
+	wo := suite.urgentRepair(StatusDraft)
+
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(wo, nil)
+	suite.approvals.On("GetApprovalChains", suite.ctx, suite.tenantID).Return([]ApprovalChain{}, nil)
+	suite.approvals.On("SubmitForApproval", suite.ctx, suite.tenantID, 42, StatusDraft,
+		mock.MatchedBy(func(a []WorkOrderApproval) bool {
+			return len(a) == 1 && a[0].ChainID == nil && a[0].ApproverType == ApproverManager
+		}), mock.Anything).Return(nil)
+	suite.publisher.On("Publish", suite.ctx, mock.Anything).Return(nil)
+
+	_, err := suite.service.SubmitForApproval(suite.ctx, suite.tenantID, suite.manager, 42, "ready")
+
+	assert.NoError(suite.T(), err)
+	suite.approvals.AssertExpectations(suite.T())
+}
+
+func (suite *ApprovalServiceTestSuite) TestSubmitForApproval_NotDraft() {
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(suite.urgentRepair(StatusInProgress), nil)
+
+	_, err := suite.service.SubmitForApproval(suite.ctx, suite.tenantID, suite.manager, 42, "")
+
+	assert.True(suite.T(), errors.Is(err, ErrInvalidTransition))
+	suite.approvals.AssertNotCalled(suite.T(), "SubmitForApproval")
+}
+
+func (suite *ApprovalServiceTestSuite) TestSubmitWithCustomerApproval_AddsCustomerLevel() {
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(suite.urgentRepair(StatusDraft), nil)
+	suite.approvals.On("GetApprovalChains", suite.ctx, suite.tenantID).Return([]ApprovalChain{}, nil)
+	suite.approvals.On("SubmitForApproval", suite.ctx, suite.tenantID, 42, StatusDraft,
+		mock.MatchedBy(func(a []WorkOrderApproval) bool {
+			return len(a) == 2 &&
+				a[0].ApproverType == ApproverManager && a[0].ApprovalLevel == 1 &&
+				a[1].ApproverType == ApproverCustomerApprover && a[1].ApprovalLevel == 2
+		}), mock.Anything).Return(nil)
+	suite.publisher.On("Publish", suite.ctx, mock.Anything).Return(nil)
+
+	wo, err := suite.service.SubmitWithCustomerApproval(suite.ctx, suite.tenantID, suite.manager, 42, "")
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), wo.Approvals, 2)
+	assert.Len(suite.T(), defaultApprovalLevels, 1)
+	suite.approvals.AssertExpectations(suite.T())
+}
+
+func TestWithCustomerLevel_KeepsExistingCustomerLevel(t *testing.T) {
+	levels := []ApprovalChainLevel{
+		{Level: 1, ApproverType: ApproverCustomerApprover},
+		{Level: 2, ApproverType: ApproverManager},
+	}
+
+	assert.Equal(t, levels, withCustomerLevel(levels))
+}
+
+func (suite *ApprovalServiceTestSuite) TestApprove_AdvancesToNextLevel() {
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(suite.urgentRepair(StatusPending), nil)
+	suite.approvals.On("GetApprovals", suite.ctx, suite.tenantID, 42).Return(suite.twoLevelApprovals(), nil)
+	suite.approvals.On("RecordDecision", suite.ctx, suite.tenantID, mock.MatchedBy(func(d *ApprovalDecision) bool {
+		return d.Approval.ID == 100 &&
+			d.Approval.Status == ApprovalApproved &&
+			*d.Approval.ApproverUserID == suite.manager.ID &&
+			d.ToStatus == nil &&
+			len(d.History) == 1 && d.History[0].Action == "approval_granted"
+	})).Return(nil)
+	// Only the level's decision is logged; the chain is still running
+	suite.publisher.On("Publish", suite.ctx, mock.MatchedBy(func(e *WorkOrderApprovalDecidedEvent) bool {
+		return e.ApprovalLevel == 1 && e.Status == ApprovalApproved &&
+			e.DecidedBy == suite.manager.ID && !e.Final
+	})).Return(nil).Once()
+
+	wo, err := suite.service.Approve(suite.ctx, suite.tenantID, suite.manager, 42, "")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), StatusPending, wo.Status)
+	suite.approvals.AssertExpectations(suite.T())
+	suite.publisher.AssertExpectations(suite.T())
+	suite.publisher.AssertNumberOfCalls(suite.T(), "Publish", 1)
+}
+
+func (suite *ApprovalServiceTestSuite) TestApprove_LastLevelApprovesWorkOrder() {
+	approvals := suite.twoLevelApprovals()
+	approvals[0].Status = ApprovalApproved
+	approvals[0].ApproverUserID = &suite.manager.ID
+
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(suite.urgentRepair(StatusPending), nil)
+	suite.approvals.On("GetApprovals", suite.ctx, suite.tenantID, 42).Return(approvals, nil)
+	suite.approvals.On("RecordDecision", suite.ctx, suite.tenantID, mock.MatchedBy(func(d *ApprovalDecision) bool {
+		return d.Approval.ID == 101 &&
+			d.ToStatus != nil && *d.ToStatus == StatusApproved &&
+			len(d.History) == 2 && *d.History[1].NewValue == string(StatusApproved)
+	})).Return(nil)
+	suite.publisher.On("Publish", suite.ctx, mock.AnythingOfType("*workorder.WorkOrderStatusChangedEvent")).Return(nil)
+	suite.publisher.On("Publish", suite.ctx, mock.MatchedBy(func(e *WorkOrderApprovalEvent) bool {
+		return e.EventType() == "workorder.approved" && e.ApprovalLevel == 2
+	})).Return(nil)
+	suite.publisher.On("Publish", suite.ctx, mock.MatchedBy(func(e *WorkOrderApprovalDecidedEvent) bool {
+		return e.ApprovalLevel == 2 && e.DecidedBy == suite.approver.ID && e.Final
+	})).Return(nil)
+
+	wo, err := suite.service.Approve(suite.ctx, suite.tenantID, suite.approver, 42, "Go ahead")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), StatusApproved, wo.Status)
+	suite.approvals.AssertExpectations(suite.T())
+	suite.publisher.AssertExpectations(suite.T())
+}
+
+func (suite *ApprovalServiceTestSuite) TestApprove_WrongApproverForLevel() {
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(suite.urgentRepair(StatusPending), nil)
+	suite.approvals.On("GetApprovals", suite.ctx, suite.tenantID, 42).Return(suite.twoLevelApprovals(), nil)
+
+	// Level 1 belongs to managers, so the customer approver has to wait
+	_, err := suite.service.Approve(suite.ctx, suite.tenantID, suite.approver, 42, "")
+
+	assert.True(suite.T(), errors.Is(err, ErrNotApprover))
+	suite.approvals.AssertNotCalled(suite.T(), "RecordDecision")
+}
+
+func (suite *ApprovalServiceTestSuite) TestApprove_OtherCustomersWorkOrderIsNotFound() {
+	wo := suite.urgentRepair(StatusPending)
+	wo.CustomerID = 9
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(wo, nil)
+
+	_, err := suite.service.Approve(suite.ctx, suite.tenantID, suite.approver, 42, "")
+
+	assert.True(suite.T(), errors.Is(err, ErrWorkOrderNotFound))
+	suite.approvals.AssertNotCalled(suite.T(), "GetApprovals")
+}
+
+func (suite *ApprovalServiceTestSuite) TestGetCustomerWorkOrder() {
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(suite.urgentRepair(StatusPending), nil)
+	suite.approvals.On("GetApprovals", suite.ctx, suite.tenantID, 42).Return(suite.twoLevelApprovals(), nil)
+
+	wo, err := suite.service.GetCustomerWorkOrder(suite.ctx, suite.tenantID, suite.approver, 42)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), wo.Approvals, 2)
+
+	// Staff go through the regular work order routes
+	_, err = suite.service.GetCustomerWorkOrder(suite.ctx, suite.tenantID, suite.manager, 42)
+	assert.True(suite.T(), errors.Is(err, ErrWorkOrderNotFound))
+
+	otherCustomerID := 9
+	other := *suite.approver
+	other.CustomerID = &otherCustomerID
+	_, err = suite.service.GetCustomerWorkOrder(suite.ctx, suite.tenantID, &other, 42)
+	assert.True(suite.T(), errors.Is(err, ErrWorkOrderNotFound))
+}
+
+func (suite *ApprovalServiceTestSuite) TestReject_ReturnsToDraft() {
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(suite.urgentRepair(StatusPending), nil)
+	suite.approvals.On("GetApprovals", suite.ctx, suite.tenantID, 42).Return(suite.twoLevelApprovals(), nil)
+	suite.approvals.On("RecordDecision", suite.ctx, suite.tenantID, mock.MatchedBy(func(d *ApprovalDecision) bool {
+		return d.Approval.Status == ApprovalRejected &&
+			*d.Approval.Comments == "Quote is too high" &&
+			d.ToStatus != nil && *d.ToStatus == StatusDraft
+	})).Return(nil)
+	suite.publisher.On("Publish", suite.ctx, mock.AnythingOfType("*workorder.WorkOrderStatusChangedEvent")).Return(nil)
+	suite.publisher.On("Publish", suite.ctx, mock.MatchedBy(func(e *WorkOrderApprovalEvent) bool {
+		return e.EventType() == "workorder.rejected"
+	})).Return(nil)
+	suite.publisher.On("Publish", suite.ctx, mock.MatchedBy(func(e *WorkOrderApprovalDecidedEvent) bool {
+		return e.Status == ApprovalRejected && e.Final
+	})).Return(nil)
+
+	wo, err := suite.service.Reject(suite.ctx, suite.tenantID, suite.manager, 42, "Quote is too high")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), StatusDraft, wo.Status)
+	suite.approvals.AssertExpectations(suite.T())
+}
+
+func (suite *ApprovalServiceTestSuite) TestReject_RequiresComments() {
+	_, err := suite.service.Reject(suite.ctx, suite.tenantID, suite.manager, 42, "  ")
+
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "comments are required")
+	suite.repo.AssertNotCalled(suite.T(), "GetWorkOrderByID")
+}
+
+func (suite *ApprovalServiceTestSuite) TestGetApprovalsWaitingOn() {
+	otherCustomerID := 9
+	pending := []PendingApproval{
+		{WorkOrderApproval: WorkOrderApproval{ID: 1, WorkOrderID: 40, ApproverType: ApproverManager, ApprovalLevel: 1}, CustomerID: 3},
+		{WorkOrderApproval: WorkOrderApproval{ID: 2, WorkOrderID: 41, ApproverType: ApproverCustomerApprover, ApprovalLevel: 2}, CustomerID: 3},
+		{WorkOrderApproval: WorkOrderApproval{ID: 3, WorkOrderID: 42, ApproverType: ApproverCustomerApprover, ApprovalLevel: 2}, CustomerID: otherCustomerID},
+	}
+	suite.approvals.On("GetPendingApprovals", suite.ctx, suite.tenantID).Return(pending, nil)
+
+	forManager, err := suite.service.GetApprovalsWaitingOn(suite.ctx, suite.tenantID, suite.manager)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), forManager, 1)
+	assert.Equal(suite.T(), 40, forManager[0].WorkOrderID)
+
+	forApprover, err := suite.service.GetApprovalsWaitingOn(suite.ctx, suite.tenantID, suite.approver)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), forApprover, 1)
+	assert.Equal(suite.T(), 41, forApprover[0].WorkOrderID)
+}
+
+func (suite *ApprovalServiceTestSuite) TestCreateApprovalChain_Validation() {
+	testCases := []struct {
+		name        string
+		chain       *ApprovalChain
+		expectError string
+	}{
+		{
+			name:        "missing name",
+			chain:       &ApprovalChain{Levels: []ApprovalChainLevel{{Level: 1, ApproverType: ApproverManager}}},
+			expectError: "name is required",
+		},
+		{
+			name:        "no levels",
+			chain:       &ApprovalChain{Name: "Empty"},
+			expectError: "at least one approval level is required",
+		},
+		{
+			name: "gap in levels",
+			chain: &ApprovalChain{Name: "Gap", Levels: []ApprovalChainLevel{
+				{Level: 1, ApproverType: ApproverManager},
+				{Level: 3, ApproverType: ApproverCustomerApprover},
+			}},
+			expectError: "numbered consecutively",
+		},
+		{
+			name:        "unknown approver type",
+			chain:       &ApprovalChain{Name: "Bad", Levels: []ApprovalChainLevel{{Level: 1, ApproverType: "FOREMAN"}}},
+			expectError: "invalid approver type",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			err := suite.service.CreateApprovalChain(suite.ctx, suite.tenantID, tc.chain)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectError)
+		})
+	}
+
+	suite.approvals.AssertNotCalled(suite.T(), "CreateApprovalChain")
+}
+
+func TestSelectApprovalChain(t *testing.T) {
+	repair := ServiceRepair
+	low, high := 1000.0, 10000.0
+	chains := []ApprovalChain{
+		{ID: 1, Name: "Any", IsActive: true, Levels: []ApprovalChainLevel{{Level: 1}}},
+		{ID: 2, Name: "Repairs", ServiceType: &repair, IsActive: true, Levels: []ApprovalChainLevel{{Level: 1}}},
+		{ID: 3, Name: "Over 1k", MinAmount: &low, IsActive: true, Levels: []ApprovalChainLevel{{Level: 1}}},
+		{ID: 4, Name: "Over 10k", MinAmount: &high, IsActive: true, Levels: []ApprovalChainLevel{{Level: 1}, {Level: 2}}},
+	}
+
+	hours, rate := 10.0, 125.0
+	testCases := []struct {
+		name     string
+		wo       *WorkOrder
+		expectID int
+	}{
+		{"no criteria match falls back to catch-all", &WorkOrder{ServiceType: ServiceCleaning}, 1},
+		{"service type beats catch-all", &WorkOrder{ServiceType: ServiceRepair}, 2},
+		{"estimate drives threshold", &WorkOrder{ServiceType: ServiceRepair, EstimatedHours: &hours, HourlyRate: &rate}, 3},
+		{"highest threshold wins", &WorkOrder{ServiceType: ServiceRepair, TotalAmount: &high}, 4},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			chain := selectApprovalChain(chains, tc.wo)
+			assert.NotNil(t, chain)
+			assert.Equal(t, tc.expectID, chain.ID)
+		})
+	}
+}
+
+func TestUpdateStatusTx_WithdrawDiscardsPendingApprovals(t *testing.T) {
+	for _, to := range []WorkOrderStatus{StatusDraft, StatusCancelled} {
+		t.Run(string(to), func(t *testing.T) {
+			db, dbMock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec(`UPDATE store.workorders`).
+				WithArgs(42, "longbeach", to, StatusPending).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			if to == StatusCancelled {
+				dbMock.ExpectExec(`UPDATE store.inventory_reservations`).WillReturnResult(sqlmock.NewResult(0, 0))
+			}
+			dbMock.ExpectExec(`DELETE FROM store.workorder_approvals\s+WHERE workorder_id = \$1 AND status = 'PENDING'`).
+				WithArgs(42).
+				WillReturnResult(sqlmock.NewResult(0, 2))
+
+			tx, err := db.Begin()
+			assert.NoError(t, err)
+			assert.NoError(t, updateStatusTx(context.Background(), tx, "longbeach", 42, StatusPending, to))
+			assert.NoError(t, dbMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUpdateStatusTx_CancelFromHoldDiscardsPendingApprovals(t *testing.T) {
+	for _, to := range []WorkOrderStatus{StatusDraft, StatusCancelled} {
+		t.Run(string(to), func(t *testing.T) {
+			db, dbMock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			// Held while PENDING, then withdrawn or cancelled from the hold
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec(`UPDATE store.workorders`).
+				WithArgs(42, "longbeach", to, StatusOnHold).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			if to == StatusCancelled {
+				dbMock.ExpectExec(`UPDATE store.inventory_reservations`).WillReturnResult(sqlmock.NewResult(0, 0))
+			}
+			dbMock.ExpectExec(`DELETE FROM store.workorder_approvals\s+WHERE workorder_id = \$1 AND status = 'PENDING'`).
+				WithArgs(42).
+				WillReturnResult(sqlmock.NewResult(0, 2))
+
+			tx, err := db.Begin()
+			assert.NoError(t, err)
+			assert.NoError(t, updateStatusTx(context.Background(), tx, "longbeach", 42, StatusOnHold, to))
+			assert.NoError(t, dbMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUpdateStatusTx_HoldKeepsPendingApprovals(t *testing.T) {
+	db, dbMock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// Resuming to PENDING picks the same round back up
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(`UPDATE store.workorders`).
+		WithArgs(42, "longbeach", StatusOnHold, StatusPending).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	assert.NoError(t, updateStatusTx(context.Background(), tx, "longbeach", 42, StatusPending, StatusOnHold))
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+}
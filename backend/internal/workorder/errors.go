@@ -0,0 +1,100 @@
+// backend/internal/workorder/errors.go
+package workorder
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Work order errors
+var (
+	ErrWorkOrderNotFound  = errors.New("work order not found")
+	ErrInvalidTransition  = errors.New("invalid status transition")
+	ErrStatusConflict     = errors.New("work order status changed concurrently")
+	ErrNotEditable        = errors.New("work order can no longer be edited")
+	ErrManagedByInvoicing = errors.New("status change must go through invoicing")
+	ErrRescheduleRequired = errors.New("change affects the schedule; reschedule the work order instead")
+	ErrNotDeletable       = errors.New("only draft work orders can be deleted; cancel the work order instead")
+)
+
+// Work order item errors
+var (
+	ErrWorkOrderItemNotFound = errors.New("work order item not found")
+	ErrItemAlreadyCompleted  = errors.New("work order item is already completed")
+	ErrItemNotCompletable    = errors.New("items can only be completed while work is in progress")
+)
+
+// Approval errors
+var (
+	ErrApprovalRequired      = errors.New("status change must go through the approval workflow")
+	ErrApprovalChainNotFound = errors.New("approval chain not found")
+	ErrNoPendingApproval     = errors.New("work order has no pending approval")
+	ErrNotApprover           = errors.New("user cannot respond to the current approval level")
+	ErrApprovalConflict      = errors.New("approval was already answered")
+)
+
+// Labor errors
+var (
+	ErrTimeEntryNotFound  = errors.New("time entry not found")
+	ErrAlreadyClockedIn   = errors.New("user is already clocked in")
+	ErrNotClockedIn       = errors.New("user is not clocked in")
+	ErrBreakInProgress    = errors.New("a break is already in progress")
+	ErrNoBreakInProgress  = errors.New("no break is in progress")
+	ErrNotLaborManager    = errors.New("only managers can edit time entries")
+	ErrTimeEntryConflict  = errors.New("time entry was changed concurrently")
+	ErrLaborNotRecordable = errors.New("labor cannot be recorded against this work order")
+)
+
+// Scheduling errors
+var (
+	ErrYardNotFound       = errors.New("yard not found")
+	ErrServiceBayNotFound = errors.New("service bay not found")
+	ErrNotSchedulable     = errors.New("work order cannot be scheduled in its current status")
+	ErrOutsideYardHours   = errors.New("start time is outside yard working hours")
+	ErrScheduleConflict   = errors.New("schedule conflict")
+	ErrNoFreeSlot         = errors.New("no free slot found in the search window")
+)
+
+// SLA errors
+var (
+	ErrSLARuleNotFound  = errors.New("SLA rule not found")
+	ErrAlreadyEscalated = errors.New("work order was already escalated for this rule")
+	ErrWorkOrderChanged = errors.New("work order changed since it was evaluated")
+)
+
+// Template errors
+var (
+	ErrTemplateNotFound      = errors.New("work order template not found")
+	ErrTemplateConflict      = errors.New("template was changed concurrently")
+	ErrChecklistItemNotFound = errors.New("checklist item not found")
+	ErrChecklistLocked       = errors.New("checklist can only be changed while work is in progress")
+	ErrChecklistIncomplete   = errors.New("required checklist steps are not checked")
+)
+
+// Legacy import errors
+var (
+	ErrLegacyAlreadyImported = errors.New("legacy rows were already imported")
+	ErrLegacyNumberTaken     = errors.New("work order number is already in use")
+)
+
+// Inspection errors
+var (
+	ErrInspectionNotFound   = errors.New("inspection result not found")
+	ErrNotInspectable       = errors.New("inspection results can only be recorded while work is in progress")
+	ErrJointSegregated      = errors.New("joint has been segregated and its result can no longer change")
+	ErrNoRejectsToSegregate = errors.New("no rejected joints waiting to be segregated")
+	ErrInsufficientJoints   = errors.New("inventory item has fewer joints than rejects to segregate")
+)
+
+// ConflictError reports the bookings a schedule request collides with
+type ConflictError struct {
+	Conflicts []ScheduleConflict
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s: %d overlapping booking(s)", ErrScheduleConflict, len(e.Conflicts))
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ErrScheduleConflict
+}
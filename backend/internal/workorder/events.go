@@ -2,7 +2,10 @@
 package workorder
 
 import (
+    "context"
+    "log"
     "time"
+
     "github.com/google/uuid"
     "oilgas-backend/internal/shared/events"
 )
@@ -40,6 +43,118 @@ type WorkOrderStatusChangedEvent struct {
     Notes       string `json:"notes"`
 }
 
+func NewWorkOrderStatusChangedEvent(tenantID string, workOrderID int, oldStatus, newStatus WorkOrderStatus, changedBy int, notes string) *WorkOrderStatusChangedEvent {
+    return &WorkOrderStatusChangedEvent{
+        BaseEvent: events.BaseEvent{
+            ID:        uuid.New().String(),
+            Type:      "workorder.status_changed",
+            Tenant:    tenantID,
+            CreatedAt: time.Now(),
+        },
+        WorkOrderID: workOrderID,
+        OldStatus:   string(oldStatus),
+        NewStatus:   string(newStatus),
+        ChangedBy:   changedBy,
+        Notes:       notes,
+    }
+}
+
+// WorkOrderApprovalEvent is published when an approval chain finishes,
+// either with every level approved or with a rejection
+type WorkOrderApprovalEvent struct {
+    events.BaseEvent
+    WorkOrderID   int    `json:"work_order_id"`
+    ApprovalLevel int    `json:"approval_level"`
+    DecidedBy     int    `json:"decided_by_user_id"`
+    Comments      string `json:"comments"`
+}
+
+func NewWorkOrderApprovalEvent(tenantID string, workOrderID int, status ApprovalStatus, level, decidedBy int, comments string) *WorkOrderApprovalEvent {
+    eventType := "workorder.approved"
+    if status == ApprovalRejected {
+        eventType = "workorder.rejected"
+    }
+    return &WorkOrderApprovalEvent{
+        BaseEvent: events.BaseEvent{
+            ID:        uuid.New().String(),
+            Type:      eventType,
+            Tenant:    tenantID,
+            CreatedAt: time.Now(),
+        },
+        WorkOrderID:   workOrderID,
+        ApprovalLevel: level,
+        DecidedBy:     decidedBy,
+        Comments:      comments,
+    }
+}
+
+// WorkOrderApprovalDecidedEvent is published for every decision recorded on
+// an approval level, so a multi-level chain leaves a record of who decided
+// each level
+type WorkOrderApprovalDecidedEvent struct {
+    events.BaseEvent
+    WorkOrderID   int            `json:"work_order_id"`
+    ApprovalLevel int            `json:"approval_level"`
+    Status        ApprovalStatus `json:"status"`
+    DecidedBy     int            `json:"decided_by_user_id"`
+    Comments      string         `json:"comments"`
+    Final         bool           `json:"final"` // The decision finished the chain
+}
+
+func NewWorkOrderApprovalDecidedEvent(tenantID string, workOrderID int, status ApprovalStatus, level, decidedBy int, comments string, final bool) *WorkOrderApprovalDecidedEvent {
+    return &WorkOrderApprovalDecidedEvent{
+        BaseEvent: events.BaseEvent{
+            ID:        uuid.New().String(),
+            Type:      "workorder.approval_decided",
+            Tenant:    tenantID,
+            CreatedAt: time.Now(),
+        },
+        WorkOrderID:   workOrderID,
+        ApprovalLevel: level,
+        Status:        status,
+        DecidedBy:     decidedBy,
+        Comments:      comments,
+        Final:         final,
+    }
+}
+
+// WorkOrderEscalatedEvent is published when a work order becomes at risk of
+// missing, or misses, an SLA deadline
+type WorkOrderEscalatedEvent struct {
+    events.BaseEvent
+    WorkOrderID  int        `json:"work_order_id"`
+    RuleID       int        `json:"rule_id"`
+    RuleName     string     `json:"rule_name"`
+    State        SLAState   `json:"state"`
+    Deadline     time.Time  `json:"deadline"`
+    ActionTaken  *SLAAction `json:"action_taken"`
+    NewPriority  *Priority  `json:"new_priority"`
+    ReassignedTo *int       `json:"reassigned_to_user_id"`
+}
+
+func NewWorkOrderEscalatedEvent(tenantID string, escalation *Escalation, ruleName string) *WorkOrderEscalatedEvent {
+    eventType := "workorder.sla_at_risk"
+    if escalation.State == SLABreached {
+        eventType = "workorder.sla_breached"
+    }
+    return &WorkOrderEscalatedEvent{
+        BaseEvent: events.BaseEvent{
+            ID:        uuid.New().String(),
+            Type:      eventType,
+            Tenant:    tenantID,
+            CreatedAt: time.Now(),
+        },
+        WorkOrderID:  escalation.WorkOrderID,
+        RuleID:       escalation.RuleID,
+        RuleName:     ruleName,
+        State:        escalation.State,
+        Deadline:     escalation.Deadline,
+        ActionTaken:  escalation.ActionTaken,
+        NewPriority:  escalation.NewPriority,
+        ReassignedTo: escalation.ReassignedTo,
+    }
+}
+
 type WorkOrderItemCompletedEvent struct {
     events.BaseEvent
     WorkOrderID     int    `json:"work_order_id"`
@@ -49,6 +164,26 @@ type WorkOrderItemCompletedEvent struct {
     CompletedBy     int    `json:"completed_by_user_id"`
 }
 
+func NewWorkOrderItemCompletedEvent(tenantID string, item *WorkOrderItem, completedBy int) *WorkOrderItemCompletedEvent {
+    var notes string
+    if item.ServiceNotes != nil {
+        notes = *item.ServiceNotes
+    }
+    return &WorkOrderItemCompletedEvent{
+        BaseEvent: events.BaseEvent{
+            ID:        uuid.New().String(),
+            Type:      "workorder.item_completed",
+            Tenant:    tenantID,
+            CreatedAt: time.Now(),
+        },
+        WorkOrderID:     item.WorkOrderID,
+        ItemID:          item.ID,
+        InventoryItemID: item.InventoryItemID,
+        ServiceNotes:    notes,
+        CompletedBy:     completedBy,
+    }
+}
+
 type InvoiceGeneratedEvent struct {
     events.BaseEvent
     WorkOrderID int     `json:"work_order_id"`
@@ -57,10 +192,30 @@ type InvoiceGeneratedEvent struct {
     GeneratedBy int     `json:"generated_by_user_id"`
 }
 
+func NewInvoiceGeneratedEvent(tenantID string, workOrderID, invoiceID int, amount float64, generatedBy int) *InvoiceGeneratedEvent {
+    return &InvoiceGeneratedEvent{
+        BaseEvent: events.BaseEvent{
+            ID:        uuid.New().String(),
+            Type:      "invoice.generated",
+            Tenant:    tenantID,
+            CreatedAt: time.Now(),
+        },
+        WorkOrderID: workOrderID,
+        InvoiceID:   invoiceID,
+        Amount:      amount,
+        GeneratedBy: generatedBy,
+    }
+}
+
+// InventoryService is the part of the inventory domain work orders depend on
+type InventoryService interface {
+    UpdateItemStatus(ctx context.Context, tenantID string, inventoryItemID int, status string) error
+}
+
 // Event Handlers for Cross-Domain Coordination
 func RegisterWorkOrderEventHandlers(eventBus *events.EventBus, inventoryService InventoryService) {
     // When work order item is completed, update inventory status
-    eventBus.Subscribe("workorder.item.completed", func(ctx context.Context, event events.Event) error {
+    eventBus.Subscribe("workorder.item_completed", func(ctx context.Context, event events.Event) error {
         if itemEvent, ok := event.(*WorkOrderItemCompletedEvent); ok {
             if itemEvent.InventoryItemID != nil {
                 return inventoryService.UpdateItemStatus(ctx, event.TenantID(), 
@@ -0,0 +1,454 @@
+// backend/internal/workorder/handlers.go
+package workorder
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"oilgas-backend/internal/auth"
+)
+
+type Handlers struct {
+	service Service
+}
+
+func NewHandlers(service Service) *Handlers {
+	return &Handlers{service: service}
+}
+
+func (h *Handlers) RegisterRoutes(router *gin.RouterGroup, authMiddleware *auth.Middleware) {
+	staff := authMiddleware.RequireRole(auth.RoleOperator, auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin)
+	dispatchers := authMiddleware.RequireRole(auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin)
+
+	workOrders := router.Group("/workorders")
+	workOrders.Use(authMiddleware.RequireAuth())
+	workOrders.Use(authMiddleware.RequirePermission(auth.PermissionCreateWorkOrder))
+
+	workOrders.GET("", h.SearchWorkOrders)
+	workOrders.POST("", h.CreateWorkOrder)
+	workOrders.GET("/:id", h.GetWorkOrder)
+	workOrders.PUT("/:id", staff, h.UpdateWorkOrder)
+	workOrders.DELETE("/:id", staff, h.DeleteWorkOrder)
+	workOrders.POST("/:id/items", staff, h.AddItem)
+	workOrders.DELETE("/:id/items/:itemId", staff, h.RemoveItem)
+	workOrders.POST("/:id/items/:itemId/complete", staff, h.CompleteItem)
+	workOrders.POST("/:id/status", staff, h.TransitionStatus)
+	workOrders.PUT("/:id/assignment", dispatchers, h.AssignWorkOrder)
+	workOrders.GET("/:id/history", h.GetHistory)
+}
+
+func (h *Handlers) SearchWorkOrders(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	filters := SearchFilters{
+		ServiceType: ServiceType(strings.ToUpper(c.Query("service_type"))),
+		Priority:    Priority(strings.ToUpper(c.Query("priority"))),
+	}
+
+	if status := c.Query("status"); status != "" {
+		for _, s := range strings.Split(status, ",") {
+			filters.Status = append(filters.Status, WorkOrderStatus(strings.ToUpper(strings.TrimSpace(s))))
+		}
+	}
+
+	if customerID := c.Query("customer_id"); customerID != "" {
+		if id, err := strconv.Atoi(customerID); err == nil {
+			filters.CustomerID = &id
+		}
+	}
+
+	if assignedTo := c.Query("assigned_to_user_id"); assignedTo != "" {
+		if id, err := strconv.Atoi(assignedTo); err == nil {
+			filters.AssignedToUserID = &id
+		}
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filters.Limit = l
+		}
+	}
+
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filters.Offset = o
+		}
+	}
+
+	// Customer contacts only ever see their own company's work orders; staff
+	// only see the yards they have work order access to
+	if customerID := customerScope(c, user); customerID != nil {
+		filters.CustomerID = customerID
+	} else {
+		filters.YardLocations = workOrderYards(user, tenantID)
+	}
+
+	workOrders, total, err := h.service.SearchWorkOrders(c.Request.Context(), tenantID, filters)
+	if err != nil {
+		c.JSON(workOrderErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  workOrders,
+		"total": total,
+	})
+}
+
+func (h *Handlers) GetWorkOrder(c *gin.Context) {
+	wo, _, ok := h.authorizeWorkOrder(c, false)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, wo)
+}
+
+func (h *Handlers) CreateWorkOrder(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var wo WorkOrder
+	if err := c.ShouldBindJSON(&wo); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if customerID := customerScope(c, user); customerID != nil {
+		wo.CustomerID = *customerID
+	} else if wo.YardLocation != nil && !canUseWorkOrderYard(user, tenantID, *wo.YardLocation, true) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "No access to this yard"})
+		return
+	}
+
+	if err := h.service.CreateWorkOrder(c.Request.Context(), tenantID, user.ID, &wo); err != nil {
+		c.JSON(workOrderErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, wo)
+}
+
+func (h *Handlers) UpdateWorkOrder(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	existing, user, ok := h.authorizeWorkOrder(c, true)
+	if !ok {
+		return
+	}
+
+	var wo WorkOrder
+	if err := c.ShouldBindJSON(&wo); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	wo.ID = existing.ID
+	wo.CustomerID = existing.CustomerID
+
+	// Moving a work order needs access to the destination yard as well
+	if wo.YardLocation != nil && !canUseWorkOrderYard(user, tenantID, *wo.YardLocation, true) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "No access to this yard"})
+		return
+	}
+
+	if err := h.service.UpdateWorkOrder(c.Request.Context(), tenantID, user.ID, &wo); err != nil {
+		c.JSON(workOrderErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, wo)
+}
+
+func (h *Handlers) DeleteWorkOrder(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	wo, user, ok := h.authorizeWorkOrder(c, true)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteWorkOrder(c.Request.Context(), tenantID, user.ID, wo.ID); err != nil {
+		c.JSON(workOrderErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Work order deleted"})
+}
+
+func (h *Handlers) AddItem(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	wo, user, ok := h.authorizeWorkOrder(c, true)
+	if !ok {
+		return
+	}
+
+	var item WorkOrderItem
+	if err := c.ShouldBindJSON(&item); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.AddItem(c.Request.Context(), tenantID, user.ID, wo.ID, &item); err != nil {
+		c.JSON(workOrderErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, item)
+}
+
+func (h *Handlers) RemoveItem(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	wo, user, ok := h.authorizeWorkOrder(c, true)
+	if !ok {
+		return
+	}
+
+	itemID, err := strconv.Atoi(c.Param("itemId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		return
+	}
+
+	if err := h.service.RemoveItem(c.Request.Context(), tenantID, user.ID, wo.ID, itemID); err != nil {
+		c.JSON(workOrderErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Item removed"})
+}
+
+type CompleteItemRequest struct {
+	Notes string `json:"notes"`
+}
+
+func (h *Handlers) CompleteItem(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	wo, user, ok := h.authorizeWorkOrder(c, true)
+	if !ok {
+		return
+	}
+
+	itemID, err := strconv.Atoi(c.Param("itemId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		return
+	}
+
+	// Notes are optional, so an empty body is allowed
+	var req CompleteItemRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	item, err := h.service.CompleteItem(c.Request.Context(), tenantID, user.ID, wo.ID, itemID, req.Notes)
+	if err != nil {
+		c.JSON(workOrderErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+type StatusTransitionRequest struct {
+	Status WorkOrderStatus `json:"status" binding:"required"`
+	Notes  string          `json:"notes"`
+}
+
+func (h *Handlers) TransitionStatus(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	wo, user, ok := h.authorizeWorkOrder(c, true)
+	if !ok {
+		return
+	}
+
+	var req StatusTransitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated, err := h.service.TransitionStatus(c.Request.Context(), tenantID, user.ID, wo.ID, WorkOrderStatus(strings.ToUpper(string(req.Status))), req.Notes)
+	if err != nil {
+		c.JSON(workOrderErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+type AssignmentRequest struct {
+	AssignedToUserID *int `json:"assigned_to_user_id"`
+}
+
+func (h *Handlers) AssignWorkOrder(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	wo, user, ok := h.authorizeWorkOrder(c, true)
+	if !ok {
+		return
+	}
+
+	var req AssignmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated, err := h.service.AssignWorkOrder(c.Request.Context(), tenantID, user.ID, wo.ID, req.AssignedToUserID)
+	if err != nil {
+		c.JSON(workOrderErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+func (h *Handlers) GetHistory(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	wo, _, ok := h.authorizeWorkOrder(c, false)
+	if !ok {
+		return
+	}
+
+	history, err := h.service.GetHistory(c.Request.Context(), tenantID, wo.ID)
+	if err != nil {
+		c.JSON(workOrderErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  history,
+		"total": len(history),
+	})
+}
+
+// authorizeWorkOrder loads the work order named in the path and checks the
+// caller may see it, or change it when write is set. Work orders of another
+// customer are reported as not found so contacts cannot probe for them.
+func (h *Handlers) authorizeWorkOrder(c *gin.Context, write bool) (*WorkOrder, *auth.User, bool) {
+	tenantID := c.GetString("tenant_id")
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return nil, nil, false
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid work order ID"})
+		return nil, nil, false
+	}
+
+	wo, err := h.service.GetWorkOrder(c.Request.Context(), tenantID, id)
+	if err != nil {
+		c.JSON(workOrderErrorStatus(err), gin.H{"error": err.Error()})
+		return nil, nil, false
+	}
+
+	if customerID := customerScope(c, user); customerID != nil {
+		if wo.CustomerID != *customerID {
+			c.JSON(http.StatusNotFound, gin.H{"error": ErrWorkOrderNotFound.Error()})
+			return nil, nil, false
+		}
+		return wo, user, true
+	}
+
+	if wo.YardLocation != nil && !canUseWorkOrderYard(user, tenantID, *wo.YardLocation, write) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "No access to this yard"})
+		return nil, nil, false
+	}
+
+	return wo, user, true
+}
+
+// customerScope returns the customer a customer contact is limited to, using
+// the same customer_filter the customer handlers honour
+func customerScope(c *gin.Context, user *auth.User) *int {
+	if user.IsCustomerContact() {
+		customerID := *user.CustomerID
+		return &customerID
+	}
+	if customerID := c.GetInt("customer_filter"); customerID > 0 {
+		return &customerID
+	}
+	return nil
+}
+
+// canUseWorkOrderYard applies the per-yard work order flags on top of plain
+// yard access. Viewing needs either flag; changing needs create access.
+func canUseWorkOrderYard(user *auth.User, tenantID, yardLocation string, write bool) bool {
+	if !canAccessYard(user, tenantID, yardLocation) {
+		return false
+	}
+
+	switch user.Role {
+	case auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin:
+		return true
+	}
+
+	for _, access := range user.TenantAccess {
+		if access.TenantID != tenantID {
+			continue
+		}
+		for _, yard := range access.YardAccess {
+			if yard.YardLocation != yardLocation {
+				continue
+			}
+			if yard.CanCreateWorkOrders || (!write && yard.CanViewWorkOrders) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// workOrderYards lists the yards whose work orders the user may search, or
+// nil when the user sees every yard
+func workOrderYards(user *auth.User, tenantID string) []string {
+	switch user.Role {
+	case auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin:
+		return nil
+	}
+
+	yards := []string{}
+	for _, access := range user.TenantAccess {
+		if access.TenantID != tenantID {
+			continue
+		}
+		for _, yard := range access.YardAccess {
+			if yard.CanViewWorkOrders || yard.CanCreateWorkOrders {
+				yards = append(yards, yard.YardLocation)
+			}
+		}
+	}
+	return yards
+}
+
+func workOrderErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrWorkOrderNotFound), errors.Is(err, ErrWorkOrderItemNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidTransition), errors.Is(err, ErrStatusConflict),
+		errors.Is(err, ErrNotEditable), errors.Is(err, ErrNotDeletable),
+		errors.Is(err, ErrItemAlreadyCompleted), errors.Is(err, ErrItemNotCompletable),
+		errors.Is(err, ErrRescheduleRequired), errors.Is(err, ErrApprovalRequired),
+		errors.Is(err, ErrManagedByInvoicing), errors.Is(err, ErrChecklistIncomplete):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
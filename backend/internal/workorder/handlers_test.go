@@ -0,0 +1,250 @@
+// backend/internal/workorder/handlers_test.go
+package workorder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"oilgas-backend/internal/auth"
+)
+
+type mockService struct {
+	mock.Mock
+}
+
+func (m *mockService) GetWorkOrder(ctx context.Context, tenantID string, id int) (*WorkOrder, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*WorkOrder), args.Error(1)
+}
+
+func (m *mockService) SearchWorkOrders(ctx context.Context, tenantID string, filters SearchFilters) ([]WorkOrder, int, error) {
+	args := m.Called(ctx, tenantID, filters)
+	return args.Get(0).([]WorkOrder), args.Int(1), args.Error(2)
+}
+
+func (m *mockService) CreateWorkOrder(ctx context.Context, tenantID string, userID int, wo *WorkOrder) error {
+	args := m.Called(ctx, tenantID, userID, wo)
+	return args.Error(0)
+}
+
+func (m *mockService) UpdateWorkOrder(ctx context.Context, tenantID string, userID int, wo *WorkOrder) error {
+	args := m.Called(ctx, tenantID, userID, wo)
+	return args.Error(0)
+}
+
+func (m *mockService) TransitionStatus(ctx context.Context, tenantID string, userID, id int, to WorkOrderStatus, notes string) (*WorkOrder, error) {
+	args := m.Called(ctx, tenantID, userID, id, to, notes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*WorkOrder), args.Error(1)
+}
+
+func (m *mockService) DeleteWorkOrder(ctx context.Context, tenantID string, userID, id int) error {
+	args := m.Called(ctx, tenantID, userID, id)
+	return args.Error(0)
+}
+
+func (m *mockService) AssignWorkOrder(ctx context.Context, tenantID string, userID, id int, assigneeID *int) (*WorkOrder, error) {
+	args := m.Called(ctx, tenantID, userID, id, assigneeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*WorkOrder), args.Error(1)
+}
+
+func (m *mockService) AddItem(ctx context.Context, tenantID string, userID, workOrderID int, item *WorkOrderItem) error {
+	args := m.Called(ctx, tenantID, userID, workOrderID, item)
+	return args.Error(0)
+}
+
+func (m *mockService) RemoveItem(ctx context.Context, tenantID string, userID, workOrderID, itemID int) error {
+	args := m.Called(ctx, tenantID, userID, workOrderID, itemID)
+	return args.Error(0)
+}
+
+func (m *mockService) CompleteItem(ctx context.Context, tenantID string, userID, workOrderID, itemID int, notes string) (*WorkOrderItem, error) {
+	args := m.Called(ctx, tenantID, userID, workOrderID, itemID, notes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*WorkOrderItem), args.Error(1)
+}
+
+func (m *mockService) GetHistory(ctx context.Context, tenantID string, workOrderID int) ([]WorkOrderHistory, error) {
+	args := m.Called(ctx, tenantID, workOrderID)
+	return args.Get(0).([]WorkOrderHistory), args.Error(1)
+}
+
+// setupHandlerRouter registers the handlers behind a stand-in for
+// auth.Middleware that signs the given user in to the longbeach tenant
+func setupHandlerRouter(service Service, user *auth.User) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("tenant_id", "longbeach")
+		c.Set("user", user)
+		c.Set("user_id", user.ID)
+		c.Next()
+	})
+
+	h := NewHandlers(service)
+	router.GET("/workorders", h.SearchWorkOrders)
+	router.GET("/workorders/:id", h.GetWorkOrder)
+	router.DELETE("/workorders/:id", h.DeleteWorkOrder)
+	router.POST("/workorders/:id/status", h.TransitionStatus)
+	router.POST("/workorders/:id/items/:itemId/complete", h.CompleteItem)
+	return router
+}
+
+func yardOperator(yards ...auth.YardAccess) *auth.User {
+	return &auth.User{
+		ID:   7,
+		Role: auth.RoleOperator,
+		TenantAccess: []auth.TenantAccess{
+			{TenantID: "longbeach", Role: auth.RoleOperator, YardAccess: yards},
+		},
+	}
+}
+
+func customerContact(customerID int) *auth.User {
+	return &auth.User{
+		ID:           40,
+		Role:         auth.RoleCustomerContact,
+		CustomerID:   &customerID,
+		TenantAccess: []auth.TenantAccess{{TenantID: "longbeach", Role: auth.RoleCustomerContact}},
+	}
+}
+
+func yardWorkOrder(yard string) *WorkOrder {
+	return &WorkOrder{ID: 42, CustomerID: 3, Status: StatusInProgress, YardLocation: &yard}
+}
+
+func TestHandlers_CustomerContactCannotSeeOtherCustomers(t *testing.T) {
+	service := &mockService{}
+	service.On("GetWorkOrder", mock.Anything, "longbeach", 42).Return(yardWorkOrder("North"), nil)
+
+	router := setupHandlerRouter(service, customerContact(9))
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/workorders/42", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandlers_SearchScopesCallerToAllowedData(t *testing.T) {
+	testCases := []struct {
+		name    string
+		user    *auth.User
+		matches func(SearchFilters) bool
+	}{
+		{"customer contact", customerContact(3), func(f SearchFilters) bool {
+			return f.CustomerID != nil && *f.CustomerID == 3 && f.YardLocations == nil
+		}},
+		{"yard operator", yardOperator(
+			auth.YardAccess{YardLocation: "North", CanViewWorkOrders: true},
+			auth.YardAccess{YardLocation: "South", CanViewInventory: true},
+		), func(f SearchFilters) bool {
+			return len(f.YardLocations) == 1 && f.YardLocations[0] == "North"
+		}},
+		{"operator without yards", yardOperator(), func(f SearchFilters) bool {
+			return f.YardLocations != nil && len(f.YardLocations) == 0
+		}},
+		{"tenant admin", &auth.User{ID: 1, Role: auth.RoleAdmin}, func(f SearchFilters) bool {
+			return f.YardLocations == nil && f.CustomerID == nil
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := &mockService{}
+			service.On("SearchWorkOrders", mock.Anything, "longbeach", mock.MatchedBy(tc.matches)).
+				Return([]WorkOrder{}, 0, nil)
+
+			router := setupHandlerRouter(service, tc.user)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/workorders?status=approved,in_progress", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandlers_ViewOnlyYardCannotChangeStatus(t *testing.T) {
+	service := &mockService{}
+	service.On("GetWorkOrder", mock.Anything, "longbeach", 42).Return(yardWorkOrder("North"), nil)
+
+	router := setupHandlerRouter(service, yardOperator(auth.YardAccess{YardLocation: "North", CanViewWorkOrders: true}))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/workorders/42", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	body, _ := json.Marshal(StatusTransitionRequest{Status: StatusCompleted})
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/workorders/42/status", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	service.AssertNotCalled(t, "TransitionStatus")
+}
+
+func TestHandlers_CompleteItem(t *testing.T) {
+	service := &mockService{}
+	service.On("GetWorkOrder", mock.Anything, "longbeach", 42).Return(yardWorkOrder("North"), nil)
+	service.On("CompleteItem", mock.Anything, "longbeach", 7, 42, 5, "").
+		Return(&WorkOrderItem{ID: 5, WorkOrderID: 42, IsCompleted: true}, nil)
+
+	router := setupHandlerRouter(service, yardOperator(auth.YardAccess{YardLocation: "North", CanCreateWorkOrders: true}))
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/workorders/42/items/5/complete", nil)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var item WorkOrderItem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &item))
+	assert.True(t, item.IsCompleted)
+}
+
+func TestHandlers_ErrorStatuses(t *testing.T) {
+	testCases := []struct {
+		err    error
+		status int
+	}{
+		{fmt.Errorf("failed to get work order 42: %w", ErrWorkOrderNotFound), http.StatusNotFound},
+		{fmt.Errorf("%w: status is APPROVED", ErrNotDeletable), http.StatusConflict},
+		{fmt.Errorf("validation failed: quantity must be positive"), http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.err.Error(), func(t *testing.T) {
+			service := &mockService{}
+			service.On("GetWorkOrder", mock.Anything, "longbeach", 42).Return(&WorkOrder{ID: 42, Status: StatusDraft}, nil)
+			service.On("DeleteWorkOrder", mock.Anything, "longbeach", 1, 42).Return(tc.err)
+
+			router := setupHandlerRouter(service, &auth.User{ID: 1, Role: auth.RoleAdmin})
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("DELETE", "/workorders/42", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.status, w.Code)
+		})
+	}
+}
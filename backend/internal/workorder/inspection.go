@@ -0,0 +1,169 @@
+// backend/internal/workorder/inspection.go
+package workorder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// maxWallReading is a sanity bound on a single wall thickness reading, in
+// inches; anything thicker is a keying error
+const maxWallReading = 5.0
+
+type InspectionService interface {
+	GetInspectionResults(ctx context.Context, tenantID string, workOrderID int, itemID *int) ([]InspectionResult, error)
+	GetInspectionSummary(ctx context.Context, tenantID string, workOrderID int) (*InspectionSummary, error)
+
+	// RecordInspection saves the result for one joint, replacing any earlier
+	// result for it, and returns the work order's updated roll-up
+	RecordInspection(ctx context.Context, tenantID string, userID int, result *InspectionResult) (*InspectionSummary, error)
+	DeleteInspectionResult(ctx context.Context, tenantID string, workOrderID, id int) (*InspectionSummary, error)
+
+	// SegregateRejects removes an item's rejected joints from the
+	// customer's inventory count
+	SegregateRejects(ctx context.Context, tenantID string, userID, workOrderID, itemID int) (*Segregation, error)
+}
+
+type inspectionService struct {
+	inspections InspectionRepository
+}
+
+func NewInspectionService(inspections InspectionRepository) InspectionService {
+	return &inspectionService{inspections: inspections}
+}
+
+func (s *inspectionService) GetInspectionResults(ctx context.Context, tenantID string, workOrderID int, itemID *int) ([]InspectionResult, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.inspections.GetInspectionResults(ctx, tenantID, workOrderID, itemID)
+}
+
+func (s *inspectionService) GetInspectionSummary(ctx context.Context, tenantID string, workOrderID int) (*InspectionSummary, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.inspections.GetInspectionSummary(ctx, tenantID, workOrderID)
+}
+
+func (s *inspectionService) RecordInspection(ctx context.Context, tenantID string, userID int, result *InspectionResult) (*InspectionSummary, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if err := normalizeInspectionResult(result); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	result.InspectedByUserID = userID
+	return s.inspections.SaveInspectionResult(ctx, tenantID, result)
+}
+
+func (s *inspectionService) DeleteInspectionResult(ctx context.Context, tenantID string, workOrderID, id int) (*InspectionSummary, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.inspections.DeleteInspectionResult(ctx, tenantID, workOrderID, id)
+}
+
+func (s *inspectionService) SegregateRejects(ctx context.Context, tenantID string, userID, workOrderID, itemID int) (*Segregation, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	return s.inspections.SegregateRejects(ctx, tenantID, userID, workOrderID, itemID)
+}
+
+// normalizeInspectionResult checks a joint result against the reason code
+// rules and derives its minimum wall from the readings
+func normalizeInspectionResult(result *InspectionResult) error {
+	if result == nil {
+		return fmt.Errorf("inspection result is required")
+	}
+	if result.WorkOrderID <= 0 || result.WorkOrderItemID <= 0 {
+		return fmt.Errorf("work order and item are required")
+	}
+	if result.JointNumber <= 0 {
+		return fmt.Errorf("invalid joint number: %d", result.JointNumber)
+	}
+
+	switch result.Classification {
+	case InspectionPass:
+		if result.RejectReason != nil {
+			return fmt.Errorf("a passed joint cannot have a reject reason")
+		}
+	case InspectionRepair, InspectionReject:
+		if result.RejectReason == nil {
+			return fmt.Errorf("a reason code is required for %s", result.Classification)
+		}
+		if !isValidRejectReason(*result.RejectReason) {
+			return fmt.Errorf("invalid reason code: %s", *result.RejectReason)
+		}
+	default:
+		return fmt.Errorf("invalid classification: %s", result.Classification)
+	}
+
+	if result.ThreadCondition == "" {
+		result.ThreadCondition = ThreadNotInspected
+	}
+	if !isValidThreadCondition(result.ThreadCondition) {
+		return fmt.Errorf("invalid thread condition: %s", result.ThreadCondition)
+	}
+
+	result.MinWall = nil
+	for i, reading := range result.WallReadings {
+		if reading <= 0 || reading > maxWallReading {
+			return fmt.Errorf("wall reading %d out of range: %.3f", i+1, reading)
+		}
+		if result.MinWall == nil || reading < *result.MinWall {
+			min := reading
+			result.MinWall = &min
+		}
+	}
+
+	if result.PitDepth != nil && *result.PitDepth < 0 {
+		return fmt.Errorf("pit depth cannot be negative")
+	}
+	if result.PitDepth != nil && result.MinWall != nil && *result.PitDepth >= *result.MinWall {
+		return fmt.Errorf("pit depth %.3f is not less than the minimum wall %.3f", *result.PitDepth, *result.MinWall)
+	}
+
+	if result.Notes != nil {
+		notes := strings.TrimSpace(*result.Notes)
+		if len(notes) > 1000 {
+			return fmt.Errorf("notes too long (max 1000 characters)")
+		}
+		result.Notes = nullableString(notes)
+	}
+
+	return nil
+}
+
+func isValidRejectReason(reason RejectReason) bool {
+	switch reason {
+	case ReasonWallLoss, ReasonPitting, ReasonCorrosion, ReasonThreadDamage,
+		ReasonCrack, ReasonBent, ReasonDent, ReasonOther:
+		return true
+	}
+	return false
+}
+
+func isValidThreadCondition(condition ThreadCondition) bool {
+	switch condition {
+	case ThreadGood, ThreadMinorDamage, ThreadDamaged, ThreadNotInspected:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+// backend/internal/workorder/inspection_handlers.go
+package workorder
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"oilgas-backend/internal/auth"
+)
+
+type InspectionHandlers struct {
+	service InspectionService
+}
+
+func NewInspectionHandlers(service InspectionService) *InspectionHandlers {
+	return &InspectionHandlers{service: service}
+}
+
+func (h *InspectionHandlers) RegisterRoutes(router *gin.RouterGroup, authMiddleware *auth.Middleware) {
+	technicians := authMiddleware.RequireRole(auth.RoleOperator, auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin)
+
+	workOrders := router.Group("/workorders")
+	workOrders.Use(authMiddleware.RequireAuth())
+	workOrders.Use(technicians)
+
+	workOrders.GET("/:id/inspections", h.GetInspectionResults)
+	workOrders.GET("/:id/inspections/summary", h.GetInspectionSummary)
+	workOrders.DELETE("/:id/inspections/:resultId", h.DeleteInspectionResult)
+	workOrders.PUT("/:id/items/:itemId/joints/:joint", h.RecordInspection)
+	workOrders.POST("/:id/items/:itemId/segregate-rejects", h.SegregateRejects)
+}
+
+// GetInspectionResults lists a work order's joint results, optionally for
+// one item (?item_id=)
+func (h *InspectionHandlers) GetInspectionResults(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid work order ID"})
+		return
+	}
+
+	var itemID *int
+	if raw := c.Query("item_id"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+			return
+		}
+		itemID = &parsed
+	}
+
+	results, err := h.service.GetInspectionResults(c.Request.Context(), tenantID, id, itemID)
+	if err != nil {
+		c.JSON(inspectionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  results,
+		"total": len(results),
+	})
+}
+
+func (h *InspectionHandlers) GetInspectionSummary(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid work order ID"})
+		return
+	}
+
+	summary, err := h.service.GetInspectionSummary(c.Request.Context(), tenantID, id)
+	if err != nil {
+		c.JSON(inspectionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+type RecordInspectionRequest struct {
+	InventoryItemID *int            `json:"inventory_item_id"`
+	Classification  InspectionClass `json:"classification" binding:"required"`
+	RejectReason    *RejectReason   `json:"reject_reason"`
+	WallReadings    []float64       `json:"wall_readings"`
+	PitDepth        *float64        `json:"pit_depth"`
+	ThreadCondition ThreadCondition `json:"thread_condition"`
+	Notes           *string         `json:"notes"`
+}
+
+// RecordInspection sets the result for one joint of an item; recording the
+// same joint again replaces it
+func (h *InspectionHandlers) RecordInspection(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid work order ID"})
+		return
+	}
+	itemID, err := strconv.Atoi(c.Param("itemId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		return
+	}
+	joint, err := strconv.Atoi(c.Param("joint"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid joint number"})
+		return
+	}
+
+	var req RecordInspectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result := &InspectionResult{
+		WorkOrderID:     id,
+		WorkOrderItemID: itemID,
+		InventoryItemID: req.InventoryItemID,
+		JointNumber:     joint,
+		Classification:  req.Classification,
+		RejectReason:    req.RejectReason,
+		WallReadings:    req.WallReadings,
+		PitDepth:        req.PitDepth,
+		ThreadCondition: req.ThreadCondition,
+		Notes:           req.Notes,
+	}
+
+	summary, err := h.service.RecordInspection(c.Request.Context(), tenantID, c.GetInt("user_id"), result)
+	if err != nil {
+		c.JSON(inspectionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result":  result,
+		"summary": summary,
+	})
+}
+
+func (h *InspectionHandlers) DeleteInspectionResult(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid work order ID"})
+		return
+	}
+	resultID, err := strconv.Atoi(c.Param("resultId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid inspection result ID"})
+		return
+	}
+
+	summary, err := h.service.DeleteInspectionResult(c.Request.Context(), tenantID, id, resultID)
+	if err != nil {
+		c.JSON(inspectionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+func (h *InspectionHandlers) SegregateRejects(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid work order ID"})
+		return
+	}
+	itemID, err := strconv.Atoi(c.Param("itemId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		return
+	}
+
+	segregation, err := h.service.SegregateRejects(c.Request.Context(), tenantID, c.GetInt("user_id"), id, itemID)
+	if err != nil {
+		c.JSON(inspectionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, segregation)
+}
+
+func inspectionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrWorkOrderNotFound), errors.Is(err, ErrWorkOrderItemNotFound), errors.Is(err, ErrInspectionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrNotInspectable), errors.Is(err, ErrJointSegregated),
+		errors.Is(err, ErrNoRejectsToSegregate), errors.Is(err, ErrInsufficientJoints):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
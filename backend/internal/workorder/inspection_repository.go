@@ -0,0 +1,404 @@
+// backend/internal/workorder/inspection_repository.go
+package workorder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"oilgas-backend/internal/shared/database"
+)
+
+type InspectionRepository interface {
+	GetInspectionResults(ctx context.Context, tenantID string, workOrderID int, itemID *int) ([]InspectionResult, error)
+	GetInspectionSummary(ctx context.Context, tenantID string, workOrderID int) (*InspectionSummary, error)
+
+	// SaveInspectionResult records or replaces the result for one joint and
+	// refreshes the work order's roll-up counts
+	SaveInspectionResult(ctx context.Context, tenantID string, result *InspectionResult) (*InspectionSummary, error)
+	DeleteInspectionResult(ctx context.Context, tenantID string, workOrderID, id int) (*InspectionSummary, error)
+
+	// SegregateRejects takes an item's rejected joints out of the inventory
+	// they belong to and marks them segregated
+	SegregateRejects(ctx context.Context, tenantID string, userID, workOrderID, itemID int) (*Segregation, error)
+}
+
+type inspectionRepository struct {
+	dbManager *database.DatabaseManager
+}
+
+func NewInspectionRepository(dbManager *database.DatabaseManager) InspectionRepository {
+	return &inspectionRepository{dbManager: dbManager}
+}
+
+const inspectionColumns = `
+	id, workorder_id, workorder_item_id, inventory_item_id, joint_number,
+	classification, reject_reason, wall_readings, min_wall, pit_depth, thread_condition, notes,
+	segregated_at, inspected_by_user_id, inspected_at, updated_at`
+
+func scanInspectionResult(row rowScanner, r *InspectionResult) error {
+	return row.Scan(
+		&r.ID, &r.WorkOrderID, &r.WorkOrderItemID, &r.InventoryItemID, &r.JointNumber,
+		&r.Classification, &r.RejectReason, pq.Array(&r.WallReadings), &r.MinWall, &r.PitDepth, &r.ThreadCondition, &r.Notes,
+		&r.SegregatedAt, &r.InspectedByUserID, &r.InspectedAt, &r.UpdatedAt,
+	)
+}
+
+func (r *inspectionRepository) GetInspectionResults(ctx context.Context, tenantID string, workOrderID int, itemID *int) ([]InspectionResult, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	conditions := []string{"tenant_id = $1", "workorder_id = $2"}
+	args := []interface{}{tenantID, workOrderID}
+	if itemID != nil {
+		args = append(args, *itemID)
+		conditions = append(conditions, fmt.Sprintf("workorder_item_id = $%d", len(args)))
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT`+inspectionColumns+`
+		FROM store.inspection_results
+		WHERE `+strings.Join(conditions, " AND ")+`
+		ORDER BY workorder_item_id, joint_number`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inspection results: %w", err)
+	}
+	defer rows.Close()
+
+	results := []InspectionResult{}
+	for rows.Next() {
+		var result InspectionResult
+		if err := scanInspectionResult(rows, &result); err != nil {
+			return nil, fmt.Errorf("failed to scan inspection result: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
+
+func (r *inspectionRepository) GetInspectionSummary(ctx context.Context, tenantID string, workOrderID int) (*InspectionSummary, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	var exists bool
+	err = db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM store.workorders WHERE id = $1 AND tenant_id = $2 AND is_active = true)`,
+		workOrderID, tenantID).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work order: %w", err)
+	}
+	if !exists {
+		return nil, ErrWorkOrderNotFound
+	}
+
+	return inspectionSummary(ctx, db, workOrderID)
+}
+
+func (r *inspectionRepository) SaveInspectionResult(ctx context.Context, tenantID string, result *InspectionResult) (*InspectionSummary, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	status, err := lockWorkOrderStatusTx(ctx, tx, tenantID, result.WorkOrderID)
+	if err != nil {
+		return nil, err
+	}
+	if status != StatusInProgress {
+		return nil, fmt.Errorf("%w: status is %s", ErrNotInspectable, status)
+	}
+
+	var quantity int
+	var inventoryItemID *int
+	err = tx.QueryRowContext(ctx, `
+		SELECT quantity, inventory_item_id FROM store.workorder_items
+		WHERE id = $1 AND workorder_id = $2`,
+		result.WorkOrderItemID, result.WorkOrderID).Scan(&quantity, &inventoryItemID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrWorkOrderItemNotFound
+		}
+		return nil, fmt.Errorf("failed to get work order item: %w", err)
+	}
+	if result.JointNumber > quantity {
+		return nil, fmt.Errorf("validation failed: joint %d is beyond the item's %d joints", result.JointNumber, quantity)
+	}
+	if result.InventoryItemID == nil {
+		result.InventoryItemID = inventoryItemID
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO store.inspection_results (
+			tenant_id, workorder_id, workorder_item_id, inventory_item_id, joint_number,
+			classification, reject_reason, wall_readings, min_wall, pit_depth, thread_condition, notes,
+			inspected_by_user_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (workorder_item_id, joint_number) DO UPDATE SET
+			inventory_item_id = EXCLUDED.inventory_item_id,
+			classification = EXCLUDED.classification,
+			reject_reason = EXCLUDED.reject_reason,
+			wall_readings = EXCLUDED.wall_readings,
+			min_wall = EXCLUDED.min_wall,
+			pit_depth = EXCLUDED.pit_depth,
+			thread_condition = EXCLUDED.thread_condition,
+			notes = EXCLUDED.notes,
+			inspected_by_user_id = EXCLUDED.inspected_by_user_id,
+			inspected_at = NOW(),
+			updated_at = NOW()
+		WHERE store.inspection_results.segregated_at IS NULL
+		RETURNING`+inspectionColumns,
+		tenantID, result.WorkOrderID, result.WorkOrderItemID, result.InventoryItemID, result.JointNumber,
+		result.Classification, result.RejectReason, pq.Array(result.WallReadings), result.MinWall, result.PitDepth, result.ThreadCondition, result.Notes,
+		result.InspectedByUserID,
+	).Scan(
+		&result.ID, &result.WorkOrderID, &result.WorkOrderItemID, &result.InventoryItemID, &result.JointNumber,
+		&result.Classification, &result.RejectReason, pq.Array(&result.WallReadings), &result.MinWall, &result.PitDepth, &result.ThreadCondition, &result.Notes,
+		&result.SegregatedAt, &result.InspectedByUserID, &result.InspectedAt, &result.UpdatedAt,
+	)
+	if err != nil {
+		// The conflict update is skipped for a segregated joint
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: joint %d", ErrJointSegregated, result.JointNumber)
+		}
+		return nil, fmt.Errorf("failed to save inspection result: %w", err)
+	}
+
+	summary, err := refreshInspectionRollupTx(ctx, tx, tenantID, result.WorkOrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit inspection result: %w", err)
+	}
+
+	return summary, nil
+}
+
+func (r *inspectionRepository) DeleteInspectionResult(ctx context.Context, tenantID string, workOrderID, id int) (*InspectionSummary, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	status, err := lockWorkOrderStatusTx(ctx, tx, tenantID, workOrderID)
+	if err != nil {
+		return nil, err
+	}
+	if status != StatusInProgress {
+		return nil, fmt.Errorf("%w: status is %s", ErrNotInspectable, status)
+	}
+
+	var segregatedAt sql.NullTime
+	err = tx.QueryRowContext(ctx, `
+		DELETE FROM store.inspection_results
+		WHERE id = $1 AND workorder_id = $2 AND tenant_id = $3
+		RETURNING segregated_at`, id, workOrderID, tenantID).Scan(&segregatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrInspectionNotFound
+		}
+		return nil, fmt.Errorf("failed to delete inspection result: %w", err)
+	}
+	if segregatedAt.Valid {
+		return nil, ErrJointSegregated
+	}
+
+	summary, err := refreshInspectionRollupTx(ctx, tx, tenantID, workOrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit inspection result: %w", err)
+	}
+
+	return summary, nil
+}
+
+func (r *inspectionRepository) SegregateRejects(ctx context.Context, tenantID string, userID, workOrderID, itemID int) (*Segregation, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	status, err := lockWorkOrderStatusTx(ctx, tx, tenantID, workOrderID)
+	if err != nil {
+		return nil, err
+	}
+	if status != StatusInProgress && status != StatusCompleted {
+		return nil, fmt.Errorf("%w: status is %s", ErrNotInspectable, status)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, joint_number, inventory_item_id
+		FROM store.inspection_results
+		WHERE workorder_id = $1 AND workorder_item_id = $2 AND tenant_id = $3
+		  AND classification = 'REJECT' AND segregated_at IS NULL
+		ORDER BY joint_number
+		FOR UPDATE`, workOrderID, itemID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rejected joints: %w", err)
+	}
+
+	segregation := &Segregation{WorkOrderItemID: itemID, InventoryJoints: map[int]int{}}
+	var ids []int64
+	var unlinked []int
+	for rows.Next() {
+		var id int64
+		var joint int
+		var inventoryItemID *int
+		if err := rows.Scan(&id, &joint, &inventoryItemID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan rejected joint: %w", err)
+		}
+		if inventoryItemID == nil {
+			unlinked = append(unlinked, joint)
+			continue
+		}
+		ids = append(ids, id)
+		segregation.InventoryJoints[*inventoryItemID]++
+		segregation.Joints++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(unlinked) > 0 {
+		return nil, fmt.Errorf("validation failed: rejected joints %s are not linked to inventory", joinInts(unlinked))
+	}
+	if segregation.Joints == 0 {
+		return nil, ErrNoRejectsToSegregate
+	}
+
+	inventoryIDs := make([]int, 0, len(segregation.InventoryJoints))
+	for id := range segregation.InventoryJoints {
+		inventoryIDs = append(inventoryIDs, id)
+	}
+	sort.Ints(inventoryIDs)
+
+	for _, inventoryID := range inventoryIDs {
+		count := segregation.InventoryJoints[inventoryID]
+		res, err := tx.ExecContext(ctx, `
+			UPDATE store.inventory
+			SET joints = joints - $1
+			WHERE id = $2 AND tenant_id = $3 AND deleted = false AND joints >= $1`,
+			count, inventoryID, tenantID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update inventory %d: %w", inventoryID, err)
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return nil, fmt.Errorf("%w: inventory item %d, %d rejects", ErrInsufficientJoints, inventoryID, count)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE store.inspection_results SET segregated_at = NOW(), updated_at = NOW()
+		WHERE id = ANY($1)`, pq.Array(ids)); err != nil {
+		return nil, fmt.Errorf("failed to mark joints segregated: %w", err)
+	}
+
+	history := &WorkOrderHistory{
+		WorkOrderID:     workOrderID,
+		ChangedByUserID: userID,
+		Action:          "rejects_segregated",
+		NewValue:        stringPtr(fmt.Sprintf("%d", segregation.Joints)),
+		Notes:           stringPtr(fmt.Sprintf("Removed %d rejected joint(s) of item %d from inventory", segregation.Joints, itemID)),
+	}
+	if err := insertHistory(ctx, tx, history); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit segregation: %w", err)
+	}
+
+	return segregation, nil
+}
+
+// inspectionSummary counts a work order's joints by class and reason
+func inspectionSummary(ctx context.Context, q queryer, workOrderID int) (*InspectionSummary, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT classification, reject_reason, COUNT(*), COUNT(segregated_at)
+		FROM store.inspection_results
+		WHERE workorder_id = $1
+		GROUP BY classification, reject_reason`, workOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count inspection results: %w", err)
+	}
+	defer rows.Close()
+
+	summary := &InspectionSummary{WorkOrderID: workOrderID, ByReason: map[RejectReason]int{}}
+	for rows.Next() {
+		var class InspectionClass
+		var reason *RejectReason
+		var count, segregated int
+		if err := rows.Scan(&class, &reason, &count, &segregated); err != nil {
+			return nil, fmt.Errorf("failed to scan inspection counts: %w", err)
+		}
+
+		summary.Inspected += count
+		summary.Segregated += segregated
+		switch class {
+		case InspectionPass:
+			summary.Passed += count
+		case InspectionRepair:
+			summary.Repair += count
+		case InspectionReject:
+			summary.Rejected += count
+		}
+		if reason != nil {
+			summary.ByReason[*reason] += count
+		}
+	}
+
+	return summary, rows.Err()
+}
+
+// refreshInspectionRollupTx copies the current counts onto the work order
+func refreshInspectionRollupTx(ctx context.Context, tx *sql.Tx, tenantID string, workOrderID int) (*InspectionSummary, error) {
+	summary, err := inspectionSummary(ctx, tx, workOrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE store.workorders
+		SET joints_inspected = $1, joints_passed = $2, joints_repair = $3, joints_rejected = $4,
+		    updated_at = NOW()
+		WHERE id = $5 AND tenant_id = $6`,
+		summary.Inspected, summary.Passed, summary.Repair, summary.Rejected, workOrderID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update inspection counts: %w", err)
+	}
+
+	return summary, nil
+}
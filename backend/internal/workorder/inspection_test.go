@@ -0,0 +1,156 @@
+// backend/internal/workorder/inspection_test.go
+package workorder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type mockInspectionRepository struct {
+	mock.Mock
+}
+
+func (m *mockInspectionRepository) GetInspectionResults(ctx context.Context, tenantID string, workOrderID int, itemID *int) ([]InspectionResult, error) {
+	args := m.Called(ctx, tenantID, workOrderID, itemID)
+	return args.Get(0).([]InspectionResult), args.Error(1)
+}
+
+func (m *mockInspectionRepository) GetInspectionSummary(ctx context.Context, tenantID string, workOrderID int) (*InspectionSummary, error) {
+	args := m.Called(ctx, tenantID, workOrderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*InspectionSummary), args.Error(1)
+}
+
+func (m *mockInspectionRepository) SaveInspectionResult(ctx context.Context, tenantID string, result *InspectionResult) (*InspectionSummary, error) {
+	args := m.Called(ctx, tenantID, result)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*InspectionSummary), args.Error(1)
+}
+
+func (m *mockInspectionRepository) DeleteInspectionResult(ctx context.Context, tenantID string, workOrderID, id int) (*InspectionSummary, error) {
+	args := m.Called(ctx, tenantID, workOrderID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*InspectionSummary), args.Error(1)
+}
+
+func (m *mockInspectionRepository) SegregateRejects(ctx context.Context, tenantID string, userID, workOrderID, itemID int) (*Segregation, error) {
+	args := m.Called(ctx, tenantID, userID, workOrderID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Segregation), args.Error(1)
+}
+
+type InspectionServiceTestSuite struct {
+	suite.Suite
+	service     InspectionService
+	inspections *mockInspectionRepository
+	ctx         context.Context
+	tenantID    string
+}
+
+func (suite *InspectionServiceTestSuite) SetupTest() {
+	suite.inspections = &mockInspectionRepository{}
+	suite.service = NewInspectionService(suite.inspections)
+	suite.ctx = context.Background()
+	suite.tenantID = "longbeach"
+}
+
+func TestInspectionServiceSuite(t *testing.T) {
+	suite.Run(t, new(InspectionServiceTestSuite))
+}
+
+func rejectReason(reason RejectReason) *RejectReason {
+	return &reason
+}
+
+func (suite *InspectionServiceTestSuite) TestRecordInspection_DerivesMinimumWall() {
+	result := &InspectionResult{
+		WorkOrderID:     10,
+		WorkOrderItemID: 20,
+		JointNumber:     14,
+		Classification:  InspectionRepair,
+		RejectReason:    rejectReason(ReasonPitting),
+		WallReadings:    []float64{0.362, 0.341, 0.355},
+		PitDepth:        floatPtr(0.040),
+		Notes:           stringPtr("  pin end pitting  "),
+	}
+	summary := &InspectionSummary{WorkOrderID: 10, Inspected: 14, Passed: 13, Repair: 1}
+	suite.inspections.On("SaveInspectionResult", suite.ctx, suite.tenantID, result).Return(summary, nil)
+
+	got, err := suite.service.RecordInspection(suite.ctx, suite.tenantID, 7, result)
+
+	suite.NoError(err)
+	suite.Equal(summary, got)
+	suite.Equal(0.341, *result.MinWall)
+	suite.Equal(ThreadNotInspected, result.ThreadCondition)
+	suite.Equal("pin end pitting", *result.Notes)
+	suite.Equal(7, result.InspectedByUserID)
+}
+
+func (suite *InspectionServiceTestSuite) TestRecordInspection_RejectsInvalidResults() {
+	testCases := []struct {
+		name   string
+		result InspectionResult
+	}{
+		{"no classification", InspectionResult{}},
+		{"reject without reason", InspectionResult{Classification: InspectionReject}},
+		{"repair with unknown reason", InspectionResult{Classification: InspectionRepair, RejectReason: rejectReason("RUST")}},
+		{"pass with reason", InspectionResult{Classification: InspectionPass, RejectReason: rejectReason(ReasonDent)}},
+		{"negative joint", InspectionResult{Classification: InspectionPass, JointNumber: -1}},
+		{"negative reading", InspectionResult{Classification: InspectionPass, WallReadings: []float64{0.3, -0.3}}},
+		{"implausible reading", InspectionResult{Classification: InspectionPass, WallReadings: []float64{36}}},
+		{"pit through the wall", InspectionResult{Classification: InspectionReject, RejectReason: rejectReason(ReasonPitting), WallReadings: []float64{0.2}, PitDepth: floatPtr(0.25)}},
+		{"unknown thread condition", InspectionResult{Classification: InspectionPass, ThreadCondition: "FAIR"}},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			result := tc.result
+			result.WorkOrderID = 10
+			result.WorkOrderItemID = 20
+			if result.JointNumber == 0 {
+				result.JointNumber = 1
+			}
+
+			_, err := suite.service.RecordInspection(suite.ctx, suite.tenantID, 7, &result)
+
+			assert.Error(suite.T(), err)
+			assert.Contains(suite.T(), err.Error(), "validation failed")
+		})
+	}
+
+	suite.inspections.AssertNotCalled(suite.T(), "SaveInspectionResult")
+}
+
+func (suite *InspectionServiceTestSuite) TestRecordInspection_SegregatedJoint() {
+	result := &InspectionResult{WorkOrderID: 10, WorkOrderItemID: 20, JointNumber: 3, Classification: InspectionPass}
+	suite.inspections.On("SaveInspectionResult", suite.ctx, suite.tenantID, result).Return(nil, ErrJointSegregated)
+
+	_, err := suite.service.RecordInspection(suite.ctx, suite.tenantID, 7, result)
+
+	suite.ErrorIs(err, ErrJointSegregated)
+}
+
+func (suite *InspectionServiceTestSuite) TestSegregateRejects() {
+	segregation := &Segregation{WorkOrderItemID: 20, Joints: 3, InventoryJoints: map[int]int{501: 3}}
+	suite.inspections.On("SegregateRejects", suite.ctx, suite.tenantID, 7, 10, 20).Return(segregation, nil)
+
+	got, err := suite.service.SegregateRejects(suite.ctx, suite.tenantID, 7, 10, 20)
+
+	suite.NoError(err)
+	suite.Equal(segregation, got)
+
+	_, err = suite.service.SegregateRejects(suite.ctx, suite.tenantID, 0, 10, 20)
+	suite.Error(err)
+}
@@ -0,0 +1,410 @@
+// backend/internal/workorder/labor.go
+package workorder
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"oilgas-backend/internal/auth"
+)
+
+type LaborService interface {
+	GetTimeEntries(ctx context.Context, tenantID string, filters TimeEntryFilters) ([]TimeEntry, error)
+	GetCurrentTimeEntry(ctx context.Context, tenantID string, userID int) (*TimeEntry, error)
+
+	ClockIn(ctx context.Context, tenantID string, userID, workOrderID int, itemID *int, notes string) (*TimeEntry, error)
+	ClockOut(ctx context.Context, tenantID string, userID int, notes string) (*TimeEntry, error)
+	StartBreak(ctx context.Context, tenantID string, userID int) (*TimeEntry, error)
+	EndBreak(ctx context.Context, tenantID string, userID int) (*TimeEntry, error)
+
+	EditTimeEntry(ctx context.Context, tenantID string, user *auth.User, edit *TimeEntryEdit) (*TimeEntry, error)
+	DeleteTimeEntry(ctx context.Context, tenantID string, user *auth.User, id int, reason string) error
+}
+
+type laborService struct {
+	repo  Repository
+	labor LaborRepository
+	now   func() time.Time
+}
+
+func NewLaborService(repo Repository, labor LaborRepository) LaborService {
+	return &laborService{
+		repo:  repo,
+		labor: labor,
+		now:   time.Now,
+	}
+}
+
+func (s *laborService) GetTimeEntries(ctx context.Context, tenantID string, filters TimeEntryFilters) ([]TimeEntry, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if filters.Limit > 1000 {
+		return nil, fmt.Errorf("limit too large: %d (max 1000)", filters.Limit)
+	}
+
+	if filters.Offset < 0 {
+		return nil, fmt.Errorf("offset cannot be negative: %d", filters.Offset)
+	}
+
+	entries, err := s.labor.GetTimeEntries(ctx, tenantID, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (s *laborService) GetCurrentTimeEntry(ctx context.Context, tenantID string, userID int) (*TimeEntry, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.labor.GetOpenTimeEntry(ctx, tenantID, userID)
+}
+
+func (s *laborService) ClockIn(ctx context.Context, tenantID string, userID, workOrderID int, itemID *int, notes string) (*TimeEntry, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	wo, err := s.repo.GetWorkOrderByID(ctx, tenantID, workOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work order %d: %w", workOrderID, err)
+	}
+
+	if wo.Status != StatusInProgress {
+		return nil, fmt.Errorf("%w: status is %s", ErrLaborNotRecordable, wo.Status)
+	}
+
+	if itemID != nil {
+		if err := s.checkItem(ctx, tenantID, workOrderID, *itemID); err != nil {
+			return nil, err
+		}
+	}
+
+	entry := &TimeEntry{
+		WorkOrderID:     workOrderID,
+		WorkOrderItemID: itemID,
+		UserID:          userID,
+		ClockIn:         s.now(),
+		Notes:           nullableString(strings.TrimSpace(notes)),
+	}
+
+	if err := s.labor.CreateTimeEntry(ctx, tenantID, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (s *laborService) ClockOut(ctx context.Context, tenantID string, userID int, notes string) (*TimeEntry, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	entry, err := s.labor.GetOpenTimeEntry(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if entry.BreakStartedAt != nil {
+		entry.BreakMinutes += breakMinutes(*entry.BreakStartedAt, now)
+		entry.BreakStartedAt = nil
+	}
+	entry.ClockOut = &now
+
+	if notes = strings.TrimSpace(notes); notes != "" {
+		if entry.Notes != nil {
+			notes = *entry.Notes + "\n" + notes
+		}
+		entry.Notes = &notes
+	}
+
+	if err := s.labor.UpdateTimeEntry(ctx, tenantID, entry, nil); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (s *laborService) StartBreak(ctx context.Context, tenantID string, userID int) (*TimeEntry, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	entry, err := s.labor.GetOpenTimeEntry(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.BreakStartedAt != nil {
+		return nil, ErrBreakInProgress
+	}
+
+	now := s.now()
+	entry.BreakStartedAt = &now
+
+	if err := s.labor.UpdateTimeEntry(ctx, tenantID, entry, nil); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (s *laborService) EndBreak(ctx context.Context, tenantID string, userID int) (*TimeEntry, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	entry, err := s.labor.GetOpenTimeEntry(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.BreakStartedAt == nil {
+		return nil, ErrNoBreakInProgress
+	}
+
+	entry.BreakMinutes += breakMinutes(*entry.BreakStartedAt, s.now())
+	entry.BreakStartedAt = nil
+
+	if err := s.labor.UpdateTimeEntry(ctx, tenantID, entry, nil); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (s *laborService) EditTimeEntry(ctx context.Context, tenantID string, user *auth.User, edit *TimeEntryEdit) (*TimeEntry, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if edit == nil || edit.ID <= 0 {
+		return nil, fmt.Errorf("invalid time entry ID")
+	}
+
+	if !canManageLabor(user, tenantID) {
+		return nil, ErrNotLaborManager
+	}
+
+	reason := strings.TrimSpace(edit.Reason)
+	if reason == "" {
+		return nil, fmt.Errorf("validation failed: reason is required when editing a time entry")
+	}
+
+	entry, err := s.labor.GetTimeEntryByID(ctx, tenantID, edit.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *entry
+	if edit.ClockIn != nil {
+		updated.ClockIn = *edit.ClockIn
+	}
+	if edit.ClockOut != nil {
+		clockOut := *edit.ClockOut
+		updated.ClockOut = &clockOut
+		// Closing an open entry on someone's behalf ends any running break
+		if updated.BreakStartedAt != nil {
+			updated.BreakMinutes += breakMinutes(*updated.BreakStartedAt, clockOut)
+			updated.BreakStartedAt = nil
+		}
+	}
+	if edit.BreakMinutes != nil {
+		updated.BreakMinutes = *edit.BreakMinutes
+	}
+	if edit.WorkOrderItemID != nil {
+		if err := s.checkItem(ctx, tenantID, entry.WorkOrderID, *edit.WorkOrderItemID); err != nil {
+			return nil, err
+		}
+		updated.WorkOrderItemID = edit.WorkOrderItemID
+	}
+
+	if err := validateTimeEntry(&updated, s.now()); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	history := diffTimeEntry(entry, &updated, user.ID, reason)
+	if len(history) == 0 {
+		return entry, nil
+	}
+
+	now := s.now()
+	updated.EditedByUserID = &user.ID
+	updated.EditedAt = &now
+
+	if err := s.labor.UpdateTimeEntry(ctx, tenantID, &updated, history); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (s *laborService) DeleteTimeEntry(ctx context.Context, tenantID string, user *auth.User, id int, reason string) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if !canManageLabor(user, tenantID) {
+		return ErrNotLaborManager
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return fmt.Errorf("validation failed: reason is required when deleting a time entry")
+	}
+
+	entry, err := s.labor.GetTimeEntryByID(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+
+	history := &WorkOrderHistory{
+		ChangedByUserID: user.ID,
+		Action:          "time_entry_deleted",
+		OldValue:        stringPtr(formatTimeEntry(entry)),
+		Notes:           stringPtr(timeEntryNote(entry, reason)),
+	}
+
+	return s.labor.DeleteTimeEntry(ctx, tenantID, entry, history)
+}
+
+func (s *laborService) checkItem(ctx context.Context, tenantID string, workOrderID, itemID int) error {
+	items, err := s.repo.GetWorkOrderItems(ctx, tenantID, workOrderID)
+	if err != nil {
+		return fmt.Errorf("failed to get work order items: %w", err)
+	}
+
+	for _, item := range items {
+		if item.ID == itemID {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("validation failed: item %d does not belong to work order %d", itemID, workOrderID)
+}
+
+// canManageLabor allows managers and above, globally or within the tenant,
+// to correct other people's time
+func canManageLabor(user *auth.User, tenantID string) bool {
+	if user == nil {
+		return false
+	}
+
+	isManagerRole := func(role auth.UserRole) bool {
+		switch role {
+		case auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin:
+			return true
+		}
+		return false
+	}
+
+	if user.Role == auth.RoleSystemAdmin {
+		return true
+	}
+
+	if !user.CanAccessTenant(tenantID) {
+		return false
+	}
+
+	if isManagerRole(user.Role) {
+		return true
+	}
+
+	for _, access := range user.TenantAccess {
+		if access.TenantID == tenantID && isManagerRole(access.Role) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateTimeEntry(e *TimeEntry, now time.Time) error {
+	if e.ClockIn.After(now) {
+		return fmt.Errorf("clock in cannot be in the future")
+	}
+
+	if e.BreakMinutes < 0 {
+		return fmt.Errorf("break minutes cannot be negative")
+	}
+
+	if e.ClockOut == nil {
+		return nil
+	}
+
+	if e.ClockOut.After(now) {
+		return fmt.Errorf("clock out cannot be in the future")
+	}
+
+	if !e.ClockOut.After(e.ClockIn) {
+		return fmt.Errorf("clock out must be after clock in")
+	}
+
+	if time.Duration(e.BreakMinutes)*time.Minute >= e.ClockOut.Sub(e.ClockIn) {
+		return fmt.Errorf("breaks cannot exceed the time clocked")
+	}
+
+	if e.ClockOut.Sub(e.ClockIn) > 24*time.Hour {
+		return fmt.Errorf("time entries cannot span more than 24 hours")
+	}
+
+	return nil
+}
+
+func diffTimeEntry(old, updated *TimeEntry, userID int, reason string) []WorkOrderHistory {
+	var history []WorkOrderHistory
+	note := timeEntryNote(old, reason)
+
+	record := func(field, oldValue, newValue string) {
+		if oldValue == newValue {
+			return
+		}
+		history = append(history, WorkOrderHistory{
+			ChangedByUserID: userID,
+			Action:          "time_entry_" + field + "_changed",
+			OldValue:        nullableString(oldValue),
+			NewValue:        nullableString(newValue),
+			Notes:           stringPtr(note),
+		})
+	}
+
+	record("clock_in", formatTime(&old.ClockIn), formatTime(&updated.ClockIn))
+	record("clock_out", formatTime(old.ClockOut), formatTime(updated.ClockOut))
+	record("break_minutes", strconv.Itoa(old.BreakMinutes), strconv.Itoa(updated.BreakMinutes))
+	record("item", formatInt(old.WorkOrderItemID), formatInt(updated.WorkOrderItemID))
+
+	return history
+}
+
+func timeEntryNote(e *TimeEntry, reason string) string {
+	return fmt.Sprintf("Time entry %d for user %d: %s", e.ID, e.UserID, reason)
+}
+
+func formatTimeEntry(e *TimeEntry) string {
+	clockOut := formatTime(e.ClockOut)
+	if clockOut == "" {
+		clockOut = "open"
+	}
+	return fmt.Sprintf("%s to %s, %d min break", formatTime(&e.ClockIn), clockOut, e.BreakMinutes)
+}
+
+// breakMinutes rounds a break up to whole minutes so short breaks still count
+func breakMinutes(start, end time.Time) int {
+	if !end.After(start) {
+		return 0
+	}
+	return int(math.Ceil(end.Sub(start).Minutes()))
+}
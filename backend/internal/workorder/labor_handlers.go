@@ -0,0 +1,280 @@
+// backend/internal/workorder/labor_handlers.go
+package workorder
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"oilgas-backend/internal/auth"
+)
+
+type LaborHandlers struct {
+	service LaborService
+}
+
+func NewLaborHandlers(service LaborService) *LaborHandlers {
+	return &LaborHandlers{service: service}
+}
+
+func (h *LaborHandlers) RegisterRoutes(router *gin.RouterGroup, authMiddleware *auth.Middleware) {
+	technicians := authMiddleware.RequireRole(auth.RoleOperator, auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin)
+
+	workOrders := router.Group("/workorders")
+	workOrders.Use(authMiddleware.RequireAuth())
+
+	workOrders.GET("/:id/time-entries", technicians, h.GetWorkOrderTimeEntries)
+	workOrders.POST("/:id/clock-in", technicians, h.ClockIn)
+
+	entries := router.Group("/time-entries")
+	entries.Use(authMiddleware.RequireAuth())
+	entries.Use(technicians)
+
+	entries.GET("", h.GetTimeEntries)
+	entries.GET("/current", h.GetCurrentTimeEntry)
+	entries.POST("/clock-out", h.ClockOut)
+	entries.POST("/break/start", h.StartBreak)
+	entries.POST("/break/end", h.EndBreak)
+	entries.PUT("/:id", h.EditTimeEntry)
+	entries.DELETE("/:id", h.DeleteTimeEntry)
+}
+
+func (h *LaborHandlers) GetWorkOrderTimeEntries(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid work order ID"})
+		return
+	}
+
+	h.listTimeEntries(c, TimeEntryFilters{WorkOrderID: &id})
+}
+
+// GetTimeEntries lists time entries; technicians only ever see their own
+func (h *LaborHandlers) GetTimeEntries(c *gin.Context) {
+	filters := TimeEntryFilters{
+		OpenOnly: c.Query("open") == "true",
+	}
+
+	if workOrderID := c.Query("work_order_id"); workOrderID != "" {
+		if id, err := strconv.Atoi(workOrderID); err == nil {
+			filters.WorkOrderID = &id
+		}
+	}
+
+	if userID := c.Query("user_id"); userID != "" {
+		if id, err := strconv.Atoi(userID); err == nil {
+			filters.UserID = &id
+		}
+	}
+
+	if from := c.Query("from"); from != "" {
+		if t, err := time.Parse("2006-01-02", from); err == nil {
+			filters.From = &t
+		}
+	}
+
+	if to := c.Query("to"); to != "" {
+		if t, err := time.Parse("2006-01-02", to); err == nil {
+			end := t.AddDate(0, 0, 1)
+			filters.To = &end
+		}
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filters.Limit = l
+		}
+	}
+
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filters.Offset = o
+		}
+	}
+
+	h.listTimeEntries(c, filters)
+}
+
+func (h *LaborHandlers) listTimeEntries(c *gin.Context, filters TimeEntryFilters) {
+	tenantID := c.GetString("tenant_id")
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	if !canManageLabor(user, tenantID) {
+		filters.UserID = &user.ID
+	}
+
+	entries, err := h.service.GetTimeEntries(c.Request.Context(), tenantID, filters)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  entries,
+		"total": len(entries),
+	})
+}
+
+func (h *LaborHandlers) GetCurrentTimeEntry(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+
+	entry, err := h.service.GetCurrentTimeEntry(c.Request.Context(), tenantID, c.GetInt("user_id"))
+	if err != nil {
+		if errors.Is(err, ErrNotClockedIn) {
+			c.JSON(http.StatusOK, gin.H{"data": nil})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get current time entry"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": entry})
+}
+
+type ClockInRequest struct {
+	WorkOrderItemID *int   `json:"work_order_item_id"`
+	Notes           string `json:"notes"`
+}
+
+func (h *LaborHandlers) ClockIn(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid work order ID"})
+		return
+	}
+
+	var req ClockInRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	entry, err := h.service.ClockIn(c.Request.Context(), tenantID, c.GetInt("user_id"), id, req.WorkOrderItemID, req.Notes)
+	if err != nil {
+		c.JSON(laborErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+type ClockOutRequest struct {
+	Notes string `json:"notes"`
+}
+
+func (h *LaborHandlers) ClockOut(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+
+	var req ClockOutRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	entry, err := h.service.ClockOut(c.Request.Context(), tenantID, c.GetInt("user_id"), req.Notes)
+	if err != nil {
+		c.JSON(laborErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (h *LaborHandlers) StartBreak(c *gin.Context) {
+	entry, err := h.service.StartBreak(c.Request.Context(), c.GetString("tenant_id"), c.GetInt("user_id"))
+	if err != nil {
+		c.JSON(laborErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (h *LaborHandlers) EndBreak(c *gin.Context) {
+	entry, err := h.service.EndBreak(c.Request.Context(), c.GetString("tenant_id"), c.GetInt("user_id"))
+	if err != nil {
+		c.JSON(laborErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (h *LaborHandlers) EditTimeEntry(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time entry ID"})
+		return
+	}
+
+	var edit TimeEntryEdit
+	if err := c.ShouldBindJSON(&edit); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	edit.ID = id
+
+	entry, err := h.service.EditTimeEntry(c.Request.Context(), tenantID, user, &edit)
+	if err != nil {
+		c.JSON(laborErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (h *LaborHandlers) DeleteTimeEntry(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time entry ID"})
+		return
+	}
+
+	if err := h.service.DeleteTimeEntry(c.Request.Context(), tenantID, user, id, c.Query("reason")); err != nil {
+		c.JSON(laborErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Time entry deleted"})
+}
+
+func laborErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrWorkOrderNotFound), errors.Is(err, ErrTimeEntryNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrNotLaborManager):
+		return http.StatusForbidden
+	case errors.Is(err, ErrAlreadyClockedIn), errors.Is(err, ErrNotClockedIn),
+		errors.Is(err, ErrBreakInProgress), errors.Is(err, ErrNoBreakInProgress),
+		errors.Is(err, ErrTimeEntryConflict), errors.Is(err, ErrLaborNotRecordable):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,373 @@
+// backend/internal/workorder/labor_repository.go
+package workorder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"oilgas-backend/internal/shared/database"
+)
+
+type LaborRepository interface {
+	GetTimeEntries(ctx context.Context, tenantID string, filters TimeEntryFilters) ([]TimeEntry, error)
+	GetTimeEntryByID(ctx context.Context, tenantID string, id int) (*TimeEntry, error)
+	GetOpenTimeEntry(ctx context.Context, tenantID string, userID int) (*TimeEntry, error)
+
+	CreateTimeEntry(ctx context.Context, tenantID string, entry *TimeEntry) error
+	UpdateTimeEntry(ctx context.Context, tenantID string, entry *TimeEntry, history []WorkOrderHistory) error
+	DeleteTimeEntry(ctx context.Context, tenantID string, entry *TimeEntry, history *WorkOrderHistory) error
+}
+
+type laborRepository struct {
+	dbManager *database.DatabaseManager
+}
+
+func NewLaborRepository(dbManager *database.DatabaseManager) LaborRepository {
+	return &laborRepository{dbManager: dbManager}
+}
+
+const timeEntryColumns = `
+		id, tenant_id, workorder_id, workorder_item_id, user_id,
+		clock_in, clock_out, break_minutes, break_started_at, notes,
+		edited_by_user_id, edited_at, created_at, updated_at`
+
+func scanTimeEntry(row rowScanner, e *TimeEntry) error {
+	return row.Scan(
+		&e.ID, &e.TenantID, &e.WorkOrderID, &e.WorkOrderItemID, &e.UserID,
+		&e.ClockIn, &e.ClockOut, &e.BreakMinutes, &e.BreakStartedAt, &e.Notes,
+		&e.EditedByUserID, &e.EditedAt, &e.CreatedAt, &e.UpdatedAt,
+	)
+}
+
+func (r *laborRepository) GetTimeEntries(ctx context.Context, tenantID string, filters TimeEntryFilters) ([]TimeEntry, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", argIndex))
+	args = append(args, tenantID)
+	argIndex++
+
+	if filters.WorkOrderID != nil {
+		conditions = append(conditions, fmt.Sprintf("workorder_id = $%d", argIndex))
+		args = append(args, *filters.WorkOrderID)
+		argIndex++
+	}
+
+	if filters.UserID != nil {
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", argIndex))
+		args = append(args, *filters.UserID)
+		argIndex++
+	}
+
+	if filters.OpenOnly {
+		conditions = append(conditions, "clock_out IS NULL")
+	}
+
+	if filters.From != nil {
+		conditions = append(conditions, fmt.Sprintf("clock_in >= $%d", argIndex))
+		args = append(args, *filters.From)
+		argIndex++
+	}
+
+	if filters.To != nil {
+		conditions = append(conditions, fmt.Sprintf("clock_in < $%d", argIndex))
+		args = append(args, *filters.To)
+		argIndex++
+	}
+
+	query := fmt.Sprintf(`SELECT %s
+		FROM store.workorder_time_entries
+		WHERE %s
+		ORDER BY clock_in DESC
+		LIMIT $%d OFFSET $%d`, timeEntryColumns, strings.Join(conditions, " AND "), argIndex, argIndex+1)
+
+	limit := filters.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	args = append(args, limit, filters.Offset)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []TimeEntry
+	for rows.Next() {
+		var e TimeEntry
+		if err := scanTimeEntry(rows, &e); err != nil {
+			return nil, fmt.Errorf("failed to scan time entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
+
+func (r *laborRepository) GetTimeEntryByID(ctx context.Context, tenantID string, id int) (*TimeEntry, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	query := `SELECT ` + timeEntryColumns + `
+		FROM store.workorder_time_entries
+		WHERE id = $1 AND tenant_id = $2`
+
+	var e TimeEntry
+	if err := scanTimeEntry(db.QueryRowContext(ctx, query, id, tenantID), &e); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrTimeEntryNotFound
+		}
+		return nil, fmt.Errorf("failed to get time entry: %w", err)
+	}
+
+	return &e, nil
+}
+
+func (r *laborRepository) GetOpenTimeEntry(ctx context.Context, tenantID string, userID int) (*TimeEntry, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	query := `SELECT ` + timeEntryColumns + `
+		FROM store.workorder_time_entries
+		WHERE user_id = $1 AND tenant_id = $2 AND clock_out IS NULL`
+
+	var e TimeEntry
+	if err := scanTimeEntry(db.QueryRowContext(ctx, query, userID, tenantID), &e); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotClockedIn
+		}
+		return nil, fmt.Errorf("failed to get open time entry: %w", err)
+	}
+
+	return &e, nil
+}
+
+// CreateTimeEntry clocks a user in. The work order row is locked so the entry
+// cannot slip in while the work order is being moved out of IN_PROGRESS.
+func (r *laborRepository) CreateTimeEntry(ctx context.Context, tenantID string, entry *TimeEntry) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	status, err := lockWorkOrderStatusTx(ctx, tx, tenantID, entry.WorkOrderID)
+	if err != nil {
+		return err
+	}
+	if status != StatusInProgress {
+		return fmt.Errorf("%w: status is %s", ErrLaborNotRecordable, status)
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO store.workorder_time_entries (
+			tenant_id, workorder_id, workorder_item_id, user_id, clock_in, notes
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at`,
+		tenantID, entry.WorkOrderID, entry.WorkOrderItemID, entry.UserID, entry.ClockIn, entry.Notes,
+	).Scan(&entry.ID, &entry.CreatedAt, &entry.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return ErrAlreadyClockedIn
+		}
+		return fmt.Errorf("failed to create time entry: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit time entry: %w", err)
+	}
+
+	entry.TenantID = tenantID
+	return nil
+}
+
+// UpdateTimeEntry saves clock-outs, breaks and manager edits, then recomputes
+// the work order's actual hours. The update only applies if the entry has not
+// changed since it was read.
+func (r *laborRepository) UpdateTimeEntry(ctx context.Context, tenantID string, entry *TimeEntry, history []WorkOrderHistory) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	status, err := lockWorkOrderStatusTx(ctx, tx, tenantID, entry.WorkOrderID)
+	if err != nil {
+		return err
+	}
+	if !IsLaborEditable(status) {
+		return fmt.Errorf("%w: status is %s", ErrLaborNotRecordable, status)
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		UPDATE store.workorder_time_entries
+		SET workorder_item_id = $3, clock_in = $4, clock_out = $5,
+		    break_minutes = $6, break_started_at = $7, notes = $8,
+		    edited_by_user_id = $9, edited_at = $10, updated_at = NOW()
+		WHERE id = $1 AND tenant_id = $2 AND updated_at = $11
+		RETURNING updated_at`,
+		entry.ID, tenantID, entry.WorkOrderItemID, entry.ClockIn, entry.ClockOut,
+		entry.BreakMinutes, entry.BreakStartedAt, entry.Notes,
+		entry.EditedByUserID, entry.EditedAt, entry.UpdatedAt,
+	).Scan(&entry.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrTimeEntryConflict
+		}
+		return fmt.Errorf("failed to update time entry: %w", err)
+	}
+
+	if err := recomputeActualHoursTx(ctx, tx, tenantID, entry.WorkOrderID); err != nil {
+		return err
+	}
+
+	for i := range history {
+		history[i].WorkOrderID = entry.WorkOrderID
+		if err := insertHistory(ctx, tx, &history[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit time entry: %w", err)
+	}
+
+	return nil
+}
+
+func (r *laborRepository) DeleteTimeEntry(ctx context.Context, tenantID string, entry *TimeEntry, history *WorkOrderHistory) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	status, err := lockWorkOrderStatusTx(ctx, tx, tenantID, entry.WorkOrderID)
+	if err != nil {
+		return err
+	}
+	if !IsLaborEditable(status) {
+		return fmt.Errorf("%w: status is %s", ErrLaborNotRecordable, status)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		DELETE FROM store.workorder_time_entries
+		WHERE id = $1 AND tenant_id = $2 AND updated_at = $3`,
+		entry.ID, tenantID, entry.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to delete time entry: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check deleted rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrTimeEntryConflict
+	}
+
+	if err := recomputeActualHoursTx(ctx, tx, tenantID, entry.WorkOrderID); err != nil {
+		return err
+	}
+
+	if history != nil {
+		history.WorkOrderID = entry.WorkOrderID
+		if err := insertHistory(ctx, tx, history); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit time entry deletion: %w", err)
+	}
+
+	return nil
+}
+
+// lockWorkOrderStatusTx locks the work order row for the rest of the
+// transaction and returns its current status
+func lockWorkOrderStatusTx(ctx context.Context, tx *sql.Tx, tenantID string, id int) (WorkOrderStatus, error) {
+	var status WorkOrderStatus
+	err := tx.QueryRowContext(ctx, `
+		SELECT status FROM store.workorders
+		WHERE id = $1 AND tenant_id = $2 AND is_active = true
+		FOR UPDATE`, id, tenantID).Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", ErrWorkOrderNotFound
+		}
+		return "", fmt.Errorf("failed to lock work order: %w", err)
+	}
+	return status, nil
+}
+
+// closeOpenTimeEntriesTx clocks everyone out of a work order that is leaving
+// IN_PROGRESS, ending any running break at the same moment
+func closeOpenTimeEntriesTx(ctx context.Context, tx *sql.Tx, tenantID string, workOrderID int) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE store.workorder_time_entries
+		SET clock_out = NOW(),
+		    break_minutes = break_minutes + CASE
+		        WHEN break_started_at IS NULL THEN 0
+		        ELSE CEIL(EXTRACT(EPOCH FROM NOW() - break_started_at) / 60)::INTEGER
+		    END,
+		    break_started_at = NULL,
+		    updated_at = NOW()
+		WHERE workorder_id = $1 AND tenant_id = $2 AND clock_out IS NULL`,
+		workOrderID, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to close open time entries: %w", err)
+	}
+	return nil
+}
+
+// recomputeActualHoursTx sets actual_hours to the net hours of all closed
+// time entries on the work order
+func recomputeActualHoursTx(ctx context.Context, tx *sql.Tx, tenantID string, workOrderID int) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE store.workorders
+		SET actual_hours = (
+		        SELECT ROUND(COALESCE(SUM(GREATEST(
+		            EXTRACT(EPOCH FROM clock_out - clock_in) / 3600.0 - break_minutes / 60.0, 0
+		        )), 0)::NUMERIC, 2)
+		        FROM store.workorder_time_entries
+		        WHERE workorder_id = $1 AND clock_out IS NOT NULL
+		    ),
+		    updated_at = NOW()
+		WHERE id = $1 AND tenant_id = $2`,
+		workOrderID, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to recompute actual hours: %w", err)
+	}
+	return nil
+}
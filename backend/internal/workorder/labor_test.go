@@ -0,0 +1,288 @@
+// backend/internal/workorder/labor_test.go
+package workorder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"oilgas-backend/internal/auth"
+)
+
+type mockLaborRepository struct {
+	mock.Mock
+}
+
+func (m *mockLaborRepository) GetTimeEntries(ctx context.Context, tenantID string, filters TimeEntryFilters) ([]TimeEntry, error) {
+	args := m.Called(ctx, tenantID, filters)
+	return args.Get(0).([]TimeEntry), args.Error(1)
+}
+
+func (m *mockLaborRepository) GetTimeEntryByID(ctx context.Context, tenantID string, id int) (*TimeEntry, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*TimeEntry), args.Error(1)
+}
+
+func (m *mockLaborRepository) GetOpenTimeEntry(ctx context.Context, tenantID string, userID int) (*TimeEntry, error) {
+	args := m.Called(ctx, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*TimeEntry), args.Error(1)
+}
+
+func (m *mockLaborRepository) CreateTimeEntry(ctx context.Context, tenantID string, entry *TimeEntry) error {
+	args := m.Called(ctx, tenantID, entry)
+	if args.Error(0) == nil {
+		entry.ID = 77
+	}
+	return args.Error(0)
+}
+
+func (m *mockLaborRepository) UpdateTimeEntry(ctx context.Context, tenantID string, entry *TimeEntry, history []WorkOrderHistory) error {
+	args := m.Called(ctx, tenantID, entry, history)
+	return args.Error(0)
+}
+
+func (m *mockLaborRepository) DeleteTimeEntry(ctx context.Context, tenantID string, entry *TimeEntry, history *WorkOrderHistory) error {
+	args := m.Called(ctx, tenantID, entry, history)
+	return args.Error(0)
+}
+
+type LaborServiceTestSuite struct {
+	suite.Suite
+	service  *laborService
+	repo     *mockRepository
+	labor    *mockLaborRepository
+	ctx      context.Context
+	tenantID string
+	now      time.Time
+	manager  *auth.User
+}
+
+func (suite *LaborServiceTestSuite) SetupTest() {
+	suite.repo = &mockRepository{}
+	suite.labor = &mockLaborRepository{}
+	suite.now = time.Date(2025, time.June, 3, 16, 0, 0, 0, time.UTC)
+	suite.service = &laborService{
+		repo:  suite.repo,
+		labor: suite.labor,
+		now:   func() time.Time { return suite.now },
+	}
+	suite.ctx = context.Background()
+	suite.tenantID = "longbeach"
+	suite.manager = &auth.User{
+		ID:   9,
+		Role: auth.RoleOperator,
+		TenantAccess: auth.TenantAccessList{
+			{TenantID: "longbeach", Role: auth.RoleManager},
+		},
+	}
+}
+
+func TestLaborServiceSuite(t *testing.T) {
+	suite.Run(t, new(LaborServiceTestSuite))
+}
+
+func (suite *LaborServiceTestSuite) TestClockIn_Success() {
+	itemID := 3
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 10).
+		Return(&WorkOrder{ID: 10, Status: StatusInProgress}, nil)
+	suite.repo.On("GetWorkOrderItems", suite.ctx, suite.tenantID, 10).
+		Return([]WorkOrderItem{{ID: 3, WorkOrderID: 10}}, nil)
+	suite.labor.On("CreateTimeEntry", suite.ctx, suite.tenantID, mock.MatchedBy(func(e *TimeEntry) bool {
+		return e.WorkOrderID == 10 && e.UserID == 5 && *e.WorkOrderItemID == 3 && e.ClockIn.Equal(suite.now)
+	})).Return(nil)
+
+	entry, err := suite.service.ClockIn(suite.ctx, suite.tenantID, 5, 10, &itemID, "")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 77, entry.ID)
+	assert.Nil(suite.T(), entry.Notes)
+}
+
+func (suite *LaborServiceTestSuite) TestClockIn_WorkOrderNotInProgress() {
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 10).
+		Return(&WorkOrder{ID: 10, Status: StatusApproved}, nil)
+
+	_, err := suite.service.ClockIn(suite.ctx, suite.tenantID, 5, 10, nil, "")
+
+	assert.True(suite.T(), errors.Is(err, ErrLaborNotRecordable))
+	suite.labor.AssertNotCalled(suite.T(), "CreateTimeEntry")
+}
+
+func (suite *LaborServiceTestSuite) TestClockIn_ItemFromAnotherWorkOrder() {
+	itemID := 99
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 10).
+		Return(&WorkOrder{ID: 10, Status: StatusInProgress}, nil)
+	suite.repo.On("GetWorkOrderItems", suite.ctx, suite.tenantID, 10).
+		Return([]WorkOrderItem{{ID: 3, WorkOrderID: 10}}, nil)
+
+	_, err := suite.service.ClockIn(suite.ctx, suite.tenantID, 5, 10, &itemID, "")
+
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "does not belong to work order 10")
+}
+
+func (suite *LaborServiceTestSuite) TestClockOut_EndsRunningBreak() {
+	breakStart := suite.now.Add(-10*time.Minute - 20*time.Second)
+	open := &TimeEntry{
+		ID: 77, WorkOrderID: 10, UserID: 5,
+		ClockIn:        suite.now.Add(-4 * time.Hour),
+		BreakMinutes:   30,
+		BreakStartedAt: &breakStart,
+	}
+	suite.labor.On("GetOpenTimeEntry", suite.ctx, suite.tenantID, 5).Return(open, nil)
+	suite.labor.On("UpdateTimeEntry", suite.ctx, suite.tenantID, open, []WorkOrderHistory(nil)).Return(nil)
+
+	entry, err := suite.service.ClockOut(suite.ctx, suite.tenantID, 5, "Finished threads")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), suite.now, *entry.ClockOut)
+	assert.Nil(suite.T(), entry.BreakStartedAt)
+	assert.Equal(suite.T(), 41, entry.BreakMinutes)
+	assert.Equal(suite.T(), "Finished threads", *entry.Notes)
+	assert.InDelta(suite.T(), 4.0-41.0/60.0, entry.Hours(), 0.0001)
+}
+
+func (suite *LaborServiceTestSuite) TestClockOut_NotClockedIn() {
+	suite.labor.On("GetOpenTimeEntry", suite.ctx, suite.tenantID, 5).Return(nil, ErrNotClockedIn)
+
+	_, err := suite.service.ClockOut(suite.ctx, suite.tenantID, 5, "")
+
+	assert.True(suite.T(), errors.Is(err, ErrNotClockedIn))
+}
+
+func (suite *LaborServiceTestSuite) TestBreaks() {
+	open := &TimeEntry{ID: 77, WorkOrderID: 10, UserID: 5, ClockIn: suite.now.Add(-2 * time.Hour)}
+	suite.labor.On("GetOpenTimeEntry", suite.ctx, suite.tenantID, 5).Return(open, nil)
+	suite.labor.On("UpdateTimeEntry", suite.ctx, suite.tenantID, open, []WorkOrderHistory(nil)).Return(nil)
+
+	_, err := suite.service.EndBreak(suite.ctx, suite.tenantID, 5)
+	assert.True(suite.T(), errors.Is(err, ErrNoBreakInProgress))
+
+	_, err = suite.service.StartBreak(suite.ctx, suite.tenantID, 5)
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.service.StartBreak(suite.ctx, suite.tenantID, 5)
+	assert.True(suite.T(), errors.Is(err, ErrBreakInProgress))
+
+	suite.now = suite.now.Add(15 * time.Minute)
+	entry, err := suite.service.EndBreak(suite.ctx, suite.tenantID, 5)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 15, entry.BreakMinutes)
+	assert.Nil(suite.T(), entry.BreakStartedAt)
+}
+
+func (suite *LaborServiceTestSuite) TestEditTimeEntry_RecordsHistory() {
+	clockIn := suite.now.Add(-8 * time.Hour)
+	clockOut := suite.now.Add(-time.Hour)
+	existing := &TimeEntry{ID: 77, WorkOrderID: 10, UserID: 5, ClockIn: clockIn, ClockOut: &clockOut, BreakMinutes: 30}
+	suite.labor.On("GetTimeEntryByID", suite.ctx, suite.tenantID, 77).Return(existing, nil)
+
+	correctedOut := suite.now.Add(-30 * time.Minute)
+	suite.labor.On("UpdateTimeEntry", suite.ctx, suite.tenantID, mock.MatchedBy(func(e *TimeEntry) bool {
+		return e.ClockOut.Equal(correctedOut) && e.BreakMinutes == 30 && *e.EditedByUserID == 9
+	}), mock.MatchedBy(func(history []WorkOrderHistory) bool {
+		return len(history) == 1 &&
+			history[0].Action == "time_entry_clock_out_changed" &&
+			history[0].ChangedByUserID == 9 &&
+			*history[0].Notes == "Time entry 77 for user 5: Forgot to clock out"
+	})).Return(nil)
+
+	entry, err := suite.service.EditTimeEntry(suite.ctx, suite.tenantID, suite.manager, &TimeEntryEdit{
+		ID:       77,
+		ClockOut: &correctedOut,
+		Reason:   "Forgot to clock out",
+	})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), correctedOut, *entry.ClockOut)
+	assert.Equal(suite.T(), clockOut, *existing.ClockOut, "original entry must not be mutated")
+	suite.labor.AssertExpectations(suite.T())
+}
+
+func (suite *LaborServiceTestSuite) TestEditTimeEntry_RequiresManager() {
+	technician := &auth.User{
+		ID:           5,
+		Role:         auth.RoleOperator,
+		TenantAccess: auth.TenantAccessList{{TenantID: "longbeach", Role: auth.RoleOperator}},
+	}
+
+	_, err := suite.service.EditTimeEntry(suite.ctx, suite.tenantID, technician, &TimeEntryEdit{ID: 77, Reason: "x"})
+
+	assert.True(suite.T(), errors.Is(err, ErrNotLaborManager))
+	suite.labor.AssertNotCalled(suite.T(), "GetTimeEntryByID")
+}
+
+func (suite *LaborServiceTestSuite) TestEditTimeEntry_Validation() {
+	clockIn := suite.now.Add(-8 * time.Hour)
+	clockOut := suite.now.Add(-time.Hour)
+	suite.labor.On("GetTimeEntryByID", suite.ctx, suite.tenantID, 77).
+		Return(&TimeEntry{ID: 77, WorkOrderID: 10, UserID: 5, ClockIn: clockIn, ClockOut: &clockOut}, nil)
+
+	future := suite.now.Add(time.Hour)
+	beforeIn := clockIn.Add(-time.Minute)
+	tooLongBreak := 8 * 60
+	negativeBreak := -5
+
+	testCases := []struct {
+		name        string
+		edit        TimeEntryEdit
+		expectError string
+	}{
+		{"missing reason", TimeEntryEdit{ID: 77}, "reason is required"},
+		{"clock out in future", TimeEntryEdit{ID: 77, ClockOut: &future, Reason: "fix"}, "cannot be in the future"},
+		{"clock out before clock in", TimeEntryEdit{ID: 77, ClockOut: &beforeIn, Reason: "fix"}, "must be after clock in"},
+		{"break longer than shift", TimeEntryEdit{ID: 77, BreakMinutes: &tooLongBreak, Reason: "fix"}, "breaks cannot exceed"},
+		{"negative break", TimeEntryEdit{ID: 77, BreakMinutes: &negativeBreak, Reason: "fix"}, "cannot be negative"},
+	}
+
+	for _, tc := range testCases {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			edit := tc.edit
+			_, err := suite.service.EditTimeEntry(suite.ctx, suite.tenantID, suite.manager, &edit)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectError)
+		})
+	}
+
+	suite.labor.AssertNotCalled(suite.T(), "UpdateTimeEntry")
+}
+
+func (suite *LaborServiceTestSuite) TestDeleteTimeEntry() {
+	clockOut := suite.now.Add(-time.Hour)
+	existing := &TimeEntry{ID: 77, WorkOrderID: 10, UserID: 5, ClockIn: suite.now.Add(-3 * time.Hour), ClockOut: &clockOut}
+	suite.labor.On("GetTimeEntryByID", suite.ctx, suite.tenantID, 77).Return(existing, nil)
+	suite.labor.On("DeleteTimeEntry", suite.ctx, suite.tenantID, existing, mock.MatchedBy(func(h *WorkOrderHistory) bool {
+		return h.Action == "time_entry_deleted" && h.ChangedByUserID == 9 && h.OldValue != nil
+	})).Return(nil)
+
+	err := suite.service.DeleteTimeEntry(suite.ctx, suite.tenantID, suite.manager, 77, "Duplicate entry")
+
+	assert.NoError(suite.T(), err)
+	suite.labor.AssertExpectations(suite.T())
+}
+
+func TestTimeEntryHours(t *testing.T) {
+	clockIn := time.Date(2025, time.June, 3, 7, 0, 0, 0, time.UTC)
+	clockOut := clockIn.Add(9 * time.Hour)
+
+	assert.Equal(t, 0.0, (&TimeEntry{ClockIn: clockIn}).Hours())
+	assert.Equal(t, 8.5, (&TimeEntry{ClockIn: clockIn, ClockOut: &clockOut, BreakMinutes: 30}).Hours())
+}
+
+func TestIsLaborEditable(t *testing.T) {
+	assert.True(t, IsLaborEditable(StatusInProgress))
+	assert.True(t, IsLaborEditable(StatusCompleted))
+	assert.False(t, IsLaborEditable(StatusInvoiced))
+	assert.False(t, IsLaborEditable(StatusCancelled))
+}
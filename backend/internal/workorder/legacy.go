@@ -0,0 +1,324 @@
+// backend/internal/workorder/legacy.go
+package workorder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"oilgas-backend/internal/models"
+)
+
+// LegacyImporter promotes rows of the imported Access received log into
+// structured work orders, one per legacy work order number
+type LegacyImporter interface {
+	Import(ctx context.Context, tenantID string, userID int, serviceType ServiceType, dryRun bool) (*LegacyImportReport, error)
+}
+
+type legacyImporter struct {
+	legacy LegacyRepository
+}
+
+func NewLegacyImporter(legacy LegacyRepository) LegacyImporter {
+	return &legacyImporter{legacy: legacy}
+}
+
+func (s *legacyImporter) Import(ctx context.Context, tenantID string, userID int, serviceType ServiceType, dryRun bool) (*LegacyImportReport, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if !isValidServiceType(serviceType) {
+		return nil, fmt.Errorf("invalid service type: %s", serviceType)
+	}
+
+	rows, err := s.legacy.GetReceivedItems(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get legacy received rows: %w", err)
+	}
+
+	customers, err := s.legacy.GetCustomerMapping(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer mapping: %w", err)
+	}
+
+	jobs, unresolved := planLegacyJobs(rows, customers, serviceType)
+
+	report := &LegacyImportReport{
+		DryRun:       dryRun,
+		Rows:         len(rows),
+		Jobs:         len(jobs),
+		StatusCounts: map[WorkOrderStatus]int{},
+		Unresolved:   unresolved,
+	}
+
+	for i := range jobs {
+		job := &jobs[i]
+		job.WorkOrder.TenantID = tenantID
+		job.WorkOrder.CreatedByUserID = userID
+
+		if dryRun {
+			report.Imported++
+			report.Items += len(job.WorkOrder.Items)
+			report.StatusCounts[job.WorkOrder.Status]++
+			continue
+		}
+
+		linked, err := s.legacy.ImportLegacyJob(ctx, tenantID, userID, job)
+		switch {
+		case errors.Is(err, ErrLegacyAlreadyImported):
+			report.AlreadyImported++
+			continue
+		case errors.Is(err, ErrLegacyNumberTaken):
+			for _, id := range job.ReceivedIDs {
+				report.Unresolved = append(report.Unresolved, LegacyUnresolved{
+					ReceivedID: id,
+					WorkOrder:  job.Number,
+					Reason:     "work order number is already used by another work order",
+				})
+			}
+			continue
+		case err != nil:
+			return report, fmt.Errorf("failed to import legacy work order %s: %w", job.Number, err)
+		}
+
+		report.Imported++
+		report.Items += len(job.WorkOrder.Items)
+		report.LinkedInventory += linked
+		report.StatusCounts[job.WorkOrder.Status]++
+	}
+
+	// Legacy numbers can share the allocator's format, so move the counter
+	// past them before anyone creates a new work order
+	if !dryRun && report.Imported > 0 {
+		if err := s.legacy.AdvanceWorkOrderSequence(ctx, tenantID); err != nil {
+			return report, fmt.Errorf("failed to advance work order numbering: %w", err)
+		}
+	}
+
+	return report, nil
+}
+
+// planLegacyJobs groups received rows by normalized work order number and
+// builds the work order each group becomes. customers maps legacy customer
+// IDs to migrated store.customers IDs.
+func planLegacyJobs(rows []models.ReceivedItem, customers map[int]int, serviceType ServiceType) ([]LegacyJob, []LegacyUnresolved) {
+	var unresolved []LegacyUnresolved
+	groups := map[string][]models.ReceivedItem{}
+
+	for _, row := range rows {
+		number := normalizeLegacyNumber(row.WorkOrder)
+		if number == "" {
+			unresolved = append(unresolved, LegacyUnresolved{ReceivedID: row.ID, Reason: "missing work order number"})
+			continue
+		}
+		groups[number] = append(groups[number], row)
+	}
+
+	numbers := make([]string, 0, len(groups))
+	for number := range groups {
+		numbers = append(numbers, number)
+	}
+	sort.Strings(numbers)
+
+	var jobs []LegacyJob
+	for _, number := range numbers {
+		group := groups[number]
+
+		reject := func(reason string) {
+			for _, row := range group {
+				unresolved = append(unresolved, LegacyUnresolved{ReceivedID: row.ID, WorkOrder: number, Reason: reason})
+			}
+		}
+
+		legacyCustomers := map[int]bool{}
+		for _, row := range group {
+			if row.CustomerID != nil {
+				legacyCustomers[*row.CustomerID] = true
+			}
+		}
+		if len(legacyCustomers) == 0 {
+			reject("no customer on any row")
+			continue
+		}
+		if len(legacyCustomers) > 1 {
+			ids := make([]int, 0, len(legacyCustomers))
+			for id := range legacyCustomers {
+				ids = append(ids, id)
+			}
+			sort.Ints(ids)
+			reject(fmt.Sprintf("rows belong to different customers %s", joinInts(ids)))
+			continue
+		}
+
+		// Rows without a customer inherit the one the rest of the job names
+		var legacyCustomerID int
+		for id := range legacyCustomers {
+			legacyCustomerID = id
+		}
+		customerID, ok := customers[legacyCustomerID]
+		if !ok {
+			reject(fmt.Sprintf("customer %d has not been migrated", legacyCustomerID))
+			continue
+		}
+
+		job := LegacyJob{Number: number}
+		for _, row := range group {
+			if row.Joints == nil || *row.Joints <= 0 {
+				unresolved = append(unresolved, LegacyUnresolved{ReceivedID: row.ID, WorkOrder: number, Reason: "missing joint count"})
+				continue
+			}
+
+			job.WorkOrder.Items = append(job.WorkOrder.Items, WorkOrderItem{
+				Description:  legacyItemDescription(row),
+				Quantity:     *row.Joints,
+				ServiceNotes: trimmedOrNil(row.Notes),
+				IsCompleted:  row.Complete,
+			})
+			job.ReceivedIDs = append(job.ReceivedIDs, row.ID)
+		}
+		if len(job.ReceivedIDs) == 0 {
+			continue
+		}
+
+		receivedAt := legacyReceivedAt(group)
+		status := deriveLegacyStatus(group)
+		job.WorkOrder.CustomerID = customerID
+		job.WorkOrder.WorkOrderNumber = number
+		job.WorkOrder.ServiceType = serviceType
+		job.WorkOrder.Status = status
+		job.WorkOrder.Priority = PriorityMedium
+		job.WorkOrder.Description = legacyDescription(number, group)
+		job.WorkOrder.IsActive = true
+		job.WorkOrder.CreatedAt = receivedAt
+		// The received log never recorded when work finished, so only the
+		// start is back-filled
+		if status != StatusApproved {
+			job.WorkOrder.StartedAt = &receivedAt
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, unresolved
+}
+
+// deriveLegacyStatus maps the received log's two flags onto the lifecycle:
+// everything complete is COMPLETED, any progress is IN_PROGRESS, and pipe
+// that was only received is treated as approved work waiting to start
+func deriveLegacyStatus(rows []models.ReceivedItem) WorkOrderStatus {
+	complete := 0
+	started := false
+	for _, row := range rows {
+		if row.Complete {
+			complete++
+		}
+		if row.InProduction || row.Complete {
+			started = true
+		}
+	}
+
+	switch {
+	case complete == len(rows):
+		return StatusCompleted
+	case started:
+		return StatusInProgress
+	default:
+		return StatusApproved
+	}
+}
+
+func normalizeLegacyNumber(number *string) string {
+	if number == nil {
+		return ""
+	}
+	return strings.ToUpper(strings.TrimSpace(*number))
+}
+
+func legacyReceivedAt(rows []models.ReceivedItem) time.Time {
+	var earliest time.Time
+	for _, row := range rows {
+		at := row.CreatedAt
+		if row.DateReceived != nil {
+			at = *row.DateReceived
+		}
+		if earliest.IsZero() || at.Before(earliest) {
+			earliest = at
+		}
+	}
+	return earliest
+}
+
+// legacyItemDescription describes a received row the way the yard writes pipe,
+// e.g. `5-1/2" 17# L80 BTC`
+func legacyItemDescription(row models.ReceivedItem) string {
+	var parts []string
+	if size := derefString(row.Size); strings.TrimSpace(size) != "" {
+		parts = append(parts, strings.TrimSpace(size))
+	}
+	if row.Weight != nil && *row.Weight > 0 {
+		parts = append(parts, strconv.FormatFloat(*row.Weight, 'f', -1, 64)+"#")
+	}
+	if grade := derefString(row.Grade); strings.TrimSpace(grade) != "" {
+		parts = append(parts, strings.TrimSpace(grade))
+	}
+	if connection := derefString(row.Connection); strings.TrimSpace(connection) != "" {
+		parts = append(parts, strings.TrimSpace(connection))
+	}
+
+	if len(parts) == 0 {
+		return "Received pipe"
+	}
+	return strings.Join(parts, " ")
+}
+
+// legacyDescription names the job and, when every row agrees, its well and lease
+func legacyDescription(number string, rows []models.ReceivedItem) string {
+	description := "Legacy work order " + number
+	if well := sharedValue(rows, func(r models.ReceivedItem) *string { return r.Well }); well != "" {
+		description += ", well " + well
+	}
+	if lease := sharedValue(rows, func(r models.ReceivedItem) *string { return r.Lease }); lease != "" {
+		description += ", lease " + lease
+	}
+	if orderedBy := sharedValue(rows, func(r models.ReceivedItem) *string { return r.OrderedBy }); orderedBy != "" {
+		description += ", ordered by " + orderedBy
+	}
+	return description
+}
+
+func sharedValue(rows []models.ReceivedItem, field func(models.ReceivedItem) *string) string {
+	value := ""
+	for i, row := range rows {
+		v := strings.TrimSpace(derefString(field(row)))
+		if i == 0 {
+			value = v
+		} else if v != value {
+			return ""
+		}
+	}
+	return value
+}
+
+func trimmedOrNil(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	return nullableString(strings.TrimSpace(*s))
+}
+
+func joinInts(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return strings.Join(parts, ", ")
+}
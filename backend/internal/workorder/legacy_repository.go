@@ -0,0 +1,255 @@
+// backend/internal/workorder/legacy_repository.go
+package workorder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"oilgas-backend/internal/models"
+	"oilgas-backend/internal/shared/database"
+)
+
+type LegacyRepository interface {
+	GetReceivedItems(ctx context.Context, tenantID string) ([]models.ReceivedItem, error)
+	GetCustomerMapping(ctx context.Context, tenantID string) (map[int]int, error)
+	ImportLegacyJob(ctx context.Context, tenantID string, userID int, job *LegacyJob) (int, error)
+	AdvanceWorkOrderSequence(ctx context.Context, tenantID string) error
+}
+
+type legacyRepository struct {
+	dbManager *database.DatabaseManager
+}
+
+func NewLegacyRepository(dbManager *database.DatabaseManager) LegacyRepository {
+	return &legacyRepository{dbManager: dbManager}
+}
+
+func (r *legacyRepository) GetReceivedItems(ctx context.Context, tenantID string) ([]models.ReceivedItem, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, work_order, customer_id, customer, joints, size, weight, grade,
+		       connection, well, lease, ordered_by, notes, date_received,
+		       in_production, complete, tenant_id, imported_at, deleted, created_at
+		FROM store.received
+		WHERE tenant_id = $1 AND deleted = false
+		ORDER BY id`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get received rows: %w", err)
+	}
+	defer rows.Close()
+
+	var items []models.ReceivedItem
+	for rows.Next() {
+		var item models.ReceivedItem
+		err := rows.Scan(
+			&item.ID, &item.WorkOrder, &item.CustomerID, &item.Customer, &item.Joints, &item.Size, &item.Weight, &item.Grade,
+			&item.Connection, &item.Well, &item.Lease, &item.OrderedBy, &item.Notes, &item.DateReceived,
+			&item.InProduction, &item.Complete, &item.TenantID, &item.ImportedAt, &item.Deleted, &item.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan received row: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// GetCustomerMapping maps legacy customer IDs to the migrated customers that
+// recorded them as original_customer_id
+func (r *legacyRepository) GetCustomerMapping(ctx context.Context, tenantID string) (map[int]int, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT original_customer_id, id
+		FROM store.customers
+		WHERE tenant_id = $1 AND original_customer_id IS NOT NULL`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customers: %w", err)
+	}
+	defer rows.Close()
+
+	mapping := map[int]int{}
+	for rows.Next() {
+		var legacyID, id int
+		if err := rows.Scan(&legacyID, &id); err != nil {
+			return nil, fmt.Errorf("failed to scan customer: %w", err)
+		}
+		mapping[legacyID] = id
+	}
+
+	return mapping, rows.Err()
+}
+
+// ImportLegacyJob creates the job's work order, items and import records in
+// one transaction and returns how many items were linked to inventory. Rows
+// imported by an earlier run make the whole job return ErrLegacyAlreadyImported.
+func (r *legacyRepository) ImportLegacyJob(ctx context.Context, tenantID string, userID int, job *LegacyJob) (int, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var imported bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM store.legacy_received_imports WHERE received_id = ANY($1))`,
+		pq.Array(job.ReceivedIDs)).Scan(&imported)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check previous imports: %w", err)
+	}
+	if imported {
+		return 0, ErrLegacyAlreadyImported
+	}
+
+	wo := &job.WorkOrder
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO store.workorders (
+			tenant_id, customer_id, work_order_number, service_type, status, priority,
+			description, created_by_user_id, started_at, completed_at, is_active, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, true, $11)
+		RETURNING id, updated_at`,
+		tenantID, wo.CustomerID, wo.WorkOrderNumber, wo.ServiceType, wo.Status, wo.Priority,
+		wo.Description, userID, wo.StartedAt, wo.CompletedAt, wo.CreatedAt,
+	).Scan(&wo.ID, &wo.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return 0, ErrLegacyNumberTaken
+		}
+		return 0, fmt.Errorf("failed to create work order: %w", err)
+	}
+
+	linked := 0
+	usedInventory := []int64{}
+	for i := range wo.Items {
+		item := &wo.Items[i]
+		receivedID := job.ReceivedIDs[i]
+
+		inventoryID, err := findLegacyInventoryTx(ctx, tx, tenantID, job.Number, receivedID, usedInventory)
+		if err != nil {
+			return 0, err
+		}
+		if inventoryID != nil {
+			item.InventoryItemID = inventoryID
+			usedInventory = append(usedInventory, int64(*inventoryID))
+			linked++
+		}
+
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO store.workorder_items (
+				workorder_id, inventory_item_id, description, quantity, service_notes, is_completed, created_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id, updated_at`,
+			wo.ID, item.InventoryItemID, item.Description, item.Quantity, item.ServiceNotes, item.IsCompleted, wo.CreatedAt,
+		).Scan(&item.ID, &item.UpdatedAt)
+		if err != nil {
+			return 0, fmt.Errorf("failed to add work order item: %w", err)
+		}
+		item.WorkOrderID = wo.ID
+
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO store.legacy_received_imports (
+				received_id, tenant_id, legacy_work_order, workorder_id, workorder_item_id,
+				inventory_item_id, imported_by_user_id
+			) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			receivedID, tenantID, job.Number, wo.ID, item.ID, item.InventoryItemID, userID); err != nil {
+			return 0, fmt.Errorf("failed to record legacy import: %w", err)
+		}
+	}
+
+	history := &WorkOrderHistory{
+		WorkOrderID:     wo.ID,
+		ChangedByUserID: userID,
+		Action:          "imported",
+		NewValue:        stringPtr(string(wo.Status)),
+		Notes:           stringPtr("Promoted from legacy received rows " + joinInts(job.ReceivedIDs)),
+	}
+	if err := insertHistory(ctx, tx, history); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit legacy import: %w", err)
+	}
+
+	return linked, nil
+}
+
+// findLegacyInventoryTx finds the inventory row a received row turned into:
+// same work order and customer, matching pipe description, not already
+// linked to another item
+func findLegacyInventoryTx(ctx context.Context, tx *sql.Tx, tenantID, number string, receivedID int, used []int64) (*int, error) {
+	var id int
+	err := tx.QueryRowContext(ctx, `
+		SELECT i.id
+		FROM store.inventory i
+		JOIN store.received r ON r.id = $3
+		WHERE i.tenant_id = $1 AND i.deleted = false
+		  AND UPPER(TRIM(i.work_order)) = $2
+		  AND i.customer_id IS NOT DISTINCT FROM r.customer_id
+		  AND i.size IS NOT DISTINCT FROM r.size
+		  AND i.grade IS NOT DISTINCT FROM r.grade
+		  AND i.connection IS NOT DISTINCT FROM r.connection
+		  AND i.id <> ALL($4)
+		  AND NOT EXISTS (
+		      SELECT 1 FROM store.legacy_received_imports l WHERE l.inventory_item_id = i.id
+		  )
+		ORDER BY (i.joints IS NOT DISTINCT FROM r.joints) DESC, i.id
+		LIMIT 1`,
+		tenantID, number, receivedID, pq.Array(used)).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find inventory for received row %d: %w", receivedID, err)
+	}
+	return &id, nil
+}
+
+// AdvanceWorkOrderSequence moves the undated work order counter past any
+// imported number in the default {TENANT:3}-{seq} format, the same rule the
+// document sequence migration used to seed it
+func (r *legacyRepository) AdvanceWorkOrderSequence(ctx context.Context, tenantID string) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	prefix := strings.ToUpper(tenantID)
+	if len(prefix) > 3 {
+		prefix = prefix[:3]
+	}
+
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO store.document_sequences (tenant_id, document_type, period, last_value)
+		SELECT $1, 'WORK_ORDER', '', MAX(CAST(SUBSTRING(work_order_number FROM `+strconv.Itoa(len(prefix)+2)+`) AS BIGINT))
+		FROM store.workorders
+		WHERE tenant_id = $1 AND work_order_number ~ ('^' || $2 || '-[0-9]+$')
+		HAVING COUNT(*) > 0
+		ON CONFLICT (tenant_id, document_type, period)
+		DO UPDATE SET last_value = GREATEST(store.document_sequences.last_value, EXCLUDED.last_value),
+		              updated_at = NOW()`,
+		tenantID, prefix)
+	if err != nil {
+		return fmt.Errorf("failed to advance work order sequence: %w", err)
+	}
+
+	return nil
+}
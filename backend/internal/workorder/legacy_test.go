@@ -0,0 +1,178 @@
+// backend/internal/workorder/legacy_test.go
+package workorder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"oilgas-backend/internal/models"
+)
+
+type mockLegacyRepository struct {
+	mock.Mock
+}
+
+func (m *mockLegacyRepository) GetReceivedItems(ctx context.Context, tenantID string) ([]models.ReceivedItem, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).([]models.ReceivedItem), args.Error(1)
+}
+
+func (m *mockLegacyRepository) GetCustomerMapping(ctx context.Context, tenantID string) (map[int]int, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).(map[int]int), args.Error(1)
+}
+
+func (m *mockLegacyRepository) ImportLegacyJob(ctx context.Context, tenantID string, userID int, job *LegacyJob) (int, error) {
+	args := m.Called(ctx, tenantID, userID, job)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockLegacyRepository) AdvanceWorkOrderSequence(ctx context.Context, tenantID string) error {
+	args := m.Called(ctx, tenantID)
+	return args.Error(0)
+}
+
+func received(id int, workOrder string, customerID int, joints int, inProduction, complete bool) models.ReceivedItem {
+	received := time.Date(2019, time.March, id, 8, 0, 0, 0, time.UTC)
+	row := models.ReceivedItem{
+		ID:           id,
+		WorkOrder:    stringPtr(workOrder),
+		Joints:       &joints,
+		Size:         stringPtr(`5-1/2"`),
+		Weight:       floatPtr(17),
+		Grade:        stringPtr("L80"),
+		Connection:   stringPtr("BTC"),
+		Well:         stringPtr("Pioneer 4H"),
+		DateReceived: &received,
+		InProduction: inProduction,
+		Complete:     complete,
+		TenantID:     "longbeach",
+	}
+	if customerID > 0 {
+		row.CustomerID = &customerID
+	}
+	return row
+}
+
+func TestPlanLegacyJobs_GroupsAndDerivesStatus(t *testing.T) {
+	rows := []models.ReceivedItem{
+		received(1, "lb-1001 ", 55, 120, false, true),
+		received(2, "LB-1001", 55, 80, false, true),
+		received(3, "LB-1002", 55, 40, true, false),
+		received(4, "LB-1002", 0, 60, false, false), // inherits the job's customer
+		received(5, "LB-1003", 55, 200, false, false),
+	}
+
+	jobs, unresolved := planLegacyJobs(rows, map[int]int{55: 3}, ServiceInspection)
+
+	require.Len(t, jobs, 3)
+	assert.Empty(t, unresolved)
+
+	assert.Equal(t, "LB-1001", jobs[0].Number)
+	assert.Equal(t, StatusCompleted, jobs[0].WorkOrder.Status)
+	assert.Equal(t, []int{1, 2}, jobs[0].ReceivedIDs)
+	assert.Equal(t, 3, jobs[0].WorkOrder.CustomerID)
+	assert.Equal(t, `5-1/2" 17# L80 BTC`, jobs[0].WorkOrder.Items[0].Description)
+	assert.Equal(t, 120, jobs[0].WorkOrder.Items[0].Quantity)
+	assert.True(t, jobs[0].WorkOrder.Items[0].IsCompleted)
+	assert.Equal(t, "Legacy work order LB-1001, well Pioneer 4H", jobs[0].WorkOrder.Description)
+	assert.Equal(t, time.Date(2019, time.March, 1, 8, 0, 0, 0, time.UTC), jobs[0].WorkOrder.CreatedAt)
+
+	assert.Equal(t, StatusInProgress, jobs[1].WorkOrder.Status)
+	assert.NotNil(t, jobs[1].WorkOrder.StartedAt)
+	assert.Len(t, jobs[1].WorkOrder.Items, 2)
+
+	assert.Equal(t, StatusApproved, jobs[2].WorkOrder.Status)
+	assert.Nil(t, jobs[2].WorkOrder.StartedAt)
+}
+
+func TestPlanLegacyJobs_ReportsUnresolvableRows(t *testing.T) {
+	rows := []models.ReceivedItem{
+		received(1, "  ", 55, 10, false, false),
+		received(2, "LB-2001", 55, 10, false, false),
+		received(3, "LB-2001", 56, 10, false, false),
+		received(4, "LB-2002", 99, 10, false, false),
+		received(5, "LB-2003", 55, 0, false, false),
+		received(6, "LB-2003", 55, 30, false, false),
+		received(7, "LB-2004", 0, 30, false, false),
+	}
+
+	jobs, unresolved := planLegacyJobs(rows, map[int]int{55: 3, 56: 4}, ServiceInspection)
+
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "LB-2003", jobs[0].Number)
+	assert.Equal(t, []int{6}, jobs[0].ReceivedIDs)
+
+	reasons := map[int]string{}
+	for _, u := range unresolved {
+		reasons[u.ReceivedID] = u.Reason
+	}
+	assert.Equal(t, "missing work order number", reasons[1])
+	assert.Equal(t, "rows belong to different customers 55, 56", reasons[2])
+	assert.Equal(t, "rows belong to different customers 55, 56", reasons[3])
+	assert.Equal(t, "customer 99 has not been migrated", reasons[4])
+	assert.Equal(t, "missing joint count", reasons[5])
+	assert.Equal(t, "no customer on any row", reasons[7])
+}
+
+type LegacyImporterTestSuite struct {
+	suite.Suite
+	importer LegacyImporter
+	legacy   *mockLegacyRepository
+	ctx      context.Context
+	tenantID string
+}
+
+func (suite *LegacyImporterTestSuite) SetupTest() {
+	suite.legacy = &mockLegacyRepository{}
+	suite.importer = NewLegacyImporter(suite.legacy)
+	suite.ctx = context.Background()
+	suite.tenantID = "longbeach"
+
+	suite.legacy.On("GetReceivedItems", suite.ctx, suite.tenantID).Return([]models.ReceivedItem{
+		received(1, "LB-1001", 55, 120, false, true),
+		received(2, "LB-1002", 55, 40, true, false),
+		received(3, "LB-1003", 55, 40, false, false),
+	}, nil)
+	suite.legacy.On("GetCustomerMapping", suite.ctx, suite.tenantID).Return(map[int]int{55: 3}, nil)
+}
+
+func TestLegacyImporterSuite(t *testing.T) {
+	suite.Run(t, new(LegacyImporterTestSuite))
+}
+
+func (suite *LegacyImporterTestSuite) TestImport_DryRunWritesNothing() {
+	report, err := suite.importer.Import(suite.ctx, suite.tenantID, 1, ServiceInspection, true)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, report.Imported)
+	assert.Equal(suite.T(), 1, report.StatusCounts[StatusCompleted])
+	suite.legacy.AssertNotCalled(suite.T(), "ImportLegacyJob")
+	suite.legacy.AssertNotCalled(suite.T(), "AdvanceWorkOrderSequence")
+}
+
+func (suite *LegacyImporterTestSuite) TestImport_SkipsPreviousRunsAndReportsTakenNumbers() {
+	byNumber := func(number string) interface{} {
+		return mock.MatchedBy(func(job *LegacyJob) bool { return job.Number == number })
+	}
+	suite.legacy.On("ImportLegacyJob", suite.ctx, suite.tenantID, 1, byNumber("LB-1001")).Return(0, ErrLegacyAlreadyImported)
+	suite.legacy.On("ImportLegacyJob", suite.ctx, suite.tenantID, 1, byNumber("LB-1002")).Return(1, nil)
+	suite.legacy.On("ImportLegacyJob", suite.ctx, suite.tenantID, 1, byNumber("LB-1003")).Return(0, ErrLegacyNumberTaken)
+	suite.legacy.On("AdvanceWorkOrderSequence", suite.ctx, suite.tenantID).Return(nil)
+
+	report, err := suite.importer.Import(suite.ctx, suite.tenantID, 1, ServiceInspection, false)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, report.Imported)
+	assert.Equal(suite.T(), 1, report.AlreadyImported)
+	assert.Equal(suite.T(), 1, report.LinkedInventory)
+	require.Len(suite.T(), report.Unresolved, 1)
+	assert.Equal(suite.T(), 3, report.Unresolved[0].ReceivedID)
+	suite.legacy.AssertExpectations(suite.T())
+}
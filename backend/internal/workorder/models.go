@@ -3,7 +3,6 @@ package workorder
 
 import (
     "time"
-    "encoding/json"
 )
 
 // WorkOrder represents a service work order
@@ -16,6 +15,7 @@ type WorkOrder struct {
     WorkOrderNumber  string                 `json:"work_order_number" db:"work_order_number"`
     ServiceType      ServiceType            `json:"service_type" db:"service_type"`
     Status           WorkOrderStatus        `json:"status" db:"status"`
+    HeldFrom         *WorkOrderStatus       `json:"held_from,omitempty" db:"held_from"` // Set while ON_HOLD; the only status it may resume to
     Priority         Priority               `json:"priority" db:"priority"`
     
     // Service Details
@@ -33,8 +33,23 @@ type WorkOrder struct {
     AssignedToUserID *int                   `json:"assigned_to_user_id" db:"assigned_to_user_id"`
     CreatedByUserID  int                    `json:"created_by_user_id" db:"created_by_user_id"`
     
+    // Template the work order was prefilled from; the exact version is kept
+    TemplateID       *int                   `json:"template_id" db:"template_id"`
+    TemplateVersion  *int                   `json:"template_version" db:"template_version"`
+    
+    // Scheduling (set through ScheduleService)
+    YardLocation     *string                `json:"yard_location" db:"yard_location"`
+    ServiceBayID     *int                   `json:"service_bay_id" db:"service_bay_id"`
+    
+    // Inspection roll-up, kept in step with the joint inspection results
+    JointsInspected  int                    `json:"joints_inspected" db:"joints_inspected"`
+    JointsPassed     int                    `json:"joints_passed" db:"joints_passed"`
+    JointsRepair     int                    `json:"joints_repair" db:"joints_repair"`
+    JointsRejected   int                    `json:"joints_rejected" db:"joints_rejected"`
+    
     // Dates & Timeline
     ScheduledDate    *time.Time             `json:"scheduled_date" db:"scheduled_date"`
+    ScheduledEnd     *time.Time             `json:"scheduled_end" db:"scheduled_end"` // End of the booked working time
     StartedAt        *time.Time             `json:"started_at" db:"started_at"`
     CompletedAt      *time.Time             `json:"completed_at" db:"completed_at"`
     DueDate          *time.Time             `json:"due_date" db:"due_date"`
@@ -48,6 +63,7 @@ type WorkOrder struct {
     Items            []WorkOrderItem        `json:"items,omitempty"`
     History          []WorkOrderHistory     `json:"history,omitempty"`
     Approvals        []WorkOrderApproval    `json:"approvals,omitempty"`
+    Checklist        []ChecklistItem        `json:"checklist,omitempty"`
 }
 
 // ServiceType defines the type of service being performed
@@ -126,7 +142,9 @@ type WorkOrderHistory struct {
 type WorkOrderApproval struct {
     ID               int                    `json:"id" db:"id"`
     WorkOrderID      int                    `json:"work_order_id" db:"work_order_id"`
-    ApproverUserID   int                    `json:"approver_user_id" db:"approver_user_id"`
+    ChainID          *int                   `json:"chain_id" db:"chain_id"`
+    ApproverType     ApproverType           `json:"approver_type" db:"approver_type"`
+    ApproverUserID   *int                   `json:"approver_user_id" db:"approver_user_id"` // Set up front for named approvers, otherwise by whoever responds
     
     ApprovalLevel    int                    `json:"approval_level" db:"approval_level"`
     Status           ApprovalStatus         `json:"status" db:"status"`
@@ -143,3 +161,533 @@ const (
     ApprovalApproved ApprovalStatus = "APPROVED"
     ApprovalRejected ApprovalStatus = "REJECTED"
 )
+
+// ApproverType defines who may respond to an approval level
+type ApproverType string
+
+const (
+    ApproverManager          ApproverType = "MANAGER"           // Tenant users holding approval rights
+    ApproverCustomerApprover ApproverType = "CUSTOMER_APPROVER" // Customer contacts with the APPROVER contact type
+)
+
+// ApprovalChain is a tenant-configured sequence of approval levels. A chain
+// applies to work orders matching its service type, priority and minimum
+// amount; nil criteria match everything.
+type ApprovalChain struct {
+    ID               int                    `json:"id" db:"id"`
+    TenantID         string                 `json:"tenant_id" db:"tenant_id"`
+    Name             string                 `json:"name" db:"name"`
+    
+    // Matching criteria
+    ServiceType      *ServiceType           `json:"service_type" db:"service_type"`
+    Priority         *Priority              `json:"priority" db:"priority"`
+    MinAmount        *float64               `json:"min_amount" db:"min_amount"`
+    
+    IsActive         bool                   `json:"is_active" db:"is_active"`
+    CreatedAt        time.Time              `json:"created_at" db:"created_at"`
+    UpdatedAt        time.Time              `json:"updated_at" db:"updated_at"`
+    
+    Levels           []ApprovalChainLevel   `json:"levels"`
+}
+
+// ApprovalChainLevel is a single step in an approval chain
+type ApprovalChainLevel struct {
+    ID               int                    `json:"id" db:"id"`
+    ChainID          int                    `json:"chain_id" db:"chain_id"`
+    Level            int                    `json:"level" db:"level"`
+    ApproverType     ApproverType           `json:"approver_type" db:"approver_type"`
+    ApproverUserID   *int                   `json:"approver_user_id" db:"approver_user_id"` // Restricts the level to one user
+}
+
+// PendingApproval is an approval awaiting a response, with enough of the
+// work order to decide on it
+type PendingApproval struct {
+    WorkOrderApproval
+    WorkOrderNumber  string                 `json:"work_order_number" db:"work_order_number"`
+    CustomerID       int                    `json:"customer_id" db:"customer_id"`
+    ServiceType      ServiceType            `json:"service_type" db:"service_type"`
+    Priority         Priority               `json:"priority" db:"priority"`
+    Description      string                 `json:"description" db:"description"`
+    Amount           float64                `json:"amount"`
+}
+
+// SearchFilters narrows work order listings
+type SearchFilters struct {
+    CustomerID       *int              `json:"customer_id,omitempty"`
+    Status           []WorkOrderStatus `json:"status,omitempty"`
+    ServiceType      ServiceType       `json:"service_type,omitempty"`
+    Priority         Priority          `json:"priority,omitempty"`
+    AssignedToUserID *int              `json:"assigned_to_user_id,omitempty"`
+    YardLocations    []string          `json:"yard_locations,omitempty"`  // Nil for every yard; also matches work orders without a yard
+    Limit            int               `json:"limit,omitempty"`
+    Offset           int               `json:"offset,omitempty"`
+}
+
+// TimeEntry is one clocked labor session by a technician on a work order
+type TimeEntry struct {
+    ID               int                    `json:"id" db:"id"`
+    TenantID         string                 `json:"tenant_id" db:"tenant_id"`
+    WorkOrderID      int                    `json:"work_order_id" db:"workorder_id"`
+    WorkOrderItemID  *int                   `json:"work_order_item_id" db:"workorder_item_id"`
+    UserID           int                    `json:"user_id" db:"user_id"`
+    
+    ClockIn          time.Time              `json:"clock_in" db:"clock_in"`
+    ClockOut         *time.Time             `json:"clock_out" db:"clock_out"`               // Nil while clocked in
+    BreakMinutes     int                    `json:"break_minutes" db:"break_minutes"`       // Completed breaks only
+    BreakStartedAt   *time.Time             `json:"break_started_at" db:"break_started_at"` // Set while on break
+    Notes            *string                `json:"notes" db:"notes"`
+    
+    EditedByUserID   *int                   `json:"edited_by_user_id" db:"edited_by_user_id"`
+    EditedAt         *time.Time             `json:"edited_at" db:"edited_at"`
+    CreatedAt        time.Time              `json:"created_at" db:"created_at"`
+    UpdatedAt        time.Time              `json:"updated_at" db:"updated_at"`
+}
+
+// IsOpen reports whether the technician is still clocked in
+func (e *TimeEntry) IsOpen() bool {
+    return e.ClockOut == nil
+}
+
+// Hours is the worked time of a closed entry, net of breaks
+func (e *TimeEntry) Hours() float64 {
+    if e.ClockOut == nil {
+        return 0
+    }
+    worked := e.ClockOut.Sub(e.ClockIn) - time.Duration(e.BreakMinutes)*time.Minute
+    if worked < 0 {
+        return 0
+    }
+    return worked.Hours()
+}
+
+// TimeEntryEdit is a manager correction to a time entry. Nil fields are left
+// unchanged; Reason is recorded in the work order history.
+type TimeEntryEdit struct {
+    ID               int                    `json:"id"`
+    ClockIn          *time.Time             `json:"clock_in"`
+    ClockOut         *time.Time             `json:"clock_out"`
+    BreakMinutes     *int                   `json:"break_minutes"`
+    WorkOrderItemID  *int                   `json:"work_order_item_id"`
+    Reason           string                 `json:"reason"`
+}
+
+// TimeEntryFilters narrows time entry listings
+type TimeEntryFilters struct {
+    WorkOrderID      *int                   `json:"work_order_id,omitempty"`
+    UserID           *int                   `json:"user_id,omitempty"`
+    OpenOnly         bool                   `json:"open_only,omitempty"`
+    From             *time.Time             `json:"from,omitempty"`
+    To               *time.Time             `json:"to,omitempty"`
+    Limit            int                    `json:"limit,omitempty"`
+    Offset           int                    `json:"offset,omitempty"`
+}
+
+// Yard is a service location with its own working calendar
+type Yard struct {
+    TenantID         string                 `json:"tenant_id" db:"tenant_id"`
+    YardLocation     string                 `json:"yard_location" db:"yard_location"`
+    Name             string                 `json:"name" db:"name"`
+    TimeZone         string                 `json:"time_zone" db:"time_zone"`
+    
+    Hours            []YardHours            `json:"hours"`
+    Closures         []YardClosure          `json:"closures"`
+}
+
+// YardHours are the working hours for one weekday, as "15:04" local times
+type YardHours struct {
+    Weekday          time.Weekday           `json:"weekday" db:"weekday"`
+    OpensAt          string                 `json:"opens_at" db:"opens_at"`
+    ClosesAt         string                 `json:"closes_at" db:"closes_at"`
+}
+
+// YardClosure is a whole day the yard does not work, e.g. a holiday
+type YardClosure struct {
+    Date             time.Time              `json:"date" db:"closed_on"`
+    Reason           *string                `json:"reason" db:"reason"`
+}
+
+// ServiceBay is a physical work area; one work order occupies it at a time
+type ServiceBay struct {
+    ID               int                    `json:"id" db:"id"`
+    TenantID         string                 `json:"tenant_id" db:"tenant_id"`
+    YardLocation     string                 `json:"yard_location" db:"yard_location"`
+    Name             string                 `json:"name" db:"name"`
+    IsActive         bool                   `json:"is_active" db:"is_active"`
+    CreatedAt        time.Time              `json:"created_at" db:"created_at"`
+}
+
+// Booking is a scheduled work order as shown on the yard calendar
+type Booking struct {
+    WorkOrderID      int                    `json:"work_order_id" db:"id"`
+    WorkOrderNumber  string                 `json:"work_order_number" db:"work_order_number"`
+    CustomerID       int                    `json:"customer_id" db:"customer_id"`
+    Status           WorkOrderStatus        `json:"status" db:"status"`
+    Priority         Priority               `json:"priority" db:"priority"`
+    Description      string                 `json:"description" db:"description"`
+    YardLocation     string                 `json:"yard_location" db:"yard_location"`
+    ServiceBayID     *int                   `json:"service_bay_id" db:"service_bay_id"`
+    AssignedToUserID *int                   `json:"assigned_to_user_id" db:"assigned_to_user_id"`
+    EstimatedHours   float64                `json:"estimated_hours" db:"estimated_hours"`
+    Start            time.Time              `json:"start" db:"scheduled_date"`
+    End              time.Time              `json:"end" db:"scheduled_end"`
+    DueDate          *time.Time             `json:"due_date" db:"due_date"`
+    
+    Conflicts        []ScheduleConflict     `json:"conflicts,omitempty"` // Overlapping bookings for the same technician or bay
+}
+
+// YardSchedule is the calendar for one yard over a date range
+type YardSchedule struct {
+    Yard             Yard                   `json:"yard"`
+    ServiceBays      []ServiceBay           `json:"service_bays"`
+    From             time.Time              `json:"from"`
+    To               time.Time              `json:"to"`
+    Bookings         []Booking              `json:"bookings"`
+}
+
+// ScheduleRequest places (or moves) a work order on the calendar
+type ScheduleRequest struct {
+    WorkOrderID      int                    `json:"work_order_id"`
+    YardLocation     string                 `json:"yard_location"`
+    ServiceBayID     *int                   `json:"service_bay_id"`
+    AssignedToUserID *int                   `json:"assigned_to_user_id"`
+    Start            time.Time              `json:"start"`
+}
+
+// ScheduleResource names what a conflicting booking competes for
+type ScheduleResource string
+
+const (
+    ResourceTechnician   ScheduleResource = "TECHNICIAN"
+    ResourceServiceBay   ScheduleResource = "SERVICE_BAY"
+)
+
+// ScheduleConflict is an existing booking overlapping a requested one
+type ScheduleConflict struct {
+    Resource         ScheduleResource       `json:"resource"`
+    ResourceID       int                    `json:"resource_id"`
+    WorkOrderID      int                    `json:"work_order_id"`
+    WorkOrderNumber  string                 `json:"work_order_number"`
+    Start            time.Time              `json:"start"`
+    End              time.Time              `json:"end"`
+}
+
+// SlotQuery asks for the earliest time a job of the given length fits
+type SlotQuery struct {
+    WorkOrderID      int                    `json:"work_order_id"` // Ignored when checking conflicts, for moving a booked job
+    YardLocation     string                 `json:"yard_location"`
+    EstimatedHours   float64                `json:"estimated_hours"`
+    AssignedToUserID *int                   `json:"assigned_to_user_id"`
+    ServiceBayID     *int                   `json:"service_bay_id"` // Nil means any active bay in the yard
+    After            time.Time              `json:"after"`
+}
+
+// Slot is a free window on the calendar
+type Slot struct {
+    Start            time.Time              `json:"start"`
+    End              time.Time              `json:"end"`
+    ServiceBayID     *int                   `json:"service_bay_id"`
+}
+
+// ScheduleFilters narrows the bookings loaded for conflict checks and calendars
+type ScheduleFilters struct {
+    YardLocation       string
+    AssignedToUserID   *int
+    ServiceBayIDs      []int
+    From               time.Time
+    To                 time.Time
+    ExcludeWorkOrderID int
+}
+
+// SLARule is a tenant's service level target. The clock starts when a work
+// order enters FromStatus and stops once it reaches TargetStatus; without
+// TargetHours the deadline is the work order's due date.
+type SLARule struct {
+    ID               int                    `json:"id" db:"id"`
+    TenantID         string                 `json:"tenant_id" db:"tenant_id"`
+    Name             string                 `json:"name" db:"name"`
+    Priority         *Priority              `json:"priority" db:"priority"` // Nil applies to every priority
+    
+    FromStatus       WorkOrderStatus        `json:"from_status" db:"from_status"`
+    TargetStatus     WorkOrderStatus        `json:"target_status" db:"target_status"`
+    TargetHours      *float64               `json:"target_hours" db:"target_hours"`
+    WarningHours     float64                `json:"warning_hours" db:"warning_hours"` // At risk this long before the deadline
+    
+    Action           SLAAction              `json:"action" db:"action"` // Taken once the deadline is missed
+    ReassignToUserID *int                   `json:"reassign_to_user_id" db:"reassign_to_user_id"`
+    
+    IsActive         bool                   `json:"is_active" db:"is_active"`
+    CreatedByUserID  int                    `json:"created_by_user_id" db:"created_by_user_id"`
+    CreatedAt        time.Time              `json:"created_at" db:"created_at"`
+}
+
+// SLAAction is what the escalation worker does when a deadline is missed
+type SLAAction string
+
+const (
+    SLAActionNotify       SLAAction = "NOTIFY"
+    SLAActionBumpPriority SLAAction = "BUMP_PRIORITY"
+    SLAActionReassign     SLAAction = "REASSIGN"
+)
+
+// SLAState is how close a work order is to missing a rule's deadline
+type SLAState string
+
+const (
+    SLAAtRisk   SLAState = "AT_RISK"
+    SLABreached SLAState = "BREACHED"
+)
+
+// SLACandidate is an open work order with the times it entered each status
+type SLACandidate struct {
+    WorkOrder        WorkOrder
+    StatusEnteredAt  map[WorkOrderStatus]time.Time
+}
+
+// SLAStatus is one work order's standing against one rule
+type SLAStatus struct {
+    WorkOrderID      int                    `json:"work_order_id"`
+    WorkOrderNumber  string                 `json:"work_order_number"`
+    CustomerID       int                    `json:"customer_id"`
+    Status           WorkOrderStatus        `json:"status"`
+    Priority         Priority               `json:"priority"`
+    AssignedToUserID *int                   `json:"assigned_to_user_id"`
+    RuleID           int                    `json:"rule_id"`
+    RuleName         string                 `json:"rule_name"`
+    Deadline         time.Time              `json:"deadline"`
+    State            SLAState               `json:"state"`
+    HoursRemaining   float64                `json:"hours_remaining"` // Negative once breached
+}
+
+// Escalation records that a rule fired for a work order; each state fires once
+type Escalation struct {
+    ID               int                    `json:"id" db:"id"`
+    TenantID         string                 `json:"tenant_id" db:"tenant_id"`
+    WorkOrderID      int                    `json:"work_order_id" db:"workorder_id"`
+    RuleID           int                    `json:"rule_id" db:"rule_id"`
+    State            SLAState               `json:"state" db:"state"`
+    Deadline         time.Time              `json:"deadline" db:"deadline"`
+    ActionTaken      *SLAAction             `json:"action_taken" db:"action_taken"`
+    OldPriority      *Priority              `json:"old_priority" db:"old_priority"`
+    NewPriority      *Priority              `json:"new_priority" db:"new_priority"`
+    ReassignedFrom   *int                   `json:"reassigned_from_user_id" db:"reassigned_from_user_id"`
+    ReassignedTo     *int                   `json:"reassigned_to_user_id" db:"reassigned_to_user_id"`
+    CreatedAt        time.Time              `json:"created_at" db:"created_at"`
+}
+
+// WorkOrderTemplate prefills new work orders of one service type. Templates
+// are immutable: saving creates a new version and only the latest is current.
+type WorkOrderTemplate struct {
+    ID               int                    `json:"id" db:"id"`
+    TenantID         string                 `json:"tenant_id" db:"tenant_id"`
+    ServiceType      ServiceType            `json:"service_type" db:"service_type"`
+    Version          int                    `json:"version" db:"version"`
+    Name             string                 `json:"name" db:"name"`
+    
+    Description      string                 `json:"description" db:"description"`
+    Instructions     *string                `json:"instructions" db:"instructions"`
+    EstimatedHours   *float64               `json:"estimated_hours" db:"estimated_hours"`
+    HourlyRate       *float64               `json:"hourly_rate" db:"hourly_rate"`
+    Items            []TemplateItem         `json:"items" db:"items"`
+    Checklist        []TemplateChecklistItem `json:"checklist" db:"checklist"`
+    
+    IsCurrent        bool                   `json:"is_current" db:"is_current"`
+    CreatedByUserID  int                    `json:"created_by_user_id" db:"created_by_user_id"`
+    CreatedAt        time.Time              `json:"created_at" db:"created_at"`
+}
+
+// TemplateItem is a default line item copied onto new work orders
+type TemplateItem struct {
+    Description      string                 `json:"description"`
+    Quantity         int                    `json:"quantity"`
+    UnitPrice        *float64               `json:"unit_price"`
+}
+
+// TemplateChecklistItem is a step technicians tick off on the work order
+type TemplateChecklistItem struct {
+    Label            string                 `json:"label"`
+    Required         bool                   `json:"required"`
+}
+
+// ChecklistItem is a work order's copy of a template checklist step
+type ChecklistItem struct {
+    ID               int                    `json:"id" db:"id"`
+    WorkOrderID      int                    `json:"work_order_id" db:"workorder_id"`
+    Label            string                 `json:"label" db:"label"`
+    Required         bool                   `json:"required" db:"required"`
+    SortOrder        int                    `json:"sort_order" db:"sort_order"`
+    IsChecked        bool                   `json:"is_checked" db:"is_checked"`
+    CheckedByUserID  *int                   `json:"checked_by_user_id" db:"checked_by_user_id"`
+    CheckedAt        *time.Time             `json:"checked_at" db:"checked_at"`
+}
+
+// LegacyJob is one legacy work order number and the received rows promoted
+// into it. Items and ReceivedIDs are parallel: item i came from row i.
+type LegacyJob struct {
+    Number           string                 `json:"number"`
+    WorkOrder        WorkOrder              `json:"work_order"`
+    ReceivedIDs      []int                  `json:"received_ids"`
+}
+
+// LegacyUnresolved is a received row the import could not place
+type LegacyUnresolved struct {
+    ReceivedID       int                    `json:"received_id"`
+    WorkOrder        string                 `json:"work_order"`
+    Reason           string                 `json:"reason"`
+}
+
+// LegacyImportReport summarises one run of the legacy received import
+type LegacyImportReport struct {
+    DryRun           bool                   `json:"dry_run"`
+    Rows             int                    `json:"rows"`
+    Jobs             int                    `json:"jobs"`
+    Imported         int                    `json:"imported"`
+    AlreadyImported  int                    `json:"already_imported"`
+    Items            int                    `json:"items"`
+    LinkedInventory  int                    `json:"linked_inventory"`
+    StatusCounts     map[WorkOrderStatus]int `json:"status_counts"`
+    Unresolved       []LegacyUnresolved     `json:"unresolved"`
+}
+
+// VarianceFilters selects the work orders a variance report covers: those
+// completed in [From, To)
+type VarianceFilters struct {
+    From             time.Time
+    To               time.Time
+    ServiceType      *ServiceType
+    CustomerID       *int
+    ThresholdPct     float64 // Flag jobs whose hours or cost are off by more than this; 0 uses the default
+}
+
+// VarianceRecord is a completed work order with the hours each technician
+// logged against it
+type VarianceRecord struct {
+    WorkOrder        WorkOrder
+    CustomerName     string
+    TechnicianHours  map[int]float64
+}
+
+// WorkOrderVariance compares one work order's quote with what it took.
+// Cost is labor at the quoted hourly rate plus materials.
+type WorkOrderVariance struct {
+    WorkOrderID      int                    `json:"work_order_id"`
+    WorkOrderNumber  string                 `json:"work_order_number"`
+    CustomerID       int                    `json:"customer_id"`
+    CustomerName     string                 `json:"customer_name"`
+    ServiceType      ServiceType            `json:"service_type"`
+    AssignedToUserID *int                   `json:"assigned_to_user_id"`
+    CompletedAt      *time.Time             `json:"completed_at"`
+    
+    EstimatedHours   float64                `json:"estimated_hours"`
+    ActualHours      float64                `json:"actual_hours"`
+    HoursVariance    float64                `json:"hours_variance"`
+    HoursVariancePct float64                `json:"hours_variance_pct"`
+    EstimatedCost    float64                `json:"estimated_cost"`
+    ActualCost       float64                `json:"actual_cost"`
+    CostVariance     float64                `json:"cost_variance"`
+    CostVariancePct  *float64               `json:"cost_variance_pct"` // Nil when nothing was quoted
+    Flagged          bool                   `json:"flagged"`
+}
+
+// VarianceTotals sums the work orders of one service type, customer or technician
+type VarianceTotals struct {
+    Key              string                 `json:"key"` // Service type, customer ID or technician user ID
+    Label            string                 `json:"label"`
+    WorkOrders       int                    `json:"work_orders"`
+    Flagged          int                    `json:"flagged"`
+    
+    EstimatedHours   float64                `json:"estimated_hours"`
+    ActualHours      float64                `json:"actual_hours"`
+    HoursVariance    float64                `json:"hours_variance"`
+    HoursVariancePct *float64               `json:"hours_variance_pct"`
+    EstimatedCost    float64                `json:"estimated_cost"`
+    ActualCost       float64                `json:"actual_cost"`
+    CostVariance     float64                `json:"cost_variance"`
+    CostVariancePct  *float64               `json:"cost_variance_pct"`
+}
+
+// VarianceReport is estimate-versus-actual over a date range
+type VarianceReport struct {
+    From             time.Time              `json:"from"`
+    To               time.Time              `json:"to"`
+    ThresholdPct     float64                `json:"threshold_pct"`
+    Unestimated      int                    `json:"unestimated"` // Completed work orders without an hours estimate
+    
+    WorkOrders       []WorkOrderVariance    `json:"work_orders"`
+    ByServiceType    []VarianceTotals       `json:"by_service_type"`
+    ByCustomer       []VarianceTotals       `json:"by_customer"`
+    ByTechnician     []VarianceTotals       `json:"by_technician"`
+}
+
+// InspectionClass is the outcome of inspecting one joint
+type InspectionClass string
+
+const (
+    InspectionPass       InspectionClass = "PASS"
+    InspectionRepair     InspectionClass = "REPAIR"  // Serviceable after repair, e.g. rethreading
+    InspectionReject     InspectionClass = "REJECT"  // Scrap; segregated out of the customer's stock
+)
+
+// RejectReason codes why a joint needs repair or was rejected
+type RejectReason string
+
+const (
+    ReasonWallLoss       RejectReason = "WALL_LOSS"
+    ReasonPitting        RejectReason = "PITTING"
+    ReasonCorrosion      RejectReason = "CORROSION"
+    ReasonThreadDamage   RejectReason = "THREAD_DAMAGE"
+    ReasonCrack          RejectReason = "CRACK"
+    ReasonBent           RejectReason = "BENT"
+    ReasonDent           RejectReason = "DENT"
+    ReasonOther          RejectReason = "OTHER"
+)
+
+// ThreadCondition is the inspector's call on the connection threads
+type ThreadCondition string
+
+const (
+    ThreadGood           ThreadCondition = "GOOD"
+    ThreadMinorDamage    ThreadCondition = "MINOR_DAMAGE"
+    ThreadDamaged        ThreadCondition = "DAMAGED"
+    ThreadNotInspected   ThreadCondition = "NOT_INSPECTED"
+)
+
+// InspectionResult is the record for one joint of a work order item.
+// Readings are in inches.
+type InspectionResult struct {
+    ID               int                    `json:"id" db:"id"`
+    WorkOrderID      int                    `json:"work_order_id" db:"workorder_id"`
+    WorkOrderItemID  int                    `json:"work_order_item_id" db:"workorder_item_id"`
+    InventoryItemID  *int                   `json:"inventory_item_id" db:"inventory_item_id"` // Defaults to the item's inventory
+    JointNumber      int                    `json:"joint_number" db:"joint_number"`
+    
+    Classification   InspectionClass        `json:"classification" db:"classification"`
+    RejectReason     *RejectReason          `json:"reject_reason" db:"reject_reason"` // Required unless the joint passed
+    WallReadings     []float64              `json:"wall_readings" db:"wall_readings"`
+    MinWall          *float64               `json:"min_wall" db:"min_wall"` // Lowest of WallReadings
+    PitDepth         *float64               `json:"pit_depth" db:"pit_depth"`
+    ThreadCondition  ThreadCondition        `json:"thread_condition" db:"thread_condition"`
+    Notes            *string                `json:"notes" db:"notes"`
+    
+    SegregatedAt     *time.Time             `json:"segregated_at" db:"segregated_at"`
+    InspectedByUserID int                   `json:"inspected_by_user_id" db:"inspected_by_user_id"`
+    InspectedAt      time.Time              `json:"inspected_at" db:"inspected_at"`
+    UpdatedAt        time.Time              `json:"updated_at" db:"updated_at"`
+}
+
+// InspectionSummary rolls a work order's joint results up
+type InspectionSummary struct {
+    WorkOrderID      int                    `json:"work_order_id"`
+    Inspected        int                    `json:"inspected"`
+    Passed           int                    `json:"passed"`
+    Repair           int                    `json:"repair"`
+    Rejected         int                    `json:"rejected"`
+    Segregated       int                    `json:"segregated"` // Rejects already taken out of inventory
+    ByReason         map[RejectReason]int   `json:"by_reason"`
+}
+
+// Segregation reports rejects moved out of inventory for one item
+type Segregation struct {
+    WorkOrderItemID  int                    `json:"work_order_item_id"`
+    Joints           int                    `json:"joints"`
+    InventoryJoints  map[int]int            `json:"inventory_joints"` // Joints removed per inventory item
+}
@@ -0,0 +1,165 @@
+// backend/internal/workorder/portal_handlers.go
+package workorder
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"oilgas-backend/internal/auth"
+)
+
+// PortalHandlers serve customer contacts their own company's work orders and
+// let APPROVER contacts answer the approval levels addressed to them
+type PortalHandlers struct {
+	workOrders Service
+	approvals  ApprovalService
+}
+
+func NewPortalHandlers(workOrders Service, approvals ApprovalService) *PortalHandlers {
+	return &PortalHandlers{
+		workOrders: workOrders,
+		approvals:  approvals,
+	}
+}
+
+func (h *PortalHandlers) RegisterRoutes(router *gin.RouterGroup, authMiddleware *auth.Middleware) {
+	portal := router.Group("/portal/workorders")
+	portal.Use(authMiddleware.RequireAuth())
+	portal.Use(authMiddleware.RequireCustomerAccess())
+
+	portal.GET("", h.ListWorkOrders)
+	portal.GET("/awaiting-approval", h.GetAwaitingApproval)
+	portal.GET("/:id", h.GetWorkOrder)
+	portal.POST("/:id/approve", h.Approve)
+	portal.POST("/:id/reject", h.Reject)
+}
+
+// ListWorkOrders lists the contact's customer's work orders; ?status= takes a
+// comma-separated list, e.g. PENDING for quotes awaiting sign-off
+func (h *PortalHandlers) ListWorkOrders(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	customerID := *user.CustomerID
+	filters := SearchFilters{CustomerID: &customerID}
+
+	if status := c.Query("status"); status != "" {
+		for _, s := range strings.Split(status, ",") {
+			filters.Status = append(filters.Status, WorkOrderStatus(strings.ToUpper(strings.TrimSpace(s))))
+		}
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filters.Limit = l
+		}
+	}
+
+	if offset := c.Query("offset"); offset != "" {
+		if o, err := strconv.Atoi(offset); err == nil {
+			filters.Offset = o
+		}
+	}
+
+	workOrders, total, err := h.workOrders.SearchWorkOrders(c.Request.Context(), tenantID, filters)
+	if err != nil {
+		c.JSON(workOrderErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  workOrders,
+		"total": total,
+	})
+}
+
+// GetAwaitingApproval lists the approvals the contact can answer right now
+func (h *PortalHandlers) GetAwaitingApproval(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	approvals, err := h.approvals.GetApprovalsWaitingOn(c.Request.Context(), tenantID, user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get pending approvals"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  approvals,
+		"total": len(approvals),
+	})
+}
+
+func (h *PortalHandlers) GetWorkOrder(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid work order ID"})
+		return
+	}
+
+	wo, err := h.approvals.GetCustomerWorkOrder(c.Request.Context(), tenantID, user, id)
+	if err != nil {
+		c.JSON(approvalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, wo)
+}
+
+func (h *PortalHandlers) Approve(c *gin.Context) {
+	h.decide(c, h.approvals.Approve)
+}
+
+// Reject sends the work order back to the shop; comments are required
+func (h *PortalHandlers) Reject(c *gin.Context) {
+	h.decide(c, h.approvals.Reject)
+}
+
+func (h *PortalHandlers) decide(c *gin.Context, action approvalAction) {
+	tenantID := c.GetString("tenant_id")
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid work order ID"})
+		return
+	}
+
+	var req ApprovalRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	wo, err := action(c.Request.Context(), tenantID, user, id, req.Comments)
+	if err != nil {
+		c.JSON(approvalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, wo)
+}
@@ -0,0 +1,783 @@
+// backend/internal/workorder/repository.go
+package workorder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"oilgas-backend/internal/numbering"
+	"oilgas-backend/internal/shared/database"
+)
+
+type Repository interface {
+	GetWorkOrderByID(ctx context.Context, tenantID string, id int) (*WorkOrder, error)
+	SearchWorkOrders(ctx context.Context, tenantID string, filters SearchFilters) ([]WorkOrder, int, error)
+	CreateWorkOrder(ctx context.Context, tenantID string, wo *WorkOrder, history *WorkOrderHistory) error
+	UpdateWorkOrder(ctx context.Context, tenantID string, wo *WorkOrder, history []WorkOrderHistory) error
+	UpdateStatus(ctx context.Context, tenantID string, id int, from, to WorkOrderStatus, history *WorkOrderHistory) error
+
+	DeleteWorkOrder(ctx context.Context, tenantID string, id int, history *WorkOrderHistory) error
+
+	GetWorkOrderItems(ctx context.Context, tenantID string, workOrderID int) ([]WorkOrderItem, error)
+	AddWorkOrderItem(ctx context.Context, tenantID string, workOrderID int, item *WorkOrderItem, history *WorkOrderHistory) error
+	RemoveWorkOrderItem(ctx context.Context, tenantID string, workOrderID, itemID int, history *WorkOrderHistory) error
+	CompleteWorkOrderItem(ctx context.Context, tenantID string, workOrderID, itemID int, notes *string, history *WorkOrderHistory) (*WorkOrderItem, error)
+	GetWorkOrderHistory(ctx context.Context, tenantID string, workOrderID int) ([]WorkOrderHistory, error)
+}
+
+type repository struct {
+	dbManager *database.DatabaseManager
+	numbers   numbering.Allocator
+}
+
+func NewRepository(dbManager *database.DatabaseManager, numbers numbering.Allocator) Repository {
+	return &repository{dbManager: dbManager, numbers: numbers}
+}
+
+const workOrderColumns = `
+		id, tenant_id, customer_id, work_order_number, service_type, status, held_from, priority,
+		description, instructions, estimated_hours, actual_hours,
+		hourly_rate, materials_cost, total_amount,
+		assigned_to_user_id, created_by_user_id, template_id, template_version,
+		yard_location, service_bay_id,
+		joints_inspected, joints_passed, joints_repair, joints_rejected,
+		scheduled_date, scheduled_end, started_at, completed_at, due_date,
+		is_active, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanWorkOrder(row rowScanner, wo *WorkOrder) error {
+	return row.Scan(
+		&wo.ID, &wo.TenantID, &wo.CustomerID, &wo.WorkOrderNumber, &wo.ServiceType, &wo.Status, &wo.HeldFrom, &wo.Priority,
+		&wo.Description, &wo.Instructions, &wo.EstimatedHours, &wo.ActualHours,
+		&wo.HourlyRate, &wo.MaterialsCost, &wo.TotalAmount,
+		&wo.AssignedToUserID, &wo.CreatedByUserID, &wo.TemplateID, &wo.TemplateVersion,
+		&wo.YardLocation, &wo.ServiceBayID,
+		&wo.JointsInspected, &wo.JointsPassed, &wo.JointsRepair, &wo.JointsRejected,
+		&wo.ScheduledDate, &wo.ScheduledEnd, &wo.StartedAt, &wo.CompletedAt, &wo.DueDate,
+		&wo.IsActive, &wo.CreatedAt, &wo.UpdatedAt,
+	)
+}
+
+func (r *repository) GetWorkOrderByID(ctx context.Context, tenantID string, id int) (*WorkOrder, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	query := `SELECT ` + workOrderColumns + `
+		FROM store.workorders
+		WHERE id = $1 AND tenant_id = $2 AND is_active = true`
+
+	var wo WorkOrder
+	if err := scanWorkOrder(db.QueryRowContext(ctx, query, id, tenantID), &wo); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrWorkOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get work order: %w", err)
+	}
+
+	return &wo, nil
+}
+
+func (r *repository) SearchWorkOrders(ctx context.Context, tenantID string, filters SearchFilters) ([]WorkOrder, int, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", argIndex))
+	args = append(args, tenantID)
+	argIndex++
+
+	conditions = append(conditions, "is_active = true")
+
+	if filters.CustomerID != nil {
+		conditions = append(conditions, fmt.Sprintf("customer_id = $%d", argIndex))
+		args = append(args, *filters.CustomerID)
+		argIndex++
+	}
+
+	if len(filters.Status) > 0 {
+		statusPlaceholders := make([]string, len(filters.Status))
+		for i, status := range filters.Status {
+			statusPlaceholders[i] = fmt.Sprintf("$%d", argIndex)
+			args = append(args, status)
+			argIndex++
+		}
+		conditions = append(conditions, fmt.Sprintf("status IN (%s)", strings.Join(statusPlaceholders, ",")))
+	}
+
+	if filters.ServiceType != "" {
+		conditions = append(conditions, fmt.Sprintf("service_type = $%d", argIndex))
+		args = append(args, filters.ServiceType)
+		argIndex++
+	}
+
+	if filters.Priority != "" {
+		conditions = append(conditions, fmt.Sprintf("priority = $%d", argIndex))
+		args = append(args, filters.Priority)
+		argIndex++
+	}
+
+	if filters.AssignedToUserID != nil {
+		conditions = append(conditions, fmt.Sprintf("assigned_to_user_id = $%d", argIndex))
+		args = append(args, *filters.AssignedToUserID)
+		argIndex++
+	}
+
+	// An empty, non-nil yard list still limits results to work orders not yet
+	// placed in a yard
+	if filters.YardLocations != nil {
+		yardCondition := "yard_location IS NULL"
+		if len(filters.YardLocations) > 0 {
+			yardPlaceholders := make([]string, len(filters.YardLocations))
+			for i, yard := range filters.YardLocations {
+				yardPlaceholders[i] = fmt.Sprintf("$%d", argIndex)
+				args = append(args, yard)
+				argIndex++
+			}
+			yardCondition = fmt.Sprintf("(yard_location IS NULL OR yard_location IN (%s))", strings.Join(yardPlaceholders, ","))
+		}
+		conditions = append(conditions, yardCondition)
+	}
+
+	whereClause := strings.Join(conditions, " AND ")
+
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM store.workorders WHERE %s", whereClause)
+	var total int
+	if err := db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count work orders: %w", err)
+	}
+
+	query := fmt.Sprintf(`SELECT %s
+		FROM store.workorders
+		WHERE %s
+		ORDER BY created_at DESC`, workOrderColumns, whereClause)
+
+	if filters.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIndex)
+		args = append(args, filters.Limit)
+		argIndex++
+	}
+
+	if filters.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIndex)
+		args = append(args, filters.Offset)
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search work orders: %w", err)
+	}
+	defer rows.Close()
+
+	var workOrders []WorkOrder
+	for rows.Next() {
+		var wo WorkOrder
+		if err := scanWorkOrder(rows, &wo); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan work order: %w", err)
+		}
+		workOrders = append(workOrders, wo)
+	}
+
+	return workOrders, total, rows.Err()
+}
+
+func (r *repository) CreateWorkOrder(ctx context.Context, tenantID string, wo *WorkOrder, history *WorkOrderHistory) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if wo.WorkOrderNumber == "" {
+		number, err := r.numbers.Next(ctx, tx, tenantID, numbering.DocumentWorkOrder)
+		if err != nil {
+			return err
+		}
+		wo.WorkOrderNumber = number
+	}
+
+	query := `
+		INSERT INTO store.workorders (
+			tenant_id, customer_id, work_order_number, service_type, status, priority,
+			description, instructions, estimated_hours, actual_hours,
+			hourly_rate, materials_cost, total_amount,
+			assigned_to_user_id, created_by_user_id, yard_location,
+			scheduled_date, due_date, template_id, template_version, is_active
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, true)
+		RETURNING id, created_at, updated_at`
+
+	err = tx.QueryRowContext(ctx, query,
+		tenantID, wo.CustomerID, wo.WorkOrderNumber, wo.ServiceType, wo.Status, wo.Priority,
+		wo.Description, wo.Instructions, wo.EstimatedHours, wo.ActualHours,
+		wo.HourlyRate, wo.MaterialsCost, wo.TotalAmount,
+		wo.AssignedToUserID, wo.CreatedByUserID, wo.YardLocation,
+		wo.ScheduledDate, wo.DueDate, wo.TemplateID, wo.TemplateVersion,
+	).Scan(&wo.ID, &wo.CreatedAt, &wo.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create work order: %w", err)
+	}
+
+	for i := range wo.Items {
+		if err := insertItemTx(ctx, tx, wo.ID, &wo.Items[i]); err != nil {
+			return err
+		}
+	}
+
+	for i := range wo.Checklist {
+		if err := insertChecklistItemTx(ctx, tx, wo.ID, &wo.Checklist[i]); err != nil {
+			return err
+		}
+	}
+
+	if history != nil {
+		history.WorkOrderID = wo.ID
+		if err := insertHistory(ctx, tx, history); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit work order: %w", err)
+	}
+
+	wo.TenantID = tenantID
+	wo.IsActive = true
+	return nil
+}
+
+func (r *repository) UpdateWorkOrder(ctx context.Context, tenantID string, wo *WorkOrder, history []WorkOrderHistory) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE store.workorders
+		SET service_type = $3, priority = $4, description = $5, instructions = $6,
+		    estimated_hours = $7, actual_hours = $8, hourly_rate = $9, materials_cost = $10,
+		    total_amount = $11, assigned_to_user_id = $12, scheduled_date = $13, due_date = $14,
+		    yard_location = $15, updated_at = NOW()
+		WHERE id = $1 AND tenant_id = $2 AND is_active = true
+		RETURNING updated_at`
+
+	err = tx.QueryRowContext(ctx, query,
+		wo.ID, tenantID, wo.ServiceType, wo.Priority, wo.Description, wo.Instructions,
+		wo.EstimatedHours, wo.ActualHours, wo.HourlyRate, wo.MaterialsCost,
+		wo.TotalAmount, wo.AssignedToUserID, wo.ScheduledDate, wo.DueDate,
+		wo.YardLocation,
+	).Scan(&wo.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrWorkOrderNotFound
+		}
+		return fmt.Errorf("failed to update work order: %w", err)
+	}
+
+	for i := range history {
+		history[i].WorkOrderID = wo.ID
+		if err := insertHistory(ctx, tx, &history[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit work order update: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateStatus moves a work order from one status to another. The update only
+// applies while the row is still in the expected status, so two concurrent
+// transitions cannot both succeed.
+func (r *repository) UpdateStatus(ctx context.Context, tenantID string, id int, from, to WorkOrderStatus, history *WorkOrderHistory) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := updateStatusTx(ctx, tx, tenantID, id, from, to); err != nil {
+		return err
+	}
+
+	if history != nil {
+		history.WorkOrderID = id
+		if err := insertHistory(ctx, tx, history); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit status change: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteWorkOrder soft deletes a draft. Work orders that have left DRAFT are
+// cancelled instead so their history stays visible.
+func (r *repository) DeleteWorkOrder(ctx context.Context, tenantID string, id int, history *WorkOrderHistory) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE store.workorders
+		SET is_active = false, updated_at = NOW()
+		WHERE id = $1 AND tenant_id = $2 AND status = 'DRAFT' AND is_active = true`,
+		id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete work order: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrStatusConflict
+	}
+
+	if err := releaseReservationsTx(ctx, tx, tenantID, id, "work order deleted"); err != nil {
+		return err
+	}
+
+	if history != nil {
+		history.WorkOrderID = id
+		if err := insertHistory(ctx, tx, history); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit work order deletion: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) GetWorkOrderItems(ctx context.Context, tenantID string, workOrderID int) ([]WorkOrderItem, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	query := `
+		SELECT i.id, i.workorder_id, i.inventory_item_id, i.description, i.quantity,
+		       i.unit_price, i.total_price, i.service_notes, i.is_completed, i.completed_at,
+		       i.created_at, i.updated_at
+		FROM store.workorder_items i
+		JOIN store.workorders w ON w.id = i.workorder_id
+		WHERE i.workorder_id = $1 AND w.tenant_id = $2
+		ORDER BY i.id ASC`
+
+	rows, err := db.QueryContext(ctx, query, workOrderID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work order items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []WorkOrderItem
+	for rows.Next() {
+		var item WorkOrderItem
+		err := rows.Scan(
+			&item.ID, &item.WorkOrderID, &item.InventoryItemID, &item.Description, &item.Quantity,
+			&item.UnitPrice, &item.TotalPrice, &item.ServiceNotes, &item.IsCompleted, &item.CompletedAt,
+			&item.CreatedAt, &item.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan work order item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// AddWorkOrderItem adds a line item while the work order is still editable.
+// The work order row is locked so the item cannot slip in after a concurrent
+// completion or invoice.
+func (r *repository) AddWorkOrderItem(ctx context.Context, tenantID string, workOrderID int, item *WorkOrderItem, history *WorkOrderHistory) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	status, err := lockWorkOrderStatusTx(ctx, tx, tenantID, workOrderID)
+	if err != nil {
+		return err
+	}
+	if !IsEditable(status) {
+		return fmt.Errorf("%w: status is %s", ErrNotEditable, status)
+	}
+
+	if err := insertItemTx(ctx, tx, workOrderID, item); err != nil {
+		return err
+	}
+
+	if history != nil {
+		history.WorkOrderID = workOrderID
+		if err := insertHistory(ctx, tx, history); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit work order item: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveWorkOrderItem deletes an item that has not been completed yet
+func (r *repository) RemoveWorkOrderItem(ctx context.Context, tenantID string, workOrderID, itemID int, history *WorkOrderHistory) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	status, err := lockWorkOrderStatusTx(ctx, tx, tenantID, workOrderID)
+	if err != nil {
+		return err
+	}
+	if !IsEditable(status) {
+		return fmt.Errorf("%w: status is %s", ErrNotEditable, status)
+	}
+
+	var description string
+	var completed bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT description, is_completed FROM store.workorder_items
+		WHERE id = $1 AND workorder_id = $2`,
+		itemID, workOrderID).Scan(&description, &completed)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrWorkOrderItemNotFound
+		}
+		return fmt.Errorf("failed to get work order item: %w", err)
+	}
+	if completed {
+		return ErrItemAlreadyCompleted
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM store.workorder_items WHERE id = $1 AND workorder_id = $2`,
+		itemID, workOrderID); err != nil {
+		return fmt.Errorf("failed to remove work order item: %w", err)
+	}
+
+	if history != nil {
+		history.WorkOrderID = workOrderID
+		history.OldValue = stringPtr(description)
+		if err := insertHistory(ctx, tx, history); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit work order item removal: %w", err)
+	}
+
+	return nil
+}
+
+// CompleteWorkOrderItem marks an item done while work is in progress
+func (r *repository) CompleteWorkOrderItem(ctx context.Context, tenantID string, workOrderID, itemID int, notes *string, history *WorkOrderHistory) (*WorkOrderItem, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	status, err := lockWorkOrderStatusTx(ctx, tx, tenantID, workOrderID)
+	if err != nil {
+		return nil, err
+	}
+	if status != StatusInProgress {
+		return nil, fmt.Errorf("%w: status is %s", ErrItemNotCompletable, status)
+	}
+
+	var item WorkOrderItem
+	err = tx.QueryRowContext(ctx, `
+		UPDATE store.workorder_items
+		SET is_completed = true, completed_at = NOW(),
+		    service_notes = COALESCE($3, service_notes), updated_at = NOW()
+		WHERE id = $1 AND workorder_id = $2 AND is_completed = false
+		RETURNING id, workorder_id, inventory_item_id, description, quantity,
+		          unit_price, total_price, service_notes, is_completed, completed_at,
+		          created_at, updated_at`,
+		itemID, workOrderID, notes,
+	).Scan(
+		&item.ID, &item.WorkOrderID, &item.InventoryItemID, &item.Description, &item.Quantity,
+		&item.UnitPrice, &item.TotalPrice, &item.ServiceNotes, &item.IsCompleted, &item.CompletedAt,
+		&item.CreatedAt, &item.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `
+			SELECT EXISTS (SELECT 1 FROM store.workorder_items WHERE id = $1 AND workorder_id = $2)`,
+			itemID, workOrderID).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("failed to get work order item: %w", err)
+		}
+		if exists {
+			return nil, ErrItemAlreadyCompleted
+		}
+		return nil, ErrWorkOrderItemNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete work order item: %w", err)
+	}
+
+	if history != nil {
+		history.WorkOrderID = workOrderID
+		history.NewValue = stringPtr(item.Description)
+		if err := insertHistory(ctx, tx, history); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit work order item completion: %w", err)
+	}
+
+	return &item, nil
+}
+
+func (r *repository) GetWorkOrderHistory(ctx context.Context, tenantID string, workOrderID int) ([]WorkOrderHistory, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	query := `
+		SELECT h.id, h.workorder_id, h.changed_by_user_id, h.action,
+		       h.old_value, h.new_value, h.notes, h.created_at
+		FROM store.workorder_history h
+		JOIN store.workorders w ON w.id = h.workorder_id
+		WHERE h.workorder_id = $1 AND w.tenant_id = $2
+		ORDER BY h.created_at DESC, h.id DESC`
+
+	rows, err := db.QueryContext(ctx, query, workOrderID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work order history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []WorkOrderHistory
+	for rows.Next() {
+		var h WorkOrderHistory
+		err := rows.Scan(
+			&h.ID, &h.WorkOrderID, &h.ChangedByUserID, &h.Action,
+			&h.OldValue, &h.NewValue, &h.Notes, &h.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan work order history: %w", err)
+		}
+		history = append(history, h)
+	}
+
+	return history, rows.Err()
+}
+
+// ApplyStatusChange changes a work order's status inside a transaction owned
+// by another domain, such as invoicing, so the change commits or rolls back
+// together with that domain's own writes
+func ApplyStatusChange(ctx context.Context, tx *sql.Tx, tenantID string, id int, from, to WorkOrderStatus, history *WorkOrderHistory) error {
+	if !CanTransition(from, to) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, from, to)
+	}
+
+	if err := updateStatusTx(ctx, tx, tenantID, id, from, to); err != nil {
+		return err
+	}
+
+	if history != nil {
+		history.WorkOrderID = id
+		history.Action = "status_changed"
+		history.OldValue = stringPtr(string(from))
+		history.NewValue = stringPtr(string(to))
+		if err := insertHistory(ctx, tx, history); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateStatusTx applies a status change inside an existing transaction,
+// returning ErrStatusConflict if the work order is no longer in the expected status
+func updateStatusTx(ctx context.Context, tx *sql.Tx, tenantID string, id int, from, to WorkOrderStatus) error {
+	result, err := tx.ExecContext(ctx, `
+		UPDATE store.workorders
+		SET status = $3,
+		    held_from = CASE WHEN $3 = 'ON_HOLD' THEN $4 ELSE NULL END,
+		    started_at = CASE WHEN $3 = 'IN_PROGRESS' AND started_at IS NULL THEN NOW() ELSE started_at END,
+		    completed_at = CASE WHEN $3 = 'COMPLETED' THEN NOW() ELSE completed_at END,
+		    updated_at = NOW()
+		WHERE id = $1 AND tenant_id = $2 AND status = $4 AND is_active = true`,
+		id, tenantID, to, from)
+	if err != nil {
+		return fmt.Errorf("failed to update work order status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrStatusConflict
+	}
+
+	if to == StatusCompleted {
+		var unchecked int
+		err := tx.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM store.workorder_checklist_items
+			WHERE workorder_id = $1 AND required = true AND is_checked = false`, id).Scan(&unchecked)
+		if err != nil {
+			return fmt.Errorf("failed to check work order checklist: %w", err)
+		}
+		if unchecked > 0 {
+			return fmt.Errorf("%w: %d remaining", ErrChecklistIncomplete, unchecked)
+		}
+	}
+
+	if to == StatusCancelled {
+		if err := releaseReservationsTx(ctx, tx, tenantID, id, "work order cancelled"); err != nil {
+			return err
+		}
+	}
+
+	// A withdrawn or cancelled submission's requests are void, including
+	// one put on hold first; resubmitting starts a fresh round
+	if to == StatusDraft || to == StatusCancelled {
+		_, err := tx.ExecContext(ctx, `
+			DELETE FROM store.workorder_approvals
+			WHERE workorder_id = $1 AND status = 'PENDING'`, id)
+		if err != nil {
+			return fmt.Errorf("failed to discard pending approvals: %w", err)
+		}
+	}
+
+	// Labor is only recorded while work is in progress
+	if from == StatusInProgress {
+		if err := closeOpenTimeEntriesTx(ctx, tx, tenantID, id); err != nil {
+			return err
+		}
+		if err := recomputeActualHoursTx(ctx, tx, tenantID, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// releaseReservationsTx frees the inventory held for a work order that will
+// never be worked, so its joints can be promised to other jobs
+func releaseReservationsTx(ctx context.Context, tx *sql.Tx, tenantID string, id int, reason string) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE store.inventory_reservations
+		SET status = 'RELEASED', released_at = NOW(), release_reason = $3
+		WHERE workorder_id = $1 AND tenant_id = $2 AND status = 'ACTIVE'`,
+		id, tenantID, reason)
+	if err != nil {
+		return fmt.Errorf("failed to release inventory reservations: %w", err)
+	}
+	return nil
+}
+
+func insertItemTx(ctx context.Context, tx *sql.Tx, workOrderID int, item *WorkOrderItem) error {
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO store.workorder_items (
+			workorder_id, inventory_item_id, description, quantity, unit_price, total_price, service_notes
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, is_completed, created_at, updated_at`,
+		workOrderID, item.InventoryItemID, item.Description, item.Quantity,
+		item.UnitPrice, item.TotalPrice, item.ServiceNotes,
+	).Scan(&item.ID, &item.IsCompleted, &item.CreatedAt, &item.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add work order item: %w", err)
+	}
+	item.WorkOrderID = workOrderID
+	return nil
+}
+
+func insertChecklistItemTx(ctx context.Context, tx *sql.Tx, workOrderID int, item *ChecklistItem) error {
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO store.workorder_checklist_items (workorder_id, label, required, sort_order)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`,
+		workOrderID, item.Label, item.Required, item.SortOrder,
+	).Scan(&item.ID)
+	if err != nil {
+		return fmt.Errorf("failed to add checklist item: %w", err)
+	}
+	item.WorkOrderID = workOrderID
+	return nil
+}
+
+func insertHistory(ctx context.Context, tx *sql.Tx, h *WorkOrderHistory) error {
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO store.workorder_history (
+			workorder_id, changed_by_user_id, action, old_value, new_value, notes
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`,
+		h.WorkOrderID, h.ChangedByUserID, h.Action, h.OldValue, h.NewValue, h.Notes,
+	).Scan(&h.ID, &h.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record work order history: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,395 @@
+// backend/internal/workorder/schedule.go
+package workorder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type ScheduleService interface {
+	GetYard(ctx context.Context, tenantID, yardLocation string) (*Yard, error)
+	SaveYard(ctx context.Context, tenantID string, yard *Yard) error
+
+	ListServiceBays(ctx context.Context, tenantID, yardLocation string) ([]ServiceBay, error)
+	CreateServiceBay(ctx context.Context, tenantID string, bay *ServiceBay) error
+	DeactivateServiceBay(ctx context.Context, tenantID string, id int) error
+
+	GetYardSchedule(ctx context.Context, tenantID, yardLocation string, from, to time.Time) (*YardSchedule, error)
+	FindEarliestSlot(ctx context.Context, tenantID string, query SlotQuery) (*Slot, error)
+
+	ScheduleWorkOrder(ctx context.Context, tenantID string, userID int, req ScheduleRequest) (*WorkOrder, error)
+	UnscheduleWorkOrder(ctx context.Context, tenantID string, userID, workOrderID int) (*WorkOrder, error)
+}
+
+type scheduleService struct {
+	repo      Repository
+	schedules ScheduleRepository
+	now       func() time.Time
+}
+
+func NewScheduleService(repo Repository, schedules ScheduleRepository) ScheduleService {
+	return &scheduleService{
+		repo:      repo,
+		schedules: schedules,
+		now:       time.Now,
+	}
+}
+
+// GetYard returns the yard's calendar, falling back to default weekday
+// hours for yards that have not been configured yet
+func (s *scheduleService) GetYard(ctx context.Context, tenantID, yardLocation string) (*Yard, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	yardLocation = strings.TrimSpace(yardLocation)
+	if yardLocation == "" {
+		return nil, fmt.Errorf("yard location is required")
+	}
+
+	yard, err := s.schedules.GetYard(ctx, tenantID, yardLocation)
+	if errors.Is(err, ErrYardNotFound) {
+		return &Yard{
+			TenantID:     tenantID,
+			YardLocation: yardLocation,
+			Name:         yardLocation,
+			TimeZone:     defaultYardTimeZone,
+			Hours:        defaultYardHours,
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(yard.Hours) == 0 {
+		yard.Hours = defaultYardHours
+	}
+	return yard, nil
+}
+
+func (s *scheduleService) SaveYard(ctx context.Context, tenantID string, yard *Yard) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if yard == nil {
+		return fmt.Errorf("yard is required")
+	}
+
+	yard.YardLocation = strings.TrimSpace(yard.YardLocation)
+	yard.Name = strings.TrimSpace(yard.Name)
+	if yard.YardLocation == "" {
+		return fmt.Errorf("validation failed: yard location is required")
+	}
+	if len(yard.YardLocation) > 100 {
+		return fmt.Errorf("validation failed: yard location too long: %d characters", len(yard.YardLocation))
+	}
+	if yard.Name == "" {
+		yard.Name = yard.YardLocation
+	}
+	if yard.TimeZone == "" {
+		yard.TimeZone = defaultYardTimeZone
+	}
+
+	if _, err := newWorkCalendar(yard); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return s.schedules.SaveYard(ctx, tenantID, yard)
+}
+
+func (s *scheduleService) ListServiceBays(ctx context.Context, tenantID, yardLocation string) ([]ServiceBay, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.schedules.GetServiceBays(ctx, tenantID, yardLocation)
+}
+
+func (s *scheduleService) CreateServiceBay(ctx context.Context, tenantID string, bay *ServiceBay) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if bay == nil {
+		return fmt.Errorf("service bay is required")
+	}
+
+	bay.Name = strings.TrimSpace(bay.Name)
+	if bay.Name == "" {
+		return fmt.Errorf("validation failed: service bay name is required")
+	}
+	if len(bay.Name) > 100 {
+		return fmt.Errorf("validation failed: service bay name too long: %d characters", len(bay.Name))
+	}
+	if strings.TrimSpace(bay.YardLocation) == "" {
+		return fmt.Errorf("validation failed: yard location is required")
+	}
+
+	return s.schedules.CreateServiceBay(ctx, tenantID, bay)
+}
+
+func (s *scheduleService) DeactivateServiceBay(ctx context.Context, tenantID string, id int) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.schedules.DeactivateServiceBay(ctx, tenantID, id)
+}
+
+func (s *scheduleService) GetYardSchedule(ctx context.Context, tenantID, yardLocation string, from, to time.Time) (*YardSchedule, error) {
+	if !to.After(from) {
+		return nil, fmt.Errorf("schedule range must end after it starts")
+	}
+	if to.Sub(from) > scheduleHorizonDays*24*time.Hour {
+		return nil, fmt.Errorf("schedule range too long (max %d days)", scheduleHorizonDays)
+	}
+
+	yard, err := s.GetYard(ctx, tenantID, yardLocation)
+	if err != nil {
+		return nil, err
+	}
+
+	bays, err := s.schedules.GetServiceBays(ctx, tenantID, yard.YardLocation)
+	if err != nil {
+		return nil, err
+	}
+
+	bookings, err := s.schedules.GetBookings(ctx, tenantID, ScheduleFilters{
+		YardLocation: yard.YardLocation,
+		From:         from,
+		To:           to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bookings: %w", err)
+	}
+
+	flagConflicts(bookings)
+
+	return &YardSchedule{
+		Yard:        *yard,
+		ServiceBays: bays,
+		From:        from,
+		To:          to,
+		Bookings:    bookings,
+	}, nil
+}
+
+// FindEarliestSlot suggests the first start at which the technician and a
+// bay (the requested one, or any active bay in the yard) are both free for
+// the whole job
+func (s *scheduleService) FindEarliestSlot(ctx context.Context, tenantID string, query SlotQuery) (*Slot, error) {
+	if query.EstimatedHours <= 0 {
+		return nil, fmt.Errorf("estimated hours must be positive")
+	}
+
+	yard, err := s.GetYard(ctx, tenantID, query.YardLocation)
+	if err != nil {
+		return nil, err
+	}
+
+	cal, err := newWorkCalendar(yard)
+	if err != nil {
+		return nil, err
+	}
+
+	var bayIDs []int
+	if query.ServiceBayID != nil {
+		bay, err := s.activeBayInYard(ctx, tenantID, *query.ServiceBayID, yard.YardLocation)
+		if err != nil {
+			return nil, err
+		}
+		bayIDs = []int{bay.ID}
+	} else {
+		bays, err := s.schedules.GetServiceBays(ctx, tenantID, yard.YardLocation)
+		if err != nil {
+			return nil, err
+		}
+		for _, bay := range bays {
+			bayIDs = append(bayIDs, bay.ID)
+		}
+	}
+
+	after := query.After
+	if now := s.now(); after.Before(now) {
+		after = now
+	}
+	after = roundUpToSlot(after)
+
+	var bookings []Booking
+	if query.AssignedToUserID != nil || len(bayIDs) > 0 {
+		bookings, err = s.schedules.GetBookings(ctx, tenantID, ScheduleFilters{
+			AssignedToUserID:   query.AssignedToUserID,
+			ServiceBayIDs:      bayIDs,
+			From:               after,
+			To:                 after.AddDate(0, 0, scheduleHorizonDays),
+			ExcludeWorkOrderID: query.WorkOrderID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get bookings: %w", err)
+		}
+	}
+
+	for _, start := range candidateStarts(cal, after, bookings) {
+		end, err := cal.end(start, query.EstimatedHours)
+		if err != nil {
+			break
+		}
+
+		if len(findConflicts(bookings, query.WorkOrderID, start, end, query.AssignedToUserID, nil)) > 0 {
+			continue
+		}
+
+		if len(bayIDs) == 0 {
+			return &Slot{Start: start, End: end}, nil
+		}
+
+		for _, bayID := range bayIDs {
+			bayID := bayID
+			if len(findConflicts(bookings, query.WorkOrderID, start, end, nil, &bayID)) == 0 {
+				return &Slot{Start: start, End: end, ServiceBayID: &bayID}, nil
+			}
+		}
+	}
+
+	return nil, ErrNoFreeSlot
+}
+
+// ScheduleWorkOrder books a work order or moves an existing booking. The
+// request describes the whole booking: a nil technician or bay frees it.
+func (s *scheduleService) ScheduleWorkOrder(ctx context.Context, tenantID string, userID int, req ScheduleRequest) (*WorkOrder, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	existing, err := s.repo.GetWorkOrderByID(ctx, tenantID, req.WorkOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work order %d: %w", req.WorkOrderID, err)
+	}
+
+	if !IsSchedulable(existing.Status) {
+		return nil, fmt.Errorf("%w: status is %s", ErrNotSchedulable, existing.Status)
+	}
+
+	if existing.EstimatedHours == nil || *existing.EstimatedHours <= 0 {
+		return nil, fmt.Errorf("%w: estimated hours are required", ErrNotSchedulable)
+	}
+
+	if req.Start.IsZero() {
+		return nil, fmt.Errorf("validation failed: start time is required")
+	}
+
+	yardLocation := strings.TrimSpace(req.YardLocation)
+	if yardLocation == "" {
+		yardLocation = derefString(existing.YardLocation)
+	}
+
+	yard, err := s.GetYard(ctx, tenantID, yardLocation)
+	if err != nil {
+		return nil, err
+	}
+
+	cal, err := newWorkCalendar(yard)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ServiceBayID != nil {
+		if _, err := s.activeBayInYard(ctx, tenantID, *req.ServiceBayID, yard.YardLocation); err != nil {
+			return nil, err
+		}
+	}
+
+	end, err := cal.end(req.Start, *existing.EstimatedHours)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *existing
+	updated.YardLocation = &yard.YardLocation
+	updated.ServiceBayID = req.ServiceBayID
+	updated.AssignedToUserID = req.AssignedToUserID
+	updated.ScheduledDate = &req.Start
+	updated.ScheduledEnd = &end
+
+	// Checked again under lock when saving; this catches the common case
+	// without opening a transaction
+	if req.AssignedToUserID != nil || req.ServiceBayID != nil {
+		var bayIDs []int
+		if req.ServiceBayID != nil {
+			bayIDs = []int{*req.ServiceBayID}
+		}
+
+		bookings, err := s.schedules.GetBookings(ctx, tenantID, ScheduleFilters{
+			AssignedToUserID:   req.AssignedToUserID,
+			ServiceBayIDs:      bayIDs,
+			From:               req.Start,
+			To:                 end,
+			ExcludeWorkOrderID: existing.ID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get bookings: %w", err)
+		}
+
+		conflicts := findConflicts(bookings, existing.ID, req.Start, end, req.AssignedToUserID, req.ServiceBayID)
+		if len(conflicts) > 0 {
+			return nil, &ConflictError{Conflicts: conflicts}
+		}
+	}
+
+	history := diffWorkOrder(existing, &updated, userID)
+	if err := s.schedules.SaveSchedule(ctx, tenantID, &updated, existing.Status, history); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// UnscheduleWorkOrder takes a work order off the calendar, keeping its yard
+// and technician assignment
+func (s *scheduleService) UnscheduleWorkOrder(ctx context.Context, tenantID string, userID, workOrderID int) (*WorkOrder, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	existing, err := s.repo.GetWorkOrderByID(ctx, tenantID, workOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work order %d: %w", workOrderID, err)
+	}
+
+	if !IsSchedulable(existing.Status) {
+		return nil, fmt.Errorf("%w: status is %s", ErrNotSchedulable, existing.Status)
+	}
+
+	if existing.ScheduledDate == nil {
+		return existing, nil
+	}
+
+	updated := *existing
+	updated.ServiceBayID = nil
+	updated.ScheduledDate = nil
+	updated.ScheduledEnd = nil
+
+	history := diffWorkOrder(existing, &updated, userID)
+	if err := s.schedules.SaveSchedule(ctx, tenantID, &updated, existing.Status, history); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (s *scheduleService) activeBayInYard(ctx context.Context, tenantID string, id int, yardLocation string) (*ServiceBay, error) {
+	bay, err := s.schedules.GetServiceBay(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bay.IsActive || bay.YardLocation != yardLocation {
+		return nil, fmt.Errorf("%w: bay %d is not active in %s", ErrServiceBayNotFound, id, yardLocation)
+	}
+
+	return bay, nil
+}
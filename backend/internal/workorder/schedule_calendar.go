@@ -0,0 +1,258 @@
+// backend/internal/workorder/schedule_calendar.go
+package workorder
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	defaultYardTimeZone = "America/Los_Angeles"
+
+	// scheduleHorizonDays bounds how far ahead bookings and slot searches look
+	scheduleHorizonDays = 90
+
+	// slotGranularity is the step suggested start times are rounded up to
+	slotGranularity = 15 * time.Minute
+)
+
+// defaultYardHours apply to yards that have not configured their own
+var defaultYardHours = []YardHours{
+	{Weekday: time.Monday, OpensAt: "07:00", ClosesAt: "17:00"},
+	{Weekday: time.Tuesday, OpensAt: "07:00", ClosesAt: "17:00"},
+	{Weekday: time.Wednesday, OpensAt: "07:00", ClosesAt: "17:00"},
+	{Weekday: time.Thursday, OpensAt: "07:00", ClosesAt: "17:00"},
+	{Weekday: time.Friday, OpensAt: "07:00", ClosesAt: "17:00"},
+}
+
+// capacityStatuses are the statuses whose bookings hold a technician or bay
+var capacityStatuses = []WorkOrderStatus{
+	StatusDraft, StatusPending, StatusApproved, StatusInProgress, StatusOnHold,
+}
+
+// IsSchedulable reports whether a work order may be booked or moved
+func IsSchedulable(status WorkOrderStatus) bool {
+	switch status {
+	case StatusDraft, StatusPending, StatusApproved, StatusOnHold:
+		return true
+	default:
+		return false
+	}
+}
+
+type dayHours struct {
+	opens  int // Minutes after local midnight
+	closes int
+}
+
+// workCalendar answers working-time questions for one yard. A booking
+// consumes every working minute between its start and end, so two bookings
+// share working time exactly when their [start, end) ranges overlap.
+type workCalendar struct {
+	loc      *time.Location
+	hours    map[time.Weekday]dayHours
+	closures map[string]bool
+}
+
+func newWorkCalendar(yard *Yard) (*workCalendar, error) {
+	zone := yard.TimeZone
+	if zone == "" {
+		zone = defaultYardTimeZone
+	}
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q: %w", zone, err)
+	}
+
+	hours := yard.Hours
+	if len(hours) == 0 {
+		hours = defaultYardHours
+	}
+
+	c := &workCalendar{
+		loc:      loc,
+		hours:    make(map[time.Weekday]dayHours),
+		closures: make(map[string]bool),
+	}
+
+	for _, h := range hours {
+		if h.Weekday < time.Sunday || h.Weekday > time.Saturday {
+			return nil, fmt.Errorf("invalid weekday: %d", h.Weekday)
+		}
+		if _, exists := c.hours[h.Weekday]; exists {
+			return nil, fmt.Errorf("duplicate hours for %s", h.Weekday)
+		}
+		opens, err := parseClock(h.OpensAt)
+		if err != nil {
+			return nil, err
+		}
+		closes, err := parseClock(h.ClosesAt)
+		if err != nil {
+			return nil, err
+		}
+		if closes <= opens {
+			return nil, fmt.Errorf("%s closes before it opens", h.Weekday)
+		}
+		c.hours[h.Weekday] = dayHours{opens: opens, closes: closes}
+	}
+
+	for _, closure := range yard.Closures {
+		c.closures[closure.Date.Format("2006-01-02")] = true
+	}
+
+	return c, nil
+}
+
+// parseClock converts "15:04" to minutes after midnight
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// window returns the working hours on the local day containing t
+func (c *workCalendar) window(t time.Time) (time.Time, time.Time, bool) {
+	local := t.In(c.loc)
+	if c.closures[local.Format("2006-01-02")] {
+		return time.Time{}, time.Time{}, false
+	}
+
+	h, ok := c.hours[local.Weekday()]
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+
+	y, m, d := local.Date()
+	opens := time.Date(y, m, d, 0, h.opens, 0, 0, c.loc)
+	closes := time.Date(y, m, d, 0, h.closes, 0, 0, c.loc)
+	return opens, closes, true
+}
+
+func (c *workCalendar) nextDay(t time.Time) time.Time {
+	y, m, d := t.In(c.loc).Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, c.loc)
+}
+
+func (c *workCalendar) isOpenAt(t time.Time) bool {
+	opens, closes, ok := c.window(t)
+	return ok && !t.Before(opens) && t.Before(closes)
+}
+
+// nextOpen returns the earliest working moment at or after t
+func (c *workCalendar) nextOpen(t time.Time) (time.Time, bool) {
+	cursor := t
+	for i := 0; i <= scheduleHorizonDays; i++ {
+		if opens, closes, ok := c.window(cursor); ok && cursor.Before(closes) {
+			if cursor.Before(opens) {
+				return opens, true
+			}
+			return cursor, true
+		}
+		cursor = c.nextDay(cursor)
+	}
+	return time.Time{}, false
+}
+
+// end returns when a job starting at start finishes after consuming the
+// given number of working hours
+func (c *workCalendar) end(start time.Time, hours float64) (time.Time, error) {
+	if !c.isOpenAt(start) {
+		return time.Time{}, ErrOutsideYardHours
+	}
+
+	remaining := time.Duration(math.Round(hours * float64(time.Hour)))
+	cursor := start
+	for i := 0; i <= scheduleHorizonDays; i++ {
+		if opens, closes, ok := c.window(cursor); ok && cursor.Before(closes) {
+			if cursor.Before(opens) {
+				cursor = opens
+			}
+			available := closes.Sub(cursor)
+			if remaining <= available {
+				return cursor.Add(remaining), nil
+			}
+			remaining -= available
+		}
+		cursor = c.nextDay(cursor)
+	}
+
+	return time.Time{}, fmt.Errorf("job of %.2f hours does not fit within %d days", hours, scheduleHorizonDays)
+}
+
+// findConflicts lists bookings that overlap [start, end) and share the
+// technician or service bay
+func findConflicts(bookings []Booking, workOrderID int, start, end time.Time, technicianID, serviceBayID *int) []ScheduleConflict {
+	var conflicts []ScheduleConflict
+	for _, b := range bookings {
+		if b.WorkOrderID == workOrderID || !b.Start.Before(end) || !start.Before(b.End) {
+			continue
+		}
+		if technicianID != nil && b.AssignedToUserID != nil && *b.AssignedToUserID == *technicianID {
+			conflicts = append(conflicts, newConflict(ResourceTechnician, *technicianID, b))
+		}
+		if serviceBayID != nil && b.ServiceBayID != nil && *b.ServiceBayID == *serviceBayID {
+			conflicts = append(conflicts, newConflict(ResourceServiceBay, *serviceBayID, b))
+		}
+	}
+	return conflicts
+}
+
+func newConflict(resource ScheduleResource, resourceID int, b Booking) ScheduleConflict {
+	return ScheduleConflict{
+		Resource:        resource,
+		ResourceID:      resourceID,
+		WorkOrderID:     b.WorkOrderID,
+		WorkOrderNumber: b.WorkOrderNumber,
+		Start:           b.Start,
+		End:             b.End,
+	}
+}
+
+// flagConflicts marks bookings on a calendar that overlap each other, which
+// can happen when yard hours change after jobs were booked
+func flagConflicts(bookings []Booking) {
+	for i := range bookings {
+		b := &bookings[i]
+		b.Conflicts = findConflicts(bookings, b.WorkOrderID, b.Start, b.End, b.AssignedToUserID, b.ServiceBayID)
+	}
+}
+
+// candidateStarts are the only times the earliest free slot can begin: the
+// first working moment after the search start, or the first working moment
+// after an existing booking ends
+func candidateStarts(cal *workCalendar, after time.Time, bookings []Booking) []time.Time {
+	seen := make(map[int64]bool)
+	var starts []time.Time
+
+	add := func(t time.Time) {
+		if t.Before(after) {
+			t = after
+		}
+		opens, ok := cal.nextOpen(t)
+		if !ok || seen[opens.UnixNano()] {
+			return
+		}
+		seen[opens.UnixNano()] = true
+		starts = append(starts, opens)
+	}
+
+	add(after)
+	for _, b := range bookings {
+		add(b.End)
+	}
+
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+	return starts
+}
+
+func roundUpToSlot(t time.Time) time.Time {
+	rounded := t.Truncate(slotGranularity)
+	if rounded.Before(t) {
+		rounded = rounded.Add(slotGranularity)
+	}
+	return rounded
+}
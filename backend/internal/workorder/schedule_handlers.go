@@ -0,0 +1,345 @@
+// backend/internal/workorder/schedule_handlers.go
+package workorder
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"oilgas-backend/internal/auth"
+)
+
+type ScheduleHandlers struct {
+	service ScheduleService
+}
+
+func NewScheduleHandlers(service ScheduleService) *ScheduleHandlers {
+	return &ScheduleHandlers{service: service}
+}
+
+func (h *ScheduleHandlers) RegisterRoutes(router *gin.RouterGroup, authMiddleware *auth.Middleware) {
+	staff := authMiddleware.RequireRole(auth.RoleOperator, auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin)
+	dispatchers := authMiddleware.RequireRole(auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin)
+	admins := authMiddleware.RequireRole(auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin)
+
+	yards := router.Group("/yards")
+	yards.Use(authMiddleware.RequireAuth())
+	yards.Use(staff)
+
+	yards.GET("/:yard", h.GetYard)
+	yards.PUT("/:yard", admins, h.SaveYard)
+	yards.GET("/:yard/bays", h.ListServiceBays)
+	yards.POST("/:yard/bays", admins, h.CreateServiceBay)
+	yards.GET("/:yard/schedule", h.GetYardSchedule)
+	yards.GET("/:yard/schedule/slots", h.FindEarliestSlot)
+
+	bays := router.Group("/service-bays")
+	bays.Use(authMiddleware.RequireAuth())
+	bays.Use(admins)
+
+	bays.DELETE("/:id", h.DeactivateServiceBay)
+
+	workOrders := router.Group("/workorders")
+	workOrders.Use(authMiddleware.RequireAuth())
+
+	workOrders.PUT("/:id/schedule", dispatchers, h.ScheduleWorkOrder)
+	workOrders.DELETE("/:id/schedule", dispatchers, h.UnscheduleWorkOrder)
+}
+
+func (h *ScheduleHandlers) GetYard(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	yardLocation, ok := h.authorizeYard(c, tenantID)
+	if !ok {
+		return
+	}
+
+	yard, err := h.service.GetYard(c.Request.Context(), tenantID, yardLocation)
+	if err != nil {
+		c.JSON(scheduleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, yard)
+}
+
+func (h *ScheduleHandlers) SaveYard(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	yardLocation, ok := h.authorizeYard(c, tenantID)
+	if !ok {
+		return
+	}
+
+	var yard Yard
+	if err := c.ShouldBindJSON(&yard); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	yard.YardLocation = yardLocation
+
+	if err := h.service.SaveYard(c.Request.Context(), tenantID, &yard); err != nil {
+		c.JSON(scheduleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, yard)
+}
+
+func (h *ScheduleHandlers) ListServiceBays(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	yardLocation, ok := h.authorizeYard(c, tenantID)
+	if !ok {
+		return
+	}
+
+	bays, err := h.service.ListServiceBays(c.Request.Context(), tenantID, yardLocation)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get service bays"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  bays,
+		"total": len(bays),
+	})
+}
+
+func (h *ScheduleHandlers) CreateServiceBay(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	yardLocation, ok := h.authorizeYard(c, tenantID)
+	if !ok {
+		return
+	}
+
+	var bay ServiceBay
+	if err := c.ShouldBindJSON(&bay); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	bay.YardLocation = yardLocation
+
+	if err := h.service.CreateServiceBay(c.Request.Context(), tenantID, &bay); err != nil {
+		c.JSON(scheduleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, bay)
+}
+
+func (h *ScheduleHandlers) DeactivateServiceBay(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid service bay ID"})
+		return
+	}
+
+	if err := h.service.DeactivateServiceBay(c.Request.Context(), tenantID, id); err != nil {
+		c.JSON(scheduleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Service bay deactivated"})
+}
+
+// GetYardSchedule returns the calendar between from and to (YYYY-MM-DD,
+// inclusive), defaulting to the next seven days
+func (h *ScheduleHandlers) GetYardSchedule(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	yardLocation, ok := h.authorizeYard(c, tenantID)
+	if !ok {
+		return
+	}
+
+	y, m, d := time.Now().Date()
+	from := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	if value := c.Query("from"); value != "" {
+		t, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date, expected YYYY-MM-DD"})
+			return
+		}
+		from = t
+		to = from.AddDate(0, 0, 7)
+	}
+
+	if value := c.Query("to"); value != "" {
+		t, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date, expected YYYY-MM-DD"})
+			return
+		}
+		to = t.AddDate(0, 0, 1)
+	}
+
+	schedule, err := h.service.GetYardSchedule(c.Request.Context(), tenantID, yardLocation, from, to)
+	if err != nil {
+		c.JSON(scheduleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+func (h *ScheduleHandlers) FindEarliestSlot(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	yardLocation, ok := h.authorizeYard(c, tenantID)
+	if !ok {
+		return
+	}
+
+	query := SlotQuery{YardLocation: yardLocation}
+
+	hours, err := strconv.ParseFloat(c.Query("hours"), 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "hours is required"})
+		return
+	}
+	query.EstimatedHours = hours
+
+	if workOrderID := c.Query("work_order_id"); workOrderID != "" {
+		if id, err := strconv.Atoi(workOrderID); err == nil {
+			query.WorkOrderID = id
+		}
+	}
+
+	if technicianID := c.Query("technician_id"); technicianID != "" {
+		if id, err := strconv.Atoi(technicianID); err == nil {
+			query.AssignedToUserID = &id
+		}
+	}
+
+	if bayID := c.Query("service_bay_id"); bayID != "" {
+		if id, err := strconv.Atoi(bayID); err == nil {
+			query.ServiceBayID = &id
+		}
+	}
+
+	if after := c.Query("after"); after != "" {
+		t, err := time.Parse(time.RFC3339, after)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid after time, expected RFC 3339"})
+			return
+		}
+		query.After = t
+	}
+
+	slot, err := h.service.FindEarliestSlot(c.Request.Context(), tenantID, query)
+	if err != nil {
+		c.JSON(scheduleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, slot)
+}
+
+// ScheduleWorkOrder books or moves a work order; the calendar's
+// drag-to-reschedule sends the whole booking with its new start
+func (h *ScheduleHandlers) ScheduleWorkOrder(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid work order ID"})
+		return
+	}
+
+	var req ScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.WorkOrderID = id
+
+	if req.YardLocation != "" && !canAccessYard(user, tenantID, req.YardLocation) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "No access to this yard"})
+		return
+	}
+
+	wo, err := h.service.ScheduleWorkOrder(c.Request.Context(), tenantID, user.ID, req)
+	if err != nil {
+		var conflict *ConflictError
+		if errors.As(err, &conflict) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":     err.Error(),
+				"conflicts": conflict.Conflicts,
+			})
+			return
+		}
+		c.JSON(scheduleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, wo)
+}
+
+func (h *ScheduleHandlers) UnscheduleWorkOrder(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid work order ID"})
+		return
+	}
+
+	wo, err := h.service.UnscheduleWorkOrder(c.Request.Context(), tenantID, c.GetInt("user_id"), id)
+	if err != nil {
+		c.JSON(scheduleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, wo)
+}
+
+func (h *ScheduleHandlers) authorizeYard(c *gin.Context, tenantID string) (string, bool) {
+	user, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return "", false
+	}
+
+	yardLocation := c.Param("yard")
+	if !canAccessYard(user, tenantID, yardLocation) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "No access to this yard"})
+		return "", false
+	}
+
+	return yardLocation, true
+}
+
+// canAccessYard lets tenant admins see every yard; everyone else needs the
+// yard in their tenant access
+func canAccessYard(user *auth.User, tenantID, yardLocation string) bool {
+	if user == nil || !user.CanAccessTenant(tenantID) {
+		return false
+	}
+
+	switch user.Role {
+	case auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin:
+		return true
+	}
+
+	return user.HasAccessToYard(tenantID, yardLocation)
+}
+
+func scheduleErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrWorkOrderNotFound), errors.Is(err, ErrServiceBayNotFound), errors.Is(err, ErrYardNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrScheduleConflict), errors.Is(err, ErrStatusConflict),
+		errors.Is(err, ErrNotSchedulable), errors.Is(err, ErrNoFreeSlot):
+		return http.StatusConflict
+	case errors.Is(err, ErrOutsideYardHours):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusBadRequest
+	}
+}
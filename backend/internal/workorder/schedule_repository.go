@@ -0,0 +1,445 @@
+// backend/internal/workorder/schedule_repository.go
+package workorder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"oilgas-backend/internal/shared/database"
+)
+
+type ScheduleRepository interface {
+	GetYard(ctx context.Context, tenantID, yardLocation string) (*Yard, error)
+	SaveYard(ctx context.Context, tenantID string, yard *Yard) error
+
+	GetServiceBays(ctx context.Context, tenantID, yardLocation string) ([]ServiceBay, error)
+	GetServiceBay(ctx context.Context, tenantID string, id int) (*ServiceBay, error)
+	CreateServiceBay(ctx context.Context, tenantID string, bay *ServiceBay) error
+	DeactivateServiceBay(ctx context.Context, tenantID string, id int) error
+
+	GetBookings(ctx context.Context, tenantID string, filters ScheduleFilters) ([]Booking, error)
+	SaveSchedule(ctx context.Context, tenantID string, wo *WorkOrder, from WorkOrderStatus, history []WorkOrderHistory) error
+}
+
+type scheduleRepository struct {
+	dbManager *database.DatabaseManager
+}
+
+func NewScheduleRepository(dbManager *database.DatabaseManager) ScheduleRepository {
+	return &scheduleRepository{dbManager: dbManager}
+}
+
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func (r *scheduleRepository) GetYard(ctx context.Context, tenantID, yardLocation string) (*Yard, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	yard := Yard{TenantID: tenantID, YardLocation: yardLocation}
+	err = db.QueryRowContext(ctx, `
+		SELECT name, time_zone FROM store.yards
+		WHERE tenant_id = $1 AND yard_location = $2`,
+		tenantID, yardLocation).Scan(&yard.Name, &yard.TimeZone)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrYardNotFound
+		}
+		return nil, fmt.Errorf("failed to get yard: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT weekday, TO_CHAR(opens_at, 'HH24:MI'), TO_CHAR(closes_at, 'HH24:MI')
+		FROM store.yard_hours
+		WHERE tenant_id = $1 AND yard_location = $2
+		ORDER BY weekday`, tenantID, yardLocation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get yard hours: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var h YardHours
+		if err := rows.Scan(&h.Weekday, &h.OpensAt, &h.ClosesAt); err != nil {
+			return nil, fmt.Errorf("failed to scan yard hours: %w", err)
+		}
+		yard.Hours = append(yard.Hours, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	closureRows, err := db.QueryContext(ctx, `
+		SELECT closed_on, reason
+		FROM store.yard_closures
+		WHERE tenant_id = $1 AND yard_location = $2 AND closed_on >= CURRENT_DATE - 30
+		ORDER BY closed_on`, tenantID, yardLocation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get yard closures: %w", err)
+	}
+	defer closureRows.Close()
+
+	for closureRows.Next() {
+		var c YardClosure
+		if err := closureRows.Scan(&c.Date, &c.Reason); err != nil {
+			return nil, fmt.Errorf("failed to scan yard closure: %w", err)
+		}
+		yard.Closures = append(yard.Closures, c)
+	}
+
+	return &yard, closureRows.Err()
+}
+
+// SaveYard creates or replaces a yard's calendar: hours and upcoming closures
+// are rewritten as a whole so the stored calendar always matches the request
+func (r *scheduleRepository) SaveYard(ctx context.Context, tenantID string, yard *Yard) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO store.yards (tenant_id, yard_location, name, time_zone)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (tenant_id, yard_location)
+		DO UPDATE SET name = EXCLUDED.name, time_zone = EXCLUDED.time_zone, updated_at = NOW()`,
+		tenantID, yard.YardLocation, yard.Name, yard.TimeZone)
+	if err != nil {
+		return fmt.Errorf("failed to save yard: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM store.yard_hours WHERE tenant_id = $1 AND yard_location = $2`,
+		tenantID, yard.YardLocation); err != nil {
+		return fmt.Errorf("failed to clear yard hours: %w", err)
+	}
+
+	for _, h := range yard.Hours {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO store.yard_hours (tenant_id, yard_location, weekday, opens_at, closes_at)
+			VALUES ($1, $2, $3, $4, $5)`,
+			tenantID, yard.YardLocation, int(h.Weekday), h.OpensAt, h.ClosesAt)
+		if err != nil {
+			return fmt.Errorf("failed to save yard hours: %w", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM store.yard_closures
+		WHERE tenant_id = $1 AND yard_location = $2 AND closed_on >= CURRENT_DATE - 30`,
+		tenantID, yard.YardLocation); err != nil {
+		return fmt.Errorf("failed to clear yard closures: %w", err)
+	}
+
+	for _, c := range yard.Closures {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO store.yard_closures (tenant_id, yard_location, closed_on, reason)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (tenant_id, yard_location, closed_on) DO UPDATE SET reason = EXCLUDED.reason`,
+			tenantID, yard.YardLocation, c.Date, c.Reason)
+		if err != nil {
+			return fmt.Errorf("failed to save yard closure: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit yard: %w", err)
+	}
+
+	yard.TenantID = tenantID
+	return nil
+}
+
+func (r *scheduleRepository) GetServiceBays(ctx context.Context, tenantID, yardLocation string) ([]ServiceBay, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, tenant_id, yard_location, name, is_active, created_at
+		FROM store.service_bays
+		WHERE tenant_id = $1 AND yard_location = $2 AND is_active = true
+		ORDER BY name`, tenantID, yardLocation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service bays: %w", err)
+	}
+	defer rows.Close()
+
+	var bays []ServiceBay
+	for rows.Next() {
+		var b ServiceBay
+		if err := rows.Scan(&b.ID, &b.TenantID, &b.YardLocation, &b.Name, &b.IsActive, &b.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan service bay: %w", err)
+		}
+		bays = append(bays, b)
+	}
+
+	return bays, rows.Err()
+}
+
+func (r *scheduleRepository) GetServiceBay(ctx context.Context, tenantID string, id int) (*ServiceBay, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	var b ServiceBay
+	err = db.QueryRowContext(ctx, `
+		SELECT id, tenant_id, yard_location, name, is_active, created_at
+		FROM store.service_bays
+		WHERE id = $1 AND tenant_id = $2`, id, tenantID,
+	).Scan(&b.ID, &b.TenantID, &b.YardLocation, &b.Name, &b.IsActive, &b.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrServiceBayNotFound
+		}
+		return nil, fmt.Errorf("failed to get service bay: %w", err)
+	}
+
+	return &b, nil
+}
+
+func (r *scheduleRepository) CreateServiceBay(ctx context.Context, tenantID string, bay *ServiceBay) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	err = db.QueryRowContext(ctx, `
+		INSERT INTO store.service_bays (tenant_id, yard_location, name)
+		VALUES ($1, $2, $3)
+		RETURNING id, is_active, created_at`,
+		tenantID, bay.YardLocation, bay.Name,
+	).Scan(&bay.ID, &bay.IsActive, &bay.CreatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("validation failed: service bay %q already exists in %s", bay.Name, bay.YardLocation)
+		}
+		return fmt.Errorf("failed to create service bay: %w", err)
+	}
+
+	bay.TenantID = tenantID
+	return nil
+}
+
+func (r *scheduleRepository) DeactivateServiceBay(ctx context.Context, tenantID string, id int) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	result, err := db.ExecContext(ctx, `
+		UPDATE store.service_bays SET is_active = false
+		WHERE id = $1 AND tenant_id = $2 AND is_active = true`, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate service bay: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check deactivated rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrServiceBayNotFound
+	}
+
+	return nil
+}
+
+func (r *scheduleRepository) GetBookings(ctx context.Context, tenantID string, filters ScheduleFilters) ([]Booking, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	return queryBookings(ctx, db, tenantID, filters)
+}
+
+// SaveSchedule books, moves or unbooks a work order. Bookings for the same
+// technician or bay are serialized with advisory locks and re-checked for
+// overlaps inside the transaction, so two dispatchers cannot double-book.
+func (r *scheduleRepository) SaveSchedule(ctx context.Context, tenantID string, wo *WorkOrder, from WorkOrderStatus, history []WorkOrderHistory) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if wo.ScheduledDate != nil && wo.ScheduledEnd != nil {
+		// Always technician before bay so concurrent bookings lock in one order
+		var bayIDs []int
+		if wo.AssignedToUserID != nil {
+			if err := lockScheduleResourceTx(ctx, tx, tenantID, ResourceTechnician, *wo.AssignedToUserID); err != nil {
+				return err
+			}
+		}
+		if wo.ServiceBayID != nil {
+			if err := lockScheduleResourceTx(ctx, tx, tenantID, ResourceServiceBay, *wo.ServiceBayID); err != nil {
+				return err
+			}
+			bayIDs = []int{*wo.ServiceBayID}
+		}
+
+		if wo.AssignedToUserID != nil || wo.ServiceBayID != nil {
+			bookings, err := queryBookings(ctx, tx, tenantID, ScheduleFilters{
+				AssignedToUserID:   wo.AssignedToUserID,
+				ServiceBayIDs:      bayIDs,
+				From:               *wo.ScheduledDate,
+				To:                 *wo.ScheduledEnd,
+				ExcludeWorkOrderID: wo.ID,
+			})
+			if err != nil {
+				return err
+			}
+
+			conflicts := findConflicts(bookings, wo.ID, *wo.ScheduledDate, *wo.ScheduledEnd, wo.AssignedToUserID, wo.ServiceBayID)
+			if len(conflicts) > 0 {
+				return &ConflictError{Conflicts: conflicts}
+			}
+		}
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		UPDATE store.workorders
+		SET yard_location = $3, service_bay_id = $4, assigned_to_user_id = $5,
+		    scheduled_date = $6, scheduled_end = $7, updated_at = NOW()
+		WHERE id = $1 AND tenant_id = $2 AND status = $8 AND is_active = true
+		RETURNING updated_at`,
+		wo.ID, tenantID, wo.YardLocation, wo.ServiceBayID, wo.AssignedToUserID,
+		wo.ScheduledDate, wo.ScheduledEnd, from,
+	).Scan(&wo.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrStatusConflict
+		}
+		return fmt.Errorf("failed to save schedule: %w", err)
+	}
+
+	for i := range history {
+		history[i].WorkOrderID = wo.ID
+		if err := insertHistory(ctx, tx, &history[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit schedule: %w", err)
+	}
+
+	return nil
+}
+
+func lockScheduleResourceTx(ctx context.Context, tx *sql.Tx, tenantID string, resource ScheduleResource, id int) error {
+	key := fmt.Sprintf("schedule:%s:%s:%d", tenantID, resource, id)
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, key); err != nil {
+		return fmt.Errorf("failed to lock %s schedule: %w", strings.ToLower(string(resource)), err)
+	}
+	return nil
+}
+
+// queryBookings loads scheduled work orders overlapping [From, To). When a
+// technician or bays are given, bookings for either are returned regardless
+// of yard, since a technician can only be in one place at a time.
+func queryBookings(ctx context.Context, q queryer, tenantID string, filters ScheduleFilters) ([]Booking, error) {
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", argIndex))
+	args = append(args, tenantID)
+	argIndex++
+
+	conditions = append(conditions, "is_active = true",
+		"scheduled_date IS NOT NULL", "scheduled_end IS NOT NULL")
+
+	statuses := make([]string, len(capacityStatuses))
+	for i, status := range capacityStatuses {
+		statuses[i] = string(status)
+	}
+	conditions = append(conditions, fmt.Sprintf("status = ANY($%d)", argIndex))
+	args = append(args, pq.Array(statuses))
+	argIndex++
+
+	conditions = append(conditions, fmt.Sprintf("scheduled_date < $%d", argIndex))
+	args = append(args, filters.To)
+	argIndex++
+
+	conditions = append(conditions, fmt.Sprintf("scheduled_end > $%d", argIndex))
+	args = append(args, filters.From)
+	argIndex++
+
+	if filters.YardLocation != "" {
+		conditions = append(conditions, fmt.Sprintf("yard_location = $%d", argIndex))
+		args = append(args, filters.YardLocation)
+		argIndex++
+	}
+
+	var resources []string
+	if filters.AssignedToUserID != nil {
+		resources = append(resources, fmt.Sprintf("assigned_to_user_id = $%d", argIndex))
+		args = append(args, *filters.AssignedToUserID)
+		argIndex++
+	}
+	if len(filters.ServiceBayIDs) > 0 {
+		resources = append(resources, fmt.Sprintf("service_bay_id = ANY($%d)", argIndex))
+		args = append(args, pq.Array(filters.ServiceBayIDs))
+		argIndex++
+	}
+	if len(resources) > 0 {
+		conditions = append(conditions, "("+strings.Join(resources, " OR ")+")")
+	}
+
+	if filters.ExcludeWorkOrderID > 0 {
+		conditions = append(conditions, fmt.Sprintf("id <> $%d", argIndex))
+		args = append(args, filters.ExcludeWorkOrderID)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, work_order_number, customer_id, status, priority, description,
+		       COALESCE(yard_location, ''), service_bay_id, assigned_to_user_id,
+		       COALESCE(estimated_hours, 0), scheduled_date, scheduled_end, due_date
+		FROM store.workorders
+		WHERE %s
+		ORDER BY scheduled_date`, strings.Join(conditions, " AND "))
+
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bookings: %w", err)
+	}
+	defer rows.Close()
+
+	var bookings []Booking
+	for rows.Next() {
+		var b Booking
+		err := rows.Scan(
+			&b.WorkOrderID, &b.WorkOrderNumber, &b.CustomerID, &b.Status, &b.Priority, &b.Description,
+			&b.YardLocation, &b.ServiceBayID, &b.AssignedToUserID,
+			&b.EstimatedHours, &b.Start, &b.End, &b.DueDate,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan booking: %w", err)
+		}
+		bookings = append(bookings, b)
+	}
+
+	return bookings, rows.Err()
+}
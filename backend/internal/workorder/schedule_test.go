@@ -0,0 +1,272 @@
+// backend/internal/workorder/schedule_test.go
+package workorder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type mockScheduleRepository struct {
+	mock.Mock
+}
+
+func (m *mockScheduleRepository) GetYard(ctx context.Context, tenantID, yardLocation string) (*Yard, error) {
+	args := m.Called(ctx, tenantID, yardLocation)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Yard), args.Error(1)
+}
+
+func (m *mockScheduleRepository) SaveYard(ctx context.Context, tenantID string, yard *Yard) error {
+	args := m.Called(ctx, tenantID, yard)
+	return args.Error(0)
+}
+
+func (m *mockScheduleRepository) GetServiceBays(ctx context.Context, tenantID, yardLocation string) ([]ServiceBay, error) {
+	args := m.Called(ctx, tenantID, yardLocation)
+	return args.Get(0).([]ServiceBay), args.Error(1)
+}
+
+func (m *mockScheduleRepository) GetServiceBay(ctx context.Context, tenantID string, id int) (*ServiceBay, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ServiceBay), args.Error(1)
+}
+
+func (m *mockScheduleRepository) CreateServiceBay(ctx context.Context, tenantID string, bay *ServiceBay) error {
+	args := m.Called(ctx, tenantID, bay)
+	return args.Error(0)
+}
+
+func (m *mockScheduleRepository) DeactivateServiceBay(ctx context.Context, tenantID string, id int) error {
+	args := m.Called(ctx, tenantID, id)
+	return args.Error(0)
+}
+
+func (m *mockScheduleRepository) GetBookings(ctx context.Context, tenantID string, filters ScheduleFilters) ([]Booking, error) {
+	args := m.Called(ctx, tenantID, filters)
+	return args.Get(0).([]Booking), args.Error(1)
+}
+
+func (m *mockScheduleRepository) SaveSchedule(ctx context.Context, tenantID string, wo *WorkOrder, from WorkOrderStatus, history []WorkOrderHistory) error {
+	args := m.Called(ctx, tenantID, wo, from, history)
+	return args.Error(0)
+}
+
+type ScheduleServiceTestSuite struct {
+	suite.Suite
+	service   *scheduleService
+	repo      *mockRepository
+	schedules *mockScheduleRepository
+	ctx       context.Context
+	tenantID  string
+	yard      *Yard
+	monday    time.Time
+}
+
+func (suite *ScheduleServiceTestSuite) SetupTest() {
+	suite.repo = &mockRepository{}
+	suite.schedules = &mockScheduleRepository{}
+	suite.monday = time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC)
+	suite.service = &scheduleService{
+		repo:      suite.repo,
+		schedules: suite.schedules,
+		now:       func() time.Time { return suite.monday.Add(6*time.Hour + 50*time.Minute) },
+	}
+	suite.ctx = context.Background()
+	suite.tenantID = "longbeach"
+	suite.yard = &Yard{
+		TenantID:     "longbeach",
+		YardLocation: "Long Beach",
+		Name:         "Long Beach",
+		TimeZone:     "UTC",
+		Hours:        defaultYardHours,
+	}
+}
+
+func TestScheduleServiceSuite(t *testing.T) {
+	suite.Run(t, new(ScheduleServiceTestSuite))
+}
+
+func (suite *ScheduleServiceTestSuite) at(day, hour int) time.Time {
+	return suite.monday.AddDate(0, 0, day).Add(time.Duration(hour) * time.Hour)
+}
+
+func (suite *ScheduleServiceTestSuite) TestFindEarliestSlot_SkipsBusyTechnicianAndBay() {
+	technician := 5
+	bays := []ServiceBay{
+		{ID: 1, YardLocation: "Long Beach", Name: "Bay 1", IsActive: true},
+		{ID: 2, YardLocation: "Long Beach", Name: "Bay 2", IsActive: true},
+	}
+
+	suite.schedules.On("GetYard", suite.ctx, suite.tenantID, "Long Beach").Return(suite.yard, nil)
+	suite.schedules.On("GetServiceBays", suite.ctx, suite.tenantID, "Long Beach").Return(bays, nil)
+	suite.schedules.On("GetBookings", suite.ctx, suite.tenantID, mock.MatchedBy(func(f ScheduleFilters) bool {
+		return *f.AssignedToUserID == technician && len(f.ServiceBayIDs) == 2 && f.From.Equal(suite.at(0, 7))
+	})).Return([]Booking{
+		{WorkOrderID: 20, AssignedToUserID: &technician, Start: suite.at(0, 7), End: suite.at(0, 12)},
+		{WorkOrderID: 21, ServiceBayID: &bays[0].ID, Start: suite.at(0, 12), End: suite.at(0, 17)},
+	}, nil)
+
+	slot, err := suite.service.FindEarliestSlot(suite.ctx, suite.tenantID, SlotQuery{
+		YardLocation:     "Long Beach",
+		EstimatedHours:   3,
+		AssignedToUserID: &technician,
+	})
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), suite.at(0, 12), slot.Start)
+	assert.Equal(suite.T(), suite.at(0, 15), slot.End)
+	assert.Equal(suite.T(), 2, *slot.ServiceBayID)
+}
+
+func (suite *ScheduleServiceTestSuite) TestScheduleWorkOrder_Success() {
+	technician := 5
+	bayID := 1
+	start := suite.at(0, 13)
+	existing := &WorkOrder{ID: 42, Status: StatusApproved, EstimatedHours: floatPtr(6)}
+
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(existing, nil)
+	suite.schedules.On("GetYard", suite.ctx, suite.tenantID, "Long Beach").Return(suite.yard, nil)
+	suite.schedules.On("GetServiceBay", suite.ctx, suite.tenantID, 1).
+		Return(&ServiceBay{ID: 1, YardLocation: "Long Beach", IsActive: true}, nil)
+	suite.schedules.On("GetBookings", suite.ctx, suite.tenantID, mock.Anything).Return([]Booking{}, nil)
+	suite.schedules.On("SaveSchedule", suite.ctx, suite.tenantID, mock.MatchedBy(func(wo *WorkOrder) bool {
+		// Four hours on Monday afternoon, the remaining two on Tuesday morning
+		return wo.ScheduledEnd.Equal(suite.at(1, 9)) && *wo.ServiceBayID == 1 && *wo.YardLocation == "Long Beach"
+	}), StatusApproved, mock.MatchedBy(func(h []WorkOrderHistory) bool {
+		actions := make(map[string]bool)
+		for _, entry := range h {
+			actions[entry.Action] = true
+		}
+		return actions["scheduled_date_changed"] && actions["service_bay_id_changed"] && actions["assigned_to_user_id_changed"]
+	})).Return(nil)
+
+	wo, err := suite.service.ScheduleWorkOrder(suite.ctx, suite.tenantID, 7, ScheduleRequest{
+		WorkOrderID:      42,
+		YardLocation:     "Long Beach",
+		ServiceBayID:     &bayID,
+		AssignedToUserID: &technician,
+		Start:            start,
+	})
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), suite.at(1, 9), *wo.ScheduledEnd)
+	suite.schedules.AssertExpectations(suite.T())
+}
+
+func (suite *ScheduleServiceTestSuite) TestScheduleWorkOrder_TechnicianOverbooked() {
+	technician := 5
+	existing := &WorkOrder{ID: 42, Status: StatusApproved, EstimatedHours: floatPtr(4)}
+
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(existing, nil)
+	suite.schedules.On("GetYard", suite.ctx, suite.tenantID, "Long Beach").Return(suite.yard, nil)
+	suite.schedules.On("GetBookings", suite.ctx, suite.tenantID, mock.Anything).Return([]Booking{
+		{WorkOrderID: 20, WorkOrderNumber: "LON-000020", AssignedToUserID: &technician, Start: suite.at(0, 10), End: suite.at(0, 14)},
+	}, nil)
+
+	_, err := suite.service.ScheduleWorkOrder(suite.ctx, suite.tenantID, 7, ScheduleRequest{
+		WorkOrderID:      42,
+		YardLocation:     "Long Beach",
+		AssignedToUserID: &technician,
+		Start:            suite.at(0, 8),
+	})
+
+	var conflict *ConflictError
+	require.True(suite.T(), errors.As(err, &conflict))
+	assert.True(suite.T(), errors.Is(err, ErrScheduleConflict))
+	assert.Len(suite.T(), conflict.Conflicts, 1)
+	assert.Equal(suite.T(), ResourceTechnician, conflict.Conflicts[0].Resource)
+	assert.Equal(suite.T(), "LON-000020", conflict.Conflicts[0].WorkOrderNumber)
+	suite.schedules.AssertNotCalled(suite.T(), "SaveSchedule")
+}
+
+func (suite *ScheduleServiceTestSuite) TestScheduleWorkOrder_Rejections() {
+	testCases := []struct {
+		name      string
+		workOrder *WorkOrder
+		start     time.Time
+		expectErr error
+	}{
+		{"in progress", &WorkOrder{ID: 42, Status: StatusInProgress, EstimatedHours: floatPtr(4)}, suite.at(0, 8), ErrNotSchedulable},
+		{"no estimate", &WorkOrder{ID: 42, Status: StatusApproved}, suite.at(0, 8), ErrNotSchedulable},
+		{"saturday", &WorkOrder{ID: 42, Status: StatusApproved, EstimatedHours: floatPtr(4)}, suite.at(5, 8), ErrOutsideYardHours},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(tc.workOrder, nil)
+			suite.schedules.On("GetYard", suite.ctx, suite.tenantID, "Long Beach").Return(suite.yard, nil)
+
+			_, err := suite.service.ScheduleWorkOrder(suite.ctx, suite.tenantID, 7, ScheduleRequest{
+				WorkOrderID:  42,
+				YardLocation: "Long Beach",
+				Start:        tc.start,
+			})
+
+			assert.True(suite.T(), errors.Is(err, tc.expectErr), "got %v", err)
+			suite.schedules.AssertNotCalled(suite.T(), "SaveSchedule")
+		})
+	}
+}
+
+func TestWorkCalendar_EndSkipsClosuresAndWeekends(t *testing.T) {
+	friday := time.Date(2025, time.June, 6, 15, 0, 0, 0, time.UTC)
+	yard := &Yard{
+		TimeZone: "UTC",
+		Closures: []YardClosure{{Date: time.Date(2025, time.June, 9, 0, 0, 0, 0, time.UTC)}},
+	}
+
+	cal, err := newWorkCalendar(yard)
+	require.NoError(t, err)
+
+	// Two hours Friday, nothing over the weekend or the closed Monday, two on Tuesday
+	end, err := cal.end(friday, 4)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, time.June, 10, 9, 0, 0, 0, time.UTC), end)
+
+	_, err = cal.end(friday.Add(3*time.Hour), 1)
+	assert.True(t, errors.Is(err, ErrOutsideYardHours))
+}
+
+func TestNewWorkCalendar_InvalidHours(t *testing.T) {
+	_, err := newWorkCalendar(&Yard{
+		TimeZone: "UTC",
+		Hours:    []YardHours{{Weekday: time.Monday, OpensAt: "17:00", ClosesAt: "07:00"}},
+	})
+	assert.Error(t, err)
+
+	_, err = newWorkCalendar(&Yard{
+		TimeZone: "UTC",
+		Hours:    []YardHours{{Weekday: time.Monday, OpensAt: "7am", ClosesAt: "17:00"}},
+	})
+	assert.Error(t, err)
+}
+
+func TestFlagConflicts(t *testing.T) {
+	technician := 5
+	base := time.Date(2025, time.June, 2, 7, 0, 0, 0, time.UTC)
+	bookings := []Booking{
+		{WorkOrderID: 1, AssignedToUserID: &technician, Start: base, End: base.Add(4 * time.Hour)},
+		{WorkOrderID: 2, AssignedToUserID: &technician, Start: base.Add(3 * time.Hour), End: base.Add(5 * time.Hour)},
+		{WorkOrderID: 3, AssignedToUserID: &technician, Start: base.Add(5 * time.Hour), End: base.Add(6 * time.Hour)},
+	}
+
+	flagConflicts(bookings)
+
+	assert.Len(t, bookings[0].Conflicts, 1)
+	assert.Equal(t, 2, bookings[0].Conflicts[0].WorkOrderID)
+	assert.Len(t, bookings[1].Conflicts, 1)
+	assert.Empty(t, bookings[2].Conflicts, "back-to-back bookings do not overlap")
+}
@@ -0,0 +1,678 @@
+// backend/internal/workorder/service.go
+package workorder
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"oilgas-backend/internal/shared/events"
+)
+
+type Service interface {
+	GetWorkOrder(ctx context.Context, tenantID string, id int) (*WorkOrder, error)
+	SearchWorkOrders(ctx context.Context, tenantID string, filters SearchFilters) ([]WorkOrder, int, error)
+	CreateWorkOrder(ctx context.Context, tenantID string, userID int, wo *WorkOrder) error
+	UpdateWorkOrder(ctx context.Context, tenantID string, userID int, wo *WorkOrder) error
+	TransitionStatus(ctx context.Context, tenantID string, userID, id int, to WorkOrderStatus, notes string) (*WorkOrder, error)
+	DeleteWorkOrder(ctx context.Context, tenantID string, userID, id int) error
+	AssignWorkOrder(ctx context.Context, tenantID string, userID, id int, assigneeID *int) (*WorkOrder, error)
+
+	AddItem(ctx context.Context, tenantID string, userID, workOrderID int, item *WorkOrderItem) error
+	RemoveItem(ctx context.Context, tenantID string, userID, workOrderID, itemID int) error
+	CompleteItem(ctx context.Context, tenantID string, userID, workOrderID, itemID int, notes string) (*WorkOrderItem, error)
+	GetHistory(ctx context.Context, tenantID string, workOrderID int) ([]WorkOrderHistory, error)
+}
+
+type service struct {
+	repo      Repository
+	publisher events.Publisher
+}
+
+func NewService(repo Repository, publisher events.Publisher) Service {
+	return &service{
+		repo:      repo,
+		publisher: publisher,
+	}
+}
+
+func (s *service) GetWorkOrder(ctx context.Context, tenantID string, id int) (*WorkOrder, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid work order ID: %d", id)
+	}
+
+	wo, err := s.repo.GetWorkOrderByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work order %d: %w", id, err)
+	}
+
+	items, err := s.repo.GetWorkOrderItems(ctx, tenantID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work order items: %w", err)
+	}
+	wo.Items = items
+
+	history, err := s.repo.GetWorkOrderHistory(ctx, tenantID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work order history: %w", err)
+	}
+	wo.History = history
+
+	return wo, nil
+}
+
+func (s *service) SearchWorkOrders(ctx context.Context, tenantID string, filters SearchFilters) ([]WorkOrder, int, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, 0, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if err := s.validateSearchFilters(&filters); err != nil {
+		return nil, 0, fmt.Errorf("validation failed: %w", err)
+	}
+
+	workOrders, total, err := s.repo.SearchWorkOrders(ctx, tenantID, filters)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search work orders: %w", err)
+	}
+
+	return workOrders, total, nil
+}
+
+func (s *service) CreateWorkOrder(ctx context.Context, tenantID string, userID int, wo *WorkOrder) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if wo != nil && wo.Priority == "" {
+		wo.Priority = PriorityMedium
+	}
+
+	if err := s.validateWorkOrder(wo); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := prepareInitialItems(wo); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	wo.TenantID = tenantID
+	wo.Status = StatusDraft
+	wo.ActualHours = nil
+	// Bookings are made through ScheduleService so they are conflict checked
+	wo.ScheduledDate = nil
+	wo.ScheduledEnd = nil
+	wo.ServiceBayID = nil
+	wo.CreatedByUserID = userID
+	wo.IsActive = true
+
+	history := &WorkOrderHistory{
+		ChangedByUserID: userID,
+		Action:          "created",
+		NewValue:        stringPtr(string(wo.Status)),
+	}
+
+	if err := s.repo.CreateWorkOrder(ctx, tenantID, wo, history); err != nil {
+		return fmt.Errorf("failed to create work order: %w", err)
+	}
+
+	s.publish(ctx, NewWorkOrderCreatedEvent(tenantID, wo.ID, wo.CustomerID, userID, string(wo.ServiceType)))
+	return nil
+}
+
+func (s *service) UpdateWorkOrder(ctx context.Context, tenantID string, userID int, wo *WorkOrder) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if wo == nil || wo.ID <= 0 {
+		return fmt.Errorf("invalid work order ID")
+	}
+
+	if err := s.validateWorkOrder(wo); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	existing, err := s.repo.GetWorkOrderByID(ctx, tenantID, wo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get work order %d: %w", wo.ID, err)
+	}
+
+	if !IsEditable(existing.Status) {
+		return fmt.Errorf("%w: status is %s", ErrNotEditable, existing.Status)
+	}
+
+	// Status, customer and ownership only change through dedicated operations
+	wo.TenantID = existing.TenantID
+	wo.CustomerID = existing.CustomerID
+	wo.WorkOrderNumber = existing.WorkOrderNumber
+	wo.Status = existing.Status
+	wo.CreatedByUserID = existing.CreatedByUserID
+	wo.ActualHours = existing.ActualHours // Derived from time entries
+	wo.ScheduledDate = existing.ScheduledDate
+	wo.ScheduledEnd = existing.ScheduledEnd
+	wo.ServiceBayID = existing.ServiceBayID
+	wo.TemplateID = existing.TemplateID
+	wo.TemplateVersion = existing.TemplateVersion
+	wo.StartedAt = existing.StartedAt
+	wo.CompletedAt = existing.CompletedAt
+	wo.IsActive = existing.IsActive
+	wo.CreatedAt = existing.CreatedAt
+
+	// Fields that size or place a booking must go through rescheduling so
+	// the calendar is re-checked for conflicts
+	if existing.ScheduledDate != nil &&
+		(formatFloat(existing.EstimatedHours) != formatFloat(wo.EstimatedHours) ||
+			formatInt(existing.AssignedToUserID) != formatInt(wo.AssignedToUserID) ||
+			derefString(existing.YardLocation) != derefString(wo.YardLocation)) {
+		return ErrRescheduleRequired
+	}
+
+	history := diffWorkOrder(existing, wo, userID)
+	if len(history) == 0 {
+		wo.UpdatedAt = existing.UpdatedAt
+		return nil
+	}
+
+	if err := s.repo.UpdateWorkOrder(ctx, tenantID, wo, history); err != nil {
+		return fmt.Errorf("failed to update work order: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) TransitionStatus(ctx context.Context, tenantID string, userID, id int, to WorkOrderStatus, notes string) (*WorkOrder, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid work order ID: %d", id)
+	}
+
+	if !IsValidStatus(to) {
+		return nil, fmt.Errorf("invalid status: %s", to)
+	}
+
+	wo, err := s.repo.GetWorkOrderByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work order %d: %w", id, err)
+	}
+
+	from := wo.Status
+	if !CanTransition(from, to) {
+		return nil, fmt.Errorf("%w: %s to %s", ErrInvalidTransition, from, to)
+	}
+
+	// A hold resumes to the status it was held from and nowhere else, so it
+	// cannot step around the approval chain. A hold with no recorded status
+	// goes back to DRAFT.
+	if from == StatusOnHold && to != StatusCancelled {
+		resume := StatusDraft
+		if wo.HeldFrom != nil {
+			resume = *wo.HeldFrom
+		}
+		if to != resume {
+			return nil, fmt.Errorf("%w: held from %s, cannot resume to %s", ErrInvalidTransition, resume, to)
+		}
+	}
+
+	// Submitting and approving go through ApprovalService; only resuming a
+	// held work order may re-enter these statuses directly
+	if (to == StatusPending || to == StatusApproved) && from != StatusOnHold {
+		return nil, fmt.Errorf("%w: %s to %s", ErrApprovalRequired, from, to)
+	}
+
+	// Billing statuses follow the work order's invoice
+	if to == StatusInvoiced || to == StatusPaid || from == StatusInvoiced {
+		return nil, fmt.Errorf("%w: %s to %s", ErrManagedByInvoicing, from, to)
+	}
+
+	history := &WorkOrderHistory{
+		ChangedByUserID: userID,
+		Action:          "status_changed",
+		OldValue:        stringPtr(string(from)),
+		NewValue:        stringPtr(string(to)),
+	}
+	if strings.TrimSpace(notes) != "" {
+		history.Notes = stringPtr(notes)
+	}
+
+	if err := s.repo.UpdateStatus(ctx, tenantID, id, from, to, history); err != nil {
+		return nil, fmt.Errorf("failed to change work order status: %w", err)
+	}
+
+	now := time.Now()
+	wo.Status = to
+	wo.HeldFrom = nil
+	if to == StatusOnHold {
+		wo.HeldFrom = &from
+	}
+	wo.UpdatedAt = now
+	if to == StatusInProgress && wo.StartedAt == nil {
+		wo.StartedAt = &now
+	}
+	if to == StatusCompleted {
+		wo.CompletedAt = &now
+	}
+
+	s.publish(ctx, NewWorkOrderStatusChangedEvent(tenantID, id, from, to, userID, notes))
+	return wo, nil
+}
+
+// DeleteWorkOrder discards a draft. Anything past DRAFT has been seen by
+// approvers or the shop, so it must be cancelled instead.
+func (s *service) DeleteWorkOrder(ctx context.Context, tenantID string, userID, id int) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	wo, err := s.repo.GetWorkOrderByID(ctx, tenantID, id)
+	if err != nil {
+		return fmt.Errorf("failed to get work order %d: %w", id, err)
+	}
+
+	if wo.Status != StatusDraft {
+		return fmt.Errorf("%w: status is %s", ErrNotDeletable, wo.Status)
+	}
+
+	history := &WorkOrderHistory{
+		ChangedByUserID: userID,
+		Action:          "deleted",
+		OldValue:        stringPtr(string(wo.Status)),
+	}
+
+	if err := s.repo.DeleteWorkOrder(ctx, tenantID, id, history); err != nil {
+		return fmt.Errorf("failed to delete work order: %w", err)
+	}
+
+	return nil
+}
+
+// AssignWorkOrder sets or clears the technician on a work order. A nil
+// assignee returns the work order to the unassigned queue.
+func (s *service) AssignWorkOrder(ctx context.Context, tenantID string, userID, id int, assigneeID *int) (*WorkOrder, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if assigneeID != nil && *assigneeID <= 0 {
+		return nil, fmt.Errorf("validation failed: invalid assignee ID: %d", *assigneeID)
+	}
+
+	existing, err := s.repo.GetWorkOrderByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work order %d: %w", id, err)
+	}
+
+	if !IsEditable(existing.Status) {
+		return nil, fmt.Errorf("%w: status is %s", ErrNotEditable, existing.Status)
+	}
+
+	if formatInt(existing.AssignedToUserID) == formatInt(assigneeID) {
+		return existing, nil
+	}
+
+	// The technician is part of the booking, so scheduled work is reassigned
+	// through ScheduleService where the new technician's calendar is checked
+	if existing.ScheduledDate != nil {
+		return nil, ErrRescheduleRequired
+	}
+
+	updated := *existing
+	updated.AssignedToUserID = assigneeID
+
+	history := diffWorkOrder(existing, &updated, userID)
+	if err := s.repo.UpdateWorkOrder(ctx, tenantID, &updated, history); err != nil {
+		return nil, fmt.Errorf("failed to assign work order: %w", err)
+	}
+
+	return &updated, nil
+}
+
+func (s *service) AddItem(ctx context.Context, tenantID string, userID, workOrderID int, item *WorkOrderItem) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if item == nil {
+		return fmt.Errorf("validation failed: item is required")
+	}
+
+	// Reuse the create-time rules so items are priced the same way
+	items := &WorkOrder{Items: []WorkOrderItem{*item}}
+	if err := prepareInitialItems(items); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	*item = items.Items[0]
+
+	history := &WorkOrderHistory{
+		ChangedByUserID: userID,
+		Action:          "item_added",
+		NewValue:        stringPtr(item.Description),
+	}
+
+	if err := s.repo.AddWorkOrderItem(ctx, tenantID, workOrderID, item, history); err != nil {
+		return fmt.Errorf("failed to add work order item: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) RemoveItem(ctx context.Context, tenantID string, userID, workOrderID, itemID int) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	history := &WorkOrderHistory{
+		ChangedByUserID: userID,
+		Action:          "item_removed",
+	}
+
+	if err := s.repo.RemoveWorkOrderItem(ctx, tenantID, workOrderID, itemID, history); err != nil {
+		return fmt.Errorf("failed to remove work order item: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) CompleteItem(ctx context.Context, tenantID string, userID, workOrderID, itemID int, notes string) (*WorkOrderItem, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	history := &WorkOrderHistory{
+		ChangedByUserID: userID,
+		Action:          "item_completed",
+		Notes:           nullableString(strings.TrimSpace(notes)),
+	}
+
+	item, err := s.repo.CompleteWorkOrderItem(ctx, tenantID, workOrderID, itemID, history.Notes, history)
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete work order item: %w", err)
+	}
+
+	s.publish(ctx, NewWorkOrderItemCompletedEvent(tenantID, item, userID))
+	return item, nil
+}
+
+func (s *service) GetHistory(ctx context.Context, tenantID string, workOrderID int) ([]WorkOrderHistory, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	// Confirms the work order belongs to the tenant and has not been deleted
+	if _, err := s.repo.GetWorkOrderByID(ctx, tenantID, workOrderID); err != nil {
+		return nil, fmt.Errorf("failed to get work order %d: %w", workOrderID, err)
+	}
+
+	return s.repo.GetWorkOrderHistory(ctx, tenantID, workOrderID)
+}
+
+func (s *service) publish(ctx context.Context, event events.Event) {
+	publishEvent(ctx, s.publisher, event)
+}
+
+// publishEvent publishes best-effort: the change is already committed, so a
+// failed publish is logged rather than returned
+func publishEvent(ctx context.Context, publisher events.Publisher, event events.Event) {
+	if publisher == nil {
+		return
+	}
+	if err := publisher.Publish(ctx, event); err != nil {
+		log.Printf("Failed to publish %s for tenant %s: %v", event.EventType(), event.TenantID(), err)
+	}
+}
+
+// diffWorkOrder returns one history row per changed editable field
+func diffWorkOrder(old, updated *WorkOrder, userID int) []WorkOrderHistory {
+	var history []WorkOrderHistory
+
+	record := func(field, oldValue, newValue string) {
+		if oldValue == newValue {
+			return
+		}
+		history = append(history, WorkOrderHistory{
+			ChangedByUserID: userID,
+			Action:          field + "_changed",
+			OldValue:        nullableString(oldValue),
+			NewValue:        nullableString(newValue),
+		})
+	}
+
+	record("service_type", string(old.ServiceType), string(updated.ServiceType))
+	record("priority", string(old.Priority), string(updated.Priority))
+	record("description", old.Description, updated.Description)
+	record("instructions", derefString(old.Instructions), derefString(updated.Instructions))
+	record("estimated_hours", formatFloat(old.EstimatedHours), formatFloat(updated.EstimatedHours))
+	record("hourly_rate", formatFloat(old.HourlyRate), formatFloat(updated.HourlyRate))
+	record("materials_cost", formatFloat(old.MaterialsCost), formatFloat(updated.MaterialsCost))
+	record("total_amount", formatFloat(old.TotalAmount), formatFloat(updated.TotalAmount))
+	record("assigned_to_user_id", formatInt(old.AssignedToUserID), formatInt(updated.AssignedToUserID))
+	record("yard_location", derefString(old.YardLocation), derefString(updated.YardLocation))
+	record("service_bay_id", formatInt(old.ServiceBayID), formatInt(updated.ServiceBayID))
+	record("scheduled_date", formatTime(old.ScheduledDate), formatTime(updated.ScheduledDate))
+	record("scheduled_end", formatTime(old.ScheduledEnd), formatTime(updated.ScheduledEnd))
+	record("due_date", formatTime(old.DueDate), formatTime(updated.DueDate))
+
+	return history
+}
+
+func validateTenantID(tenantID string) error {
+	if tenantID == "" {
+		return fmt.Errorf("tenant ID is required")
+	}
+	if len(tenantID) > 100 {
+		return fmt.Errorf("tenant ID too long: %d characters", len(tenantID))
+	}
+	return nil
+}
+
+func (s *service) validateWorkOrder(wo *WorkOrder) error {
+	if wo == nil {
+		return fmt.Errorf("work order is required")
+	}
+
+	if wo.CustomerID <= 0 {
+		return fmt.Errorf("customer ID is required")
+	}
+
+	if strings.TrimSpace(wo.Description) == "" {
+		return fmt.Errorf("description is required")
+	}
+
+	if !isValidServiceType(wo.ServiceType) {
+		return fmt.Errorf("invalid service type: %s", wo.ServiceType)
+	}
+
+	if !isValidPriority(wo.Priority) {
+		return fmt.Errorf("invalid priority: %s", wo.Priority)
+	}
+
+	if len(wo.WorkOrderNumber) > 100 {
+		return fmt.Errorf("work order number too long: %d characters", len(wo.WorkOrderNumber))
+	}
+
+	if wo.YardLocation != nil && len(*wo.YardLocation) > 100 {
+		return fmt.Errorf("yard location too long: %d characters", len(*wo.YardLocation))
+	}
+
+	amounts := []struct {
+		name  string
+		value *float64
+	}{
+		{"estimated hours", wo.EstimatedHours},
+		{"actual hours", wo.ActualHours},
+		{"hourly rate", wo.HourlyRate},
+		{"materials cost", wo.MaterialsCost},
+		{"total amount", wo.TotalAmount},
+	}
+	for _, amount := range amounts {
+		if amount.value != nil && *amount.value < 0 {
+			return fmt.Errorf("%s cannot be negative", amount.name)
+		}
+	}
+
+	if wo.ScheduledDate != nil && wo.DueDate != nil && wo.DueDate.Before(*wo.ScheduledDate) {
+		return fmt.Errorf("due date cannot be before scheduled date")
+	}
+
+	return nil
+}
+
+// prepareInitialItems validates the line items and checklist a work order is
+// created with, such as those copied from a template, and prices the items
+func prepareInitialItems(wo *WorkOrder) error {
+	for i := range wo.Items {
+		item := &wo.Items[i]
+		item.Description = strings.TrimSpace(item.Description)
+		if item.Description == "" {
+			return fmt.Errorf("item %d: description is required", i+1)
+		}
+		if item.Quantity <= 0 {
+			return fmt.Errorf("item %d: quantity must be positive", i+1)
+		}
+		if item.UnitPrice != nil {
+			if *item.UnitPrice < 0 {
+				return fmt.Errorf("item %d: unit price cannot be negative", i+1)
+			}
+			total := *item.UnitPrice * float64(item.Quantity)
+			item.TotalPrice = &total
+		} else {
+			item.TotalPrice = nil
+		}
+		item.IsCompleted = false
+		item.CompletedAt = nil
+	}
+
+	for i := range wo.Checklist {
+		step := &wo.Checklist[i]
+		step.Label = strings.TrimSpace(step.Label)
+		if step.Label == "" {
+			return fmt.Errorf("checklist step %d: label is required", i+1)
+		}
+		step.SortOrder = i + 1
+		step.IsChecked = false
+		step.CheckedByUserID = nil
+		step.CheckedAt = nil
+	}
+
+	return nil
+}
+
+func (s *service) validateSearchFilters(filters *SearchFilters) error {
+	if filters == nil {
+		return nil
+	}
+
+	if filters.Limit < 0 || filters.Limit > 1000 {
+		return fmt.Errorf("limit must be between 0 and 1000")
+	}
+
+	if filters.Offset < 0 {
+		return fmt.Errorf("offset must be non-negative")
+	}
+
+	for _, status := range filters.Status {
+		if !IsValidStatus(status) {
+			return fmt.Errorf("invalid status in filter: %s", status)
+		}
+	}
+
+	return nil
+}
+
+func isValidServiceType(serviceType ServiceType) bool {
+	switch serviceType {
+	case ServiceInspection, ServiceMaintenance, ServiceRepair, ServiceCleaning, ServiceTesting, ServiceCustom:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidPriority(priority Priority) bool {
+	switch priority {
+	case PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent:
+		return true
+	default:
+		return false
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func nullableString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func formatFloat(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f, 'f', -1, 64)
+}
+
+func formatInt(i *int) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.Itoa(*i)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,508 @@
+// backend/internal/workorder/service_test.go
+package workorder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"oilgas-backend/internal/shared/events"
+)
+
+type mockRepository struct {
+	mock.Mock
+}
+
+func (m *mockRepository) GetWorkOrderByID(ctx context.Context, tenantID string, id int) (*WorkOrder, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*WorkOrder), args.Error(1)
+}
+
+func (m *mockRepository) SearchWorkOrders(ctx context.Context, tenantID string, filters SearchFilters) ([]WorkOrder, int, error) {
+	args := m.Called(ctx, tenantID, filters)
+	return args.Get(0).([]WorkOrder), args.Get(1).(int), args.Error(2)
+}
+
+func (m *mockRepository) CreateWorkOrder(ctx context.Context, tenantID string, wo *WorkOrder, history *WorkOrderHistory) error {
+	args := m.Called(ctx, tenantID, wo, history)
+	if args.Error(0) == nil {
+		wo.ID = 1
+		wo.WorkOrderNumber = "LON-000001"
+		wo.CreatedAt = time.Now()
+		wo.UpdatedAt = time.Now()
+	}
+	return args.Error(0)
+}
+
+func (m *mockRepository) UpdateWorkOrder(ctx context.Context, tenantID string, wo *WorkOrder, history []WorkOrderHistory) error {
+	args := m.Called(ctx, tenantID, wo, history)
+	return args.Error(0)
+}
+
+func (m *mockRepository) UpdateStatus(ctx context.Context, tenantID string, id int, from, to WorkOrderStatus, history *WorkOrderHistory) error {
+	args := m.Called(ctx, tenantID, id, from, to, history)
+	return args.Error(0)
+}
+
+func (m *mockRepository) DeleteWorkOrder(ctx context.Context, tenantID string, id int, history *WorkOrderHistory) error {
+	args := m.Called(ctx, tenantID, id, history)
+	return args.Error(0)
+}
+
+func (m *mockRepository) GetWorkOrderItems(ctx context.Context, tenantID string, workOrderID int) ([]WorkOrderItem, error) {
+	args := m.Called(ctx, tenantID, workOrderID)
+	return args.Get(0).([]WorkOrderItem), args.Error(1)
+}
+
+func (m *mockRepository) AddWorkOrderItem(ctx context.Context, tenantID string, workOrderID int, item *WorkOrderItem, history *WorkOrderHistory) error {
+	args := m.Called(ctx, tenantID, workOrderID, item, history)
+	return args.Error(0)
+}
+
+func (m *mockRepository) RemoveWorkOrderItem(ctx context.Context, tenantID string, workOrderID, itemID int, history *WorkOrderHistory) error {
+	args := m.Called(ctx, tenantID, workOrderID, itemID, history)
+	return args.Error(0)
+}
+
+func (m *mockRepository) CompleteWorkOrderItem(ctx context.Context, tenantID string, workOrderID, itemID int, notes *string, history *WorkOrderHistory) (*WorkOrderItem, error) {
+	args := m.Called(ctx, tenantID, workOrderID, itemID, notes, history)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*WorkOrderItem), args.Error(1)
+}
+
+func (m *mockRepository) GetWorkOrderHistory(ctx context.Context, tenantID string, workOrderID int) ([]WorkOrderHistory, error) {
+	args := m.Called(ctx, tenantID, workOrderID)
+	return args.Get(0).([]WorkOrderHistory), args.Error(1)
+}
+
+type mockPublisher struct {
+	mock.Mock
+}
+
+func (m *mockPublisher) Publish(ctx context.Context, event events.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+type WorkOrderServiceTestSuite struct {
+	suite.Suite
+	service   Service
+	repo      *mockRepository
+	publisher *mockPublisher
+	ctx       context.Context
+	tenantID  string
+	userID    int
+}
+
+func (suite *WorkOrderServiceTestSuite) SetupTest() {
+	suite.repo = &mockRepository{}
+	suite.publisher = &mockPublisher{}
+	suite.service = NewService(suite.repo, suite.publisher)
+	suite.ctx = context.Background()
+	suite.tenantID = "longbeach"
+	suite.userID = 7
+}
+
+func TestWorkOrderServiceSuite(t *testing.T) {
+	suite.Run(t, new(WorkOrderServiceTestSuite))
+}
+
+func (suite *WorkOrderServiceTestSuite) newWorkOrder(status WorkOrderStatus) *WorkOrder {
+	return &WorkOrder{
+		ID:              42,
+		TenantID:        suite.tenantID,
+		CustomerID:      3,
+		WorkOrderNumber: "LON-000042",
+		ServiceType:     ServiceInspection,
+		Status:          status,
+		Priority:        PriorityMedium,
+		Description:     "Inspect 200 joints of 5-1/2 casing",
+		CreatedByUserID: 1,
+		IsActive:        true,
+	}
+}
+
+func (suite *WorkOrderServiceTestSuite) TestCreateWorkOrder_Success() {
+	wo := &WorkOrder{
+		CustomerID:  3,
+		ServiceType: ServiceCleaning,
+		Description: "Clean and drift tubing",
+		Status:      StatusPaid, // ignored, new work orders always start as drafts
+	}
+
+	suite.repo.On("CreateWorkOrder", suite.ctx, suite.tenantID, wo, mock.MatchedBy(func(h *WorkOrderHistory) bool {
+		return h.Action == "created" && h.ChangedByUserID == suite.userID && *h.NewValue == string(StatusDraft)
+	})).Return(nil)
+	suite.publisher.On("Publish", suite.ctx, mock.AnythingOfType("*workorder.WorkOrderCreatedEvent")).Return(nil)
+
+	err := suite.service.CreateWorkOrder(suite.ctx, suite.tenantID, suite.userID, wo)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), StatusDraft, wo.Status)
+	assert.Equal(suite.T(), PriorityMedium, wo.Priority)
+	assert.Equal(suite.T(), suite.userID, wo.CreatedByUserID)
+	assert.Equal(suite.T(), suite.tenantID, wo.TenantID)
+	suite.repo.AssertExpectations(suite.T())
+	suite.publisher.AssertExpectations(suite.T())
+}
+
+func (suite *WorkOrderServiceTestSuite) TestCreateWorkOrder_ValidationErrors() {
+	testCases := []struct {
+		name        string
+		workOrder   *WorkOrder
+		expectError string
+	}{
+		{
+			name:        "nil work order",
+			workOrder:   nil,
+			expectError: "work order is required",
+		},
+		{
+			name:        "missing customer",
+			workOrder:   &WorkOrder{ServiceType: ServiceRepair, Description: "Repair"},
+			expectError: "customer ID is required",
+		},
+		{
+			name:        "missing description",
+			workOrder:   &WorkOrder{CustomerID: 1, ServiceType: ServiceRepair},
+			expectError: "description is required",
+		},
+		{
+			name:        "invalid service type",
+			workOrder:   &WorkOrder{CustomerID: 1, ServiceType: "WELDING", Description: "Weld"},
+			expectError: "invalid service type: WELDING",
+		},
+		{
+			name:        "negative hours",
+			workOrder:   &WorkOrder{CustomerID: 1, ServiceType: ServiceRepair, Description: "Repair", EstimatedHours: floatPtr(-1)},
+			expectError: "estimated hours cannot be negative",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			err := suite.service.CreateWorkOrder(suite.ctx, suite.tenantID, suite.userID, tc.workOrder)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectError)
+		})
+	}
+
+	suite.repo.AssertNotCalled(suite.T(), "CreateWorkOrder")
+}
+
+func (suite *WorkOrderServiceTestSuite) TestTransitionStatus_Success() {
+	existing := suite.newWorkOrder(StatusApproved)
+
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(existing, nil)
+	suite.repo.On("UpdateStatus", suite.ctx, suite.tenantID, 42, StatusApproved, StatusInProgress,
+		mock.MatchedBy(func(h *WorkOrderHistory) bool {
+			return h.Action == "status_changed" &&
+				*h.OldValue == string(StatusApproved) &&
+				*h.NewValue == string(StatusInProgress) &&
+				*h.Notes == "crew on site"
+		})).Return(nil)
+	suite.publisher.On("Publish", suite.ctx, mock.MatchedBy(func(e *WorkOrderStatusChangedEvent) bool {
+		return e.OldStatus == "APPROVED" && e.NewStatus == "IN_PROGRESS" && e.ChangedBy == suite.userID
+	})).Return(nil)
+
+	result, err := suite.service.TransitionStatus(suite.ctx, suite.tenantID, suite.userID, 42, StatusInProgress, "crew on site")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), StatusInProgress, result.Status)
+	assert.NotNil(suite.T(), result.StartedAt)
+	suite.repo.AssertExpectations(suite.T())
+	suite.publisher.AssertExpectations(suite.T())
+}
+
+func (suite *WorkOrderServiceTestSuite) TestTransitionStatus_IllegalTransition() {
+	existing := suite.newWorkOrder(StatusDraft)
+
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(existing, nil)
+
+	result, err := suite.service.TransitionStatus(suite.ctx, suite.tenantID, suite.userID, 42, StatusPaid, "")
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), result)
+	assert.True(suite.T(), errors.Is(err, ErrInvalidTransition))
+	suite.repo.AssertNotCalled(suite.T(), "UpdateStatus")
+	suite.publisher.AssertNotCalled(suite.T(), "Publish")
+}
+
+func (suite *WorkOrderServiceTestSuite) TestTransitionStatus_ConcurrentChange() {
+	existing := suite.newWorkOrder(StatusInProgress)
+
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(existing, nil)
+	suite.repo.On("UpdateStatus", suite.ctx, suite.tenantID, 42, StatusInProgress, StatusCompleted, mock.Anything).
+		Return(ErrStatusConflict)
+
+	_, err := suite.service.TransitionStatus(suite.ctx, suite.tenantID, suite.userID, 42, StatusCompleted, "")
+
+	assert.True(suite.T(), errors.Is(err, ErrStatusConflict))
+	suite.publisher.AssertNotCalled(suite.T(), "Publish")
+}
+
+func (suite *WorkOrderServiceTestSuite) TestTransitionStatus_RequiresApprovalWorkflow() {
+	testCases := []struct {
+		from WorkOrderStatus
+		to   WorkOrderStatus
+	}{
+		{StatusDraft, StatusPending},
+		{StatusPending, StatusApproved},
+	}
+
+	for _, tc := range testCases {
+		suite.T().Run(string(tc.from)+"->"+string(tc.to), func(t *testing.T) {
+			repo := &mockRepository{}
+			service := NewService(repo, suite.publisher)
+			repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(suite.newWorkOrder(tc.from), nil)
+
+			_, err := service.TransitionStatus(suite.ctx, suite.tenantID, suite.userID, 42, tc.to, "")
+
+			assert.True(t, errors.Is(err, ErrApprovalRequired))
+			repo.AssertNotCalled(t, "UpdateStatus")
+		})
+	}
+}
+
+func (suite *WorkOrderServiceTestSuite) TestTransitionStatus_HoldResumesOnlyWhereItWasHeld() {
+	testCases := []struct {
+		name     string
+		heldFrom *WorkOrderStatus
+		to       WorkOrderStatus
+	}{
+		{"draft to approved", statusPtr(StatusDraft), StatusApproved},
+		{"draft to pending", statusPtr(StatusDraft), StatusPending},
+		{"pending to in progress", statusPtr(StatusPending), StatusInProgress},
+		{"approved to in progress", statusPtr(StatusApproved), StatusInProgress},
+		{"unrecorded to approved", nil, StatusApproved},
+	}
+
+	for _, tc := range testCases {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			repo := &mockRepository{}
+			service := NewService(repo, suite.publisher)
+			held := suite.newWorkOrder(StatusOnHold)
+			held.HeldFrom = tc.heldFrom
+			repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(held, nil)
+
+			_, err := service.TransitionStatus(suite.ctx, suite.tenantID, suite.userID, 42, tc.to, "")
+
+			assert.True(t, errors.Is(err, ErrInvalidTransition))
+			repo.AssertNotCalled(t, "UpdateStatus")
+		})
+	}
+}
+
+func (suite *WorkOrderServiceTestSuite) TestTransitionStatus_HoldResumes() {
+	held := suite.newWorkOrder(StatusOnHold)
+	held.HeldFrom = statusPtr(StatusApproved)
+
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(held, nil)
+	suite.repo.On("UpdateStatus", suite.ctx, suite.tenantID, 42, StatusOnHold, StatusApproved, mock.Anything).Return(nil)
+	suite.publisher.On("Publish", suite.ctx, mock.Anything).Return(nil)
+
+	result, err := suite.service.TransitionStatus(suite.ctx, suite.tenantID, suite.userID, 42, StatusApproved, "parts arrived")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), StatusApproved, result.Status)
+	assert.Nil(suite.T(), result.HeldFrom)
+}
+
+func (suite *WorkOrderServiceTestSuite) TestTransitionStatus_HoldRecordsHeldFrom() {
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(suite.newWorkOrder(StatusPending), nil)
+	suite.repo.On("UpdateStatus", suite.ctx, suite.tenantID, 42, StatusPending, StatusOnHold, mock.Anything).Return(nil)
+	suite.publisher.On("Publish", suite.ctx, mock.Anything).Return(nil)
+
+	result, err := suite.service.TransitionStatus(suite.ctx, suite.tenantID, suite.userID, 42, StatusOnHold, "")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), statusPtr(StatusPending), result.HeldFrom)
+}
+
+func (suite *WorkOrderServiceTestSuite) TestTransitionStatus_BillingStatusesManagedByInvoicing() {
+	existing := suite.newWorkOrder(StatusCompleted)
+
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(existing, nil)
+
+	_, err := suite.service.TransitionStatus(suite.ctx, suite.tenantID, suite.userID, 42, StatusInvoiced, "")
+
+	assert.True(suite.T(), errors.Is(err, ErrManagedByInvoicing))
+	suite.repo.AssertNotCalled(suite.T(), "UpdateStatus")
+}
+
+func (suite *WorkOrderServiceTestSuite) TestUpdateWorkOrder_RecordsChangedFields() {
+	existing := suite.newWorkOrder(StatusDraft)
+	updated := suite.newWorkOrder(StatusPaid) // status in the payload is ignored
+	updated.Priority = PriorityUrgent
+	updated.EstimatedHours = floatPtr(12.5)
+
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(existing, nil)
+	suite.repo.On("UpdateWorkOrder", suite.ctx, suite.tenantID, updated, mock.MatchedBy(func(h []WorkOrderHistory) bool {
+		return len(h) == 2 &&
+			h[0].Action == "priority_changed" && *h[0].OldValue == "MEDIUM" && *h[0].NewValue == "URGENT" &&
+			h[1].Action == "estimated_hours_changed" && h[1].OldValue == nil && *h[1].NewValue == "12.5"
+	})).Return(nil)
+
+	err := suite.service.UpdateWorkOrder(suite.ctx, suite.tenantID, suite.userID, updated)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), StatusDraft, updated.Status)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *WorkOrderServiceTestSuite) TestUpdateWorkOrder_NotEditable() {
+	existing := suite.newWorkOrder(StatusInvoiced)
+	updated := suite.newWorkOrder(StatusInvoiced)
+	updated.Description = "Changed after invoicing"
+
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(existing, nil)
+
+	err := suite.service.UpdateWorkOrder(suite.ctx, suite.tenantID, suite.userID, updated)
+
+	assert.True(suite.T(), errors.Is(err, ErrNotEditable))
+	suite.repo.AssertNotCalled(suite.T(), "UpdateWorkOrder")
+}
+
+func (suite *WorkOrderServiceTestSuite) TestUpdateWorkOrder_ScheduledRequiresReschedule() {
+	start := time.Date(2025, time.June, 2, 7, 0, 0, 0, time.UTC)
+	existing := suite.newWorkOrder(StatusApproved)
+	existing.EstimatedHours = floatPtr(4)
+	existing.ScheduledDate = &start
+	updated := suite.newWorkOrder(StatusApproved)
+	updated.EstimatedHours = floatPtr(8)
+
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(existing, nil)
+
+	err := suite.service.UpdateWorkOrder(suite.ctx, suite.tenantID, suite.userID, updated)
+
+	assert.True(suite.T(), errors.Is(err, ErrRescheduleRequired))
+	suite.repo.AssertNotCalled(suite.T(), "UpdateWorkOrder")
+}
+
+func (suite *WorkOrderServiceTestSuite) TestDeleteWorkOrder_OnlyDrafts() {
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(suite.newWorkOrder(StatusApproved), nil)
+
+	err := suite.service.DeleteWorkOrder(suite.ctx, suite.tenantID, suite.userID, 42)
+
+	assert.True(suite.T(), errors.Is(err, ErrNotDeletable))
+	suite.repo.AssertNotCalled(suite.T(), "DeleteWorkOrder")
+}
+
+func (suite *WorkOrderServiceTestSuite) TestAssignWorkOrder_RecordsHistory() {
+	technician := 12
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(suite.newWorkOrder(StatusApproved), nil)
+	suite.repo.On("UpdateWorkOrder", suite.ctx, suite.tenantID, mock.MatchedBy(func(wo *WorkOrder) bool {
+		return *wo.AssignedToUserID == technician
+	}), mock.MatchedBy(func(h []WorkOrderHistory) bool {
+		return len(h) == 1 && h[0].Action == "assigned_to_user_id_changed" && *h[0].NewValue == "12"
+	})).Return(nil)
+
+	wo, err := suite.service.AssignWorkOrder(suite.ctx, suite.tenantID, suite.userID, 42, &technician)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), technician, *wo.AssignedToUserID)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *WorkOrderServiceTestSuite) TestAssignWorkOrder_ScheduledRequiresReschedule() {
+	start := time.Date(2025, time.June, 2, 7, 0, 0, 0, time.UTC)
+	technician := 12
+	existing := suite.newWorkOrder(StatusApproved)
+	existing.ScheduledDate = &start
+
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).Return(existing, nil)
+
+	_, err := suite.service.AssignWorkOrder(suite.ctx, suite.tenantID, suite.userID, 42, &technician)
+
+	assert.True(suite.T(), errors.Is(err, ErrRescheduleRequired))
+	suite.repo.AssertNotCalled(suite.T(), "UpdateWorkOrder")
+}
+
+func (suite *WorkOrderServiceTestSuite) TestAddItem_PricesItem() {
+	item := &WorkOrderItem{Description: " Thread protectors ", Quantity: 4, UnitPrice: floatPtr(12.5)}
+
+	suite.repo.On("AddWorkOrderItem", suite.ctx, suite.tenantID, 42, mock.MatchedBy(func(i *WorkOrderItem) bool {
+		return i.Description == "Thread protectors" && *i.TotalPrice == 50.0
+	}), mock.MatchedBy(func(h *WorkOrderHistory) bool {
+		return h.Action == "item_added" && *h.NewValue == "Thread protectors"
+	})).Return(nil)
+
+	err := suite.service.AddItem(suite.ctx, suite.tenantID, suite.userID, 42, item)
+
+	assert.NoError(suite.T(), err)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *WorkOrderServiceTestSuite) TestCompleteItem_PublishesEvent() {
+	inventoryItemID := 880
+	completed := &WorkOrderItem{ID: 5, WorkOrderID: 42, InventoryItemID: &inventoryItemID, Description: "Joint 17", IsCompleted: true}
+
+	suite.repo.On("CompleteWorkOrderItem", suite.ctx, suite.tenantID, 42, 5, mock.MatchedBy(func(notes *string) bool {
+		return notes != nil && *notes == "Pin end redressed"
+	}), mock.Anything).Return(completed, nil)
+	suite.publisher.On("Publish", suite.ctx, mock.MatchedBy(func(e *WorkOrderItemCompletedEvent) bool {
+		return e.EventType() == "workorder.item_completed" && e.ItemID == 5 && *e.InventoryItemID == inventoryItemID
+	})).Return(nil)
+
+	item, err := suite.service.CompleteItem(suite.ctx, suite.tenantID, suite.userID, 42, 5, " Pin end redressed ")
+
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), item.IsCompleted)
+	suite.publisher.AssertExpectations(suite.T())
+}
+
+func (suite *WorkOrderServiceTestSuite) TestCompleteItem_NotInProgress() {
+	suite.repo.On("CompleteWorkOrderItem", suite.ctx, suite.tenantID, 42, 5, mock.Anything, mock.Anything).
+		Return(nil, ErrItemNotCompletable)
+
+	_, err := suite.service.CompleteItem(suite.ctx, suite.tenantID, suite.userID, 42, 5, "")
+
+	assert.True(suite.T(), errors.Is(err, ErrItemNotCompletable))
+	suite.publisher.AssertNotCalled(suite.T(), "Publish")
+}
+
+func TestCanTransition(t *testing.T) {
+	testCases := []struct {
+		from, to WorkOrderStatus
+		allowed  bool
+	}{
+		{StatusDraft, StatusPending, true},
+		{StatusPending, StatusApproved, true},
+		{StatusApproved, StatusInProgress, true},
+		{StatusInProgress, StatusCompleted, true},
+		{StatusCompleted, StatusInvoiced, true},
+		{StatusInvoiced, StatusPaid, true},
+		{StatusInvoiced, StatusCompleted, true},
+		{StatusInProgress, StatusOnHold, true},
+		{StatusOnHold, StatusInProgress, true},
+		{StatusDraft, StatusPaid, false},
+		{StatusDraft, StatusCompleted, false},
+		{StatusCompleted, StatusCancelled, false},
+		{StatusPaid, StatusDraft, false},
+		{StatusCancelled, StatusDraft, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.from)+"->"+string(tc.to), func(t *testing.T) {
+			assert.Equal(t, tc.allowed, CanTransition(tc.from, tc.to))
+		})
+	}
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func statusPtr(s WorkOrderStatus) *WorkOrderStatus {
+	return &s
+}
@@ -0,0 +1,359 @@
+// backend/internal/workorder/sla.go
+package workorder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"oilgas-backend/internal/shared/events"
+)
+
+type SLAService interface {
+	ListSLARules(ctx context.Context, tenantID string) ([]SLARule, error)
+	CreateSLARule(ctx context.Context, tenantID string, userID int, rule *SLARule) error
+	DeactivateSLARule(ctx context.Context, tenantID string, id int) error
+
+	// GetAtRisk lists work orders that are at risk of missing, or have
+	// missed, an SLA deadline, soonest deadline first
+	GetAtRisk(ctx context.Context, tenantID string) ([]SLAStatus, error)
+
+	// Evaluate raises escalations that have not fired yet and applies the
+	// rule's action to breached work orders
+	Evaluate(ctx context.Context, tenantID string) ([]Escalation, error)
+}
+
+type slaService struct {
+	sla       SLARepository
+	publisher events.Publisher
+	now       func() time.Time
+}
+
+// NewSLAService builds the SLA service; now is the clock used for every
+// evaluation and defaults to time.Now
+func NewSLAService(sla SLARepository, publisher events.Publisher, now func() time.Time) SLAService {
+	if now == nil {
+		now = time.Now
+	}
+	return &slaService{
+		sla:       sla,
+		publisher: publisher,
+		now:       now,
+	}
+}
+
+// slaStatusRank orders statuses along the normal workflow so a rule can tell
+// whether a work order has reached its target. ON_HOLD counts as started.
+var slaStatusRank = map[WorkOrderStatus]int{
+	StatusDraft:      0,
+	StatusPending:    1,
+	StatusApproved:   2,
+	StatusInProgress: 3,
+	StatusOnHold:     3,
+	StatusCompleted:  4,
+	StatusInvoiced:   5,
+	StatusPaid:       6,
+}
+
+func (s *slaService) ListSLARules(ctx context.Context, tenantID string) ([]SLARule, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.sla.GetSLARules(ctx, tenantID)
+}
+
+func (s *slaService) CreateSLARule(ctx context.Context, tenantID string, userID int, rule *SLARule) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if err := validateSLARule(rule); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	rule.TenantID = tenantID
+	rule.CreatedByUserID = userID
+	rule.IsActive = true
+
+	return s.sla.CreateSLARule(ctx, tenantID, rule)
+}
+
+func (s *slaService) DeactivateSLARule(ctx context.Context, tenantID string, id int) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.sla.DeactivateSLARule(ctx, tenantID, id)
+}
+
+func (s *slaService) GetAtRisk(ctx context.Context, tenantID string) ([]SLAStatus, error) {
+	statuses, _, err := s.evaluate(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Deadline.Before(statuses[j].Deadline)
+	})
+
+	return statuses, nil
+}
+
+func (s *slaService) Evaluate(ctx context.Context, tenantID string) ([]Escalation, error) {
+	statuses, rules, err := s.evaluate(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	var raised []Escalation
+	for _, status := range statuses {
+		rule := rules[status.RuleID]
+		escalation, history := buildEscalation(tenantID, rule, status)
+
+		err := s.sla.RecordEscalation(ctx, tenantID, escalation, status, history)
+		if errors.Is(err, ErrAlreadyEscalated) || errors.Is(err, ErrWorkOrderChanged) {
+			// Already handled, or the work order moved on; the next pass sees the new state
+			continue
+		}
+		if err != nil {
+			log.Printf("Failed to escalate work order %d for tenant %s: %v", status.WorkOrderID, tenantID, err)
+			continue
+		}
+
+		publishEvent(ctx, s.publisher, NewWorkOrderEscalatedEvent(tenantID, escalation, rule.Name))
+		raised = append(raised, *escalation)
+	}
+
+	return raised, nil
+}
+
+// evaluate checks every open work order against every active rule at the
+// current clock time
+func (s *slaService) evaluate(ctx context.Context, tenantID string) ([]SLAStatus, map[int]SLARule, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	rules, err := s.sla.GetSLARules(ctx, tenantID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get SLA rules: %w", err)
+	}
+
+	byID := make(map[int]SLARule, len(rules))
+	for _, rule := range rules {
+		byID[rule.ID] = rule
+	}
+
+	if len(rules) == 0 {
+		return nil, byID, nil
+	}
+
+	candidates, err := s.sla.GetSLACandidates(ctx, tenantID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get open work orders: %w", err)
+	}
+
+	now := s.now()
+	var statuses []SLAStatus
+	for _, candidate := range candidates {
+		for _, rule := range rules {
+			if status, ok := evaluateSLA(rule, candidate, now); ok {
+				statuses = append(statuses, status)
+			}
+		}
+	}
+
+	return statuses, byID, nil
+}
+
+// evaluateSLA reports a work order's standing against a rule, or false if
+// the rule does not apply or the deadline is not yet within the warning window
+func evaluateSLA(rule SLARule, candidate SLACandidate, now time.Time) (SLAStatus, bool) {
+	wo := candidate.WorkOrder
+
+	if rule.Priority != nil && *rule.Priority != wo.Priority {
+		return SLAStatus{}, false
+	}
+
+	rank, ok := slaStatusRank[wo.Status]
+	if !ok || rank < slaStatusRank[rule.FromStatus] || rank >= slaStatusRank[rule.TargetStatus] {
+		return SLAStatus{}, false
+	}
+
+	var deadline time.Time
+	if rule.TargetHours != nil {
+		entered, ok := candidate.StatusEnteredAt[rule.FromStatus]
+		if !ok {
+			if rule.FromStatus != StatusDraft {
+				return SLAStatus{}, false
+			}
+			entered = wo.CreatedAt
+		}
+		deadline = entered.Add(time.Duration(*rule.TargetHours * float64(time.Hour)))
+	} else {
+		if wo.DueDate == nil {
+			return SLAStatus{}, false
+		}
+		deadline = *wo.DueDate
+	}
+
+	warning := time.Duration(rule.WarningHours * float64(time.Hour))
+
+	var state SLAState
+	switch {
+	case !now.Before(deadline):
+		state = SLABreached
+	case !now.Before(deadline.Add(-warning)):
+		state = SLAAtRisk
+	default:
+		return SLAStatus{}, false
+	}
+
+	return SLAStatus{
+		WorkOrderID:      wo.ID,
+		WorkOrderNumber:  wo.WorkOrderNumber,
+		CustomerID:       wo.CustomerID,
+		Status:           wo.Status,
+		Priority:         wo.Priority,
+		AssignedToUserID: wo.AssignedToUserID,
+		RuleID:           rule.ID,
+		RuleName:         rule.Name,
+		Deadline:         deadline,
+		State:            state,
+		HoursRemaining:   math.Round(deadline.Sub(now).Hours()*100) / 100,
+	}, true
+}
+
+// buildEscalation decides what an escalation does. At-risk work orders only
+// raise an event; the rule's action is taken once the deadline is missed.
+func buildEscalation(tenantID string, rule SLARule, status SLAStatus) (*Escalation, []WorkOrderHistory) {
+	escalation := &Escalation{
+		TenantID:    tenantID,
+		WorkOrderID: status.WorkOrderID,
+		RuleID:      rule.ID,
+		State:       status.State,
+		Deadline:    status.Deadline,
+	}
+
+	notes := fmt.Sprintf("SLA rule %q: deadline %s", rule.Name, status.Deadline.UTC().Format(time.RFC3339))
+	history := []WorkOrderHistory{{
+		ChangedByUserID: rule.CreatedByUserID,
+		Action:          "sla_" + strings.ToLower(string(status.State)),
+		NewValue:        stringPtr(status.Deadline.UTC().Format(time.RFC3339)),
+		Notes:           &notes,
+	}}
+
+	if status.State != SLABreached {
+		return escalation, history
+	}
+
+	action := rule.Action
+	switch rule.Action {
+	case SLAActionBumpPriority:
+		next := nextPriority(status.Priority)
+		if next == status.Priority {
+			action = SLAActionNotify
+			break
+		}
+		old := status.Priority
+		escalation.OldPriority = &old
+		escalation.NewPriority = &next
+		history = append(history, WorkOrderHistory{
+			ChangedByUserID: rule.CreatedByUserID,
+			Action:          "priority_changed",
+			OldValue:        stringPtr(string(old)),
+			NewValue:        stringPtr(string(next)),
+			Notes:           &notes,
+		})
+
+	case SLAActionReassign:
+		if rule.ReassignToUserID == nil || formatInt(status.AssignedToUserID) == formatInt(rule.ReassignToUserID) {
+			action = SLAActionNotify
+			break
+		}
+		escalation.ReassignedFrom = status.AssignedToUserID
+		escalation.ReassignedTo = rule.ReassignToUserID
+		history = append(history, WorkOrderHistory{
+			ChangedByUserID: rule.CreatedByUserID,
+			Action:          "assigned_to_user_id_changed",
+			OldValue:        nullableString(formatInt(status.AssignedToUserID)),
+			NewValue:        nullableString(formatInt(rule.ReassignToUserID)),
+			Notes:           &notes,
+		})
+	}
+	escalation.ActionTaken = &action
+
+	return escalation, history
+}
+
+func nextPriority(p Priority) Priority {
+	switch p {
+	case PriorityLow:
+		return PriorityMedium
+	case PriorityMedium:
+		return PriorityHigh
+	default:
+		return PriorityUrgent
+	}
+}
+
+func validateSLARule(rule *SLARule) error {
+	if rule == nil {
+		return fmt.Errorf("SLA rule is required")
+	}
+
+	rule.Name = strings.TrimSpace(rule.Name)
+	if rule.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(rule.Name) > 100 {
+		return fmt.Errorf("name too long: %d characters", len(rule.Name))
+	}
+
+	if rule.Priority != nil && !isValidPriority(*rule.Priority) {
+		return fmt.Errorf("invalid priority: %s", *rule.Priority)
+	}
+
+	from, ok := slaStatusRank[rule.FromStatus]
+	if !ok {
+		return fmt.Errorf("invalid from status: %s", rule.FromStatus)
+	}
+	target, ok := slaStatusRank[rule.TargetStatus]
+	if !ok {
+		return fmt.Errorf("invalid target status: %s", rule.TargetStatus)
+	}
+	if target <= from {
+		return fmt.Errorf("target status %s must come after %s", rule.TargetStatus, rule.FromStatus)
+	}
+
+	if rule.TargetHours != nil && *rule.TargetHours <= 0 {
+		return fmt.Errorf("target hours must be positive")
+	}
+	if rule.WarningHours < 0 {
+		return fmt.Errorf("warning hours cannot be negative")
+	}
+
+	switch rule.Action {
+	case "":
+		rule.Action = SLAActionNotify
+	case SLAActionNotify, SLAActionBumpPriority:
+	case SLAActionReassign:
+		if rule.ReassignToUserID == nil {
+			return fmt.Errorf("reassign action requires a user to reassign to")
+		}
+	default:
+		return fmt.Errorf("invalid action: %s", rule.Action)
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+// backend/internal/workorder/sla_handlers.go
+package workorder
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"oilgas-backend/internal/auth"
+)
+
+type SLAHandlers struct {
+	service SLAService
+}
+
+func NewSLAHandlers(service SLAService) *SLAHandlers {
+	return &SLAHandlers{service: service}
+}
+
+func (h *SLAHandlers) RegisterRoutes(router *gin.RouterGroup, authMiddleware *auth.Middleware) {
+	workOrders := router.Group("/workorders")
+	workOrders.Use(authMiddleware.RequireAuth())
+
+	workOrders.GET("/at-risk", authMiddleware.RequireRole(auth.RoleOperator, auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin), h.GetAtRisk)
+
+	rules := router.Group("/sla-rules")
+	rules.Use(authMiddleware.RequireAuth())
+	rules.Use(authMiddleware.RequireRole(auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin))
+
+	rules.GET("", h.ListSLARules)
+	rules.POST("", h.CreateSLARule)
+	rules.DELETE("/:id", h.DeactivateSLARule)
+}
+
+// GetAtRisk lists work orders close to or past an SLA deadline; pass
+// state=BREACHED for only the missed ones
+func (h *SLAHandlers) GetAtRisk(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+
+	statuses, err := h.service.GetAtRisk(c.Request.Context(), tenantID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to evaluate SLAs"})
+		return
+	}
+
+	if state := SLAState(c.Query("state")); state != "" {
+		filtered := statuses[:0]
+		for _, status := range statuses {
+			if status.State == state {
+				filtered = append(filtered, status)
+			}
+		}
+		statuses = filtered
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  statuses,
+		"total": len(statuses),
+	})
+}
+
+func (h *SLAHandlers) ListSLARules(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+
+	rules, err := h.service.ListSLARules(c.Request.Context(), tenantID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get SLA rules"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  rules,
+		"total": len(rules),
+	})
+}
+
+func (h *SLAHandlers) CreateSLARule(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+
+	var rule SLARule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.CreateSLARule(c.Request.Context(), tenantID, c.GetInt("user_id"), &rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+func (h *SLAHandlers) DeactivateSLARule(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid SLA rule ID"})
+		return
+	}
+
+	if err := h.service.DeactivateSLARule(c.Request.Context(), tenantID, id); err != nil {
+		if errors.Is(err, ErrSLARuleNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to deactivate SLA rule"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "SLA rule deactivated"})
+}
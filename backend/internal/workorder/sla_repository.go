@@ -0,0 +1,257 @@
+// backend/internal/workorder/sla_repository.go
+package workorder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"oilgas-backend/internal/shared/database"
+)
+
+type SLARepository interface {
+	GetSLARules(ctx context.Context, tenantID string) ([]SLARule, error)
+	CreateSLARule(ctx context.Context, tenantID string, rule *SLARule) error
+	DeactivateSLARule(ctx context.Context, tenantID string, id int) error
+
+	GetSLACandidates(ctx context.Context, tenantID string) ([]SLACandidate, error)
+	RecordEscalation(ctx context.Context, tenantID string, escalation *Escalation, status SLAStatus, history []WorkOrderHistory) error
+}
+
+type slaRepository struct {
+	dbManager *database.DatabaseManager
+}
+
+func NewSLARepository(dbManager *database.DatabaseManager) SLARepository {
+	return &slaRepository{dbManager: dbManager}
+}
+
+func (r *slaRepository) GetSLARules(ctx context.Context, tenantID string) ([]SLARule, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, tenant_id, name, priority, from_status, target_status, target_hours,
+		       warning_hours, action, reassign_to_user_id, is_active, created_by_user_id, created_at
+		FROM store.sla_rules
+		WHERE tenant_id = $1 AND is_active = true
+		ORDER BY id`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SLA rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []SLARule
+	for rows.Next() {
+		var rule SLARule
+		err := rows.Scan(
+			&rule.ID, &rule.TenantID, &rule.Name, &rule.Priority, &rule.FromStatus, &rule.TargetStatus, &rule.TargetHours,
+			&rule.WarningHours, &rule.Action, &rule.ReassignToUserID, &rule.IsActive, &rule.CreatedByUserID, &rule.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan SLA rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+func (r *slaRepository) CreateSLARule(ctx context.Context, tenantID string, rule *SLARule) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	err = db.QueryRowContext(ctx, `
+		INSERT INTO store.sla_rules (
+			tenant_id, name, priority, from_status, target_status, target_hours,
+			warning_hours, action, reassign_to_user_id, created_by_user_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at`,
+		tenantID, rule.Name, rule.Priority, rule.FromStatus, rule.TargetStatus, rule.TargetHours,
+		rule.WarningHours, rule.Action, rule.ReassignToUserID, rule.CreatedByUserID,
+	).Scan(&rule.ID, &rule.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create SLA rule: %w", err)
+	}
+
+	return nil
+}
+
+func (r *slaRepository) DeactivateSLARule(ctx context.Context, tenantID string, id int) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	result, err := db.ExecContext(ctx, `
+		UPDATE store.sla_rules SET is_active = false
+		WHERE id = $1 AND tenant_id = $2 AND is_active = true`, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate SLA rule: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check deactivated rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrSLARuleNotFound
+	}
+
+	return nil
+}
+
+// GetSLACandidates loads open work orders along with when each last entered
+// every status it has been through
+func (r *slaRepository) GetSLACandidates(ctx context.Context, tenantID string) ([]SLACandidate, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT `+workOrderColumns+`
+		FROM store.workorders
+		WHERE tenant_id = $1 AND is_active = true AND status NOT IN ('CANCELLED', 'PAID')`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open work orders: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []SLACandidate
+	index := make(map[int]int)
+	var ids []int
+	for rows.Next() {
+		var wo WorkOrder
+		if err := scanWorkOrder(rows, &wo); err != nil {
+			return nil, fmt.Errorf("failed to scan work order: %w", err)
+		}
+		index[wo.ID] = len(candidates)
+		ids = append(ids, wo.ID)
+		candidates = append(candidates, SLACandidate{
+			WorkOrder:       wo,
+			StatusEnteredAt: map[WorkOrderStatus]time.Time{StatusDraft: wo.CreatedAt},
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return candidates, nil
+	}
+
+	historyRows, err := db.QueryContext(ctx, `
+		SELECT workorder_id, new_value, MAX(created_at)
+		FROM store.workorder_history
+		WHERE workorder_id = ANY($1) AND action = 'status_changed' AND new_value IS NOT NULL
+		GROUP BY workorder_id, new_value`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status history: %w", err)
+	}
+	defer historyRows.Close()
+
+	for historyRows.Next() {
+		var workOrderID int
+		var status WorkOrderStatus
+		var enteredAt time.Time
+		if err := historyRows.Scan(&workOrderID, &status, &enteredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan status history: %w", err)
+		}
+		candidates[index[workOrderID]].StatusEnteredAt[status] = enteredAt
+	}
+
+	return candidates, historyRows.Err()
+}
+
+// RecordEscalation stores an escalation and applies its action in one
+// transaction. Each rule fires once per state and deadline, so a second
+// worker evaluating the same work order gets ErrAlreadyEscalated.
+func (r *slaRepository) RecordEscalation(ctx context.Context, tenantID string, escalation *Escalation, status SLAStatus, history []WorkOrderHistory) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO store.workorder_escalations (
+			tenant_id, workorder_id, rule_id, state, deadline, action_taken,
+			old_priority, new_priority, reassigned_from_user_id, reassigned_to_user_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (workorder_id, rule_id, state, deadline) DO NOTHING
+		RETURNING id, created_at`,
+		tenantID, escalation.WorkOrderID, escalation.RuleID, escalation.State, escalation.Deadline, escalation.ActionTaken,
+		escalation.OldPriority, escalation.NewPriority, escalation.ReassignedFrom, escalation.ReassignedTo,
+	).Scan(&escalation.ID, &escalation.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrAlreadyEscalated
+		}
+		return fmt.Errorf("failed to record escalation: %w", err)
+	}
+
+	if escalation.NewPriority != nil {
+		if err := execGuarded(ctx, tx, `
+			UPDATE store.workorders SET priority = $4, updated_at = NOW()
+			WHERE id = $1 AND tenant_id = $2 AND status = $3 AND priority = $5 AND is_active = true`,
+			escalation.WorkOrderID, tenantID, status.Status, *escalation.NewPriority, *escalation.OldPriority); err != nil {
+			return err
+		}
+	}
+
+	// A reassigned booking stays on the calendar, where it is flagged if it
+	// overlaps the new technician's work
+	if escalation.ReassignedTo != nil {
+		if err := execGuarded(ctx, tx, `
+			UPDATE store.workorders SET assigned_to_user_id = $4, updated_at = NOW()
+			WHERE id = $1 AND tenant_id = $2 AND status = $3
+			  AND assigned_to_user_id IS NOT DISTINCT FROM $5 AND is_active = true`,
+			escalation.WorkOrderID, tenantID, status.Status, *escalation.ReassignedTo, escalation.ReassignedFrom); err != nil {
+			return err
+		}
+	}
+
+	for i := range history {
+		history[i].WorkOrderID = escalation.WorkOrderID
+		if err := insertHistory(ctx, tx, &history[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit escalation: %w", err)
+	}
+
+	return nil
+}
+
+// execGuarded runs an update that must touch exactly the work order as it
+// was evaluated, returning ErrWorkOrderChanged otherwise
+func execGuarded(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) error {
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to apply escalation: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrWorkOrderChanged
+	}
+
+	return nil
+}
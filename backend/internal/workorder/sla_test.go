@@ -0,0 +1,218 @@
+// backend/internal/workorder/sla_test.go
+package workorder
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type mockSLARepository struct {
+	mock.Mock
+}
+
+func (m *mockSLARepository) GetSLARules(ctx context.Context, tenantID string) ([]SLARule, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).([]SLARule), args.Error(1)
+}
+
+func (m *mockSLARepository) CreateSLARule(ctx context.Context, tenantID string, rule *SLARule) error {
+	args := m.Called(ctx, tenantID, rule)
+	return args.Error(0)
+}
+
+func (m *mockSLARepository) DeactivateSLARule(ctx context.Context, tenantID string, id int) error {
+	args := m.Called(ctx, tenantID, id)
+	return args.Error(0)
+}
+
+func (m *mockSLARepository) GetSLACandidates(ctx context.Context, tenantID string) ([]SLACandidate, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).([]SLACandidate), args.Error(1)
+}
+
+func (m *mockSLARepository) RecordEscalation(ctx context.Context, tenantID string, escalation *Escalation, status SLAStatus, history []WorkOrderHistory) error {
+	args := m.Called(ctx, tenantID, escalation, status, history)
+	return args.Error(0)
+}
+
+type SLAServiceTestSuite struct {
+	suite.Suite
+	service   SLAService
+	sla       *mockSLARepository
+	publisher *mockPublisher
+	ctx       context.Context
+	tenantID  string
+	now       time.Time
+}
+
+func (suite *SLAServiceTestSuite) SetupTest() {
+	suite.sla = &mockSLARepository{}
+	suite.publisher = &mockPublisher{}
+	suite.now = time.Date(2025, time.June, 3, 16, 0, 0, 0, time.UTC)
+	suite.service = NewSLAService(suite.sla, suite.publisher, func() time.Time { return suite.now })
+	suite.ctx = context.Background()
+	suite.tenantID = "longbeach"
+}
+
+func TestSLAServiceSuite(t *testing.T) {
+	suite.Run(t, new(SLAServiceTestSuite))
+}
+
+func (suite *SLAServiceTestSuite) startWithin(priority Priority, hours float64, action SLAAction) SLARule {
+	return SLARule{
+		ID:              1,
+		Name:            "High priority starts within 4h",
+		Priority:        &priority,
+		FromStatus:      StatusApproved,
+		TargetStatus:    StatusInProgress,
+		TargetHours:     &hours,
+		WarningHours:    1,
+		Action:          action,
+		CreatedByUserID: 2,
+	}
+}
+
+func (suite *SLAServiceTestSuite) approvedAgo(id int, priority Priority, status WorkOrderStatus, ago time.Duration) SLACandidate {
+	return SLACandidate{
+		WorkOrder: WorkOrder{ID: id, WorkOrderNumber: fmt.Sprintf("LON-%06d", id), Status: status, Priority: priority},
+		StatusEnteredAt: map[WorkOrderStatus]time.Time{
+			StatusApproved: suite.now.Add(-ago),
+		},
+	}
+}
+
+func (suite *SLAServiceTestSuite) TestEvaluate_BreachBumpsPriorityAndAtRiskOnlyNotifies() {
+	rule := suite.startWithin(PriorityHigh, 4, SLAActionBumpPriority)
+
+	suite.sla.On("GetSLARules", suite.ctx, suite.tenantID).Return([]SLARule{rule}, nil)
+	suite.sla.On("GetSLACandidates", suite.ctx, suite.tenantID).Return([]SLACandidate{
+		suite.approvedAgo(1, PriorityHigh, StatusApproved, 5*time.Hour),
+		suite.approvedAgo(2, PriorityHigh, StatusApproved, 3*time.Hour+30*time.Minute),
+		suite.approvedAgo(3, PriorityHigh, StatusApproved, time.Hour),
+		suite.approvedAgo(4, PriorityHigh, StatusInProgress, 5*time.Hour),
+		suite.approvedAgo(5, PriorityLow, StatusApproved, 5*time.Hour),
+	}, nil)
+
+	suite.sla.On("RecordEscalation", suite.ctx, suite.tenantID, mock.MatchedBy(func(e *Escalation) bool {
+		return e.WorkOrderID == 1 && e.State == SLABreached &&
+			*e.ActionTaken == SLAActionBumpPriority && *e.NewPriority == PriorityUrgent
+	}), mock.Anything, mock.MatchedBy(func(h []WorkOrderHistory) bool {
+		return len(h) == 2 && h[0].Action == "sla_breached" && h[1].Action == "priority_changed" &&
+			*h[1].NewValue == "URGENT" && h[1].ChangedByUserID == 2
+	})).Return(nil)
+	suite.sla.On("RecordEscalation", suite.ctx, suite.tenantID, mock.MatchedBy(func(e *Escalation) bool {
+		return e.WorkOrderID == 2 && e.State == SLAAtRisk && e.ActionTaken == nil && e.NewPriority == nil
+	}), mock.Anything, mock.Anything).Return(nil)
+
+	suite.publisher.On("Publish", suite.ctx, mock.MatchedBy(func(e *WorkOrderEscalatedEvent) bool {
+		return e.WorkOrderID == 1 && e.EventType() == "workorder.sla_breached"
+	})).Return(nil)
+	suite.publisher.On("Publish", suite.ctx, mock.MatchedBy(func(e *WorkOrderEscalatedEvent) bool {
+		return e.WorkOrderID == 2 && e.EventType() == "workorder.sla_at_risk"
+	})).Return(nil)
+
+	escalations, err := suite.service.Evaluate(suite.ctx, suite.tenantID)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), escalations, 2)
+	suite.sla.AssertExpectations(suite.T())
+	suite.publisher.AssertExpectations(suite.T())
+}
+
+func (suite *SLAServiceTestSuite) TestEvaluate_AlreadyEscalatedIsNotPublishedAgain() {
+	rule := suite.startWithin(PriorityUrgent, 4, SLAActionNotify)
+
+	suite.sla.On("GetSLARules", suite.ctx, suite.tenantID).Return([]SLARule{rule}, nil)
+	suite.sla.On("GetSLACandidates", suite.ctx, suite.tenantID).Return([]SLACandidate{
+		suite.approvedAgo(1, PriorityUrgent, StatusApproved, 5*time.Hour),
+	}, nil)
+	suite.sla.On("RecordEscalation", suite.ctx, suite.tenantID, mock.Anything, mock.Anything, mock.Anything).
+		Return(ErrAlreadyEscalated)
+
+	escalations, err := suite.service.Evaluate(suite.ctx, suite.tenantID)
+
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), escalations)
+	suite.publisher.AssertNotCalled(suite.T(), "Publish")
+}
+
+func (suite *SLAServiceTestSuite) TestEvaluate_DueDateRuleReassigns() {
+	supervisor := 30
+	technician := 12
+	due := suite.now.Add(-time.Hour)
+	rule := SLARule{
+		ID:               4,
+		Name:             "Complete by due date",
+		FromStatus:       StatusApproved,
+		TargetStatus:     StatusCompleted,
+		WarningHours:     24,
+		Action:           SLAActionReassign,
+		ReassignToUserID: &supervisor,
+		CreatedByUserID:  2,
+	}
+
+	suite.sla.On("GetSLARules", suite.ctx, suite.tenantID).Return([]SLARule{rule}, nil)
+	suite.sla.On("GetSLACandidates", suite.ctx, suite.tenantID).Return([]SLACandidate{{
+		WorkOrder: WorkOrder{ID: 8, Status: StatusOnHold, Priority: PriorityMedium, AssignedToUserID: &technician, DueDate: &due},
+	}}, nil)
+	suite.sla.On("RecordEscalation", suite.ctx, suite.tenantID, mock.MatchedBy(func(e *Escalation) bool {
+		return e.State == SLABreached && *e.ActionTaken == SLAActionReassign &&
+			*e.ReassignedFrom == technician && *e.ReassignedTo == supervisor && e.Deadline.Equal(due)
+	}), mock.Anything, mock.Anything).Return(nil)
+	suite.publisher.On("Publish", suite.ctx, mock.AnythingOfType("*workorder.WorkOrderEscalatedEvent")).Return(nil)
+
+	escalations, err := suite.service.Evaluate(suite.ctx, suite.tenantID)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), escalations, 1)
+	suite.sla.AssertExpectations(suite.T())
+}
+
+func (suite *SLAServiceTestSuite) TestGetAtRisk_SortedByDeadline() {
+	rule := suite.startWithin(PriorityHigh, 4, SLAActionNotify)
+
+	suite.sla.On("GetSLARules", suite.ctx, suite.tenantID).Return([]SLARule{rule}, nil)
+	suite.sla.On("GetSLACandidates", suite.ctx, suite.tenantID).Return([]SLACandidate{
+		suite.approvedAgo(1, PriorityHigh, StatusApproved, 3*time.Hour+30*time.Minute),
+		suite.approvedAgo(2, PriorityHigh, StatusApproved, 6*time.Hour),
+	}, nil)
+
+	statuses, err := suite.service.GetAtRisk(suite.ctx, suite.tenantID)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), statuses, 2)
+	assert.Equal(suite.T(), 2, statuses[0].WorkOrderID)
+	assert.Equal(suite.T(), SLABreached, statuses[0].State)
+	assert.Equal(suite.T(), -2.0, statuses[0].HoursRemaining)
+	assert.Equal(suite.T(), SLAAtRisk, statuses[1].State)
+	assert.Equal(suite.T(), 0.5, statuses[1].HoursRemaining)
+	suite.sla.AssertNotCalled(suite.T(), "RecordEscalation")
+}
+
+func (suite *SLAServiceTestSuite) TestCreateSLARule_Validation() {
+	hours := 4.0
+	testCases := []struct {
+		name string
+		rule SLARule
+	}{
+		{"missing name", SLARule{FromStatus: StatusApproved, TargetStatus: StatusInProgress, TargetHours: &hours}},
+		{"target before start", SLARule{Name: "Backwards", FromStatus: StatusInProgress, TargetStatus: StatusApproved}},
+		{"cancelled target", SLARule{Name: "Cancel", FromStatus: StatusApproved, TargetStatus: StatusCancelled}},
+		{"reassign without user", SLARule{Name: "Reassign", FromStatus: StatusApproved, TargetStatus: StatusCompleted, Action: SLAActionReassign}},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			rule := tc.rule
+			err := suite.service.CreateSLARule(suite.ctx, suite.tenantID, 2, &rule)
+			assert.ErrorContains(suite.T(), err, "validation failed")
+		})
+	}
+	suite.sla.AssertNotCalled(suite.T(), "CreateSLARule")
+}
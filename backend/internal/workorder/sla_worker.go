@@ -0,0 +1,55 @@
+// backend/internal/workorder/sla_worker.go
+package workorder
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// SLAWorker periodically evaluates SLA rules for a fixed set of tenants
+type SLAWorker struct {
+	service  SLAService
+	tenants  []string
+	interval time.Duration
+}
+
+func NewSLAWorker(service SLAService, tenants []string, interval time.Duration) *SLAWorker {
+	return &SLAWorker{
+		service:  service,
+		tenants:  tenants,
+		interval: interval,
+	}
+}
+
+// Start runs the worker in the background until ctx is cancelled
+func (w *SLAWorker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		w.RunOnce(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.RunOnce(ctx)
+			}
+		}
+	}()
+}
+
+// RunOnce evaluates every tenant; one tenant failing does not stop the others
+func (w *SLAWorker) RunOnce(ctx context.Context) {
+	for _, tenantID := range w.tenants {
+		escalations, err := w.service.Evaluate(ctx, tenantID)
+		if err != nil {
+			log.Printf("SLA evaluation failed for tenant %s: %v", tenantID, err)
+			continue
+		}
+		if len(escalations) > 0 {
+			log.Printf("SLA evaluation raised %d escalation(s) for tenant %s", len(escalations), tenantID)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// backend/internal/workorder/status.go
+package workorder
+
+// allowedTransitions is the work order lifecycle:
+// DRAFT → PENDING → APPROVED → IN_PROGRESS → COMPLETED → INVOICED → PAID,
+// with CANCELLED and ON_HOLD reachable from any open status. A held work
+// order resumes only to the status it was held from (WorkOrder.HeldFrom),
+// which TransitionStatus enforces. Voiding an invoice returns the work order
+// from INVOICED to COMPLETED.
+var allowedTransitions = map[WorkOrderStatus][]WorkOrderStatus{
+	StatusDraft:      {StatusPending, StatusCancelled, StatusOnHold},
+	StatusPending:    {StatusApproved, StatusDraft, StatusCancelled, StatusOnHold},
+	StatusApproved:   {StatusInProgress, StatusCancelled, StatusOnHold},
+	StatusInProgress: {StatusCompleted, StatusCancelled, StatusOnHold},
+	StatusCompleted:  {StatusInvoiced},
+	StatusInvoiced:   {StatusPaid, StatusCompleted},
+	StatusOnHold:     {StatusDraft, StatusPending, StatusApproved, StatusInProgress, StatusCancelled},
+	StatusPaid:       {},
+	StatusCancelled:  {},
+}
+
+// IsValidStatus reports whether status is a known work order status
+func IsValidStatus(status WorkOrderStatus) bool {
+	_, ok := allowedTransitions[status]
+	return ok
+}
+
+// CanTransition reports whether a work order may move from one status to another
+func CanTransition(from, to WorkOrderStatus) bool {
+	for _, next := range allowedTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedTransitions returns the statuses reachable from the given status
+func AllowedTransitions(from WorkOrderStatus) []WorkOrderStatus {
+	next := allowedTransitions[from]
+	result := make([]WorkOrderStatus, len(next))
+	copy(result, next)
+	return result
+}
+
+// IsLaborEditable reports whether time entries may still be corrected. Once a
+// work order is invoiced its hours are locked in.
+func IsLaborEditable(status WorkOrderStatus) bool {
+	switch status {
+	case StatusInProgress, StatusOnHold, StatusCompleted:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsEditable reports whether work order details may still be changed
+func IsEditable(status WorkOrderStatus) bool {
+	switch status {
+	case StatusDraft, StatusPending, StatusApproved, StatusInProgress, StatusOnHold:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,244 @@
+// backend/internal/workorder/template.go
+package workorder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type TemplateService interface {
+	ListTemplates(ctx context.Context, tenantID string) ([]WorkOrderTemplate, error)
+	GetTemplate(ctx context.Context, tenantID string, id int) (*WorkOrderTemplate, error)
+	GetTemplateVersions(ctx context.Context, tenantID string, serviceType ServiceType) ([]WorkOrderTemplate, error)
+	SaveTemplate(ctx context.Context, tenantID string, userID int, template *WorkOrderTemplate) error
+	RetireTemplate(ctx context.Context, tenantID string, serviceType ServiceType) error
+
+	// Prefill fills the blanks of a new work order from the current template
+	// for its service type
+	Prefill(ctx context.Context, tenantID string, wo *WorkOrder) error
+	CreateFromTemplate(ctx context.Context, tenantID string, userID int, wo *WorkOrder) error
+
+	GetChecklist(ctx context.Context, tenantID string, workOrderID int) ([]ChecklistItem, error)
+	SetChecklistItem(ctx context.Context, tenantID string, userID, workOrderID, itemID int, checked bool) (*ChecklistItem, error)
+}
+
+type templateService struct {
+	repo       Repository
+	templates  TemplateRepository
+	workOrders Service
+}
+
+func NewTemplateService(repo Repository, templates TemplateRepository, workOrders Service) TemplateService {
+	return &templateService{
+		repo:       repo,
+		templates:  templates,
+		workOrders: workOrders,
+	}
+}
+
+func (s *templateService) ListTemplates(ctx context.Context, tenantID string) ([]WorkOrderTemplate, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.templates.GetTemplates(ctx, tenantID)
+}
+
+func (s *templateService) GetTemplate(ctx context.Context, tenantID string, id int) (*WorkOrderTemplate, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.templates.GetTemplateByID(ctx, tenantID, id)
+}
+
+func (s *templateService) GetTemplateVersions(ctx context.Context, tenantID string, serviceType ServiceType) ([]WorkOrderTemplate, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if !isValidServiceType(serviceType) {
+		return nil, fmt.Errorf("invalid service type: %s", serviceType)
+	}
+
+	return s.templates.GetTemplateVersions(ctx, tenantID, serviceType)
+}
+
+// SaveTemplate publishes a new version of the template for its service type
+func (s *templateService) SaveTemplate(ctx context.Context, tenantID string, userID int, template *WorkOrderTemplate) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if userID <= 0 {
+		return fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	if err := validateTemplate(template); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	template.CreatedByUserID = userID
+
+	return s.templates.CreateTemplateVersion(ctx, tenantID, template)
+}
+
+func (s *templateService) RetireTemplate(ctx context.Context, tenantID string, serviceType ServiceType) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.templates.RetireTemplate(ctx, tenantID, serviceType)
+}
+
+// Prefill only fills what the caller left empty, so a dispatcher can still
+// override any template value when creating the work order
+func (s *templateService) Prefill(ctx context.Context, tenantID string, wo *WorkOrder) error {
+	if err := validateTenantID(tenantID); err != nil {
+		return fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if wo == nil {
+		return fmt.Errorf("work order is required")
+	}
+
+	template, err := s.templates.GetCurrentTemplate(ctx, tenantID, wo.ServiceType)
+	if err != nil {
+		return err
+	}
+
+	applyTemplate(wo, template)
+	return nil
+}
+
+func (s *templateService) CreateFromTemplate(ctx context.Context, tenantID string, userID int, wo *WorkOrder) error {
+	if err := s.Prefill(ctx, tenantID, wo); err != nil {
+		return err
+	}
+
+	return s.workOrders.CreateWorkOrder(ctx, tenantID, userID, wo)
+}
+
+func (s *templateService) GetChecklist(ctx context.Context, tenantID string, workOrderID int) ([]ChecklistItem, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	return s.templates.GetChecklist(ctx, tenantID, workOrderID)
+}
+
+func (s *templateService) SetChecklistItem(ctx context.Context, tenantID string, userID, workOrderID, itemID int, checked bool) (*ChecklistItem, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	wo, err := s.repo.GetWorkOrderByID(ctx, tenantID, workOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work order %d: %w", workOrderID, err)
+	}
+
+	if wo.Status != StatusInProgress {
+		return nil, fmt.Errorf("%w: status is %s", ErrChecklistLocked, wo.Status)
+	}
+
+	return s.templates.SetChecklistItem(ctx, tenantID, workOrderID, itemID, checked, userID)
+}
+
+func applyTemplate(wo *WorkOrder, template *WorkOrderTemplate) {
+	if strings.TrimSpace(wo.Description) == "" {
+		wo.Description = template.Description
+	}
+	if wo.Instructions == nil && template.Instructions != nil {
+		instructions := *template.Instructions
+		wo.Instructions = &instructions
+	}
+	if wo.EstimatedHours == nil && template.EstimatedHours != nil {
+		hours := *template.EstimatedHours
+		wo.EstimatedHours = &hours
+	}
+	if wo.HourlyRate == nil && template.HourlyRate != nil {
+		rate := *template.HourlyRate
+		wo.HourlyRate = &rate
+	}
+
+	if len(wo.Items) == 0 {
+		for _, item := range template.Items {
+			wo.Items = append(wo.Items, WorkOrderItem{
+				Description: item.Description,
+				Quantity:    item.Quantity,
+				UnitPrice:   item.UnitPrice,
+			})
+		}
+	}
+
+	if len(wo.Checklist) == 0 {
+		for _, step := range template.Checklist {
+			wo.Checklist = append(wo.Checklist, ChecklistItem{
+				Label:    step.Label,
+				Required: step.Required,
+			})
+		}
+	}
+
+	id, version := template.ID, template.Version
+	wo.TemplateID = &id
+	wo.TemplateVersion = &version
+}
+
+func validateTemplate(t *WorkOrderTemplate) error {
+	if t == nil {
+		return fmt.Errorf("template is required")
+	}
+
+	if !isValidServiceType(t.ServiceType) {
+		return fmt.Errorf("invalid service type: %s", t.ServiceType)
+	}
+
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" {
+		t.Name = string(t.ServiceType)
+	}
+	if len(t.Name) > 100 {
+		return fmt.Errorf("name too long: %d characters", len(t.Name))
+	}
+
+	if strings.TrimSpace(t.Description) == "" {
+		return fmt.Errorf("description is required")
+	}
+
+	if t.EstimatedHours != nil && *t.EstimatedHours < 0 {
+		return fmt.Errorf("estimated hours cannot be negative")
+	}
+	if t.HourlyRate != nil && *t.HourlyRate < 0 {
+		return fmt.Errorf("hourly rate cannot be negative")
+	}
+
+	for i, item := range t.Items {
+		if strings.TrimSpace(item.Description) == "" {
+			return fmt.Errorf("item %d: description is required", i+1)
+		}
+		if item.Quantity <= 0 {
+			return fmt.Errorf("item %d: quantity must be positive", i+1)
+		}
+		if item.UnitPrice != nil && *item.UnitPrice < 0 {
+			return fmt.Errorf("item %d: unit price cannot be negative", i+1)
+		}
+	}
+
+	for i, step := range t.Checklist {
+		if strings.TrimSpace(step.Label) == "" {
+			return fmt.Errorf("checklist step %d: label is required", i+1)
+		}
+	}
+
+	// Store empty lists rather than null so every version decodes the same way
+	if t.Items == nil {
+		t.Items = []TemplateItem{}
+	}
+	if t.Checklist == nil {
+		t.Checklist = []TemplateChecklistItem{}
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+// backend/internal/workorder/template_handlers.go
+package workorder
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"oilgas-backend/internal/auth"
+)
+
+type TemplateHandlers struct {
+	service TemplateService
+}
+
+func NewTemplateHandlers(service TemplateService) *TemplateHandlers {
+	return &TemplateHandlers{service: service}
+}
+
+func (h *TemplateHandlers) RegisterRoutes(router *gin.RouterGroup, authMiddleware *auth.Middleware) {
+	technicians := authMiddleware.RequireRole(auth.RoleOperator, auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin)
+	admins := authMiddleware.RequireRole(auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin)
+
+	templates := router.Group("/workorder-templates")
+	templates.Use(authMiddleware.RequireAuth())
+
+	templates.GET("", h.ListTemplates)
+	templates.GET("/:id", h.GetTemplate)
+	templates.GET("/service-types/:serviceType/versions", h.GetTemplateVersions)
+	templates.POST("", admins, h.SaveTemplate)
+	templates.DELETE("/service-types/:serviceType", admins, h.RetireTemplate)
+
+	workOrders := router.Group("/workorders")
+	workOrders.Use(authMiddleware.RequireAuth())
+
+	workOrders.POST("/prefill", authMiddleware.RequirePermission(auth.PermissionCreateWorkOrder), h.Prefill)
+	workOrders.GET("/:id/checklist", h.GetChecklist)
+	workOrders.PUT("/:id/checklist/:itemId", technicians, h.SetChecklistItem)
+}
+
+func (h *TemplateHandlers) ListTemplates(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+
+	templates, err := h.service.ListTemplates(c.Request.Context(), tenantID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get work order templates"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  templates,
+		"total": len(templates),
+	})
+}
+
+// GetTemplate returns one template version, including retired ones still
+// referenced by older work orders
+func (h *TemplateHandlers) GetTemplate(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	template, err := h.service.GetTemplate(c.Request.Context(), tenantID, id)
+	if err != nil {
+		c.JSON(templateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+func (h *TemplateHandlers) GetTemplateVersions(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	serviceType := ServiceType(strings.ToUpper(c.Param("serviceType")))
+
+	versions, err := h.service.GetTemplateVersions(c.Request.Context(), tenantID, serviceType)
+	if err != nil {
+		c.JSON(templateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  versions,
+		"total": len(versions),
+	})
+}
+
+func (h *TemplateHandlers) SaveTemplate(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+
+	var template WorkOrderTemplate
+	if err := c.ShouldBindJSON(&template); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.SaveTemplate(c.Request.Context(), tenantID, c.GetInt("user_id"), &template); err != nil {
+		c.JSON(templateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+func (h *TemplateHandlers) RetireTemplate(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	serviceType := ServiceType(strings.ToUpper(c.Param("serviceType")))
+
+	if err := h.service.RetireTemplate(c.Request.Context(), tenantID, serviceType); err != nil {
+		c.JSON(templateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Template retired"})
+}
+
+// Prefill returns a draft work order filled from the current template for
+// the requested service type without saving it, for the create form
+func (h *TemplateHandlers) Prefill(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+
+	var wo WorkOrder
+	if err := c.ShouldBindJSON(&wo); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.Prefill(c.Request.Context(), tenantID, &wo); err != nil {
+		c.JSON(templateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, wo)
+}
+
+func (h *TemplateHandlers) GetChecklist(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid work order ID"})
+		return
+	}
+
+	items, err := h.service.GetChecklist(c.Request.Context(), tenantID, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get checklist"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  items,
+		"total": len(items),
+	})
+}
+
+type ChecklistItemRequest struct {
+	Checked bool `json:"checked"`
+}
+
+func (h *TemplateHandlers) SetChecklistItem(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid work order ID"})
+		return
+	}
+
+	itemID, err := strconv.Atoi(c.Param("itemId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid checklist item ID"})
+		return
+	}
+
+	var req ChecklistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.service.SetChecklistItem(c.Request.Context(), tenantID, c.GetInt("user_id"), id, itemID, req.Checked)
+	if err != nil {
+		c.JSON(templateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+func templateErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrTemplateNotFound), errors.Is(err, ErrWorkOrderNotFound), errors.Is(err, ErrChecklistItemNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrTemplateConflict), errors.Is(err, ErrChecklistLocked):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,281 @@
+// backend/internal/workorder/template_repository.go
+package workorder
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"oilgas-backend/internal/shared/database"
+)
+
+type TemplateRepository interface {
+	GetTemplates(ctx context.Context, tenantID string) ([]WorkOrderTemplate, error)
+	GetTemplateByID(ctx context.Context, tenantID string, id int) (*WorkOrderTemplate, error)
+	GetCurrentTemplate(ctx context.Context, tenantID string, serviceType ServiceType) (*WorkOrderTemplate, error)
+	GetTemplateVersions(ctx context.Context, tenantID string, serviceType ServiceType) ([]WorkOrderTemplate, error)
+	CreateTemplateVersion(ctx context.Context, tenantID string, template *WorkOrderTemplate) error
+	RetireTemplate(ctx context.Context, tenantID string, serviceType ServiceType) error
+
+	GetChecklist(ctx context.Context, tenantID string, workOrderID int) ([]ChecklistItem, error)
+	SetChecklistItem(ctx context.Context, tenantID string, workOrderID, itemID int, checked bool, userID int) (*ChecklistItem, error)
+}
+
+type templateRepository struct {
+	dbManager *database.DatabaseManager
+}
+
+func NewTemplateRepository(dbManager *database.DatabaseManager) TemplateRepository {
+	return &templateRepository{dbManager: dbManager}
+}
+
+const templateColumns = `
+		id, tenant_id, service_type, version, name, description, instructions,
+		estimated_hours, hourly_rate, items, checklist, is_current, created_by_user_id, created_at`
+
+func scanTemplate(row rowScanner, t *WorkOrderTemplate) error {
+	var items, checklist []byte
+	err := row.Scan(
+		&t.ID, &t.TenantID, &t.ServiceType, &t.Version, &t.Name, &t.Description, &t.Instructions,
+		&t.EstimatedHours, &t.HourlyRate, &items, &checklist, &t.IsCurrent, &t.CreatedByUserID, &t.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(items, &t.Items); err != nil {
+		return fmt.Errorf("failed to decode template items: %w", err)
+	}
+	if err := json.Unmarshal(checklist, &t.Checklist); err != nil {
+		return fmt.Errorf("failed to decode template checklist: %w", err)
+	}
+	return nil
+}
+
+// GetTemplates lists the current version of every template
+func (r *templateRepository) GetTemplates(ctx context.Context, tenantID string) ([]WorkOrderTemplate, error) {
+	return r.queryTemplates(ctx, tenantID, `SELECT `+templateColumns+`
+		FROM store.workorder_templates
+		WHERE tenant_id = $1 AND is_current = true
+		ORDER BY service_type`, tenantID)
+}
+
+func (r *templateRepository) GetTemplateVersions(ctx context.Context, tenantID string, serviceType ServiceType) ([]WorkOrderTemplate, error) {
+	return r.queryTemplates(ctx, tenantID, `SELECT `+templateColumns+`
+		FROM store.workorder_templates
+		WHERE tenant_id = $1 AND service_type = $2
+		ORDER BY version DESC`, tenantID, serviceType)
+}
+
+func (r *templateRepository) GetTemplateByID(ctx context.Context, tenantID string, id int) (*WorkOrderTemplate, error) {
+	return r.queryTemplate(ctx, tenantID, `SELECT `+templateColumns+`
+		FROM store.workorder_templates
+		WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+}
+
+func (r *templateRepository) GetCurrentTemplate(ctx context.Context, tenantID string, serviceType ServiceType) (*WorkOrderTemplate, error) {
+	return r.queryTemplate(ctx, tenantID, `SELECT `+templateColumns+`
+		FROM store.workorder_templates
+		WHERE tenant_id = $1 AND service_type = $2 AND is_current = true`, tenantID, serviceType)
+}
+
+func (r *templateRepository) queryTemplate(ctx context.Context, tenantID, query string, args ...interface{}) (*WorkOrderTemplate, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	var t WorkOrderTemplate
+	if err := scanTemplate(db.QueryRowContext(ctx, query, args...), &t); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("failed to get work order template: %w", err)
+	}
+
+	return &t, nil
+}
+
+func (r *templateRepository) queryTemplates(ctx context.Context, tenantID, query string, args ...interface{}) ([]WorkOrderTemplate, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work order templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []WorkOrderTemplate
+	for rows.Next() {
+		var t WorkOrderTemplate
+		if err := scanTemplate(rows, &t); err != nil {
+			return nil, fmt.Errorf("failed to scan work order template: %w", err)
+		}
+		templates = append(templates, t)
+	}
+
+	return templates, rows.Err()
+}
+
+// CreateTemplateVersion stores the template as the next version for its
+// service type and makes it current. Earlier versions are kept unchanged so
+// work orders created from them still resolve.
+func (r *templateRepository) CreateTemplateVersion(ctx context.Context, tenantID string, t *WorkOrderTemplate) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	items, err := json.Marshal(t.Items)
+	if err != nil {
+		return fmt.Errorf("failed to encode template items: %w", err)
+	}
+	checklist, err := json.Marshal(t.Checklist)
+	if err != nil {
+		return fmt.Errorf("failed to encode template checklist: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		SELECT COALESCE(MAX(version), 0) + 1
+		FROM store.workorder_templates
+		WHERE tenant_id = $1 AND service_type = $2`,
+		tenantID, t.ServiceType).Scan(&t.Version)
+	if err != nil {
+		return fmt.Errorf("failed to get next template version: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE store.workorder_templates SET is_current = false
+		WHERE tenant_id = $1 AND service_type = $2 AND is_current = true`,
+		tenantID, t.ServiceType); err != nil {
+		return fmt.Errorf("failed to retire previous template version: %w", err)
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO store.workorder_templates (
+			tenant_id, service_type, version, name, description, instructions,
+			estimated_hours, hourly_rate, items, checklist, is_current, created_by_user_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, true, $11)
+		RETURNING id, created_at`,
+		tenantID, t.ServiceType, t.Version, t.Name, t.Description, t.Instructions,
+		t.EstimatedHours, t.HourlyRate, items, checklist, t.CreatedByUserID,
+	).Scan(&t.ID, &t.CreatedAt)
+	if err != nil {
+		// Two admins saving the same service type at once race for the version number
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return ErrTemplateConflict
+		}
+		return fmt.Errorf("failed to create template version: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit template: %w", err)
+	}
+
+	t.TenantID = tenantID
+	t.IsCurrent = true
+	return nil
+}
+
+// RetireTemplate stops a service type from prefilling new work orders
+func (r *templateRepository) RetireTemplate(ctx context.Context, tenantID string, serviceType ServiceType) error {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	result, err := db.ExecContext(ctx, `
+		UPDATE store.workorder_templates SET is_current = false
+		WHERE tenant_id = $1 AND service_type = $2 AND is_current = true`,
+		tenantID, serviceType)
+	if err != nil {
+		return fmt.Errorf("failed to retire template: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check retired rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrTemplateNotFound
+	}
+
+	return nil
+}
+
+func (r *templateRepository) GetChecklist(ctx context.Context, tenantID string, workOrderID int) ([]ChecklistItem, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT c.id, c.workorder_id, c.label, c.required, c.sort_order,
+		       c.is_checked, c.checked_by_user_id, c.checked_at
+		FROM store.workorder_checklist_items c
+		JOIN store.workorders w ON w.id = c.workorder_id
+		WHERE c.workorder_id = $1 AND w.tenant_id = $2
+		ORDER BY c.sort_order`, workOrderID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checklist: %w", err)
+	}
+	defer rows.Close()
+
+	var items []ChecklistItem
+	for rows.Next() {
+		var item ChecklistItem
+		err := rows.Scan(
+			&item.ID, &item.WorkOrderID, &item.Label, &item.Required, &item.SortOrder,
+			&item.IsChecked, &item.CheckedByUserID, &item.CheckedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan checklist item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func (r *templateRepository) SetChecklistItem(ctx context.Context, tenantID string, workOrderID, itemID int, checked bool, userID int) (*ChecklistItem, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	var item ChecklistItem
+	err = db.QueryRowContext(ctx, `
+		UPDATE store.workorder_checklist_items c
+		SET is_checked = $4,
+		    checked_by_user_id = CASE WHEN $4 THEN $5::INTEGER END,
+		    checked_at = CASE WHEN $4 THEN NOW() END
+		FROM store.workorders w
+		WHERE c.id = $1 AND c.workorder_id = $2 AND w.id = c.workorder_id AND w.tenant_id = $3
+		RETURNING c.id, c.workorder_id, c.label, c.required, c.sort_order,
+		          c.is_checked, c.checked_by_user_id, c.checked_at`,
+		itemID, workOrderID, tenantID, checked, userID,
+	).Scan(
+		&item.ID, &item.WorkOrderID, &item.Label, &item.Required, &item.SortOrder,
+		&item.IsChecked, &item.CheckedByUserID, &item.CheckedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChecklistItemNotFound
+		}
+		return nil, fmt.Errorf("failed to update checklist item: %w", err)
+	}
+
+	return &item, nil
+}
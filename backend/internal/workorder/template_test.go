@@ -0,0 +1,209 @@
+// backend/internal/workorder/template_test.go
+package workorder
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type mockTemplateRepository struct {
+	mock.Mock
+}
+
+func (m *mockTemplateRepository) GetTemplates(ctx context.Context, tenantID string) ([]WorkOrderTemplate, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).([]WorkOrderTemplate), args.Error(1)
+}
+
+func (m *mockTemplateRepository) GetTemplateByID(ctx context.Context, tenantID string, id int) (*WorkOrderTemplate, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*WorkOrderTemplate), args.Error(1)
+}
+
+func (m *mockTemplateRepository) GetCurrentTemplate(ctx context.Context, tenantID string, serviceType ServiceType) (*WorkOrderTemplate, error) {
+	args := m.Called(ctx, tenantID, serviceType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*WorkOrderTemplate), args.Error(1)
+}
+
+func (m *mockTemplateRepository) GetTemplateVersions(ctx context.Context, tenantID string, serviceType ServiceType) ([]WorkOrderTemplate, error) {
+	args := m.Called(ctx, tenantID, serviceType)
+	return args.Get(0).([]WorkOrderTemplate), args.Error(1)
+}
+
+func (m *mockTemplateRepository) CreateTemplateVersion(ctx context.Context, tenantID string, template *WorkOrderTemplate) error {
+	args := m.Called(ctx, tenantID, template)
+	return args.Error(0)
+}
+
+func (m *mockTemplateRepository) RetireTemplate(ctx context.Context, tenantID string, serviceType ServiceType) error {
+	args := m.Called(ctx, tenantID, serviceType)
+	return args.Error(0)
+}
+
+func (m *mockTemplateRepository) GetChecklist(ctx context.Context, tenantID string, workOrderID int) ([]ChecklistItem, error) {
+	args := m.Called(ctx, tenantID, workOrderID)
+	return args.Get(0).([]ChecklistItem), args.Error(1)
+}
+
+func (m *mockTemplateRepository) SetChecklistItem(ctx context.Context, tenantID string, workOrderID, itemID int, checked bool, userID int) (*ChecklistItem, error) {
+	args := m.Called(ctx, tenantID, workOrderID, itemID, checked, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ChecklistItem), args.Error(1)
+}
+
+type TemplateServiceTestSuite struct {
+	suite.Suite
+	service   TemplateService
+	repo      *mockRepository
+	templates *mockTemplateRepository
+	publisher *mockPublisher
+	ctx       context.Context
+	tenantID  string
+}
+
+func (suite *TemplateServiceTestSuite) SetupTest() {
+	suite.repo = &mockRepository{}
+	suite.templates = &mockTemplateRepository{}
+	suite.publisher = &mockPublisher{}
+	suite.service = NewTemplateService(suite.repo, suite.templates, NewService(suite.repo, suite.publisher))
+	suite.ctx = context.Background()
+	suite.tenantID = "longbeach"
+}
+
+func TestTemplateServiceSuite(t *testing.T) {
+	suite.Run(t, new(TemplateServiceTestSuite))
+}
+
+func (suite *TemplateServiceTestSuite) inspectionTemplate() *WorkOrderTemplate {
+	instructions := "Drift, visually inspect threads, EMI each joint"
+	return &WorkOrderTemplate{
+		ID:             14,
+		ServiceType:    ServiceInspection,
+		Version:        3,
+		Name:           "Standard inspection",
+		Description:    "Full-length inspection of OCTG",
+		Instructions:   &instructions,
+		EstimatedHours: floatPtr(6),
+		HourlyRate:     floatPtr(95),
+		Items: []TemplateItem{
+			{Description: "Thread protectors", Quantity: 2, UnitPrice: floatPtr(12.5)},
+		},
+		Checklist: []TemplateChecklistItem{
+			{Label: "Drift test", Required: true},
+			{Label: "Photograph damaged joints"},
+		},
+		IsCurrent: true,
+	}
+}
+
+func (suite *TemplateServiceTestSuite) TestPrefill_FillsBlanksAndKeepsOverrides() {
+	suite.templates.On("GetCurrentTemplate", suite.ctx, suite.tenantID, ServiceInspection).
+		Return(suite.inspectionTemplate(), nil)
+
+	wo := &WorkOrder{
+		CustomerID:     3,
+		ServiceType:    ServiceInspection,
+		EstimatedHours: floatPtr(10), // dispatcher override
+	}
+
+	err := suite.service.Prefill(suite.ctx, suite.tenantID, wo)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Full-length inspection of OCTG", wo.Description)
+	assert.Equal(suite.T(), 10.0, *wo.EstimatedHours)
+	assert.Equal(suite.T(), 95.0, *wo.HourlyRate)
+	assert.Len(suite.T(), wo.Items, 1)
+	assert.Len(suite.T(), wo.Checklist, 2)
+	assert.True(suite.T(), wo.Checklist[0].Required)
+	assert.Equal(suite.T(), 14, *wo.TemplateID)
+	assert.Equal(suite.T(), 3, *wo.TemplateVersion)
+}
+
+func (suite *TemplateServiceTestSuite) TestPrefill_NoTemplate() {
+	suite.templates.On("GetCurrentTemplate", suite.ctx, suite.tenantID, ServiceRepair).
+		Return(nil, ErrTemplateNotFound)
+
+	err := suite.service.Prefill(suite.ctx, suite.tenantID, &WorkOrder{ServiceType: ServiceRepair})
+
+	assert.True(suite.T(), errors.Is(err, ErrTemplateNotFound))
+}
+
+func (suite *TemplateServiceTestSuite) TestCreateFromTemplate_PricesItemsAndOrdersChecklist() {
+	suite.templates.On("GetCurrentTemplate", suite.ctx, suite.tenantID, ServiceInspection).
+		Return(suite.inspectionTemplate(), nil)
+	suite.repo.On("CreateWorkOrder", suite.ctx, suite.tenantID, mock.MatchedBy(func(wo *WorkOrder) bool {
+		return wo.Status == StatusDraft && *wo.TemplateVersion == 3 &&
+			*wo.Items[0].TotalPrice == 25.0 &&
+			wo.Checklist[0].SortOrder == 1 && wo.Checklist[1].SortOrder == 2
+	}), mock.Anything).Return(nil)
+	suite.publisher.On("Publish", suite.ctx, mock.AnythingOfType("*workorder.WorkOrderCreatedEvent")).Return(nil)
+
+	err := suite.service.CreateFromTemplate(suite.ctx, suite.tenantID, 7, &WorkOrder{
+		CustomerID:  3,
+		ServiceType: ServiceInspection,
+	})
+
+	assert.NoError(suite.T(), err)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TemplateServiceTestSuite) TestSaveTemplate_CreatesVersion() {
+	template := &WorkOrderTemplate{
+		ServiceType: ServiceCleaning,
+		Description: "Clean and drift tubing",
+	}
+
+	suite.templates.On("CreateTemplateVersion", suite.ctx, suite.tenantID, mock.MatchedBy(func(t *WorkOrderTemplate) bool {
+		return t.CreatedByUserID == 7 && t.Name == "CLEANING" && t.Items != nil && t.Checklist != nil
+	})).Return(nil)
+
+	err := suite.service.SaveTemplate(suite.ctx, suite.tenantID, 7, template)
+
+	assert.NoError(suite.T(), err)
+	suite.templates.AssertExpectations(suite.T())
+}
+
+func (suite *TemplateServiceTestSuite) TestSaveTemplate_ValidationErrors() {
+	testCases := []struct {
+		name     string
+		template *WorkOrderTemplate
+	}{
+		{"unknown service type", &WorkOrderTemplate{ServiceType: "WELDING", Description: "Weld"}},
+		{"missing description", &WorkOrderTemplate{ServiceType: ServiceCleaning}},
+		{"zero quantity item", &WorkOrderTemplate{ServiceType: ServiceCleaning, Description: "Clean",
+			Items: []TemplateItem{{Description: "Solvent", Quantity: 0}}}},
+		{"blank checklist step", &WorkOrderTemplate{ServiceType: ServiceCleaning, Description: "Clean",
+			Checklist: []TemplateChecklistItem{{Label: " "}}}},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			err := suite.service.SaveTemplate(suite.ctx, suite.tenantID, 7, tc.template)
+			assert.ErrorContains(suite.T(), err, "validation failed")
+		})
+	}
+	suite.templates.AssertNotCalled(suite.T(), "CreateTemplateVersion")
+}
+
+func (suite *TemplateServiceTestSuite) TestSetChecklistItem_OnlyWhileInProgress() {
+	suite.repo.On("GetWorkOrderByID", suite.ctx, suite.tenantID, 42).
+		Return(&WorkOrder{ID: 42, Status: StatusApproved}, nil)
+
+	_, err := suite.service.SetChecklistItem(suite.ctx, suite.tenantID, 7, 42, 1, true)
+
+	assert.True(suite.T(), errors.Is(err, ErrChecklistLocked))
+	suite.templates.AssertNotCalled(suite.T(), "SetChecklistItem")
+}
@@ -0,0 +1,283 @@
+// backend/internal/workorder/variance.go
+package workorder
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// DefaultVarianceThresholdPct flags jobs that ran more than 20% over or
+// under their quote when the caller does not choose a threshold
+const DefaultVarianceThresholdPct = 20
+
+// maxVarianceRange keeps a single report to roughly two years of jobs
+const maxVarianceRange = 731 * 24 * time.Hour
+
+type VarianceService interface {
+	// GetVarianceReport compares estimated and actual hours and cost for the
+	// work orders completed in the filter's date range
+	GetVarianceReport(ctx context.Context, tenantID string, filters VarianceFilters) (*VarianceReport, error)
+}
+
+type varianceService struct {
+	variance VarianceRepository
+}
+
+func NewVarianceService(variance VarianceRepository) VarianceService {
+	return &varianceService{variance: variance}
+}
+
+func (s *varianceService) GetVarianceReport(ctx context.Context, tenantID string, filters VarianceFilters) (*VarianceReport, error) {
+	if err := validateTenantID(tenantID); err != nil {
+		return nil, fmt.Errorf("invalid tenant: %w", err)
+	}
+
+	if err := validateVarianceFilters(&filters); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	records, err := s.variance.GetVarianceRecords(ctx, tenantID, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get completed work orders: %w", err)
+	}
+
+	return buildVarianceReport(records, filters), nil
+}
+
+func validateVarianceFilters(filters *VarianceFilters) error {
+	if filters.From.IsZero() || filters.To.IsZero() {
+		return fmt.Errorf("from and to dates are required")
+	}
+	if !filters.To.After(filters.From) {
+		return fmt.Errorf("to must be after from")
+	}
+	if filters.To.Sub(filters.From) > maxVarianceRange {
+		return fmt.Errorf("date range too large (max 2 years)")
+	}
+	if filters.ServiceType != nil && !isValidServiceType(*filters.ServiceType) {
+		return fmt.Errorf("invalid service type: %s", *filters.ServiceType)
+	}
+	if filters.CustomerID != nil && *filters.CustomerID <= 0 {
+		return fmt.Errorf("invalid customer ID: %d", *filters.CustomerID)
+	}
+	if filters.ThresholdPct < 0 || filters.ThresholdPct > 1000 {
+		return fmt.Errorf("threshold must be between 0 and 1000 percent")
+	}
+	if filters.ThresholdPct == 0 {
+		filters.ThresholdPct = DefaultVarianceThresholdPct
+	}
+	return nil
+}
+
+// varianceAccumulator collects one group's totals before rounding
+type varianceAccumulator struct {
+	totals         VarianceTotals
+	estimatedHours float64
+	actualHours    float64
+	estimatedCost  float64
+	actualCost     float64
+}
+
+func (a *varianceAccumulator) add(v *WorkOrderVariance, share float64) {
+	a.totals.WorkOrders++
+	if v.Flagged {
+		a.totals.Flagged++
+	}
+	a.estimatedHours += v.EstimatedHours * share
+	a.actualHours += v.ActualHours * share
+	a.estimatedCost += v.EstimatedCost * share
+	a.actualCost += v.ActualCost * share
+}
+
+func (a *varianceAccumulator) result() VarianceTotals {
+	t := a.totals
+	t.EstimatedHours = roundVariance(a.estimatedHours)
+	t.ActualHours = roundVariance(a.actualHours)
+	t.HoursVariance = roundVariance(a.actualHours - a.estimatedHours)
+	t.HoursVariancePct = variancePct(a.estimatedHours, a.actualHours)
+	t.EstimatedCost = roundVariance(a.estimatedCost)
+	t.ActualCost = roundVariance(a.actualCost)
+	t.CostVariance = roundVariance(a.actualCost - a.estimatedCost)
+	t.CostVariancePct = variancePct(a.estimatedCost, a.actualCost)
+	return t
+}
+
+type varianceGroups struct {
+	byKey map[string]*varianceAccumulator
+	order []string
+}
+
+func (g *varianceGroups) get(key, label string) *varianceAccumulator {
+	if g.byKey == nil {
+		g.byKey = map[string]*varianceAccumulator{}
+	}
+	acc, ok := g.byKey[key]
+	if !ok {
+		acc = &varianceAccumulator{totals: VarianceTotals{Key: key, Label: label}}
+		g.byKey[key] = acc
+		g.order = append(g.order, key)
+	}
+	return acc
+}
+
+// results lists the groups with the largest absolute hours variance first
+func (g *varianceGroups) results() []VarianceTotals {
+	totals := make([]VarianceTotals, 0, len(g.order))
+	for _, key := range g.order {
+		totals = append(totals, g.byKey[key].result())
+	}
+	sort.SliceStable(totals, func(i, j int) bool {
+		return math.Abs(totals[i].HoursVariance) > math.Abs(totals[j].HoursVariance)
+	})
+	return totals
+}
+
+// buildVarianceReport prices every estimated work order and rolls the
+// results up. A technician is credited with the share of each job's estimate
+// matching their share of its logged hours; jobs without time entries count
+// in full for the assigned technician.
+func buildVarianceReport(records []VarianceRecord, filters VarianceFilters) *VarianceReport {
+	report := &VarianceReport{
+		From:         filters.From,
+		To:           filters.To,
+		ThresholdPct: filters.ThresholdPct,
+		WorkOrders:   []WorkOrderVariance{},
+	}
+
+	var byServiceType, byCustomer, byTechnician varianceGroups
+
+	for _, record := range records {
+		wo := record.WorkOrder
+		if wo.EstimatedHours == nil || *wo.EstimatedHours <= 0 {
+			report.Unestimated++
+			continue
+		}
+
+		v := workOrderVariance(record, filters.ThresholdPct)
+		report.WorkOrders = append(report.WorkOrders, v)
+
+		byServiceType.get(string(wo.ServiceType), string(wo.ServiceType)).add(&v, 1)
+		byCustomer.get(strconv.Itoa(wo.CustomerID), record.CustomerName).add(&v, 1)
+
+		logged := 0.0
+		for _, hours := range record.TechnicianHours {
+			logged += hours
+		}
+		if logged > 0 {
+			userIDs := make([]int, 0, len(record.TechnicianHours))
+			for userID := range record.TechnicianHours {
+				userIDs = append(userIDs, userID)
+			}
+			sort.Ints(userIDs)
+			for _, userID := range userIDs {
+				key := strconv.Itoa(userID)
+				byTechnician.get(key, key).add(&v, record.TechnicianHours[userID]/logged)
+			}
+		} else if wo.AssignedToUserID != nil {
+			key := strconv.Itoa(*wo.AssignedToUserID)
+			byTechnician.get(key, key).add(&v, 1)
+		}
+	}
+
+	report.ByServiceType = byServiceType.results()
+	report.ByCustomer = byCustomer.results()
+	report.ByTechnician = byTechnician.results()
+
+	return report
+}
+
+func workOrderVariance(record VarianceRecord, thresholdPct float64) WorkOrderVariance {
+	wo := record.WorkOrder
+
+	estimated := *wo.EstimatedHours
+	actual := 0.0
+	if wo.ActualHours != nil {
+		actual = *wo.ActualHours
+	}
+	rate, materials := 0.0, 0.0
+	if wo.HourlyRate != nil {
+		rate = *wo.HourlyRate
+	}
+	if wo.MaterialsCost != nil {
+		materials = *wo.MaterialsCost
+	}
+	estimatedCost := estimated*rate + materials
+	actualCost := actual*rate + materials
+
+	v := WorkOrderVariance{
+		WorkOrderID:      wo.ID,
+		WorkOrderNumber:  wo.WorkOrderNumber,
+		CustomerID:       wo.CustomerID,
+		CustomerName:     record.CustomerName,
+		ServiceType:      wo.ServiceType,
+		AssignedToUserID: wo.AssignedToUserID,
+		CompletedAt:      wo.CompletedAt,
+		EstimatedHours:   roundVariance(estimated),
+		ActualHours:      roundVariance(actual),
+		HoursVariance:    roundVariance(actual - estimated),
+		HoursVariancePct: *variancePct(estimated, actual),
+		EstimatedCost:    roundVariance(estimatedCost),
+		ActualCost:       roundVariance(actualCost),
+		CostVariance:     roundVariance(actualCost - estimatedCost),
+		CostVariancePct:  variancePct(estimatedCost, actualCost),
+	}
+
+	v.Flagged = math.Abs(v.HoursVariancePct) > thresholdPct ||
+		(v.CostVariancePct != nil && math.Abs(*v.CostVariancePct) > thresholdPct)
+
+	return v
+}
+
+// variancePct is how far actual is from estimated as a percentage of the
+// estimate; positive means over
+func variancePct(estimated, actual float64) *float64 {
+	if estimated <= 0 {
+		return nil
+	}
+	pct := roundVariance((actual - estimated) / estimated * 100)
+	return &pct
+}
+
+func roundVariance(f float64) float64 {
+	return math.Round(f*100) / 100
+}
+
+// WriteVarianceCSV writes one row per work order in the report
+func WriteVarianceCSV(w io.Writer, report *VarianceReport) error {
+	cw := csv.NewWriter(w)
+
+	cw.Write([]string{
+		"work_order_number", "customer_id", "customer_name", "service_type", "assigned_to_user_id", "completed_at",
+		"estimated_hours", "actual_hours", "hours_variance", "hours_variance_pct",
+		"estimated_cost", "actual_cost", "cost_variance", "cost_variance_pct", "flagged",
+	})
+
+	for _, v := range report.WorkOrders {
+		cw.Write([]string{
+			v.WorkOrderNumber,
+			strconv.Itoa(v.CustomerID),
+			v.CustomerName,
+			string(v.ServiceType),
+			formatInt(v.AssignedToUserID),
+			formatTime(v.CompletedAt),
+			formatFloat(&v.EstimatedHours),
+			formatFloat(&v.ActualHours),
+			formatFloat(&v.HoursVariance),
+			formatFloat(&v.HoursVariancePct),
+			formatFloat(&v.EstimatedCost),
+			formatFloat(&v.ActualCost),
+			formatFloat(&v.CostVariance),
+			formatFloat(v.CostVariancePct),
+			strconv.FormatBool(v.Flagged),
+		})
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
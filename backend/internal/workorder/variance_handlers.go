@@ -0,0 +1,119 @@
+// backend/internal/workorder/variance_handlers.go
+package workorder
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"oilgas-backend/internal/auth"
+)
+
+type VarianceHandlers struct {
+	service VarianceService
+}
+
+func NewVarianceHandlers(service VarianceService) *VarianceHandlers {
+	return &VarianceHandlers{service: service}
+}
+
+func (h *VarianceHandlers) RegisterRoutes(router *gin.RouterGroup, authMiddleware *auth.Middleware) {
+	reports := router.Group("/workorders/reports")
+	reports.Use(authMiddleware.RequireAuth())
+	reports.Use(authMiddleware.RequireRole(auth.RoleManager, auth.RoleAdmin, auth.RoleEnterpriseAdmin, auth.RoleSystemAdmin))
+
+	reports.GET("/variance", h.GetVarianceReport)
+	reports.GET("/variance.csv", authMiddleware.RequirePermission(auth.PermissionExportData), h.ExportVarianceCSV)
+}
+
+// GetVarianceReport compares estimates with actuals for work orders completed
+// between from and to (YYYY-MM-DD, inclusive). Optional: service_type,
+// customer_id and threshold (percent).
+func (h *VarianceHandlers) GetVarianceReport(c *gin.Context) {
+	report, ok := h.buildReport(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ExportVarianceCSV returns the per-work-order rows of the same report as CSV
+func (h *VarianceHandlers) ExportVarianceCSV(c *gin.Context) {
+	report, ok := h.buildReport(c)
+	if !ok {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := WriteVarianceCSV(&buf, report); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export variance report"})
+		return
+	}
+
+	filename := fmt.Sprintf("workorder-variance-%s-%s.csv",
+		report.From.Format("20060102"), report.To.AddDate(0, 0, -1).Format("20060102"))
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+func (h *VarianceHandlers) buildReport(c *gin.Context) (*VarianceReport, bool) {
+	tenantID := c.GetString("tenant_id")
+
+	filters, err := parseVarianceFilters(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	report, err := h.service.GetVarianceReport(c.Request.Context(), tenantID, filters)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	return report, true
+}
+
+func parseVarianceFilters(c *gin.Context) (VarianceFilters, error) {
+	var filters VarianceFilters
+
+	from, err := time.Parse("2006-01-02", c.Query("from"))
+	if err != nil {
+		return filters, fmt.Errorf("invalid from date, expected YYYY-MM-DD")
+	}
+	to, err := time.Parse("2006-01-02", c.Query("to"))
+	if err != nil {
+		return filters, fmt.Errorf("invalid to date, expected YYYY-MM-DD")
+	}
+	filters.From = from
+	filters.To = to.AddDate(0, 0, 1)
+
+	if serviceType := c.Query("service_type"); serviceType != "" {
+		st := ServiceType(strings.ToUpper(serviceType))
+		filters.ServiceType = &st
+	}
+
+	if customerID := c.Query("customer_id"); customerID != "" {
+		id, err := strconv.Atoi(customerID)
+		if err != nil {
+			return filters, fmt.Errorf("invalid customer ID")
+		}
+		filters.CustomerID = &id
+	}
+
+	if threshold := c.Query("threshold"); threshold != "" {
+		pct, err := strconv.ParseFloat(threshold, 64)
+		if err != nil {
+			return filters, fmt.Errorf("invalid threshold")
+		}
+		filters.ThresholdPct = pct
+	}
+
+	return filters, nil
+}
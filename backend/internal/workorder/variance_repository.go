@@ -0,0 +1,121 @@
+// backend/internal/workorder/variance_repository.go
+package workorder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"oilgas-backend/internal/shared/database"
+)
+
+type VarianceRepository interface {
+	// GetVarianceRecords loads work orders completed in the filter's range,
+	// including those since invoiced or paid
+	GetVarianceRecords(ctx context.Context, tenantID string, filters VarianceFilters) ([]VarianceRecord, error)
+}
+
+type varianceRepository struct {
+	dbManager *database.DatabaseManager
+}
+
+func NewVarianceRepository(dbManager *database.DatabaseManager) VarianceRepository {
+	return &varianceRepository{dbManager: dbManager}
+}
+
+func (r *varianceRepository) GetVarianceRecords(ctx context.Context, tenantID string, filters VarianceFilters) ([]VarianceRecord, error) {
+	db, err := r.dbManager.GetTenantDB(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	conditions := []string{
+		"w.tenant_id = $1",
+		"w.is_active = true",
+		"w.status IN ('COMPLETED', 'INVOICED', 'PAID')",
+		"w.completed_at >= $2",
+		"w.completed_at < $3",
+	}
+	args := []interface{}{tenantID, filters.From, filters.To}
+
+	if filters.ServiceType != nil {
+		args = append(args, *filters.ServiceType)
+		conditions = append(conditions, fmt.Sprintf("w.service_type = $%d", len(args)))
+	}
+	if filters.CustomerID != nil {
+		args = append(args, *filters.CustomerID)
+		conditions = append(conditions, fmt.Sprintf("w.customer_id = $%d", len(args)))
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT w.id, w.work_order_number, w.customer_id, COALESCE(c.name, ''), w.service_type,
+		       w.assigned_to_user_id, w.estimated_hours, w.actual_hours, w.hourly_rate,
+		       w.materials_cost, w.completed_at
+		FROM store.workorders w
+		LEFT JOIN store.customers c ON c.id = w.customer_id
+		WHERE `+strings.Join(conditions, " AND ")+`
+		ORDER BY w.completed_at, w.id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work orders: %w", err)
+	}
+	defer rows.Close()
+
+	var records []VarianceRecord
+	index := map[int]int{}
+	for rows.Next() {
+		var record VarianceRecord
+		wo := &record.WorkOrder
+		err := rows.Scan(
+			&wo.ID, &wo.WorkOrderNumber, &wo.CustomerID, &record.CustomerName, &wo.ServiceType,
+			&wo.AssignedToUserID, &wo.EstimatedHours, &wo.ActualHours, &wo.HourlyRate,
+			&wo.MaterialsCost, &wo.CompletedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan work order: %w", err)
+		}
+		wo.TenantID = tenantID
+		record.TechnicianHours = map[int]float64{}
+		index[wo.ID] = len(records)
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return records, nil
+	}
+
+	ids := make([]int64, 0, len(records))
+	for _, record := range records {
+		ids = append(ids, int64(record.WorkOrder.ID))
+	}
+
+	// Same net-hours rule as recomputeActualHoursTx, split by technician
+	hoursRows, err := db.QueryContext(ctx, `
+		SELECT workorder_id, user_id,
+		       ROUND(SUM(GREATEST(
+		           EXTRACT(EPOCH FROM clock_out - clock_in) / 3600.0 - break_minutes / 60.0, 0
+		       ))::NUMERIC, 2)
+		FROM store.workorder_time_entries
+		WHERE tenant_id = $1 AND workorder_id = ANY($2) AND clock_out IS NOT NULL
+		GROUP BY workorder_id, user_id`,
+		tenantID, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get technician hours: %w", err)
+	}
+	defer hoursRows.Close()
+
+	for hoursRows.Next() {
+		var workOrderID, userID int
+		var hours float64
+		if err := hoursRows.Scan(&workOrderID, &userID, &hours); err != nil {
+			return nil, fmt.Errorf("failed to scan technician hours: %w", err)
+		}
+		records[index[workOrderID]].TechnicianHours[userID] = hours
+	}
+
+	return records, hoursRows.Err()
+}
@@ -0,0 +1,179 @@
+// backend/internal/workorder/variance_test.go
+package workorder
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type mockVarianceRepository struct {
+	mock.Mock
+}
+
+func (m *mockVarianceRepository) GetVarianceRecords(ctx context.Context, tenantID string, filters VarianceFilters) ([]VarianceRecord, error) {
+	args := m.Called(ctx, tenantID, filters)
+	return args.Get(0).([]VarianceRecord), args.Error(1)
+}
+
+func varianceRecord(id, customerID int, serviceType ServiceType, estimated, actual float64, technicians map[int]float64) VarianceRecord {
+	completed := time.Date(2026, time.March, 10, 15, 0, 0, 0, time.UTC)
+	return VarianceRecord{
+		WorkOrder: WorkOrder{
+			ID:              id,
+			WorkOrderNumber: "LON-" + formatInt(&id),
+			CustomerID:      customerID,
+			ServiceType:     serviceType,
+			Status:          StatusCompleted,
+			EstimatedHours:  &estimated,
+			ActualHours:     &actual,
+			HourlyRate:      floatPtr(100),
+			MaterialsCost:   floatPtr(200),
+			CompletedAt:     &completed,
+		},
+		CustomerName:    "Customer " + formatInt(&customerID),
+		TechnicianHours: technicians,
+	}
+}
+
+type VarianceServiceTestSuite struct {
+	suite.Suite
+	service  VarianceService
+	variance *mockVarianceRepository
+	ctx      context.Context
+	tenantID string
+	filters  VarianceFilters
+}
+
+func (suite *VarianceServiceTestSuite) SetupTest() {
+	suite.variance = &mockVarianceRepository{}
+	suite.service = NewVarianceService(suite.variance)
+	suite.ctx = context.Background()
+	suite.tenantID = "longbeach"
+	suite.filters = VarianceFilters{
+		From: time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestVarianceServiceSuite(t *testing.T) {
+	suite.Run(t, new(VarianceServiceTestSuite))
+}
+
+func (suite *VarianceServiceTestSuite) TestGetVarianceReport_PerWorkOrderAndGroups() {
+	expected := suite.filters
+	expected.ThresholdPct = DefaultVarianceThresholdPct
+
+	suite.variance.On("GetVarianceRecords", suite.ctx, suite.tenantID, expected).Return([]VarianceRecord{
+		varianceRecord(1, 3, ServiceInspection, 10, 15, map[int]float64{7: 10, 8: 5}),
+		varianceRecord(2, 3, ServiceInspection, 10, 11, map[int]float64{7: 11}),
+		varianceRecord(3, 4, ServiceRepair, 8, 6, nil),
+		{WorkOrder: WorkOrder{ID: 4, CustomerID: 4, ServiceType: ServiceRepair}},
+	}, nil)
+
+	report, err := suite.service.GetVarianceReport(suite.ctx, suite.tenantID, suite.filters)
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, report.Unestimated)
+	assert.Equal(suite.T(), float64(DefaultVarianceThresholdPct), report.ThresholdPct)
+	require.Len(suite.T(), report.WorkOrders, 3)
+
+	first := report.WorkOrders[0]
+	assert.Equal(suite.T(), 5.0, first.HoursVariance)
+	assert.Equal(suite.T(), 50.0, first.HoursVariancePct)
+	assert.Equal(suite.T(), 1200.0, first.EstimatedCost)
+	assert.Equal(suite.T(), 1700.0, first.ActualCost)
+	assert.Equal(suite.T(), 41.67, *first.CostVariancePct)
+	assert.True(suite.T(), first.Flagged)
+
+	assert.False(suite.T(), report.WorkOrders[1].Flagged) // 10% over
+	assert.True(suite.T(), report.WorkOrders[2].Flagged)  // 25% under
+
+	require.Len(suite.T(), report.ByServiceType, 2)
+	inspection := report.ByServiceType[0]
+	assert.Equal(suite.T(), "INSPECTION", inspection.Key)
+	assert.Equal(suite.T(), 2, inspection.WorkOrders)
+	assert.Equal(suite.T(), 1, inspection.Flagged)
+	assert.Equal(suite.T(), 6.0, inspection.HoursVariance)
+	assert.Equal(suite.T(), 30.0, *inspection.HoursVariancePct)
+
+	require.Len(suite.T(), report.ByCustomer, 2)
+	assert.Equal(suite.T(), "Customer 3", report.ByCustomer[0].Label)
+
+	// Technician 7 logged 2/3 of job 1 and all of job 2; technician 8 the rest
+	// of job 1; job 3 had no time entries and no assignee
+	require.Len(suite.T(), report.ByTechnician, 2)
+	byKey := map[string]VarianceTotals{}
+	for _, totals := range report.ByTechnician {
+		byKey[totals.Key] = totals
+	}
+	assert.Equal(suite.T(), 16.67, byKey["7"].EstimatedHours)
+	assert.Equal(suite.T(), 21.0, byKey["7"].ActualHours)
+	assert.Equal(suite.T(), 3.33, byKey["8"].EstimatedHours)
+	assert.Equal(suite.T(), 5.0, byKey["8"].ActualHours)
+}
+
+func (suite *VarianceServiceTestSuite) TestGetVarianceReport_ThresholdIsConfigurable() {
+	filters := suite.filters
+	filters.ThresholdPct = 5
+	suite.variance.On("GetVarianceRecords", suite.ctx, suite.tenantID, filters).Return([]VarianceRecord{
+		varianceRecord(2, 3, ServiceInspection, 10, 11, nil),
+	}, nil)
+
+	report, err := suite.service.GetVarianceReport(suite.ctx, suite.tenantID, filters)
+
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), report.WorkOrders[0].Flagged)
+}
+
+func (suite *VarianceServiceTestSuite) TestGetVarianceReport_InvalidFilters() {
+	testCases := []struct {
+		name   string
+		mutate func(f *VarianceFilters)
+	}{
+		{"missing range", func(f *VarianceFilters) { f.From = time.Time{} }},
+		{"reversed range", func(f *VarianceFilters) { f.From, f.To = f.To, f.From }},
+		{"range too large", func(f *VarianceFilters) { f.From = f.To.AddDate(-3, 0, 0) }},
+		{"bad service type", func(f *VarianceFilters) { st := ServiceType("PAINTING"); f.ServiceType = &st }},
+		{"negative threshold", func(f *VarianceFilters) { f.ThresholdPct = -1 }},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			filters := suite.filters
+			tc.mutate(&filters)
+
+			_, err := suite.service.GetVarianceReport(suite.ctx, suite.tenantID, filters)
+
+			assert.Error(suite.T(), err)
+			assert.Contains(suite.T(), err.Error(), "validation failed")
+		})
+	}
+	suite.variance.AssertNotCalled(suite.T(), "GetVarianceRecords")
+}
+
+func TestWriteVarianceCSV(t *testing.T) {
+	filters := VarianceFilters{ThresholdPct: DefaultVarianceThresholdPct}
+	report := buildVarianceReport([]VarianceRecord{
+		varianceRecord(1, 3, ServiceInspection, 10, 15, nil),
+	}, filters)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteVarianceCSV(&buf, report))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "work_order_number", rows[0][0])
+	assert.Equal(t, []string{
+		"LON-1", "3", "Customer 3", "INSPECTION", "", "2026-03-10T15:00:00Z",
+		"10", "15", "5", "50", "1200", "1700", "500", "41.67", "true",
+	}, rows[1])
+}
@@ -0,0 +1,2 @@
+-- 003_add_tenant_settings.down.sql
+ALTER TABLE tenants DROP COLUMN IF EXISTS settings;
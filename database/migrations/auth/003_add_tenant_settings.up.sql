@@ -0,0 +1,4 @@
+-- 003_add_tenant_settings.up.sql
+-- Per-tenant configuration, e.g. document number formats:
+--   {"document_numbering": {"work_order": "LB-{YYYY}-{seq:05}"}}
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS settings JSONB NOT NULL DEFAULT '{}';
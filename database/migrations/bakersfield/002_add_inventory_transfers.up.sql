@@ -0,0 +1,222 @@
+-- 002_add_inventory_transfers.up.sql
+-- Inter-yard transfers for Bakersfield: the tables Long Beach gained in its
+-- 009, 017, 021 and 022 migrations, folded into one. Transfers write the
+-- same rows in both yards, so these match Long Beach's definitions. The
+-- inventory table and audit trail are only created if the yard lacks them.
+CREATE SCHEMA IF NOT EXISTS audit;
+
+CREATE TABLE IF NOT EXISTS store.inventory (
+    id SERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL DEFAULT 'bakersfield',
+    work_order VARCHAR(100),
+    r_number VARCHAR(50),
+    customer_id INTEGER REFERENCES store.customers(id),
+    customer VARCHAR(255),
+    joints INTEGER,
+    size VARCHAR(50),
+    weight DECIMAL(10,2),
+    grade VARCHAR(10),
+    connection VARCHAR(100),
+    rack VARCHAR(50),
+    location VARCHAR(100),
+    date_in DATE,
+    date_out DATE,
+    well_in VARCHAR(255),
+    lease_in VARCHAR(255),
+    well_out VARCHAR(255),
+    lease_out VARCHAR(255),
+    notes TEXT,
+    deleted BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+ALTER TABLE store.inventory
+    ADD COLUMN IF NOT EXISTS rack VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS r_number VARCHAR(50);
+
+CREATE INDEX IF NOT EXISTS idx_inventory_customer_stock ON store.inventory(tenant_id, customer_id)
+    WHERE deleted = false AND date_out IS NULL;
+
+CREATE TABLE IF NOT EXISTS audit.events (
+    id VARCHAR(255) PRIMARY KEY,
+    event_type VARCHAR(100) NOT NULL,
+    tenant_id VARCHAR(100) NOT NULL,
+    entity_type VARCHAR(100),
+    entity_id VARCHAR(100),
+    user_id INTEGER,                       -- Central auth user; not a foreign key across databases
+    event_data JSONB DEFAULT '{}',
+    old_values JSONB,
+    new_values JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_entity ON audit.events(entity_type, entity_id, created_at);
+
+CREATE OR REPLACE FUNCTION audit.log_event(
+    p_event_type VARCHAR(100),
+    p_tenant_id VARCHAR(100),
+    p_entity_type VARCHAR(100) DEFAULT NULL,
+    p_entity_id VARCHAR(100) DEFAULT NULL,
+    p_user_id INTEGER DEFAULT NULL,
+    p_event_data JSONB DEFAULT '{}',
+    p_old_values JSONB DEFAULT NULL,
+    p_new_values JSONB DEFAULT NULL
+) RETURNS VARCHAR(255) AS $$
+DECLARE
+    event_id VARCHAR(255);
+BEGIN
+    event_id := gen_random_uuid()::text;
+
+    INSERT INTO audit.events (
+        id, event_type, tenant_id, entity_type, entity_id,
+        user_id, event_data, old_values, new_values, created_at
+    ) VALUES (
+        event_id, p_event_type, p_tenant_id, p_entity_type, p_entity_id,
+        p_user_id, p_event_data, p_old_values, p_new_values, NOW()
+    );
+
+    RETURN event_id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Transfer numbers are allocated by the sending yard
+CREATE TABLE store.document_sequences (
+    tenant_id VARCHAR(100) NOT NULL,
+    document_type VARCHAR(30) NOT NULL,
+    period VARCHAR(7) NOT NULL DEFAULT '',
+    last_value BIGINT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    PRIMARY KEY (tenant_id, document_type, period),
+    CONSTRAINT chk_document_type CHECK (document_type IN ('WORK_ORDER', 'INVOICE', 'BILL_OF_LADING', 'TRANSFER')),
+    CONSTRAINT chk_last_value CHECK (last_value > 0)
+);
+
+CREATE TABLE store.inventory_movements (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    movement_type VARCHAR(20) NOT NULL DEFAULT 'MOVE',
+
+    inventory_item_id INTEGER NOT NULL,
+    to_inventory_item_id INTEGER,
+    r_number VARCHAR(50),
+    customer_id INTEGER,
+    joints INTEGER NOT NULL,
+
+    from_rack VARCHAR(50),
+    from_location VARCHAR(100),
+    to_rack VARCHAR(50),
+    to_location VARCHAR(100),
+    notes TEXT,
+    reason_code VARCHAR(30),
+
+    moved_by_user_id INTEGER NOT NULL,     -- Central auth user; not a foreign key across databases
+    moved_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT chk_inventory_movement_type CHECK (movement_type IN ('MOVE', 'SHIP', 'RECEIVE', 'TRANSFER_OUT', 'TRANSFER_IN', 'ADJUST')),
+    CONSTRAINT chk_inventory_movement_reason CHECK (movement_type <> 'ADJUST' OR reason_code IS NOT NULL),
+    CONSTRAINT chk_inventory_movement_joints CHECK (joints > 0)
+);
+
+CREATE INDEX idx_inventory_movements_item ON store.inventory_movements(inventory_item_id, moved_at);
+CREATE INDEX idx_inventory_movements_to_item ON store.inventory_movements(to_inventory_item_id) WHERE to_inventory_item_id IS NOT NULL;
+CREATE INDEX idx_inventory_movements_r_number ON store.inventory_movements(tenant_id, r_number, moved_at);
+CREATE INDEX idx_inventory_movements_moved_at ON store.inventory_movements(tenant_id, moved_at);
+
+CREATE OR REPLACE FUNCTION store.reject_inventory_movement_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'inventory movements are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_inventory_movements_immutable
+    BEFORE UPDATE OR DELETE ON store.inventory_movements
+    FOR EACH ROW EXECUTE FUNCTION store.reject_inventory_movement_change();
+
+CREATE TABLE store.transfer_orders (
+    id SERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    transfer_uid UUID NOT NULL,            -- Shared by both yards' copies
+    transfer_number VARCHAR(50) NOT NULL,  -- Allocated by the source yard
+    direction VARCHAR(10) NOT NULL,
+    source_tenant_id VARCHAR(100) NOT NULL,
+    destination_tenant_id VARCHAR(100) NOT NULL,
+
+    customer_id INTEGER NOT NULL,          -- In this yard's customer ids
+    customer VARCHAR(255),
+    remote_customer_id INTEGER NOT NULL,   -- The same customer in the other yard
+
+    carrier VARCHAR(255),
+    truck_number VARCHAR(50),
+    notes TEXT,
+    total_joints INTEGER NOT NULL DEFAULT 0,
+
+    status VARCHAR(20) NOT NULL,
+    created_by_user_id INTEGER,            -- Not a foreign key: inbound copies are written by the relay
+    dispatched_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    delivered_at TIMESTAMP WITH TIME ZONE, -- When the destination yard took the inbound copy
+    received_by_user_id INTEGER,
+    received_at TIMESTAMP WITH TIME ZONE,
+    cancelled_by_user_id INTEGER,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    cancel_reason TEXT,
+
+    CONSTRAINT uq_transfer_orders_uid UNIQUE (tenant_id, transfer_uid),
+    CONSTRAINT uq_transfer_orders_number UNIQUE (tenant_id, source_tenant_id, transfer_number),
+    CONSTRAINT chk_transfer_direction CHECK (direction IN ('OUTBOUND', 'INBOUND')),
+    CONSTRAINT chk_transfer_status CHECK (status IN ('PENDING', 'IN_TRANSIT', 'RECEIVED', 'CANCELLED')),
+    CONSTRAINT chk_transfer_yards CHECK (source_tenant_id <> destination_tenant_id)
+);
+
+CREATE INDEX idx_transfer_orders_open ON store.transfer_orders(tenant_id, direction)
+    WHERE status IN ('PENDING', 'IN_TRANSIT');
+CREATE INDEX idx_transfer_orders_dispatched ON store.transfer_orders(tenant_id, dispatched_at);
+
+CREATE TABLE store.transfer_lines (
+    id SERIAL PRIMARY KEY,
+    transfer_order_id INTEGER NOT NULL REFERENCES store.transfer_orders(id) ON DELETE CASCADE,
+    line_number INTEGER NOT NULL,
+    source_item_id INTEGER NOT NULL,       -- Inventory row in the source yard
+    in_transit_item_id INTEGER,            -- Source yard row dated out while in transit; OUTBOUND only
+    received_item_id INTEGER,              -- Destination yard row once racked; INBOUND only
+
+    work_order VARCHAR(100),
+    r_number VARCHAR(50),
+    joints INTEGER NOT NULL,
+    size VARCHAR(50),
+    weight DECIMAL(10,2),
+    grade VARCHAR(10),
+    connection VARCHAR(100),
+    from_rack VARCHAR(50),
+    from_location VARCHAR(100),
+    received_rack VARCHAR(50),
+    received_location VARCHAR(100),
+
+    CONSTRAINT uq_transfer_lines_number UNIQUE (transfer_order_id, line_number),
+    CONSTRAINT chk_transfer_line_joints CHECK (joints > 0)
+);
+
+CREATE INDEX idx_transfer_lines_order ON store.transfer_lines(transfer_order_id);
+
+CREATE TABLE store.transfer_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    transfer_order_id INTEGER NOT NULL REFERENCES store.transfer_orders(id) ON DELETE CASCADE,
+    message_type VARCHAR(20) NOT NULL,
+    target_tenant_id VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    last_attempt_at TIMESTAMP WITH TIME ZONE,
+    next_attempt_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    discarded_at TIMESTAMP WITH TIME ZONE, -- Withdrawn before delivery, e.g. a cancelled dispatch
+
+    CONSTRAINT chk_transfer_outbox_type CHECK (message_type IN ('DISPATCHED', 'RECEIVED', 'CANCELLED'))
+);
+
+CREATE INDEX idx_transfer_outbox_pending ON store.transfer_outbox(tenant_id, next_attempt_at)
+    WHERE delivered_at IS NULL AND discarded_at IS NULL;
+CREATE INDEX idx_transfer_outbox_order ON store.transfer_outbox(transfer_order_id);
@@ -0,0 +1,10 @@
+-- 002_add_inventory_transfers.down.sql
+-- store.inventory and the audit trail stay: they may hold the yard's own rows
+DROP TABLE IF EXISTS store.transfer_outbox CASCADE;
+DROP TABLE IF EXISTS store.transfer_lines CASCADE;
+DROP TABLE IF EXISTS store.transfer_orders CASCADE;
+
+DROP TABLE IF EXISTS store.inventory_movements CASCADE;
+DROP FUNCTION IF EXISTS store.reject_inventory_movement_change();
+
+DROP TABLE IF EXISTS store.document_sequences CASCADE;
@@ -0,0 +1,26 @@
+-- 006_move_workorder_history_to_service.down.sql
+-- Restore trigger-based status history
+CREATE OR REPLACE FUNCTION log_workorder_changes() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        -- Log status changes
+        IF OLD.status != NEW.status THEN
+            INSERT INTO store.workorder_history (
+                workorder_id, changed_by_user_id, action, old_value, new_value
+            ) VALUES (
+                NEW.id,
+                COALESCE(NEW.assigned_to_user_id, NEW.created_by_user_id),
+                'status_changed',
+                OLD.status,
+                NEW.status
+            );
+        END IF;
+        RETURN NEW;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_log_workorder_changes
+    AFTER UPDATE ON store.workorders
+    FOR EACH ROW EXECUTE FUNCTION log_workorder_changes();
@@ -0,0 +1,7 @@
+-- 006_move_workorder_history_to_service.up.sql
+-- Work order history is now written by the workorder service in the same
+-- transaction as each change, attributed to the acting user. The trigger
+-- below attributed status changes to the assignee/creator and would
+-- duplicate every status_changed row.
+DROP TRIGGER IF EXISTS trigger_log_workorder_changes ON store.workorders;
+DROP FUNCTION IF EXISTS log_workorder_changes();
@@ -0,0 +1,14 @@
+-- 007_add_approval_chains.down.sql
+DROP INDEX IF EXISTS store.idx_workorder_approvals_open;
+DROP INDEX IF EXISTS store.idx_workorder_approvals_workorder;
+
+DELETE FROM store.workorder_approvals WHERE approver_user_id IS NULL;
+
+ALTER TABLE store.workorder_approvals
+    DROP CONSTRAINT IF EXISTS chk_approval_approver_type,
+    DROP COLUMN IF EXISTS approver_type,
+    DROP COLUMN IF EXISTS chain_id,
+    ALTER COLUMN approver_user_id SET NOT NULL;
+
+DROP TABLE IF EXISTS store.approval_chain_levels CASCADE;
+DROP TABLE IF EXISTS store.approval_chains CASCADE;
@@ -0,0 +1,44 @@
+-- 007_add_approval_chains.up.sql
+-- Configurable multi-level approval chains for work orders
+CREATE TABLE store.approval_chains (
+    id SERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    
+    -- Matching criteria (NULL matches any work order)
+    service_type VARCHAR(50),
+    priority VARCHAR(20),
+    min_amount DECIMAL(12,2),
+    
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    
+    CONSTRAINT fk_approval_chains_tenant FOREIGN KEY (tenant_id) REFERENCES store.tenants(tenant_id),
+    CONSTRAINT chk_chain_priority CHECK (priority IS NULL OR priority IN ('LOW', 'MEDIUM', 'HIGH', 'URGENT')),
+    CONSTRAINT chk_chain_min_amount CHECK (min_amount IS NULL OR min_amount >= 0)
+);
+
+CREATE TABLE store.approval_chain_levels (
+    id SERIAL PRIMARY KEY,
+    chain_id INTEGER NOT NULL REFERENCES store.approval_chains(id) ON DELETE CASCADE,
+    level INTEGER NOT NULL,
+    approver_type VARCHAR(30) NOT NULL,
+    approver_user_id INTEGER REFERENCES auth.users(id),
+    
+    CONSTRAINT uq_chain_level UNIQUE(chain_id, level),
+    CONSTRAINT chk_level_positive CHECK (level > 0),
+    CONSTRAINT chk_level_approver_type CHECK (approver_type IN ('MANAGER', 'CUSTOMER_APPROVER'))
+);
+
+-- Approval rows are created for every level when a work order is submitted.
+-- Role-based levels have no approver until someone responds.
+ALTER TABLE store.workorder_approvals
+    ALTER COLUMN approver_user_id DROP NOT NULL,
+    ADD COLUMN chain_id INTEGER REFERENCES store.approval_chains(id),
+    ADD COLUMN approver_type VARCHAR(30) NOT NULL DEFAULT 'MANAGER',
+    ADD CONSTRAINT chk_approval_approver_type CHECK (approver_type IN ('MANAGER', 'CUSTOMER_APPROVER'));
+
+CREATE INDEX idx_approval_chains_tenant ON store.approval_chains(tenant_id) WHERE is_active = true;
+CREATE INDEX idx_workorder_approvals_workorder ON store.workorder_approvals(workorder_id, approval_level);
+CREATE INDEX idx_workorder_approvals_open ON store.workorder_approvals(approver_type, approval_level) WHERE status = 'PENDING';
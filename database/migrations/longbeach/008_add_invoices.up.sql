@@ -0,0 +1,106 @@
+-- 008_add_invoices.up.sql
+-- Invoices, credit memos and payments generated from completed work orders
+CREATE SEQUENCE store.invoice_number_seq;
+
+CREATE TABLE store.invoices (
+    id SERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    customer_id INTEGER NOT NULL REFERENCES store.customers(id),
+    workorder_id INTEGER REFERENCES store.workorders(id),
+    original_invoice_id INTEGER REFERENCES store.invoices(id),
+    
+    invoice_number VARCHAR(100) NOT NULL DEFAULT 'INV-' || LPAD(nextval('store.invoice_number_seq')::TEXT, 6, '0'),
+    invoice_type VARCHAR(20) NOT NULL DEFAULT 'INVOICE',
+    status VARCHAR(20) NOT NULL DEFAULT 'OPEN',
+    
+    payment_terms VARCHAR(50) NOT NULL,
+    invoice_date DATE NOT NULL,
+    due_date DATE NOT NULL,
+    
+    total DECIMAL(12,2) NOT NULL,
+    amount_paid DECIMAL(12,2) NOT NULL DEFAULT 0,
+    balance_due DECIMAL(12,2) GENERATED ALWAYS AS (total - amount_paid) STORED,
+    
+    notes TEXT,
+    void_reason TEXT,
+    voided_at TIMESTAMP WITH TIME ZONE,
+    
+    created_by_user_id INTEGER NOT NULL REFERENCES auth.users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    
+    CONSTRAINT fk_invoices_tenant FOREIGN KEY (tenant_id) REFERENCES store.tenants(tenant_id),
+    CONSTRAINT uq_invoice_number UNIQUE(tenant_id, invoice_number),
+    CONSTRAINT chk_invoice_type CHECK (invoice_type IN ('INVOICE', 'CREDIT_MEMO')),
+    CONSTRAINT chk_invoice_status CHECK (status IN ('OPEN', 'PARTIALLY_PAID', 'PAID', 'VOID', 'APPLIED')),
+    CONSTRAINT chk_credit_memo_original CHECK (invoice_type = 'INVOICE' OR original_invoice_id IS NOT NULL),
+    CONSTRAINT chk_invoice_due_date CHECK (due_date >= invoice_date)
+);
+
+CREATE TABLE store.invoice_lines (
+    id SERIAL PRIMARY KEY,
+    invoice_id INTEGER NOT NULL REFERENCES store.invoices(id) ON DELETE CASCADE,
+    line_type VARCHAR(20) NOT NULL,
+    workorder_item_id INTEGER REFERENCES store.workorder_items(id),
+    description TEXT NOT NULL,
+    quantity DECIMAL(10,2) NOT NULL DEFAULT 1,
+    unit_price DECIMAL(12,2) NOT NULL,
+    amount DECIMAL(12,2) NOT NULL,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    
+    CONSTRAINT chk_line_type CHECK (line_type IN ('ITEM', 'LABOR', 'MATERIALS', 'CREDIT'))
+);
+
+CREATE TABLE store.invoice_payments (
+    id SERIAL PRIMARY KEY,
+    invoice_id INTEGER NOT NULL REFERENCES store.invoices(id),
+    amount DECIMAL(12,2) NOT NULL,
+    payment_date DATE NOT NULL,
+    method VARCHAR(20) NOT NULL,
+    reference VARCHAR(100),
+    notes TEXT,
+    recorded_by_user_id INTEGER NOT NULL REFERENCES auth.users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    
+    CONSTRAINT chk_payment_amount_positive CHECK (amount > 0),
+    CONSTRAINT chk_payment_method CHECK (method IN ('CHECK', 'ACH', 'WIRE', 'CARD', 'CASH', 'CREDIT_MEMO'))
+);
+
+-- One live invoice per work order
+CREATE UNIQUE INDEX uq_invoices_live_workorder ON store.invoices(workorder_id)
+WHERE invoice_type = 'INVOICE' AND status <> 'VOID';
+
+CREATE INDEX idx_invoices_customer_status ON store.invoices(customer_id, status);
+CREATE INDEX idx_invoices_tenant_date ON store.invoices(tenant_id, invoice_date DESC);
+CREATE INDEX idx_invoices_open_due ON store.invoices(due_date) WHERE status IN ('OPEN', 'PARTIALLY_PAID');
+CREATE INDEX idx_invoice_lines_invoice ON store.invoice_lines(invoice_id, sort_order);
+CREATE INDEX idx_invoice_payments_invoice ON store.invoice_payments(invoice_id);
+
+-- Allow invoice events in the audit trail
+ALTER TABLE audit.events DROP CONSTRAINT IF EXISTS chk_event_type;
+ALTER TABLE audit.events 
+ADD CONSTRAINT chk_event_type CHECK (event_type IN (
+    -- User events
+    'user.login', 'user.logout', 'user.created', 'user.password_changed',
+    'user.deactivated', 'user.permission_granted',
+    
+    -- Customer events  
+    'customer.created', 'customer.updated', 'customer.contact_added',
+    'customer.contact_removed', 'customer.contact_permissions_updated',
+    
+    -- Work order events (for invoice audit trail)
+    'workorder.created', 'workorder.status_changed', 'workorder.assigned',
+    'workorder.started', 'workorder.completed', 'workorder.cancelled',
+    'workorder.item_added', 'workorder.item_completed', 'workorder.item_removed',
+    'workorder.approved', 'workorder.rejected', 'workorder.invoice_generated',
+    
+    -- Invoice events
+    'invoice.generated', 'invoice.payment_recorded', 'invoice.voided',
+    
+    -- Inventory events (for tracking where items go)
+    'inventory.received', 'inventory.moved', 'inventory.inspected',
+    'inventory.assigned_to_workorder', 'inventory.returned_from_workorder',
+    
+    -- System events
+    'system.migration_completed', 'system.backup_created'
+));
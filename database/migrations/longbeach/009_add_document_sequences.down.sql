@@ -0,0 +1,47 @@
+-- 009_add_document_sequences.down.sql
+CREATE SEQUENCE store.invoice_number_seq;
+SELECT setval('store.invoice_number_seq', COALESCE((
+    SELECT MAX(last_value) FROM store.document_sequences
+    WHERE document_type = 'INVOICE' AND period = ''
+), 1));
+ALTER TABLE store.invoices ALTER COLUMN invoice_number
+    SET DEFAULT 'INV-' || LPAD(nextval('store.invoice_number_seq')::TEXT, 6, '0');
+
+CREATE OR REPLACE FUNCTION generate_work_order_number(p_tenant_id VARCHAR(100)) RETURNS VARCHAR(100) AS $$
+DECLARE
+    next_number INTEGER;
+    tenant_prefix VARCHAR(10);
+BEGIN
+    -- Get tenant prefix (first 3 chars of tenant_id, uppercase)
+    tenant_prefix := UPPER(LEFT(p_tenant_id, 3));
+    
+    -- Get next number for this tenant
+    SELECT COALESCE(MAX(
+        CASE 
+            WHEN work_order_number ~ ('^' || tenant_prefix || '-[0-9]+$')
+            THEN CAST(SUBSTRING(work_order_number FROM LENGTH(tenant_prefix) + 2) AS INTEGER)
+            ELSE 0
+        END
+    ), 0) + 1
+    INTO next_number
+    FROM store.workorders
+    WHERE tenant_id = p_tenant_id;
+    
+    RETURN tenant_prefix || '-' || LPAD(next_number::TEXT, 6, '0');
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION set_work_order_number() RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.work_order_number IS NULL OR NEW.work_order_number = '' THEN
+        NEW.work_order_number := generate_work_order_number(NEW.tenant_id);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_set_work_order_number
+    BEFORE INSERT ON store.workorders
+    FOR EACH ROW EXECUTE FUNCTION set_work_order_number();
+
+DROP TABLE IF EXISTS store.document_sequences;
@@ -0,0 +1,36 @@
+-- 009_add_document_sequences.up.sql
+-- Per-tenant document numbering backed by a locked counter table. Numbers are
+-- allocated by the application inside the creating transaction, replacing the
+-- MAX()+1 work order trigger and the invoice number sequence default.
+CREATE TABLE store.document_sequences (
+    tenant_id VARCHAR(100) NOT NULL,
+    document_type VARCHAR(30) NOT NULL,
+    period VARCHAR(7) NOT NULL DEFAULT '',
+    last_value BIGINT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    
+    PRIMARY KEY (tenant_id, document_type, period),
+    CONSTRAINT chk_document_type CHECK (document_type IN ('WORK_ORDER', 'INVOICE', 'BILL_OF_LADING')),
+    CONSTRAINT chk_last_value CHECK (last_value > 0)
+);
+
+-- Continue existing series under the default formats ({TENANT:3}-{seq:06}
+-- and INV-{seq:06}), which use the undated '' period
+INSERT INTO store.document_sequences (tenant_id, document_type, period, last_value)
+SELECT tenant_id, 'WORK_ORDER', '', MAX(CAST(SUBSTRING(work_order_number FROM LENGTH(LEFT(tenant_id, 3)) + 2) AS BIGINT))
+FROM store.workorders
+WHERE work_order_number ~ ('^' || UPPER(LEFT(tenant_id, 3)) || '-[0-9]+$')
+GROUP BY tenant_id;
+
+INSERT INTO store.document_sequences (tenant_id, document_type, period, last_value)
+SELECT tenant_id, 'INVOICE', '', MAX(CAST(SUBSTRING(invoice_number FROM 5) AS BIGINT))
+FROM store.invoices
+WHERE invoice_number ~ '^INV-[0-9]+$'
+GROUP BY tenant_id;
+
+DROP TRIGGER IF EXISTS trigger_set_work_order_number ON store.workorders;
+DROP FUNCTION IF EXISTS set_work_order_number();
+DROP FUNCTION IF EXISTS generate_work_order_number(VARCHAR);
+
+ALTER TABLE store.invoices ALTER COLUMN invoice_number DROP DEFAULT;
+DROP SEQUENCE IF EXISTS store.invoice_number_seq;
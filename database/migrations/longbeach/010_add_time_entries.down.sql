@@ -0,0 +1,2 @@
+-- 010_add_time_entries.down.sql
+DROP TABLE IF EXISTS store.workorder_time_entries CASCADE;
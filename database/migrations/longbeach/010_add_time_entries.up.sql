@@ -0,0 +1,30 @@
+-- 010_add_time_entries.up.sql
+-- Technician clock in/out against work orders; actual_hours is derived from these
+CREATE TABLE store.workorder_time_entries (
+    id SERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    workorder_id INTEGER NOT NULL REFERENCES store.workorders(id) ON DELETE CASCADE,
+    workorder_item_id INTEGER REFERENCES store.workorder_items(id) ON DELETE SET NULL,
+    user_id INTEGER NOT NULL REFERENCES auth.users(id),
+    
+    clock_in TIMESTAMP WITH TIME ZONE NOT NULL,
+    clock_out TIMESTAMP WITH TIME ZONE,
+    break_minutes INTEGER NOT NULL DEFAULT 0,
+    break_started_at TIMESTAMP WITH TIME ZONE,
+    notes TEXT,
+    
+    edited_by_user_id INTEGER REFERENCES auth.users(id),
+    edited_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    
+    CONSTRAINT chk_time_entry_order CHECK (clock_out IS NULL OR clock_out > clock_in),
+    CONSTRAINT chk_break_minutes CHECK (break_minutes >= 0),
+    CONSTRAINT chk_break_open_entry CHECK (break_started_at IS NULL OR clock_out IS NULL)
+);
+
+-- A technician can only be clocked in to one work order at a time
+CREATE UNIQUE INDEX uq_time_entries_open ON store.workorder_time_entries(tenant_id, user_id)
+    WHERE clock_out IS NULL;
+CREATE INDEX idx_time_entries_workorder ON store.workorder_time_entries(workorder_id);
+CREATE INDEX idx_time_entries_user ON store.workorder_time_entries(user_id, clock_in DESC);
@@ -0,0 +1,15 @@
+-- 011_add_scheduling.down.sql
+DROP INDEX IF EXISTS store.idx_workorders_bay_schedule;
+DROP INDEX IF EXISTS store.idx_workorders_technician_schedule;
+DROP INDEX IF EXISTS store.idx_workorders_yard_schedule;
+
+ALTER TABLE store.workorders
+    DROP CONSTRAINT IF EXISTS chk_workorder_schedule,
+    DROP COLUMN IF EXISTS scheduled_end,
+    DROP COLUMN IF EXISTS service_bay_id,
+    DROP COLUMN IF EXISTS yard_location;
+
+DROP TABLE IF EXISTS store.service_bays CASCADE;
+DROP TABLE IF EXISTS store.yard_closures CASCADE;
+DROP TABLE IF EXISTS store.yard_hours CASCADE;
+DROP TABLE IF EXISTS store.yards CASCADE;
@@ -0,0 +1,64 @@
+-- 011_add_scheduling.up.sql
+-- Per-yard working calendars, service bays and booked time ranges on work orders
+CREATE TABLE store.yards (
+    tenant_id VARCHAR(100) NOT NULL,
+    yard_location VARCHAR(100) NOT NULL,
+    name VARCHAR(200) NOT NULL,
+    time_zone VARCHAR(64) NOT NULL DEFAULT 'America/Los_Angeles',
+    
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    
+    PRIMARY KEY (tenant_id, yard_location)
+);
+
+CREATE TABLE store.yard_hours (
+    tenant_id VARCHAR(100) NOT NULL,
+    yard_location VARCHAR(100) NOT NULL,
+    weekday SMALLINT NOT NULL,
+    opens_at TIME NOT NULL,
+    closes_at TIME NOT NULL,
+    
+    PRIMARY KEY (tenant_id, yard_location, weekday),
+    FOREIGN KEY (tenant_id, yard_location) REFERENCES store.yards(tenant_id, yard_location) ON DELETE CASCADE,
+    CONSTRAINT chk_yard_weekday CHECK (weekday BETWEEN 0 AND 6),
+    CONSTRAINT chk_yard_hours_order CHECK (closes_at > opens_at)
+);
+
+CREATE TABLE store.yard_closures (
+    tenant_id VARCHAR(100) NOT NULL,
+    yard_location VARCHAR(100) NOT NULL,
+    closed_on DATE NOT NULL,
+    reason VARCHAR(200),
+    
+    PRIMARY KEY (tenant_id, yard_location, closed_on),
+    FOREIGN KEY (tenant_id, yard_location) REFERENCES store.yards(tenant_id, yard_location) ON DELETE CASCADE
+);
+
+-- Bays are not tied to a configured yard so they can be set up before its hours
+CREATE TABLE store.service_bays (
+    id SERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    yard_location VARCHAR(100) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX uq_service_bays_name ON store.service_bays(tenant_id, yard_location, name)
+    WHERE is_active = true;
+
+ALTER TABLE store.workorders
+    ADD COLUMN yard_location VARCHAR(100),
+    ADD COLUMN service_bay_id INTEGER REFERENCES store.service_bays(id),
+    ADD COLUMN scheduled_end TIMESTAMP WITH TIME ZONE,
+    ADD CONSTRAINT chk_workorder_schedule CHECK (
+        scheduled_end IS NULL OR (scheduled_date IS NOT NULL AND scheduled_end > scheduled_date)
+    );
+
+CREATE INDEX idx_workorders_yard_schedule ON store.workorders(tenant_id, yard_location, scheduled_date)
+    WHERE scheduled_end IS NOT NULL;
+CREATE INDEX idx_workorders_technician_schedule ON store.workorders(tenant_id, assigned_to_user_id, scheduled_date)
+    WHERE scheduled_end IS NOT NULL;
+CREATE INDEX idx_workorders_bay_schedule ON store.workorders(service_bay_id, scheduled_date)
+    WHERE service_bay_id IS NOT NULL;
@@ -0,0 +1,22 @@
+-- 012_add_sla_rules.down.sql
+DELETE FROM audit.events WHERE event_type IN ('workorder.sla_at_risk', 'workorder.sla_breached');
+
+ALTER TABLE audit.events DROP CONSTRAINT IF EXISTS chk_event_type;
+ALTER TABLE audit.events 
+ADD CONSTRAINT chk_event_type CHECK (event_type IN (
+    'user.login', 'user.logout', 'user.created', 'user.password_changed',
+    'user.deactivated', 'user.permission_granted',
+    'customer.created', 'customer.updated', 'customer.contact_added',
+    'customer.contact_removed', 'customer.contact_permissions_updated',
+    'workorder.created', 'workorder.status_changed', 'workorder.assigned',
+    'workorder.started', 'workorder.completed', 'workorder.cancelled',
+    'workorder.item_added', 'workorder.item_completed', 'workorder.item_removed',
+    'workorder.approved', 'workorder.rejected', 'workorder.invoice_generated',
+    'invoice.generated', 'invoice.payment_recorded', 'invoice.voided',
+    'inventory.received', 'inventory.moved', 'inventory.inspected',
+    'inventory.assigned_to_workorder', 'inventory.returned_from_workorder',
+    'system.migration_completed', 'system.backup_created'
+));
+
+DROP TABLE IF EXISTS store.workorder_escalations CASCADE;
+DROP TABLE IF EXISTS store.sla_rules CASCADE;
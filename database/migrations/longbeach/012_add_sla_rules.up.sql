@@ -0,0 +1,80 @@
+-- 012_add_sla_rules.up.sql
+-- Per-tenant SLA targets and the escalations raised against them
+CREATE TABLE store.sla_rules (
+    id SERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    priority VARCHAR(20),
+    
+    from_status VARCHAR(20) NOT NULL,
+    target_status VARCHAR(20) NOT NULL,
+    target_hours DECIMAL(8,2),
+    warning_hours DECIMAL(8,2) NOT NULL DEFAULT 0,
+    
+    action VARCHAR(20) NOT NULL DEFAULT 'NOTIFY',
+    reassign_to_user_id INTEGER REFERENCES auth.users(id),
+    
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_by_user_id INTEGER NOT NULL REFERENCES auth.users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    
+    CONSTRAINT chk_sla_priority CHECK (priority IS NULL OR priority IN ('LOW', 'MEDIUM', 'HIGH', 'URGENT')),
+    CONSTRAINT chk_sla_action CHECK (action IN ('NOTIFY', 'BUMP_PRIORITY', 'REASSIGN')),
+    CONSTRAINT chk_sla_target_hours CHECK (target_hours IS NULL OR target_hours > 0),
+    CONSTRAINT chk_sla_reassign CHECK (action <> 'REASSIGN' OR reassign_to_user_id IS NOT NULL)
+);
+
+CREATE INDEX idx_sla_rules_tenant ON store.sla_rules(tenant_id) WHERE is_active = true;
+
+CREATE TABLE store.workorder_escalations (
+    id SERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    workorder_id INTEGER NOT NULL REFERENCES store.workorders(id) ON DELETE CASCADE,
+    rule_id INTEGER NOT NULL REFERENCES store.sla_rules(id),
+    state VARCHAR(20) NOT NULL,
+    deadline TIMESTAMP WITH TIME ZONE NOT NULL,
+    
+    action_taken VARCHAR(20),
+    old_priority VARCHAR(20),
+    new_priority VARCHAR(20),
+    reassigned_from_user_id INTEGER REFERENCES auth.users(id),
+    reassigned_to_user_id INTEGER REFERENCES auth.users(id),
+    
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    
+    CONSTRAINT chk_escalation_state CHECK (state IN ('AT_RISK', 'BREACHED'))
+);
+
+-- Each rule escalates a work order once per state and deadline; re-entering
+-- the starting status produces a new deadline and can escalate again
+CREATE UNIQUE INDEX uq_workorder_escalations ON store.workorder_escalations(workorder_id, rule_id, state, deadline);
+
+-- Allow SLA events in the audit trail
+ALTER TABLE audit.events DROP CONSTRAINT IF EXISTS chk_event_type;
+ALTER TABLE audit.events 
+ADD CONSTRAINT chk_event_type CHECK (event_type IN (
+    -- User events
+    'user.login', 'user.logout', 'user.created', 'user.password_changed',
+    'user.deactivated', 'user.permission_granted',
+    
+    -- Customer events  
+    'customer.created', 'customer.updated', 'customer.contact_added',
+    'customer.contact_removed', 'customer.contact_permissions_updated',
+    
+    -- Work order events
+    'workorder.created', 'workorder.status_changed', 'workorder.assigned',
+    'workorder.started', 'workorder.completed', 'workorder.cancelled',
+    'workorder.item_added', 'workorder.item_completed', 'workorder.item_removed',
+    'workorder.approved', 'workorder.rejected', 'workorder.invoice_generated',
+    'workorder.sla_at_risk', 'workorder.sla_breached',
+    
+    -- Invoice events
+    'invoice.generated', 'invoice.payment_recorded', 'invoice.voided',
+    
+    -- Inventory events (for tracking where items go)
+    'inventory.received', 'inventory.moved', 'inventory.inspected',
+    'inventory.assigned_to_workorder', 'inventory.returned_from_workorder',
+    
+    -- System events
+    'system.migration_completed', 'system.backup_created'
+));
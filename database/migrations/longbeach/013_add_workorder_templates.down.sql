@@ -0,0 +1,8 @@
+-- 013_add_workorder_templates.down.sql
+DROP TABLE IF EXISTS store.workorder_checklist_items CASCADE;
+
+ALTER TABLE store.workorders
+    DROP COLUMN IF EXISTS template_version,
+    DROP COLUMN IF EXISTS template_id;
+
+DROP TABLE IF EXISTS store.workorder_templates CASCADE;
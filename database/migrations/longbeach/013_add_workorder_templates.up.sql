@@ -0,0 +1,46 @@
+-- 013_add_workorder_templates.up.sql
+-- Versioned per-tenant templates that prefill work orders by service type
+CREATE TABLE store.workorder_templates (
+    id SERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    service_type VARCHAR(50) NOT NULL,
+    version INTEGER NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    
+    description TEXT NOT NULL,
+    instructions TEXT,
+    estimated_hours DECIMAL(8,2),
+    hourly_rate DECIMAL(10,2),
+    items JSONB NOT NULL DEFAULT '[]',
+    checklist JSONB NOT NULL DEFAULT '[]',
+    
+    is_current BOOLEAN NOT NULL DEFAULT true,
+    created_by_user_id INTEGER NOT NULL REFERENCES auth.users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    
+    CONSTRAINT uq_workorder_template_version UNIQUE (tenant_id, service_type, version),
+    CONSTRAINT chk_template_service_type CHECK (service_type IN (
+        'INSPECTION', 'MAINTENANCE', 'REPAIR', 'CLEANING', 'TESTING', 'CUSTOM'
+    ))
+);
+
+-- Only one version per service type prefills new work orders
+CREATE UNIQUE INDEX uq_workorder_template_current ON store.workorder_templates(tenant_id, service_type)
+    WHERE is_current = true;
+
+ALTER TABLE store.workorders
+    ADD COLUMN template_id INTEGER REFERENCES store.workorder_templates(id),
+    ADD COLUMN template_version INTEGER;
+
+CREATE TABLE store.workorder_checklist_items (
+    id SERIAL PRIMARY KEY,
+    workorder_id INTEGER NOT NULL REFERENCES store.workorders(id) ON DELETE CASCADE,
+    label VARCHAR(500) NOT NULL,
+    required BOOLEAN NOT NULL DEFAULT false,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    is_checked BOOLEAN NOT NULL DEFAULT false,
+    checked_by_user_id INTEGER REFERENCES auth.users(id),
+    checked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_checklist_items_workorder ON store.workorder_checklist_items(workorder_id, sort_order);
@@ -0,0 +1,2 @@
+-- 014_add_legacy_received_imports.down.sql
+DROP TABLE IF EXISTS store.legacy_received_imports CASCADE;
@@ -0,0 +1,16 @@
+-- 014_add_legacy_received_imports.up.sql
+-- Tracks which legacy received rows were promoted into work orders so the
+-- import can be re-run without duplicating historical jobs
+CREATE TABLE store.legacy_received_imports (
+    received_id INTEGER PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    legacy_work_order VARCHAR(100) NOT NULL,
+    workorder_id INTEGER NOT NULL REFERENCES store.workorders(id) ON DELETE CASCADE,
+    workorder_item_id INTEGER REFERENCES store.workorder_items(id) ON DELETE SET NULL,
+    inventory_item_id INTEGER,
+    imported_by_user_id INTEGER NOT NULL REFERENCES auth.users(id),
+    imported_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_legacy_received_imports_workorder ON store.legacy_received_imports(workorder_id);
+CREATE INDEX idx_legacy_received_imports_number ON store.legacy_received_imports(tenant_id, legacy_work_order);
@@ -0,0 +1,2 @@
+-- 015_add_attachments.down.sql
+DROP TABLE IF EXISTS store.attachments CASCADE;
@@ -0,0 +1,30 @@
+-- 015_add_attachments.up.sql
+-- File metadata for photos, mill test reports and signed tickets; the bytes
+-- live in the blob store under storage_key
+CREATE TABLE store.attachments (
+    id SERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    entity_type VARCHAR(30) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    checksum_sha256 CHAR(64) NOT NULL,
+    storage_key VARCHAR(500) NOT NULL UNIQUE,
+    description TEXT,
+    
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    uploaded_by_user_id INTEGER NOT NULL REFERENCES auth.users(id),
+    deleted_by_user_id INTEGER REFERENCES auth.users(id),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    
+    CONSTRAINT chk_attachment_entity_type CHECK (entity_type IN (
+        'WORK_ORDER', 'WORK_ORDER_ITEM', 'INVENTORY_ITEM', 'CUSTOMER'
+    )),
+    CONSTRAINT chk_attachment_size CHECK (size_bytes > 0)
+);
+
+CREATE INDEX idx_attachments_entity ON store.attachments(tenant_id, entity_type, entity_id)
+    WHERE is_active = true;
@@ -0,0 +1,8 @@
+-- 016_add_inspection_results.down.sql
+ALTER TABLE store.workorders
+    DROP COLUMN IF EXISTS joints_rejected,
+    DROP COLUMN IF EXISTS joints_repair,
+    DROP COLUMN IF EXISTS joints_passed,
+    DROP COLUMN IF EXISTS joints_inspected;
+
+DROP TABLE IF EXISTS store.inspection_results CASCADE;
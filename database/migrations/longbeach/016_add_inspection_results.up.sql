@@ -0,0 +1,46 @@
+-- 016_add_inspection_results.up.sql
+-- Joint-by-joint inspection results, rolled up onto the work order
+CREATE TABLE store.inspection_results (
+    id SERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    workorder_id INTEGER NOT NULL REFERENCES store.workorders(id) ON DELETE CASCADE,
+    workorder_item_id INTEGER NOT NULL REFERENCES store.workorder_items(id) ON DELETE CASCADE,
+    inventory_item_id INTEGER,
+    joint_number INTEGER NOT NULL,
+    
+    classification VARCHAR(10) NOT NULL,
+    reject_reason VARCHAR(30),
+    wall_readings NUMERIC(6,3)[] NOT NULL DEFAULT '{}',
+    min_wall NUMERIC(6,3),
+    pit_depth NUMERIC(6,3),
+    thread_condition VARCHAR(20) NOT NULL DEFAULT 'NOT_INSPECTED',
+    notes TEXT,
+    
+    segregated_at TIMESTAMP WITH TIME ZONE,
+    inspected_by_user_id INTEGER NOT NULL REFERENCES auth.users(id),
+    inspected_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    
+    CONSTRAINT uq_inspection_joint UNIQUE (workorder_item_id, joint_number),
+    CONSTRAINT chk_inspection_joint_number CHECK (joint_number > 0),
+    CONSTRAINT chk_inspection_classification CHECK (classification IN ('PASS', 'REPAIR', 'REJECT')),
+    CONSTRAINT chk_inspection_reason CHECK (
+        (classification = 'PASS' AND reject_reason IS NULL) OR
+        (classification <> 'PASS' AND reject_reason IS NOT NULL)
+    ),
+    CONSTRAINT chk_inspection_reject_reason CHECK (reject_reason IN (
+        'WALL_LOSS', 'PITTING', 'CORROSION', 'THREAD_DAMAGE', 'CRACK', 'BENT', 'DENT', 'OTHER'
+    )),
+    CONSTRAINT chk_inspection_thread_condition CHECK (thread_condition IN (
+        'GOOD', 'MINOR_DAMAGE', 'DAMAGED', 'NOT_INSPECTED'
+    )),
+    CONSTRAINT chk_inspection_segregated CHECK (segregated_at IS NULL OR classification = 'REJECT')
+);
+
+CREATE INDEX idx_inspection_results_workorder ON store.inspection_results(workorder_id);
+
+ALTER TABLE store.workorders
+    ADD COLUMN joints_inspected INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN joints_passed INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN joints_repair INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN joints_rejected INTEGER NOT NULL DEFAULT 0;
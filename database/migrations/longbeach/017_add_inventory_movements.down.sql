@@ -0,0 +1,4 @@
+-- 017_add_inventory_movements.down.sql
+-- store.inventory keeps rack and r_number: imported legacy rows may predate this migration's columns
+DROP TABLE IF EXISTS store.inventory_movements CASCADE;
+DROP FUNCTION IF EXISTS store.reject_inventory_movement_change();
@@ -0,0 +1,46 @@
+-- 017_add_inventory_movements.up.sql
+-- Immutable ledger of inventory movements between racks and locations
+ALTER TABLE IF EXISTS store.inventory
+    ADD COLUMN IF NOT EXISTS rack VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS r_number VARCHAR(50);
+
+CREATE TABLE store.inventory_movements (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    movement_type VARCHAR(20) NOT NULL DEFAULT 'MOVE',
+    
+    -- The row the joints left, and the row split off for them on a partial move
+    inventory_item_id INTEGER NOT NULL,
+    to_inventory_item_id INTEGER,
+    r_number VARCHAR(50),
+    customer_id INTEGER,
+    joints INTEGER NOT NULL,
+    
+    from_rack VARCHAR(50),
+    from_location VARCHAR(100),
+    to_rack VARCHAR(50),
+    to_location VARCHAR(100),
+    notes TEXT,
+    
+    moved_by_user_id INTEGER NOT NULL REFERENCES auth.users(id),
+    moved_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    
+    CONSTRAINT chk_inventory_movement_type CHECK (movement_type IN ('MOVE')),
+    CONSTRAINT chk_inventory_movement_joints CHECK (joints > 0)
+);
+
+CREATE INDEX idx_inventory_movements_item ON store.inventory_movements(inventory_item_id, moved_at);
+CREATE INDEX idx_inventory_movements_to_item ON store.inventory_movements(to_inventory_item_id) WHERE to_inventory_item_id IS NOT NULL;
+CREATE INDEX idx_inventory_movements_r_number ON store.inventory_movements(tenant_id, r_number, moved_at);
+
+-- Ledger rows are never corrected in place; a wrong move is undone by
+-- moving the joints back
+CREATE OR REPLACE FUNCTION store.reject_inventory_movement_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'inventory movements are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_inventory_movements_immutable
+    BEFORE UPDATE OR DELETE ON store.inventory_movements
+    FOR EACH ROW EXECUTE FUNCTION store.reject_inventory_movement_change();
@@ -0,0 +1,3 @@
+-- 018_add_inventory_reservations.down.sql
+DROP VIEW IF EXISTS store.inventory_availability;
+DROP TABLE IF EXISTS store.inventory_reservations CASCADE;
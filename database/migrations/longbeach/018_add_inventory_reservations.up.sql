@@ -0,0 +1,45 @@
+-- 018_add_inventory_reservations.up.sql
+-- Joints held for a work order, so the same pipe cannot be promised twice
+CREATE TABLE store.inventory_reservations (
+    id SERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    inventory_item_id INTEGER NOT NULL,
+    workorder_id INTEGER NOT NULL REFERENCES store.workorders(id) ON DELETE CASCADE,
+    workorder_item_id INTEGER REFERENCES store.workorder_items(id) ON DELETE SET NULL,
+    joints INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE',
+    notes TEXT,
+    
+    reserved_by_user_id INTEGER NOT NULL REFERENCES auth.users(id),
+    reserved_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    released_by_user_id INTEGER REFERENCES auth.users(id),
+    released_at TIMESTAMP WITH TIME ZONE,
+    release_reason VARCHAR(255),
+    
+    CONSTRAINT chk_inventory_reservation_status CHECK (status IN ('ACTIVE', 'RELEASED')),
+    CONSTRAINT chk_inventory_reservation_joints CHECK (joints > 0),
+    CONSTRAINT chk_inventory_reservation_released CHECK (status = 'ACTIVE' OR released_at IS NOT NULL)
+);
+
+-- One live hold per row and work order; a changed quantity is a release and
+-- a new reservation
+CREATE UNIQUE INDEX idx_inventory_reservations_active
+    ON store.inventory_reservations(inventory_item_id, workorder_id) WHERE status = 'ACTIVE';
+CREATE INDEX idx_inventory_reservations_workorder ON store.inventory_reservations(workorder_id, status);
+CREATE INDEX idx_inventory_reservations_tenant ON store.inventory_reservations(tenant_id, status);
+
+-- Available-to-promise: joints in the yard less those held by active reservations
+CREATE OR REPLACE VIEW store.inventory_availability AS
+SELECT i.id AS inventory_item_id,
+       i.tenant_id,
+       COALESCE(i.joints, 0) AS joints,
+       COALESCE(r.reserved, 0) AS reserved_joints,
+       COALESCE(i.joints, 0) - COALESCE(r.reserved, 0) AS available_joints
+FROM store.inventory i
+LEFT JOIN (
+    SELECT inventory_item_id, SUM(joints) AS reserved
+    FROM store.inventory_reservations
+    WHERE status = 'ACTIVE'
+    GROUP BY inventory_item_id
+) r ON r.inventory_item_id = i.id
+WHERE i.deleted = false AND i.date_out IS NULL;
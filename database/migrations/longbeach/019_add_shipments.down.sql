@@ -0,0 +1,38 @@
+-- 019_add_shipments.down.sql
+DELETE FROM audit.events WHERE event_type = 'inventory.shipped';
+
+ALTER TABLE audit.events DROP CONSTRAINT IF EXISTS chk_event_type;
+ALTER TABLE audit.events 
+ADD CONSTRAINT chk_event_type CHECK (event_type IN (
+    'user.login', 'user.logout', 'user.created', 'user.password_changed',
+    'user.deactivated', 'user.permission_granted',
+    'customer.created', 'customer.updated', 'customer.contact_added',
+    'customer.contact_removed', 'customer.contact_permissions_updated',
+    'workorder.created', 'workorder.status_changed', 'workorder.assigned',
+    'workorder.started', 'workorder.completed', 'workorder.cancelled',
+    'workorder.item_added', 'workorder.item_completed', 'workorder.item_removed',
+    'workorder.approved', 'workorder.rejected', 'workorder.invoice_generated',
+    'workorder.sla_at_risk', 'workorder.sla_breached',
+    'invoice.generated', 'invoice.payment_recorded', 'invoice.voided',
+    'inventory.received', 'inventory.moved', 'inventory.inspected',
+    'inventory.assigned_to_workorder', 'inventory.returned_from_workorder',
+    'system.migration_completed', 'system.backup_created'
+));
+
+UPDATE store.inventory_reservations SET status = 'RELEASED' WHERE status = 'CONSUMED';
+ALTER TABLE store.inventory_reservations DROP CONSTRAINT IF EXISTS chk_inventory_reservation_status;
+ALTER TABLE store.inventory_reservations
+ADD CONSTRAINT chk_inventory_reservation_status CHECK (status IN ('ACTIVE', 'RELEASED'));
+
+-- The ledger is immutable, so the trigger is lifted just long enough to drop
+-- the shipping entries
+ALTER TABLE store.inventory_movements DISABLE TRIGGER trigger_inventory_movements_immutable;
+DELETE FROM store.inventory_movements WHERE movement_type = 'SHIP';
+ALTER TABLE store.inventory_movements ENABLE TRIGGER trigger_inventory_movements_immutable;
+
+ALTER TABLE store.inventory_movements DROP CONSTRAINT IF EXISTS chk_inventory_movement_type;
+ALTER TABLE store.inventory_movements
+ADD CONSTRAINT chk_inventory_movement_type CHECK (movement_type IN ('MOVE'));
+
+DROP TABLE IF EXISTS store.shipment_lines CASCADE;
+DROP TABLE IF EXISTS store.shipments CASCADE;
@@ -0,0 +1,93 @@
+-- 019_add_shipments.up.sql
+-- Shipments of pipe leaving the yard for a well or lease, each with its own
+-- bill of lading number
+CREATE TABLE store.shipments (
+    id SERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    shipment_number VARCHAR(50) NOT NULL,
+    customer_id INTEGER NOT NULL,
+    customer VARCHAR(255),
+    
+    well_out VARCHAR(255),
+    lease_out VARCHAR(255),
+    carrier VARCHAR(255),
+    truck_number VARCHAR(50),
+    trailer_number VARCHAR(50),
+    driver_name VARCHAR(255),
+    notes TEXT,
+    total_joints INTEGER NOT NULL DEFAULT 0,
+    
+    shipped_by_user_id INTEGER NOT NULL REFERENCES auth.users(id),
+    shipped_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    
+    CONSTRAINT uq_shipments_number UNIQUE (tenant_id, shipment_number),
+    CONSTRAINT chk_shipment_destination CHECK (well_out IS NOT NULL OR lease_out IS NOT NULL)
+);
+
+CREATE INDEX idx_shipments_customer ON store.shipments(tenant_id, customer_id, shipped_at);
+
+-- One line per inventory row shipped from. The pipe description is copied so
+-- the bill of lading reprints the same even if the row is later corrected.
+CREATE TABLE store.shipment_lines (
+    id SERIAL PRIMARY KEY,
+    shipment_id INTEGER NOT NULL REFERENCES store.shipments(id) ON DELETE CASCADE,
+    inventory_item_id INTEGER NOT NULL,
+    shipped_item_id INTEGER NOT NULL,  -- The row marked out; differs on a partial shipment
+    reservation_id INTEGER REFERENCES store.inventory_reservations(id),
+    
+    work_order VARCHAR(100),
+    r_number VARCHAR(50),
+    joints INTEGER NOT NULL,
+    size VARCHAR(50),
+    weight DECIMAL(10,2),
+    grade VARCHAR(10),
+    connection VARCHAR(100),
+    from_rack VARCHAR(50),
+    from_location VARCHAR(100),
+    
+    CONSTRAINT chk_shipment_line_joints CHECK (joints > 0)
+);
+
+CREATE INDEX idx_shipment_lines_shipment ON store.shipment_lines(shipment_id);
+CREATE INDEX idx_shipment_lines_item ON store.shipment_lines(inventory_item_id);
+
+-- Shipping is recorded in the movement ledger alongside rack moves
+ALTER TABLE store.inventory_movements DROP CONSTRAINT IF EXISTS chk_inventory_movement_type;
+ALTER TABLE store.inventory_movements
+ADD CONSTRAINT chk_inventory_movement_type CHECK (movement_type IN ('MOVE', 'SHIP'));
+
+-- A reservation shipped against is consumed rather than released
+ALTER TABLE store.inventory_reservations DROP CONSTRAINT IF EXISTS chk_inventory_reservation_status;
+ALTER TABLE store.inventory_reservations
+ADD CONSTRAINT chk_inventory_reservation_status CHECK (status IN ('ACTIVE', 'RELEASED', 'CONSUMED'));
+
+-- Allow shipment events in the audit trail
+ALTER TABLE audit.events DROP CONSTRAINT IF EXISTS chk_event_type;
+ALTER TABLE audit.events 
+ADD CONSTRAINT chk_event_type CHECK (event_type IN (
+    -- User events
+    'user.login', 'user.logout', 'user.created', 'user.password_changed',
+    'user.deactivated', 'user.permission_granted',
+    
+    -- Customer events  
+    'customer.created', 'customer.updated', 'customer.contact_added',
+    'customer.contact_removed', 'customer.contact_permissions_updated',
+    
+    -- Work order events
+    'workorder.created', 'workorder.status_changed', 'workorder.assigned',
+    'workorder.started', 'workorder.completed', 'workorder.cancelled',
+    'workorder.item_added', 'workorder.item_completed', 'workorder.item_removed',
+    'workorder.approved', 'workorder.rejected', 'workorder.invoice_generated',
+    'workorder.sla_at_risk', 'workorder.sla_breached',
+    
+    -- Invoice events
+    'invoice.generated', 'invoice.payment_recorded', 'invoice.voided',
+    
+    -- Inventory events (for tracking where items go)
+    'inventory.received', 'inventory.moved', 'inventory.inspected',
+    'inventory.assigned_to_workorder', 'inventory.returned_from_workorder',
+    'inventory.shipped',
+    
+    -- System events
+    'system.migration_completed', 'system.backup_created'
+));
@@ -0,0 +1,12 @@
+-- 020_add_receiving.down.sql
+-- Inventory rows created by receiving are kept; only the check-in records go
+ALTER TABLE store.inventory_movements DISABLE TRIGGER trigger_inventory_movements_immutable;
+DELETE FROM store.inventory_movements WHERE movement_type = 'RECEIVE';
+ALTER TABLE store.inventory_movements ENABLE TRIGGER trigger_inventory_movements_immutable;
+
+ALTER TABLE store.inventory_movements DROP CONSTRAINT IF EXISTS chk_inventory_movement_type;
+ALTER TABLE store.inventory_movements
+ADD CONSTRAINT chk_inventory_movement_type CHECK (movement_type IN ('MOVE', 'SHIP'));
+
+DROP TABLE IF EXISTS store.receipt_lines CASCADE;
+DROP TABLE IF EXISTS store.receipts CASCADE;
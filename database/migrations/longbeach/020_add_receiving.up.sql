@@ -0,0 +1,49 @@
+-- 020_add_receiving.up.sql
+-- Check-in of received tickets: the count taken at the rack, and the
+-- inventory rows it produced
+CREATE TABLE store.receipts (
+    id SERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    received_id INTEGER NOT NULL,  -- The store.received ticket checked in
+    customer_id INTEGER,
+    work_order VARCHAR(100),
+    r_number VARCHAR(50),
+    
+    ordered_joints INTEGER,        -- What the customer said was coming; NULL if not given
+    counted_joints INTEGER NOT NULL,
+    discrepancy INTEGER GENERATED ALWAYS AS (counted_joints - ordered_joints) STORED,
+    discrepancy_reason TEXT,
+    notes TEXT,
+    
+    received_by_user_id INTEGER NOT NULL REFERENCES auth.users(id),
+    received_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    
+    CONSTRAINT uq_receipts_received UNIQUE (tenant_id, received_id),
+    CONSTRAINT chk_receipt_counted CHECK (counted_joints > 0),
+    CONSTRAINT chk_receipt_discrepancy_reason CHECK (
+        ordered_joints IS NULL OR counted_joints = ordered_joints OR discrepancy_reason IS NOT NULL
+    )
+);
+
+CREATE INDEX idx_receipts_received_at ON store.receipts(tenant_id, received_at);
+CREATE INDEX idx_receipts_discrepancy ON store.receipts(tenant_id) WHERE discrepancy <> 0;
+
+-- A count can be racked in more than one place; each rack becomes its own
+-- inventory row
+CREATE TABLE store.receipt_lines (
+    id SERIAL PRIMARY KEY,
+    receipt_id INTEGER NOT NULL REFERENCES store.receipts(id) ON DELETE CASCADE,
+    inventory_item_id INTEGER NOT NULL,
+    joints INTEGER NOT NULL,
+    rack VARCHAR(50),
+    location VARCHAR(100),
+    
+    CONSTRAINT chk_receipt_line_joints CHECK (joints > 0)
+);
+
+CREATE INDEX idx_receipt_lines_receipt ON store.receipt_lines(receipt_id);
+
+-- Receiving opens each row's movement ledger
+ALTER TABLE store.inventory_movements DROP CONSTRAINT IF EXISTS chk_inventory_movement_type;
+ALTER TABLE store.inventory_movements
+ADD CONSTRAINT chk_inventory_movement_type CHECK (movement_type IN ('MOVE', 'SHIP', 'RECEIVE'));
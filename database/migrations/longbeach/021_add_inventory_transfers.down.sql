@@ -0,0 +1,39 @@
+-- 021_add_inventory_transfers.down.sql
+-- Rows dated out by a transfer stay dated out; only the transfer records go
+DELETE FROM audit.events WHERE event_type IN ('inventory.transferred_out', 'inventory.transferred_in');
+
+ALTER TABLE audit.events DROP CONSTRAINT IF EXISTS chk_event_type;
+ALTER TABLE audit.events
+ADD CONSTRAINT chk_event_type CHECK (event_type IN (
+    'user.login', 'user.logout', 'user.created', 'user.password_changed',
+    'user.deactivated', 'user.permission_granted',
+    'customer.created', 'customer.updated', 'customer.contact_added',
+    'customer.contact_removed', 'customer.contact_permissions_updated',
+    'workorder.created', 'workorder.status_changed', 'workorder.assigned',
+    'workorder.started', 'workorder.completed', 'workorder.cancelled',
+    'workorder.item_added', 'workorder.item_completed', 'workorder.item_removed',
+    'workorder.approved', 'workorder.rejected', 'workorder.invoice_generated',
+    'workorder.sla_at_risk', 'workorder.sla_breached',
+    'invoice.generated', 'invoice.payment_recorded', 'invoice.voided',
+    'inventory.received', 'inventory.moved', 'inventory.inspected',
+    'inventory.assigned_to_workorder', 'inventory.returned_from_workorder',
+    'inventory.shipped',
+    'system.migration_completed', 'system.backup_created'
+));
+
+ALTER TABLE store.inventory_movements DISABLE TRIGGER trigger_inventory_movements_immutable;
+DELETE FROM store.inventory_movements WHERE movement_type IN ('TRANSFER_OUT', 'TRANSFER_IN');
+ALTER TABLE store.inventory_movements ENABLE TRIGGER trigger_inventory_movements_immutable;
+
+ALTER TABLE store.inventory_movements DROP CONSTRAINT IF EXISTS chk_inventory_movement_type;
+ALTER TABLE store.inventory_movements
+ADD CONSTRAINT chk_inventory_movement_type CHECK (movement_type IN ('MOVE', 'SHIP', 'RECEIVE'));
+
+DELETE FROM store.document_sequences WHERE document_type = 'TRANSFER';
+ALTER TABLE store.document_sequences DROP CONSTRAINT IF EXISTS chk_document_type;
+ALTER TABLE store.document_sequences
+ADD CONSTRAINT chk_document_type CHECK (document_type IN ('WORK_ORDER', 'INVOICE', 'BILL_OF_LADING'));
+
+DROP TABLE IF EXISTS store.transfer_outbox CASCADE;
+DROP TABLE IF EXISTS store.transfer_lines CASCADE;
+DROP TABLE IF EXISTS store.transfer_orders CASCADE;
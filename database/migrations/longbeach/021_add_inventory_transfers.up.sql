@@ -0,0 +1,140 @@
+-- 021_add_inventory_transfers.up.sql
+-- Transfers of pipe between yards. Each yard is its own tenant database, so
+-- a transfer is written twice: an OUTBOUND order in the source yard and an
+-- INBOUND copy in the destination, tied together by transfer_uid. Every
+-- yard taking part in transfers needs this migration and 017; Bakersfield
+-- and Colorado get both in their 002_add_inventory_transfers.
+CREATE TABLE store.transfer_orders (
+    id SERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    transfer_uid UUID NOT NULL,            -- Shared by both yards' copies
+    transfer_number VARCHAR(50) NOT NULL,  -- Allocated by the source yard
+    direction VARCHAR(10) NOT NULL,
+    source_tenant_id VARCHAR(100) NOT NULL,
+    destination_tenant_id VARCHAR(100) NOT NULL,
+
+    customer_id INTEGER NOT NULL,          -- In this yard's customer ids
+    customer VARCHAR(255),
+    remote_customer_id INTEGER NOT NULL,   -- The same customer in the other yard
+
+    carrier VARCHAR(255),
+    truck_number VARCHAR(50),
+    notes TEXT,
+    total_joints INTEGER NOT NULL DEFAULT 0,
+
+    status VARCHAR(20) NOT NULL,
+    created_by_user_id INTEGER,            -- Not a foreign key: inbound copies are written by the relay
+    dispatched_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    delivered_at TIMESTAMP WITH TIME ZONE, -- When the destination yard took the inbound copy
+    received_by_user_id INTEGER,
+    received_at TIMESTAMP WITH TIME ZONE,
+    cancelled_by_user_id INTEGER,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    cancel_reason TEXT,
+
+    CONSTRAINT uq_transfer_orders_uid UNIQUE (tenant_id, transfer_uid),
+    CONSTRAINT uq_transfer_orders_number UNIQUE (tenant_id, source_tenant_id, transfer_number),
+    CONSTRAINT chk_transfer_direction CHECK (direction IN ('OUTBOUND', 'INBOUND')),
+    CONSTRAINT chk_transfer_status CHECK (status IN ('PENDING', 'IN_TRANSIT', 'RECEIVED', 'CANCELLED')),
+    CONSTRAINT chk_transfer_yards CHECK (source_tenant_id <> destination_tenant_id)
+);
+
+CREATE INDEX idx_transfer_orders_open ON store.transfer_orders(tenant_id, direction)
+    WHERE status IN ('PENDING', 'IN_TRANSIT');
+CREATE INDEX idx_transfer_orders_dispatched ON store.transfer_orders(tenant_id, dispatched_at);
+
+-- The pipe description is copied onto each line so the destination can rack
+-- the joints without reading the source yard's inventory
+CREATE TABLE store.transfer_lines (
+    id SERIAL PRIMARY KEY,
+    transfer_order_id INTEGER NOT NULL REFERENCES store.transfer_orders(id) ON DELETE CASCADE,
+    line_number INTEGER NOT NULL,
+    source_item_id INTEGER NOT NULL,       -- Inventory row in the source yard
+    in_transit_item_id INTEGER,            -- Source yard row dated out while in transit; OUTBOUND only
+    received_item_id INTEGER,              -- Destination yard row once racked; INBOUND only
+
+    work_order VARCHAR(100),
+    r_number VARCHAR(50),
+    joints INTEGER NOT NULL,
+    size VARCHAR(50),
+    weight DECIMAL(10,2),
+    grade VARCHAR(10),
+    connection VARCHAR(100),
+    from_rack VARCHAR(50),
+    from_location VARCHAR(100),
+    received_rack VARCHAR(50),
+    received_location VARCHAR(100),
+
+    CONSTRAINT uq_transfer_lines_number UNIQUE (transfer_order_id, line_number),
+    CONSTRAINT chk_transfer_line_joints CHECK (joints > 0)
+);
+
+CREATE INDEX idx_transfer_lines_order ON store.transfer_lines(transfer_order_id);
+
+-- Messages for the other yard, written in the same transaction as the change
+-- they announce and delivered by the relay until the other yard accepts
+-- them. Delivery is idempotent on transfer_uid, so a message may safely be
+-- sent more than once.
+CREATE TABLE store.transfer_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    transfer_order_id INTEGER NOT NULL REFERENCES store.transfer_orders(id) ON DELETE CASCADE,
+    message_type VARCHAR(20) NOT NULL,
+    target_tenant_id VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    last_attempt_at TIMESTAMP WITH TIME ZONE,
+    next_attempt_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    discarded_at TIMESTAMP WITH TIME ZONE, -- Withdrawn before delivery, e.g. a cancelled dispatch
+
+    CONSTRAINT chk_transfer_outbox_type CHECK (message_type IN ('DISPATCHED', 'RECEIVED', 'CANCELLED'))
+);
+
+CREATE INDEX idx_transfer_outbox_pending ON store.transfer_outbox(tenant_id, next_attempt_at)
+    WHERE delivered_at IS NULL AND discarded_at IS NULL;
+CREATE INDEX idx_transfer_outbox_order ON store.transfer_outbox(transfer_order_id);
+
+-- Transfer numbers are their own series
+ALTER TABLE store.document_sequences DROP CONSTRAINT IF EXISTS chk_document_type;
+ALTER TABLE store.document_sequences
+ADD CONSTRAINT chk_document_type CHECK (document_type IN ('WORK_ORDER', 'INVOICE', 'BILL_OF_LADING', 'TRANSFER'));
+
+-- Joints leaving for, or arriving from, another yard
+ALTER TABLE store.inventory_movements DROP CONSTRAINT IF EXISTS chk_inventory_movement_type;
+ALTER TABLE store.inventory_movements
+ADD CONSTRAINT chk_inventory_movement_type CHECK (movement_type IN ('MOVE', 'SHIP', 'RECEIVE', 'TRANSFER_OUT', 'TRANSFER_IN'));
+
+-- Allow transfer events in the audit trail
+ALTER TABLE audit.events DROP CONSTRAINT IF EXISTS chk_event_type;
+ALTER TABLE audit.events
+ADD CONSTRAINT chk_event_type CHECK (event_type IN (
+    -- User events
+    'user.login', 'user.logout', 'user.created', 'user.password_changed',
+    'user.deactivated', 'user.permission_granted',
+
+    -- Customer events
+    'customer.created', 'customer.updated', 'customer.contact_added',
+    'customer.contact_removed', 'customer.contact_permissions_updated',
+
+    -- Work order events
+    'workorder.created', 'workorder.status_changed', 'workorder.assigned',
+    'workorder.started', 'workorder.completed', 'workorder.cancelled',
+    'workorder.item_added', 'workorder.item_completed', 'workorder.item_removed',
+    'workorder.approved', 'workorder.rejected', 'workorder.invoice_generated',
+    'workorder.sla_at_risk', 'workorder.sla_breached',
+
+    -- Invoice events
+    'invoice.generated', 'invoice.payment_recorded', 'invoice.voided',
+
+    -- Inventory events (for tracking where items go)
+    'inventory.received', 'inventory.moved', 'inventory.inspected',
+    'inventory.assigned_to_workorder', 'inventory.returned_from_workorder',
+    'inventory.shipped', 'inventory.transferred_out', 'inventory.transferred_in',
+
+    -- System events
+    'system.migration_completed', 'system.backup_created'
+));
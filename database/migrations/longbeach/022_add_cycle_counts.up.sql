@@ -0,0 +1,97 @@
+-- 022_add_cycle_counts.up.sql
+-- Cycle counts: a session snapshots the rows on a rack (or the whole yard),
+-- operators enter what is physically there, and a manager approves the
+-- differences as ledger adjustments
+CREATE TABLE store.count_sessions (
+    id SERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    rack VARCHAR(50),          -- NULL counts every rack
+    location VARCHAR(100),     -- NULL counts every location
+    status VARCHAR(20) NOT NULL DEFAULT 'OPEN',
+    notes TEXT,
+
+    opened_by_user_id INTEGER NOT NULL REFERENCES auth.users(id),
+    opened_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    submitted_by_user_id INTEGER REFERENCES auth.users(id),
+    submitted_at TIMESTAMP WITH TIME ZONE,
+    reviewed_by_user_id INTEGER REFERENCES auth.users(id),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    rejection_reason TEXT,     -- Set when a manager sends the count back for a recount
+
+    CONSTRAINT chk_count_session_status CHECK (status IN ('OPEN', 'SUBMITTED', 'APPROVED', 'CANCELLED'))
+);
+
+CREATE INDEX idx_count_sessions_status ON store.count_sessions(tenant_id, status, opened_at);
+
+-- One line per inventory row in scope, with the joints the system expected
+-- when the session was opened
+CREATE TABLE store.count_lines (
+    id SERIAL PRIMARY KEY,
+    session_id INTEGER NOT NULL REFERENCES store.count_sessions(id) ON DELETE CASCADE,
+    inventory_item_id INTEGER NOT NULL,
+    r_number VARCHAR(50),
+    rack VARCHAR(50),
+    location VARCHAR(100),
+
+    expected_joints INTEGER NOT NULL,
+    counted_joints INTEGER,
+    variance INTEGER GENERATED ALWAYS AS (counted_joints - expected_joints) STORED,
+    reason_code VARCHAR(30),
+    notes TEXT,
+    counted_by_user_id INTEGER REFERENCES auth.users(id),
+    counted_at TIMESTAMP WITH TIME ZONE,
+    movement_id BIGINT REFERENCES store.inventory_movements(id),  -- The approved adjustment
+
+    CONSTRAINT uq_count_lines_item UNIQUE (session_id, inventory_item_id),
+    CONSTRAINT chk_count_line_counted CHECK (counted_joints IS NULL OR counted_joints >= 0),
+    CONSTRAINT chk_count_line_reason CHECK (reason_code IN (
+        'MISCOUNT', 'DAMAGED', 'SCRAPPED', 'FOUND', 'MISPLACED',
+        'UNRECORDED_SHIPMENT', 'UNRECORDED_RECEIPT', 'OTHER'
+    ))
+);
+
+CREATE INDEX idx_count_lines_session ON store.count_lines(session_id);
+CREATE INDEX idx_count_lines_item ON store.count_lines(inventory_item_id);
+
+-- Adjustments are ledger entries with a reason code. A loss is recorded
+-- from the rack, a gain to it.
+ALTER TABLE store.inventory_movements ADD COLUMN reason_code VARCHAR(30);
+
+ALTER TABLE store.inventory_movements DROP CONSTRAINT IF EXISTS chk_inventory_movement_type;
+ALTER TABLE store.inventory_movements
+ADD CONSTRAINT chk_inventory_movement_type CHECK (movement_type IN ('MOVE', 'SHIP', 'RECEIVE', 'TRANSFER_OUT', 'TRANSFER_IN', 'ADJUST'));
+
+ALTER TABLE store.inventory_movements
+ADD CONSTRAINT chk_inventory_movement_reason CHECK (movement_type <> 'ADJUST' OR reason_code IS NOT NULL);
+
+-- Allow adjustment events in the audit trail
+ALTER TABLE audit.events DROP CONSTRAINT IF EXISTS chk_event_type;
+ALTER TABLE audit.events
+ADD CONSTRAINT chk_event_type CHECK (event_type IN (
+    -- User events
+    'user.login', 'user.logout', 'user.created', 'user.password_changed',
+    'user.deactivated', 'user.permission_granted',
+
+    -- Customer events
+    'customer.created', 'customer.updated', 'customer.contact_added',
+    'customer.contact_removed', 'customer.contact_permissions_updated',
+
+    -- Work order events
+    'workorder.created', 'workorder.status_changed', 'workorder.assigned',
+    'workorder.started', 'workorder.completed', 'workorder.cancelled',
+    'workorder.item_added', 'workorder.item_completed', 'workorder.item_removed',
+    'workorder.approved', 'workorder.rejected', 'workorder.invoice_generated',
+    'workorder.sla_at_risk', 'workorder.sla_breached',
+
+    -- Invoice events
+    'invoice.generated', 'invoice.payment_recorded', 'invoice.voided',
+
+    -- Inventory events (for tracking where items go)
+    'inventory.received', 'inventory.moved', 'inventory.inspected',
+    'inventory.assigned_to_workorder', 'inventory.returned_from_workorder',
+    'inventory.shipped', 'inventory.transferred_out', 'inventory.transferred_in',
+    'inventory.adjusted',
+
+    -- System events
+    'system.migration_completed', 'system.backup_created'
+));
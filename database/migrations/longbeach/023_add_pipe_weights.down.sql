@@ -0,0 +1,6 @@
+-- 023_add_pipe_weights.down.sql
+DROP TABLE IF EXISTS store.joint_tallies CASCADE;
+
+ALTER TABLE IF EXISTS store.inventory DROP COLUMN IF EXISTS average_joint_length;
+
+DROP TABLE IF EXISTS store.sizes CASCADE;
@@ -0,0 +1,59 @@
+-- 023_add_pipe_weights.up.sql
+-- Pipe weights: size reference entries carry a weight per foot and the
+-- diameters behind it, and joint tallies record what each joint measured
+CREATE TABLE IF NOT EXISTS store.sizes (
+    id SERIAL PRIMARY KEY,
+    size VARCHAR(50) NOT NULL UNIQUE,      -- e.g. 5 1/2" 17#
+    nominal_size VARCHAR(20),              -- e.g. 5 1/2"
+    outer_diameter NUMERIC(7,3),           -- Inches
+    inner_diameter NUMERIC(7,3),           -- Inches
+    weight_per_foot NUMERIC(7,2),          -- Nominal lb/ft
+    active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT chk_size_diameters CHECK (inner_diameter IS NULL OR outer_diameter IS NULL OR inner_diameter < outer_diameter),
+    CONSTRAINT chk_size_weight CHECK (weight_per_foot IS NULL OR weight_per_foot > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sizes_nominal ON store.sizes(nominal_size) WHERE active = true;
+
+-- Common tubing and casing weights
+INSERT INTO store.sizes (size, nominal_size, outer_diameter, inner_diameter, weight_per_foot) VALUES
+    ('2 3/8" 4.7#',   '2 3/8"',   2.375,  1.995,  4.70),
+    ('2 7/8" 6.5#',   '2 7/8"',   2.875,  2.441,  6.50),
+    ('3 1/2" 9.3#',   '3 1/2"',   3.500,  2.992,  9.30),
+    ('4 1/2" 11.6#',  '4 1/2"',   4.500,  4.000, 11.60),
+    ('4 1/2" 13.5#',  '4 1/2"',   4.500,  3.920, 13.50),
+    ('5 1/2" 17#',    '5 1/2"',   5.500,  4.892, 17.00),
+    ('5 1/2" 20#',    '5 1/2"',   5.500,  4.778, 20.00),
+    ('5 1/2" 23#',    '5 1/2"',   5.500,  4.670, 23.00),
+    ('7" 23#',        '7"',       7.000,  6.366, 23.00),
+    ('7" 26#',        '7"',       7.000,  6.276, 26.00),
+    ('7" 29#',        '7"',       7.000,  6.184, 29.00),
+    ('9 5/8" 40#',    '9 5/8"',   9.625,  8.835, 40.00),
+    ('9 5/8" 47#',    '9 5/8"',   9.625,  8.681, 47.00),
+    ('13 3/8" 54.5#', '13 3/8"', 13.375, 12.615, 54.50),
+    ('13 3/8" 68#',   '13 3/8"', 13.375, 12.415, 68.00)
+ON CONFLICT (size) DO NOTHING;
+
+-- An average joint length for rows that have not been tallied
+ALTER TABLE IF EXISTS store.inventory
+    ADD COLUMN IF NOT EXISTS average_joint_length NUMERIC(6,2);
+
+-- One length per joint. A tally belongs to the row it was taken on; joints
+-- split off later are weighed at the tally's average length.
+CREATE TABLE store.joint_tallies (
+    id SERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    inventory_item_id INTEGER NOT NULL,
+    joint_number INTEGER NOT NULL,
+    length_ft NUMERIC(6,2) NOT NULL,
+    tallied_by_user_id INTEGER NOT NULL REFERENCES auth.users(id),
+    tallied_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT uq_joint_tallies_joint UNIQUE (inventory_item_id, joint_number),
+    CONSTRAINT chk_joint_tally_number CHECK (joint_number > 0),
+    CONSTRAINT chk_joint_tally_length CHECK (length_ft > 0 AND length_ft <= 60)
+);
+
+CREATE INDEX idx_joint_tallies_item ON store.joint_tallies(tenant_id, inventory_item_id);
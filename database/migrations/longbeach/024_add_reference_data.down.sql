@@ -0,0 +1,4 @@
+-- 024_add_reference_data.down.sql
+DROP TABLE IF EXISTS store.locations CASCADE;
+DROP TABLE IF EXISTS store.connections CASCADE;
+DROP TABLE IF EXISTS store.grades CASCADE;
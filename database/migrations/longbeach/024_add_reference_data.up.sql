@@ -0,0 +1,60 @@
+-- 024_add_reference_data.up.sql
+-- Reference data the yard validates pipe descriptions against: grades,
+-- connections and storage locations, alongside the sizes from 023.
+-- Entries are deactivated rather than deleted so old rows still resolve.
+CREATE TABLE IF NOT EXISTS store.grades (
+    id SERIAL PRIMARY KEY,
+    grade VARCHAR(10) NOT NULL UNIQUE,
+    description TEXT,
+    strength INTEGER,                -- Minimum yield, ksi
+    standard VARCHAR(50),            -- e.g. API 5CT
+    active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT chk_grade_strength CHECK (strength IS NULL OR strength > 0)
+);
+
+CREATE TABLE IF NOT EXISTS store.connections (
+    id SERIAL PRIMARY KEY,
+    connection VARCHAR(50) NOT NULL UNIQUE,
+    description TEXT,
+    category VARCHAR(20),            -- API or PREMIUM
+    active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS store.locations (
+    id SERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    location VARCHAR(100) NOT NULL,
+    description TEXT,
+    capacity INTEGER,                -- Joints
+    active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT uq_locations_location UNIQUE (tenant_id, location),
+    CONSTRAINT chk_location_capacity CHECK (capacity IS NULL OR capacity >= 0)
+);
+
+-- The grades the backend used to hard-code
+INSERT INTO store.grades (grade, description, strength, standard) VALUES
+    ('J55',  'Standard grade steel casing',        55,  'API 5CT'),
+    ('JZ55', 'Enhanced J55 grade',                 55,  NULL),
+    ('L80',  'Higher strength grade',              80,  'API 5CT'),
+    ('N80',  'Medium strength grade',              80,  'API 5CT'),
+    ('P105', 'High performance grade',             105, 'API 5CT'),
+    ('P110', 'Premium performance grade',          110, 'API 5CT'),
+    ('Q125', 'Ultra-high strength grade',          125, 'API 5CT'),
+    ('C75',  'Carbon steel grade',                 75,  'API 5CT'),
+    ('C95',  'Higher carbon steel grade',          95,  'API 5CT'),
+    ('T95',  'Tough grade for harsh environments', 95,  'API 5CT')
+ON CONFLICT (grade) DO NOTHING;
+
+INSERT INTO store.connections (connection, description, category) VALUES
+    ('STC', 'Short thread and coupled',  'API'),
+    ('LTC', 'Long thread and coupled',   'API'),
+    ('BTC', 'Buttress thread and coupled', 'API'),
+    ('EUE', 'External upset end',        'API'),
+    ('NUE', 'Non-upset end',             'API'),
+    ('IJ',  'Integral joint',            'API')
+ON CONFLICT (connection) DO NOTHING;
@@ -0,0 +1,9 @@
+-- 025_add_storage_billing.down.sql
+DELETE FROM store.invoice_lines WHERE line_type = 'STORAGE';
+
+ALTER TABLE store.invoice_lines DROP CONSTRAINT IF EXISTS chk_line_type;
+ALTER TABLE store.invoice_lines
+ADD CONSTRAINT chk_line_type CHECK (line_type IN ('ITEM', 'LABOR', 'MATERIALS', 'CREDIT'));
+
+DROP TABLE IF EXISTS store.storage_charges CASCADE;
+DROP TABLE IF EXISTS store.storage_rate_plans CASCADE;
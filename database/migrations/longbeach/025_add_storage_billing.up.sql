@@ -0,0 +1,61 @@
+-- 025_add_storage_billing.up.sql
+-- Storage (rack rent) billing: rate plans price the days a customer's pipe
+-- spends on rack, and each month's charges are kept as invoice-ready lines
+CREATE TABLE store.storage_rate_plans (
+    id SERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    customer_id INTEGER REFERENCES store.customers(id),  -- NULL is the yard's default plan
+    name VARCHAR(100) NOT NULL,
+    rate_basis VARCHAR(20) NOT NULL,
+    rate DECIMAL(12,4) NOT NULL,
+    free_days INTEGER NOT NULL DEFAULT 0,          -- Days after date in that are not charged
+    minimum_charge DECIMAL(12,2) NOT NULL DEFAULT 0, -- Per customer per month
+    active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT chk_storage_rate_basis CHECK (rate_basis IN ('JOINT_DAY', 'TON_MONTH')),
+    CONSTRAINT chk_storage_rate CHECK (rate >= 0),
+    CONSTRAINT chk_storage_free_days CHECK (free_days >= 0),
+    CONSTRAINT chk_storage_minimum CHECK (minimum_charge >= 0)
+);
+
+-- One active plan per customer, and one active default
+CREATE UNIQUE INDEX uq_storage_rate_plans_customer
+    ON store.storage_rate_plans(tenant_id, customer_id) WHERE active = true AND customer_id IS NOT NULL;
+CREATE UNIQUE INDEX uq_storage_rate_plans_default
+    ON store.storage_rate_plans(tenant_id) WHERE active = true AND customer_id IS NULL;
+
+-- A month's charges: one line per inventory row with billable days, plus a
+-- line topping the customer up to the plan's minimum. Regenerating a month
+-- replaces its lines.
+CREATE TABLE store.storage_charges (
+    id SERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    period_start DATE NOT NULL,                    -- First day of the month billed
+    customer_id INTEGER NOT NULL,
+    rate_plan_id INTEGER NOT NULL REFERENCES store.storage_rate_plans(id),
+    inventory_item_id INTEGER,                     -- NULL on the minimum charge line
+    r_number VARCHAR(50),
+    description TEXT NOT NULL,
+    joints INTEGER NOT NULL DEFAULT 0,
+    billable_days INTEGER NOT NULL DEFAULT 0,
+    short_tons DECIMAL(12,4),
+    quantity DECIMAL(14,4) NOT NULL,
+    unit_price DECIMAL(12,4) NOT NULL,
+    amount DECIMAL(12,2) NOT NULL,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    generated_by_user_id INTEGER NOT NULL REFERENCES auth.users(id),
+    generated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT chk_storage_period_start CHECK (EXTRACT(DAY FROM period_start) = 1)
+);
+
+CREATE INDEX idx_storage_charges_period ON store.storage_charges(tenant_id, period_start, customer_id, sort_order);
+CREATE UNIQUE INDEX uq_storage_charges_item
+    ON store.storage_charges(tenant_id, period_start, inventory_item_id) WHERE inventory_item_id IS NOT NULL;
+
+-- Storage charges go on invoices as their own line type
+ALTER TABLE store.invoice_lines DROP CONSTRAINT IF EXISTS chk_line_type;
+ALTER TABLE store.invoice_lines
+ADD CONSTRAINT chk_line_type CHECK (line_type IN ('ITEM', 'LABOR', 'MATERIALS', 'CREDIT', 'STORAGE'));
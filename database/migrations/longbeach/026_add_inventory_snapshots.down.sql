@@ -0,0 +1,3 @@
+-- 026_add_inventory_snapshots.down.sql
+DROP INDEX IF EXISTS store.idx_inventory_movements_moved_at;
+DROP TABLE IF EXISTS store.inventory_snapshots CASCADE;
@@ -0,0 +1,35 @@
+-- 026_add_inventory_snapshots.up.sql
+-- Nightly closing summaries of the pipe on hand, for the yard as a whole and
+-- for each customer, so month-end holdings and trends can be reported
+CREATE TABLE store.inventory_snapshots (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id VARCHAR(100) NOT NULL,
+    snapshot_date DATE NOT NULL,
+    customer_id INTEGER,                    -- NULL is the whole yard
+
+    total_items INTEGER NOT NULL DEFAULT 0,
+    total_joints INTEGER NOT NULL DEFAULT 0,
+    total_weight DECIMAL(14,2) NOT NULL DEFAULT 0,  -- Pounds
+    short_tons DECIMAL(12,4) NOT NULL DEFAULT 0,
+    unique_customers INTEGER NOT NULL DEFAULT 0,
+    unique_work_orders INTEGER NOT NULL DEFAULT 0,
+    unique_sizes INTEGER NOT NULL DEFAULT 0,
+    unique_grades INTEGER NOT NULL DEFAULT 0,
+
+    -- Joints per location, grade and size
+    location_counts JSONB NOT NULL DEFAULT '{}',
+    grade_counts JSONB NOT NULL DEFAULT '{}',
+    size_counts JSONB NOT NULL DEFAULT '{}',
+
+    taken_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Retaking a day replaces its snapshots
+CREATE UNIQUE INDEX uq_inventory_snapshots_day
+    ON store.inventory_snapshots(tenant_id, snapshot_date, COALESCE(customer_id, 0));
+CREATE INDEX idx_inventory_snapshots_customer
+    ON store.inventory_snapshots(tenant_id, customer_id, snapshot_date);
+
+-- Reading the ledger back from the present
+CREATE INDEX IF NOT EXISTS idx_inventory_movements_moved_at
+    ON store.inventory_movements(tenant_id, moved_at);
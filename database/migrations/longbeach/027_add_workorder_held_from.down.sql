@@ -0,0 +1,2 @@
+-- 027_add_workorder_held_from.down.sql
+ALTER TABLE store.workorders DROP COLUMN IF EXISTS held_from;
@@ -0,0 +1,15 @@
+-- 027_add_workorder_held_from.up.sql
+-- The status a work order was put on hold from. Resuming returns it there and
+-- nowhere else, so a hold cannot be used to step around the approval chain.
+ALTER TABLE store.workorders
+    ADD COLUMN held_from VARCHAR(50);
+
+-- Work orders already on hold take the status recorded by their last hold
+UPDATE store.workorders w
+SET held_from = (
+    SELECT h.old_value FROM store.workorder_history h
+    WHERE h.workorder_id = w.id AND h.action = 'status_changed' AND h.new_value = 'ON_HOLD'
+    ORDER BY h.created_at DESC, h.id DESC
+    LIMIT 1
+)
+WHERE w.status = 'ON_HOLD';
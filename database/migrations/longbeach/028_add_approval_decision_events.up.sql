@@ -0,0 +1,34 @@
+-- 028_add_approval_decision_events.up.sql
+-- Each approval level's decision is logged as it is made, so a multi-level
+-- chain records who approved at every level, not only the final outcome
+ALTER TABLE audit.events DROP CONSTRAINT IF EXISTS chk_event_type;
+ALTER TABLE audit.events
+ADD CONSTRAINT chk_event_type CHECK (event_type IN (
+    -- User events
+    'user.login', 'user.logout', 'user.created', 'user.password_changed',
+    'user.deactivated', 'user.permission_granted',
+
+    -- Customer events
+    'customer.created', 'customer.updated', 'customer.contact_added',
+    'customer.contact_removed', 'customer.contact_permissions_updated',
+
+    -- Work order events
+    'workorder.created', 'workorder.status_changed', 'workorder.assigned',
+    'workorder.started', 'workorder.completed', 'workorder.cancelled',
+    'workorder.item_added', 'workorder.item_completed', 'workorder.item_removed',
+    'workorder.approved', 'workorder.rejected', 'workorder.invoice_generated',
+    'workorder.approval_decided',
+    'workorder.sla_at_risk', 'workorder.sla_breached',
+
+    -- Invoice events
+    'invoice.generated', 'invoice.payment_recorded', 'invoice.voided',
+
+    -- Inventory events (for tracking where items go)
+    'inventory.received', 'inventory.moved', 'inventory.inspected',
+    'inventory.assigned_to_workorder', 'inventory.returned_from_workorder',
+    'inventory.shipped', 'inventory.transferred_out', 'inventory.transferred_in',
+    'inventory.adjusted',
+
+    -- System events
+    'system.migration_completed', 'system.backup_created'
+));